@@ -105,3 +105,21 @@ func (f *Frustum) normalizePlane(index int) {
 		p.Distance *= invLen
 	}
 }
+
+// IntersectsSphere reports whether a bounding sphere is at least partially inside the frustum.
+//
+// Parameters:
+//   - center: the world-space sphere center
+//   - radius: the sphere radius
+//
+// Returns:
+//   - bool: true if the sphere intersects or is inside the frustum
+func (f *Frustum) IntersectsSphere(center [3]float32, radius float32) bool {
+	for _, p := range f.Planes {
+		d := p.Normal[0]*center[0] + p.Normal[1]*center[1] + p.Normal[2]*center[2] + p.Distance
+		if d < -radius {
+			return false
+		}
+	}
+	return true
+}
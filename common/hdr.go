@@ -0,0 +1,194 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// hdrSignatures are the first-line markers of a Radiance RGBE (.hdr) image.
+var hdrSignatures = [][]byte{[]byte("#?RADIANCE"), []byte("#?RGBE")}
+
+// IsHDR reports whether data starts with a Radiance RGBE (.hdr) signature.
+//
+// Parameters:
+//   - data: the file contents
+//
+// Returns:
+//   - bool: true if the data is a Radiance HDR image
+func IsHDR(data []byte) bool {
+	for _, sig := range hdrSignatures {
+		if bytes.HasPrefix(data, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeHDR decodes a Radiance RGBE (.hdr) image into linear RGBA float32 pixels with alpha 1.
+// Both run-length encoded and flat scanlines are accepted. Only the standard "-Y height +X width"
+// orientation and the 32-bit_rle_rgbe format are supported.
+//
+// Parameters:
+//   - data: the file contents
+//
+// Returns:
+//   - []float32: the pixels, 4 floats per pixel, row-major from the top row
+//   - uint32: the image width in pixels
+//   - uint32: the image height in pixels
+//   - error: an error if the header is invalid or the pixel data is truncated
+func DecodeHDR(data []byte) ([]float32, uint32, uint32, error) {
+	if !IsHDR(data) {
+		return nil, 0, 0, errors.New("not a Radiance HDR image")
+	}
+
+	// Header lines run until the first empty line; the resolution line follows.
+	pos := 0
+	readLine := func() (string, bool) {
+		end := bytes.IndexByte(data[pos:], '\n')
+		if end < 0 {
+			return "", false
+		}
+		line := string(data[pos : pos+end])
+		pos += end + 1
+		return strings.TrimRight(line, "\r"), true
+	}
+	for {
+		line, ok := readLine()
+		if !ok {
+			return nil, 0, 0, errors.New("hdr: unterminated header")
+		}
+		if line == "" {
+			break
+		}
+		if format, ok := strings.CutPrefix(line, "FORMAT="); ok && format != "32-bit_rle_rgbe" {
+			return nil, 0, 0, fmt.Errorf("hdr: unsupported format %q", format)
+		}
+	}
+	resolution, ok := readLine()
+	if !ok {
+		return nil, 0, 0, errors.New("hdr: missing resolution line")
+	}
+	fields := strings.Fields(resolution)
+	if len(fields) != 4 || fields[0] != "-Y" || fields[2] != "+X" {
+		return nil, 0, 0, fmt.Errorf("hdr: unsupported orientation %q", resolution)
+	}
+	height, errH := strconv.ParseUint(fields[1], 10, 31)
+	width, errW := strconv.ParseUint(fields[3], 10, 31)
+	if errH != nil || errW != nil || width == 0 || height == 0 {
+		return nil, 0, 0, fmt.Errorf("hdr: invalid resolution %q", resolution)
+	}
+
+	w, h := int(width), int(height)
+	out := make([]float32, w*h*4)
+	scanline := make([]byte, w*4)
+	for y := range h {
+		n, err := readHDRScanline(data[pos:], scanline, w)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("hdr: scanline %d: %w", y, err)
+		}
+		pos += n
+		row := out[y*w*4 : (y+1)*w*4]
+		for x := range w {
+			r, g, b, e := scanline[x*4], scanline[x*4+1], scanline[x*4+2], scanline[x*4+3]
+			row[x*4+3] = 1
+			if e == 0 {
+				continue
+			}
+			f := float32(math.Ldexp(1, int(e)-(128+8)))
+			row[x*4] = float32(r) * f
+			row[x*4+1] = float32(g) * f
+			row[x*4+2] = float32(b) * f
+		}
+	}
+
+	return out, uint32(w), uint32(h), nil
+}
+
+// readHDRScanline reads one scanline of RGBE pixels, interleaved as RGBE per pixel.
+//
+// Parameters:
+//   - data: the remaining file contents, starting at the scanline
+//   - out: the destination, 4 bytes per pixel
+//   - width: the scanline width in pixels
+//
+// Returns:
+//   - int: the number of bytes consumed
+//   - error: an error if the data is truncated or a run overflows the scanline
+func readHDRScanline(data []byte, out []byte, width int) (int, error) {
+	truncated := errors.New("truncated pixel data")
+
+	// New-style RLE stores each channel separately, flagged by a 2, 2, width header.
+	if width >= 8 && width < 0x8000 && len(data) >= 4 &&
+		data[0] == 2 && data[1] == 2 && int(data[2])<<8|int(data[3]) == width {
+		pos := 4
+		for c := range 4 {
+			for x := 0; x < width; {
+				if pos >= len(data) {
+					return 0, truncated
+				}
+				count := int(data[pos])
+				pos++
+				if count > 128 {
+					count -= 128
+					if pos >= len(data) {
+						return 0, truncated
+					}
+					if x+count > width {
+						return 0, errors.New("run exceeds scanline width")
+					}
+					for range count {
+						out[x*4+c] = data[pos]
+						x++
+					}
+					pos++
+					continue
+				}
+				if count == 0 || x+count > width {
+					return 0, errors.New("invalid literal run")
+				}
+				if pos+count > len(data) {
+					return 0, truncated
+				}
+				for i := range count {
+					out[(x+i)*4+c] = data[pos+i]
+				}
+				x += count
+				pos += count
+			}
+		}
+		return pos, nil
+	}
+
+	// Flat scanline, possibly with old-style 1, 1, 1, n repeat markers.
+	pos, shift := 0, 0
+	for x := 0; x < width; {
+		if pos+4 > len(data) {
+			return 0, truncated
+		}
+		px := data[pos : pos+4]
+		pos += 4
+		if px[0] == 1 && px[1] == 1 && px[2] == 1 {
+			if x == 0 {
+				return 0, errors.New("repeat marker at scanline start")
+			}
+			count := int(px[3]) << shift
+			if x+count > width {
+				return 0, errors.New("run exceeds scanline width")
+			}
+			for range count {
+				copy(out[x*4:x*4+4], out[(x-1)*4:x*4])
+				x++
+			}
+			shift += 8
+			continue
+		}
+		copy(out[x*4:x*4+4], px)
+		x++
+		shift = 0
+	}
+	return pos, nil
+}
@@ -37,6 +37,110 @@ const (
 
 // Additional non-printable keys
 const (
-	KeyLeftShift  = 340 // Left Shift (GLFW)
-	KeyRightShift = 344 // Right Shift (GLFW)
+	KeyLeftShift    = 340 // Left Shift (GLFW)
+	KeyRightShift   = 344 // Right Shift (GLFW)
+	KeyLeftControl  = 341 // Left Control (GLFW)
+	KeyRightControl = 345 // Right Control (GLFW)
+	KeyLeftAlt      = 342 // Left Alt (GLFW)
+	KeyRightAlt     = 346 // Right Alt (GLFW)
+	KeyLeftSuper    = 343 // Left Super / Windows / Command (GLFW)
+	KeyRightSuper   = 347 // Right Super / Windows / Command (GLFW)
+
+	KeyEnter    = 257 // Enter key (GLFW)
+	KeyTab      = 258 // Tab key (GLFW)
+	KeyInsert   = 260 // Insert key (GLFW)
+	KeyDelete   = 261 // Delete key (GLFW)
+	KeyRight    = 262 // Right arrow (GLFW)
+	KeyLeft     = 263 // Left arrow (GLFW)
+	KeyDown     = 264 // Down arrow (GLFW)
+	KeyUp       = 265 // Up arrow (GLFW)
+	KeyPageUp   = 266 // Page Up (GLFW)
+	KeyPageDown = 267 // Page Down (GLFW)
+	KeyHome     = 268 // Home (GLFW)
+	KeyEnd      = 269 // End (GLFW)
+	KeyCapsLock = 280 // Caps Lock (GLFW)
+
+	KeyF1  = 290 // F1 (GLFW)
+	KeyF2  = 291 // F2 (GLFW)
+	KeyF3  = 292 // F3 (GLFW)
+	KeyF4  = 293 // F4 (GLFW)
+	KeyF5  = 294 // F5 (GLFW)
+	KeyF6  = 295 // F6 (GLFW)
+	KeyF7  = 296 // F7 (GLFW)
+	KeyF8  = 297 // F8 (GLFW)
+	KeyF9  = 298 // F9 (GLFW)
+	KeyF10 = 299 // F10 (GLFW)
+	KeyF11 = 300 // F11 (GLFW)
+	KeyF12 = 301 // F12 (GLFW)
+)
+
+// Remaining printable letter keys not covered above.
+const (
+	KeyH = 72 // H key (ASCII)
+	KeyI = 73 // I key (ASCII)
+	KeyJ = 74 // J key (ASCII)
+	KeyK = 75 // K key (ASCII)
+	KeyN = 78 // N key (ASCII)
+	KeyO = 79 // O key (ASCII)
+	KeyP = 80 // P key (ASCII)
+	KeyR = 82 // R key (ASCII)
+	KeyU = 85 // U key (ASCII)
+	KeyY = 89 // Y key (ASCII)
+	KeyZ = 90 // Z key (ASCII)
+)
+
+// Mouse button codes. These values match GLFW mouse button indices.
+// Reference: https://pkg.go.dev/github.com/go-gl/glfw/v3.3/glfw#MouseButton
+const (
+	MouseButtonLeft   = 0 // Primary (left) mouse button
+	MouseButtonRight  = 1 // Secondary (right) mouse button
+	MouseButtonMiddle = 2 // Middle mouse button / wheel click
+	MouseButton4      = 3 // Extra button 4 (commonly "back")
+	MouseButton5      = 4 // Extra button 5 (commonly "forward")
+	MouseButton6      = 5 // Extra button 6
+	MouseButton7      = 6 // Extra button 7
+	MouseButton8      = 7 // Extra button 8
+)
+
+// Modifier key bit flags. These values match GLFW modifier bits.
+// Reference: https://pkg.go.dev/github.com/go-gl/glfw/v3.3/glfw#ModifierKey
+const (
+	ModShift    = 0x0001 // Either Shift key held
+	ModControl  = 0x0002 // Either Control key held
+	ModAlt      = 0x0004 // Either Alt key held
+	ModSuper    = 0x0008 // Either Super key held
+	ModCapsLock = 0x0010 // Caps Lock enabled
+	ModNumLock  = 0x0020 // Num Lock enabled
+)
+
+// Gamepad button codes in the standard gamepad layout (Xbox naming).
+// These values match GLFW gamepad button indices.
+// Reference: https://www.glfw.org/docs/latest/input_guide.html#gamepad
+const (
+	GamepadButtonA           = 0  // Bottom face button (Cross on PlayStation)
+	GamepadButtonB           = 1  // Right face button (Circle)
+	GamepadButtonX           = 2  // Left face button (Square)
+	GamepadButtonY           = 3  // Top face button (Triangle)
+	GamepadButtonLeftBumper  = 4  // Left shoulder button
+	GamepadButtonRightBumper = 5  // Right shoulder button
+	GamepadButtonBack        = 6  // Back / Select / Share
+	GamepadButtonStart       = 7  // Start / Options
+	GamepadButtonGuide       = 8  // Guide / Home
+	GamepadButtonLeftThumb   = 9  // Left stick click
+	GamepadButtonRightThumb  = 10 // Right stick click
+	GamepadButtonDpadUp      = 11 // D-pad up
+	GamepadButtonDpadRight   = 12 // D-pad right
+	GamepadButtonDpadDown    = 13 // D-pad down
+	GamepadButtonDpadLeft    = 14 // D-pad left
+)
+
+// Gamepad axis codes in the standard gamepad layout.
+// These values match GLFW gamepad axis indices.
+const (
+	GamepadAxisLeftX        = 0 // Left stick horizontal, -1 (left) to 1 (right)
+	GamepadAxisLeftY        = 1 // Left stick vertical, -1 (up) to 1 (down)
+	GamepadAxisRightX       = 2 // Right stick horizontal, -1 (left) to 1 (right)
+	GamepadAxisRightY       = 3 // Right stick vertical, -1 (up) to 1 (down)
+	GamepadAxisLeftTrigger  = 4 // Left trigger, 0 (released) to 1 (fully pressed)
+	GamepadAxisRightTrigger = 5 // Right trigger, 0 (released) to 1 (fully pressed)
 )
@@ -0,0 +1,427 @@
+package common
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/cogentcore/webgpu/wgpu"
+	"github.com/klauspost/compress/zstd"
+)
+
+// ktx2Identifier is the 12-byte file identifier at the start of every KTX2 container.
+var ktx2Identifier = []byte{0xAB, 0x4B, 0x54, 0x58, 0x20, 0x32, 0x30, 0xBB, 0x0D, 0x0A, 0x1A, 0x0A}
+
+// ktx2HeaderSize is the size of the fixed KTX2 header and index, before the level index.
+const ktx2HeaderSize = 80
+
+// KTX2Supercompression identifies the supercompression scheme applied to the mip levels of a KTX2 container.
+type KTX2Supercompression uint32
+
+const (
+	// KTX2SupercompressionNone stores the level data as-is.
+	KTX2SupercompressionNone KTX2Supercompression = 0
+
+	// KTX2SupercompressionBasisLZ is the Basis Universal ETC1S scheme; levels need a KTX2Transcoder.
+	KTX2SupercompressionBasisLZ KTX2Supercompression = 1
+
+	// KTX2SupercompressionZstd compresses each level with Zstandard.
+	KTX2SupercompressionZstd KTX2Supercompression = 2
+
+	// KTX2SupercompressionZlib compresses each level with zlib.
+	KTX2SupercompressionZlib KTX2Supercompression = 3
+)
+
+// KTX2 data format descriptor color models used by Basis Universal payloads.
+const (
+	ktx2ColorModelETC1S uint8 = 163
+	ktx2ColorModelUASTC uint8 = 166
+)
+
+// ktx2TransferSRGB is the data format descriptor transfer function for sRGB encoded data.
+const ktx2TransferSRGB uint8 = 2
+
+// ktx2Formats maps the Vulkan formats a KTX2 container may declare to their WebGPU equivalents.
+var ktx2Formats = map[uint32]wgpu.TextureFormat{
+	37:  wgpu.TextureFormatRGBA8Unorm,
+	43:  wgpu.TextureFormatRGBA8UnormSrgb,
+	97:  wgpu.TextureFormatRGBA16Float,
+	109: wgpu.TextureFormatRGBA32Float,
+	131: wgpu.TextureFormatBC1RGBAUnorm,
+	132: wgpu.TextureFormatBC1RGBAUnormSrgb,
+	133: wgpu.TextureFormatBC1RGBAUnorm,
+	134: wgpu.TextureFormatBC1RGBAUnormSrgb,
+	135: wgpu.TextureFormatBC2RGBAUnorm,
+	136: wgpu.TextureFormatBC2RGBAUnormSrgb,
+	137: wgpu.TextureFormatBC3RGBAUnorm,
+	138: wgpu.TextureFormatBC3RGBAUnormSrgb,
+	139: wgpu.TextureFormatBC4RUnorm,
+	140: wgpu.TextureFormatBC4RSnorm,
+	141: wgpu.TextureFormatBC5RGUnorm,
+	142: wgpu.TextureFormatBC5RGSnorm,
+	143: wgpu.TextureFormatBC6HRGBUfloat,
+	144: wgpu.TextureFormatBC6HRGBFloat,
+	145: wgpu.TextureFormatBC7RGBAUnorm,
+	146: wgpu.TextureFormatBC7RGBAUnormSrgb,
+	147: wgpu.TextureFormatETC2RGB8Unorm,
+	148: wgpu.TextureFormatETC2RGB8UnormSrgb,
+	149: wgpu.TextureFormatETC2RGB8A1Unorm,
+	150: wgpu.TextureFormatETC2RGB8A1UnormSrgb,
+	151: wgpu.TextureFormatETC2RGBA8Unorm,
+	152: wgpu.TextureFormatETC2RGBA8UnormSrgb,
+	153: wgpu.TextureFormatEACR11Unorm,
+	154: wgpu.TextureFormatEACR11Snorm,
+	155: wgpu.TextureFormatEACRG11Unorm,
+	156: wgpu.TextureFormatEACRG11Snorm,
+	157: wgpu.TextureFormatASTC4x4Unorm,
+	158: wgpu.TextureFormatASTC4x4UnormSrgb,
+	159: wgpu.TextureFormatASTC5x4Unorm,
+	160: wgpu.TextureFormatASTC5x4UnormSrgb,
+	161: wgpu.TextureFormatASTC5x5Unorm,
+	162: wgpu.TextureFormatASTC5x5UnormSrgb,
+	163: wgpu.TextureFormatASTC6x5Unorm,
+	164: wgpu.TextureFormatASTC6x5UnormSrgb,
+	165: wgpu.TextureFormatASTC6x6Unorm,
+	166: wgpu.TextureFormatASTC6x6UnormSrgb,
+	167: wgpu.TextureFormatASTC8x5Unorm,
+	168: wgpu.TextureFormatASTC8x5UnormSrgb,
+	169: wgpu.TextureFormatASTC8x6Unorm,
+	170: wgpu.TextureFormatASTC8x6UnormSrgb,
+	171: wgpu.TextureFormatASTC8x8Unorm,
+	172: wgpu.TextureFormatASTC8x8UnormSrgb,
+	173: wgpu.TextureFormatASTC10x5Unorm,
+	174: wgpu.TextureFormatASTC10x5UnormSrgb,
+	175: wgpu.TextureFormatASTC10x6Unorm,
+	176: wgpu.TextureFormatASTC10x6UnormSrgb,
+	177: wgpu.TextureFormatASTC10x8Unorm,
+	178: wgpu.TextureFormatASTC10x8UnormSrgb,
+	179: wgpu.TextureFormatASTC10x10Unorm,
+	180: wgpu.TextureFormatASTC10x10UnormSrgb,
+	181: wgpu.TextureFormatASTC12x10Unorm,
+	182: wgpu.TextureFormatASTC12x10UnormSrgb,
+	183: wgpu.TextureFormatASTC12x12Unorm,
+	184: wgpu.TextureFormatASTC12x12UnormSrgb,
+}
+
+// KTX2Texture is a parsed KTX2 container. Level data has had zlib and Zstandard
+// supercompression undone; BasisLZ levels are left as stored for a KTX2Transcoder to handle.
+// Reference: https://registry.khronos.org/KTX/specs/2.0/ktxspec.v2.html
+type KTX2Texture struct {
+	// VkFormat is the Vulkan format of the level data, or 0 for Basis Universal payloads.
+	VkFormat uint32
+
+	// Width and Height are the base level size in pixels.
+	Width, Height uint32
+
+	// Depth is the base level depth for 3D textures, 0 otherwise.
+	Depth uint32
+
+	// LayerCount is the array layer count, 0 for non-array textures.
+	LayerCount uint32
+
+	// FaceCount is 6 for cubemaps and 1 otherwise.
+	FaceCount uint32
+
+	// Supercompression is the scheme the levels were stored with.
+	Supercompression KTX2Supercompression
+
+	// ColorModel is the data format descriptor color model (163 for ETC1S, 166 for UASTC).
+	ColorModel uint8
+
+	// SRGB reports whether the data format descriptor declares an sRGB transfer function.
+	SRGB bool
+
+	// Levels holds the data of each mip level, base level first. Each level holds all of its
+	// layers and faces in order.
+	Levels [][]byte
+
+	// GlobalData is the supercompression global data (the BasisLZ codebooks), if any.
+	GlobalData []byte
+
+	// decompressed is true when Levels no longer carry supercompression.
+	decompressed bool
+}
+
+// KTX2Transcoder converts Basis Universal ETC1S/UASTC payloads, which the engine cannot upload
+// or decode itself, into uploadable staging data. Zstandard supercompression has already been
+// undone, so UASTC levels hold raw 16-byte blocks; ETC1S levels are BasisLZ slices decoded with
+// GlobalData.
+type KTX2Transcoder interface {
+	// Transcode converts a KTX2 texture into staging data in a format the device supports,
+	// preferring a compressed format and decoding to RGBA8 as a last resort.
+	//
+	// Parameters:
+	//   - tex: the parsed container
+	//   - supported: reports whether the device can sample a format
+	//
+	// Returns:
+	//   - TextureStagingData: the transcoded base level, format and mip levels
+	//   - error: an error if the texture cannot be transcoded
+	Transcode(tex *KTX2Texture, supported func(wgpu.TextureFormat) bool) (TextureStagingData, error)
+}
+
+// IsKTX2 reports whether data starts with the KTX2 file identifier.
+//
+// Parameters:
+//   - data: the file bytes
+//
+// Returns:
+//   - bool: true if data is a KTX2 container
+func IsKTX2(data []byte) bool {
+	return len(data) >= len(ktx2Identifier) && bytes.Equal(data[:len(ktx2Identifier)], ktx2Identifier)
+}
+
+// ParseKTX2 parses a KTX2 container and extracts its mip levels, undoing zlib and Zstandard
+// supercompression.
+//
+// Parameters:
+//   - data: the file bytes
+//
+// Returns:
+//   - *KTX2Texture: the parsed texture
+//   - error: an error if the container is malformed
+func ParseKTX2(data []byte) (*KTX2Texture, error) {
+	if !IsKTX2(data) {
+		return nil, fmt.Errorf("not a KTX2 file")
+	}
+	if len(data) < ktx2HeaderSize {
+		return nil, fmt.Errorf("KTX2 header truncated")
+	}
+
+	le := binary.LittleEndian
+	k := &KTX2Texture{
+		VkFormat:         le.Uint32(data[12:]),
+		Width:            le.Uint32(data[20:]),
+		Height:           le.Uint32(data[24:]),
+		Depth:            le.Uint32(data[28:]),
+		LayerCount:       le.Uint32(data[32:]),
+		FaceCount:        le.Uint32(data[36:]),
+		Supercompression: KTX2Supercompression(le.Uint32(data[44:])),
+	}
+	levelCount := max(le.Uint32(data[40:]), 1)
+	if k.Width == 0 {
+		return nil, fmt.Errorf("KTX2 texture has zero width")
+	}
+	k.Height = max(k.Height, 1)
+
+	dfdOffset, dfdLength := le.Uint32(data[48:]), le.Uint32(data[52:])
+	if dfdLength >= 16 && uint64(dfdOffset)+uint64(dfdLength) <= uint64(len(data)) {
+		// Skip the total size word and the two block header words of the basic descriptor block.
+		dfd := data[dfdOffset:]
+		k.ColorModel = dfd[12]
+		k.SRGB = dfd[14] == ktx2TransferSRGB
+	}
+
+	n := uint64(len(data))
+	sgdOffset, sgdLength := le.Uint64(data[64:]), le.Uint64(data[72:])
+	if sgdLength > 0 {
+		if sgdOffset > n || sgdLength > n-sgdOffset {
+			return nil, fmt.Errorf("KTX2 supercompression global data out of bounds")
+		}
+		k.GlobalData = data[sgdOffset : sgdOffset+sgdLength]
+	}
+
+	indexEnd := ktx2HeaderSize + uint64(levelCount)*24
+	if indexEnd > n {
+		return nil, fmt.Errorf("KTX2 level index truncated")
+	}
+	k.Levels = make([][]byte, levelCount)
+	for i := range levelCount {
+		entry := data[ktx2HeaderSize+i*24:]
+		offset, length, uncompressed := le.Uint64(entry), le.Uint64(entry[8:]), le.Uint64(entry[16:])
+		if offset > n || length > n-offset {
+			return nil, fmt.Errorf("KTX2 level %d out of bounds", i)
+		}
+		level := data[offset : offset+length]
+
+		if k.Supercompression == KTX2SupercompressionZlib || k.Supercompression == KTX2SupercompressionZstd {
+			// Never trust the header's size for the allocation: it cannot exceed what the
+			// format needs for a level of this size.
+			if uncompressed > 1<<31 || uncompressed > k.maxLevelByteLength(int(i)) {
+				return nil, fmt.Errorf("KTX2 level %d too large", i)
+			}
+			out, err := inflateKTX2Level(k.Supercompression, level, uncompressed)
+			if err != nil {
+				return nil, fmt.Errorf("KTX2 level %d: %w", i, err)
+			}
+			level = out
+		}
+		k.Levels[i] = level
+	}
+	k.decompressed = k.Supercompression != KTX2SupercompressionBasisLZ
+
+	return k, nil
+}
+
+// inflateKTX2Level undoes the zlib or Zstandard supercompression of one mip level.
+//
+// Parameters:
+//   - scheme: the supercompression scheme, zlib or Zstandard
+//   - level: the compressed level data
+//   - size: the uncompressed byte length from the level index
+//
+// Returns:
+//   - []byte: the uncompressed level data
+//   - error: an error if the data is corrupt or does not inflate to exactly size bytes
+func inflateKTX2Level(scheme KTX2Supercompression, level []byte, size uint64) ([]byte, error) {
+	if scheme == KTX2SupercompressionZstd {
+		// The memory limit also bounds the frame's window, which encoders that do not know the
+		// content size set to 8 MiB, the most the Zstandard spec requires decoders to accept.
+		const maxWindow = 8 << 20
+		d, err := zstd.NewReader(nil,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxWindow(maxWindow),
+			zstd.WithDecoderMaxMemory(max(size, maxWindow)))
+		if err != nil {
+			return nil, err
+		}
+		defer d.Close()
+		out, err := d.DecodeAll(level, make([]byte, 0, size))
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(out)) != size {
+			return nil, fmt.Errorf("inflated to %d bytes, want %d", len(out), size)
+		}
+		return out, nil
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(level))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	out := make([]byte, size)
+	if _, err := io.ReadFull(r, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// maxLevelByteLength returns the most bytes a mip level can hold: its exact size for known
+// formats, or its size at 16 bytes per texel (the largest uncompressed texel) otherwise.
+// Saturates at math.MaxUint64.
+//
+// Parameters:
+//   - level: the mip level
+//
+// Returns:
+//   - uint64: the maximum byte length of the level
+func (k *KTX2Texture) maxLevelByteLength(level int) uint64 {
+	bw, bh, bb := uint32(1), uint32(1), uint32(16)
+	if format, ok := k.Format(); ok {
+		bw, bh, bb = TextureBlockSize(format)
+	}
+	w, h := k.LevelSize(level)
+	depth := max(k.Depth>>level, 1)
+	factors := []uint64{
+		(uint64(w) + uint64(bw) - 1) / uint64(bw),
+		(uint64(h) + uint64(bh) - 1) / uint64(bh),
+		uint64(bb),
+		uint64(depth),
+		uint64(max(k.LayerCount, 1)),
+		uint64(max(k.FaceCount, 1)),
+	}
+	size := uint64(1)
+	for _, f := range factors {
+		hi, lo := bits.Mul64(size, f)
+		if hi != 0 {
+			return math.MaxUint64
+		}
+		size = lo
+	}
+	return size
+}
+
+// IsBasis reports whether the texture holds Basis Universal (ETC1S or UASTC) data, which must be transcoded.
+//
+// Returns:
+//   - bool: true for Basis Universal payloads
+func (k *KTX2Texture) IsBasis() bool {
+	return k.Supercompression == KTX2SupercompressionBasisLZ || k.ColorModel == ktx2ColorModelETC1S || k.ColorModel == ktx2ColorModelUASTC
+}
+
+// Format returns the WebGPU format of the level data.
+//
+// Returns:
+//   - wgpu.TextureFormat: the texture format
+//   - bool: false if the Vulkan format has no WebGPU equivalent (including Basis payloads)
+func (k *KTX2Texture) Format() (wgpu.TextureFormat, bool) {
+	format, ok := ktx2Formats[k.VkFormat]
+	return format, ok
+}
+
+// LevelSize returns the pixel size of a mip level.
+//
+// Parameters:
+//   - level: the mip level
+//
+// Returns:
+//   - uint32: the level width in pixels
+//   - uint32: the level height in pixels
+func (k *KTX2Texture) LevelSize(level int) (uint32, uint32) {
+	return max(k.Width>>level, 1), max(k.Height>>level, 1)
+}
+
+// Stage converts a 2D KTX2 texture into staging data. Formats the device supports are uploaded
+// as stored with every mip level from the container. Basis Universal payloads are handed to
+// the transcoder. Other formats the device cannot sample are decoded to RGBA8 on the
+// CPU where a decoder exists (BC1-BC5, ETC2, EAC).
+//
+// Parameters:
+//   - supported: reports whether the device can sample a format; nil accepts every format
+//   - transcoder: the transcoder for Basis Universal payloads, may be nil
+//
+// Returns:
+//   - TextureStagingData: the staging data
+//   - error: an error if the texture is not 2D or cannot be converted
+func (k *KTX2Texture) Stage(supported func(wgpu.TextureFormat) bool, transcoder KTX2Transcoder) (TextureStagingData, error) {
+	if k.Depth > 1 || k.LayerCount > 1 || k.FaceCount > 1 {
+		return TextureStagingData{}, fmt.Errorf("KTX2 texture is not a plain 2D texture")
+	}
+	if k.IsBasis() || !k.decompressed {
+		if transcoder == nil {
+			return TextureStagingData{}, fmt.Errorf("KTX2 Basis Universal texture needs a KTX2Transcoder")
+		}
+		return transcoder.Transcode(k, supported)
+	}
+
+	format, ok := k.Format()
+	if !ok {
+		return TextureStagingData{}, fmt.Errorf("KTX2 vkFormat %d is not supported", k.VkFormat)
+	}
+	if supported == nil || supported(format) {
+		return TextureStagingData{
+			Pixels:    k.Levels[0],
+			Width:     k.Width,
+			Height:    k.Height,
+			Format:    format,
+			MipLevels: k.Levels[1:],
+		}, nil
+	}
+
+	levels := make([][]byte, len(k.Levels))
+	for i, level := range k.Levels {
+		w, h := k.LevelSize(i)
+		pixels, err := DecodeBlocks(format, level, w, h)
+		if err != nil {
+			return TextureStagingData{}, err
+		}
+		levels[i] = pixels
+	}
+	decoded := wgpu.TextureFormatRGBA8Unorm
+	if IsSRGBFormat(format) {
+		decoded = wgpu.TextureFormatRGBA8UnormSrgb
+	}
+	return TextureStagingData{
+		Pixels:    levels[0],
+		Width:     k.Width,
+		Height:    k.Height,
+		Format:    decoded,
+		MipLevels: levels[1:],
+	}, nil
+}
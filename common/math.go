@@ -49,6 +49,69 @@ func StructToBytes[T any](v *T) []byte {
 	return unsafe.Slice((*byte)(unsafe.Pointer(v)), int(size))
 }
 
+// maxHalf is the largest finite value a 16-bit float can hold.
+const maxHalf = 65504
+
+// Float32ToHalf converts a float32 to an IEEE 754 half-precision float, rounding to nearest even.
+// Values beyond the half range become infinity; values too small become zero.
+//
+// Parameters:
+//   - f: the value to convert
+//
+// Returns:
+//   - uint16: the half-precision bit pattern
+func Float32ToHalf(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int32(bits>>23&0xFF) - 127 + 15
+	mant := bits & 0x7FFFFF
+
+	switch {
+	case bits&0x7FFFFFFF > 0x7F800000:
+		return sign | 0x7E00 // NaN
+	case exp >= 31:
+		return sign | 0x7C00
+	case exp <= 0:
+		// Subnormal half: shift the implicit leading bit into the mantissa.
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint32(14 - exp)
+		half := uint16(mant >> shift)
+		rem, halfway := mant&(1<<shift-1), uint32(1)<<(shift-1)
+		if rem > halfway || (rem == halfway && half&1 == 1) {
+			half++
+		}
+		return sign | half
+	}
+
+	// A carry out of the mantissa rounds up into the exponent, which is still correct.
+	half := sign | uint16(exp)<<10 | uint16(mant>>13)
+	if rem := mant & 0x1FFF; rem > 0x1000 || (rem == 0x1000 && half&1 == 1) {
+		half++
+	}
+	return half
+}
+
+// PackHalfFloats converts float32 values to little-endian half floats, e.g. for RGBA16Float
+// or RG16Float texture uploads. Values are clamped to the finite half range.
+//
+// Parameters:
+//   - values: the values to convert
+//
+// Returns:
+//   - []byte: 2 bytes per value
+func PackHalfFloats(values []float32) []byte {
+	out := make([]byte, len(values)*2)
+	for i, v := range values {
+		h := Float32ToHalf(min(max(v, -maxHalf), maxHalf))
+		out[i*2] = byte(h)
+		out[i*2+1] = byte(h >> 8)
+	}
+	return out
+}
+
 // Mul4 multiplies two 4x4 matrices and stores the result in out.
 // All matrices are stored in column-major order (OpenGL/WebGPU convention).
 // Result: out = a * b
@@ -92,6 +155,26 @@ func Perspective(out []float32, fovY, aspect, near, far float32) {
 	out[15] = 0.0
 }
 
+// Orthographic creates an orthographic projection matrix mapping a view-space box to
+// WebGPU clip space, with depth in [0, 1] from the near to the far plane.
+//
+// Parameters:
+//   - out: destination slice (must be at least 16 elements)
+//   - left, right: view-space X of the left and right planes
+//   - bottom, top: view-space Y of the bottom and top planes
+//   - near: near clipping plane distance
+//   - far: far clipping plane distance (must differ from near)
+func Orthographic(out []float32, left, right, bottom, top, near, far float32) {
+	Identity(out)
+
+	out[0] = 2 / (right - left)
+	out[5] = 2 / (top - bottom)
+	out[10] = 1 / (near - far)
+	out[12] = -(right + left) / (right - left)
+	out[13] = -(top + bottom) / (top - bottom)
+	out[14] = near / (near - far)
+}
+
 // BuildModelMatrix constructs a 4x4 model matrix from position, Euler rotation, and scale.
 // The rotation order is Y * X * Z (yaw-pitch-roll). All matrices are column-major.
 //
@@ -130,6 +213,60 @@ func BuildModelMatrix(out []float32, posX, posY, posZ, rotX, rotY, rotZ, scaleX,
 	out[15] = 1
 }
 
+// DecomposeModelMatrix splits a 4x4 model matrix into position, Euler rotation, and scale,
+// inverting BuildModelMatrix (rotation order Y * X * Z). Shear cannot be represented and is
+// discarded; a negative determinant is folded into the X scale.
+//
+// Parameters:
+//   - m: source matrix (16 elements, column-major)
+//
+// Returns:
+//   - pos: translation
+//   - rot: rotation angles in radians around each axis
+//   - scale: scale factors along each axis
+func DecomposeModelMatrix(m []float32) (pos, rot, scale [3]float32) {
+	pos = [3]float32{m[12], m[13], m[14]}
+
+	length := func(x, y, z float32) float32 {
+		return float32(math.Sqrt(float64(x*x + y*y + z*z)))
+	}
+	scale = [3]float32{
+		length(m[0], m[1], m[2]),
+		length(m[4], m[5], m[6]),
+		length(m[8], m[9], m[10]),
+	}
+
+	// A mirrored basis has a negative determinant; attribute the flip to X.
+	det := m[0]*(m[5]*m[10]-m[6]*m[9]) - m[4]*(m[1]*m[10]-m[2]*m[9]) + m[8]*(m[1]*m[6]-m[2]*m[5])
+	if det < 0 {
+		scale[0] = -scale[0]
+	}
+
+	var r [9]float32 // normalized basis columns
+	for col := range 3 {
+		if scale[col] == 0 {
+			continue
+		}
+		for row := range 3 {
+			r[col*3+row] = m[col*4+row] / scale[col]
+		}
+	}
+
+	// From R = Ry * Rx * Rz: r[7] = -sin(x), r[6] = sin(y)cos(x), r[8] = cos(y)cos(x),
+	// r[1] = cos(x)sin(z), r[4] = cos(x)cos(z).
+	sx := min(max(-r[7], -1), 1)
+	rot[0] = float32(math.Asin(float64(sx)))
+	if math.Abs(float64(sx)) < 0.9999 {
+		rot[1] = float32(math.Atan2(float64(r[6]), float64(r[8])))
+		rot[2] = float32(math.Atan2(float64(r[1]), float64(r[4])))
+	} else {
+		// Gimbal lock: Y and Z rotate about the same axis; fold everything into Y.
+		rot[1] = float32(math.Atan2(float64(-r[2]), float64(r[0])))
+		rot[2] = 0
+	}
+	return
+}
+
 // Invert4 computes the inverse of a 4x4 column-major matrix using the Laplace
 // expansion (cofactor) method. If the matrix is singular (determinant ≈ 0) the
 // output is left unchanged and the function returns false.
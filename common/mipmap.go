@@ -0,0 +1,114 @@
+package common
+
+import (
+	"math"
+	"math/bits"
+)
+
+// srgbToLinear maps an 8-bit sRGB channel value to linear intensity in [0, 1].
+var srgbToLinear = func() (table [256]float32) {
+	for i := range table {
+		c := float64(i) / 255
+		if c <= 0.04045 {
+			table[i] = float32(c / 12.92)
+		} else {
+			table[i] = float32(math.Pow((c+0.055)/1.055, 2.4))
+		}
+	}
+	return table
+}()
+
+// SRGBToLinear decodes an 8-bit sRGB channel value to linear intensity in [0, 1].
+//
+// Parameters:
+//   - v: the sRGB encoded value
+//
+// Returns:
+//   - float32: the linear intensity
+func SRGBToLinear(v byte) float32 {
+	return srgbToLinear[v]
+}
+
+// MipLevelCount returns the number of levels in a full mip chain for a texture of the given size,
+// down to and including the 1x1 level.
+//
+// Parameters:
+//   - width: the base level width in pixels
+//   - height: the base level height in pixels
+//
+// Returns:
+//   - uint32: the mip level count (at least 1)
+func MipLevelCount(width, height uint32) uint32 {
+	size := max(width, height, 1)
+	return uint32(bits.Len32(size))
+}
+
+// DownsampleRGBA halves an RGBA8 image with a 2x2 box filter, producing the next level of a mip chain.
+// Odd dimensions round down (to a minimum of 1), dropping the last row or column, as GPU mip
+// sizes do. When srgb is true the color channels are averaged in linear space, matching what
+// the GPU does for sRGB texture formats; alpha is always averaged as stored.
+//
+// Parameters:
+//   - pixels: the source pixels, 4 bytes per pixel, row-major
+//   - width: the source width in pixels
+//   - height: the source height in pixels
+//   - srgb: true if the color channels are sRGB encoded
+//
+// Returns:
+//   - []byte: the downsampled pixels
+//   - uint32: the downsampled width
+//   - uint32: the downsampled height
+func DownsampleRGBA(pixels []byte, width, height uint32, srgb bool) ([]byte, uint32, uint32) {
+	outW := max(width/2, 1)
+	outH := max(height/2, 1)
+	out := make([]byte, outW*outH*4)
+
+	for y := range outH {
+		y0 := min(y*2, height-1)
+		y1 := min(y*2+1, height-1)
+		for x := range outW {
+			x0 := min(x*2, width-1)
+			x1 := min(x*2+1, width-1)
+			src := [4]uint32{
+				(y0*width + x0) * 4,
+				(y0*width + x1) * 4,
+				(y1*width + x0) * 4,
+				(y1*width + x1) * 4,
+			}
+			dst := (y*outW + x) * 4
+			for c := range uint32(4) {
+				if srgb && c < 3 {
+					var sum float32
+					for _, s := range src {
+						sum += srgbToLinear[pixels[s+c]]
+					}
+					out[dst+c] = linearToSRGB(sum / 4)
+					continue
+				}
+				var sum uint32
+				for _, s := range src {
+					sum += uint32(pixels[s+c])
+				}
+				out[dst+c] = byte((sum + 2) / 4)
+			}
+		}
+	}
+	return out, outW, outH
+}
+
+// linearToSRGB encodes a linear intensity in [0, 1] as an 8-bit sRGB channel value.
+//
+// Parameters:
+//   - v: the linear intensity
+//
+// Returns:
+//   - byte: the sRGB encoded value
+func linearToSRGB(v float32) byte {
+	c := float64(v)
+	if c <= 0.0031308 {
+		c *= 12.92
+	} else {
+		c = 1.055*math.Pow(c, 1/2.4) - 0.055
+	}
+	return byte(min(max(c*255+0.5, 0), 255))
+}
@@ -0,0 +1,183 @@
+package common
+
+import (
+	"math"
+)
+
+// rayEpsilon rejects near-parallel triangle hits and self-intersections at the ray origin.
+const rayEpsilon = 1e-7
+
+// Ray is a half-line starting at Origin and extending along Direction.
+// Direction should be normalized so intersection distances are in world units.
+type Ray struct {
+	Origin    [3]float32
+	Direction [3]float32
+}
+
+// NewRay creates a Ray with a normalized direction.
+//
+// Parameters:
+//   - origin: the ray origin
+//   - direction: the ray direction (normalized by this function)
+//
+// Returns:
+//   - Ray: the ray
+func NewRay(origin, direction [3]float32) Ray {
+	return Ray{Origin: origin, Direction: normalize3(direction)}
+}
+
+// At returns the point at distance t along the ray.
+//
+// Parameters:
+//   - t: the distance along the direction
+//
+// Returns:
+//   - [3]float32: the point Origin + t*Direction
+func (r Ray) At(t float32) [3]float32 {
+	return [3]float32{
+		r.Origin[0] + r.Direction[0]*t,
+		r.Origin[1] + r.Direction[1]*t,
+		r.Origin[2] + r.Direction[2]*t,
+	}
+}
+
+// Transform returns the ray transformed by a column-major 4x4 matrix. The direction is
+// transformed as a vector and not renormalized, so distances along the transformed ray
+// match distances along the original one.
+//
+// Parameters:
+//   - m: the 16-element column-major matrix
+//
+// Returns:
+//   - Ray: the transformed ray
+func (r Ray) Transform(m []float32) Ray {
+	return Ray{
+		Origin:    TransformPoint(m, r.Origin),
+		Direction: TransformVector(m, r.Direction),
+	}
+}
+
+// IntersectSphere tests the ray against a sphere.
+//
+// Parameters:
+//   - center: the sphere center
+//   - radius: the sphere radius
+//
+// Returns:
+//   - float32: the distance to the first intersection in front of the origin (0 if the origin is inside)
+//   - bool: true if the ray hits the sphere
+func (r Ray) IntersectSphere(center [3]float32, radius float32) (float32, bool) {
+	oc := [3]float32{r.Origin[0] - center[0], r.Origin[1] - center[1], r.Origin[2] - center[2]}
+	a := dot3(r.Direction, r.Direction)
+	if a == 0 {
+		return 0, false
+	}
+	b := dot3(oc, r.Direction)
+	c := dot3(oc, oc) - radius*radius
+	if c <= 0 {
+		return 0, true
+	}
+	disc := b*b - a*c
+	if b > 0 || disc < 0 {
+		return 0, false
+	}
+	return (-b - float32(math.Sqrt(float64(disc)))) / a, true
+}
+
+// IntersectTriangle tests the ray against a triangle using the Möller–Trumbore algorithm.
+// Both faces are hit.
+//
+// Reference: https://www.graphics.cornell.edu/pubs/1997/MT97.pdf
+//
+// Parameters:
+//   - a, b, c: the triangle vertices
+//
+// Returns:
+//   - float32: the distance along the ray to the hit point
+//   - bool: true if the ray hits the triangle in front of the origin
+func (r Ray) IntersectTriangle(a, b, c [3]float32) (float32, bool) {
+	e1 := sub3(b, a)
+	e2 := sub3(c, a)
+	p := cross3(r.Direction, e2)
+	det := dot3(e1, p)
+	if det > -rayEpsilon && det < rayEpsilon {
+		return 0, false
+	}
+	inv := 1 / det
+
+	s := sub3(r.Origin, a)
+	u := dot3(s, p) * inv
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+	q := cross3(s, e1)
+	v := dot3(r.Direction, q) * inv
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+	t := dot3(e2, q) * inv
+	if t <= rayEpsilon {
+		return 0, false
+	}
+	return t, true
+}
+
+// TransformPoint transforms a point by a column-major 4x4 matrix, applying translation
+// and the perspective divide.
+//
+// Parameters:
+//   - m: the 16-element column-major matrix
+//   - p: the point
+//
+// Returns:
+//   - [3]float32: the transformed point
+func TransformPoint(m []float32, p [3]float32) [3]float32 {
+	x := m[0]*p[0] + m[4]*p[1] + m[8]*p[2] + m[12]
+	y := m[1]*p[0] + m[5]*p[1] + m[9]*p[2] + m[13]
+	z := m[2]*p[0] + m[6]*p[1] + m[10]*p[2] + m[14]
+	w := m[3]*p[0] + m[7]*p[1] + m[11]*p[2] + m[15]
+	if w != 0 && w != 1 {
+		return [3]float32{x / w, y / w, z / w}
+	}
+	return [3]float32{x, y, z}
+}
+
+// TransformVector transforms a direction by a column-major 4x4 matrix, ignoring translation.
+//
+// Parameters:
+//   - m: the 16-element column-major matrix
+//   - v: the direction
+//
+// Returns:
+//   - [3]float32: the transformed direction
+func TransformVector(m []float32, v [3]float32) [3]float32 {
+	return [3]float32{
+		m[0]*v[0] + m[4]*v[1] + m[8]*v[2],
+		m[1]*v[0] + m[5]*v[1] + m[9]*v[2],
+		m[2]*v[0] + m[6]*v[1] + m[10]*v[2],
+	}
+}
+
+func dot3(a, b [3]float32) float32 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func sub3(a, b [3]float32) [3]float32 {
+	return [3]float32{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func cross3(a, b [3]float32) [3]float32 {
+	return [3]float32{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func normalize3(v [3]float32) [3]float32 {
+	l := float32(math.Sqrt(float64(dot3(v, v))))
+	if l == 0 {
+		return v
+	}
+	return [3]float32{v[0] / l, v[1] / l, v[2] / l}
+}
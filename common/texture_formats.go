@@ -0,0 +1,507 @@
+package common
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// etcModifiers are the ETC1/ETC2 intensity modifier tables, as {small, large} pairs.
+var etcModifiers = [8][2]int{
+	{2, 8}, {5, 17}, {9, 29}, {13, 42}, {18, 60}, {24, 80}, {33, 106}, {47, 183},
+}
+
+// etcDistances are the ETC2 T and H mode distance table.
+var etcDistances = [8]int{3, 6, 11, 16, 23, 32, 41, 64}
+
+// eacModifiers are the EAC alpha and R11/RG11 modifier tables.
+var eacModifiers = [16][8]int{
+	{-3, -6, -9, -15, 2, 5, 8, 14},
+	{-3, -7, -10, -13, 2, 6, 9, 12},
+	{-2, -5, -8, -13, 1, 4, 7, 12},
+	{-2, -4, -6, -13, 1, 3, 5, 12},
+	{-3, -6, -8, -12, 2, 5, 7, 11},
+	{-3, -7, -9, -11, 2, 6, 8, 10},
+	{-4, -7, -8, -11, 3, 6, 7, 10},
+	{-3, -5, -8, -11, 2, 4, 7, 10},
+	{-2, -6, -8, -10, 1, 5, 7, 9},
+	{-2, -5, -8, -10, 1, 4, 7, 9},
+	{-2, -4, -8, -10, 1, 3, 7, 9},
+	{-2, -5, -7, -10, 1, 4, 6, 9},
+	{-3, -4, -7, -10, 2, 3, 6, 9},
+	{-1, -2, -3, -10, 0, 1, 2, 9},
+	{-4, -6, -8, -9, 3, 5, 7, 8},
+	{-3, -5, -7, -9, 2, 4, 6, 8},
+}
+
+// TextureBlockSize returns the block dimensions and bytes per block of a texture format.
+// Uncompressed formats report a 1x1 block. Formats not listed are treated as 4-byte texels.
+//
+// Parameters:
+//   - format: the texture format
+//
+// Returns:
+//   - uint32: the block width in pixels
+//   - uint32: the block height in pixels
+//   - uint32: the size of one block in bytes
+func TextureBlockSize(format wgpu.TextureFormat) (uint32, uint32, uint32) {
+	switch format {
+	case wgpu.TextureFormatR8Unorm:
+		return 1, 1, 1
+	case wgpu.TextureFormatRG16Float:
+		return 1, 1, 4
+	case wgpu.TextureFormatRGBA16Float:
+		return 1, 1, 8
+	case wgpu.TextureFormatRGBA32Float:
+		return 1, 1, 16
+	case wgpu.TextureFormatBC1RGBAUnorm, wgpu.TextureFormatBC1RGBAUnormSrgb,
+		wgpu.TextureFormatBC4RUnorm, wgpu.TextureFormatBC4RSnorm,
+		wgpu.TextureFormatETC2RGB8Unorm, wgpu.TextureFormatETC2RGB8UnormSrgb,
+		wgpu.TextureFormatETC2RGB8A1Unorm, wgpu.TextureFormatETC2RGB8A1UnormSrgb,
+		wgpu.TextureFormatEACR11Unorm, wgpu.TextureFormatEACR11Snorm:
+		return 4, 4, 8
+	case wgpu.TextureFormatBC2RGBAUnorm, wgpu.TextureFormatBC2RGBAUnormSrgb,
+		wgpu.TextureFormatBC3RGBAUnorm, wgpu.TextureFormatBC3RGBAUnormSrgb,
+		wgpu.TextureFormatBC5RGUnorm, wgpu.TextureFormatBC5RGSnorm,
+		wgpu.TextureFormatBC6HRGBUfloat, wgpu.TextureFormatBC6HRGBFloat,
+		wgpu.TextureFormatBC7RGBAUnorm, wgpu.TextureFormatBC7RGBAUnormSrgb,
+		wgpu.TextureFormatETC2RGBA8Unorm, wgpu.TextureFormatETC2RGBA8UnormSrgb,
+		wgpu.TextureFormatEACRG11Unorm, wgpu.TextureFormatEACRG11Snorm:
+		return 4, 4, 16
+	case wgpu.TextureFormatASTC4x4Unorm, wgpu.TextureFormatASTC4x4UnormSrgb:
+		return 4, 4, 16
+	case wgpu.TextureFormatASTC5x4Unorm, wgpu.TextureFormatASTC5x4UnormSrgb:
+		return 5, 4, 16
+	case wgpu.TextureFormatASTC5x5Unorm, wgpu.TextureFormatASTC5x5UnormSrgb:
+		return 5, 5, 16
+	case wgpu.TextureFormatASTC6x5Unorm, wgpu.TextureFormatASTC6x5UnormSrgb:
+		return 6, 5, 16
+	case wgpu.TextureFormatASTC6x6Unorm, wgpu.TextureFormatASTC6x6UnormSrgb:
+		return 6, 6, 16
+	case wgpu.TextureFormatASTC8x5Unorm, wgpu.TextureFormatASTC8x5UnormSrgb:
+		return 8, 5, 16
+	case wgpu.TextureFormatASTC8x6Unorm, wgpu.TextureFormatASTC8x6UnormSrgb:
+		return 8, 6, 16
+	case wgpu.TextureFormatASTC8x8Unorm, wgpu.TextureFormatASTC8x8UnormSrgb:
+		return 8, 8, 16
+	case wgpu.TextureFormatASTC10x5Unorm, wgpu.TextureFormatASTC10x5UnormSrgb:
+		return 10, 5, 16
+	case wgpu.TextureFormatASTC10x6Unorm, wgpu.TextureFormatASTC10x6UnormSrgb:
+		return 10, 6, 16
+	case wgpu.TextureFormatASTC10x8Unorm, wgpu.TextureFormatASTC10x8UnormSrgb:
+		return 10, 8, 16
+	case wgpu.TextureFormatASTC10x10Unorm, wgpu.TextureFormatASTC10x10UnormSrgb:
+		return 10, 10, 16
+	case wgpu.TextureFormatASTC12x10Unorm, wgpu.TextureFormatASTC12x10UnormSrgb:
+		return 12, 10, 16
+	case wgpu.TextureFormatASTC12x12Unorm, wgpu.TextureFormatASTC12x12UnormSrgb:
+		return 12, 12, 16
+	}
+	return 1, 1, 4
+}
+
+// IsSRGBFormat reports whether a texture format stores sRGB encoded color.
+//
+// Parameters:
+//   - format: the texture format
+//
+// Returns:
+//   - bool: true for sRGB formats
+func IsSRGBFormat(format wgpu.TextureFormat) bool {
+	switch format {
+	case wgpu.TextureFormatRGBA8UnormSrgb, wgpu.TextureFormatBGRA8UnormSrgb,
+		wgpu.TextureFormatBC1RGBAUnormSrgb, wgpu.TextureFormatBC2RGBAUnormSrgb,
+		wgpu.TextureFormatBC3RGBAUnormSrgb, wgpu.TextureFormatBC7RGBAUnormSrgb,
+		wgpu.TextureFormatETC2RGB8UnormSrgb, wgpu.TextureFormatETC2RGB8A1UnormSrgb,
+		wgpu.TextureFormatETC2RGBA8UnormSrgb,
+		wgpu.TextureFormatASTC4x4UnormSrgb, wgpu.TextureFormatASTC5x4UnormSrgb,
+		wgpu.TextureFormatASTC5x5UnormSrgb, wgpu.TextureFormatASTC6x5UnormSrgb,
+		wgpu.TextureFormatASTC6x6UnormSrgb, wgpu.TextureFormatASTC8x5UnormSrgb,
+		wgpu.TextureFormatASTC8x6UnormSrgb, wgpu.TextureFormatASTC8x8UnormSrgb,
+		wgpu.TextureFormatASTC10x5UnormSrgb, wgpu.TextureFormatASTC10x6UnormSrgb,
+		wgpu.TextureFormatASTC10x8UnormSrgb, wgpu.TextureFormatASTC10x10UnormSrgb,
+		wgpu.TextureFormatASTC12x10UnormSrgb, wgpu.TextureFormatASTC12x12UnormSrgb:
+		return true
+	}
+	return false
+}
+
+// DecodeBlocks decodes one level of block-compressed texture data to RGBA8 on the CPU. Supported
+// formats are BC1-BC5 (unsigned), ETC2 RGB8/RGB8A1/RGBA8 and EAC R11/RG11 (unsigned). Color is
+// returned in the encoding it was stored in; single and dual channel formats fill R and G,
+// leave B at 0 and A at 255.
+//
+// Parameters:
+//   - format: the compressed format of data
+//   - data: the level's blocks, row-major
+//   - width: the level width in pixels
+//   - height: the level height in pixels
+//
+// Returns:
+//   - []byte: the RGBA8 pixels, 4 bytes per pixel, row-major
+//   - error: an error if the format has no decoder or data is too short
+func DecodeBlocks(format wgpu.TextureFormat, data []byte, width, height uint32) ([]byte, error) {
+	var decode func(block []byte, out *[16][4]byte)
+	switch format {
+	case wgpu.TextureFormatRGBA8Unorm, wgpu.TextureFormatRGBA8UnormSrgb:
+		if len(data) < int(width*height*4) {
+			return nil, fmt.Errorf("texture level data too short")
+		}
+		return data[:width*height*4], nil
+	case wgpu.TextureFormatBC1RGBAUnorm, wgpu.TextureFormatBC1RGBAUnormSrgb:
+		decode = func(b []byte, out *[16][4]byte) { decodeBC1Color(b, out, true) }
+	case wgpu.TextureFormatBC2RGBAUnorm, wgpu.TextureFormatBC2RGBAUnormSrgb:
+		decode = decodeBC2
+	case wgpu.TextureFormatBC3RGBAUnorm, wgpu.TextureFormatBC3RGBAUnormSrgb:
+		decode = decodeBC3
+	case wgpu.TextureFormatBC4RUnorm:
+		decode = func(b []byte, out *[16][4]byte) { decodeBC4Channel(b, out, 0) }
+	case wgpu.TextureFormatBC5RGUnorm:
+		decode = func(b []byte, out *[16][4]byte) {
+			decodeBC4Channel(b, out, 0)
+			decodeBC4Channel(b[8:], out, 1)
+		}
+	case wgpu.TextureFormatETC2RGB8Unorm, wgpu.TextureFormatETC2RGB8UnormSrgb:
+		decode = func(b []byte, out *[16][4]byte) { decodeETC2Color(b, out, false) }
+	case wgpu.TextureFormatETC2RGB8A1Unorm, wgpu.TextureFormatETC2RGB8A1UnormSrgb:
+		decode = func(b []byte, out *[16][4]byte) { decodeETC2Color(b, out, true) }
+	case wgpu.TextureFormatETC2RGBA8Unorm, wgpu.TextureFormatETC2RGBA8UnormSrgb:
+		decode = func(b []byte, out *[16][4]byte) {
+			decodeETC2Color(b[8:], out, false)
+			decodeEACChannel(b, out, 3, false)
+		}
+	case wgpu.TextureFormatEACR11Unorm:
+		decode = func(b []byte, out *[16][4]byte) { decodeEACChannel(b, out, 0, true) }
+	case wgpu.TextureFormatEACRG11Unorm:
+		decode = func(b []byte, out *[16][4]byte) {
+			decodeEACChannel(b, out, 0, true)
+			decodeEACChannel(b[8:], out, 1, true)
+		}
+	default:
+		return nil, fmt.Errorf("no CPU decoder for texture format %s", format)
+	}
+
+	_, _, blockBytes := TextureBlockSize(format)
+	blocksX, blocksY := (width+3)/4, (height+3)/4
+	if len(data) < int(blocksX*blocksY*blockBytes) {
+		return nil, fmt.Errorf("texture level data too short")
+	}
+
+	pixels := make([]byte, width*height*4)
+	var block [16][4]byte
+	for by := range blocksY {
+		for bx := range blocksX {
+			offset := (by*blocksX + bx) * blockBytes
+			block = [16][4]byte{}
+			for i := range block {
+				block[i][3] = 255
+			}
+			decode(data[offset:offset+blockBytes], &block)
+			for y := range uint32(4) {
+				py := by*4 + y
+				if py >= height {
+					break
+				}
+				for x := range uint32(4) {
+					px := bx*4 + x
+					if px >= width {
+						break
+					}
+					copy(pixels[(py*width+px)*4:], block[y*4+x][:])
+				}
+			}
+		}
+	}
+	return pixels, nil
+}
+
+// decodeBC1Color decodes the 8-byte BC1 color block into a 4x4 row-major pixel block. When
+// threeColor is true, blocks with color0 <= color1 use the three-color mode with transparent black.
+func decodeBC1Color(b []byte, out *[16][4]byte, threeColor bool) {
+	c0 := binary.LittleEndian.Uint16(b)
+	c1 := binary.LittleEndian.Uint16(b[2:])
+	indices := binary.LittleEndian.Uint32(b[4:])
+
+	var palette [4][4]byte
+	palette[0] = expand565(c0)
+	palette[1] = expand565(c1)
+	for ch := range 3 {
+		a, bb := int(palette[0][ch]), int(palette[1][ch])
+		if c0 > c1 || !threeColor {
+			palette[2][ch] = byte((2*a + bb) / 3)
+			palette[3][ch] = byte((a + 2*bb) / 3)
+		} else {
+			palette[2][ch] = byte((a + bb) / 2)
+		}
+	}
+	palette[2][3] = 255
+	palette[3][3] = 255
+	if c0 <= c1 && threeColor {
+		palette[3] = [4]byte{}
+	}
+
+	for i := range 16 {
+		alpha := out[i][3]
+		out[i] = palette[(indices>>(2*i))&3]
+		if !threeColor {
+			out[i][3] = alpha
+		}
+	}
+}
+
+// decodeBC2 decodes a BC2 block: explicit 4-bit alpha followed by a four-color BC1 block.
+func decodeBC2(b []byte, out *[16][4]byte) {
+	alpha := binary.LittleEndian.Uint64(b)
+	for i := range 16 {
+		out[i][3] = byte((alpha>>(4*i))&15) * 17
+	}
+	decodeBC1Color(b[8:], out, false)
+}
+
+// decodeBC3 decodes a BC3 block: interpolated BC4-style alpha followed by a four-color BC1 block.
+func decodeBC3(b []byte, out *[16][4]byte) {
+	decodeBC4Channel(b, out, 3)
+	decodeBC1Color(b[8:], out, false)
+}
+
+// decodeBC4Channel decodes an 8-byte unsigned BC4 block into one channel of the pixel block.
+func decodeBC4Channel(b []byte, out *[16][4]byte, channel int) {
+	a0, a1 := int(b[0]), int(b[1])
+	var palette [8]int
+	palette[0], palette[1] = a0, a1
+	if a0 > a1 {
+		for i := 1; i <= 6; i++ {
+			palette[i+1] = ((7-i)*a0 + i*a1) / 7
+		}
+	} else {
+		for i := 1; i <= 4; i++ {
+			palette[i+1] = ((5-i)*a0 + i*a1) / 5
+		}
+		palette[6], palette[7] = 0, 255
+	}
+
+	var bits uint64
+	for i := range 6 {
+		bits |= uint64(b[2+i]) << (8 * i)
+	}
+	for i := range 16 {
+		out[i][channel] = byte(palette[(bits>>(3*i))&7])
+	}
+}
+
+// decodeETC2Color decodes an 8-byte ETC2 RGB block into a 4x4 row-major pixel block. With
+// punchthrough set the block is an RGB8A1 block, whose differential bit is the opaque flag.
+func decodeETC2Color(b []byte, out *[16][4]byte, punchthrough bool) {
+	diff := b[3]&2 != 0
+	opaque := true
+	if punchthrough {
+		opaque, diff = diff, true
+	}
+	flip := b[3]&1 != 0
+
+	if !diff {
+		base := [2][3]int{
+			{int(b[0]>>4) * 17, int(b[1]>>4) * 17, int(b[2]>>4) * 17},
+			{int(b[0]&15) * 17, int(b[1]&15) * 17, int(b[2]&15) * 17},
+		}
+		decodeETCSubblocks(b, out, base, flip, true)
+		return
+	}
+
+	r, dr := int(b[0]>>3), signExtend3(b[0]&7)
+	g, dg := int(b[1]>>3), signExtend3(b[1]&7)
+	bl, db := int(b[2]>>3), signExtend3(b[2]&7)
+	switch {
+	case r+dr < 0 || r+dr > 31:
+		decodeETC2T(b, out, opaque)
+	case g+dg < 0 || g+dg > 31:
+		decodeETC2H(b, out, opaque)
+	case bl+db < 0 || bl+db > 31:
+		decodeETC2Planar(b, out)
+	default:
+		base := [2][3]int{
+			{expand5(r), expand5(g), expand5(bl)},
+			{expand5(r + dr), expand5(g + dg), expand5(bl + db)},
+		}
+		decodeETCSubblocks(b, out, base, flip, opaque)
+	}
+}
+
+// decodeETCSubblocks decodes the individual and differential modes, which split the block into
+// two 2x4 (or, flipped, 4x2) subblocks with their own base color and modifier table.
+func decodeETCSubblocks(b []byte, out *[16][4]byte, base [2][3]int, flip, opaque bool) {
+	tables := [2]int{int(b[3]>>5) & 7, int(b[3]>>2) & 7}
+	indices := binary.BigEndian.Uint32(b[4:])
+	for y := range 4 {
+		for x := range 4 {
+			sub := 0
+			if (!flip && x >= 2) || (flip && y >= 2) {
+				sub = 1
+			}
+			k := x*4 + y
+			index := (indices>>(k+15))&2 | (indices>>k)&1
+			mod := etcModifiers[tables[sub]]
+			var delta int
+			switch index {
+			case 0:
+				delta = mod[0]
+			case 1:
+				delta = mod[1]
+			case 2:
+				delta = -mod[0]
+			case 3:
+				delta = -mod[1]
+			}
+			if !opaque {
+				if index == 2 {
+					out[y*4+x] = [4]byte{}
+					continue
+				}
+				if index == 0 {
+					delta = 0
+				}
+			}
+			c := base[sub]
+			out[y*4+x] = [4]byte{clampByte(c[0] + delta), clampByte(c[1] + delta), clampByte(c[2] + delta), 255}
+		}
+	}
+}
+
+// decodeETC2T decodes the ETC2 T mode, triggered by red overflow in differential mode.
+func decodeETC2T(b []byte, out *[16][4]byte, opaque bool) {
+	c0 := [3]int{
+		int((b[0]>>1)&0xC|b[0]&3) * 17,
+		int(b[1]>>4) * 17,
+		int(b[1]&15) * 17,
+	}
+	c1 := [3]int{int(b[2]>>4) * 17, int(b[2]&15) * 17, int(b[3]>>4) * 17}
+	d := etcDistances[(b[3]>>1)&6|b[3]&1]
+	paint := [4][3]int{c0, offsetColor(c1, d), c1, offsetColor(c1, -d)}
+	writeETC2Paint(b, out, paint, opaque)
+}
+
+// decodeETC2H decodes the ETC2 H mode, triggered by green overflow in differential mode.
+func decodeETC2H(b []byte, out *[16][4]byte, opaque bool) {
+	r0 := int(b[0]>>3) & 15
+	g0 := int(b[0]&7)<<1 | int(b[1]>>4)&1
+	b0 := int(b[1]&8) | int(b[1]&3)<<1 | int(b[2]>>7)
+	r1 := int(b[2]>>3) & 15
+	g1 := int(b[2]&7)<<1 | int(b[3]>>7)
+	b1 := int(b[3]>>3) & 15
+
+	di := int(b[3]&4) | int(b[3]&1)<<1
+	if r0<<8|g0<<4|b0 >= r1<<8|g1<<4|b1 {
+		di |= 1
+	}
+	d := etcDistances[di]
+	c0 := [3]int{r0 * 17, g0 * 17, b0 * 17}
+	c1 := [3]int{r1 * 17, g1 * 17, b1 * 17}
+	paint := [4][3]int{offsetColor(c0, d), offsetColor(c0, -d), offsetColor(c1, d), offsetColor(c1, -d)}
+	writeETC2Paint(b, out, paint, opaque)
+}
+
+// writeETC2Paint writes T/H mode pixels, which select one of four paint colors directly.
+// Without the opaque flag, paint color 2 is transparent black.
+func writeETC2Paint(b []byte, out *[16][4]byte, paint [4][3]int, opaque bool) {
+	indices := binary.BigEndian.Uint32(b[4:])
+	for y := range 4 {
+		for x := range 4 {
+			k := x*4 + y
+			index := (indices>>(k+15))&2 | (indices>>k)&1
+			if !opaque && index == 2 {
+				out[y*4+x] = [4]byte{}
+				continue
+			}
+			c := paint[index]
+			out[y*4+x] = [4]byte{clampByte(c[0]), clampByte(c[1]), clampByte(c[2]), 255}
+		}
+	}
+}
+
+// decodeETC2Planar decodes the ETC2 planar mode, triggered by blue overflow in differential mode.
+func decodeETC2Planar(b []byte, out *[16][4]byte) {
+	ro := expand6(int(b[0]>>1) & 63)
+	gO := expand7(int(b[0]&1)<<6 | int(b[1]>>1)&63)
+	bo := expand6(int(b[1]&1)<<5 | int(b[2]&0x18) | int(b[2]&3)<<1 | int(b[3]>>7))
+	rh := expand6(int(b[3]>>1)&0x3E | int(b[3]&1))
+	gh := expand7(int(b[4] >> 1))
+	bh := expand6(int(b[4]&1)<<5 | int(b[5]>>3))
+	rv := expand6(int(b[5]&7)<<3 | int(b[6]>>5))
+	gv := expand7(int(b[6]&0x1F)<<2 | int(b[7]>>6))
+	bv := expand6(int(b[7] & 0x3F))
+
+	for y := range 4 {
+		for x := range 4 {
+			out[y*4+x] = [4]byte{
+				clampByte((x*(rh-ro) + y*(rv-ro) + 4*ro + 2) >> 2),
+				clampByte((x*(gh-gO) + y*(gv-gO) + 4*gO + 2) >> 2),
+				clampByte((x*(bh-bo) + y*(bv-bo) + 4*bo + 2) >> 2),
+				255,
+			}
+		}
+	}
+}
+
+// decodeEACChannel decodes an 8-byte EAC block into one channel of the pixel block. With r11
+// set the block is decoded at 11-bit precision (EAC R11/RG11) and reduced to 8 bits.
+func decodeEACChannel(b []byte, out *[16][4]byte, channel int, r11 bool) {
+	base := int(b[0])
+	mult := int(b[1] >> 4)
+	table := eacModifiers[b[1]&15]
+	var bits uint64
+	for i := 2; i < 8; i++ {
+		bits = bits<<8 | uint64(b[i])
+	}
+	for x := range 4 {
+		for y := range 4 {
+			k := x*4 + y
+			mod := table[(bits>>(45-3*k))&7]
+			var v int
+			if r11 {
+				if mult == 0 {
+					v = base*8 + 4 + mod
+				} else {
+					v = base*8 + 4 + mod*mult*8
+				}
+				v = (min(max(v, 0), 2047)*255 + 1023) / 2047
+			} else {
+				v = base + mod*mult
+			}
+			out[y*4+x][channel] = clampByte(v)
+		}
+	}
+}
+
+// expand565 expands a packed RGB565 color to RGBA8.
+func expand565(c uint16) [4]byte {
+	r, g, b := byte(c>>11)&31, byte(c>>5)&63, byte(c)&31
+	return [4]byte{r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2, 255}
+}
+
+// expand5 expands a 5-bit channel to 8 bits.
+func expand5(v int) int { return v<<3 | v>>2 }
+
+// expand6 expands a 6-bit channel to 8 bits.
+func expand6(v int) int { return v<<2 | v>>4 }
+
+// expand7 expands a 7-bit channel to 8 bits.
+func expand7(v int) int { return v<<1 | v>>6 }
+
+// signExtend3 sign-extends a 3-bit two's complement value.
+func signExtend3(v byte) int {
+	if v&4 != 0 {
+		return int(v) - 8
+	}
+	return int(v)
+}
+
+// offsetColor adds d to every channel of an RGB color.
+func offsetColor(c [3]int, d int) [3]int {
+	return [3]int{c[0] + d, c[1] + d, c[2] + d}
+}
+
+// clampByte clamps an integer to [0, 255].
+func clampByte(v int) byte {
+	return byte(min(max(v, 0), 255))
+}
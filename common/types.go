@@ -23,10 +23,34 @@ type TextureStagingData struct {
 	Width uint32
 	// Height is the height of the texture in pixels. This is required to correctly create the GPU texture and interpret the pixel data.
 	Height uint32
+	// GenerateMipmaps requests a full mip chain, generated from the base level when the texture is uploaded.
+	// Without it the texture has a single level and sampler LOD clamps and anisotropy have no effect.
+	// Only RGBA8 textures can be generated; it is ignored for other formats and when MipLevels is set.
+	GenerateMipmaps bool
+	// Format is the GPU format of Pixels and MipLevels. The zero value (TextureFormatUndefined) means RGBA8UnormSrgb.
+	// Block-compressed formats hold whole blocks, row-major.
+	Format wgpu.TextureFormat
+	// MipLevels holds precomputed mip levels below the base level, largest first, in the same format as Pixels.
+	MipLevels [][]byte
+}
+
+// CubemapStagingData holds the data for a cube texture pending GPU upload.
+// Faces are ordered +X, -X, +Y, -Y, +Z, -Z, each a square image of Size×Size pixels.
+type CubemapStagingData struct {
+	// Faces holds the base level of each face, in the texture format.
+	Faces [6][]byte
+	// Size is the width and height of each face of the base level in pixels.
+	Size uint32
+	// Format is the GPU format of Faces and MipLevels. The zero value (TextureFormatUndefined) means RGBA8UnormSrgb.
+	Format wgpu.TextureFormat
+	// MipLevels holds precomputed mip levels below the base level, largest first, each with all six faces.
+	MipLevels [][6][]byte
 }
 
 // SamplerStagingData holds the configuration for a sampler binding pending GPU creation.
 // This is primarily used in the BindGroupProvider to stage sampler data before creating the GPU sampler and bind group.
+// Every field is used as given, so the zero value is a nearest-filtered, repeating sampler that only samples the
+// base mip level; set the filters and LodMaxClamp (e.g. 32) explicitly for linear, mipmapped sampling.
 type SamplerStagingData struct {
 	// AddressModeU, AddressModeV, AddressModeW specify the addressing mode for texture coordinates outside the [0, 1] range in each dimension (U, V, W).
 	AddressModeU, AddressModeV, AddressModeW wgpu.AddressMode
@@ -73,6 +97,15 @@ type ImportedMaterial struct {
 
 	// MetallicRoughnessTexture holds embedded metallic/roughness data (if present).
 	MetallicRoughnessTexture *ImportedTexture
+
+	// AlphaMode is the alpha rendering mode: "OPAQUE" (default), "MASK" or "BLEND".
+	AlphaMode string
+
+	// AlphaCutoff is the alpha threshold for MASK mode (default 0.5).
+	AlphaCutoff float32
+
+	// DoubleSided indicates that back faces should be rendered.
+	DoubleSided bool
 }
 
 // ImportedTexture represents texture data extracted from a model file.
@@ -101,11 +134,21 @@ type ImportedTexture struct {
 	// When non-nil, these values override the default linear/repeat settings
 	// used during material GPU initialization.
 	SamplerData *SamplerStagingData
+
+	// DisableMipmaps uploads only the base level of this texture during material GPU initialization.
+	// Material textures get a generated mip chain by default. The glTF importer sets this when the
+	// texture's sampler uses a non-mipmapped minification filter.
+	DisableMipmaps bool
+
+	// Fallback is an alternative image used when this one cannot be staged, e.g. the PNG/JPEG
+	// source of a glTF KHR_texture_basisu texture when no KTX2 transcoder is available.
+	Fallback *ImportedTexture
 }
 
 // Decode decodes the texture to raw RGBA pixel data.
 // Uses either embedded Data bytes or loads from Path on disk.
-// Supports PNG and JPEG formats.
+// Supports PNG and JPEG formats, and the base level of KTX2 containers whose format
+// has a CPU decoder (see DecodeBlocks).
 // Reference: https://pkg.go.dev/image
 //
 // Returns:
@@ -118,27 +161,112 @@ func (t *ImportedTexture) Decode() ([]byte, uint32, uint32, error) {
 		return nil, 0, 0, fmt.Errorf("texture is nil")
 	}
 
-	var img image.Image
-	var err error
+	data, err := t.bytes()
+	if err != nil {
+		return nil, 0, 0, err
+	}
 
-	if len(t.Data) > 0 {
-		img, _, err = image.Decode(bytes.NewReader(t.Data))
+	if IsKTX2(data) {
+		k, err := ParseKTX2(data)
 		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to decode embedded image: %w", err)
+			return nil, 0, 0, err
 		}
-	} else if t.Path != "" {
-		file, fileErr := os.Open(t.Path)
-		if fileErr != nil {
-			return nil, 0, 0, fmt.Errorf("failed to open texture file %s: %w", t.Path, fileErr)
+		staged, err := k.Stage(func(format wgpu.TextureFormat) bool {
+			return format == wgpu.TextureFormatRGBA8Unorm || format == wgpu.TextureFormatRGBA8UnormSrgb
+		}, nil)
+		if err != nil {
+			return nil, 0, 0, err
 		}
-		defer file.Close()
+		t.Width = int(staged.Width)
+		t.Height = int(staged.Height)
+		return staged.Pixels, staged.Width, staged.Height, nil
+	}
 
-		img, _, err = image.Decode(file)
+	return t.decodeImage(data)
+}
+
+// Stage prepares the texture for GPU upload. KTX2 containers keep their stored format and
+// mip levels when the device supports the format, are handed to the transcoder when they hold
+// Basis Universal data, and are decoded to RGBA8 otherwise. PNG and JPEG images
+// are decoded to RGBA8. If the texture cannot be staged and has a Fallback, the fallback is
+// staged instead.
+//
+// Parameters:
+//   - supported: reports whether the device can sample a format; nil accepts every format
+//   - transcoder: the transcoder for Basis Universal KTX2 payloads, may be nil
+//
+// Returns:
+//   - TextureStagingData: the staging data
+//   - error: error if the texture cannot be read, decoded or transcoded
+func (t *ImportedTexture) Stage(supported func(wgpu.TextureFormat) bool, transcoder KTX2Transcoder) (TextureStagingData, error) {
+	if t == nil {
+		return TextureStagingData{}, fmt.Errorf("texture is nil")
+	}
+
+	data, err := t.bytes()
+	if err != nil {
+		return TextureStagingData{}, err
+	}
+	if !IsKTX2(data) {
+		pixels, width, height, err := t.decodeImage(data)
 		if err != nil {
-			return nil, 0, 0, fmt.Errorf("failed to decode texture file %s: %w", t.Path, err)
+			return TextureStagingData{}, err
+		}
+		return TextureStagingData{Pixels: pixels, Width: width, Height: height}, nil
+	}
+
+	k, err := ParseKTX2(data)
+	if err != nil {
+		return TextureStagingData{}, err
+	}
+	staged, err := k.Stage(supported, transcoder)
+	if err != nil {
+		if t.Fallback != nil {
+			return t.Fallback.Stage(supported, transcoder)
+		}
+		return TextureStagingData{}, err
+	}
+	t.Width = int(staged.Width)
+	t.Height = int(staged.Height)
+	return staged, nil
+}
+
+// bytes returns the embedded Data, or reads the file at Path.
+//
+// Returns:
+//   - []byte: the encoded texture bytes
+//   - error: error if the texture has no source or the file cannot be read
+func (t *ImportedTexture) bytes() ([]byte, error) {
+	if len(t.Data) > 0 {
+		return t.Data, nil
+	}
+	if t.Path == "" {
+		return nil, fmt.Errorf("texture has neither data nor path")
+	}
+	data, err := os.ReadFile(t.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open texture file %s: %w", t.Path, err)
+	}
+	return data, nil
+}
+
+// decodeImage decodes PNG or JPEG bytes to RGBA pixels and records the size on the texture.
+//
+// Parameters:
+//   - data: the encoded image bytes
+//
+// Returns:
+//   - []byte: raw RGBA pixel data (4 bytes per pixel, row-major order)
+//   - uint32: texture width in pixels
+//   - uint32: texture height in pixels
+//   - error: error if decoding fails
+func (t *ImportedTexture) decodeImage(data []byte) ([]byte, uint32, uint32, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		if len(t.Data) > 0 {
+			return nil, 0, 0, fmt.Errorf("failed to decode embedded image: %w", err)
 		}
-	} else {
-		return nil, 0, 0, fmt.Errorf("texture has neither data nor path")
+		return nil, 0, 0, fmt.Errorf("failed to decode texture file %s: %w", t.Path, err)
 	}
 
 	bounds := img.Bounds()
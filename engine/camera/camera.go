@@ -10,6 +10,18 @@ import (
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
 )
 
+// Projection selects how a camera projects view space onto the screen.
+type Projection int
+
+const (
+	// ProjectionPerspective projects with a field of view, so distant objects appear smaller.
+	ProjectionPerspective Projection = iota
+
+	// ProjectionOrthographic projects a box of OrthoSize world units high and OrthoSize × aspect
+	// wide, so objects keep their size at any distance. Used for 2D, sprites and top-down views.
+	ProjectionOrthographic
+)
+
 // cameraCount is an atomic counter used to generate unique bind group provider names for each camera instance.
 var cameraCount atomic.Uint64
 
@@ -18,10 +30,12 @@ type cameraImpl struct {
 
 	up [3]float32
 
-	fov    float32
-	aspect float32
-	near   float32
-	far    float32
+	projection Projection
+	fov        float32
+	orthoSize  float32
+	aspect     float32
+	near       float32
+	far        float32
 
 	viewMatrix              [16]float32
 	projectionMatrix        [16]float32
@@ -33,8 +47,8 @@ type cameraImpl struct {
 }
 
 // Camera defines the interface for the camera system.
-// The camera holds perspective settings and computes view/projection matrices
-// from an attached CameraController each frame via Update().
+// The camera holds perspective or orthographic projection settings and computes view/projection
+// matrices from an attached CameraController each frame via Update().
 type Camera interface {
 	// Up returns the camera's up vector.
 	//
@@ -42,12 +56,25 @@ type Camera interface {
 	//   - x, y, z: up vector components
 	Up() (x, y, z float32)
 
-	// Fov returns the field of view in radians.
+	// Projection returns whether the camera uses a perspective or orthographic projection.
+	//
+	// Returns:
+	//   - Projection: the projection type
+	Projection() Projection
+
+	// Fov returns the field of view in radians. Only used by perspective projections.
 	//
 	// Returns:
 	//   - float32: field of view in radians
 	Fov() float32
 
+	// OrthoSize returns the height of the view in world units. Only used by orthographic
+	// projections; the width is the height times the aspect ratio.
+	//
+	// Returns:
+	//   - float32: the view height in world units
+	OrthoSize() float32
+
 	// Aspect returns the aspect ratio (width / height).
 	//
 	// Returns:
@@ -92,6 +119,20 @@ type Camera interface {
 	//   - [16]float32: the inverse projection matrix
 	InverseProjectionMatrix() [16]float32
 
+	// ScreenPointToRay converts a window position into a world-space ray through the scene.
+	// The ray starts on the near plane and points away from the camera, so it works for
+	// picking with the current view and projection matrices.
+	//
+	// Parameters:
+	//   - x: the horizontal position in pixels from the left edge
+	//   - y: the vertical position in pixels from the top edge
+	//   - width: the viewport width in pixels
+	//   - height: the viewport height in pixels
+	//
+	// Returns:
+	//   - common.Ray: the world-space ray with a normalized direction
+	ScreenPointToRay(x, y, width, height float32) common.Ray
+
 	// Controller returns the attached CameraController.
 	// Returns nil if no controller is attached.
 	//
@@ -117,12 +158,24 @@ type Camera interface {
 	//   - x, y, z: up vector components
 	SetUp(x, y, z float32)
 
+	// SetProjection sets the projection type and recomputes matrices.
+	//
+	// Parameters:
+	//   - projection: the projection type
+	SetProjection(projection Projection)
+
 	// SetFov sets the field of view in radians and recomputes matrices.
 	//
 	// Parameters:
 	//   - fov: field of view in radians
 	SetFov(fov float32)
 
+	// SetOrthoSize sets the height of the orthographic view in world units and recomputes matrices.
+	//
+	// Parameters:
+	//   - size: the view height in world units
+	SetOrthoSize(size float32)
+
 	// SetAspect sets the aspect ratio (width / height) and recomputes matrices.
 	//
 	// Parameters:
@@ -170,6 +223,7 @@ func NewCamera(options ...CameraBuilderOption) Camera {
 		mu:                   &sync.Mutex{},
 		up:                   [3]float32{0, 1, 0},
 		fov:                  45.0 * (math.Pi / 180.0), // radians
+		orthoSize:            10.0,
 		aspect:               1.0,
 		near:                 0.1,
 		far:                  100.0,
@@ -196,12 +250,24 @@ func (c *cameraImpl) Up() (x, y, z float32) {
 	return c.up[0], c.up[1], c.up[2]
 }
 
+func (c *cameraImpl) Projection() Projection {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.projection
+}
+
 func (c *cameraImpl) Fov() float32 {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	return c.fov
 }
 
+func (c *cameraImpl) OrthoSize() float32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.orthoSize
+}
+
 func (c *cameraImpl) Aspect() float32 {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -244,6 +310,22 @@ func (c *cameraImpl) InverseProjectionMatrix() [16]float32 {
 	return c.inverseProjectionMatrix
 }
 
+func (c *cameraImpl) ScreenPointToRay(x, y, width, height float32) common.Ray {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// WebGPU NDC: x and y in [-1, 1] with +y up, depth in [0, 1] from near to far.
+	ndcX := 2*x/width - 1
+	ndcY := 1 - 2*y/height
+
+	var invView [16]float32
+	common.Invert4(invView[:], c.viewMatrix[:])
+
+	near := common.TransformPoint(invView[:], common.TransformPoint(c.inverseProjectionMatrix[:], [3]float32{ndcX, ndcY, 0}))
+	far := common.TransformPoint(invView[:], common.TransformPoint(c.inverseProjectionMatrix[:], [3]float32{ndcX, ndcY, 1}))
+	return common.NewRay(near, [3]float32{far[0] - near[0], far[1] - near[1], far[2] - near[2]})
+}
+
 func (c *cameraImpl) SetUp(x, y, z float32) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -251,6 +333,13 @@ func (c *cameraImpl) SetUp(x, y, z float32) {
 	c.updateMatrices()
 }
 
+func (c *cameraImpl) SetProjection(projection Projection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.projection = projection
+	c.updateMatrices()
+}
+
 func (c *cameraImpl) SetFov(fov float32) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -258,6 +347,13 @@ func (c *cameraImpl) SetFov(fov float32) {
 	c.updateMatrices()
 }
 
+func (c *cameraImpl) SetOrthoSize(size float32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.orthoSize = size
+	c.updateMatrices()
+}
+
 func (c *cameraImpl) SetAspect(aspect float32) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -329,9 +425,18 @@ func (c *cameraImpl) updateMatrices() {
 		c.up[0], c.up[1], c.up[2],
 	)
 
-	common.Perspective(c.projectionMatrix[:],
-		c.fov, c.aspect, c.near, c.far,
-	)
+	switch c.projection {
+	case ProjectionOrthographic:
+		halfHeight := c.orthoSize / 2
+		halfWidth := halfHeight * c.aspect
+		common.Orthographic(c.projectionMatrix[:],
+			-halfWidth, halfWidth, -halfHeight, halfHeight, c.near, c.far,
+		)
+	default:
+		common.Perspective(c.projectionMatrix[:],
+			c.fov, c.aspect, c.near, c.far,
+		)
+	}
 
 	common.Mul4(c.viewProjectionMatrix[:], c.projectionMatrix[:], c.viewMatrix[:])
 	common.Invert4(c.inverseProjectionMatrix[:], c.projectionMatrix[:])
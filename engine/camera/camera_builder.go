@@ -34,6 +34,35 @@ func WithFov(fov float32) CameraBuilderOption {
 	}
 }
 
+// WithProjection sets whether the camera uses a perspective or orthographic projection.
+// Default: ProjectionPerspective.
+//
+// Parameters:
+//   - projection: the projection type
+//
+// Returns:
+//   - CameraBuilderOption: a function that sets the camera's projection type
+func WithProjection(projection Projection) CameraBuilderOption {
+	return func(c *cameraImpl) {
+		c.projection = projection
+		c.updateMatrices()
+	}
+}
+
+// WithOrthoSize sets the height of the orthographic view in world units. Default: 10.
+//
+// Parameters:
+//   - size: the view height in world units
+//
+// Returns:
+//   - CameraBuilderOption: a function that sets the camera's orthographic view height
+func WithOrthoSize(size float32) CameraBuilderOption {
+	return func(c *cameraImpl) {
+		c.orthoSize = size
+		c.updateMatrices()
+	}
+}
+
 // WithAspect sets the camera's aspect ratio (width / height).
 //
 // Parameters:
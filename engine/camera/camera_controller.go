@@ -81,6 +81,13 @@ type orbitCameraController interface {
 	//   - float32: maximum zoom distance
 	MaxRadius() float32
 
+	// SetRadiusBounds sets the minimum and maximum orbit radius and re-clamps the current radius.
+	//
+	// Parameters:
+	//   - min: minimum zoom distance
+	//   - max: maximum zoom distance
+	SetRadiusBounds(min, max float32)
+
 	// Azimuth returns the current horizontal angle around the Y axis.
 	//
 	// Returns:
@@ -117,23 +124,48 @@ type orbitCameraController interface {
 	//   - float32: maximum elevation in radians
 	MaxElevation() float32
 
+	// SetElevationBounds sets the minimum and maximum elevation angles and re-clamps the current elevation.
+	//
+	// Parameters:
+	//   - min: minimum vertical angle in radians
+	//   - max: maximum vertical angle in radians
+	SetElevationBounds(min, max float32)
+
 	// OrbitSpeed returns the keyboard orbit speed in radians per step.
 	//
 	// Returns:
 	//   - float32: radians per orbit call
 	OrbitSpeed() float32
 
+	// SetOrbitSpeed sets the keyboard orbit speed.
+	//
+	// Parameters:
+	//   - speed: radians per orbit call
+	SetOrbitSpeed(speed float32)
+
 	// MouseSensitivity returns the mouse drag sensitivity multiplier.
 	//
 	// Returns:
 	//   - float32: multiplier for mouse movement
 	MouseSensitivity() float32
 
+	// SetMouseSensitivity sets the mouse drag sensitivity multiplier.
+	//
+	// Parameters:
+	//   - sensitivity: multiplier for mouse movement
+	SetMouseSensitivity(sensitivity float32)
+
 	// ZoomSpeed returns the zoom speed multiplier.
 	//
 	// Returns:
 	//   - float32: multiplier for zoom input
 	ZoomSpeed() float32
+
+	// SetZoomSpeed sets the zoom speed multiplier.
+	//
+	// Parameters:
+	//   - speed: multiplier for zoom input
+	SetZoomSpeed(speed float32)
 }
 
 // planarCameraController defines planar translation control methods.
@@ -167,4 +199,10 @@ type planarCameraController interface {
 	// Returns:
 	//   - float32: multiplier for pan input
 	PanSpeed() float32
+
+	// SetPanSpeed sets the pan speed multiplier.
+	//
+	// Parameters:
+	//   - speed: multiplier for pan input
+	SetPanSpeed(speed float32)
 }
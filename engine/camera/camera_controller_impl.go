@@ -255,6 +255,20 @@ func (cc *cameraControllerImpl) MaxRadius() float32 {
 	return cc.maxRadius
 }
 
+func (cc *cameraControllerImpl) SetRadiusBounds(min, max float32) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.minRadius = min
+	cc.maxRadius = max
+	if cc.radius < cc.minRadius {
+		cc.radius = cc.minRadius
+	}
+	if cc.radius > cc.maxRadius {
+		cc.radius = cc.maxRadius
+	}
+	cc.updatePosition()
+}
+
 func (cc *cameraControllerImpl) Azimuth() float32 {
 	cc.mu.Lock()
 	defer cc.mu.Unlock()
@@ -299,24 +313,56 @@ func (cc *cameraControllerImpl) MaxElevation() float32 {
 	return cc.maxElevation
 }
 
+func (cc *cameraControllerImpl) SetElevationBounds(min, max float32) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.minElevation = min
+	cc.maxElevation = max
+	if cc.elevation < cc.minElevation {
+		cc.elevation = cc.minElevation
+	}
+	if cc.elevation > cc.maxElevation {
+		cc.elevation = cc.maxElevation
+	}
+	cc.updatePosition()
+}
+
 func (cc *cameraControllerImpl) OrbitSpeed() float32 {
 	cc.mu.Lock()
 	defer cc.mu.Unlock()
 	return cc.orbitSpeed
 }
 
+func (cc *cameraControllerImpl) SetOrbitSpeed(speed float32) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.orbitSpeed = speed
+}
+
 func (cc *cameraControllerImpl) MouseSensitivity() float32 {
 	cc.mu.Lock()
 	defer cc.mu.Unlock()
 	return cc.mouseSensitivity
 }
 
+func (cc *cameraControllerImpl) SetMouseSensitivity(sensitivity float32) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.mouseSensitivity = sensitivity
+}
+
 func (cc *cameraControllerImpl) ZoomSpeed() float32 {
 	cc.mu.Lock()
 	defer cc.mu.Unlock()
 	return cc.zoomSpeed
 }
 
+func (cc *cameraControllerImpl) SetZoomSpeed(speed float32) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.zoomSpeed = speed
+}
+
 // --- planarCameraController implementation ---
 
 func (cc *cameraControllerImpl) PanRight(delta float32) {
@@ -369,3 +415,9 @@ func (cc *cameraControllerImpl) PanSpeed() float32 {
 	defer cc.mu.Unlock()
 	return cc.panSpeed
 }
+
+func (cc *cameraControllerImpl) SetPanSpeed(speed float32) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.panSpeed = speed
+}
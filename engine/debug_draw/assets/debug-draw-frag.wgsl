@@ -0,0 +1,12 @@
+// Debug draw fragment shader
+//
+// Outputs the interpolated line color. Alpha blends over the scene.
+
+struct FragmentInput {
+    @location(0) color: vec4<f32>,
+};
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    return in.color;
+}
@@ -0,0 +1,34 @@
+// Debug draw vertex shader
+//
+// Pulls line vertices from a storage buffer by vertex index, so the debug lines of a
+// frame need no vertex buffer. Each vertex carries a world-space position and an RGBA
+// color packed as four unorm8 values.
+//
+// Bind group layout:
+//   @group(0) debug_draw — CameraUniform, DebugVertex storage array
+
+//@oxy:include camera
+
+struct DebugVertex {
+    position: vec3<f32>,
+    color: u32,
+};
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) color: vec4<f32>,
+};
+
+//@oxy:group 0 0 storage_uniform camera camera
+//@oxy:provider 0 1 debug_draw
+@group(0) @binding(1) var<storage, read> vertices: array<DebugVertex>;
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> VertexOutput {
+    let v = vertices[index];
+
+    var out: VertexOutput;
+    out.position = camera.view_proj * vec4<f32>(v.position, 1.0);
+    out.color = unpack4x8unorm(v.color);
+    return out;
+}
@@ -0,0 +1,574 @@
+package debug_draw
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/camera"
+	"github.com/Carmen-Shannon/oxy-go/engine/game_object"
+	"github.com/Carmen-Shannon/oxy-go/engine/light"
+	"github.com/Carmen-Shannon/oxy-go/engine/model"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+)
+
+// defaultMaxVertices is the default capacity of the debug vertex buffer, 32768 lines.
+const defaultMaxVertices = 65536
+
+// defaultSegments is the default number of line segments used for circles.
+const defaultSegments = 24
+
+// maxDrawsPerFrame is the number of Draw calls a debug draw accepts between two BeginFrame
+// calls, each with its own camera uniform and vertex buffer.
+const maxDrawsPerFrame = 16
+
+// debugLine is one queued world-space line segment.
+type debugLine struct {
+	from      [3]float32
+	to        [3]float32
+	color     uint32 // RGBA packed as four unorm8 values, red in the low byte
+	depthTest bool
+	expires   time.Time // zero for lines kept until the next tick or frame, see BeginTick and BeginFrame
+	tick      bool      // submitted between BeginTick and EndTick
+	drawn     bool      // drawn by at least one Draw
+}
+
+// drawConfig holds the options of a single debug primitive.
+type drawConfig struct {
+	lifetime  time.Duration
+	depthTest bool
+	transform *[16]float32
+	segments  int
+}
+
+// DrawOption is a functional option for configuring a single debug primitive.
+type DrawOption func(*drawConfig)
+
+// WithLifetime keeps the primitive on screen for the given duration. With the default of 0,
+// a primitive submitted during a tick is drawn until the next tick and any other primitive
+// until the frame after it is first drawn, which suits primitives re-submitted every tick or
+// render callback. Longer lifetimes suit one-off events.
+//
+// Parameters:
+//   - lifetime: how long the primitive is drawn
+//
+// Returns:
+//   - DrawOption: option function to apply
+func WithLifetime(lifetime time.Duration) DrawOption {
+	return func(c *drawConfig) {
+		c.lifetime = max(lifetime, 0)
+	}
+}
+
+// WithDepthTest sets whether the primitive is hidden behind scene geometry (default true).
+// Primitives without depth testing are drawn over everything.
+//
+// Parameters:
+//   - enabled: true to depth test the primitive
+//
+// Returns:
+//   - DrawOption: option function to apply
+func WithDepthTest(enabled bool) DrawOption {
+	return func(c *drawConfig) {
+		c.depthTest = enabled
+	}
+}
+
+// WithTransform transforms the primitive's points by a column-major matrix before they are
+// queued, e.g. to draw a box in an object's local space.
+//
+// Parameters:
+//   - m: the column-major transform
+//
+// Returns:
+//   - DrawOption: option function to apply
+func WithTransform(m [16]float32) DrawOption {
+	return func(c *drawConfig) {
+		c.transform = &m
+	}
+}
+
+// WithSegments sets the number of line segments used for each circle of a sphere, circle or
+// cone (default 24, minimum 3).
+//
+// Parameters:
+//   - segments: the number of segments per circle
+//
+// Returns:
+//   - DrawOption: option function to apply
+func WithSegments(segments int) DrawOption {
+	return func(c *drawConfig) {
+		c.segments = max(segments, 3)
+	}
+}
+
+// debugDrawImpl is the implementation of the DebugDraw interface.
+type debugDrawImpl struct {
+	mu *sync.Mutex
+
+	maxVertices int
+	enabled     bool
+	ticking     bool // between BeginTick and EndTick
+	lines       []debugLine
+
+	r          renderer.Renderer                       // renderer the GPU resources were created on
+	slots      []bind_group_provider.BindGroupProvider // one camera uniform and vertex buffer per draw of a frame
+	slot       int                                     // slot of the next draw of the current frame
+	vertexData []byte                                  // reused staging buffer of packed DebugVertex values
+}
+
+// DebugDraw defines the interface for queuing debug line primitives and drawing them in a
+// single batch at the end of a scene's draw.
+//
+// Primitives can be submitted from any goroutine, typically from the engine's tick or render
+// callback. Each one is expanded into world-space line segments when submitted, and every
+// frame all queued segments are packed into one dynamic storage buffer and drawn with two
+// draws: one depth tested against the scene and one overlaid on top of it. Colors are linear
+// RGBA with alpha blending.
+//
+// A DebugDraw is drawn by the scenes it is attached to with scene.WithDebugDraw, each with its
+// own camera. Every draw of a frame writes its own camera uniform and vertex buffer, so one
+// DebugDraw can be shared by several scenes, e.g. the views of a split screen.
+type DebugDraw interface {
+	// Line queues a line segment.
+	//
+	// Parameters:
+	//   - from: the start point
+	//   - to: the end point
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	Line(from, to [3]float32, color [4]float32, opts ...DrawOption)
+
+	// Arrow queues a line with an arrow head at its end. The head is a fifth of the arrow's length.
+	//
+	// Parameters:
+	//   - from: the tail point
+	//   - to: the head point
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	Arrow(from, to [3]float32, color [4]float32, opts ...DrawOption)
+
+	// WireBox queues the twelve edges of an axis-aligned box. Use WithTransform for an oriented box.
+	//
+	// Parameters:
+	//   - center: the center of the box
+	//   - halfExtents: the half size of the box along each axis
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	WireBox(center, halfExtents [3]float32, color [4]float32, opts ...DrawOption)
+
+	// WireSphere queues a sphere as three circles around the X, Y and Z axes.
+	//
+	// Parameters:
+	//   - center: the center of the sphere
+	//   - radius: the radius of the sphere
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	WireSphere(center [3]float32, radius float32, color [4]float32, opts ...DrawOption)
+
+	// Circle queues a circle in the plane perpendicular to a normal.
+	//
+	// Parameters:
+	//   - center: the center of the circle
+	//   - normal: the normal of the circle's plane
+	//   - radius: the radius of the circle
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	Circle(center, normal [3]float32, radius float32, color [4]float32, opts ...DrawOption)
+
+	// WireCone queues a cone as its base circle and four lines from the apex to the base.
+	//
+	// Parameters:
+	//   - apex: the tip of the cone
+	//   - direction: the direction from the apex to the center of the base
+	//   - length: the distance from the apex to the base
+	//   - halfAngle: the angle between the cone's axis and its side, in radians
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	WireCone(apex, direction [3]float32, length, halfAngle float32, color [4]float32, opts ...DrawOption)
+
+	// Grid queues a square grid in the XZ plane.
+	//
+	// Parameters:
+	//   - center: the center of the grid
+	//   - size: the side length of the grid
+	//   - divisions: the number of cells along each side
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	Grid(center [3]float32, size float32, divisions int, color [4]float32, opts ...DrawOption)
+
+	// Axes queues an axes gizmo of a transform: red, green and blue lines along its X, Y and Z
+	// axes from its origin. The transform's scale scales the lines.
+	//
+	// Parameters:
+	//   - transform: the column-major transform, e.g. an object's world matrix
+	//   - size: the length of each axis line before scaling
+	//   - opts: variadic list of DrawOption functions
+	Axes(transform [16]float32, size float32, opts ...DrawOption)
+
+	// Marker queues a three-axis cross marking a point.
+	//
+	// Parameters:
+	//   - position: the marked point
+	//   - size: the length of each line of the cross
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	Marker(position [3]float32, size float32, color [4]float32, opts ...DrawOption)
+
+	// Frustum queues the twelve edges of a frustum, with corners found by intersecting its planes.
+	// Frustums with an infinite far plane cannot be drawn.
+	//
+	// Parameters:
+	//   - f: the frustum, e.g. from common.ExtractFrustumFromMatrix
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	Frustum(f common.Frustum, color [4]float32, opts ...DrawOption)
+
+	// Light queues a visualization of a light in its own color: a range sphere for a point light,
+	// the outer cone and inner cone circle for a spot light, and a unit arrow along the direction
+	// for a directional light. Disabled lights are skipped.
+	//
+	// Parameters:
+	//   - l: the light
+	//   - opts: variadic list of DrawOption functions
+	Light(l light.Light, opts ...DrawOption)
+
+	// BoundingSphere queues the bounding sphere of an object's model, as used by frustum culling
+	// and raycasts. Skinned models use their animator's bounding sphere when it has one.
+	//
+	// Parameters:
+	//   - obj: the game object
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	BoundingSphere(obj game_object.GameObject, color [4]float32, opts ...DrawOption)
+
+	// Skeleton queues the bones of a skeleton in its bind pose: a line from each bone to its
+	// parent and a marker at each joint. Animated poses are computed on the GPU and are not drawn.
+	//
+	// Parameters:
+	//   - skel: the skeleton, e.g. from model.Model.Skeleton
+	//   - modelMatrix: the column-major world matrix of the skinned object
+	//   - color: the linear RGBA color
+	//   - opts: variadic list of DrawOption functions
+	Skeleton(skel *model.Skeleton, modelMatrix [16]float32, color [4]float32, opts ...DrawOption)
+
+	// LineCount returns the number of queued line segments.
+	//
+	// Returns:
+	//   - int: the number of queued lines
+	LineCount() int
+
+	// Clear drops every queued primitive, including those with a remaining lifetime.
+	Clear()
+
+	// Enabled returns whether primitives are queued and drawn.
+	//
+	// Returns:
+	//   - bool: true if debug drawing is enabled
+	Enabled() bool
+
+	// SetEnabled enables or disables debug drawing. While disabled, submitted primitives are
+	// dropped and nothing is drawn, so debug calls can stay in place in release builds.
+	//
+	// Parameters:
+	//   - enabled: true to enable debug drawing
+	SetEnabled(enabled bool)
+
+	// BeginTick starts a tick. It drops the primitives without a lifetime submitted during the
+	// previous tick, and primitives submitted until EndTick are drawn by every frame until the
+	// next tick, so primitives re-submitted every tick do not flicker when the frame rate is
+	// higher than the tick rate. The engine calls it before the ECS systems and tick callback
+	// of every tick.
+	BeginTick()
+
+	// EndTick ends the tick started by BeginTick. The engine calls it after physics is stepped.
+	EndTick()
+
+	// BeginFrame starts a frame. It drops the primitives without a lifetime submitted outside a
+	// tick that have already been drawn, and the primitives whose lifetime has ended, and makes
+	// the camera slots of the previous frame's draws available again. The engine calls it once
+	// per frame, after the scenes are drawn and before the render callback; call it once per
+	// frame when drawing without the engine.
+	BeginFrame()
+
+	// Draw writes the queued lines to the GPU and draws them into the current render pass with
+	// the camera's view-projection. Lines stay queued until BeginTick or BeginFrame drops them,
+	// so several scenes can draw the same lines in one frame. Pipelines and buffers are created
+	// on the first call. Lines beyond the vertex capacity are not drawn.
+	//
+	// Parameters:
+	//   - r: the renderer recording the current render pass
+	//   - cam: the camera to draw with
+	//
+	// Returns:
+	//   - error: an error if GPU resource creation or a draw fails, or if the frame already has
+	//     maxDrawsPerFrame draws
+	Draw(r renderer.Renderer, cam camera.Camera) error
+
+	// Release releases the GPU resources. They are recreated by the next Draw.
+	Release()
+}
+
+var _ DebugDraw = &debugDrawImpl{}
+
+// NewDebugDraw creates a new DebugDraw. GPU resources are created by the first Draw.
+//
+// Parameters:
+//   - opts: variadic list of DebugDrawBuilderOption functions to configure the debug draw
+//
+// Returns:
+//   - DebugDraw: a new DebugDraw instance
+func NewDebugDraw(opts ...DebugDrawBuilderOption) DebugDraw {
+	d := &debugDrawImpl{
+		mu:          &sync.Mutex{},
+		maxVertices: defaultMaxVertices,
+		enabled:     true,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+func (d *debugDrawImpl) Line(from, to [3]float32, color [4]float32, opts ...DrawOption) {
+	d.push(opts, color, from, to)
+}
+
+func (d *debugDrawImpl) Arrow(from, to [3]float32, color [4]float32, opts ...DrawOption) {
+	shaft := sub3(to, from)
+	length := length3(shaft)
+	if length == 0 {
+		return
+	}
+	dir := scale3(shaft, 1/length)
+	u, v := basis(dir)
+	headLength := length * 0.2
+	back := add3(to, scale3(dir, -headLength))
+	spread := headLength * 0.35
+
+	d.push(opts, color,
+		from, to,
+		to, add3(back, scale3(u, spread)),
+		to, add3(back, scale3(u, -spread)),
+		to, add3(back, scale3(v, spread)),
+		to, add3(back, scale3(v, -spread)),
+	)
+}
+
+func (d *debugDrawImpl) WireBox(center, halfExtents [3]float32, color [4]float32, opts ...DrawOption) {
+	var corners [8][3]float32
+	for i := range corners {
+		for axis := range 3 {
+			sign := float32(-1)
+			if i&(1<<axis) != 0 {
+				sign = 1
+			}
+			corners[i][axis] = center[axis] + sign*halfExtents[axis]
+		}
+	}
+	d.push(opts, color, boxEdges(corners)...)
+}
+
+func (d *debugDrawImpl) WireSphere(center [3]float32, radius float32, color [4]float32, opts ...DrawOption) {
+	cfg := newDrawConfig(opts)
+	var points [][3]float32
+	points = appendCircle(points, center, [3]float32{1, 0, 0}, [3]float32{0, 1, 0}, radius, cfg.segments)
+	points = appendCircle(points, center, [3]float32{0, 1, 0}, [3]float32{0, 0, 1}, radius, cfg.segments)
+	points = appendCircle(points, center, [3]float32{0, 0, 1}, [3]float32{1, 0, 0}, radius, cfg.segments)
+	d.pushConfig(cfg, color, points)
+}
+
+func (d *debugDrawImpl) Circle(center, normal [3]float32, radius float32, color [4]float32, opts ...DrawOption) {
+	cfg := newDrawConfig(opts)
+	u, v := basis(normalize3(normal))
+	d.pushConfig(cfg, color, appendCircle(nil, center, u, v, radius, cfg.segments))
+}
+
+func (d *debugDrawImpl) WireCone(apex, direction [3]float32, length, halfAngle float32, color [4]float32, opts ...DrawOption) {
+	cfg := newDrawConfig(opts)
+	d.pushConfig(cfg, color, appendCone(nil, apex, normalize3(direction), length, halfAngle, cfg.segments))
+}
+
+func (d *debugDrawImpl) Grid(center [3]float32, size float32, divisions int, color [4]float32, opts ...DrawOption) {
+	divisions = max(divisions, 1)
+	half := size / 2
+	step := size / float32(divisions)
+
+	points := make([][3]float32, 0, (divisions+1)*4)
+	for i := range divisions + 1 {
+		offset := -half + float32(i)*step
+		points = append(points,
+			[3]float32{center[0] + offset, center[1], center[2] - half},
+			[3]float32{center[0] + offset, center[1], center[2] + half},
+			[3]float32{center[0] - half, center[1], center[2] + offset},
+			[3]float32{center[0] + half, center[1], center[2] + offset},
+		)
+	}
+	d.push(opts, color, points...)
+}
+
+func (d *debugDrawImpl) Axes(transform [16]float32, size float32, opts ...DrawOption) {
+	origin := common.TransformPoint(transform[:], [3]float32{})
+	d.push(opts, [4]float32{1, 0, 0, 1}, origin, common.TransformPoint(transform[:], [3]float32{size, 0, 0}))
+	d.push(opts, [4]float32{0, 1, 0, 1}, origin, common.TransformPoint(transform[:], [3]float32{0, size, 0}))
+	d.push(opts, [4]float32{0, 0, 1, 1}, origin, common.TransformPoint(transform[:], [3]float32{0, 0, size}))
+}
+
+func (d *debugDrawImpl) Marker(position [3]float32, size float32, color [4]float32, opts ...DrawOption) {
+	d.push(opts, color, appendMarker(nil, position, size)...)
+}
+
+func (d *debugDrawImpl) Frustum(f common.Frustum, color [4]float32, opts ...DrawOption) {
+	// Corner i sits on the right plane when bit 0 is set, the top plane for bit 1 and the far
+	// plane for bit 2, matching the corner order of boxEdges.
+	var corners [8][3]float32
+	for i := range corners {
+		x, y, z := common.FrustumLeft, common.FrustumBottom, common.FrustumNear
+		if i&1 != 0 {
+			x = common.FrustumRight
+		}
+		if i&2 != 0 {
+			y = common.FrustumTop
+		}
+		if i&4 != 0 {
+			z = common.FrustumFar
+		}
+		corner, ok := intersectPlanes(f.Planes[x], f.Planes[y], f.Planes[z])
+		if !ok {
+			return
+		}
+		corners[i] = corner
+	}
+	d.push(opts, color, boxEdges(corners)...)
+}
+
+func (d *debugDrawImpl) LineCount() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.lines)
+}
+
+func (d *debugDrawImpl) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lines = d.lines[:0]
+}
+
+func (d *debugDrawImpl) Enabled() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.enabled
+}
+
+func (d *debugDrawImpl) SetEnabled(enabled bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.enabled = enabled
+	if !enabled {
+		d.lines = d.lines[:0]
+	}
+}
+
+func (d *debugDrawImpl) BeginTick() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.prune(func(ln debugLine) bool { return ln.tick })
+	d.ticking = true
+}
+
+func (d *debugDrawImpl) EndTick() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ticking = false
+}
+
+func (d *debugDrawImpl) BeginFrame() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.prune(func(ln debugLine) bool { return !ln.tick && ln.drawn })
+	d.slot = 0
+}
+
+// newDrawConfig applies the options of a primitive over the defaults.
+//
+// Parameters:
+//   - opts: the primitive's options
+//
+// Returns:
+//   - drawConfig: the resolved options
+func newDrawConfig(opts []DrawOption) drawConfig {
+	cfg := drawConfig{depthTest: true, segments: defaultSegments}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// push queues line segments given as pairs of points.
+//
+// Parameters:
+//   - opts: the primitive's options
+//   - color: the linear RGBA color
+//   - points: the segment end points, two per line
+func (d *debugDrawImpl) push(opts []DrawOption, color [4]float32, points ...[3]float32) {
+	d.pushConfig(newDrawConfig(opts), color, points)
+}
+
+// pushConfig queues line segments given as pairs of points with resolved options, applying
+// the transform option and packing the color.
+//
+// Parameters:
+//   - cfg: the resolved options
+//   - color: the linear RGBA color
+//   - points: the segment end points, two per line
+func (d *debugDrawImpl) pushConfig(cfg drawConfig, color [4]float32, points [][3]float32) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.enabled {
+		return
+	}
+
+	var expires time.Time
+	if cfg.lifetime > 0 {
+		expires = time.Now().Add(cfg.lifetime)
+	}
+	packed := packColor(color)
+
+	for i := 0; i+1 < len(points); i += 2 {
+		from, to := points[i], points[i+1]
+		if cfg.transform != nil {
+			from = common.TransformPoint(cfg.transform[:], from)
+			to = common.TransformPoint(cfg.transform[:], to)
+		}
+		d.lines = append(d.lines, debugLine{
+			from:      from,
+			to:        to,
+			color:     packed,
+			depthTest: cfg.depthTest,
+			expires:   expires,
+			tick:      d.ticking && expires.IsZero(),
+		})
+	}
+}
+
+// prune drops the lines without a lifetime that match a predicate, and the lines whose
+// lifetime has ended. Caller must hold d.mu.
+//
+// Parameters:
+//   - done: reports whether a line without a lifetime is dropped
+func (d *debugDrawImpl) prune(done func(ln debugLine) bool) {
+	now := time.Now()
+	kept := d.lines[:0]
+	for _, ln := range d.lines {
+		if ln.expires.IsZero() {
+			if !done(ln) {
+				kept = append(kept, ln)
+			}
+		} else if now.Before(ln.expires) {
+			kept = append(kept, ln)
+		}
+	}
+	clear(d.lines[len(kept):])
+	d.lines = kept
+}
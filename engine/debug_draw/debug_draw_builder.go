@@ -0,0 +1,31 @@
+package debug_draw
+
+// DebugDrawBuilderOption is a function that configures a DebugDraw instance during construction.
+type DebugDrawBuilderOption func(*debugDrawImpl)
+
+// WithMaxVertices is an option builder that sets the capacity of the debug vertex buffer, two
+// vertices per line. Lines beyond it are not drawn. Defaults to 65536.
+//
+// Parameters:
+//   - n: the maximum number of vertices drawn per frame
+//
+// Returns:
+//   - DebugDrawBuilderOption: a function that applies the vertex capacity option to a debugDrawImpl
+func WithMaxVertices(n int) DebugDrawBuilderOption {
+	return func(d *debugDrawImpl) {
+		d.maxVertices = max(n, 2) &^ 1
+	}
+}
+
+// WithEnabled is an option builder that sets whether debug drawing starts enabled. Defaults to true.
+//
+// Parameters:
+//   - enabled: true to queue and draw primitives
+//
+// Returns:
+//   - DebugDrawBuilderOption: a function that applies the enabled option to a debugDrawImpl
+func WithEnabled(enabled bool) DebugDrawBuilderOption {
+	return func(d *debugDrawImpl) {
+		d.enabled = enabled
+	}
+}
@@ -0,0 +1,213 @@
+package debug_draw
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/camera"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/pipeline"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+const (
+	// DepthPipelineKey is the pipeline key of the depth-tested debug line pass.
+	DepthPipelineKey = "debug_draw_depth"
+
+	// OverlayPipelineKey is the pipeline key of the debug line pass drawn over the scene.
+	OverlayPipelineKey = "debug_draw_overlay"
+)
+
+// Bindings of the debug draw shader's group 0.
+const (
+	cameraBinding = 0
+	vertexBinding = 1
+)
+
+// vertexSize is the size of a DebugVertex in bytes: a vec3<f32> position and a packed u32 color.
+const vertexSize = 16
+
+func (d *debugDrawImpl) Draw(r renderer.Renderer, cam camera.Camera) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.enabled || len(d.lines) == 0 || r == nil || cam == nil {
+		return nil
+	}
+	if err := d.initGPU(r); err != nil {
+		return err
+	}
+	bgp, err := d.nextSlot(r)
+	if err != nil {
+		return err
+	}
+
+	// Depth-tested lines go first and overlay lines after them, so each pipeline draws one
+	// contiguous range of the buffer.
+	maxLines := d.maxVertices / 2
+	data := d.vertexData[:0]
+	var depthLines, overlayLines int
+	for i := range d.lines {
+		if ln := &d.lines[i]; ln.depthTest && depthLines < maxLines {
+			data = appendVertex(appendVertex(data, ln.from, ln.color), ln.to, ln.color)
+			ln.drawn = true
+			depthLines++
+		}
+	}
+	for i := range d.lines {
+		if ln := &d.lines[i]; !ln.depthTest && depthLines+overlayLines < maxLines {
+			data = appendVertex(appendVertex(data, ln.from, ln.color), ln.to, ln.color)
+			ln.drawn = true
+			overlayLines++
+		}
+	}
+	d.vertexData = data
+
+	// Buffer writes are applied before the frame's commands run, so each draw of the frame
+	// needs buffers of its own or the last draw's camera would be used by all of them.
+	uniform := camera.GPUCameraUniform{ViewProj: cam.ViewProjectionMatrix()}
+	r.WriteBuffers([]bind_group_provider.BufferWrite{
+		{Provider: bgp, Binding: cameraBinding, Offset: 0, Data: uniform.Marshal()},
+		{Provider: bgp, Binding: vertexBinding, Offset: 0, Data: data},
+	})
+
+	bindGroups := []bind_group_provider.BindGroupProvider{bgp}
+	if depthLines > 0 {
+		if err := r.DrawProceduralRange(DepthPipelineKey, 0, uint32(depthLines*2), bindGroups); err != nil {
+			return fmt.Errorf("debug draw failed: %w", err)
+		}
+	}
+	if overlayLines > 0 {
+		if err := r.DrawProceduralRange(OverlayPipelineKey, uint32(depthLines*2), uint32(overlayLines*2), bindGroups); err != nil {
+			return fmt.Errorf("debug draw failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (d *debugDrawImpl) Release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.releaseGPU()
+}
+
+// initGPU registers the debug line pipelines on first use, or again when drawing with a
+// different renderer, whose draw slots replace those of the previous one. Caller must hold d.mu.
+//
+// Parameters:
+//   - r: the renderer to create the resources on
+//
+// Returns:
+//   - error: an error if pipeline registration fails
+func (d *debugDrawImpl) initGPU(r renderer.Renderer) error {
+	if d.r == r {
+		return nil
+	}
+	d.releaseGPU()
+
+	// RegisterPipelines skips keys that are already cached, so every DebugDraw shares the
+	// pipelines registered by the first.
+	err := r.RegisterPipelines(
+		newLinePipeline(DepthPipelineKey, true),
+		newLinePipeline(OverlayPipelineKey, false),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register debug draw pipelines: %w", err)
+	}
+	d.r = r
+	return nil
+}
+
+// nextSlot returns the bind group of the frame's next draw, creating it when the frame has
+// more draws than any frame before. Caller must hold d.mu.
+//
+// Parameters:
+//   - r: the renderer to create the bind group on
+//
+// Returns:
+//   - bind_group_provider.BindGroupProvider: the bind group holding the draw's camera uniform and vertices
+//   - error: an error if the frame already has maxDrawsPerFrame draws or bind group creation fails
+func (d *debugDrawImpl) nextSlot(r renderer.Renderer) (bind_group_provider.BindGroupProvider, error) {
+	if d.slot >= maxDrawsPerFrame {
+		return nil, fmt.Errorf("too many debug draws in one frame (max %d), BeginFrame must be called every frame", maxDrawsPerFrame)
+	}
+	if d.slot == len(d.slots) {
+		vert := r.Pipeline(DepthPipelineKey).Shader(shader.ShaderTypeVertex)
+		bgp := bind_group_provider.NewBindGroupProvider(fmt.Sprintf("debug_draw_%d", d.slot))
+		sizes := map[int]uint64{vertexBinding: uint64(d.maxVertices) * vertexSize}
+		if err := r.InitBindGroup(bgp, vert.BindGroupLayoutDescriptor(0), nil, sizes); err != nil {
+			bgp.Release()
+			return nil, fmt.Errorf("failed to init debug draw bind group: %w", err)
+		}
+		d.slots = append(d.slots, bgp)
+	}
+	bgp := d.slots[d.slot]
+	d.slot++
+	return bgp, nil
+}
+
+// releaseGPU releases the bind groups of the draw slots and their buffers. Caller must hold d.mu.
+func (d *debugDrawImpl) releaseGPU() {
+	for _, bgp := range d.slots {
+		bgp.Release()
+	}
+	d.slots = nil
+	d.slot = 0
+	d.r = nil
+}
+
+// newLinePipeline creates a pipeline drawing the debug line list with alpha blending. Neither
+// pipeline writes depth, so debug lines never hide scene geometry or each other.
+//
+// Parameters:
+//   - key: the pipeline key
+//   - depthTest: whether lines are hidden behind scene geometry
+//
+// Returns:
+//   - pipeline.Pipeline: the pipeline to register
+func newLinePipeline(key string, depthTest bool) pipeline.Pipeline {
+	return pipeline.NewPipeline(key, pipeline.PipelineTypeRender,
+		pipeline.WithVertexShader(shader.NewShaderFromSource(key+"_vert", shader.ShaderTypeVertex, VertexSource)),
+		pipeline.WithFragmentShader(shader.NewShaderFromSource(key+"_frag", shader.ShaderTypeFragment, FragmentSource)),
+		pipeline.WithTopology(wgpu.PrimitiveTopologyLineList),
+		pipeline.WithDepthTestEnabled(depthTest),
+		pipeline.WithDepthWriteEnabled(false),
+		pipeline.WithBlendEnabled(true),
+		pipeline.WithCullMode(wgpu.CullModeNone),
+	)
+}
+
+// appendVertex appends one packed DebugVertex.
+//
+// Parameters:
+//   - buf: the buffer to append to
+//   - p: the world-space position
+//   - color: the packed color
+//
+// Returns:
+//   - []byte: the buffer with the vertex appended
+func appendVertex(buf []byte, p [3]float32, color uint32) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(p[0]))
+	buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(p[1]))
+	buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(p[2]))
+	return binary.LittleEndian.AppendUint32(buf, color)
+}
+
+// packColor packs a linear RGBA color into four unorm8 values with red in the low byte,
+// matching WGSL's unpack4x8unorm.
+//
+// Parameters:
+//   - c: the color, each channel clamped to [0, 1]
+//
+// Returns:
+//   - uint32: the packed color
+func packColor(c [4]float32) uint32 {
+	var packed uint32
+	for i, v := range c {
+		packed |= uint32(clamp(v, 0, 1)*255+0.5) << (8 * i)
+	}
+	return packed
+}
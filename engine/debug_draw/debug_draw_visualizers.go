@@ -0,0 +1,313 @@
+package debug_draw
+
+import (
+	"math"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/game_object"
+	"github.com/Carmen-Shannon/oxy-go/engine/light"
+	"github.com/Carmen-Shannon/oxy-go/engine/model"
+)
+
+func (d *debugDrawImpl) Light(l light.Light, opts ...DrawOption) {
+	if l == nil || !l.Enabled() {
+		return
+	}
+	cfg := newDrawConfig(opts)
+	color := lightColor(l.Color())
+	pos := l.Position()
+
+	switch l.Type() {
+	case light.LightTypePoint:
+		var points [][3]float32
+		r := l.Range()
+		points = appendCircle(points, pos, [3]float32{1, 0, 0}, [3]float32{0, 1, 0}, r, cfg.segments)
+		points = appendCircle(points, pos, [3]float32{0, 1, 0}, [3]float32{0, 0, 1}, r, cfg.segments)
+		points = appendCircle(points, pos, [3]float32{0, 0, 1}, [3]float32{1, 0, 0}, r, cfg.segments)
+		d.pushConfig(cfg, color, points)
+
+	case light.LightTypeSpot:
+		// The cones are stored as cosines of their half-angles.
+		dir := normalize3(l.Direction())
+		outer := float32(math.Acos(float64(clamp(l.OuterCone(), -1, 1))))
+		inner := float32(math.Acos(float64(clamp(l.InnerCone(), -1, 1))))
+		points := appendCone(nil, pos, dir, l.Range(), outer, cfg.segments)
+		u, v := basis(dir)
+		base := add3(pos, scale3(dir, l.Range()))
+		points = appendCircle(points, base, u, v, l.Range()*float32(math.Tan(float64(inner))), cfg.segments)
+		d.pushConfig(cfg, color, points)
+
+	case light.LightTypeDirectional:
+		dir := normalize3(l.Direction())
+		to := add3(pos, dir)
+		u, v := basis(dir)
+		back := add3(to, scale3(dir, -0.2))
+		d.pushConfig(cfg, color, [][3]float32{
+			pos, to,
+			to, add3(back, scale3(u, 0.07)),
+			to, add3(back, scale3(u, -0.07)),
+			to, add3(back, scale3(v, 0.07)),
+			to, add3(back, scale3(v, -0.07)),
+		})
+	}
+}
+
+func (d *debugDrawImpl) BoundingSphere(obj game_object.GameObject, color [4]float32, opts ...DrawOption) {
+	if obj == nil || obj.Model() == nil {
+		return
+	}
+	mdl := obj.Model()
+	radius := mdl.BoundingRadius()
+	if mdl.Skinned() {
+		if anim := obj.Animator(); anim != nil && anim.BoundingRadius() > 0 {
+			radius = anim.BoundingRadius()
+		}
+	}
+	if radius <= 0 {
+		return
+	}
+
+	world := obj.WorldMatrix()
+	center := [3]float32{world[12], world[13], world[14]}
+	d.WireSphere(center, radius*maxAxisScale(world), color, opts...)
+}
+
+func (d *debugDrawImpl) Skeleton(skel *model.Skeleton, modelMatrix [16]float32, color [4]float32, opts ...DrawOption) {
+	if skel == nil || len(skel.Bones) == 0 {
+		return
+	}
+
+	// The bind pose world transform of each bone is the inverse of its inverse bind matrix.
+	joints := make([][3]float32, len(skel.Bones))
+	var bind [16]float32
+	for i, bone := range skel.Bones {
+		if !common.Invert4(bind[:], bone.InverseBindMatrix[:]) {
+			common.Identity(bind[:])
+		}
+		joints[i] = common.TransformPoint(modelMatrix[:], [3]float32{bind[12], bind[13], bind[14]})
+	}
+
+	var points [][3]float32
+	var total float32
+	var bones int
+	for i, bone := range skel.Bones {
+		if bone.ParentIndex < 0 || int(bone.ParentIndex) >= len(joints) {
+			continue
+		}
+		parent := joints[bone.ParentIndex]
+		points = append(points, parent, joints[i])
+		total += length3(sub3(joints[i], parent))
+		bones++
+	}
+
+	// Joint markers are sized relative to the average bone, so they scale with the model.
+	if bones > 0 && total > 0 {
+		size := total / float32(bones) * 0.2
+		for _, joint := range joints {
+			points = appendMarker(points, joint, size)
+		}
+	}
+	d.pushConfig(newDrawConfig(opts), color, points)
+}
+
+// lightColor turns a light color into an opaque line color, scaled down so its brightest
+// channel is at most 1.
+//
+// Parameters:
+//   - c: the light color
+//
+// Returns:
+//   - [4]float32: the line color
+func lightColor(c [3]float32) [4]float32 {
+	peak := max(c[0], c[1], c[2])
+	if peak > 1 {
+		c = scale3(c, 1/peak)
+	}
+	return [4]float32{c[0], c[1], c[2], 1}
+}
+
+// appendCircle appends the segments of a circle spanned by two orthonormal axes.
+//
+// Parameters:
+//   - points: the points to append to
+//   - center: the center of the circle
+//   - u: the first axis of the circle's plane
+//   - v: the second axis of the circle's plane
+//   - radius: the radius
+//   - segments: the number of segments
+//
+// Returns:
+//   - [][3]float32: the points with the circle's segments appended
+func appendCircle(points [][3]float32, center, u, v [3]float32, radius float32, segments int) [][3]float32 {
+	point := func(i int) [3]float32 {
+		angle := 2 * math.Pi * float64(i) / float64(segments)
+		cos, sin := float32(math.Cos(angle))*radius, float32(math.Sin(angle))*radius
+		return add3(center, add3(scale3(u, cos), scale3(v, sin)))
+	}
+	prev := point(0)
+	for i := 1; i <= segments; i++ {
+		next := point(i)
+		points = append(points, prev, next)
+		prev = next
+	}
+	return points
+}
+
+// appendCone appends the segments of a cone: its base circle and four lines from the apex.
+//
+// Parameters:
+//   - points: the points to append to
+//   - apex: the tip of the cone
+//   - dir: the unit direction from the apex to the base
+//   - length: the distance from the apex to the base
+//   - halfAngle: the half-angle of the cone in radians
+//   - segments: the number of segments of the base circle
+//
+// Returns:
+//   - [][3]float32: the points with the cone's segments appended
+func appendCone(points [][3]float32, apex, dir [3]float32, length, halfAngle float32, segments int) [][3]float32 {
+	u, v := basis(dir)
+	base := add3(apex, scale3(dir, length))
+	radius := length * float32(math.Tan(float64(halfAngle)))
+
+	points = appendCircle(points, base, u, v, radius, segments)
+	return append(points,
+		apex, add3(base, scale3(u, radius)),
+		apex, add3(base, scale3(u, -radius)),
+		apex, add3(base, scale3(v, radius)),
+		apex, add3(base, scale3(v, -radius)),
+	)
+}
+
+// appendMarker appends the three lines of a cross centered on a point.
+//
+// Parameters:
+//   - points: the points to append to
+//   - p: the marked point
+//   - size: the length of each line
+//
+// Returns:
+//   - [][3]float32: the points with the cross appended
+func appendMarker(points [][3]float32, p [3]float32, size float32) [][3]float32 {
+	h := size / 2
+	return append(points,
+		[3]float32{p[0] - h, p[1], p[2]}, [3]float32{p[0] + h, p[1], p[2]},
+		[3]float32{p[0], p[1] - h, p[2]}, [3]float32{p[0], p[1] + h, p[2]},
+		[3]float32{p[0], p[1], p[2] - h}, [3]float32{p[0], p[1], p[2] + h},
+	)
+}
+
+// boxEdges returns the twelve edges of a box from its corners, where bits 0, 1 and 2 of a
+// corner's index select its side along the first, second and third axis.
+//
+// Parameters:
+//   - c: the eight corners
+//
+// Returns:
+//   - [][3]float32: the edge end points, two per edge
+func boxEdges(c [8][3]float32) [][3]float32 {
+	points := make([][3]float32, 0, 24)
+	for i := range 8 {
+		for axis := range 3 {
+			bit := 1 << axis
+			if i&bit == 0 {
+				points = append(points, c[i], c[i|bit])
+			}
+		}
+	}
+	return points
+}
+
+// intersectPlanes returns the point shared by three planes of the form n·x + d = 0.
+//
+// Parameters:
+//   - a: the first plane
+//   - b: the second plane
+//   - c: the third plane
+//
+// Returns:
+//   - [3]float32: the intersection point
+//   - bool: false if two of the planes are parallel
+func intersectPlanes(a, b, c common.Plane) ([3]float32, bool) {
+	bc := cross3(b.Normal, c.Normal)
+	denom := dot3(a.Normal, bc)
+	if float32(math.Abs(float64(denom))) < 1e-6 {
+		return [3]float32{}, false
+	}
+	ca := cross3(c.Normal, a.Normal)
+	ab := cross3(a.Normal, b.Normal)
+	p := add3(add3(scale3(bc, -a.Distance), scale3(ca, -b.Distance)), scale3(ab, -c.Distance))
+	return scale3(p, 1/denom), true
+}
+
+// basis returns two unit vectors perpendicular to a unit direction and to each other.
+//
+// Parameters:
+//   - dir: the unit direction
+//
+// Returns:
+//   - [3]float32: the first perpendicular axis
+//   - [3]float32: the second perpendicular axis
+func basis(dir [3]float32) ([3]float32, [3]float32) {
+	up := [3]float32{0, 1, 0}
+	if float32(math.Abs(float64(dir[1]))) > 0.99 {
+		up = [3]float32{1, 0, 0}
+	}
+	u := normalize3(cross3(up, dir))
+	return u, cross3(dir, u)
+}
+
+// maxAxisScale returns the length of the longest basis vector of a column-major matrix.
+//
+// Parameters:
+//   - m: the world matrix
+//
+// Returns:
+//   - float32: the largest axis scale
+func maxAxisScale(m [16]float32) float32 {
+	var out float32
+	for col := range 3 {
+		out = max(out, length3([3]float32{m[col*4], m[col*4+1], m[col*4+2]}))
+	}
+	return out
+}
+
+func add3(a, b [3]float32) [3]float32 {
+	return [3]float32{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func sub3(a, b [3]float32) [3]float32 {
+	return [3]float32{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func scale3(a [3]float32, s float32) [3]float32 {
+	return [3]float32{a[0] * s, a[1] * s, a[2] * s}
+}
+
+func dot3(a, b [3]float32) float32 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func cross3(a, b [3]float32) [3]float32 {
+	return [3]float32{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func length3(a [3]float32) float32 {
+	return float32(math.Sqrt(float64(dot3(a, a))))
+}
+
+func normalize3(a [3]float32) [3]float32 {
+	l := length3(a)
+	if l == 0 {
+		return a
+	}
+	return scale3(a, 1/l)
+}
+
+func clamp(x, lo, hi float32) float32 {
+	return min(max(x, lo), hi)
+}
@@ -0,0 +1,16 @@
+package debug_draw
+
+import (
+	_ "embed"
+)
+
+// VertexSource is the vertex shader of the debug draw pass. It pulls each line vertex from the
+// DebugVertex storage buffer by vertex index, with no vertex buffer.
+//
+//go:embed assets/debug-draw-vert.wgsl
+var VertexSource string
+
+// FragmentSource is the fragment shader of the debug draw pass. It outputs the line color.
+//
+//go:embed assets/debug-draw-frag.wgsl
+var FragmentSource string
@@ -0,0 +1,173 @@
+package ecs
+
+import "reflect"
+
+// componentStore is the type-erased view of a component storage used by the World.
+type componentStore interface {
+	has(e Entity) bool
+	remove(e Entity)
+	len() int
+	entity(i int) Entity
+}
+
+// storage is a sparse set holding every component of type T in a dense array.
+// Removal swaps the last element into the freed slot, so the arrays stay packed.
+type storage[T any] struct {
+	dense    []T
+	entities []Entity // entities[i] owns dense[i]
+	sparse   map[Entity]int
+}
+
+func (s *storage[T]) add(e Entity, c T) *T {
+	if i, ok := s.sparse[e]; ok {
+		s.dense[i] = c
+		return &s.dense[i]
+	}
+	s.sparse[e] = len(s.dense)
+	s.dense = append(s.dense, c)
+	s.entities = append(s.entities, e)
+	return &s.dense[len(s.dense)-1]
+}
+
+func (s *storage[T]) get(e Entity) *T {
+	i, ok := s.sparse[e]
+	if !ok {
+		return nil
+	}
+	return &s.dense[i]
+}
+
+func (s *storage[T]) has(e Entity) bool {
+	_, ok := s.sparse[e]
+	return ok
+}
+
+func (s *storage[T]) remove(e Entity) {
+	i, ok := s.sparse[e]
+	if !ok {
+		return
+	}
+	last := len(s.dense) - 1
+	if i != last {
+		s.dense[i] = s.dense[last]
+		s.entities[i] = s.entities[last]
+		s.sparse[s.entities[i]] = i
+	}
+	var zero T
+	s.dense[last] = zero // release references held by the component
+	s.dense = s.dense[:last]
+	s.entities = s.entities[:last]
+	delete(s.sparse, e)
+}
+
+func (s *storage[T]) len() int {
+	return len(s.dense)
+}
+
+func (s *storage[T]) entity(i int) Entity {
+	return s.entities[i]
+}
+
+// storeOf returns the storage for component type T, creating it if requested.
+//
+// Parameters:
+//   - w: the world owning the storage
+//   - create: whether to create the storage if it does not exist
+//
+// Returns:
+//   - *storage[T]: the storage, or nil if it does not exist and create is false
+func storeOf[T any](w *world, create bool) *storage[T] {
+	key := reflect.TypeFor[T]()
+	if st, ok := w.stores[key]; ok {
+		return st.(*storage[T])
+	}
+	if !create {
+		return nil
+	}
+	st := &storage[T]{sparse: make(map[Entity]int)}
+	w.stores[key] = st
+	return st
+}
+
+// Add sets the component of type T on an entity, replacing any existing one.
+// The returned pointer is valid until the next structural change to T's storage.
+// Panics if the entity is not alive.
+//
+// Parameters:
+//   - w: the World owning the entity
+//   - e: the entity
+//   - c: the component value
+//
+// Returns:
+//   - *T: a pointer to the stored component
+func Add[T any](w World, e Entity, c T) *T {
+	wi := w.impl()
+	if !wi.Alive(e) {
+		panic("ecs: cannot add a component to a dead entity")
+	}
+	return storeOf[T](wi, true).add(e, c)
+}
+
+// Get returns the component of type T on an entity.
+// The returned pointer is valid until the next structural change to T's storage.
+//
+// Parameters:
+//   - w: the World owning the entity
+//   - e: the entity
+//
+// Returns:
+//   - *T: a pointer to the stored component, or nil if the entity has none
+func Get[T any](w World, e Entity) *T {
+	st := storeOf[T](w.impl(), false)
+	if st == nil {
+		return nil
+	}
+	return st.get(e)
+}
+
+// Has returns whether an entity has a component of type T.
+//
+// Parameters:
+//   - w: the World owning the entity
+//   - e: the entity
+//
+// Returns:
+//   - bool: true if the component is present
+func Has[T any](w World, e Entity) bool {
+	st := storeOf[T](w.impl(), false)
+	return st != nil && st.has(e)
+}
+
+// Remove removes the component of type T from an entity. Called during a query, the
+// removal is deferred until the outermost query returns.
+//
+// Parameters:
+//   - w: the World owning the entity
+//   - e: the entity
+func Remove[T any](w World, e Entity) {
+	wi := w.impl()
+	st := storeOf[T](wi, false)
+	if st == nil || !st.has(e) {
+		return
+	}
+	if wi.iterating > 0 {
+		wi.pendingRemove = append(wi.pendingRemove, pendingRemoval{store: st, e: e})
+		return
+	}
+	st.remove(e)
+}
+
+// Count returns the number of entities that have a component of type T.
+//
+// Parameters:
+//   - w: the World to inspect
+//
+// Returns:
+//   - int: the component count
+func Count[T any](w World) int {
+	st := storeOf[T](w.impl(), false)
+	if st == nil {
+		return 0
+	}
+	return st.len()
+}
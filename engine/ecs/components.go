@@ -0,0 +1,54 @@
+package ecs
+
+import (
+	"github.com/Carmen-Shannon/oxy-go/engine/light"
+	"github.com/Carmen-Shannon/oxy-go/engine/model"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/pipeline"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+)
+
+// Transform is the built-in local transform component. For entities with a Renderable it
+// drives the Scene object's local transform; for entities with only a LightSource it
+// positions the light.
+type Transform struct {
+	Position      [3]float32
+	Rotation      [3]float32 // Euler angles in radians
+	Scale         [3]float32
+	RotationSpeed [3]float32 // radians per second, integrated on the GPU
+	Parent        Entity     // NoEntity for roots; Position/Rotation/Scale are relative to the parent
+}
+
+// NewTransform returns a Transform at the given position with unit scale.
+//
+// Parameters:
+//   - x: the X position
+//   - y: the Y position
+//   - z: the Z position
+//
+// Returns:
+//   - Transform: the transform
+func NewTransform(x, y, z float32) Transform {
+	return Transform{
+		Position: [3]float32{x, y, z},
+		Scale:    [3]float32{1, 1, 1},
+	}
+}
+
+// Renderable is the built-in component that makes an entity visible. On the next scene sync
+// a GameObject with ID uint64(e) is added to the Scene with the given Model and shaders.
+// Changing Model re-creates the object; the shaders and pipeline options are only read when
+// the object is created.
+type Renderable struct {
+	Model           model.Model
+	ComputeShader   shader.Shader
+	VertexShader    shader.Shader
+	FragmentShader  shader.Shader
+	PipelineOptions []pipeline.PipelineBuilderOption
+	Hidden          bool // disables the object without removing it
+}
+
+// LightSource is the built-in component that adds a light to the Scene. The light follows
+// the entity's world position at tick rate.
+type LightSource struct {
+	Light light.Light
+}
@@ -0,0 +1,290 @@
+package ecs
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/game_object"
+	"github.com/Carmen-Shannon/oxy-go/engine/scene"
+)
+
+// Entity identifies an entity in a World. When the World is bound to a Scene, entity values
+// are scene object IDs: the GameObject created for an entity's Renderable has ID uint64(e).
+type Entity uint64
+
+// NoEntity is the zero Entity. It is never allocated and marks "no parent" in Transform.
+const NoEntity Entity = 0
+
+// World owns entities, their components and the systems that update them.
+// Components are stored per type in dense arrays and accessed through the package-level
+// generic functions (Add, Get, Has, Remove, Each, ...). A World is not safe for concurrent
+// use; when attached to an engine it is updated on the simulation goroutine.
+type World interface {
+	// NewEntity allocates a new entity with no components.
+	// When bound to a Scene the ID is reserved from the Scene so it never collides with
+	// objects added to the Scene directly.
+	//
+	// Returns:
+	//   - Entity: the new entity
+	NewEntity() Entity
+
+	// Destroy removes an entity and all of its components. Called during a query, the
+	// destruction is deferred until the outermost query returns. The Scene objects and lights
+	// created for the entity are removed on the next scene sync.
+	//
+	// Parameters:
+	//   - e: the entity to destroy
+	Destroy(e Entity)
+
+	// Alive returns whether the entity exists in the World.
+	//
+	// Parameters:
+	//   - e: the entity to check
+	//
+	// Returns:
+	//   - bool: true if the entity has been created and not destroyed
+	Alive(e Entity) bool
+
+	// Entities returns every live entity in ascending order.
+	//
+	// Returns:
+	//   - []Entity: a new slice of live entities
+	Entities() []Entity
+
+	// Len returns the number of live entities.
+	//
+	// Returns:
+	//   - int: the entity count
+	Len() int
+
+	// AddSystem registers a system. Systems run in the order they are added unless
+	// reordered with After or Before. Panics if a system with the same name is already
+	// registered or if the ordering constraints form a cycle.
+	//
+	// Parameters:
+	//   - s: the system to add
+	//   - opts: ordering constraints relative to other systems
+	AddSystem(s System, opts ...SystemOption)
+
+	// RemoveSystem unregisters a system by name.
+	//
+	// Parameters:
+	//   - name: the system name
+	//
+	// Returns:
+	//   - bool: true if a system was removed
+	RemoveSystem(name string) bool
+
+	// Systems returns the names of the registered systems in execution order.
+	//
+	// Returns:
+	//   - []string: the system names
+	Systems() []string
+
+	// Update runs every system once in execution order, then syncs the built-in components
+	// into the bound Scene. The engine calls this once per tick.
+	//
+	// Parameters:
+	//   - dt: the tick step in seconds
+	Update(dt float32)
+
+	// Scene returns the Scene the World is bound to.
+	//
+	// Returns:
+	//   - scene.Scene: the bound Scene, or nil for a standalone World
+	Scene() scene.Scene
+
+	// Object returns the Scene object created for an entity's Renderable.
+	//
+	// Parameters:
+	//   - e: the entity
+	//
+	// Returns:
+	//   - game_object.GameObject: the object, or nil if the entity has not been synced or the World has no Scene
+	Object(e Entity) game_object.GameObject
+
+	impl() *world
+}
+
+// world implements the World interface.
+type world struct {
+	nextID uint64
+	alive  map[Entity]struct{}
+	stores map[reflect.Type]componentStore
+
+	systems []*systemEntry
+	order   []*systemEntry // systems resolved into execution order
+
+	// Structural removals requested during a query are deferred until the outermost query returns.
+	iterating      int
+	pendingDestroy []Entity
+	pendingRemove  []pendingRemoval
+
+	scn  scene.Scene
+	sync *sceneSync
+}
+
+// pendingRemoval is a component removal deferred until iteration ends.
+type pendingRemoval struct {
+	store componentStore
+	e     Entity
+}
+
+var _ World = &world{}
+
+// NewWorld creates a new World configured with the given options.
+//
+// Parameters:
+//   - options: variadic list of WorldBuilderOption functions to configure the World
+//
+// Returns:
+//   - World: the newly created World
+func NewWorld(options ...WorldBuilderOption) World {
+	w := &world{
+		nextID: 1,
+		alive:  make(map[Entity]struct{}),
+		stores: make(map[reflect.Type]componentStore),
+	}
+
+	for _, opt := range options {
+		opt(w)
+	}
+
+	if w.scn != nil {
+		w.sync = newSceneSync(w.scn)
+	}
+	return w
+}
+
+func (w *world) NewEntity() Entity {
+	var e Entity
+	if w.scn != nil {
+		e = Entity(w.scn.ReserveID())
+	} else {
+		e = Entity(w.nextID)
+		w.nextID++
+	}
+	w.alive[e] = struct{}{}
+	return e
+}
+
+func (w *world) Destroy(e Entity) {
+	if _, ok := w.alive[e]; !ok {
+		return
+	}
+	if w.iterating > 0 {
+		w.pendingDestroy = append(w.pendingDestroy, e)
+		return
+	}
+	for _, st := range w.stores {
+		st.remove(e)
+	}
+	delete(w.alive, e)
+}
+
+func (w *world) Alive(e Entity) bool {
+	_, ok := w.alive[e]
+	return ok
+}
+
+func (w *world) Entities() []Entity {
+	return slices.Sorted(maps.Keys(w.alive))
+}
+
+func (w *world) Len() int {
+	return len(w.alive)
+}
+
+func (w *world) AddSystem(s System, opts ...SystemOption) {
+	if s == nil {
+		panic("ecs: cannot add a nil System")
+	}
+	for _, existing := range w.systems {
+		if existing.system.Name() == s.Name() {
+			panic("ecs: system " + s.Name() + " is already registered")
+		}
+	}
+
+	entry := &systemEntry{system: s}
+	for _, opt := range opts {
+		opt(entry)
+	}
+	w.systems = append(w.systems, entry)
+
+	order, err := resolveSystemOrder(w.systems)
+	if err != nil {
+		w.systems = w.systems[:len(w.systems)-1]
+		panic(err.Error())
+	}
+	w.order = order
+}
+
+func (w *world) RemoveSystem(name string) bool {
+	for i, entry := range w.systems {
+		if entry.system.Name() != name {
+			continue
+		}
+		w.systems = slices.Delete(w.systems, i, i+1)
+		// Removing a node cannot introduce a cycle.
+		w.order, _ = resolveSystemOrder(w.systems)
+		return true
+	}
+	return false
+}
+
+func (w *world) Systems() []string {
+	names := make([]string, len(w.order))
+	for i, entry := range w.order {
+		names[i] = entry.system.Name()
+	}
+	return names
+}
+
+func (w *world) Update(dt float32) {
+	// Iterate a snapshot so systems may add or remove systems while running.
+	for _, entry := range slices.Clone(w.order) {
+		entry.system.Update(w, dt)
+	}
+	if w.sync != nil {
+		w.sync.apply(w)
+	}
+}
+
+func (w *world) Scene() scene.Scene {
+	return w.scn
+}
+
+func (w *world) Object(e Entity) game_object.GameObject {
+	if w.scn == nil {
+		return nil
+	}
+	return w.scn.Get(uint64(e))
+}
+
+func (w *world) impl() *world {
+	return w
+}
+
+// beginIteration marks the start of a query so structural removals are deferred.
+func (w *world) beginIteration() {
+	w.iterating++
+}
+
+// endIteration marks the end of a query and applies deferred removals once the outermost query returns.
+func (w *world) endIteration() {
+	w.iterating--
+	if w.iterating > 0 {
+		return
+	}
+	removals := w.pendingRemove
+	w.pendingRemove = nil
+	for _, r := range removals {
+		r.store.remove(r.e)
+	}
+	destroyed := w.pendingDestroy
+	w.pendingDestroy = nil
+	for _, e := range destroyed {
+		w.Destroy(e)
+	}
+}
@@ -0,0 +1,37 @@
+package ecs
+
+import "github.com/Carmen-Shannon/oxy-go/engine/scene"
+
+// WorldBuilderOption is a functional option for configuring a World.
+// Use the With* functions to create options that are applied directly to the world instance.
+type WorldBuilderOption func(*world)
+
+// WithScene binds the World to a Scene. Entity IDs are reserved from the Scene, and after
+// every Update the built-in Transform, Renderable and LightSource components are synced
+// into Scene objects and lights.
+//
+// Parameters:
+//   - s: the Scene to drive
+//
+// Returns:
+//   - WorldBuilderOption: option function to apply
+func WithScene(s scene.Scene) WorldBuilderOption {
+	return func(w *world) {
+		w.scn = s
+	}
+}
+
+// WithSystem registers a system during construction. Systems run in the order they are
+// registered unless reordered with After or Before.
+//
+// Parameters:
+//   - s: the system to add
+//   - opts: ordering constraints relative to other systems
+//
+// Returns:
+//   - WorldBuilderOption: option function to apply
+func WithSystem(s System, opts ...SystemOption) WorldBuilderOption {
+	return func(w *world) {
+		w.AddSystem(s, opts...)
+	}
+}
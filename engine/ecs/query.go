@@ -0,0 +1,200 @@
+package ecs
+
+import "reflect"
+
+// Filter narrows a query by requiring or excluding a component type without fetching it.
+type Filter struct {
+	key     reflect.Type
+	exclude bool
+}
+
+// With returns a Filter that requires entities to have a component of type T.
+//
+// Returns:
+//   - Filter: the filter
+func With[T any]() Filter {
+	return Filter{key: reflect.TypeFor[T]()}
+}
+
+// Without returns a Filter that excludes entities that have a component of type T.
+//
+// Returns:
+//   - Filter: the filter
+func Without[T any]() Filter {
+	return Filter{key: reflect.TypeFor[T](), exclude: true}
+}
+
+// Query returns the entities matching every filter. With no With filter, all live entities
+// are tested. The result is a snapshot and may be used freely while mutating the World.
+//
+// Parameters:
+//   - w: the World to query
+//   - filters: the component filters to apply
+//
+// Returns:
+//   - []Entity: the matching entities, in storage order
+func Query(w World, filters ...Filter) []Entity {
+	wi := w.impl()
+	var required []componentStore
+	for _, f := range filters {
+		if f.exclude {
+			continue
+		}
+		st, ok := wi.stores[f.key]
+		if !ok {
+			return nil
+		}
+		required = append(required, st)
+	}
+
+	var out []Entity
+	if len(required) == 0 {
+		for _, e := range wi.Entities() {
+			if wi.matches(e, filters) {
+				out = append(out, e)
+			}
+		}
+		return out
+	}
+	wi.each(required, filters, func(e Entity) {
+		out = append(out, e)
+	})
+	return out
+}
+
+// Each calls fn for every entity with a component of type A.
+// Components may be modified through the pointers, which are valid only for the call.
+//
+// Parameters:
+//   - w: the World to query
+//   - fn: the callback receiving the entity and its component
+//   - filters: additional component filters
+func Each[A any](w World, fn func(e Entity, a *A), filters ...Filter) {
+	wi := w.impl()
+	sa := storeOf[A](wi, false)
+	if sa == nil {
+		return
+	}
+	wi.each([]componentStore{sa}, filters, func(e Entity) {
+		fn(e, sa.get(e))
+	})
+}
+
+// Each2 calls fn for every entity with components of types A and B.
+// Components may be modified through the pointers, which are valid only for the call.
+//
+// Parameters:
+//   - w: the World to query
+//   - fn: the callback receiving the entity and its components
+//   - filters: additional component filters
+func Each2[A, B any](w World, fn func(e Entity, a *A, b *B), filters ...Filter) {
+	wi := w.impl()
+	sa, sb := storeOf[A](wi, false), storeOf[B](wi, false)
+	if sa == nil || sb == nil {
+		return
+	}
+	wi.each([]componentStore{sa, sb}, filters, func(e Entity) {
+		fn(e, sa.get(e), sb.get(e))
+	})
+}
+
+// Each3 calls fn for every entity with components of types A, B and C.
+// Components may be modified through the pointers, which are valid only for the call.
+//
+// Parameters:
+//   - w: the World to query
+//   - fn: the callback receiving the entity and its components
+//   - filters: additional component filters
+func Each3[A, B, C any](w World, fn func(e Entity, a *A, b *B, c *C), filters ...Filter) {
+	wi := w.impl()
+	sa, sb, sc := storeOf[A](wi, false), storeOf[B](wi, false), storeOf[C](wi, false)
+	if sa == nil || sb == nil || sc == nil {
+		return
+	}
+	wi.each([]componentStore{sa, sb, sc}, filters, func(e Entity) {
+		fn(e, sa.get(e), sb.get(e), sc.get(e))
+	})
+}
+
+// Each4 calls fn for every entity with components of types A, B, C and D.
+// Components may be modified through the pointers, which are valid only for the call.
+//
+// Parameters:
+//   - w: the World to query
+//   - fn: the callback receiving the entity and its components
+//   - filters: additional component filters
+func Each4[A, B, C, D any](w World, fn func(e Entity, a *A, b *B, c *C, d *D), filters ...Filter) {
+	wi := w.impl()
+	sa, sb, sc, sd := storeOf[A](wi, false), storeOf[B](wi, false), storeOf[C](wi, false), storeOf[D](wi, false)
+	if sa == nil || sb == nil || sc == nil || sd == nil {
+		return
+	}
+	wi.each([]componentStore{sa, sb, sc, sd}, filters, func(e Entity) {
+		fn(e, sa.get(e), sb.get(e), sc.get(e), sd.get(e))
+	})
+}
+
+// each visits every entity present in all of the given stores and passing the filters.
+// The smallest store drives the iteration. Only entities present when iteration starts are
+// visited; removals are deferred until the outermost query returns.
+//
+// Parameters:
+//   - stores: the component stores an entity must be present in
+//   - filters: additional component filters
+//   - fn: the callback receiving each matching entity
+func (w *world) each(stores []componentStore, filters []Filter, fn func(e Entity)) {
+	driver := stores[0]
+	for _, st := range stores[1:] {
+		if st.len() < driver.len() {
+			driver = st
+		}
+	}
+
+	w.beginIteration()
+	defer w.endIteration()
+
+	n := driver.len()
+	for i := range n {
+		e := driver.entity(i)
+		if !hasAll(stores, e) || !w.matches(e, filters) {
+			continue
+		}
+		fn(e)
+	}
+}
+
+// matches reports whether an entity passes every filter.
+//
+// Parameters:
+//   - e: the entity to test
+//   - filters: the filters to apply
+//
+// Returns:
+//   - bool: true if the entity passes all filters
+func (w *world) matches(e Entity, filters []Filter) bool {
+	for _, f := range filters {
+		st, ok := w.stores[f.key]
+		present := ok && st.has(e)
+		if present == f.exclude {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAll reports whether an entity is present in every store.
+//
+// Parameters:
+//   - stores: the stores to check
+//   - e: the entity to test
+//
+// Returns:
+//   - bool: true if the entity is present in all stores
+func hasAll(stores []componentStore, e Entity) bool {
+	for _, st := range stores {
+		if !st.has(e) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+package ecs
+
+import (
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/game_object"
+	"github.com/Carmen-Shannon/oxy-go/engine/light"
+	"github.com/Carmen-Shannon/oxy-go/engine/model"
+	"github.com/Carmen-Shannon/oxy-go/engine/scene"
+)
+
+// maxParentDepth bounds the Transform parent walk for entities without Scene objects, so a
+// parent cycle cannot loop forever.
+const maxParentDepth = 64
+
+// sceneSync mirrors the built-in components of a World into a Scene.
+type sceneSync struct {
+	scn     scene.Scene
+	objects map[Entity]*syncedObject
+	lights  map[Entity]light.Light
+}
+
+// syncedObject is the Scene object created for an entity's Renderable.
+type syncedObject struct {
+	obj       game_object.GameObject
+	model     model.Model
+	transform Transform // the last Transform applied to obj
+}
+
+// newSceneSync creates an empty sync for the given Scene.
+//
+// Parameters:
+//   - s: the Scene to mirror into
+//
+// Returns:
+//   - *sceneSync: the new sync
+func newSceneSync(s scene.Scene) *sceneSync {
+	return &sceneSync{
+		scn:     s,
+		objects: make(map[Entity]*syncedObject),
+		lights:  make(map[Entity]light.Light),
+	}
+}
+
+// apply brings the Scene in line with the World: objects and lights are removed for entities
+// that lost their component (or were destroyed), created for new ones, and updated with
+// changed transforms, parents and visibility.
+//
+// Parameters:
+//   - w: the World to mirror
+func (s *sceneSync) apply(w *world) {
+	renderables := storeOf[Renderable](w, false)
+	transforms := storeOf[Transform](w, false)
+	sources := storeOf[LightSource](w, false)
+
+	for e, so := range s.objects {
+		var r *Renderable
+		if renderables != nil {
+			r = renderables.get(e)
+		}
+		if r == nil || r.Model != so.model {
+			s.scn.Remove(uint64(e))
+			delete(s.objects, e)
+		}
+	}
+
+	if renderables != nil {
+		for i, e := range renderables.entities {
+			r := &renderables.dense[i]
+			if r.Model == nil {
+				continue
+			}
+			so, exists := s.objects[e]
+			if !exists {
+				obj := game_object.NewGameObject(
+					game_object.WithID(uint64(e)),
+					game_object.WithModel(r.Model),
+					game_object.WithEnabled(!r.Hidden),
+				)
+				so = &syncedObject{obj: obj, model: r.Model}
+				if transforms != nil {
+					if t := transforms.get(e); t != nil {
+						applyTransform(obj, t)
+						so.transform = *t
+					}
+				}
+				s.scn.Add(obj, r.ComputeShader, r.VertexShader, r.FragmentShader, r.PipelineOptions...)
+				s.objects[e] = so
+				continue
+			}
+			if so.obj.Enabled() == r.Hidden {
+				so.obj.SetEnabled(!r.Hidden)
+			}
+		}
+	}
+
+	// Parents are linked once every object exists, so creation order does not matter.
+	if transforms != nil {
+		for e, so := range s.objects {
+			t := transforms.get(e)
+			if t == nil {
+				continue
+			}
+			var parent game_object.GameObject
+			if p, ok := s.objects[t.Parent]; ok && t.Parent != e {
+				parent = p.obj
+			}
+			reparented := so.obj.Parent() != parent
+			if reparented {
+				so.obj.SetParent(parent)
+			}
+			if reparented || *t != so.transform {
+				applyTransform(so.obj, t)
+				so.transform = *t
+			}
+		}
+	}
+
+	for e, l := range s.lights {
+		var ls *LightSource
+		if sources != nil {
+			ls = sources.get(e)
+		}
+		if ls == nil || ls.Light != l {
+			s.scn.RemoveLight(l)
+			delete(s.lights, e)
+		}
+	}
+
+	if sources != nil {
+		for i, e := range sources.entities {
+			l := sources.dense[i].Light
+			if l == nil {
+				continue
+			}
+			if _, exists := s.lights[e]; !exists {
+				s.scn.AddLight(l)
+				s.lights[e] = l
+			}
+			if m, ok := s.worldMatrix(w, e, 0); ok {
+				l.SetPosition(m[12], m[13], m[14])
+			}
+		}
+	}
+}
+
+// worldMatrix resolves an entity's world matrix from its Scene object, or by composing its
+// Transform with its parent chain.
+//
+// Parameters:
+//   - w: the World owning the entity
+//   - e: the entity
+//   - depth: the current parent depth
+//
+// Returns:
+//   - [16]float32: the column-major world matrix
+//   - bool: false if the entity has neither a Scene object nor a Transform
+func (s *sceneSync) worldMatrix(w *world, e Entity, depth int) ([16]float32, bool) {
+	if so, ok := s.objects[e]; ok {
+		return so.obj.WorldMatrix(), true
+	}
+	t := Get[Transform](w, e)
+	if t == nil {
+		return [16]float32{}, false
+	}
+
+	var local [16]float32
+	common.BuildModelMatrix(local[:],
+		t.Position[0], t.Position[1], t.Position[2],
+		t.Rotation[0], t.Rotation[1], t.Rotation[2],
+		t.Scale[0], t.Scale[1], t.Scale[2])
+	if t.Parent == NoEntity || t.Parent == e || depth >= maxParentDepth {
+		return local, true
+	}
+	parent, ok := s.worldMatrix(w, t.Parent, depth+1)
+	if !ok {
+		return local, true
+	}
+	var world [16]float32
+	common.Mul4(world[:], parent[:], local[:])
+	return world, true
+}
+
+// applyTransform copies a Transform onto a GameObject's local transform.
+//
+// Parameters:
+//   - obj: the object to update
+//   - t: the transform to apply
+func applyTransform(obj game_object.GameObject, t *Transform) {
+	obj.SetPosition(t.Position[0], t.Position[1], t.Position[2])
+	obj.SetRotation(t.Rotation[0], t.Rotation[1], t.Rotation[2])
+	obj.SetScale(t.Scale[0], t.Scale[1], t.Scale[2])
+	obj.SetRotationSpeed(t.RotationSpeed[0], t.RotationSpeed[1], t.RotationSpeed[2])
+}
@@ -0,0 +1,147 @@
+package ecs
+
+import (
+	"fmt"
+	"slices"
+)
+
+// System is a unit of logic run once per World update.
+type System interface {
+	// Name returns the unique name used to order and remove the system.
+	//
+	// Returns:
+	//   - string: the system name
+	Name() string
+
+	// Update runs the system for one fixed tick.
+	//
+	// Parameters:
+	//   - w: the World being updated
+	//   - dt: the tick step in seconds
+	Update(w World, dt float32)
+}
+
+// systemFunc adapts a plain function to the System interface.
+type systemFunc struct {
+	name string
+	fn   func(w World, dt float32)
+}
+
+var _ System = &systemFunc{}
+
+// NewSystem wraps a function as a named System.
+//
+// Parameters:
+//   - name: the unique system name
+//   - fn: the function to run every update
+//
+// Returns:
+//   - System: the wrapped system
+func NewSystem(name string, fn func(w World, dt float32)) System {
+	return &systemFunc{name: name, fn: fn}
+}
+
+func (s *systemFunc) Name() string {
+	return s.name
+}
+
+func (s *systemFunc) Update(w World, dt float32) {
+	s.fn(w, dt)
+}
+
+// systemEntry is a registered system with its ordering constraints.
+type systemEntry struct {
+	system System
+	after  []string
+	before []string
+}
+
+// SystemOption is a functional option declaring where a system runs relative to others.
+type SystemOption func(*systemEntry)
+
+// After makes the system run after the named systems. Names that are not registered are
+// ignored until a system with that name is added.
+//
+// Parameters:
+//   - names: the systems that must run first
+//
+// Returns:
+//   - SystemOption: option function to apply
+func After(names ...string) SystemOption {
+	return func(e *systemEntry) {
+		e.after = append(e.after, names...)
+	}
+}
+
+// Before makes the system run before the named systems. Names that are not registered are
+// ignored until a system with that name is added.
+//
+// Parameters:
+//   - names: the systems that must run later
+//
+// Returns:
+//   - SystemOption: option function to apply
+func Before(names ...string) SystemOption {
+	return func(e *systemEntry) {
+		e.before = append(e.before, names...)
+	}
+}
+
+// resolveSystemOrder sorts systems topologically by their After/Before constraints.
+// Among systems whose constraints are satisfied, the one registered first runs first, so
+// unconstrained systems keep their declaration order.
+//
+// Parameters:
+//   - systems: the registered systems in declaration order
+//
+// Returns:
+//   - []*systemEntry: the systems in execution order
+//   - error: error if the constraints form a cycle
+func resolveSystemOrder(systems []*systemEntry) ([]*systemEntry, error) {
+	index := make(map[string]int, len(systems))
+	for i, s := range systems {
+		index[s.system.Name()] = i
+	}
+
+	// deps[i] holds the systems that must run before system i.
+	deps := make([][]int, len(systems))
+	for i, s := range systems {
+		for _, name := range s.after {
+			if j, ok := index[name]; ok && j != i {
+				deps[i] = append(deps[i], j)
+			}
+		}
+		for _, name := range s.before {
+			if j, ok := index[name]; ok && j != i {
+				deps[j] = append(deps[j], i)
+			}
+		}
+	}
+
+	done := make([]bool, len(systems))
+	order := make([]*systemEntry, 0, len(systems))
+	for len(order) < len(systems) {
+		next := -1
+		for i := range systems {
+			if done[i] {
+				continue
+			}
+			if !slices.ContainsFunc(deps[i], func(j int) bool { return !done[j] }) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var stuck []string
+			for i, s := range systems {
+				if !done[i] {
+					stuck = append(stuck, s.system.Name())
+				}
+			}
+			return nil, fmt.Errorf("ecs: system ordering cycle between %v", stuck)
+		}
+		done[next] = true
+		order = append(order, systems[next])
+	}
+	return order, nil
+}
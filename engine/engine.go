@@ -1,16 +1,28 @@
 package engine
 
 import (
+	"errors"
+	"image"
 	"log"
+	"slices"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/Carmen-Shannon/oxy-go/engine/debug_draw"
+	"github.com/Carmen-Shannon/oxy-go/engine/ecs"
+	"github.com/Carmen-Shannon/oxy-go/engine/input"
+	"github.com/Carmen-Shannon/oxy-go/engine/physics"
 	"github.com/Carmen-Shannon/oxy-go/engine/profiler"
+	"github.com/Carmen-Shannon/oxy-go/engine/render_graph"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
 	"github.com/Carmen-Shannon/oxy-go/engine/scene"
 	"github.com/Carmen-Shannon/oxy-go/engine/window"
 )
 
+// defaultMaxCatchUpSteps bounds the number of fixed steps run per engine wake.
+const defaultMaxCatchUpSteps = 5
+
 // engine implements the Engine interface.
 // Coordinates engine, render, and window threads.
 type engine struct {
@@ -22,17 +34,42 @@ type engine struct {
 	quitChannel chan struct{}
 	quitOnce    sync.Once // Ensures quitChannel is only closed once
 
-	window window.Window
+	window  window.Window
+	input   input.Input   // sampled once at the start of every tick
+	world   ecs.World     // systems run every tick before the tick callback
+	physics physics.World // stepped every tick after the tick callback
 
 	profiler         *profiler.Profiler
 	profilingEnabled bool
 
-	engineTickRate time.Duration
+	engineTickRate time.Duration // simulation step (nominal in variable mode); guarded by timeMu once running
 	tickCallback   func(deltaTime float32)
-	renderCallback func(deltaTime float32)
+	renderCallback func(deltaTime, alpha float32)
+
+	// Fixed-timestep state. With fixedTimestep set, the engine goroutine accumulates scaled
+	// wall time and drains it in exact engineTickRate steps; the remainder drives the
+	// interpolation alpha. Otherwise each wake runs one tick with the measured delta.
+	fixedTimestep   bool
+	timeMu          sync.Mutex
+	accumulator     time.Duration
+	lastAdvance     time.Time // wall time the accumulator was last advanced
+	maxCatchUpSteps int       // maximum fixed steps run per wake before the backlog is dropped
+	timeScale       float64
+	paused          bool
+	pendingSteps    int // single steps requested via Step while paused
+
+	interpolationEnabled bool
+	simMu                sync.Mutex // serializes ticks against render-side transform interpolation
 
 	scenes map[int]scene.Scene
 
+	// The render graph runs every frame on the render goroutine. Its built-in passes read the
+	// frame's scenes and simulation delta from frameScenes and frameSimDt.
+	renderGraph    render_graph.RenderGraph
+	renderGraphErr string // last execution error, logged once until it changes
+	frameScenes    []scene.Scene
+	frameSimDt     float32
+
 	renderFrameLimit time.Duration // minimum frame duration; 0 = uncapped
 }
 
@@ -54,12 +91,67 @@ type Engine interface {
 	//   - window.Window: the window instance
 	Window() window.Window
 
+	// Input returns the input system sampled at each tick.
+	// Created automatically for the engine's window unless supplied via WithInput.
+	//
+	// Returns:
+	//   - input.Input: the input instance, or nil for a headless engine without one
+	Input() input.Input
+
+	// World returns the ECS world updated at each tick.
+	//
+	// Returns:
+	//   - ecs.World: the world, or nil if none is attached
+	World() ecs.World
+
+	// SetWorld attaches an ECS world whose systems run every tick, after input is
+	// sampled and before the tick callback. Pass nil to detach the current world.
+	// Call before Run or from the tick callback.
+	//
+	// Parameters:
+	//   - w: the World to update each tick
+	SetWorld(w ecs.World)
+
+	// Physics returns the physics world stepped at each tick.
+	//
+	// Returns:
+	//   - physics.World: the physics world, or nil if none is attached
+	Physics() physics.World
+
+	// SetPhysics attaches a physics world that is stepped every tick, after the tick
+	// callback and before scene transforms are synced, so forces applied in the callback take
+	// effect in the same tick. Pass nil to detach the current world.
+	// Call before Run or from the tick callback.
+	//
+	// Parameters:
+	//   - w: the physics World to step each tick
+	SetPhysics(w physics.World)
+
 	// EnableProfiler enables performance profiling output to the log.
 	EnableProfiler()
 
 	// DisableProfiler disables performance profiling output.
 	DisableProfiler()
 
+	// PhaseTimings returns the mean CPU and GPU time per frame of each render graph pass (the
+	// built-in compute, shadows, light culling, draw and present passes plus any custom ones)
+	// over the profiler's last update interval. Only populated while profiling is enabled; GPU
+	// times also need a renderer created with renderer.WithGPUTimestamps(true) on an adapter
+	// that supports timestamp queries.
+	//
+	// Returns:
+	//   - []profiler.PhaseTiming: one entry per pass in the order they run, or nil before the first update
+	PhaseTimings() []profiler.PhaseTiming
+
+	// RenderGraph returns the graph that renders each frame. It starts with the built-in passes
+	// PassCompute, PassShadows, PassLightCulling, PassDraw and PassPresent; custom passes can be
+	// added, inserted and removed, and built-in passes disabled, at any time. Passes declaring
+	// the built-in Resource* names are ordered against the built-in passes.
+	//
+	// Returns:
+	//   - render_graph.RenderGraph: the engine's render graph
+	RenderGraph() render_graph.RenderGraph
+
 	// SetTickRate sets the engine tick rate in frames per second.
 	// The tick callback will be called at this rate for game logic updates.
 	//
@@ -76,10 +168,61 @@ type Engine interface {
 
 	// SetRenderCallback registers the function called each render frame.
 	// Use this for GPU buffer updates and scene rendering.
+	// With a fixed timestep, the alpha value is the fraction of a step accumulated since the
+	// last tick, which can be used to blend any custom state between the last two simulation
+	// steps. With a variable timestep it is always 1.
 	//
 	// Parameters:
-	//   - callback: function to call each render frame, receiving the delta time in seconds
-	SetRenderCallback(callback func(deltaTime float32))
+	//   - callback: function to call each render frame, receiving the delta time in seconds and the interpolation alpha in [0, 1]
+	SetRenderCallback(callback func(deltaTime, alpha float32))
+
+	// SetMaxCatchUpSteps sets how many fixed steps may run in a single wake of the engine
+	// loop when the simulation falls behind. Any remaining backlog is dropped so a slow
+	// tick cannot snowball into an ever-growing queue of steps. Only used with a fixed timestep.
+	//
+	// Parameters:
+	//   - n: maximum catch-up steps per wake (defaults to 5 if <= 0)
+	SetMaxCatchUpSteps(n int)
+
+	// Pause stops the simulation from advancing. The render loop keeps running and
+	// GPU-driven animation is frozen. Use Step to advance one tick at a time while paused.
+	Pause()
+
+	// Resume continues a paused simulation without replaying the time spent paused.
+	Resume()
+
+	// Paused returns whether the simulation is currently paused.
+	//
+	// Returns:
+	//   - bool: true if paused
+	Paused() bool
+
+	// Step advances a paused simulation by exactly one tick step (1 / tick rate) on the next
+	// wake of the engine loop. No-op when the simulation is not paused.
+	Step()
+
+	// SetTimeScale sets the multiplier applied to wall time before it reaches the simulation.
+	// 1 is real-time, 0.5 is half speed, 2 is double speed. With a fixed timestep the tick
+	// callback always receives the exact step and only the number of steps per second changes;
+	// with a variable timestep the delta passed to the tick callback is scaled instead.
+	//
+	// Parameters:
+	//   - scale: the time multiplier (negative values are clamped to 0)
+	SetTimeScale(scale float64)
+
+	// TimeScale returns the current time multiplier.
+	//
+	// Returns:
+	//   - float64: the time scale
+	TimeScale() float64
+
+	// Alpha returns the current interpolation factor between the last two simulation
+	// steps, i.e. the fraction of a fixed step accumulated since the most recent tick.
+	// Always 1 with a variable timestep.
+	//
+	// Returns:
+	//   - float32: the interpolation alpha in [0, 1]
+	Alpha() float32
 
 	// SetRenderFrameLimit sets an optional render frame rate cap in frames per second.
 	// Pass 0 to uncap the render loop (default).
@@ -119,8 +262,25 @@ type Engine interface {
 	Scenes() map[int]scene.Scene
 
 	// Run starts the main engine loop (blocks until window closes).
+	// When the engine has no window, Run blocks until Quit is called instead.
 	Run()
 
+	// RenderFrame renders a single frame of all active scenes synchronously on the calling
+	// goroutine. Intended for headless use (e.g. tests, thumbnails, offline capture) without
+	// calling Run; do not call while the render loop started by Run is active.
+	//
+	// Parameters:
+	//   - deltaTime: the time in seconds to advance GPU-driven animation by
+	RenderFrame(deltaTime float32)
+
+	// CaptureFrame reads back the most recently rendered frame from the first active scene's
+	// renderer. The renderer must be headless (see renderer.NewHeadlessRenderer).
+	//
+	// Returns:
+	//   - *image.RGBA: the rendered frame
+	//   - error: an error if there is no active headless renderer or the readback fails
+	CaptureFrame() (*image.RGBA, error)
+
 	// Quit signals all engine goroutines to stop and shuts down the engine.
 	// This is an alternative to submitting a MessageShutdown message.
 	// Safe to call multiple times; subsequent calls are no-ops.
@@ -146,21 +306,28 @@ func NewEngine(options ...EngineBuilderOption) Engine {
 		profiler:         profiler.NewProfiler(),
 		profilingEnabled: false,
 		engineTickRate:   time.Second / 60,
+
+		maxCatchUpSteps:      defaultMaxCatchUpSteps,
+		timeScale:            1,
+		interpolationEnabled: true,
 	}
+	e.renderGraph = e.newRenderGraph()
 
 	for _, opt := range options {
 		opt(e)
 	}
 
+	if e.input == nil && e.window != nil {
+		e.input = input.NewInput(input.WithWindow(e.window))
+	}
+
 	if e.window != nil {
 		e.window.SetResizeCallback(func(width, height int) {
 			for _, s := range e.scenes {
 				if r := s.Renderer(); r != nil {
 					r.Resize(width, height)
 				}
-				if c := s.Camera(); c != nil {
-					c.SetAspect(float32(width) / float32(height))
-				}
+				s.Resize(width, height)
 			}
 		})
 	}
@@ -172,11 +339,48 @@ func (e *engine) Window() window.Window {
 	return e.window
 }
 
+func (e *engine) Input() input.Input {
+	return e.input
+}
+
+func (e *engine) World() ecs.World {
+	return e.world
+}
+
+func (e *engine) SetWorld(w ecs.World) {
+	e.world = w
+}
+
+func (e *engine) Physics() physics.World {
+	return e.physics
+}
+
+func (e *engine) SetPhysics(w physics.World) {
+	e.physics = w
+}
+
 func (e *engine) Run() {
 	e.handle()
+	if e.window == nil {
+		// Headless: there is no message loop to block on, so block until Quit is called.
+		<-e.quitChannel
+		return
+	}
 	e.window.ProcessMessages()
 }
 
+func (e *engine) RenderFrame(deltaTime float32) {
+	e.renderFrame(e.simulationDelta(deltaTime), e.Alpha())
+}
+
+func (e *engine) CaptureFrame() (*image.RGBA, error) {
+	active := e.activeScenes()
+	if len(active) == 0 || active[0].Renderer() == nil {
+		return nil, errors.New("engine: no active scene with a renderer to capture")
+	}
+	return active[0].Renderer().ReadPixels()
+}
+
 // Quit signals all engine goroutines to stop and shuts down the engine.
 // Safe to call multiple times; subsequent calls are no-ops due to sync.Once.
 func (e *engine) Quit() {
@@ -195,42 +399,155 @@ func (e *engine) signalQuit() {
 // handle launches the engine, render, and quit goroutines.
 // Each goroutine is tracked by the engine's WaitGroup.
 func (e *engine) handle() {
+	e.running = true
 	e.wg.Add(3)
 	go e.handleEngine()
 	go e.handleRender()
 	go e.handleQuit()
 }
 
-// handleEngine runs the fixed-rate engine tick loop in its own goroutine.
-// Fires the tick callback at the configured tick rate and listens for dynamic rate changes
-// via tickRateChannel. Exits when the quit channel is closed.
+// handleEngine runs the engine loop in its own goroutine, advancing the simulation on
+// every wake of the ticker. Listens for dynamic rate changes via tickRateChannel.
+// Exits when the quit channel is closed.
 func (e *engine) handleEngine() {
 	defer e.wg.Done()
 
 	ticker := time.NewTicker(e.engineTickRate)
 	defer ticker.Stop()
 
-	lastTick := time.Now()
+	e.timeMu.Lock()
+	e.accumulator = 0
+	e.lastAdvance = time.Now()
+	e.timeMu.Unlock()
 
 	for {
 		select {
 		case <-e.quitChannel:
 			return
 		case <-ticker.C:
-			now := time.Now()
-			dt := float32(now.Sub(lastTick).Seconds())
-			lastTick = now
-
-			if e.tickCallback != nil {
-				e.tickCallback(dt)
-			}
+			e.advance()
 		case newRate := <-e.tickRateChannel:
 			ticker.Reset(newRate)
+			e.timeMu.Lock()
 			e.engineTickRate = newRate
+			e.accumulator = min(e.accumulator, newRate)
+			e.timeMu.Unlock()
+		}
+	}
+}
+
+// advance runs the ticks due since the previous wake. With a fixed timestep it accumulates
+// the scaled wall time and runs as many exact steps as fit, up to maxCatchUpSteps; otherwise
+// it runs one tick with the scaled wall time as its delta. While paused, only the steps
+// requested via Step are run and elapsed wall time is discarded. When no tick runs, input is
+// still updated so its event buffer is drained and render callbacks see current input, e.g.
+// to detect an unpause key.
+func (e *engine) advance() {
+	now := time.Now()
+
+	e.timeMu.Lock()
+	step := e.engineTickRate
+	steps := 0
+	if e.paused {
+		steps = e.pendingSteps
+		e.pendingSteps = 0
+	} else if !e.fixedTimestep {
+		step = time.Duration(float64(now.Sub(e.lastAdvance)) * e.timeScale)
+		if step > 0 {
+			steps = 1
+		}
+	} else {
+		e.accumulator += time.Duration(float64(now.Sub(e.lastAdvance)) * e.timeScale)
+		for e.accumulator >= step && steps < e.maxCatchUpSteps {
+			e.accumulator -= step
+			steps++
+		}
+		// Drop the backlog we could not catch up on so a slow tick doesn't spiral.
+		if e.accumulator >= step {
+			e.accumulator %= step
+		}
+	}
+	e.lastAdvance = now
+	e.timeMu.Unlock()
+
+	for range steps {
+		e.runTick(step)
+	}
+	if steps == 0 && e.input != nil {
+		e.input.Update()
+	}
+}
+
+// runTick executes a single simulation step. Input is sampled first so every query
+// made by the ECS systems and the tick callback sees the same state, and physics is stepped
+// after the tick callback so bodies write their transforms before the sync. Scenes are restored to their exact
+// simulation state before the tick callback, have their world transforms synced, and are
+// snapshotted afterwards so the render loop can interpolate between the last two steps.
+// Sprite animations registered with the scenes are advanced by the step alongside physics.
+// The scenes' debug draws keep the primitives submitted during the tick until the next one.
+//
+// Parameters:
+//   - step: the step duration passed to the tick callback
+func (e *engine) runTick(step time.Duration) {
+	e.simMu.Lock()
+	defer e.simMu.Unlock()
+
+	if e.input != nil {
+		e.input.Update()
+	}
+
+	if e.interpolating() {
+		for _, s := range e.scenes {
+			s.BeginSimulationStep()
+		}
+	}
+
+	debugDraws := e.debugDraws()
+	for _, dd := range debugDraws {
+		dd.BeginTick()
+	}
+
+	if e.world != nil {
+		e.world.Update(float32(step.Seconds()))
+	}
+
+	if e.tickCallback != nil {
+		e.tickCallback(float32(step.Seconds()))
+	}
+
+	if e.physics != nil {
+		e.physics.Step(float32(step.Seconds()))
+	}
+
+	for _, s := range e.scenes {
+		s.TickSpriteAnimations(float32(step.Seconds()))
+	}
+
+	for _, dd := range debugDraws {
+		dd.EndTick()
+	}
+
+	// Feed world transforms of moved hierarchies into the animators before snapshotting.
+	for _, s := range e.scenes {
+		s.SyncTransforms()
+	}
+
+	if e.interpolating() {
+		for _, s := range e.scenes {
+			s.EndSimulationStep()
 		}
 	}
 }
 
+// interpolating reports whether render-side transform interpolation is active. It needs
+// a fixed timestep, since variable ticks leave no fraction of a step to blend by.
+//
+// Returns:
+//   - bool: true if interpolation is enabled and the timestep is fixed
+func (e *engine) interpolating() bool {
+	return e.fixedTimestep && e.interpolationEnabled
+}
+
 // handleRender runs the uncapped (or frame-limited) render loop in its own goroutine.
 // Iterates active scenes in ascending z-index order, executing the full frame lifecycle:
 // compute dispatch, shadow pass, light culling, and draw calls.
@@ -256,59 +573,11 @@ func (e *engine) handleRender() {
 			dt := float32(now.Sub(lastRender).Seconds())
 			lastRender = now
 
-			// Draw all active scenes in ascending z-index order.
-			// The engine owns the frame lifecycle: BeginFrame once, Render each scene, EndFrame + Present once.
-			// All scenes sharing the same renderer are rendered within a single render pass, enabling layered compositing.
-			keys := make([]int, 0, len(e.scenes))
-			for k := range e.scenes {
-				keys = append(keys, k)
-			}
-			sort.Ints(keys)
-
-			// Collect active scenes and find the renderer for the frame
-			var activeScenes []scene.Scene
-			for _, k := range keys {
-				s := e.scenes[k]
-				if s.Active() {
-					activeScenes = append(activeScenes, s)
-				}
-			}
-
-			if len(activeScenes) > 0 {
-				// Use the first active scene's renderer to manage the frame
-				frameRenderer := activeScenes[0].Renderer()
-				if frameRenderer != nil {
-					// Phase 1 — Compute: batch all compute dispatches into a single GPU submission
-					if err := frameRenderer.BeginComputeFrame(); err == nil {
-						for _, s := range activeScenes {
-							s.PrepareCompute(dt)
-						}
-						frameRenderer.EndComputeFrame()
-					}
-
-					// Phase 1b — Shadows: render depth-only shadow passes for directional lights.
-					for _, s := range activeScenes {
-						s.PrepareShadows()
-					}
-
-					// Phase 1c — Light culling: dispatch the Forward+ tile culling compute shader.
-					for _, s := range activeScenes {
-						s.PrepareLightCulling()
-					}
-
-					// Phase 2 — Render: batch all draw calls into a single render pass
-					if err := frameRenderer.BeginFrame(); err == nil {
-						for _, s := range activeScenes {
-							_ = s.DrawCalls()
-						}
-						frameRenderer.EndFrame()
-						frameRenderer.Present()
-					}
-				}
-			}
+			alpha := e.Alpha()
+			e.renderFrame(e.simulationDelta(dt), alpha)
 
 			if e.renderCallback != nil {
-				e.renderCallback(dt)
+				e.renderCallback(dt, alpha)
 			}
 
 			if e.profilingEnabled && e.profiler != nil {
@@ -326,6 +595,139 @@ func (e *engine) handleRender() {
 	}
 }
 
+// debugDraws returns the debug draws attached to the engine's scenes, each once even when
+// several scenes share it.
+//
+// Returns:
+//   - []debug_draw.DebugDraw: the distinct debug draws
+func (e *engine) debugDraws() []debug_draw.DebugDraw {
+	var dds []debug_draw.DebugDraw
+	for _, s := range e.scenes {
+		if dd := s.DebugDraw(); dd != nil && !slices.Contains(dds, dd) {
+			dds = append(dds, dd)
+		}
+	}
+	return dds
+}
+
+// activeScenes returns the active scenes in ascending z-index order.
+//
+// Returns:
+//   - []scene.Scene: the active scenes sorted by key
+func (e *engine) activeScenes() []scene.Scene {
+	keys := make([]int, 0, len(e.scenes))
+	for k := range e.scenes {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	var active []scene.Scene
+	for _, k := range keys {
+		s := e.scenes[k]
+		if s.Active() {
+			active = append(active, s)
+		}
+	}
+	return active
+}
+
+// renderFrame executes the full frame lifecycle for all active scenes: transform
+// interpolation, then the render graph (compute dispatch, shadow pass, light culling, draw
+// calls, present, and any custom passes).
+//
+// Parameters:
+//   - simDt: the scaled delta time in seconds passed to PrepareCompute
+//   - alpha: the interpolation factor between the last two simulation steps
+func (e *engine) renderFrame(simDt, alpha float32) {
+	// Draw all active scenes in ascending z-index order.
+	// The engine owns the frame lifecycle: BeginFrame once, Render each scene, EndFrame + Present once.
+	// All scenes sharing the same renderer are rendered within a single render pass, enabling layered compositing.
+	activeScenes := e.activeScenes()
+	if len(activeScenes) == 0 {
+		return
+	}
+
+	// Blend object transforms between the last two simulation steps before anything
+	// reads them for this frame. Holding simMu keeps a tick from interleaving.
+	if e.interpolating() {
+		e.simMu.Lock()
+		for _, s := range activeScenes {
+			s.InterpolateTransforms(alpha)
+		}
+		e.simMu.Unlock()
+	}
+
+	// Use the first active scene's renderer to manage the frame
+	frameRenderer := activeScenes[0].Renderer()
+	if frameRenderer == nil {
+		return
+	}
+
+	// Each pass is timed on the CPU, and on the GPU by a timestamp after its last submission.
+	// Present submits no GPU work of its own and is only timed on the CPU.
+	profiling := e.profilingEnabled && e.profiler != nil
+	if profiling {
+		frameRenderer.BeginGPUTimings()
+	}
+	phaseStart := time.Now()
+
+	// Run the render graph: compute, shadows, light culling, draw and present by default, plus
+	// any custom passes, in dependency order.
+	e.frameScenes = activeScenes
+	e.frameSimDt = simDt
+	err := e.renderGraph.Execute(frameRenderer, func(p render_graph.Pass) {
+		if p.Name() == PassPresent {
+			if profiling {
+				e.profiler.RecordCPU(PassPresent, time.Since(phaseStart))
+			}
+			phaseStart = time.Now()
+			return
+		}
+		phaseStart = e.endPhase(frameRenderer, p.Name(), phaseStart)
+	})
+	e.frameScenes = nil
+
+	// Every scene has drawn its debug lines; start the next debug frame before the render
+	// callback submits its lines.
+	for _, dd := range e.debugDraws() {
+		dd.BeginFrame()
+	}
+
+	if err == nil {
+		e.renderGraphErr = ""
+	} else if msg := err.Error(); msg != e.renderGraphErr {
+		log.Printf("render graph: %s", msg)
+		e.renderGraphErr = msg
+	}
+
+	// GPU timestamps are read back a frame or two later; record whichever frame is ready.
+	if profiling {
+		frameRenderer.EndGPUTimings()
+		for _, t := range frameRenderer.GPUTimings() {
+			e.profiler.RecordGPU(t.Phase, t.Duration)
+		}
+	}
+}
+
+// endPhase ends a timed render phase: records its CPU time and marks the end of its GPU work.
+// Only records while profiling is enabled.
+//
+// Parameters:
+//   - r: the renderer the frame is drawn with
+//   - phase: the phase (render graph pass) that just ended
+//   - start: when the phase started
+//
+// Returns:
+//   - time.Time: the end of the phase, which is the start of the next one
+func (e *engine) endPhase(r renderer.Renderer, phase string, start time.Time) time.Time {
+	now := time.Now()
+	if e.profilingEnabled && e.profiler != nil {
+		e.profiler.RecordCPU(phase, now.Sub(start))
+		r.MarkGPUTiming(phase)
+	}
+	return now
+}
+
 // handleQuit blocks until the quit channel is closed, then decrements the WaitGroup.
 func (e *engine) handleQuit() {
 	defer e.wg.Done()
@@ -342,6 +744,14 @@ func (e *engine) DisableProfiler() {
 	e.profilingEnabled = false
 }
 
+// PhaseTimings returns the per-phase frame timings from the profiler's last update.
+func (e *engine) PhaseTimings() []profiler.PhaseTiming {
+	if e.profiler == nil {
+		return nil
+	}
+	return e.profiler.PhaseTimings()
+}
+
 // SetTickRate sets the engine tick rate in frames per second.
 // If the engine is running, the change takes effect immediately.
 func (e *engine) SetTickRate(fps float64) {
@@ -365,7 +775,9 @@ func (e *engine) SetTickRate(fps float64) {
 		}
 	} else {
 		// Engine not running, just update the field
+		e.timeMu.Lock()
 		e.engineTickRate = newRate
+		e.timeMu.Unlock()
 	}
 }
 
@@ -375,10 +787,96 @@ func (e *engine) SetTickCallback(callback func(deltaTime float32)) {
 }
 
 // SetRenderCallback registers the function called each render frame.
-func (e *engine) SetRenderCallback(callback func(deltaTime float32)) {
+func (e *engine) SetRenderCallback(callback func(deltaTime, alpha float32)) {
 	e.renderCallback = callback
 }
 
+func (e *engine) SetMaxCatchUpSteps(n int) {
+	if n <= 0 {
+		n = defaultMaxCatchUpSteps
+	}
+	e.timeMu.Lock()
+	defer e.timeMu.Unlock()
+	e.maxCatchUpSteps = n
+}
+
+func (e *engine) Pause() {
+	e.timeMu.Lock()
+	defer e.timeMu.Unlock()
+	e.paused = true
+}
+
+func (e *engine) Resume() {
+	e.timeMu.Lock()
+	defer e.timeMu.Unlock()
+	if !e.paused {
+		return
+	}
+	e.paused = false
+	e.pendingSteps = 0
+	// Restart accumulation from now so the paused interval is not replayed.
+	e.lastAdvance = time.Now()
+}
+
+func (e *engine) Paused() bool {
+	e.timeMu.Lock()
+	defer e.timeMu.Unlock()
+	return e.paused
+}
+
+func (e *engine) Step() {
+	e.timeMu.Lock()
+	defer e.timeMu.Unlock()
+	if e.paused {
+		e.pendingSteps++
+	}
+}
+
+func (e *engine) SetTimeScale(scale float64) {
+	e.timeMu.Lock()
+	defer e.timeMu.Unlock()
+	e.timeScale = max(scale, 0)
+}
+
+func (e *engine) TimeScale() float64 {
+	e.timeMu.Lock()
+	defer e.timeMu.Unlock()
+	return e.timeScale
+}
+
+func (e *engine) Alpha() float32 {
+	e.timeMu.Lock()
+	defer e.timeMu.Unlock()
+
+	if !e.fixedTimestep || e.engineTickRate <= 0 {
+		return 1
+	}
+	acc := e.accumulator
+	// Include time elapsed since the last wake so alpha advances smoothly between ticks
+	// rather than stepping once per engine wake.
+	if !e.paused && !e.lastAdvance.IsZero() {
+		acc += time.Duration(float64(time.Since(e.lastAdvance)) * e.timeScale)
+	}
+	return min(max(float32(float64(acc)/float64(e.engineTickRate)), 0), 1)
+}
+
+// simulationDelta scales a render-frame delta by the current time scale, returning 0
+// while paused so GPU-driven animation freezes together with the simulation.
+//
+// Parameters:
+//   - dt: the unscaled render delta in seconds
+//
+// Returns:
+//   - float32: the scaled delta in seconds
+func (e *engine) simulationDelta(dt float32) float32 {
+	e.timeMu.Lock()
+	defer e.timeMu.Unlock()
+	if e.paused {
+		return 0
+	}
+	return dt * float32(e.timeScale)
+}
+
 // SetRenderFrameLimit sets an optional render frame rate cap.
 // Pass 0 to uncap the render loop.
 func (e *engine) SetRenderFrameLimit(fps float64) {
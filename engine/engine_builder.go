@@ -3,6 +3,9 @@ package engine
 import (
 	"time"
 
+	"github.com/Carmen-Shannon/oxy-go/engine/ecs"
+	"github.com/Carmen-Shannon/oxy-go/engine/input"
+	"github.com/Carmen-Shannon/oxy-go/engine/physics"
 	"github.com/Carmen-Shannon/oxy-go/engine/scene"
 	"github.com/Carmen-Shannon/oxy-go/engine/window"
 )
@@ -88,3 +91,121 @@ func WithRenderFrameLimit(fps float64) EngineBuilderOption {
 		e.renderFrameLimit = time.Second / time.Duration(fps)
 	}
 }
+
+// WithMaxCatchUpSteps sets how many fixed steps may run in a single wake of the engine
+// loop when the simulation falls behind. Any remaining backlog is dropped.
+// Values <= 0 will be treated as the default (5).
+//
+// Parameters:
+//   - n: maximum catch-up steps per wake
+//
+// Returns:
+//   - EngineBuilderOption: option function to apply
+func WithMaxCatchUpSteps(n int) EngineBuilderOption {
+	return func(e *engine) {
+		if n <= 0 {
+			n = defaultMaxCatchUpSteps
+		}
+		e.maxCatchUpSteps = n
+	}
+}
+
+// WithTimeScale sets the initial multiplier applied to wall time before it reaches the
+// simulation. Negative values are clamped to 0.
+//
+// Parameters:
+//   - scale: the time multiplier (default 1)
+//
+// Returns:
+//   - EngineBuilderOption: option function to apply
+func WithTimeScale(scale float64) EngineBuilderOption {
+	return func(e *engine) {
+		e.timeScale = max(scale, 0)
+	}
+}
+
+// WithPaused starts the engine with the simulation paused.
+//
+// Parameters:
+//   - paused: if true, the simulation does not advance until Resume or Step is called
+//
+// Returns:
+//   - EngineBuilderOption: option function to apply
+func WithPaused(paused bool) EngineBuilderOption {
+	return func(e *engine) {
+		e.paused = paused
+	}
+}
+
+// WithFixedTimestep switches the engine loop between a fixed and a variable timestep.
+// With a fixed timestep, scaled wall time is accumulated and the tick callback receives the
+// exact step (1 / tick rate), running several catch-up steps per wake if needed. With a
+// variable timestep (default), each wake runs one tick with the measured delta time.
+//
+// Parameters:
+//   - enabled: if true, ticks run in exact fixed steps
+//
+// Returns:
+//   - EngineBuilderOption: option function to apply
+func WithFixedTimestep(enabled bool) EngineBuilderOption {
+	return func(e *engine) {
+		e.fixedTimestep = enabled
+	}
+}
+
+// WithInterpolation enables or disables render-side transform interpolation. When enabled
+// (default), scene objects are rendered blended between the last two fixed simulation
+// steps using the current alpha. Disable to render the latest simulation state directly.
+// Interpolation only applies with a fixed timestep (see WithFixedTimestep).
+//
+// Parameters:
+//   - enabled: if true, interpolates object transforms between simulation steps
+//
+// Returns:
+//   - EngineBuilderOption: option function to apply
+func WithInterpolation(enabled bool) EngineBuilderOption {
+	return func(e *engine) {
+		e.interpolationEnabled = enabled
+	}
+}
+
+// WithWorld attaches an ECS world whose systems run every tick, after input is
+// sampled and before the tick callback.
+//
+// Parameters:
+//   - w: the World to update each tick
+//
+// Returns:
+//   - EngineBuilderOption: option function to apply
+func WithWorld(w ecs.World) EngineBuilderOption {
+	return func(e *engine) {
+		e.world = w
+	}
+}
+
+// WithPhysics attaches a physics world that is stepped every tick, after the tick callback.
+//
+// Parameters:
+//   - w: the physics World to step each tick
+//
+// Returns:
+//   - EngineBuilderOption: option function to apply
+func WithPhysics(w physics.World) EngineBuilderOption {
+	return func(e *engine) {
+		e.physics = w
+	}
+}
+
+// WithInput sets a custom configured input system for the engine to sample at each tick
+// rather than allowing the engine to create one for its window.
+//
+// Parameters:
+//   - in: a pre-configured Input instance
+//
+// Returns:
+//   - EngineBuilderOption: option function to apply
+func WithInput(in input.Input) EngineBuilderOption {
+	return func(e *engine) {
+		e.input = in
+	}
+}
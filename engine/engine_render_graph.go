@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/profiler"
+	"github.com/Carmen-Shannon/oxy-go/engine/render_graph"
+)
+
+// Built-in render graph passes, in the order they run by default. Each pass runs once per frame
+// for every active scene, through the first active scene's renderer, and is timed by the profiler
+// under its own name.
+const (
+	// PassCompute batches the GPU skinning, animation and culling dispatches of every scene into
+	// one compute submission. Writes ResourceInstanceData.
+	PassCompute = profiler.PhaseCompute
+
+	// PassShadows renders the shadow maps of every shadow-casting light. Reads ResourceInstanceData
+	// and writes ResourceShadowMaps.
+	PassShadows = profiler.PhaseShadows
+
+	// PassLightCulling dispatches the Forward+ tile light culling. Writes ResourceLightTiles.
+	PassLightCulling = profiler.PhaseLightCulling
+
+	// PassOffscreen draws every scene with a render target that is due into its target, each in
+	// a render pass of its own. Reads ResourceInstanceData, ResourceShadowMaps and
+	// ResourceLightTiles and writes ResourceRenderTargets.
+	PassOffscreen = profiler.PhaseOffscreen
+
+	// PassDraw draws every scene without a render target in the main render pass, including
+	// transparency and post effects. Reads ResourceInstanceData, ResourceShadowMaps,
+	// ResourceLightTiles and ResourceRenderTargets and writes ResourceFrame. The main pass
+	// clears its own color and depth targets.
+	PassDraw = profiler.PhaseDraw
+
+	// PassPresent presents the frame. Reads ResourceFrame.
+	PassPresent = profiler.PhasePresent
+)
+
+// Imported resources shared by the built-in passes. They are owned by the scenes and the
+// renderer and carry no GPU object in the graph; custom passes declare them to run before or
+// after a built-in pass.
+const (
+	// ResourceInstanceData is the per-instance transform, skinning and culling output of the
+	// animators.
+	ResourceInstanceData = "instance_data"
+
+	// ResourceShadowMaps is the cascaded shadow maps and the shadow atlas.
+	ResourceShadowMaps = "shadow_maps"
+
+	// ResourceLightTiles is the Forward+ light grid.
+	ResourceLightTiles = "light_tiles"
+
+	// ResourceRenderTargets is the color and depth textures of the scenes' render targets,
+	// sampled by the materials bound to them.
+	ResourceRenderTargets = "render_targets"
+
+	// ResourceFrame is the frame target: the swapchain image, or the offscreen texture of a
+	// headless renderer.
+	ResourceFrame = "frame"
+)
+
+// newRenderGraph builds the default render graph with the built-in passes. The passes read the
+// frame's scenes and simulation delta from the engine, set by renderFrame before every execution.
+//
+// Returns:
+//   - render_graph.RenderGraph: the default render graph
+func (e *engine) newRenderGraph() render_graph.RenderGraph {
+	return render_graph.NewRenderGraph(
+		render_graph.WithResource(render_graph.NewResource(ResourceInstanceData, render_graph.ResourceTypeBuffer, render_graph.WithImported())),
+		render_graph.WithResource(render_graph.NewResource(ResourceShadowMaps, render_graph.ResourceTypeTexture, render_graph.WithImported())),
+		render_graph.WithResource(render_graph.NewResource(ResourceLightTiles, render_graph.ResourceTypeBuffer, render_graph.WithImported())),
+		render_graph.WithResource(render_graph.NewResource(ResourceRenderTargets, render_graph.ResourceTypeTexture, render_graph.WithImported())),
+		render_graph.WithResource(render_graph.NewResource(ResourceFrame, render_graph.ResourceTypeTexture, render_graph.WithImported())),
+
+		render_graph.WithPass(render_graph.NewPass(PassCompute, render_graph.PassTypeCompute,
+			render_graph.WithWrite(ResourceInstanceData),
+			render_graph.WithExecute(func(ctx render_graph.PassContext) error {
+				for _, s := range e.frameScenes {
+					s.PrepareCompute(e.frameSimDt)
+				}
+				return nil
+			}),
+		)),
+		render_graph.WithPass(render_graph.NewPass(PassShadows, render_graph.PassTypeCallback,
+			render_graph.WithRead(ResourceInstanceData),
+			render_graph.WithWrite(ResourceShadowMaps),
+			render_graph.WithExecute(func(ctx render_graph.PassContext) error {
+				for _, s := range e.frameScenes {
+					s.PrepareShadows()
+				}
+				return nil
+			}),
+		)),
+		render_graph.WithPass(render_graph.NewPass(PassLightCulling, render_graph.PassTypeCallback,
+			render_graph.WithWrite(ResourceLightTiles),
+			render_graph.WithExecute(func(ctx render_graph.PassContext) error {
+				for _, s := range e.frameScenes {
+					s.PrepareLightCulling()
+				}
+				return nil
+			}),
+		)),
+		render_graph.WithPass(render_graph.NewPass(PassOffscreen, render_graph.PassTypeCallback,
+			render_graph.WithRead(ResourceInstanceData, ResourceShadowMaps, ResourceLightTiles),
+			render_graph.WithWrite(ResourceRenderTargets),
+			render_graph.WithExecute(func(ctx render_graph.PassContext) error {
+				now := time.Now()
+				for _, s := range e.frameScenes {
+					rt := s.RenderTarget()
+					if rt == nil || !rt.Due(now) {
+						continue
+					}
+					if err := rt.Begin(); err != nil {
+						continue
+					}
+					_ = s.DrawCalls()
+					rt.End()
+				}
+				return nil
+			}),
+		)),
+		render_graph.WithPass(render_graph.NewPass(PassDraw, render_graph.PassTypeCallback,
+			render_graph.WithRead(ResourceInstanceData, ResourceShadowMaps, ResourceLightTiles, ResourceRenderTargets),
+			render_graph.WithWrite(ResourceFrame),
+			render_graph.WithExecute(func(ctx render_graph.PassContext) error {
+				// A frame that cannot acquire its target is skipped, as is its present.
+				if err := ctx.Renderer.BeginFrame(); err != nil {
+					return nil
+				}
+				for _, s := range e.frameScenes {
+					if s.RenderTarget() != nil {
+						continue
+					}
+					_ = s.DrawCalls()
+				}
+				ctx.Renderer.EndFrame()
+				return nil
+			}),
+		)),
+		render_graph.WithPass(render_graph.NewPass(PassPresent, render_graph.PassTypeCallback,
+			render_graph.WithRead(ResourceFrame),
+			render_graph.WithExecute(func(ctx render_graph.PassContext) error {
+				ctx.Renderer.Present()
+				return nil
+			}),
+		)),
+	)
+}
+
+// RenderGraph returns the graph that renders each frame.
+func (e *engine) RenderGraph() render_graph.RenderGraph {
+	return e.renderGraph
+}
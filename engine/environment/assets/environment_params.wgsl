@@ -0,0 +1,7 @@
+struct EnvironmentParams {
+    irradiance:       array<vec4<f32>, 9>,
+    intensity:        f32,
+    specular_max_lod: f32,
+    _pad0:            f32,
+    _pad1:            f32,
+};
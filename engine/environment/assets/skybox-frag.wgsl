@@ -0,0 +1,31 @@
+// Skybox fragment shader
+//
+// Unprojects the fragment through the inverse of the camera's rotation-only
+// view-projection to get a world-space view direction, then samples the sky
+// cubemap along it. A non-zero lod samples a blurrier mip level.
+//
+// Bind group layout:
+//   @group(0) skybox — sky cubemap, sampler, SkyboxParams uniform
+
+struct FragmentInput {
+    @location(0) ndc: vec2<f32>,
+};
+
+//@oxy:include skybox_params
+
+//@oxy:provider 0 0 skybox skybox_texture
+@group(0) @binding(0) var skybox_texture: texture_cube<f32>;
+//@oxy:provider 0 1 skybox skybox_sampler
+@group(0) @binding(1) var skybox_sampler: sampler;
+//@oxy:group 0 2 storage_uniform skybox skybox_params
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    // The camera sits at the origin of the rotation-only view, so any point on
+    // the unprojected ray gives the view direction.
+    let p = skybox.inv_view_proj * vec4<f32>(in.ndc, 0.5, 1.0);
+    let dir = normalize(p.xyz / p.w);
+
+    let color = textureSampleLevel(skybox_texture, skybox_sampler, dir, skybox.lod).rgb;
+    return vec4<f32>(color * skybox.intensity, 1.0);
+}
@@ -0,0 +1,21 @@
+// Skybox vertex shader
+//
+// Emits a single triangle covering the whole target from the vertex index alone,
+// so the skybox needs no vertex buffer. The clip-space position is passed on so the
+// fragment stage can turn it back into a world-space view direction.
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) ndc: vec2<f32>,
+};
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> VertexOutput {
+    let uv = vec2<f32>(f32((index << 1u) & 2u), f32(index & 2u));
+    let ndc = vec2<f32>(uv.x * 2.0 - 1.0, 1.0 - uv.y * 2.0);
+
+    var out: VertexOutput;
+    out.position = vec4<f32>(ndc, 1.0, 1.0);
+    out.ndc = ndc;
+    return out;
+}
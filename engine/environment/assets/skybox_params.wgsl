@@ -0,0 +1,7 @@
+struct SkyboxParams {
+    inv_view_proj: mat4x4<f32>,
+    intensity:     f32,
+    lod:           f32,
+    _pad0:         f32,
+    _pad1:         f32,
+};
@@ -0,0 +1,434 @@
+package environment
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// Cube faces in GPU layer order.
+const (
+	FacePositiveX = iota
+	FaceNegativeX
+	FacePositiveY
+	FaceNegativeY
+	FacePositiveZ
+	FaceNegativeZ
+)
+
+// Cubemap is a cube texture held on the CPU in linear RGBA float32. Faces are ordered
+// +X, -X, +Y, -Y, +Z, -Z and use the WebGPU cube convention, so a face image is what an
+// observer at the center sees looking down that axis with the usual cubemap "up" vectors.
+type Cubemap struct {
+	// Size is the width and height of each face of the base level in pixels.
+	Size uint32
+	// Faces holds the base level of each face, 4 floats per pixel, row-major from the top row.
+	Faces [6][]float32
+	// MipLevels holds the levels below the base level, largest first.
+	MipLevels [][6][]float32
+}
+
+// LoadCubemap loads a cubemap from six square images of the same size, ordered +X, -X, +Y, -Y,
+// +Z, -Z. PNG and JPEG faces are treated as sRGB; Radiance .hdr faces are linear.
+//
+// Parameters:
+//   - paths: the face image files
+//
+// Returns:
+//   - Cubemap: the cubemap, without mip levels
+//   - error: an error if a face cannot be read or decoded, or the faces differ in size
+func LoadCubemap(paths [6]string) (Cubemap, error) {
+	var c Cubemap
+	for face, path := range paths {
+		pixels, width, height, err := loadImageFile(path)
+		if err != nil {
+			return Cubemap{}, err
+		}
+		if width != height {
+			return Cubemap{}, fmt.Errorf("cubemap face %s is not square (%dx%d)", path, width, height)
+		}
+		if face == 0 {
+			c.Size = width
+		} else if width != c.Size {
+			return Cubemap{}, fmt.Errorf("cubemap face %s is %dx%d, expected %dx%d", path, width, height, c.Size, c.Size)
+		}
+		c.Faces[face] = pixels
+	}
+	return c, nil
+}
+
+// LoadEquirectangular loads an equirectangular (latitude-longitude) panorama and resamples it
+// into a cubemap. The center of the image faces -Z and the top row is straight up.
+// Radiance .hdr images are linear; PNG and JPEG images are treated as sRGB.
+//
+// Parameters:
+//   - path: the panorama image file
+//   - faceSize: the width and height of each cube face in pixels
+//
+// Returns:
+//   - Cubemap: the cubemap, without mip levels
+//   - error: an error if the file cannot be read or decoded
+func LoadEquirectangular(path string, faceSize uint32) (Cubemap, error) {
+	pixels, width, height, err := loadImageFile(path)
+	if err != nil {
+		return Cubemap{}, err
+	}
+	return EquirectangularToCubemap(pixels, width, height, faceSize), nil
+}
+
+// EquirectangularToCubemap resamples an equirectangular panorama into a cubemap with bilinear
+// filtering. The center of the image faces -Z and the top row is straight up.
+//
+// Parameters:
+//   - pixels: the panorama in linear RGBA float32, row-major from the top row
+//   - width: the panorama width in pixels
+//   - height: the panorama height in pixels
+//   - faceSize: the width and height of each cube face in pixels
+//
+// Returns:
+//   - Cubemap: the cubemap, without mip levels
+func EquirectangularToCubemap(pixels []float32, width, height, faceSize uint32) Cubemap {
+	c := Cubemap{Size: faceSize}
+	for face := range 6 {
+		out := make([]float32, faceSize*faceSize*4)
+		for y := range faceSize {
+			for x := range faceSize {
+				dir := faceDirection(face, (float32(x)+0.5)/float32(faceSize), (float32(y)+0.5)/float32(faceSize))
+				u := 0.5 + float32(math.Atan2(float64(dir[0]), float64(-dir[2])))/(2*math.Pi)
+				v := float32(math.Acos(float64(min(max(dir[1], -1), 1)))) / math.Pi
+				texel := sampleEquirectangular(pixels, width, height, u, v)
+				copy(out[(y*faceSize+x)*4:], texel[:])
+			}
+		}
+		c.Faces[face] = out
+	}
+	return c
+}
+
+// LevelCount returns the number of mip levels in the cubemap, including the base level.
+//
+// Returns:
+//   - int: the level count
+func (c Cubemap) LevelCount() int {
+	return 1 + len(c.MipLevels)
+}
+
+// Level returns the faces of one mip level.
+//
+// Parameters:
+//   - level: the mip level, 0 for the base level
+//
+// Returns:
+//   - [6][]float32: the faces of the level
+//   - uint32: the face size of the level in pixels
+func (c Cubemap) Level(level int) ([6][]float32, uint32) {
+	size := max(c.Size>>level, 1)
+	if level == 0 {
+		return c.Faces, size
+	}
+	return c.MipLevels[level-1], size
+}
+
+// WithMipmaps returns a copy of the cubemap with a full mip chain down to 1×1, box-filtered from
+// the base level. Existing mip levels are replaced.
+//
+// Returns:
+//   - Cubemap: the cubemap with mip levels
+func (c Cubemap) WithMipmaps() Cubemap {
+	out := Cubemap{Size: c.Size, Faces: c.Faces}
+	faces, size := c.Faces, c.Size
+	for level := uint32(1); level < common.MipLevelCount(c.Size, c.Size); level++ {
+		var next [6][]float32
+		for face := range 6 {
+			next[face] = downsampleFloat(faces[face], size)
+		}
+		faces, size = next, max(size/2, 1)
+		out.MipLevels = append(out.MipLevels, faces)
+	}
+	return out
+}
+
+// StagingData packs the cubemap as RGBA16Float for GPU upload.
+//
+// Returns:
+//   - common.CubemapStagingData: the staging data with every mip level
+func (c Cubemap) StagingData() common.CubemapStagingData {
+	data := common.CubemapStagingData{
+		Size:   c.Size,
+		Format: wgpu.TextureFormatRGBA16Float,
+	}
+	for face := range 6 {
+		data.Faces[face] = common.PackHalfFloats(c.Faces[face])
+	}
+	for _, faces := range c.MipLevels {
+		var packed [6][]byte
+		for face := range 6 {
+			packed[face] = common.PackHalfFloats(faces[face])
+		}
+		data.MipLevels = append(data.MipLevels, packed)
+	}
+	return data
+}
+
+// Sample returns the color along a direction with trilinear filtering. Filtering does not
+// cross face edges.
+//
+// Parameters:
+//   - dir: the world-space direction (need not be normalized)
+//   - lod: the mip level to sample, fractional values blend between levels
+//
+// Returns:
+//   - [4]float32: the linear RGBA color
+func (c Cubemap) Sample(dir [3]float32, lod float32) [4]float32 {
+	lod = min(max(lod, 0), float32(c.LevelCount()-1))
+	base := int(lod)
+	face, u, v := directionFace(dir)
+	faces, size := c.Level(base)
+	color := sampleFace(faces[face], size, u, v)
+	if t := lod - float32(base); t > 0 && base+1 < c.LevelCount() {
+		faces, size = c.Level(base + 1)
+		next := sampleFace(faces[face], size, u, v)
+		for i := range color {
+			color[i] += (next[i] - color[i]) * t
+		}
+	}
+	return color
+}
+
+// faceDirection returns the normalized direction through a point on a cube face.
+//
+// Parameters:
+//   - face: the face index
+//   - u: the horizontal face coordinate in [0, 1], left to right
+//   - v: the vertical face coordinate in [0, 1], top to bottom
+//
+// Returns:
+//   - [3]float32: the unit direction
+func faceDirection(face int, u, v float32) [3]float32 {
+	s, t := 2*u-1, 2*v-1
+	var d [3]float32
+	switch face {
+	case FacePositiveX:
+		d = [3]float32{1, -t, -s}
+	case FaceNegativeX:
+		d = [3]float32{-1, -t, s}
+	case FacePositiveY:
+		d = [3]float32{s, 1, t}
+	case FaceNegativeY:
+		d = [3]float32{s, -1, -t}
+	case FacePositiveZ:
+		d = [3]float32{s, -t, 1}
+	default:
+		d = [3]float32{-s, -t, -1}
+	}
+	return normalize(d)
+}
+
+// directionFace returns the cube face a direction points at and the face coordinates it hits.
+// It is the inverse of faceDirection.
+//
+// Parameters:
+//   - d: the direction (need not be normalized)
+//
+// Returns:
+//   - int: the face index
+//   - float32: the horizontal face coordinate in [0, 1]
+//   - float32: the vertical face coordinate in [0, 1]
+func directionFace(d [3]float32) (int, float32, float32) {
+	ax, ay, az := abs32(d[0]), abs32(d[1]), abs32(d[2])
+	var face int
+	var sc, tc, ma float32
+	switch {
+	case ax >= ay && ax >= az:
+		ma, tc = ax, -d[1]
+		if d[0] > 0 {
+			face, sc = FacePositiveX, -d[2]
+		} else {
+			face, sc = FaceNegativeX, d[2]
+		}
+	case ay >= az:
+		ma, sc = ay, d[0]
+		if d[1] > 0 {
+			face, tc = FacePositiveY, d[2]
+		} else {
+			face, tc = FaceNegativeY, -d[2]
+		}
+	default:
+		ma, tc = az, -d[1]
+		if d[2] > 0 {
+			face, sc = FacePositiveZ, d[0]
+		} else {
+			face, sc = FaceNegativeZ, -d[0]
+		}
+	}
+	if ma == 0 {
+		return FacePositiveZ, 0.5, 0.5
+	}
+	return face, (sc/ma + 1) / 2, (tc/ma + 1) / 2
+}
+
+// sampleFace bilinearly samples one face, clamping at its edges.
+//
+// Parameters:
+//   - pixels: the face in RGBA float32
+//   - size: the face width and height in pixels
+//   - u: the horizontal face coordinate in [0, 1]
+//   - v: the vertical face coordinate in [0, 1]
+//
+// Returns:
+//   - [4]float32: the filtered color
+func sampleFace(pixels []float32, size uint32, u, v float32) [4]float32 {
+	return bilinear(pixels, size, size, u*float32(size)-0.5, v*float32(size)-0.5, false)
+}
+
+// sampleEquirectangular bilinearly samples a panorama, wrapping horizontally and clamping vertically.
+//
+// Parameters:
+//   - pixels: the panorama in RGBA float32
+//   - width: the panorama width in pixels
+//   - height: the panorama height in pixels
+//   - u: the horizontal coordinate in [0, 1]
+//   - v: the vertical coordinate in [0, 1]
+//
+// Returns:
+//   - [4]float32: the filtered color
+func sampleEquirectangular(pixels []float32, width, height uint32, u, v float32) [4]float32 {
+	return bilinear(pixels, width, height, u*float32(width)-0.5, v*float32(height)-0.5, true)
+}
+
+// bilinear interpolates the four texels around a continuous texel position.
+//
+// Parameters:
+//   - pixels: the image in RGBA float32
+//   - width: the image width in pixels
+//   - height: the image height in pixels
+//   - x: the horizontal texel position, texel centers at integers
+//   - y: the vertical texel position, texel centers at integers
+//   - wrapX: true to wrap horizontally instead of clamping
+//
+// Returns:
+//   - [4]float32: the filtered color
+func bilinear(pixels []float32, width, height uint32, x, y float32, wrapX bool) [4]float32 {
+	x0f, y0f := float32(math.Floor(float64(x))), float32(math.Floor(float64(y)))
+	tx, ty := x-x0f, y-y0f
+	w, h := int(width), int(height)
+	col := func(i int) int {
+		if wrapX {
+			return ((i % w) + w) % w
+		}
+		return min(max(i, 0), w-1)
+	}
+	row := func(i int) int { return min(max(i, 0), h-1) }
+	x0, x1 := col(int(x0f)), col(int(x0f)+1)
+	y0, y1 := row(int(y0f)), row(int(y0f)+1)
+
+	var out [4]float32
+	for c := range 4 {
+		top := pixels[(y0*w+x0)*4+c]*(1-tx) + pixels[(y0*w+x1)*4+c]*tx
+		bottom := pixels[(y1*w+x0)*4+c]*(1-tx) + pixels[(y1*w+x1)*4+c]*tx
+		out[c] = top*(1-ty) + bottom*ty
+	}
+	return out
+}
+
+// downsampleFloat halves a square RGBA float32 face with a 2x2 box filter.
+//
+// Parameters:
+//   - pixels: the face pixels
+//   - size: the face width and height in pixels
+//
+// Returns:
+//   - []float32: the downsampled face, max(size/2, 1) pixels square
+func downsampleFloat(pixels []float32, size uint32) []float32 {
+	outSize := max(size/2, 1)
+	out := make([]float32, outSize*outSize*4)
+	for y := range outSize {
+		y0, y1 := min(y*2, size-1), min(y*2+1, size-1)
+		for x := range outSize {
+			x0, x1 := min(x*2, size-1), min(x*2+1, size-1)
+			dst := (y*outSize + x) * 4
+			for c := range uint32(4) {
+				out[dst+c] = (pixels[(y0*size+x0)*4+c] + pixels[(y0*size+x1)*4+c] +
+					pixels[(y1*size+x0)*4+c] + pixels[(y1*size+x1)*4+c]) / 4
+			}
+		}
+	}
+	return out
+}
+
+// loadImageFile reads an image file into linear RGBA float32 pixels. Radiance .hdr files are
+// decoded as-is; PNG and JPEG color is converted from sRGB.
+//
+// Parameters:
+//   - path: the image file
+//
+// Returns:
+//   - []float32: the pixels, 4 floats per pixel, row-major from the top row
+//   - uint32: the image width in pixels
+//   - uint32: the image height in pixels
+//   - error: an error if the file cannot be read or decoded
+func loadImageFile(path string) ([]float32, uint32, uint32, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to read image %s: %w", path, err)
+	}
+	if common.IsHDR(data) {
+		pixels, width, height, err := common.DecodeHDR(data)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("failed to decode image %s: %w", path, err)
+		}
+		return pixels, width, height, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to decode image %s: %w", path, err)
+	}
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, img, bounds.Min, draw.Src)
+
+	pixels := make([]float32, len(rgba.Pix))
+	for i, v := range rgba.Pix {
+		if i%4 == 3 {
+			pixels[i] = float32(v) / 255
+		} else {
+			pixels[i] = common.SRGBToLinear(v)
+		}
+	}
+	return pixels, uint32(bounds.Dx()), uint32(bounds.Dy()), nil
+}
+
+// normalize returns v scaled to unit length, or v unchanged if it has zero length.
+//
+// Parameters:
+//   - v: the vector
+//
+// Returns:
+//   - [3]float32: the unit vector
+func normalize(v [3]float32) [3]float32 {
+	l := float32(math.Sqrt(float64(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])))
+	if l == 0 {
+		return v
+	}
+	return [3]float32{v[0] / l, v[1] / l, v[2] / l}
+}
+
+// abs32 returns the absolute value of f.
+//
+// Parameters:
+//   - f: the value
+//
+// Returns:
+//   - float32: |f|
+func abs32(f float32) float32 {
+	return float32(math.Abs(float64(f)))
+}
@@ -0,0 +1,226 @@
+package environment
+
+import (
+	"github.com/Carmen-Shannon/oxy-go/common"
+)
+
+// environmentImpl is the implementation of the Environment interface.
+type environmentImpl struct {
+	sky             Cubemap
+	specular        Cubemap
+	irradiance      [9][3]float32
+	intensity       float32
+	skyboxEnabled   bool
+	skyboxIntensity float32
+	skyboxBlur      float32
+	specularSize    uint32
+	specularLevels  int
+	specularSamples int
+}
+
+// Environment defines the interface for the distant surroundings of a scene: a sky cubemap drawn
+// behind all geometry, and the image-based lighting derived from it.
+//
+// Construction precomputes, on the CPU, the diffuse irradiance as order-2 spherical harmonics and
+// a specular cubemap prefiltered with the GGX distribution, one roughness per mip level. Together
+// with the shared split-sum BRDF lookup table (see BRDFLUT) these are what a lit shader needs for
+// ambient lighting. The scene uploads them once when the environment is attached and writes the
+// parameters each frame.
+type Environment interface {
+	// Sky returns the sky cubemap with its mip chain.
+	//
+	// Returns:
+	//   - Cubemap: the sky
+	Sky() Cubemap
+
+	// Specular returns the prefiltered specular cubemap. Mip level m holds the sky convolved for
+	// roughness m / (levels-1).
+	//
+	// Returns:
+	//   - Cubemap: the prefiltered specular cubemap
+	Specular() Cubemap
+
+	// Irradiance returns the diffuse irradiance / π as order-2 spherical harmonics.
+	//
+	// Returns:
+	//   - [9][3]float32: the RGB coefficients, ordered L00, L1-1, L10, L11, L2-2, L2-1, L20, L21, L22
+	Irradiance() [9][3]float32
+
+	// Intensity returns the multiplier applied to the image-based lighting.
+	//
+	// Returns:
+	//   - float32: the intensity
+	Intensity() float32
+
+	// SkyboxEnabled returns whether the sky is drawn behind the scene.
+	//
+	// Returns:
+	//   - bool: true if the skybox pass runs
+	SkyboxEnabled() bool
+
+	// SkyboxIntensity returns the multiplier applied to the drawn sky.
+	//
+	// Returns:
+	//   - float32: the intensity
+	SkyboxIntensity() float32
+
+	// SkyboxBlur returns the mip level the drawn sky is sampled at.
+	//
+	// Returns:
+	//   - float32: the mip level, 0 for the sharp sky
+	SkyboxBlur() float32
+
+	// SetIntensity sets the multiplier applied to the image-based lighting.
+	//
+	// Parameters:
+	//   - intensity: the intensity
+	SetIntensity(intensity float32)
+
+	// SetSkyboxEnabled sets whether the sky is drawn behind the scene.
+	// The lighting is unaffected.
+	//
+	// Parameters:
+	//   - enabled: true to draw the skybox
+	SetSkyboxEnabled(enabled bool)
+
+	// SetSkyboxIntensity sets the multiplier applied to the drawn sky.
+	//
+	// Parameters:
+	//   - intensity: the intensity
+	SetSkyboxIntensity(intensity float32)
+
+	// SetSkyboxBlur sets the mip level the drawn sky is sampled at. Fractional levels blend
+	// between mips.
+	//
+	// Parameters:
+	//   - blur: the mip level, 0 for the sharp sky
+	SetSkyboxBlur(blur float32)
+
+	// EnvironmentParams builds the uniform read by lit shaders.
+	//
+	// Returns:
+	//   - GPUEnvironmentParams: the GPU-ready parameters
+	EnvironmentParams() GPUEnvironmentParams
+
+	// SkyboxParams builds the uniform read by the skybox pass for a camera. The translation of
+	// the view matrix is dropped so the sky stays at infinity.
+	//
+	// Parameters:
+	//   - view: the camera view matrix, column-major
+	//   - projection: the camera projection matrix, column-major
+	//
+	// Returns:
+	//   - GPUSkyboxParams: the GPU-ready parameters
+	SkyboxParams(view, projection [16]float32) GPUSkyboxParams
+}
+
+var _ Environment = &environmentImpl{}
+
+// NewEnvironment creates a new Environment from a sky cubemap and precomputes its image-based
+// lighting. The precompute runs on the CPU and takes from a few milliseconds to around a second
+// depending on the specular size and sample count, so environments are best built at load time.
+//
+// Parameters:
+//   - sky: the sky cubemap, e.g. from LoadCubemap or LoadEquirectangular; existing mip levels are replaced
+//   - opts: variadic list of EnvironmentBuilderOption functions to configure the environment
+//
+// Returns:
+//   - Environment: a new Environment instance
+func NewEnvironment(sky Cubemap, opts ...EnvironmentBuilderOption) Environment {
+	if sky.Size == 0 {
+		panic("environment: sky cubemap has no faces")
+	}
+	for face := range 6 {
+		if uint32(len(sky.Faces[face])) != sky.Size*sky.Size*4 {
+			panic("environment: sky cubemap face has the wrong number of pixels")
+		}
+	}
+
+	e := &environmentImpl{
+		intensity:       1.0,
+		skyboxEnabled:   true,
+		skyboxIntensity: 1.0,
+		skyboxBlur:      0,
+		specularSize:    128,
+		specularLevels:  6,
+		specularSamples: 64,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.specularSize = max(min(e.specularSize, sky.Size), 1)
+	e.specularLevels = max(min(e.specularLevels, int(common.MipLevelCount(e.specularSize, e.specularSize))), 1)
+	e.specularSamples = max(e.specularSamples, 1)
+
+	e.sky = sky.WithMipmaps()
+	e.irradiance = projectIrradiance(e.sky)
+	e.specular = prefilterSpecular(e.sky, e.specularSize, e.specularLevels, e.specularSamples)
+	return e
+}
+
+func (e *environmentImpl) Sky() Cubemap {
+	return e.sky
+}
+
+func (e *environmentImpl) Specular() Cubemap {
+	return e.specular
+}
+
+func (e *environmentImpl) Irradiance() [9][3]float32 {
+	return e.irradiance
+}
+
+func (e *environmentImpl) Intensity() float32 {
+	return e.intensity
+}
+
+func (e *environmentImpl) SkyboxEnabled() bool {
+	return e.skyboxEnabled
+}
+
+func (e *environmentImpl) SkyboxIntensity() float32 {
+	return e.skyboxIntensity
+}
+
+func (e *environmentImpl) SkyboxBlur() float32 {
+	return e.skyboxBlur
+}
+
+func (e *environmentImpl) SetIntensity(intensity float32) {
+	e.intensity = intensity
+}
+
+func (e *environmentImpl) SetSkyboxEnabled(enabled bool) {
+	e.skyboxEnabled = enabled
+}
+
+func (e *environmentImpl) SetSkyboxIntensity(intensity float32) {
+	e.skyboxIntensity = intensity
+}
+
+func (e *environmentImpl) SetSkyboxBlur(blur float32) {
+	e.skyboxBlur = max(blur, 0)
+}
+
+func (e *environmentImpl) EnvironmentParams() GPUEnvironmentParams {
+	params := GPUEnvironmentParams{
+		Intensity:      e.intensity,
+		SpecularMaxLod: float32(e.specular.LevelCount() - 1),
+	}
+	for i, c := range e.irradiance {
+		params.Irradiance[i] = [4]float32{c[0], c[1], c[2], 0}
+	}
+	return params
+}
+
+func (e *environmentImpl) SkyboxParams(view, projection [16]float32) GPUSkyboxParams {
+	view[12], view[13], view[14] = 0, 0, 0
+	var viewProj, inv [16]float32
+	common.Mul4(viewProj[:], projection[:], view[:])
+	common.Invert4(inv[:], viewProj[:])
+	return GPUSkyboxParams{
+		InvViewProj: inv,
+		Intensity:   e.skyboxIntensity,
+		Lod:         e.skyboxBlur,
+	}
+}
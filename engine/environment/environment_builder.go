@@ -0,0 +1,98 @@
+package environment
+
+// EnvironmentBuilderOption is a function that configures an Environment instance during construction.
+type EnvironmentBuilderOption func(*environmentImpl)
+
+// WithIntensity is an option builder that sets the multiplier applied to the image-based lighting.
+//
+// Parameters:
+//   - intensity: the intensity value
+//
+// Returns:
+//   - EnvironmentBuilderOption: a function that applies the intensity option to an environmentImpl
+func WithIntensity(intensity float32) EnvironmentBuilderOption {
+	return func(e *environmentImpl) {
+		e.intensity = intensity
+	}
+}
+
+// WithSkyboxEnabled is an option builder that sets whether the sky is drawn behind the scene.
+//
+// Parameters:
+//   - enabled: true to draw the skybox
+//
+// Returns:
+//   - EnvironmentBuilderOption: a function that applies the skybox option to an environmentImpl
+func WithSkyboxEnabled(enabled bool) EnvironmentBuilderOption {
+	return func(e *environmentImpl) {
+		e.skyboxEnabled = enabled
+	}
+}
+
+// WithSkyboxIntensity is an option builder that sets the multiplier applied to the drawn sky.
+//
+// Parameters:
+//   - intensity: the intensity value
+//
+// Returns:
+//   - EnvironmentBuilderOption: a function that applies the skybox intensity option to an environmentImpl
+func WithSkyboxIntensity(intensity float32) EnvironmentBuilderOption {
+	return func(e *environmentImpl) {
+		e.skyboxIntensity = intensity
+	}
+}
+
+// WithSkyboxBlur is an option builder that sets the mip level the drawn sky is sampled at.
+//
+// Parameters:
+//   - blur: the mip level, 0 for the sharp sky
+//
+// Returns:
+//   - EnvironmentBuilderOption: a function that applies the skybox blur option to an environmentImpl
+func WithSkyboxBlur(blur float32) EnvironmentBuilderOption {
+	return func(e *environmentImpl) {
+		e.skyboxBlur = max(blur, 0)
+	}
+}
+
+// WithSpecularSize is an option builder that sets the face size of the base level of the
+// prefiltered specular cubemap. It is clamped to the sky size. Defaults to 128.
+//
+// Parameters:
+//   - size: the face size in pixels
+//
+// Returns:
+//   - EnvironmentBuilderOption: a function that applies the specular size option to an environmentImpl
+func WithSpecularSize(size uint32) EnvironmentBuilderOption {
+	return func(e *environmentImpl) {
+		e.specularSize = size
+	}
+}
+
+// WithSpecularLevels is an option builder that sets the number of roughness levels of the
+// prefiltered specular cubemap. It is clamped to the mip count of the specular size. Defaults to 6.
+//
+// Parameters:
+//   - levels: the number of mip levels
+//
+// Returns:
+//   - EnvironmentBuilderOption: a function that applies the specular levels option to an environmentImpl
+func WithSpecularLevels(levels int) EnvironmentBuilderOption {
+	return func(e *environmentImpl) {
+		e.specularLevels = levels
+	}
+}
+
+// WithSpecularSamples is an option builder that sets the number of GGX samples taken per texel
+// when prefiltering the specular cubemap. Defaults to 64.
+//
+// Parameters:
+//   - samples: the sample count
+//
+// Returns:
+//   - EnvironmentBuilderOption: a function that applies the specular samples option to an environmentImpl
+func WithSpecularSamples(samples int) EnvironmentBuilderOption {
+	return func(e *environmentImpl) {
+		e.specularSamples = samples
+	}
+}
@@ -0,0 +1,93 @@
+package environment
+
+import (
+	_ "embed"
+	"encoding/binary"
+	"math"
+	"unsafe"
+)
+
+// GPUEnvironmentParamsSource is the canonical WGSL definition of the EnvironmentParams struct.
+// Matches GPUEnvironmentParams layout exactly (160 bytes, std430 aligned).
+//
+//go:embed assets/environment_params.wgsl
+var GPUEnvironmentParamsSource string
+
+// GPUEnvironmentParams is the GPU-aligned uniform read by lit fragment shaders for image-based lighting.
+// Matches the WGSL EnvironmentParams struct layout exactly (see GPUEnvironmentParamsSource).
+// Size: 160 bytes.
+//
+// Layout:
+//
+//	array<vec4<f32>, 9> irradiance        (144 bytes, offset 0)
+//	f32                 intensity         (  4 bytes, offset 144)
+//	f32                 specular_max_lod  (  4 bytes, offset 148)
+//	f32                 _pad0, _pad1      (  8 bytes, offset 152)
+type GPUEnvironmentParams struct {
+	Irradiance     [9][4]float32 // order-2 spherical harmonics of irradiance / π, RGB in xyz
+	Intensity      float32       // scale applied to both the diffuse and specular environment terms
+	SpecularMaxLod float32       // mip level of the prefiltered specular map for roughness 1
+	_pad           [2]float32
+}
+
+// Size returns the size of the GPUEnvironmentParams struct in bytes.
+//
+// Returns:
+//   - int: the size of the struct in bytes.
+func (g *GPUEnvironmentParams) Size() int {
+	return int(unsafe.Sizeof(*g))
+}
+
+// Marshal serializes the GPUEnvironmentParams struct into a byte buffer suitable for GPU upload.
+//
+// Returns:
+//   - []byte: 160-byte buffer ready for GPU upload.
+func (g *GPUEnvironmentParams) Marshal() []byte {
+	buf := make([]byte, 160)
+	for i, c := range g.Irradiance {
+		for j, v := range c {
+			binary.LittleEndian.PutUint32(buf[i*16+j*4:], math.Float32bits(v))
+		}
+	}
+	binary.LittleEndian.PutUint32(buf[144:148], math.Float32bits(g.Intensity))
+	binary.LittleEndian.PutUint32(buf[148:152], math.Float32bits(g.SpecularMaxLod))
+	return buf
+}
+
+// GPUSkyboxParamsSource is the canonical WGSL definition of the SkyboxParams struct.
+// Matches GPUSkyboxParams layout exactly (80 bytes, std430 aligned).
+//
+//go:embed assets/skybox_params.wgsl
+var GPUSkyboxParamsSource string
+
+// GPUSkyboxParams is the GPU-aligned uniform for the skybox pass.
+// Matches the WGSL SkyboxParams struct layout exactly (see GPUSkyboxParamsSource).
+// Size: 80 bytes.
+type GPUSkyboxParams struct {
+	InvViewProj [16]float32 // offset 0: inverse of projection × rotation-only view, column-major
+	Intensity   float32     // offset 64: multiplier applied to the sky color
+	Lod         float32     // offset 68: mip level the sky is sampled at; higher is blurrier
+	_pad        [2]float32  // offset 72: padding to 80 bytes
+}
+
+// Size returns the size of the GPUSkyboxParams struct in bytes.
+//
+// Returns:
+//   - int: the size of the struct in bytes.
+func (g *GPUSkyboxParams) Size() int {
+	return int(unsafe.Sizeof(*g))
+}
+
+// Marshal serializes the GPUSkyboxParams struct into a byte buffer suitable for GPU upload.
+//
+// Returns:
+//   - []byte: 80-byte buffer ready for GPU upload.
+func (g *GPUSkyboxParams) Marshal() []byte {
+	buf := make([]byte, 80)
+	for i, v := range g.InvViewProj {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	binary.LittleEndian.PutUint32(buf[64:68], math.Float32bits(g.Intensity))
+	binary.LittleEndian.PutUint32(buf[68:72], math.Float32bits(g.Lod))
+	return buf
+}
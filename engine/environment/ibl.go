@@ -0,0 +1,371 @@
+package environment
+
+import (
+	"math"
+	"sync"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// BRDFLUTSize is the width and height in texels of the split-sum BRDF lookup table.
+const BRDFLUTSize = 128
+
+// brdfLUTSamples is the number of GGX samples integrated per BRDF lookup table texel.
+const brdfLUTSamples = 256
+
+// irradianceMaxSize caps the face size used for the spherical harmonics projection;
+// larger skies are read at the first mip level at or below it.
+const irradianceMaxSize = 32
+
+// Order-2 spherical harmonics basis constants.
+const (
+	shY00 = 0.282095
+	shY1  = 0.488603
+	shY2  = 1.092548
+	shY20 = 0.315392
+	shY22 = 0.546274
+)
+
+// shCosineLobe holds the cosine-lobe convolution factor of each SH band divided by π, which
+// turns projected radiance into irradiance / π: π/π, (2π/3)/π and (π/4)/π.
+var shCosineLobe = [9]float32{1, 2.0 / 3, 2.0 / 3, 2.0 / 3, 0.25, 0.25, 0.25, 0.25, 0.25}
+
+var (
+	brdfLUTOnce sync.Once
+	brdfLUT     []float32
+)
+
+// BRDFLUT returns the split-sum environment BRDF lookup table, computed on first use. Texel (x, y)
+// holds the scale and bias applied to F0 for N·V = (x+0.5)/BRDFLUTSize and roughness =
+// (y+0.5)/BRDFLUTSize, so a shader samples it at uv = (N·V, roughness).
+//
+// Returns:
+//   - []float32: BRDFLUTSize² texels of (scale, bias)
+func BRDFLUT() []float32 {
+	brdfLUTOnce.Do(func() {
+		brdfLUT = integrateBRDF(BRDFLUTSize, brdfLUTSamples)
+	})
+	return brdfLUT
+}
+
+// BRDFLUTStagingData packs the BRDF lookup table as RG16Float for GPU upload.
+//
+// Returns:
+//   - common.TextureStagingData: the staging data
+func BRDFLUTStagingData() common.TextureStagingData {
+	return common.TextureStagingData{
+		Pixels: common.PackHalfFloats(BRDFLUT()),
+		Width:  BRDFLUTSize,
+		Height: BRDFLUTSize,
+		Format: wgpu.TextureFormatRG16Float,
+	}
+}
+
+// projectIrradiance projects a cubemap onto order-2 spherical harmonics and convolves it with
+// the cosine lobe. Evaluating the result along a normal gives the irradiance / π arriving at a
+// surface facing that way, which is the diffuse radiance of a white Lambertian surface.
+//
+// Parameters:
+//   - c: the sky cubemap, with mip levels if it is large
+//
+// Returns:
+//   - [9][3]float32: the RGB coefficients, ordered L00, L1-1, L10, L11, L2-2, L2-1, L20, L21, L22
+func projectIrradiance(c Cubemap) [9][3]float32 {
+	level := 0
+	for level+1 < c.LevelCount() && max(c.Size>>level, 1) > irradianceMaxSize {
+		level++
+	}
+	faces, size := c.Level(level)
+
+	var sh [9][3]float64
+	var totalWeight float64
+	for face := range 6 {
+		pixels := faces[face]
+		for y := range size {
+			for x := range size {
+				u := (float32(x) + 0.5) / float32(size)
+				v := (float32(y) + 0.5) / float32(size)
+				s, t := float64(2*u-1), float64(2*v-1)
+				// Solid angle of the texel, up to the constant (2/size)² that the
+				// normalization by totalWeight below cancels.
+				weight := 1 / math.Pow(1+s*s+t*t, 1.5)
+				totalWeight += weight
+
+				basis := shBasis(faceDirection(face, u, v))
+				px := pixels[(y*size+x)*4:]
+				for i, b := range basis {
+					for ch := range 3 {
+						sh[i][ch] += float64(px[ch]) * float64(b) * weight
+					}
+				}
+			}
+		}
+	}
+
+	var out [9][3]float32
+	norm := 4 * math.Pi / totalWeight
+	for i := range sh {
+		for ch := range 3 {
+			out[i][ch] = float32(sh[i][ch]*norm) * shCosineLobe[i]
+		}
+	}
+	return out
+}
+
+// shBasis evaluates the nine order-2 real spherical harmonics basis functions along a direction.
+//
+// Parameters:
+//   - d: the unit direction
+//
+// Returns:
+//   - [9]float32: the basis values in coefficient order
+func shBasis(d [3]float32) [9]float32 {
+	x, y, z := d[0], d[1], d[2]
+	return [9]float32{
+		shY00,
+		shY1 * y,
+		shY1 * z,
+		shY1 * x,
+		shY2 * x * y,
+		shY2 * y * z,
+		shY20 * (3*z*z - 1),
+		shY2 * x * z,
+		shY22 * (x*x - y*y),
+	}
+}
+
+// prefilterSpecular convolves a sky cubemap with the GGX distribution for increasing roughness,
+// one roughness per mip level from 0 at the base level to 1 at the last. Each sample reads the
+// source at a mip level matched to the sample's solid angle, which keeps bright spots from
+// turning into speckles at low sample counts.
+//
+// Parameters:
+//   - src: the sky cubemap, with a full mip chain
+//   - size: the face size of the base level of the result
+//   - levels: the number of mip levels of the result
+//   - samples: the number of GGX samples per texel
+//
+// Returns:
+//   - Cubemap: the prefiltered cubemap
+func prefilterSpecular(src Cubemap, size uint32, levels, samples int) Cubemap {
+	out := Cubemap{Size: size}
+	texelSolidAngle := 4 * math.Pi / (6 * float64(src.Size) * float64(src.Size))
+
+	for level := range levels {
+		levelSize := max(size>>level, 1)
+		roughness := float32(0)
+		if levels > 1 {
+			roughness = float32(level) / float32(levels-1)
+		}
+
+		var faces [6][]float32
+		var wg sync.WaitGroup
+		for face := range 6 {
+			faces[face] = make([]float32, levelSize*levelSize*4)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				prefilterFace(src, faces[face], face, levelSize, roughness, samples, texelSolidAngle)
+			}()
+		}
+		wg.Wait()
+
+		if level == 0 {
+			out.Faces = faces
+		} else {
+			out.MipLevels = append(out.MipLevels, faces)
+		}
+	}
+	return out
+}
+
+// prefilterFace fills one face of one prefiltered specular level.
+//
+// Parameters:
+//   - src: the sky cubemap, with a full mip chain
+//   - out: the destination face pixels
+//   - face: the face index
+//   - size: the face size of the level
+//   - roughness: the perceptual roughness of the level
+//   - samples: the number of GGX samples per texel
+//   - texelSolidAngle: the solid angle of one texel of the source base level
+func prefilterFace(src Cubemap, out []float32, face int, size uint32, roughness float32, samples int, texelSolidAngle float64) {
+	for y := range size {
+		for x := range size {
+			n := faceDirection(face, (float32(x)+0.5)/float32(size), (float32(y)+0.5)/float32(size))
+			dst := out[(y*size+x)*4 : (y*size+x)*4+4]
+			dst[3] = 1
+
+			// A mirror reflection is the sky itself.
+			if roughness == 0 {
+				color := src.Sample(n, 0)
+				copy(dst[:3], color[:3])
+				continue
+			}
+
+			var color [3]float32
+			var weight float32
+			for i := range samples {
+				h := importanceSampleGGX(hammersley(uint32(i), uint32(samples)), n, roughness)
+				// With N = V the reflected direction is L = 2(N·H)H - N.
+				nDotH := dot(n, h)
+				l := [3]float32{2*nDotH*h[0] - n[0], 2*nDotH*h[1] - n[1], 2*nDotH*h[2] - n[2]}
+				nDotL := dot(n, l)
+				if nDotL <= 0 {
+					continue
+				}
+
+				// pdf of L is D(H)·(N·H) / (4·(V·H)), which is D/4 when N = V.
+				pdf := float64(distributionGGX(nDotH, roughness)) / 4
+				sampleSolidAngle := 1 / (float64(samples)*pdf + 1e-4)
+				lod := float32(max(0.5*math.Log2(sampleSolidAngle/texelSolidAngle)+1, 0))
+
+				c := src.Sample(l, lod)
+				color[0] += c[0] * nDotL
+				color[1] += c[1] * nDotL
+				color[2] += c[2] * nDotL
+				weight += nDotL
+			}
+			if weight > 0 {
+				dst[0], dst[1], dst[2] = color[0]/weight, color[1]/weight, color[2]/weight
+			}
+		}
+	}
+}
+
+// integrateBRDF computes the split-sum environment BRDF for a grid of N·V and roughness values.
+//
+// Parameters:
+//   - size: the width and height of the table
+//   - samples: the number of GGX samples per texel
+//
+// Returns:
+//   - []float32: size² texels of (scale, bias), rows by roughness and columns by N·V
+func integrateBRDF(size, samples int) []float32 {
+	out := make([]float32, size*size*2)
+	n := [3]float32{0, 0, 1}
+	for y := range size {
+		roughness := (float32(y) + 0.5) / float32(size)
+		alpha := roughness * roughness
+		k := alpha / 2
+		for x := range size {
+			nDotV := (float32(x) + 0.5) / float32(size)
+			v := [3]float32{float32(math.Sqrt(float64(1 - nDotV*nDotV))), 0, nDotV}
+
+			var scale, bias float32
+			for i := range samples {
+				h := importanceSampleGGX(hammersley(uint32(i), uint32(samples)), n, roughness)
+				vDotH := dot(v, h)
+				l := [3]float32{2*vDotH*h[0] - v[0], 2*vDotH*h[1] - v[1], 2*vDotH*h[2] - v[2]}
+				nDotL := max(l[2], 0)
+				nDotH := max(h[2], 0)
+				vDotH = max(vDotH, 0)
+				if nDotL <= 0 {
+					continue
+				}
+
+				g := (nDotV / (nDotV*(1-k) + k)) * (nDotL / (nDotL*(1-k) + k))
+				gVis := g * vDotH / (nDotH * nDotV)
+				fc := float32(math.Pow(float64(1-vDotH), 5))
+				scale += (1 - fc) * gVis
+				bias += fc * gVis
+			}
+			out[(y*size+x)*2] = scale / float32(samples)
+			out[(y*size+x)*2+1] = bias / float32(samples)
+		}
+	}
+	return out
+}
+
+// hammersley returns the i-th point of an n-point Hammersley set on the unit square.
+//
+// Parameters:
+//   - i: the point index
+//   - n: the number of points
+//
+// Returns:
+//   - [2]float32: the point
+func hammersley(i, n uint32) [2]float32 {
+	bits := i
+	bits = (bits << 16) | (bits >> 16)
+	bits = ((bits & 0x55555555) << 1) | ((bits & 0xAAAAAAAA) >> 1)
+	bits = ((bits & 0x33333333) << 2) | ((bits & 0xCCCCCCCC) >> 2)
+	bits = ((bits & 0x0F0F0F0F) << 4) | ((bits & 0xF0F0F0F0) >> 4)
+	bits = ((bits & 0x00FF00FF) << 8) | ((bits & 0xFF00FF00) >> 8)
+	return [2]float32{float32(i) / float32(n), float32(bits) * 2.3283064365386963e-10}
+}
+
+// importanceSampleGGX maps a point on the unit square to a GGX-distributed half vector around n.
+//
+// Parameters:
+//   - xi: the sample point
+//   - n: the unit surface normal
+//   - roughness: the perceptual roughness; the GGX alpha is its square
+//
+// Returns:
+//   - [3]float32: the unit half vector in world space
+func importanceSampleGGX(xi [2]float32, n [3]float32, roughness float32) [3]float32 {
+	a := float64(roughness * roughness)
+	phi := 2 * math.Pi * float64(xi[0])
+	cosTheta := math.Sqrt((1 - float64(xi[1])) / (1 + (a*a-1)*float64(xi[1])))
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+	hx := float32(sinTheta * math.Cos(phi))
+	hy := float32(sinTheta * math.Sin(phi))
+	hz := float32(cosTheta)
+
+	up := [3]float32{0, 0, 1}
+	if abs32(n[2]) >= 0.999 {
+		up = [3]float32{1, 0, 0}
+	}
+	t := normalize(cross(up, n))
+	b := cross(n, t)
+	return normalize([3]float32{
+		t[0]*hx + b[0]*hy + n[0]*hz,
+		t[1]*hx + b[1]*hy + n[1]*hz,
+		t[2]*hx + b[2]*hy + n[2]*hz,
+	})
+}
+
+// distributionGGX evaluates the GGX normal distribution function.
+//
+// Parameters:
+//   - nDotH: the cosine between the normal and the half vector
+//   - roughness: the perceptual roughness; the GGX alpha is its square
+//
+// Returns:
+//   - float32: the distribution value
+func distributionGGX(nDotH, roughness float32) float32 {
+	a := roughness * roughness
+	a2 := a * a
+	d := nDotH*nDotH*(a2-1) + 1
+	return a2 / (math.Pi * d * d)
+}
+
+// dot returns the dot product of a and b.
+//
+// Parameters:
+//   - a: the first vector
+//   - b: the second vector
+//
+// Returns:
+//   - float32: a·b
+func dot(a, b [3]float32) float32 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+// cross returns the cross product of a and b.
+//
+// Parameters:
+//   - a: the first vector
+//   - b: the second vector
+//
+// Returns:
+//   - [3]float32: a×b
+func cross(a, b [3]float32) [3]float32 {
+	return [3]float32{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
@@ -0,0 +1,17 @@
+package environment
+
+import (
+	_ "embed"
+)
+
+// SkyboxVertexSource is the vertex shader of the skybox pass. It draws a single fullscreen
+// triangle from the vertex index, with no vertex buffer.
+//
+//go:embed assets/skybox-vert.wgsl
+var SkyboxVertexSource string
+
+// SkyboxFragmentSource is the fragment shader of the skybox pass. It samples the sky cubemap
+// along the view direction of each pixel. Its bindings use the skybox provider identity.
+//
+//go:embed assets/skybox-frag.wgsl
+var SkyboxFragmentSource string
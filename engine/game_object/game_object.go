@@ -1,8 +1,10 @@
 package game_object
 
 import (
+	"math"
 	"sync/atomic"
 
+	"github.com/Carmen-Shannon/oxy-go/common"
 	"github.com/Carmen-Shannon/oxy-go/engine/light"
 	"github.com/Carmen-Shannon/oxy-go/engine/model"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/animator"
@@ -17,16 +19,39 @@ type gameObject struct {
 	animatorInstanceID int
 	attachedLight      light.Light
 
-	// initial transform state used before the object is added to a Scene
-	initialPosition      [3]float32
-	initialScale         [3]float32
-	initialRotation      [3]float32
-	initialRotationSpeed [3]float32
+	// local transform relative to the parent; for root objects this is the world transform
+	position      [3]float32
+	scale         [3]float32
+	rotation      [3]float32
+	rotationSpeed [3]float32
+
+	// hierarchy state; world is the cached world matrix, recomputed on demand when worldDirty
+	parent     *gameObject
+	children   []*gameObject
+	world      [16]float32
+	worldDirty bool
+	syncDirty  bool // the world transform changed since it was last written to the Animator
+	moved      bool // the transform changed since the last SnapshotTransform
+
+	// interpolation state captured at fixed-timestep boundaries in world space; prev/curr
+	// are the last two simulation states and blend is the value last written for rendering
+	prevPosition, prevScale, prevRotation    [3]float32
+	currPosition, currScale, currRotation    [3]float32
+	blendPosition, blendScale, blendRotation [3]float32
+	hasSnapshot                              bool
+	blended                                  bool
+
+	// rotation and speed last written to the Animator; the simple animator integrates the spin of
+	// rotating instances, so their rotation is only rewritten when it changes
+	writtenRotation, writtenRotationSpeed [3]float32
+	rotationWritten                       bool
 }
 
 // GameObject defines the interface for a scene entity bound to an Animator instance.
-// Position, rotation, and scale are derived from the Animator's internal arrays
-// via the animatorInstanceID, eliminating per-object data duplication.
+// Each object owns a local transform (position, Euler rotation, scale) relative to its
+// optional parent. World transforms are derived through the parent chain, cached, and
+// recomputed only when the object or one of its ancestors changes. The Animator instance
+// always holds the world transform that is rendered.
 type GameObject interface {
 	// ID returns the object's unique identifier.
 	//
@@ -65,36 +90,33 @@ type GameObject interface {
 	//   - int: the instance index, or -1 if unset
 	AnimatorInstanceID() int
 
-	// Position derives the instance's current position from the Animator.
-	// Returns zeros if no Animator is set.
+	// Position returns the object's local position relative to its parent.
+	// For root objects this is the world position.
 	//
 	// Returns:
 	//   - x, y, z: position components
 	Position() (x, y, z float32)
 
-	// Rotation derives the instance's current rotation from the Animator.
-	// Returns zeros if no Animator is set or on skeletal backends.
+	// Rotation returns the object's local Euler rotation relative to its parent.
 	//
 	// Returns:
 	//   - rx, ry, rz: rotation angles
 	Rotation() (rx, ry, rz float32)
 
-	// RotationSpeed derives the instance's current rotation speed from the Animator.
-	// Returns zeros if no Animator is set or on skeletal backends.
+	// RotationSpeed returns the object's rotation speed. The spin is applied to the
+	// object's own instance by the Animator and is not inherited by children.
 	//
 	// Returns:
 	//   - rx, ry, rz: rotation speed values
 	RotationSpeed() (rx, ry, rz float32)
 
-	// Scale derives the instance's current scale from the Animator.
-	// Returns zeros if no Animator is set.
+	// Scale returns the object's local scale relative to its parent.
 	//
 	// Returns:
 	//   - sx, sy, sz: scale components
 	Scale() (sx, sy, sz float32)
 
-	// TransformData reads all transform data from the Animator in a single pair of calls.
-	// Returns zeros if no Animator is set.
+	// TransformData reads the object's full local transform in a single call.
 	//
 	// Returns:
 	//   - pos: position as [3]float32 (x, y, z)
@@ -133,25 +155,26 @@ type GameObject interface {
 	//   - instanceID: the instance index
 	SetAnimatorInstanceID(instanceID int)
 
-	// SetPosition updates the instance's position via the Animator, preserving current scale.
+	// SetPosition sets the object's local position. Root objects write through to the
+	// Animator immediately; descendants are updated on the scene's next SyncTransforms.
 	//
 	// Parameters:
 	//   - x, y, z: new position components
 	SetPosition(x, y, z float32)
 
-	// SetRotation updates the instance's rotation via the Animator, preserving current rotation speed.
+	// SetRotation sets the object's local Euler rotation.
 	//
 	// Parameters:
 	//   - rx, ry, rz: new rotation angles
 	SetRotation(rx, ry, rz float32)
 
-	// SetRotationSpeed updates the instance's rotation speed via the Animator, preserving current rotation.
+	// SetRotationSpeed sets the object's rotation speed.
 	//
 	// Parameters:
 	//   - rx, ry, rz: new rotation speed values
 	SetRotationSpeed(rx, ry, rz float32)
 
-	// SetScale updates the instance's scale via the Animator, preserving current position.
+	// SetScale sets the object's local scale.
 	//
 	// Parameters:
 	//   - sx, sy, sz: new scale factors
@@ -163,6 +186,57 @@ type GameObject interface {
 	//   - light.Light: the attached light or nil
 	Light() light.Light
 
+	// Parent returns the object's parent, or nil for a root object.
+	//
+	// Returns:
+	//   - GameObject: the parent or nil
+	Parent() GameObject
+
+	// Children returns a copy of the object's direct children.
+	//
+	// Returns:
+	//   - []GameObject: the children
+	Children() []GameObject
+
+	// SetParent reparents the object while keeping its world transform: the local transform
+	// is recomputed relative to the new parent. Pass nil to make the object a root.
+	// Panics if the new parent is the object itself or one of its descendants.
+	//
+	// Parameters:
+	//   - parent: the new parent, or nil
+	SetParent(parent GameObject)
+
+	// WorldMatrix returns the object's column-major world matrix, composed from the local
+	// transforms of the object and its ancestors. The result is cached until the object or
+	// an ancestor changes.
+	//
+	// Returns:
+	//   - [16]float32: the world matrix
+	WorldMatrix() [16]float32
+
+	// WorldPosition returns the object's world-space position.
+	//
+	// Returns:
+	//   - x, y, z: position components
+	WorldPosition() (x, y, z float32)
+
+	// WorldRotation returns the object's world-space Euler rotation.
+	//
+	// Returns:
+	//   - rx, ry, rz: rotation angles
+	WorldRotation() (rx, ry, rz float32)
+
+	// WorldScale returns the object's world-space scale. Shear introduced by non-uniform
+	// parent scale combined with child rotation cannot be represented and is discarded.
+	//
+	// Returns:
+	//   - sx, sy, sz: scale components
+	WorldScale() (sx, sy, sz float32)
+
+	// SyncAnimator writes the world transform of this object and of every descendant whose
+	// transform changed since its last sync into their Animator instances. Called by the scene.
+	SyncAnimator()
+
 	// SetLight attaches a Light to this object. When the object is added to a
 	// scene, the scene will automatically sync the light's position from the
 	// object's transform each frame. Pass nil to detach.
@@ -170,6 +244,31 @@ type GameObject interface {
 	// Parameters:
 	//   - l: the Light to attach, or nil to detach
 	SetLight(l light.Light)
+
+	// SnapshotTransform records the object's current transform as the latest simulation
+	// state, shifting the previously recorded state into the "previous" slot. Called by
+	// the scene at the end of every fixed simulation step.
+	SnapshotTransform()
+
+	// RestoreTransform writes the object's exact world transform back to the Animator if
+	// the instance currently holds an interpolated transform written by InterpolateTransform.
+	// Called by the scene at the start of every fixed simulation step.
+	RestoreTransform()
+
+	// InterpolateTransform blends between the last two simulation states and writes the
+	// result to the Animator for rendering. Rotation is blended along the shorter arc; objects
+	// with a rotation speed only blend position and scale, keeping the spin integrated by the
+	// Animator. No-op until at least one snapshot has been recorded, when the last two states
+	// are equal, and while the object has been moved since the last snapshot so the new
+	// transform is shown as set.
+	//
+	// Parameters:
+	//   - alpha: blend factor in [0, 1] where 0 is the previous state and 1 the latest
+	InterpolateTransform(alpha float32)
+
+	// ResetInterpolation collapses both recorded simulation states to the object's
+	// current transform. Call after teleporting an object to avoid blending across the jump.
+	ResetInterpolation()
 }
 
 var _ GameObject = &gameObject{}
@@ -183,7 +282,9 @@ var _ GameObject = &gameObject{}
 //   - GameObject: the newly created object
 func NewGameObject(options ...GameObjectBuilderOption) GameObject {
 	obj := &gameObject{
-		initialScale: [3]float32{1, 1, 1},
+		scale:      [3]float32{1, 1, 1},
+		worldDirty: true,
+		syncDirty:  true,
 	}
 	for _, option := range options {
 		option(obj)
@@ -216,44 +317,23 @@ func (g *gameObject) AnimatorInstanceID() int {
 }
 
 func (g *gameObject) Position() (x, y, z float32) {
-	if g.animator == nil {
-		return g.initialPosition[0], g.initialPosition[1], g.initialPosition[2]
-	}
-	pos, _ := g.animator.InstanceTransform(uint32(g.animatorInstanceID))
-	return pos[0], pos[1], pos[2]
+	return g.position[0], g.position[1], g.position[2]
 }
 
 func (g *gameObject) Rotation() (rx, ry, rz float32) {
-	if g.animator == nil {
-		return g.initialRotation[0], g.initialRotation[1], g.initialRotation[2]
-	}
-	_, rot := g.animator.InstanceRotation(uint32(g.animatorInstanceID))
-	return rot[0], rot[1], rot[2]
+	return g.rotation[0], g.rotation[1], g.rotation[2]
 }
 
 func (g *gameObject) RotationSpeed() (rx, ry, rz float32) {
-	if g.animator == nil {
-		return g.initialRotationSpeed[0], g.initialRotationSpeed[1], g.initialRotationSpeed[2]
-	}
-	rotSpeed, _ := g.animator.InstanceRotation(uint32(g.animatorInstanceID))
-	return rotSpeed[0], rotSpeed[1], rotSpeed[2]
+	return g.rotationSpeed[0], g.rotationSpeed[1], g.rotationSpeed[2]
 }
 
 func (g *gameObject) Scale() (sx, sy, sz float32) {
-	if g.animator == nil {
-		return g.initialScale[0], g.initialScale[1], g.initialScale[2]
-	}
-	_, scale := g.animator.InstanceTransform(uint32(g.animatorInstanceID))
-	return scale[0], scale[1], scale[2]
+	return g.scale[0], g.scale[1], g.scale[2]
 }
 
 func (g *gameObject) TransformData() (pos, scale, rot, rotSpeed [3]float32) {
-	if g.animator == nil {
-		return g.initialPosition, g.initialScale, g.initialRotation, g.initialRotationSpeed
-	}
-	pos, scale = g.animator.InstanceTransform(uint32(g.animatorInstanceID))
-	rotSpeed, rot = g.animator.InstanceRotation(uint32(g.animatorInstanceID))
-	return
+	return g.position, g.scale, g.rotation, g.rotationSpeed
 }
 
 func (g *gameObject) SetID(id uint64) {
@@ -270,52 +350,280 @@ func (g *gameObject) SetModel(m model.Model) {
 
 func (g *gameObject) SetAnimator(anim animator.Animator) {
 	g.animator = anim
+	g.syncDirty = true
+	g.rotationWritten = false
 }
 
 func (g *gameObject) SetAnimatorInstanceID(instanceID int) {
 	g.animatorInstanceID = instanceID
+	g.syncDirty = true
+	g.rotationWritten = false
 }
 
 func (g *gameObject) SetPosition(x, y, z float32) {
-	if g.animator == nil {
-		g.initialPosition = [3]float32{x, y, z}
+	g.position = [3]float32{x, y, z}
+	g.transformChanged()
+}
+
+func (g *gameObject) SetRotation(rx, ry, rz float32) {
+	g.rotation = [3]float32{rx, ry, rz}
+	g.transformChanged()
+}
+
+func (g *gameObject) SetRotationSpeed(rx, ry, rz float32) {
+	g.rotationSpeed = [3]float32{rx, ry, rz}
+	g.transformChanged()
+}
+
+func (g *gameObject) SetScale(sx, sy, sz float32) {
+	g.scale = [3]float32{sx, sy, sz}
+	g.transformChanged()
+}
+
+func (g *gameObject) Light() light.Light {
+	return g.attachedLight
+}
+
+func (g *gameObject) SetLight(l light.Light) {
+	g.attachedLight = l
+}
+
+func (g *gameObject) Parent() GameObject {
+	if g.parent == nil {
+		return nil
+	}
+	return g.parent
+}
+
+func (g *gameObject) Children() []GameObject {
+	out := make([]GameObject, len(g.children))
+	for i, c := range g.children {
+		out[i] = c
+	}
+	return out
+}
+
+func (g *gameObject) SetParent(parent GameObject) {
+	var p *gameObject
+	if parent != nil {
+		p = parent.(*gameObject)
+	}
+	if p == g.parent {
 		return
 	}
-	_, scale := g.animator.InstanceTransform(uint32(g.animatorInstanceID))
-	g.animator.SetInstanceTransform(uint32(g.animatorInstanceID), [3]float32{x, y, z}, scale)
+	for a := p; a != nil; a = a.parent {
+		if a == g {
+			panic("game_object: SetParent would create a cycle")
+		}
+	}
+
+	world := g.WorldMatrix()
+	g.detach()
+
+	local := world
+	if p != nil {
+		p.children = append(p.children, g)
+		parentWorld := p.WorldMatrix()
+		var inv [16]float32
+		if common.Invert4(inv[:], parentWorld[:]) {
+			common.Mul4(local[:], inv[:], world[:])
+		}
+	}
+	g.parent = p
+	g.position, g.rotation, g.scale = common.DecomposeModelMatrix(local[:])
+	g.transformChanged()
 }
 
-func (g *gameObject) SetRotation(rx, ry, rz float32) {
-	if g.animator == nil {
-		g.initialRotation = [3]float32{rx, ry, rz}
+func (g *gameObject) WorldMatrix() [16]float32 {
+	if g.worldDirty {
+		var local [16]float32
+		common.BuildModelMatrix(local[:],
+			g.position[0], g.position[1], g.position[2],
+			g.rotation[0], g.rotation[1], g.rotation[2],
+			g.scale[0], g.scale[1], g.scale[2])
+		if g.parent != nil {
+			parentWorld := g.parent.WorldMatrix()
+			common.Mul4(g.world[:], parentWorld[:], local[:])
+		} else {
+			g.world = local
+		}
+		g.worldDirty = false
+	}
+	return g.world
+}
+
+func (g *gameObject) WorldPosition() (x, y, z float32) {
+	pos, _, _ := g.worldTransform()
+	return pos[0], pos[1], pos[2]
+}
+
+func (g *gameObject) WorldRotation() (rx, ry, rz float32) {
+	_, rot, _ := g.worldTransform()
+	return rot[0], rot[1], rot[2]
+}
+
+func (g *gameObject) WorldScale() (sx, sy, sz float32) {
+	_, _, scale := g.worldTransform()
+	return scale[0], scale[1], scale[2]
+}
+
+func (g *gameObject) SyncAnimator() {
+	if g.syncDirty {
+		g.pushWorld()
+	}
+	for _, c := range g.children {
+		c.SyncAnimator()
+	}
+}
+
+func (g *gameObject) SnapshotTransform() {
+	pos, rot, scale := g.worldTransform()
+	if !g.hasSnapshot {
+		g.prevPosition, g.prevScale, g.prevRotation = pos, scale, rot
+		g.hasSnapshot = true
+	} else {
+		g.prevPosition, g.prevScale, g.prevRotation = g.currPosition, g.currScale, g.currRotation
+	}
+	g.currPosition, g.currScale, g.currRotation = pos, scale, rot
+	g.blended = false
+	g.moved = false
+}
+
+func (g *gameObject) RestoreTransform() {
+	if g.blended {
+		g.pushWorld()
+	}
+	g.blended = false
+}
+
+func (g *gameObject) InterpolateTransform(alpha float32) {
+	if !g.hasSnapshot || g.animator == nil || g.animatorInstanceID < 0 || g.moved {
 		return
 	}
-	rotSpeed, _ := g.animator.InstanceRotation(uint32(g.animatorInstanceID))
-	g.animator.SetInstanceRotation(uint32(g.animatorInstanceID), rotSpeed, [3]float32{rx, ry, rz})
+	// The instance already holds the latest state when the object did not change in the last step.
+	if g.prevPosition == g.currPosition && g.prevScale == g.currScale && g.prevRotation == g.currRotation {
+		return
+	}
+
+	alpha = min(max(alpha, 0), 1)
+	spinning := g.rotationSpeed != [3]float32{}
+	for i := range 3 {
+		g.blendPosition[i] = g.prevPosition[i] + (g.currPosition[i]-g.prevPosition[i])*alpha
+		g.blendScale[i] = g.prevScale[i] + (g.currScale[i]-g.prevScale[i])*alpha
+		if spinning {
+			g.blendRotation[i] = g.currRotation[i]
+		} else {
+			g.blendRotation[i] = lerpAngle(g.prevRotation[i], g.currRotation[i], alpha)
+		}
+	}
+
+	g.writeInstance(g.blendPosition, g.blendScale, g.blendRotation)
+	g.blended = true
 }
 
-func (g *gameObject) SetRotationSpeed(rx, ry, rz float32) {
-	if g.animator == nil {
-		g.initialRotationSpeed = [3]float32{rx, ry, rz}
+func (g *gameObject) ResetInterpolation() {
+	pos, rot, scale := g.worldTransform()
+	g.prevPosition, g.prevScale, g.prevRotation = pos, scale, rot
+	g.currPosition, g.currScale, g.currRotation = pos, scale, rot
+	g.hasSnapshot = true
+	g.blended = false
+	g.moved = false
+}
+
+// transformChanged invalidates the cached world transform of the object and all of its
+// descendants. Root objects write their new transform to the Animator immediately since
+// their world transform is their local transform; descendants wait for SyncAnimator.
+func (g *gameObject) transformChanged() {
+	g.markDirty()
+	if g.parent == nil && g.animator != nil {
+		g.pushWorld()
+	}
+}
+
+// markDirty flags the object and its descendants as needing a world matrix rebuild and
+// an Animator sync.
+func (g *gameObject) markDirty() {
+	g.worldDirty = true
+	g.syncDirty = true
+	g.moved = true
+	for _, c := range g.children {
+		c.markDirty()
+	}
+}
+
+// detach removes the object from its current parent's child list.
+func (g *gameObject) detach() {
+	if g.parent == nil {
 		return
 	}
-	_, rot := g.animator.InstanceRotation(uint32(g.animatorInstanceID))
-	g.animator.SetInstanceRotation(uint32(g.animatorInstanceID), [3]float32{rx, ry, rz}, rot)
+	siblings := g.parent.children
+	for i, c := range siblings {
+		if c == g {
+			g.parent.children = append(siblings[:i], siblings[i+1:]...)
+			break
+		}
+	}
+	g.parent = nil
 }
 
-func (g *gameObject) SetScale(sx, sy, sz float32) {
-	if g.animator == nil {
-		g.initialScale = [3]float32{sx, sy, sz}
+// worldTransform returns the object's world transform decomposed into position, rotation,
+// and scale. Root objects return their local transform directly to avoid decomposition error.
+//
+// Returns:
+//   - pos: world position
+//   - rot: world Euler rotation
+//   - scale: world scale
+func (g *gameObject) worldTransform() (pos, rot, scale [3]float32) {
+	if g.parent == nil {
+		return g.position, g.rotation, g.scale
+	}
+	world := g.WorldMatrix()
+	return common.DecomposeModelMatrix(world[:])
+}
+
+// writeInstance writes a transform into the object's Animator instance. The simple animator
+// advances the rotation of instances with a rotation speed on the GPU, so the rotation of a
+// spinning object is only written when it or the speed differs from what was last written;
+// otherwise only position and scale are written and the integrated spin is kept.
+//
+// Parameters:
+//   - pos: world position
+//   - scale: world scale
+//   - rot: world Euler rotation
+func (g *gameObject) writeInstance(pos, scale, rot [3]float32) {
+	index := uint32(g.animatorInstanceID)
+	spinning := g.rotationSpeed != [3]float32{} && g.animator.BackendType() == animator.BackendTypeSimple
+	if spinning && g.rotationWritten && rot == g.writtenRotation && g.rotationSpeed == g.writtenRotationSpeed {
+		g.animator.SetInstanceTransform(index, pos, scale)
 		return
 	}
-	pos, _ := g.animator.InstanceTransform(uint32(g.animatorInstanceID))
-	g.animator.SetInstanceTransform(uint32(g.animatorInstanceID), pos, [3]float32{sx, sy, sz})
+	g.animator.SetInstanceData(index, pos, scale, g.rotationSpeed, rot)
+	g.writtenRotation, g.writtenRotationSpeed = rot, g.rotationSpeed
+	g.rotationWritten = true
 }
 
-func (g *gameObject) Light() light.Light {
-	return g.attachedLight
+// lerpAngle interpolates between two angles along the shorter arc, so blending across the
+// 2π wrap does not spin the long way round.
+//
+// Parameters:
+//   - a: the start angle in radians
+//   - b: the end angle in radians
+//   - t: the blend factor in [0, 1]
+//
+// Returns:
+//   - float32: the blended angle
+func lerpAngle(a, b, t float32) float32 {
+	d := float32(math.Remainder(float64(b-a), 2*math.Pi))
+	return a + d*t
 }
 
-func (g *gameObject) SetLight(l light.Light) {
-	g.attachedLight = l
+// pushWorld writes the object's world transform into its Animator instance, keeping the
+// GPU-integrated rotation of spinning objects (see writeInstance).
+func (g *gameObject) pushWorld() {
+	if g.animator == nil || g.animatorInstanceID < 0 {
+		return
+	}
+	pos, rot, scale := g.worldTransform()
+	g.writeInstance(pos, scale, rot)
+	g.syncDirty = false
 }
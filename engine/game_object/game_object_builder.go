@@ -62,7 +62,7 @@ func WithModel(m model.Model) GameObjectBuilderOption {
 	}
 }
 
-// WithPosition sets the initial position of the GameObject before it is added to a Scene.
+// WithPosition sets the initial local position of the GameObject.
 //
 // Parameters:
 //   - x: the x position
@@ -73,11 +73,11 @@ func WithModel(m model.Model) GameObjectBuilderOption {
 //   - GameObjectBuilderOption: functional option to set the initial position
 func WithPosition(x, y, z float32) GameObjectBuilderOption {
 	return func(obj *gameObject) {
-		obj.initialPosition = [3]float32{x, y, z}
+		obj.position = [3]float32{x, y, z}
 	}
 }
 
-// WithScale sets the initial scale of the GameObject before it is added to a Scene.
+// WithScale sets the initial local scale of the GameObject.
 //
 // Parameters:
 //   - sx: the x scale factor
@@ -88,11 +88,11 @@ func WithPosition(x, y, z float32) GameObjectBuilderOption {
 //   - GameObjectBuilderOption: functional option to set the initial scale
 func WithScale(sx, sy, sz float32) GameObjectBuilderOption {
 	return func(obj *gameObject) {
-		obj.initialScale = [3]float32{sx, sy, sz}
+		obj.scale = [3]float32{sx, sy, sz}
 	}
 }
 
-// WithRotation sets the initial rotation of the GameObject before it is added to a Scene.
+// WithRotation sets the initial local rotation of the GameObject.
 //
 // Parameters:
 //   - rx: the x rotation angle
@@ -103,11 +103,11 @@ func WithScale(sx, sy, sz float32) GameObjectBuilderOption {
 //   - GameObjectBuilderOption: functional option to set the initial rotation
 func WithRotation(rx, ry, rz float32) GameObjectBuilderOption {
 	return func(obj *gameObject) {
-		obj.initialRotation = [3]float32{rx, ry, rz}
+		obj.rotation = [3]float32{rx, ry, rz}
 	}
 }
 
-// WithRotationSpeed sets the initial rotation speed of the GameObject before it is added to a Scene.
+// WithRotationSpeed sets the initial rotation speed of the GameObject.
 //
 // Parameters:
 //   - rx: the x rotation speed
@@ -118,7 +118,7 @@ func WithRotation(rx, ry, rz float32) GameObjectBuilderOption {
 //   - GameObjectBuilderOption: functional option to set the initial rotation speed
 func WithRotationSpeed(rx, ry, rz float32) GameObjectBuilderOption {
 	return func(obj *gameObject) {
-		obj.initialRotationSpeed = [3]float32{rx, ry, rz}
+		obj.rotationSpeed = [3]float32{rx, ry, rz}
 	}
 }
 
@@ -136,3 +136,19 @@ func WithLight(l light.Light) GameObjectBuilderOption {
 		obj.attachedLight = l
 	}
 }
+
+// WithParent makes the GameObject a child of parent. Unlike SetParent, the transform set by
+// WithPosition, WithRotation, and WithScale is kept as the local transform relative to parent.
+//
+// Parameters:
+//   - parent: the parent object
+//
+// Returns:
+//   - GameObjectBuilderOption: functional option to set the parent
+func WithParent(parent GameObject) GameObjectBuilderOption {
+	return func(obj *gameObject) {
+		p := parent.(*gameObject)
+		obj.parent = p
+		p.children = append(p.children, obj)
+	}
+}
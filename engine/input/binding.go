@@ -0,0 +1,350 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BindingMapVersion is the current version of the serialized BindingMap format.
+const BindingMapVersion = 1
+
+// Source identifies the kind of physical input a Binding reads from.
+type Source int
+
+const (
+	// SourceKey reads a keyboard key. Digital: 1 while held.
+	SourceKey Source = iota
+
+	// SourceMouseButton reads a mouse button. Digital: 1 while held.
+	SourceMouseButton
+
+	// SourceMouseX reads the horizontal mouse delta for the current tick, in pixels.
+	SourceMouseX
+
+	// SourceMouseY reads the vertical mouse delta for the current tick, in pixels (positive = down).
+	SourceMouseY
+
+	// SourceScrollX reads the horizontal scroll delta for the current tick.
+	SourceScrollX
+
+	// SourceScrollY reads the vertical scroll delta for the current tick (positive = up).
+	SourceScrollY
+
+	// SourceGamepadButton reads a gamepad button in the standard layout. Digital: 1 while held.
+	SourceGamepadButton
+
+	// SourceGamepadAxis reads a gamepad stick or trigger axis with dead zones applied.
+	SourceGamepadAxis
+)
+
+// AnyGamepad matches every connected gamepad in a Binding or gamepad query.
+const AnyGamepad = -1
+
+var sourceNames = map[Source]string{
+	SourceKey:         "key",
+	SourceMouseButton: "mouse_button",
+	SourceMouseX:      "mouse_x",
+	SourceMouseY:      "mouse_y",
+	SourceScrollX:     "scroll_x",
+	SourceScrollY:     "scroll_y",
+
+	SourceGamepadButton: "gamepad_button",
+	SourceGamepadAxis:   "gamepad_axis",
+}
+
+// String returns the serialized name of the source.
+func (s Source) String() string {
+	if name, ok := sourceNames[s]; ok {
+		return name
+	}
+	return "source(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Digital returns whether the source is a button-like input with discrete held state.
+//
+// Returns:
+//   - bool: true for keys, mouse buttons, and gamepad buttons
+func (s Source) Digital() bool {
+	return s == SourceKey || s == SourceMouseButton || s == SourceGamepadButton
+}
+
+// hasCode returns whether bindings of this source identify their input with a Code.
+func (s Source) hasCode() bool {
+	return s.Digital() || s == SourceGamepadAxis
+}
+
+// MarshalText encodes the source as its serialized name.
+func (s Source) MarshalText() ([]byte, error) {
+	name, ok := sourceNames[s]
+	if !ok {
+		return nil, fmt.Errorf("input: unknown source %d", int(s))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText decodes a source from its serialized name.
+func (s *Source) UnmarshalText(text []byte) error {
+	for src, name := range sourceNames {
+		if name == string(text) {
+			*s = src
+			return nil
+		}
+	}
+	return fmt.Errorf("input: unknown source %q", string(text))
+}
+
+// Binding maps a single physical input to an action or axis contribution.
+// Digital sources (keys, mouse buttons, gamepad buttons) contribute Scale while held; analog
+// sources (mouse motion, scroll, gamepad axes) contribute their value multiplied by Scale.
+type Binding struct {
+	// Source is the kind of physical input.
+	Source Source
+
+	// Code is the key, mouse button, gamepad button, or gamepad axis code.
+	// Ignored for mouse motion and scroll sources.
+	Code uint32
+
+	// Gamepad is the gamepad slot read by gamepad sources, or AnyGamepad.
+	Gamepad int
+
+	// Scale multiplies the binding's value. Zero is treated as 1.
+	Scale float32
+
+	// Modifiers is a bitmask of modifier keys (common.ModShift etc.) that must all be held
+	// for a digital binding to be active. Zero requires no modifiers.
+	Modifiers uint32
+}
+
+// KeyBinding creates a Binding for a keyboard key.
+//
+// Parameters:
+//   - keyCode: the virtual key code (see common key codes)
+//
+// Returns:
+//   - Binding: the key binding with a scale of 1
+func KeyBinding(keyCode uint32) Binding {
+	return Binding{Source: SourceKey, Code: keyCode, Scale: 1}
+}
+
+// MouseButtonBinding creates a Binding for a mouse button.
+//
+// Parameters:
+//   - button: the mouse button code (see common mouse button codes)
+//
+// Returns:
+//   - Binding: the mouse button binding with a scale of 1
+func MouseButtonBinding(button uint32) Binding {
+	return Binding{Source: SourceMouseButton, Code: button, Scale: 1}
+}
+
+// MouseXBinding creates a Binding for horizontal mouse motion.
+//
+// Parameters:
+//   - scale: multiplier applied to the per-tick pixel delta
+//
+// Returns:
+//   - Binding: the mouse X binding
+func MouseXBinding(scale float32) Binding {
+	return Binding{Source: SourceMouseX, Scale: scale}
+}
+
+// MouseYBinding creates a Binding for vertical mouse motion.
+//
+// Parameters:
+//   - scale: multiplier applied to the per-tick pixel delta
+//
+// Returns:
+//   - Binding: the mouse Y binding
+func MouseYBinding(scale float32) Binding {
+	return Binding{Source: SourceMouseY, Scale: scale}
+}
+
+// ScrollXBinding creates a Binding for horizontal scrolling.
+//
+// Parameters:
+//   - scale: multiplier applied to the per-tick scroll delta
+//
+// Returns:
+//   - Binding: the scroll X binding
+func ScrollXBinding(scale float32) Binding {
+	return Binding{Source: SourceScrollX, Scale: scale}
+}
+
+// ScrollYBinding creates a Binding for vertical scrolling.
+//
+// Parameters:
+//   - scale: multiplier applied to the per-tick scroll delta
+//
+// Returns:
+//   - Binding: the scroll Y binding
+func ScrollYBinding(scale float32) Binding {
+	return Binding{Source: SourceScrollY, Scale: scale}
+}
+
+// GamepadButtonBinding creates a Binding for a standard-layout gamepad button on any gamepad.
+//
+// Parameters:
+//   - button: the gamepad button code (see common gamepad button codes)
+//
+// Returns:
+//   - Binding: the gamepad button binding with a scale of 1
+func GamepadButtonBinding(button uint32) Binding {
+	return Binding{Source: SourceGamepadButton, Code: button, Scale: 1, Gamepad: AnyGamepad}
+}
+
+// GamepadAxisBinding creates a Binding for a standard-layout gamepad axis on any gamepad.
+//
+// Parameters:
+//   - axis: the gamepad axis code (see common gamepad axis codes)
+//   - scale: multiplier applied to the axis value (use -1 to make stick up positive)
+//
+// Returns:
+//   - Binding: the gamepad axis binding
+func GamepadAxisBinding(axis uint32, scale float32) Binding {
+	return Binding{Source: SourceGamepadAxis, Code: axis, Scale: scale, Gamepad: AnyGamepad}
+}
+
+// ForGamepad returns a copy of the binding that only reads the given gamepad slot.
+//
+// Parameters:
+//   - id: the gamepad slot, or AnyGamepad
+//
+// Returns:
+//   - Binding: the binding restricted to one gamepad
+func (b Binding) ForGamepad(id int) Binding {
+	b.Gamepad = id
+	return b
+}
+
+// Scaled returns a copy of the binding with the given scale.
+//
+// Parameters:
+//   - scale: the new scale
+//
+// Returns:
+//   - Binding: the scaled binding
+func (b Binding) Scaled(scale float32) Binding {
+	b.Scale = scale
+	return b
+}
+
+// WithModifiers returns a copy of the binding that requires the given modifier bits.
+//
+// Parameters:
+//   - mods: bitmask of required modifiers (common.ModShift | common.ModControl ...)
+//
+// Returns:
+//   - Binding: the binding with required modifiers
+func (b Binding) WithModifiers(mods uint32) Binding {
+	b.Modifiers = mods
+	return b
+}
+
+// scale returns the effective scale, treating zero as 1.
+func (b Binding) scale() float32 {
+	if b.Scale == 0 {
+		return 1
+	}
+	return b.Scale
+}
+
+// bindingJSON is the on-disk representation of a Binding. Codes and modifiers are written
+// as readable names where one is known so binding files can be edited by hand.
+type bindingJSON struct {
+	Source    Source          `json:"source"`
+	Code      json.RawMessage `json:"code,omitempty"`
+	Gamepad   *int            `json:"gamepad,omitempty"`
+	Scale     float32         `json:"scale,omitempty"`
+	Modifiers []string        `json:"modifiers,omitempty"`
+}
+
+// MarshalJSON encodes the binding with named key, button, and modifier values.
+func (b Binding) MarshalJSON() ([]byte, error) {
+	out := bindingJSON{Source: b.Source}
+	if b.Scale != 1 {
+		out.Scale = b.Scale
+	}
+
+	if b.Source.hasCode() {
+		var err error
+		if name, ok := codeName(b.Source, b.Code); ok {
+			out.Code, err = json.Marshal(name)
+		} else {
+			out.Code, err = json.Marshal(b.Code)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if isGamepadSource(b.Source) && b.Gamepad != AnyGamepad {
+		id := b.Gamepad
+		out.Gamepad = &id
+	}
+
+	out.Modifiers = modifierNames(b.Modifiers)
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a binding, accepting either names or numeric codes.
+func (b *Binding) UnmarshalJSON(data []byte) error {
+	var in bindingJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*b = Binding{Source: in.Source, Scale: in.Scale}
+	if b.Scale == 0 {
+		b.Scale = 1
+	}
+
+	if len(in.Code) > 0 {
+		var name string
+		if err := json.Unmarshal(in.Code, &name); err == nil {
+			code, ok := codeByName(b.Source, name)
+			if !ok {
+				return fmt.Errorf("input: unknown %s name %q", b.Source, name)
+			}
+			b.Code = code
+		} else if err := json.Unmarshal(in.Code, &b.Code); err != nil {
+			return fmt.Errorf("input: invalid code %s: %w", string(in.Code), err)
+		}
+	}
+
+	if isGamepadSource(b.Source) {
+		b.Gamepad = AnyGamepad
+		if in.Gamepad != nil {
+			b.Gamepad = *in.Gamepad
+		}
+	}
+
+	for _, name := range in.Modifiers {
+		bit, ok := modifierBit(strings.TrimSpace(name))
+		if !ok {
+			return fmt.Errorf("input: unknown modifier %q", name)
+		}
+		b.Modifiers |= bit
+	}
+	return nil
+}
+
+// isGamepadSource returns whether a source reads from a gamepad.
+func isGamepadSource(s Source) bool {
+	return s == SourceGamepadButton || s == SourceGamepadAxis
+}
+
+// Axis2DBinding holds the per-component bindings of a two-dimensional axis.
+type Axis2DBinding struct {
+	X []Binding `json:"x,omitempty"`
+	Y []Binding `json:"y,omitempty"`
+}
+
+// BindingMap is the serializable set of every action and axis bound on an Input.
+type BindingMap struct {
+	Version int                      `json:"version"`
+	Actions map[string][]Binding     `json:"actions,omitempty"`
+	Axes    map[string][]Binding     `json:"axes,omitempty"`
+	Axes2D  map[string]Axis2DBinding `json:"axes2d,omitempty"`
+}
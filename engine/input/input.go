@@ -0,0 +1,1035 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/window"
+)
+
+// Input tracks keyboard and mouse state per simulation tick and resolves named actions and axes.
+// Raw events are buffered as they arrive from the window and folded into queryable state when
+// Update is called, so every query made during a single tick sees a consistent snapshot.
+// Input implements window.InputListener, so synthetic events can be injected directly.
+type Input interface {
+	window.InputListener
+
+	// Update folds all events received since the previous Update into the per-tick state.
+	// The engine calls this once at the start of every tick, and on every wake of its tick
+	// loop that runs no tick, such as while paused or at a time scale of 0.
+	Update()
+
+	// Attach subscribes the Input to a window's raw input events, detaching from any previous window.
+	//
+	// Parameters:
+	//   - w: the Window to observe
+	Attach(w window.Window)
+
+	// Detach unsubscribes the Input from its current window, if any.
+	Detach()
+
+	// KeyPressed returns whether the key went down during the current tick.
+	//
+	// Parameters:
+	//   - keyCode: the virtual key code
+	//
+	// Returns:
+	//   - bool: true if the key was pressed this tick
+	KeyPressed(keyCode uint32) bool
+
+	// KeyHeld returns whether the key is currently down.
+	//
+	// Parameters:
+	//   - keyCode: the virtual key code
+	//
+	// Returns:
+	//   - bool: true if the key is held
+	KeyHeld(keyCode uint32) bool
+
+	// KeyReleased returns whether the key went up during the current tick.
+	//
+	// Parameters:
+	//   - keyCode: the virtual key code
+	//
+	// Returns:
+	//   - bool: true if the key was released this tick
+	KeyReleased(keyCode uint32) bool
+
+	// MouseButtonPressed returns whether the button went down during the current tick.
+	//
+	// Parameters:
+	//   - button: the mouse button code
+	//
+	// Returns:
+	//   - bool: true if the button was pressed this tick
+	MouseButtonPressed(button uint32) bool
+
+	// MouseButtonHeld returns whether the button is currently down.
+	//
+	// Parameters:
+	//   - button: the mouse button code
+	//
+	// Returns:
+	//   - bool: true if the button is held
+	MouseButtonHeld(button uint32) bool
+
+	// MouseButtonReleased returns whether the button went up during the current tick.
+	//
+	// Parameters:
+	//   - button: the mouse button code
+	//
+	// Returns:
+	//   - bool: true if the button was released this tick
+	MouseButtonReleased(button uint32) bool
+
+	// Modifiers returns the modifier bits (common.ModShift etc.) derived from the held modifier keys.
+	//
+	// Returns:
+	//   - uint32: bitmask of held modifiers
+	Modifiers() uint32
+
+	// MousePosition returns the last known cursor position in window coordinates.
+	//
+	// Returns:
+	//   - float32: cursor x
+	//   - float32: cursor y
+	MousePosition() (float32, float32)
+
+	// MouseDelta returns the cursor movement accumulated during the current tick.
+	//
+	// Returns:
+	//   - float32: horizontal delta in pixels
+	//   - float32: vertical delta in pixels (positive = down)
+	MouseDelta() (float32, float32)
+
+	// ScrollDelta returns the scroll offset accumulated during the current tick.
+	//
+	// Returns:
+	//   - float32: horizontal scroll (positive = right)
+	//   - float32: vertical scroll (positive = up)
+	ScrollDelta() (float32, float32)
+
+	// Gamepads returns the slots of all connected gamepads in ascending order.
+	//
+	// Returns:
+	//   - []int: connected gamepad slots
+	Gamepads() []int
+
+	// GamepadConnected returns whether a gamepad is connected.
+	//
+	// Parameters:
+	//   - id: the gamepad slot, or AnyGamepad
+	//
+	// Returns:
+	//   - bool: true if the gamepad (or any gamepad) is connected
+	GamepadConnected(id int) bool
+
+	// GamepadButtonPressed returns whether the gamepad button went down during the current tick.
+	//
+	// Parameters:
+	//   - id: the gamepad slot, or AnyGamepad
+	//   - button: the gamepad button code
+	//
+	// Returns:
+	//   - bool: true if the button was pressed this tick
+	GamepadButtonPressed(id int, button uint32) bool
+
+	// GamepadButtonHeld returns whether the gamepad button is currently down.
+	//
+	// Parameters:
+	//   - id: the gamepad slot, or AnyGamepad
+	//   - button: the gamepad button code
+	//
+	// Returns:
+	//   - bool: true if the button is held
+	GamepadButtonHeld(id int, button uint32) bool
+
+	// GamepadButtonReleased returns whether the gamepad button went up during the current tick.
+	//
+	// Parameters:
+	//   - id: the gamepad slot, or AnyGamepad
+	//   - button: the gamepad button code
+	//
+	// Returns:
+	//   - bool: true if the button was released this tick
+	GamepadButtonReleased(id int, button uint32) bool
+
+	// GamepadAxis returns a gamepad axis value with dead zones applied. With AnyGamepad,
+	// the value with the largest magnitude across all gamepads is returned.
+	//
+	// Parameters:
+	//   - id: the gamepad slot, or AnyGamepad
+	//   - axis: the gamepad axis code
+	//
+	// Returns:
+	//   - float32: sticks in [-1, 1], triggers in [0, 1]
+	GamepadAxis(id int, axis uint32) float32
+
+	// SetDeadZones sets the gamepad dead zones. Stick dead zones are radial: a stick is read
+	// as centered until its deflection exceeds the threshold, then rescaled to the full range.
+	// Takes effect at the next Update.
+	//
+	// Parameters:
+	//   - stick: radial dead zone for both sticks, in [0, 1)
+	//   - trigger: dead zone for both triggers, in [0, 1)
+	SetDeadZones(stick, trigger float32)
+
+	// DeadZones returns the current gamepad dead zones.
+	//
+	// Returns:
+	//   - float32: the stick dead zone
+	//   - float32: the trigger dead zone
+	DeadZones() (float32, float32)
+
+	// BindAction binds a named action to one or more inputs, replacing any previous bindings.
+	// The action is active while any of its bindings is active.
+	//
+	// Parameters:
+	//   - name: the action name
+	//   - bindings: the inputs that trigger the action
+	BindAction(name string, bindings ...Binding)
+
+	// BindAxis binds a named one-dimensional axis, replacing any previous bindings.
+	// The axis value is the sum of every binding's scaled value.
+	//
+	// Parameters:
+	//   - name: the axis name
+	//   - bindings: the inputs that contribute to the axis
+	BindAxis(name string, bindings ...Binding)
+
+	// BindAxis2D binds a named two-dimensional axis, replacing any previous bindings.
+	// The digital (key and button) contribution is normalized so diagonals are not faster
+	// than cardinal directions; analog contributions are added unclamped.
+	//
+	// Parameters:
+	//   - name: the axis name
+	//   - x: the inputs that contribute to the horizontal component
+	//   - y: the inputs that contribute to the vertical component
+	BindAxis2D(name string, x, y []Binding)
+
+	// Unbind removes any action or axis registered under the given name.
+	//
+	// Parameters:
+	//   - name: the action or axis name
+	Unbind(name string)
+
+	// ActionPressed returns whether the action became active during the current tick.
+	//
+	// Parameters:
+	//   - name: the action name
+	//
+	// Returns:
+	//   - bool: true if the action was triggered this tick
+	ActionPressed(name string) bool
+
+	// ActionHeld returns whether the action is currently active.
+	//
+	// Parameters:
+	//   - name: the action name
+	//
+	// Returns:
+	//   - bool: true if any binding of the action is active
+	ActionHeld(name string) bool
+
+	// ActionReleased returns whether the action stopped being active during the current tick.
+	//
+	// Parameters:
+	//   - name: the action name
+	//
+	// Returns:
+	//   - bool: true if the action was released this tick
+	ActionReleased(name string) bool
+
+	// Axis returns the current value of a one-dimensional axis, or 0 if it is not bound.
+	//
+	// Parameters:
+	//   - name: the axis name
+	//
+	// Returns:
+	//   - float32: the axis value
+	Axis(name string) float32
+
+	// Axis2D returns the current value of a two-dimensional axis, or (0, 0) if it is not bound.
+	//
+	// Parameters:
+	//   - name: the axis name
+	//
+	// Returns:
+	//   - float32: the horizontal component
+	//   - float32: the vertical component
+	Axis2D(name string) (float32, float32)
+
+	// Bindings returns a copy of every action and axis binding.
+	//
+	// Returns:
+	//   - BindingMap: the current bindings
+	Bindings() BindingMap
+
+	// SetBindings replaces every action and axis binding. The map's slices are copied, and a
+	// map without a version, such as an empty JSON object, is rejected.
+	//
+	// Parameters:
+	//   - m: the bindings to apply
+	//
+	// Returns:
+	//   - error: an error if the map's version is unsupported
+	SetBindings(m BindingMap) error
+
+	// SaveBindings writes every action and axis binding as indented JSON.
+	//
+	// Parameters:
+	//   - w: the destination writer
+	//
+	// Returns:
+	//   - error: an error if encoding or writing fails
+	SaveBindings(w io.Writer) error
+
+	// LoadBindings reads JSON bindings written by SaveBindings and replaces the current bindings.
+	//
+	// Parameters:
+	//   - r: the source reader
+	//
+	// Returns:
+	//   - error: an error if decoding fails or the version is unsupported
+	LoadBindings(r io.Reader) error
+}
+
+// Default gamepad dead zones.
+const (
+	defaultStickDeadZone   float32 = 0.15
+	defaultTriggerDeadZone float32 = 0.05
+)
+
+// Button state bits tracked per key and mouse button.
+const (
+	stateHeld uint8 = 1 << iota
+	statePressed
+	stateReleased
+)
+
+type eventKind int
+
+const (
+	eventKeyDown eventKind = iota
+	eventKeyUp
+	eventButtonDown
+	eventButtonUp
+	eventMove
+	eventDelta
+	eventScroll
+	eventGamepadConnected
+	eventGamepadDisconnected
+	eventGamepadState
+)
+
+// event is a raw input event buffered between ticks.
+type event struct {
+	kind    eventKind
+	code    uint32
+	x, y    float32
+	gamepad int
+	state   window.GamepadState
+}
+
+// gamepad is the per-tick state of one connected gamepad.
+type gamepad struct {
+	buttons [window.GamepadButtonCount]uint8
+	raw     [window.GamepadAxisCount]float32 // axes as reported by the window
+	axes    [window.GamepadAxisCount]float32 // axes with dead zones applied
+}
+
+// actionState is the resolved per-tick state of a named action.
+type actionState struct {
+	held     bool
+	pressed  bool
+	released bool
+}
+
+type input struct {
+	eventsMu sync.Mutex
+	events   []event
+
+	mu           sync.RWMutex
+	win          window.Window
+	keys         map[uint32]uint8
+	buttons      map[uint32]uint8
+	mouseX       float32
+	mouseY       float32
+	deltaX       float32
+	deltaY       float32
+	scrollX      float32
+	scrollY      float32
+	gamepads     map[int]*gamepad
+	stickDZ      float32
+	triggerDZ    float32
+	actions      map[string][]Binding
+	axes         map[string][]Binding
+	axes2D       map[string]Axis2DBinding
+	actionStates map[string]actionState
+}
+
+var _ Input = &input{}
+
+// NewInput creates a new Input with the given options.
+// When a window is supplied via WithWindow, the Input subscribes to its events immediately.
+//
+// Parameters:
+//   - options: variadic InputBuilderOption functions to configure the Input
+//
+// Returns:
+//   - Input: the newly created Input instance
+func NewInput(options ...InputBuilderOption) Input {
+	in := &input{
+		keys:         make(map[uint32]uint8),
+		buttons:      make(map[uint32]uint8),
+		gamepads:     make(map[int]*gamepad),
+		stickDZ:      defaultStickDeadZone,
+		triggerDZ:    defaultTriggerDeadZone,
+		actions:      make(map[string][]Binding),
+		axes:         make(map[string][]Binding),
+		axes2D:       make(map[string]Axis2DBinding),
+		actionStates: make(map[string]actionState),
+	}
+
+	for _, opt := range options {
+		opt(in)
+	}
+
+	if in.win != nil {
+		in.win.AddInputListener(in)
+	}
+	return in
+}
+
+func (in *input) OnKeyDown(keyCode uint32) {
+	in.push(event{kind: eventKeyDown, code: keyCode})
+}
+
+func (in *input) OnKeyUp(keyCode uint32) {
+	in.push(event{kind: eventKeyUp, code: keyCode})
+}
+
+func (in *input) OnMouseButtonDown(button uint32, x, y int32) {
+	in.push(event{kind: eventButtonDown, code: button, x: float32(x), y: float32(y)})
+}
+
+func (in *input) OnMouseButtonUp(button uint32, x, y int32) {
+	in.push(event{kind: eventButtonUp, code: button, x: float32(x), y: float32(y)})
+}
+
+func (in *input) OnMouseMove(x, y int32) {
+	in.push(event{kind: eventMove, x: float32(x), y: float32(y)})
+}
+
+func (in *input) OnMouseDelta(dx, dy float32) {
+	in.push(event{kind: eventDelta, x: dx, y: dy})
+}
+
+func (in *input) OnScroll(xDelta, yDelta float32) {
+	in.push(event{kind: eventScroll, x: xDelta, y: yDelta})
+}
+
+func (in *input) OnGamepadConnected(id int) {
+	in.push(event{kind: eventGamepadConnected, gamepad: id})
+}
+
+func (in *input) OnGamepadDisconnected(id int) {
+	in.push(event{kind: eventGamepadDisconnected, gamepad: id})
+}
+
+func (in *input) OnGamepadState(id int, state window.GamepadState) {
+	in.push(event{kind: eventGamepadState, gamepad: id, state: state})
+}
+
+func (in *input) Update() {
+	in.eventsMu.Lock()
+	events := in.events
+	in.events = nil
+	in.eventsMu.Unlock()
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	clearEdges(in.keys)
+	clearEdges(in.buttons)
+	for _, pad := range in.gamepads {
+		for i, s := range pad.buttons {
+			pad.buttons[i] = s &^ (statePressed | stateReleased)
+		}
+	}
+	in.deltaX, in.deltaY = 0, 0
+	in.scrollX, in.scrollY = 0, 0
+
+	for _, ev := range events {
+		switch ev.kind {
+		case eventKeyDown:
+			press(in.keys, ev.code)
+		case eventKeyUp:
+			release(in.keys, ev.code)
+		case eventButtonDown:
+			press(in.buttons, ev.code)
+			in.moveTo(ev.x, ev.y)
+		case eventButtonUp:
+			release(in.buttons, ev.code)
+			in.moveTo(ev.x, ev.y)
+		case eventMove:
+			in.moveTo(ev.x, ev.y)
+		case eventDelta:
+			in.deltaX += ev.x
+			in.deltaY += ev.y
+		case eventScroll:
+			in.scrollX += ev.x
+			in.scrollY += ev.y
+		case eventGamepadConnected:
+			if _, ok := in.gamepads[ev.gamepad]; !ok {
+				in.gamepads[ev.gamepad] = &gamepad{}
+			}
+		case eventGamepadDisconnected:
+			if pad, ok := in.gamepads[ev.gamepad]; ok {
+				// Release everything so actions bound to the pad do not stay held.
+				in.applyGamepadState(pad, window.GamepadState{})
+				delete(in.gamepads, ev.gamepad)
+			}
+		case eventGamepadState:
+			if pad, ok := in.gamepads[ev.gamepad]; ok {
+				in.applyGamepadState(pad, ev.state)
+			}
+		}
+	}
+
+	for _, pad := range in.gamepads {
+		in.applyDeadZones(pad)
+	}
+
+	mods := in.modifiersLocked()
+	for name, bindings := range in.actions {
+		prev := in.actionStates[name]
+		var next actionState
+		for _, b := range bindings {
+			held, pressed, released := in.bindingEdges(b, mods)
+			next.held = next.held || held
+			next.pressed = next.pressed || pressed
+			next.released = next.released || released
+		}
+		// Edges of individual bindings only count when they change the action as a whole:
+		// pressing a second key while the first is held does not re-trigger the action.
+		next.pressed = (next.pressed && !prev.held) || (next.held && !prev.held)
+		next.released = (next.released && !next.held) || (!next.held && prev.held)
+		in.actionStates[name] = next
+	}
+}
+
+func (in *input) Attach(w window.Window) {
+	in.Detach()
+
+	in.mu.Lock()
+	in.win = w
+	in.mu.Unlock()
+
+	if w != nil {
+		w.AddInputListener(in)
+	}
+}
+
+func (in *input) Detach() {
+	in.mu.Lock()
+	w := in.win
+	in.win = nil
+	in.mu.Unlock()
+
+	if w != nil {
+		w.RemoveInputListener(in)
+	}
+}
+
+func (in *input) KeyPressed(keyCode uint32) bool {
+	return in.keyState(keyCode)&statePressed != 0
+}
+
+func (in *input) KeyHeld(keyCode uint32) bool {
+	return in.keyState(keyCode)&stateHeld != 0
+}
+
+func (in *input) KeyReleased(keyCode uint32) bool {
+	return in.keyState(keyCode)&stateReleased != 0
+}
+
+func (in *input) MouseButtonPressed(button uint32) bool {
+	return in.buttonState(button)&statePressed != 0
+}
+
+func (in *input) MouseButtonHeld(button uint32) bool {
+	return in.buttonState(button)&stateHeld != 0
+}
+
+func (in *input) MouseButtonReleased(button uint32) bool {
+	return in.buttonState(button)&stateReleased != 0
+}
+
+func (in *input) Modifiers() uint32 {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.modifiersLocked()
+}
+
+func (in *input) MousePosition() (float32, float32) {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.mouseX, in.mouseY
+}
+
+func (in *input) MouseDelta() (float32, float32) {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.deltaX, in.deltaY
+}
+
+func (in *input) ScrollDelta() (float32, float32) {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.scrollX, in.scrollY
+}
+
+func (in *input) Gamepads() []int {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	ids := slices.Collect(maps.Keys(in.gamepads))
+	slices.Sort(ids)
+	return ids
+}
+
+func (in *input) GamepadConnected(id int) bool {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	if id == AnyGamepad {
+		return len(in.gamepads) > 0
+	}
+	_, ok := in.gamepads[id]
+	return ok
+}
+
+func (in *input) GamepadButtonPressed(id int, button uint32) bool {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.gamepadButtonState(id, button)&statePressed != 0
+}
+
+func (in *input) GamepadButtonHeld(id int, button uint32) bool {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.gamepadButtonState(id, button)&stateHeld != 0
+}
+
+func (in *input) GamepadButtonReleased(id int, button uint32) bool {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.gamepadButtonState(id, button)&stateReleased != 0
+}
+
+func (in *input) GamepadAxis(id int, axis uint32) float32 {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.gamepadAxis(id, axis)
+}
+
+func (in *input) SetDeadZones(stick, trigger float32) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.stickDZ = clampDeadZone(stick)
+	in.triggerDZ = clampDeadZone(trigger)
+}
+
+func (in *input) DeadZones() (float32, float32) {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.stickDZ, in.triggerDZ
+}
+
+func (in *input) BindAction(name string, bindings ...Binding) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.unbindLocked(name)
+	in.actions[name] = slices.Clone(bindings)
+}
+
+func (in *input) BindAxis(name string, bindings ...Binding) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.unbindLocked(name)
+	in.axes[name] = slices.Clone(bindings)
+}
+
+func (in *input) BindAxis2D(name string, x, y []Binding) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.unbindLocked(name)
+	in.axes2D[name] = Axis2DBinding{X: slices.Clone(x), Y: slices.Clone(y)}
+}
+
+func (in *input) Unbind(name string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.unbindLocked(name)
+}
+
+func (in *input) ActionPressed(name string) bool {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.actionStates[name].pressed
+}
+
+func (in *input) ActionHeld(name string) bool {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.actionStates[name].held
+}
+
+func (in *input) ActionReleased(name string) bool {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.actionStates[name].released
+}
+
+func (in *input) Axis(name string) float32 {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+
+	bindings, ok := in.axes[name]
+	if !ok {
+		return 0
+	}
+	digital, analog := in.sumBindings(bindings)
+	return digital + analog
+}
+
+func (in *input) Axis2D(name string) (float32, float32) {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+
+	axis, ok := in.axes2D[name]
+	if !ok {
+		return 0, 0
+	}
+
+	dx, ax := in.sumBindings(axis.X)
+	dy, ay := in.sumBindings(axis.Y)
+	if length := float32(math.Hypot(float64(dx), float64(dy))); length > 1 {
+		dx /= length
+		dy /= length
+	}
+	return dx + ax, dy + ay
+}
+
+func (in *input) Bindings() BindingMap {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+
+	m := BindingMap{
+		Version: BindingMapVersion,
+		Actions: make(map[string][]Binding, len(in.actions)),
+		Axes:    make(map[string][]Binding, len(in.axes)),
+		Axes2D:  make(map[string]Axis2DBinding, len(in.axes2D)),
+	}
+	for name, b := range in.actions {
+		m.Actions[name] = slices.Clone(b)
+	}
+	for name, b := range in.axes {
+		m.Axes[name] = slices.Clone(b)
+	}
+	for name, a := range in.axes2D {
+		m.Axes2D[name] = Axis2DBinding{X: slices.Clone(a.X), Y: slices.Clone(a.Y)}
+	}
+	return m
+}
+
+func (in *input) SetBindings(m BindingMap) error {
+	if m.Version < 1 || m.Version > BindingMapVersion {
+		return fmt.Errorf("input: unsupported binding map version %d (want 1 to %d)", m.Version, BindingMapVersion)
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.actions = make(map[string][]Binding, len(m.Actions))
+	in.axes = make(map[string][]Binding, len(m.Axes))
+	in.axes2D = make(map[string]Axis2DBinding, len(m.Axes2D))
+	in.actionStates = make(map[string]actionState)
+	for name, b := range m.Actions {
+		in.actions[name] = slices.Clone(b)
+	}
+	for name, b := range m.Axes {
+		in.axes[name] = slices.Clone(b)
+	}
+	for name, a := range m.Axes2D {
+		in.axes2D[name] = Axis2DBinding{X: slices.Clone(a.X), Y: slices.Clone(a.Y)}
+	}
+	return nil
+}
+
+func (in *input) SaveBindings(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(in.Bindings())
+}
+
+func (in *input) LoadBindings(r io.Reader) error {
+	var m BindingMap
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return fmt.Errorf("input: failed to decode bindings: %w", err)
+	}
+	return in.SetBindings(m)
+}
+
+// push buffers a raw event until the next Update. Consecutive cursor moves, mouse deltas and
+// scrolls are merged into one event so motion between updates does not grow the buffer.
+func (in *input) push(ev event) {
+	in.eventsMu.Lock()
+	defer in.eventsMu.Unlock()
+	if n := len(in.events); n > 0 && in.events[n-1].kind == ev.kind {
+		last := &in.events[n-1]
+		switch ev.kind {
+		case eventMove:
+			last.x, last.y = ev.x, ev.y
+			return
+		case eventDelta, eventScroll:
+			last.x += ev.x
+			last.y += ev.y
+			return
+		}
+	}
+	in.events = append(in.events, ev)
+}
+
+// moveTo updates the cursor position. Motion is accumulated separately from OnMouseDelta
+// so captured-cursor deltas are not truncated to whole pixels.
+func (in *input) moveTo(x, y float32) {
+	in.mouseX, in.mouseY = x, y
+}
+
+func (in *input) keyState(keyCode uint32) uint8 {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.keys[keyCode]
+}
+
+func (in *input) buttonState(button uint32) uint8 {
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+	return in.buttons[button]
+}
+
+// modifiersLocked derives modifier bits from held modifier keys. Caller must hold mu.
+func (in *input) modifiersLocked() uint32 {
+	var mods uint32
+	if in.keys[common.KeyLeftShift]&stateHeld != 0 || in.keys[common.KeyRightShift]&stateHeld != 0 {
+		mods |= common.ModShift
+	}
+	if in.keys[common.KeyLeftControl]&stateHeld != 0 || in.keys[common.KeyRightControl]&stateHeld != 0 {
+		mods |= common.ModControl
+	}
+	if in.keys[common.KeyLeftAlt]&stateHeld != 0 || in.keys[common.KeyRightAlt]&stateHeld != 0 {
+		mods |= common.ModAlt
+	}
+	if in.keys[common.KeyLeftSuper]&stateHeld != 0 || in.keys[common.KeyRightSuper]&stateHeld != 0 {
+		mods |= common.ModSuper
+	}
+	return mods
+}
+
+// bindingEdges returns the held, pressed, and released state of a binding for the current tick.
+// Analog bindings are held while their value is non-zero and never report edges. Caller must hold mu.
+func (in *input) bindingEdges(b Binding, mods uint32) (bool, bool, bool) {
+	if !b.Source.Digital() {
+		return in.bindingValue(b, mods) != 0, false, false
+	}
+
+	var state uint8
+	switch b.Source {
+	case SourceKey:
+		state = in.keys[b.Code]
+	case SourceMouseButton:
+		state = in.buttons[b.Code]
+	case SourceGamepadButton:
+		state = in.gamepadButtonState(b.Gamepad, b.Code)
+	}
+	if mods&b.Modifiers != b.Modifiers {
+		// A release still counts so actions do not get stuck when the modifier is let go first.
+		return false, false, state&stateReleased != 0
+	}
+	return state&stateHeld != 0, state&statePressed != 0, state&stateReleased != 0
+}
+
+// bindingValue returns the scaled value of a binding for the current tick. Caller must hold mu.
+func (in *input) bindingValue(b Binding, mods uint32) float32 {
+	switch b.Source {
+	case SourceKey, SourceMouseButton, SourceGamepadButton:
+		held, _, _ := in.bindingEdges(b, mods)
+		if held {
+			return b.scale()
+		}
+		return 0
+	case SourceMouseX:
+		return in.deltaX * b.scale()
+	case SourceMouseY:
+		return in.deltaY * b.scale()
+	case SourceScrollX:
+		return in.scrollX * b.scale()
+	case SourceScrollY:
+		return in.scrollY * b.scale()
+	case SourceGamepadAxis:
+		return in.gamepadAxis(b.Gamepad, b.Code) * b.scale()
+	}
+	return 0
+}
+
+// applyGamepadState folds a new gamepad snapshot into a pad's button edges and raw axes. Caller must hold mu.
+func (in *input) applyGamepadState(pad *gamepad, state window.GamepadState) {
+	for i, down := range state.Buttons {
+		held := pad.buttons[i]&stateHeld != 0
+		switch {
+		case down && !held:
+			pad.buttons[i] |= statePressed | stateHeld
+		case !down && held:
+			pad.buttons[i] = (pad.buttons[i] | stateReleased) &^ stateHeld
+		}
+	}
+	pad.raw = state.Axes
+}
+
+// applyDeadZones recomputes a pad's processed axes from its raw axes. Sticks use a scaled
+// radial dead zone so diagonal movement is not clipped; triggers use a linear one. Caller must hold mu.
+func (in *input) applyDeadZones(pad *gamepad) {
+	sticks := [][2]int{
+		{common.GamepadAxisLeftX, common.GamepadAxisLeftY},
+		{common.GamepadAxisRightX, common.GamepadAxisRightY},
+	}
+	for _, s := range sticks {
+		x, y := pad.raw[s[0]], pad.raw[s[1]]
+		mag := float32(math.Hypot(float64(x), float64(y)))
+		if mag <= in.stickDZ {
+			pad.axes[s[0]], pad.axes[s[1]] = 0, 0
+			continue
+		}
+		scale := min((mag-in.stickDZ)/(1-in.stickDZ), 1) / mag
+		pad.axes[s[0]], pad.axes[s[1]] = x*scale, y*scale
+	}
+
+	for _, t := range []int{common.GamepadAxisLeftTrigger, common.GamepadAxisRightTrigger} {
+		v := pad.raw[t]
+		if v <= in.triggerDZ {
+			pad.axes[t] = 0
+			continue
+		}
+		pad.axes[t] = min((v-in.triggerDZ)/(1-in.triggerDZ), 1)
+	}
+}
+
+// gamepadButtonState returns the state bits of a gamepad button, merged across pads for AnyGamepad.
+// Caller must hold mu.
+func (in *input) gamepadButtonState(id int, button uint32) uint8 {
+	if button >= window.GamepadButtonCount {
+		return 0
+	}
+	if id != AnyGamepad {
+		if pad, ok := in.gamepads[id]; ok {
+			return pad.buttons[button]
+		}
+		return 0
+	}
+	var state uint8
+	for _, pad := range in.gamepads {
+		state |= pad.buttons[button]
+	}
+	return state
+}
+
+// gamepadAxis returns a processed axis value, taking the largest magnitude across pads for AnyGamepad.
+// Caller must hold mu.
+func (in *input) gamepadAxis(id int, axis uint32) float32 {
+	if axis >= window.GamepadAxisCount {
+		return 0
+	}
+	if id != AnyGamepad {
+		if pad, ok := in.gamepads[id]; ok {
+			return pad.axes[axis]
+		}
+		return 0
+	}
+	var value float32
+	for _, pad := range in.gamepads {
+		if v := pad.axes[axis]; abs32(v) > abs32(value) {
+			value = v
+		}
+	}
+	return value
+}
+
+// sumBindings returns the summed digital and analog contributions of a binding list. Caller must hold mu.
+func (in *input) sumBindings(bindings []Binding) (float32, float32) {
+	mods := in.modifiersLocked()
+	var digital, analog float32
+	for _, b := range bindings {
+		if b.Source.Digital() {
+			digital += in.bindingValue(b, mods)
+		} else {
+			analog += in.bindingValue(b, mods)
+		}
+	}
+	return digital, analog
+}
+
+// unbindLocked removes a name from every binding table. Caller must hold mu.
+func (in *input) unbindLocked(name string) {
+	delete(in.actions, name)
+	delete(in.axes, name)
+	delete(in.axes2D, name)
+	delete(in.actionStates, name)
+}
+
+// clearEdges drops the pressed and released bits left over from the previous tick.
+func clearEdges(states map[uint32]uint8) {
+	for code, s := range states {
+		s &^= statePressed | stateReleased
+		if s == 0 {
+			delete(states, code)
+		} else {
+			states[code] = s
+		}
+	}
+}
+
+// press marks a key or button as pressed and held. Repeat events for an already held
+// input do not produce another pressed edge.
+func press(states map[uint32]uint8, code uint32) {
+	s := states[code]
+	if s&stateHeld == 0 {
+		s |= statePressed
+	}
+	states[code] = s | stateHeld
+}
+
+// release marks a key or button as released and no longer held.
+func release(states map[uint32]uint8, code uint32) {
+	s := states[code]
+	if s&stateHeld != 0 || s&statePressed != 0 {
+		s |= stateReleased
+	}
+	states[code] = s &^ stateHeld
+}
+
+// clampDeadZone limits a dead zone to [0, 0.99] so rescaling never divides by zero.
+func clampDeadZone(dz float32) float32 {
+	return min(max(dz, 0), 0.99)
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
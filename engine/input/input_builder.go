@@ -0,0 +1,99 @@
+package input
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/window"
+)
+
+// InputBuilderOption is a functional option for configuring an Input.
+// Use the With* functions to create options that are applied directly to the input instance.
+type InputBuilderOption func(*input)
+
+// WithWindow subscribes the Input to a window's raw input events on construction.
+//
+// Parameters:
+//   - w: the Window to observe
+//
+// Returns:
+//   - InputBuilderOption: option function to apply
+func WithWindow(w window.Window) InputBuilderOption {
+	return func(in *input) {
+		in.win = w
+	}
+}
+
+// WithAction binds a named action during construction.
+//
+// Parameters:
+//   - name: the action name
+//   - bindings: the inputs that trigger the action
+//
+// Returns:
+//   - InputBuilderOption: option function to apply
+func WithAction(name string, bindings ...Binding) InputBuilderOption {
+	return func(in *input) {
+		in.actions[name] = slices.Clone(bindings)
+	}
+}
+
+// WithAxis binds a named one-dimensional axis during construction.
+//
+// Parameters:
+//   - name: the axis name
+//   - bindings: the inputs that contribute to the axis
+//
+// Returns:
+//   - InputBuilderOption: option function to apply
+func WithAxis(name string, bindings ...Binding) InputBuilderOption {
+	return func(in *input) {
+		in.axes[name] = slices.Clone(bindings)
+	}
+}
+
+// WithAxis2D binds a named two-dimensional axis during construction.
+//
+// Parameters:
+//   - name: the axis name
+//   - x: the inputs that contribute to the horizontal component
+//   - y: the inputs that contribute to the vertical component
+//
+// Returns:
+//   - InputBuilderOption: option function to apply
+func WithAxis2D(name string, x, y []Binding) InputBuilderOption {
+	return func(in *input) {
+		in.axes2D[name] = Axis2DBinding{X: slices.Clone(x), Y: slices.Clone(y)}
+	}
+}
+
+// WithBindings adds every action and axis in the given map during construction.
+//
+// Parameters:
+//   - m: the bindings to apply
+//
+// Returns:
+//   - InputBuilderOption: option function to apply
+func WithBindings(m BindingMap) InputBuilderOption {
+	return func(in *input) {
+		maps.Copy(in.actions, m.Actions)
+		maps.Copy(in.axes, m.Axes)
+		maps.Copy(in.axes2D, m.Axes2D)
+	}
+}
+
+// WithDeadZones sets the gamepad stick and trigger dead zones.
+// Defaults are 0.15 for sticks and 0.05 for triggers.
+//
+// Parameters:
+//   - stick: radial dead zone for both sticks, in [0, 1)
+//   - trigger: dead zone for both triggers, in [0, 1)
+//
+// Returns:
+//   - InputBuilderOption: option function to apply
+func WithDeadZones(stick, trigger float32) InputBuilderOption {
+	return func(in *input) {
+		in.stickDZ = clampDeadZone(stick)
+		in.triggerDZ = clampDeadZone(trigger)
+	}
+}
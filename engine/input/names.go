@@ -0,0 +1,152 @@
+package input
+
+import (
+	"strconv"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+)
+
+// keyNames maps readable key names to virtual key codes for binding files.
+var keyNames = map[string]uint32{
+	"Space":        common.KeySpace,
+	"Escape":       common.KeyEsc,
+	"Enter":        common.KeyEnter,
+	"Tab":          common.KeyTab,
+	"Backspace":    common.KeyBackspace,
+	"Insert":       common.KeyInsert,
+	"Delete":       common.KeyDelete,
+	"Right":        common.KeyRight,
+	"Left":         common.KeyLeft,
+	"Down":         common.KeyDown,
+	"Up":           common.KeyUp,
+	"PageUp":       common.KeyPageUp,
+	"PageDown":     common.KeyPageDown,
+	"Home":         common.KeyHome,
+	"End":          common.KeyEnd,
+	"CapsLock":     common.KeyCapsLock,
+	"LeftShift":    common.KeyLeftShift,
+	"RightShift":   common.KeyRightShift,
+	"LeftControl":  common.KeyLeftControl,
+	"RightControl": common.KeyRightControl,
+	"LeftAlt":      common.KeyLeftAlt,
+	"RightAlt":     common.KeyRightAlt,
+	"LeftSuper":    common.KeyLeftSuper,
+	"RightSuper":   common.KeyRightSuper,
+}
+
+// mouseButtonNames maps readable mouse button names to button codes for binding files.
+var mouseButtonNames = map[string]uint32{
+	"Left":    common.MouseButtonLeft,
+	"Right":   common.MouseButtonRight,
+	"Middle":  common.MouseButtonMiddle,
+	"Button4": common.MouseButton4,
+	"Button5": common.MouseButton5,
+	"Button6": common.MouseButton6,
+	"Button7": common.MouseButton7,
+	"Button8": common.MouseButton8,
+}
+
+// gamepadButtonNames maps readable gamepad button names to standard-layout button codes.
+var gamepadButtonNames = map[string]uint32{
+	"A":           common.GamepadButtonA,
+	"B":           common.GamepadButtonB,
+	"X":           common.GamepadButtonX,
+	"Y":           common.GamepadButtonY,
+	"LeftBumper":  common.GamepadButtonLeftBumper,
+	"RightBumper": common.GamepadButtonRightBumper,
+	"Back":        common.GamepadButtonBack,
+	"Start":       common.GamepadButtonStart,
+	"Guide":       common.GamepadButtonGuide,
+	"LeftThumb":   common.GamepadButtonLeftThumb,
+	"RightThumb":  common.GamepadButtonRightThumb,
+	"DpadUp":      common.GamepadButtonDpadUp,
+	"DpadRight":   common.GamepadButtonDpadRight,
+	"DpadDown":    common.GamepadButtonDpadDown,
+	"DpadLeft":    common.GamepadButtonDpadLeft,
+}
+
+// gamepadAxisNames maps readable gamepad axis names to standard-layout axis codes.
+var gamepadAxisNames = map[string]uint32{
+	"LeftX":        common.GamepadAxisLeftX,
+	"LeftY":        common.GamepadAxisLeftY,
+	"RightX":       common.GamepadAxisRightX,
+	"RightY":       common.GamepadAxisRightY,
+	"LeftTrigger":  common.GamepadAxisLeftTrigger,
+	"RightTrigger": common.GamepadAxisRightTrigger,
+}
+
+// modifierOrder lists modifier bits in serialization order.
+var modifierOrder = []struct {
+	name string
+	bit  uint32
+}{
+	{"Shift", common.ModShift},
+	{"Control", common.ModControl},
+	{"Alt", common.ModAlt},
+	{"Super", common.ModSuper},
+}
+
+func init() {
+	// Letters and digits share their ASCII code with the GLFW key code.
+	for c := 'A'; c <= 'Z'; c++ {
+		keyNames[string(c)] = uint32(c)
+	}
+	for c := '0'; c <= '9'; c++ {
+		keyNames[string(c)] = uint32(c)
+	}
+	for i := range 12 {
+		keyNames["F"+strconv.Itoa(i+1)] = uint32(common.KeyF1 + i)
+	}
+}
+
+// namesFor returns the name table used for a source's codes, or nil if the source has none.
+func namesFor(s Source) map[string]uint32 {
+	switch s {
+	case SourceKey:
+		return keyNames
+	case SourceMouseButton:
+		return mouseButtonNames
+	case SourceGamepadButton:
+		return gamepadButtonNames
+	case SourceGamepadAxis:
+		return gamepadAxisNames
+	}
+	return nil
+}
+
+// codeName returns the readable name of a code for the given source.
+func codeName(s Source, code uint32) (string, bool) {
+	for name, c := range namesFor(s) {
+		if c == code {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// codeByName returns the code for a readable name for the given source.
+func codeByName(s Source, name string) (uint32, bool) {
+	code, ok := namesFor(s)[name]
+	return code, ok
+}
+
+// modifierNames returns the readable names of every bit set in mods.
+func modifierNames(mods uint32) []string {
+	var names []string
+	for _, m := range modifierOrder {
+		if mods&m.bit != 0 {
+			names = append(names, m.name)
+		}
+	}
+	return names
+}
+
+// modifierBit returns the bit for a readable modifier name.
+func modifierBit(name string) (uint32, bool) {
+	for _, m := range modifierOrder {
+		if m.name == name {
+			return m.bit, true
+		}
+	}
+	return 0, false
+}
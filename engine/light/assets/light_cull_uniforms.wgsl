@@ -9,4 +9,5 @@ struct LightCullUniforms {
     near:           f32,
     far:            f32,
     _pad:           u32,
+    viewport:       vec4<f32>,
 };
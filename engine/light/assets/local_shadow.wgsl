@@ -0,0 +1,8 @@
+struct LocalShadow {
+    view_proj:   mat4x4<f32>,
+    atlas_rect:  vec4<f32>,
+    normal_bias: f32,
+    bias:        f32,
+    texel_size:  f32,
+    _pad:        f32,
+};
@@ -1,6 +1,10 @@
 struct ShadowData {
-    light_vp:    mat4x4<f32>,
-    texel_size:  vec2<f32>,
-    bias:        f32,
-    normal_bias: f32,
+    cascade_vp:          array<mat4x4<f32>, 4>,
+    cascade_splits:      vec4<f32>,
+    cascade_normal_bias: vec4<f32>,
+    camera_forward:      vec3<f32>,
+    cascade_count:       u32,
+    texel_size:          vec2<f32>,
+    bias:                f32,
+    blend_width:         f32,
 };
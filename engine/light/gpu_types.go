@@ -35,7 +35,7 @@ type GPULight struct {
 	InnerCone    float32    // offset 48: cos(inner half-angle) for spot
 	OuterCone    float32    // offset 52: cos(outer half-angle) for spot
 	CastsShadows uint32     // offset 56: 1 = casts shadows, 0 = does not
-	_pad         uint32     // offset 60: padding to 64-byte alignment
+	ShadowIndex  int32      // offset 60: first GPULocalShadow entry of a point/spot light's shadow, -1 if none this frame
 }
 
 // Size returns the size of the GPULight struct in bytes.
@@ -67,7 +67,7 @@ func (g *GPULight) Marshal() []byte {
 	binary.LittleEndian.PutUint32(buf[48:52], math.Float32bits(g.InnerCone))
 	binary.LittleEndian.PutUint32(buf[52:56], math.Float32bits(g.OuterCone))
 	binary.LittleEndian.PutUint32(buf[56:60], g.CastsShadows)
-	binary.LittleEndian.PutUint32(buf[60:64], 0) // padding
+	binary.LittleEndian.PutUint32(buf[60:64], uint32(g.ShadowIndex))
 	return buf
 }
 
@@ -109,53 +109,77 @@ func (h *GPULightHeader) Marshal() []byte {
 }
 
 // GPUShadowDataSource is the canonical WGSL definition of the ShadowData struct.
-// Matches GPUShadowData layout exactly (80 bytes, std430 aligned).
+// Matches GPUShadowData layout exactly (320 bytes, std430 aligned).
 //
 //go:embed assets/shadow_data.wgsl
 var GPUShadowDataSource string
 
-// GPUShadowData is the GPU-aligned representation of directional shadow data.
+// GPUShadowData is the GPU-aligned representation of cascaded directional shadow data.
 // Matches the WGSL ShadowData struct layout exactly (see GPUShadowDataSource).
-// Size: 80 bytes (std430 / WGSL aligned).
+// Size: 320 bytes (std430 / WGSL aligned).
 //
 // Layout:
 //
-//	mat4x4<f32> light_vp       (64 bytes, offset 0)
-//	vec2<f32>   texel_size     ( 8 bytes, offset 64)
-//	f32         bias           ( 4 bytes, offset 72)
-//	f32         normal_bias    ( 4 bytes, offset 76)
+//	array<mat4x4<f32>, 4> cascade_vp          (256 bytes, offset 0)
+//	vec4<f32>             cascade_splits      ( 16 bytes, offset 256)
+//	vec4<f32>             cascade_normal_bias ( 16 bytes, offset 272)
+//	vec3<f32>             camera_forward      ( 12 bytes, offset 288)
+//	u32                   cascade_count       (  4 bytes, offset 300)
+//	vec2<f32>             texel_size          (  8 bytes, offset 304)
+//	f32                   bias                (  4 bytes, offset 312)
+//	f32                   blend_width         (  4 bytes, offset 316)
 type GPUShadowData struct {
-	LightVP    [16]float32 // orthographic view-projection from light's perspective
-	TexelSize  [2]float32  // 1.0 / shadow_map_resolution for PCF offset calculations
-	Bias       float32     // depth comparison bias to reduce shadow acne
-	NormalBias float32     // world-space normal-offset distance for shadow lookup
+	CascadeVP         [MaxShadowCascades][16]float32 // orthographic view-projection of each cascade from the light's perspective
+	CascadeSplits     [MaxShadowCascades]float32     // view-space far distance of each cascade
+	CascadeNormalBias [MaxShadowCascades]float32     // world-space normal-offset distance for each cascade's lookup
+	CameraForward     [3]float32                     // camera forward direction, used to measure fragment view depth
+	CascadeCount      uint32                         // number of active cascades
+	TexelSize         [2]float32                     // 1.0 / shadow_map_resolution for PCF offset calculations
+	Bias              float32                        // depth comparison bias to reduce shadow acne
+	BlendWidth        float32                        // fraction of each cascade's depth range cross-faded into the next
 }
 
 // Size returns the size of the GPUShadowData struct in bytes.
 //
 // Returns:
-//   - int: the struct size in bytes (80)
+//   - int: the struct size in bytes (320)
 func (s *GPUShadowData) Size() int {
 	return int(unsafe.Sizeof(*s))
 }
 
-// ComputeDirectionalLightVP builds an orthographic view-projection matrix for a
-// directional light's shadow pass and stores it in the receiver's LightVP field.
-// The frustum is centered on the provided center position (typically the camera
-// position) and aligned to look along the light's direction.
+// ComputeCascades builds the orthographic view-projection matrix of every active cascade
+// and stores them in the receiver's CascadeVP field, along with the per-cascade normal bias
+// and the camera forward direction. CascadeSplits and CascadeCount must be set first.
+//
+// Each cascade is fitted to the bounding sphere of its slice of the camera frustum, so its
+// size does not change as the camera rotates, and its origin is snapped to whole shadow map
+// texels, so shadow edges do not shimmer as the camera moves.
 //
 // Parameters:
 //   - lightDir: normalized direction the light points (from light toward scene)
-//   - centerX, centerY, centerZ: world-space center of the shadow frustum
-//   - halfExtent: half-size of the orthographic frustum in world units
-//   - near: near plane distance
-//   - far: far plane distance
-func (s *GPUShadowData) ComputeDirectionalLightVP(lightDir [3]float32, centerX, centerY, centerZ, halfExtent, near, far float32) {
-	// Position the "eye" behind the center, opposite the light direction,
-	// so we look from behind the scene toward the lit area.
-	eyeX := centerX - lightDir[0]*far*0.5
-	eyeY := centerY - lightDir[1]*far*0.5
-	eyeZ := centerZ - lightDir[2]*far*0.5
+//   - view: the camera view matrix, column-major
+//   - projection: the camera projection matrix, column-major
+//   - near: camera near plane distance
+//   - far: camera far plane distance
+//   - lightNear: near plane distance of the light's orthographic projection
+//   - lightFar: far plane distance of the light's orthographic projection
+//   - normalBiasScale: multiplier on the per-texel world size (typically 2.0–4.0)
+//   - resolution: shadow map resolution in texels (width and height)
+func (s *GPUShadowData) ComputeCascades(lightDir [3]float32, view, projection [16]float32, near, far, lightNear, lightFar, normalBiasScale float32, resolution int) {
+	s.CameraForward = [3]float32{-view[2], -view[6], -view[10]}
+
+	// World-space frustum corners on the near and far planes. A point at view
+	// depth d lies on the line between its near and far corner at (d-near)/(far-near).
+	var viewProj, inv [16]float32
+	common.Mul4(viewProj[:], projection[:], view[:])
+	if !common.Invert4(inv[:], viewProj[:]) {
+		return
+	}
+	var nearCorners, farCorners [4][3]float32
+	for i, xy := range [4][2]float32{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+		nearCorners[i] = unproject(inv, xy[0], xy[1], 0)
+		farCorners[i] = unproject(inv, xy[0], xy[1], 1)
+	}
 
 	// Choose a stable up vector that isn't parallel to the light direction.
 	// If the light points nearly straight up or down, use X-axis as up.
@@ -164,48 +188,149 @@ func (s *GPUShadowData) ComputeDirectionalLightVP(lightDir [3]float32, centerX,
 		upX, upY, upZ = 1, 0, 0
 	}
 
-	var view [16]float32
-	common.LookAt(view[:],
-		eyeX, eyeY, eyeZ,
-		centerX, centerY, centerZ,
-		upX, upY, upZ,
-	)
+	count := int(min(max(s.CascadeCount, 1), MaxShadowCascades))
+	depthRange := far - near
+	sliceNear := near
+	for c := range count {
+		sliceFar := min(s.CascadeSplits[c], far)
+		t0 := (sliceNear - near) / depthRange
+		t1 := (sliceFar - near) / depthRange
+		sliceNear = sliceFar
+
+		var corners [8][3]float32
+		var center [3]float32
+		for i := range 4 {
+			for k := range 3 {
+				corners[i][k] = nearCorners[i][k] + (farCorners[i][k]-nearCorners[i][k])*t0
+				corners[i+4][k] = nearCorners[i][k] + (farCorners[i][k]-nearCorners[i][k])*t1
+			}
+		}
+		for _, p := range corners {
+			center[0] += p[0] / 8
+			center[1] += p[1] / 8
+			center[2] += p[2] / 8
+		}
+		var radius float32
+		for _, p := range corners {
+			dx, dy, dz := p[0]-center[0], p[1]-center[1], p[2]-center[2]
+			radius = max(radius, float32(math.Sqrt(float64(dx*dx+dy*dy+dz*dz))))
+		}
+		// Quantize the radius so floating-point noise does not rescale the cascade every frame.
+		radius = float32(math.Ceil(float64(radius)*16) / 16)
+
+		// Position the "eye" behind the center, opposite the light direction, far
+		// enough back that the whole bounding sphere lies in front of the near plane.
+		back := max(lightFar*0.5, radius)
+		eyeX := center[0] - lightDir[0]*back
+		eyeY := center[1] - lightDir[1]*back
+		eyeZ := center[2] - lightDir[2]*back
+
+		var lightView, proj [16]float32
+		common.LookAt(lightView[:],
+			eyeX, eyeY, eyeZ,
+			center[0], center[1], center[2],
+			upX, upY, upZ,
+		)
+		ortho(proj[:], -radius, radius, -radius, radius, lightNear, max(lightFar, back+radius))
+
+		// Snap the projected world origin to a whole texel so the rasterized
+		// shadow map only ever moves in texel increments.
+		var vp [16]float32
+		common.Mul4(vp[:], proj[:], lightView[:])
+		half := float32(resolution) * 0.5
+		originX := vp[12] * half
+		originY := vp[13] * half
+		proj[12] += (float32(math.Round(float64(originX))) - originX) / half
+		proj[13] += (float32(math.Round(float64(originY))) - originY) / half
+		common.Mul4(s.CascadeVP[c][:], proj[:], lightView[:])
+
+		s.CascadeNormalBias[c] = 2.0 * radius / float32(resolution) * normalBiasScale
+	}
+}
+
+// Marshal serializes the GPUShadowData struct into a byte buffer suitable for
+// GPU uniform upload.
+//
+// Returns:
+//   - []byte: 320-byte buffer ready for GPU upload
+func (s *GPUShadowData) Marshal() []byte {
+	buf := make([]byte, 320)
+	for c := 0; c < MaxShadowCascades; c++ {
+		for i := 0; i < 16; i++ {
+			off := c*64 + i*4
+			binary.LittleEndian.PutUint32(buf[off:off+4], math.Float32bits(s.CascadeVP[c][i]))
+		}
+	}
+	for c := 0; c < MaxShadowCascades; c++ {
+		binary.LittleEndian.PutUint32(buf[256+c*4:260+c*4], math.Float32bits(s.CascadeSplits[c]))
+		binary.LittleEndian.PutUint32(buf[272+c*4:276+c*4], math.Float32bits(s.CascadeNormalBias[c]))
+	}
+	binary.LittleEndian.PutUint32(buf[288:292], math.Float32bits(s.CameraForward[0]))
+	binary.LittleEndian.PutUint32(buf[292:296], math.Float32bits(s.CameraForward[1]))
+	binary.LittleEndian.PutUint32(buf[296:300], math.Float32bits(s.CameraForward[2]))
+	binary.LittleEndian.PutUint32(buf[300:304], s.CascadeCount)
+	binary.LittleEndian.PutUint32(buf[304:308], math.Float32bits(s.TexelSize[0]))
+	binary.LittleEndian.PutUint32(buf[308:312], math.Float32bits(s.TexelSize[1]))
+	binary.LittleEndian.PutUint32(buf[312:316], math.Float32bits(s.Bias))
+	binary.LittleEndian.PutUint32(buf[316:320], math.Float32bits(s.BlendWidth))
+	return buf
+}
 
-	var proj [16]float32
-	ortho(proj[:], -halfExtent, halfExtent, -halfExtent, halfExtent, near, far)
+// GPULocalShadowSource is the canonical WGSL definition of the LocalShadow struct.
+// Matches GPULocalShadow layout exactly (96 bytes, std430 aligned).
+//
+//go:embed assets/local_shadow.wgsl
+var GPULocalShadowSource string
 
-	common.Mul4(s.LightVP[:], proj[:], view[:])
+// GPULocalShadow is the GPU-aligned representation of one shadow atlas tile of a
+// point or spot light. A spot light owns one entry; a point light owns six
+// consecutive entries ordered +X, -X, +Y, -Y, +Z, -Z. GPULight.ShadowIndex points
+// at a light's first entry.
+// Matches the WGSL LocalShadow struct layout exactly (see GPULocalShadowSource).
+// Size: 96 bytes (std430 / WGSL aligned).
+//
+// Layout:
+//
+//	mat4x4<f32> view_proj    (64 bytes, offset 0)
+//	vec4<f32>   atlas_rect   (16 bytes, offset 64)
+//	f32         normal_bias  ( 4 bytes, offset 80)
+//	f32         bias         ( 4 bytes, offset 84)
+//	f32         texel_size   ( 4 bytes, offset 88)
+//	f32         _pad         ( 4 bytes, offset 92)
+type GPULocalShadow struct {
+	ViewProj   [16]float32 // perspective view-projection from the light's perspective
+	AtlasRect  [4]float32  // tile UV offset (xy) and UV size (zw) within the atlas
+	NormalBias float32     // normal-offset distance per world unit of distance from the light
+	Bias       float32     // depth comparison bias to reduce shadow acne
+	TexelSize  float32     // 1.0 / atlas resolution for PCF offset calculations
+	_pad       float32     // padding to 96-byte alignment
 }
 
-// ComputeNormalBias derives the world-space normal-offset bias from the shadow
-// map parameters and stores it in the receiver's NormalBias field. The result is
-// the distance (in world units) that fragment positions are shifted along their
-// surface normal before projecting into light clip space. This prevents
-// self-shadowing on concave geometry.
+// Size returns the size of the GPULocalShadow struct in bytes.
 //
-// Parameters:
-//   - halfExtent: orthographic frustum half-size in world units
-//   - scale: multiplier on the per-texel world size (typically 2.0–4.0)
-//   - resolution: shadow map resolution in texels (width and height)
-func (s *GPUShadowData) ComputeNormalBias(halfExtent, scale float32, resolution int) {
-	texelWorldSize := 2.0 * halfExtent / float32(resolution)
-	s.NormalBias = texelWorldSize * scale
+// Returns:
+//   - int: the struct size in bytes (96)
+func (s *GPULocalShadow) Size() int {
+	return int(unsafe.Sizeof(*s))
 }
 
-// Marshal serializes the GPUShadowData struct into a byte buffer suitable for
-// GPU uniform upload.
+// Marshal serializes the GPULocalShadow struct into a byte buffer suitable for
+// GPU storage buffer upload.
 //
 // Returns:
-//   - []byte: 80-byte buffer ready for GPU upload
-func (s *GPUShadowData) Marshal() []byte {
-	buf := make([]byte, 80)
+//   - []byte: 96-byte buffer ready for GPU upload
+func (s *GPULocalShadow) Marshal() []byte {
+	buf := make([]byte, 96)
 	for i := 0; i < 16; i++ {
-		binary.LittleEndian.PutUint32(buf[i*4:(i+1)*4], math.Float32bits(s.LightVP[i]))
+		binary.LittleEndian.PutUint32(buf[i*4:(i+1)*4], math.Float32bits(s.ViewProj[i]))
+	}
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint32(buf[64+i*4:68+i*4], math.Float32bits(s.AtlasRect[i]))
 	}
-	binary.LittleEndian.PutUint32(buf[64:68], math.Float32bits(s.TexelSize[0]))
-	binary.LittleEndian.PutUint32(buf[68:72], math.Float32bits(s.TexelSize[1]))
-	binary.LittleEndian.PutUint32(buf[72:76], math.Float32bits(s.Bias))
-	binary.LittleEndian.PutUint32(buf[76:80], math.Float32bits(s.NormalBias))
+	binary.LittleEndian.PutUint32(buf[80:84], math.Float32bits(s.NormalBias))
+	binary.LittleEndian.PutUint32(buf[84:88], math.Float32bits(s.Bias))
+	binary.LittleEndian.PutUint32(buf[88:92], math.Float32bits(s.TexelSize))
+	binary.LittleEndian.PutUint32(buf[92:96], 0) // padding
 	return buf
 }
 
@@ -245,17 +370,19 @@ func (u *GPUShadowUniform) Marshal() []byte {
 }
 
 // GPULightCullUniformsSource is the canonical WGSL definition of the LightCullUniforms struct.
-// Matches GPULightCullUniforms layout exactly (160 bytes, std430 aligned).
+// Matches GPULightCullUniforms layout exactly (176 bytes, std430 aligned).
 //
 //go:embed assets/light_cull_uniforms.wgsl
 var GPULightCullUniformsSource string
 
 // GPULightCullUniforms is the GPU-aligned uniform data for the light culling
 // compute shader. Contains the inverse projection and view matrices needed
-// to reconstruct per-tile frustum planes, plus tile/screen dimensions and
-// the active light count.
+// to reconstruct per-tile frustum planes, plus tile/screen dimensions, the
+// active light count and the pixel rectangle the camera renders into. Tiles
+// are laid out over the whole screen and mapped into NDC through the viewport,
+// so a scene drawn into a sub-rectangle shares the lit shaders' tile indexing.
 // Matches the WGSL LightCullUniforms struct layout exactly (see GPULightCullUniformsSource).
-// Size: 160 bytes (std430 / WGSL aligned).
+// Size: 176 bytes (std430 / WGSL aligned).
 //
 // Layout:
 //
@@ -269,6 +396,7 @@ var GPULightCullUniformsSource string
 //	f32         near           ( 4 bytes, offset 148)
 //	f32         far            ( 4 bytes, offset 152)
 //	u32         _pad           ( 4 bytes, offset 156)
+//	vec4<f32>   viewport       (16 bytes, offset 160)
 type GPULightCullUniforms struct {
 	InvProj      [16]float32 // inverse projection matrix
 	ViewMatrix   [16]float32 // camera view matrix
@@ -280,23 +408,24 @@ type GPULightCullUniforms struct {
 	Near         float32
 	Far          float32
 	_pad         uint32
+	Viewport     [4]float32 // x, y, width, height in pixels
 }
 
 // Size returns the size of the GPULightCullUniforms struct in bytes.
 //
 // Returns:
-//   - int: the struct size in bytes (160)
+//   - int: the struct size in bytes (176)
 func (u *GPULightCullUniforms) Size() int {
 	return int(unsafe.Sizeof(*u))
 }
 
-// Marshal serializes GPULightCullUniforms into a 160-byte little-endian buffer
+// Marshal serializes GPULightCullUniforms into a 176-byte little-endian buffer
 // suitable for GPU upload.
 //
 // Returns:
-//   - []byte: 160-byte buffer ready for GPU upload
+//   - []byte: 176-byte buffer ready for GPU upload
 func (u *GPULightCullUniforms) Marshal() []byte {
-	buf := make([]byte, 160)
+	buf := make([]byte, 176)
 	off := 0
 
 	// inv_proj (64 bytes)
@@ -329,6 +458,12 @@ func (u *GPULightCullUniforms) Marshal() []byte {
 	off += 4
 	// _pad
 	binary.LittleEndian.PutUint32(buf[off:off+4], 0)
+	off += 4
+	// viewport (16 bytes)
+	for i := range 4 {
+		binary.LittleEndian.PutUint32(buf[off:off+4], math.Float32bits(u.Viewport[i]))
+		off += 4
+	}
 
 	return buf
 }
@@ -397,6 +532,7 @@ func ToGPULight(l Light) GPULight {
 		InnerCone:    l.InnerCone(),
 		OuterCone:    l.OuterCone(),
 		CastsShadows: shadowVal,
+		ShadowIndex:  -1,
 	}
 }
 
@@ -412,10 +548,11 @@ func ToGPULight(l Light) GPULight {
 // Parameters:
 //   - lights: the full slice of lights to marshal (only enabled lights are included)
 //   - ambient: the scene ambient color as RGB
+//   - shadowIndices: the first GPULocalShadow entry of each point/spot light with a shadow this frame (may be nil)
 //
 // Returns:
 //   - []byte: the marshaled buffer ready for GPU upload
-func MarshalLightBuffer(lights []Light, ambient [3]float32) []byte {
+func MarshalLightBuffer(lights []Light, ambient [3]float32, shadowIndices map[Light]int32) []byte {
 	headerSize := (&GPULightHeader{}).Size()
 	lightSize := (&GPULight{}).Size()
 
@@ -449,6 +586,9 @@ func MarshalLightBuffer(lights []Light, ambient [3]float32) []byte {
 			break
 		}
 		gpu := ToGPULight(l)
+		if idx, ok := shadowIndices[l]; ok {
+			gpu.ShadowIndex = idx
+		}
 		copy(buf[offset:offset+lightSize], gpu.Marshal())
 		offset += lightSize
 		written++
@@ -474,6 +614,19 @@ func ortho(out []float32, left, right, bottom, top, near, far float32) {
 	out[14] = -near / fn
 }
 
+// unproject transforms a normalized device coordinate by an inverse view-projection
+// matrix and returns the world-space point after the perspective divide.
+func unproject(inv [16]float32, x, y, z float32) [3]float32 {
+	wx := inv[0]*x + inv[4]*y + inv[8]*z + inv[12]
+	wy := inv[1]*x + inv[5]*y + inv[9]*z + inv[13]
+	wz := inv[2]*x + inv[6]*y + inv[10]*z + inv[14]
+	ww := inv[3]*x + inv[7]*y + inv[11]*z + inv[15]
+	if ww == 0 {
+		ww = 1
+	}
+	return [3]float32{wx / ww, wy / ww, wz / ww}
+}
+
 // absF32 returns the absolute value of a float32.
 func absF32(v float32) float32 {
 	if v < 0 {
@@ -120,6 +120,8 @@ type Light interface {
 	// CastsShadows returns whether this light is eligible for shadow map generation.
 	// Shadow-casting lights have their depth pass rendered each frame, which is
 	// expensive. Most ephemeral and distant lights should have this disabled.
+	// The first shadow-casting directional light uses cascaded shadow maps; point
+	// and spot lights compete for shadow atlas tiles within the scene's budget.
 	//
 	// Returns:
 	//   - bool: true if the light casts shadows
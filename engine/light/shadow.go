@@ -1,21 +1,44 @@
 package light
 
-// ShadowMapResolution is the default width and height in texels of the shadow
-// depth texture. Scenes use this as their initial value but can override it
-// via the WithShadowMapResolution builder option.
+import (
+	"math"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+)
+
+// ShadowMapResolution is the default width and height in texels of each shadow
+// cascade. Scenes use this as their initial value but can override it via the
+// WithShadowMapResolution builder option.
 const ShadowMapResolution = 2048
 
-// DefaultShadowHalfExtent is the default orthographic half-extent (in world units)
-// used for the directional light shadow frustum. Controls how much of the scene
-// around the camera center is captured in the shadow map.
-const DefaultShadowHalfExtent float32 = 40.0
+// MaxShadowCascades is the maximum number of cascades a directional light shadow
+// can be split into. It matches the length of the cascade arrays in GPUShadowData.
+const MaxShadowCascades = 4
+
+// DefaultShadowCascades is the default number of cascades the camera frustum is
+// split into for directional light shadows.
+const DefaultShadowCascades = 4
+
+// DefaultShadowDistance is the default view-space distance (in world units) from
+// the camera up to which directional light shadows are rendered. The cascades
+// divide the range between the camera near plane and this distance.
+const DefaultShadowDistance float32 = 100.0
+
+// DefaultShadowSplitLambda is the default blend between logarithmic (1) and
+// uniform (0) cascade split distances used by CascadeSplits.
+const DefaultShadowSplitLambda float32 = 0.75
+
+// DefaultShadowCascadeBlend is the default fraction of each cascade's depth range
+// over which shadows cross-fade into the next cascade. 0 disables blending.
+const DefaultShadowCascadeBlend float32 = 0.1
 
 // DefaultShadowNear is the default near plane for the directional light's
 // orthographic shadow projection.
 const DefaultShadowNear float32 = 0.1
 
 // DefaultShadowFar is the default far plane for the directional light's
-// orthographic shadow projection.
+// orthographic shadow projection. It bounds how far behind a cascade
+// shadow casters are still captured.
 const DefaultShadowFar float32 = 200.0
 
 // DefaultShadowBias is the constant depth bias applied to shadow comparisons
@@ -28,3 +51,143 @@ const DefaultShadowBias float32 = 0.001
 // self-shadowing on concave geometry at the cost of slight shadow
 // detachment from contact points. Typical values are 2.0–4.0.
 const DefaultShadowNormalBiasScale float32 = 3.0
+
+// CascadeSplits computes the far view-space distance of each shadow cascade using
+// the practical split scheme, which blends logarithmic splits (even texel density
+// across depth) with uniform splits (avoids over-resolving the area near the camera).
+// Entries past count are left at distance.
+//
+// Parameters:
+//   - count: number of cascades, clamped to [1, MaxShadowCascades]
+//   - near: camera near plane distance
+//   - distance: view-space distance the last cascade ends at
+//   - lambda: 1 for purely logarithmic splits, 0 for purely uniform splits
+//
+// Returns:
+//   - [MaxShadowCascades]float32: the far distance of each cascade
+func CascadeSplits(count int, near, distance, lambda float32) [MaxShadowCascades]float32 {
+	count = max(min(count, MaxShadowCascades), 1)
+	near = max(near, 1e-4)
+	distance = max(distance, near)
+	lambda = max(min(lambda, 1), 0)
+
+	var splits [MaxShadowCascades]float32
+	for i := range splits {
+		if i >= count-1 {
+			splits[i] = distance
+			continue
+		}
+		p := float32(i+1) / float32(count)
+		logSplit := near * float32(math.Pow(float64(distance/near), float64(p)))
+		uniformSplit := near + (distance-near)*p
+		splits[i] = lambda*logSplit + (1-lambda)*uniformSplit
+	}
+	return splits
+}
+
+// ShadowAtlasResolution is the default width and height in texels of the shadow
+// atlas that point and spot light shadows are packed into. Scenes use this as
+// their initial value but can override it via the WithShadowAtlas builder option.
+const ShadowAtlasResolution = 4096
+
+// ShadowAtlasTileResolution is the default width and height in texels of one
+// shadow atlas tile. A spot light uses one tile; a point light uses six, one per
+// cube face.
+const ShadowAtlasTileResolution = 512
+
+// MaxShadowAtlasTiles is the maximum number of tiles a shadow atlas can hold. It
+// sizes the local shadow storage buffer read by lit shaders.
+const MaxShadowAtlasTiles = 64
+
+// DefaultShadowAtlasBudget is the default number of shadow atlas tiles rendered
+// per frame. Each tile is a full depth pass over the scene's shadow casters, so the
+// budget bounds the cost of point and spot light shadows.
+const DefaultShadowAtlasBudget = 16
+
+// DefaultLocalShadowNear is the default near plane of the perspective projections
+// used for point and spot light shadows.
+const DefaultLocalShadowNear float32 = 0.05
+
+// DefaultLocalShadowBias is the constant depth bias applied to point and spot light
+// shadow comparisons. Perspective depth is non-linear, so this is much smaller than
+// DefaultShadowBias; the normal-offset bias does most of the work.
+const DefaultLocalShadowBias float32 = 0.00002
+
+// ShadowTileCount returns the number of shadow atlas tiles a light of the given
+// type needs: one for a spot light, six for a point light (one per cube face), and
+// none for a directional light, which uses the cascaded shadow map instead.
+//
+// Parameters:
+//   - t: the light type
+//
+// Returns:
+//   - int: the number of atlas tiles
+func ShadowTileCount(t LightType) int {
+	switch t {
+	case LightTypeSpot:
+		return 1
+	case LightTypePoint:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// pointShadowFaces lists the view direction and up vector of each cube face of a
+// point light shadow, in the +X, -X, +Y, -Y, +Z, -Z order lit shaders expect.
+var pointShadowFaces = [6][2][3]float32{
+	{{1, 0, 0}, {0, 1, 0}},
+	{{-1, 0, 0}, {0, 1, 0}},
+	{{0, 1, 0}, {0, 0, 1}},
+	{{0, -1, 0}, {0, 0, 1}},
+	{{0, 0, 1}, {0, 1, 0}},
+	{{0, 0, -1}, {0, 1, 0}},
+}
+
+// LocalShadowViewProjections builds the perspective view-projection matrices used to
+// render a point or spot light's shadow. A spot light yields one matrix covering its
+// outer cone; a point light yields six 90° cube faces ordered +X, -X, +Y, -Y, +Z, -Z.
+// Directional lights yield none.
+//
+// Parameters:
+//   - l: the light
+//   - near: near plane distance; the far plane is the light's range
+//
+// Returns:
+//   - [][16]float32: one column-major matrix per atlas tile the light needs
+//   - float32: the tangent of the half field of view, used to size the normal bias
+func LocalShadowViewProjections(l Light, near float32) ([][16]float32, float32) {
+	pos := l.Position()
+	far := max(l.Range(), near*2)
+
+	switch l.Type() {
+	case LightTypeSpot:
+		dir := l.Direction()
+		upX, upY, upZ := float32(0), float32(1), float32(0)
+		if absF32(dir[1]) > 0.99 {
+			upX, upY, upZ = 1, 0, 0
+		}
+		halfFov := float32(math.Acos(float64(max(min(l.OuterCone(), 1), -1))))
+		halfFov = max(min(halfFov, 85*math.Pi/180), 0.5*math.Pi/180)
+
+		var view, proj, vp [16]float32
+		common.LookAt(view[:], pos[0], pos[1], pos[2], pos[0]+dir[0], pos[1]+dir[1], pos[2]+dir[2], upX, upY, upZ)
+		common.Perspective(proj[:], 2*halfFov, 1, near, far)
+		common.Mul4(vp[:], proj[:], view[:])
+		return [][16]float32{vp}, float32(math.Tan(float64(halfFov)))
+
+	case LightTypePoint:
+		var proj [16]float32
+		common.Perspective(proj[:], math.Pi/2, 1, near, far)
+		faces := make([][16]float32, len(pointShadowFaces))
+		for i, f := range pointShadowFaces {
+			var view [16]float32
+			common.LookAt(view[:], pos[0], pos[1], pos[2], pos[0]+f[0][0], pos[1]+f[0][1], pos[2]+f[0][2], f[1][0], f[1][1], f[1][2])
+			common.Mul4(faces[i][:], proj[:], view[:])
+		}
+		return faces, 1
+
+	default:
+		return nil, 0
+	}
+}
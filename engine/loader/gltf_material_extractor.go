@@ -63,10 +63,19 @@ func (e *gltfMaterialExtractorImpl) ExtractMaterial(materialIndex int) (*common.
 	mat := &doc.Materials[materialIndex]
 
 	result := &common.ImportedMaterial{
-		Name:      mat.Name,
-		BaseColor: [4]float32{1, 1, 1, 1},
-		Metallic:  1.0,
-		Roughness: 1.0,
+		Name:        mat.Name,
+		BaseColor:   [4]float32{1, 1, 1, 1},
+		Metallic:    1.0,
+		Roughness:   1.0,
+		AlphaMode:   "OPAQUE",
+		AlphaCutoff: 0.5,
+		DoubleSided: mat.DoubleSided,
+	}
+	if mat.AlphaMode != "" {
+		result.AlphaMode = mat.AlphaMode
+	}
+	if mat.AlphaCutoff != nil {
+		result.AlphaCutoff = *mat.AlphaCutoff
 	}
 
 	if mat.PbrMetallicRoughness != nil {
@@ -146,10 +155,9 @@ func (e *gltfMaterialExtractorImpl) ExtractAllMaterials() ([]*common.ImportedMat
 	return materials, nil
 }
 
-// loadTexture resolves a glTF texture index into an ImportedTexture with loaded image data.
+// loadTexture resolves a glTF texture index into an ImportedTexture with loaded image data,
+// preferring the KTX2 image of the KHR_texture_basisu extension when present.
 // Returns the texture (for embedded data) and/or a file path (for external references).
-// For embedded images (buffer view or data URI), the raw bytes are loaded into the texture.
-// For external file references, the path is resolved relative to the glTF base directory.
 func (e *gltfMaterialExtractorImpl) loadTexture(textureIndex int) (*common.ImportedTexture, string, error) {
 	doc := e.parser.Document()
 	if textureIndex < 0 || textureIndex >= len(doc.Textures) {
@@ -157,20 +165,45 @@ func (e *gltfMaterialExtractorImpl) loadTexture(textureIndex int) (*common.Impor
 	}
 
 	tex := &doc.Textures[textureIndex]
-	if tex.Source == nil {
-		return nil, "", nil
-	}
 
 	// Resolve glTF sampler parameters if this texture references one.
 	var samplerData *common.SamplerStagingData
+	disableMipmaps := false
 	if tex.Sampler != nil {
 		samplerIdx := *tex.Sampler
 		if samplerIdx >= 0 && samplerIdx < len(doc.Samplers) {
 			samplerData = gltfSamplerToStagingData(&doc.Samplers[samplerIdx])
+			disableMipmaps = !gltfSamplerUsesMipmaps(&doc.Samplers[samplerIdx])
 		}
 	}
 
-	imageIndex := *tex.Source
+	// KHR_texture_basisu points at a KTX2 image; the core source, if any, becomes its fallback.
+	if tex.Extensions != nil && tex.Extensions.KHRTextureBasisu != nil && tex.Extensions.KHRTextureBasisu.Source != nil {
+		result, path, err := e.loadImage(*tex.Extensions.KHRTextureBasisu.Source, samplerData, disableMipmaps)
+		if err != nil || result == nil {
+			return result, path, err
+		}
+		if tex.Source != nil {
+			fallback, _, err := e.loadImage(*tex.Source, samplerData, disableMipmaps)
+			if err != nil {
+				return nil, "", err
+			}
+			result.Fallback = fallback
+		}
+		return result, path, nil
+	}
+
+	if tex.Source == nil {
+		return nil, "", nil
+	}
+	return e.loadImage(*tex.Source, samplerData, disableMipmaps)
+}
+
+// loadImage resolves a glTF image index into an ImportedTexture with loaded image data.
+// For embedded images (buffer view or data URI), the raw bytes are loaded into the texture.
+// For external file references, the path is resolved relative to the glTF base directory.
+func (e *gltfMaterialExtractorImpl) loadImage(imageIndex int, samplerData *common.SamplerStagingData, disableMipmaps bool) (*common.ImportedTexture, string, error) {
+	doc := e.parser.Document()
 	if imageIndex < 0 || imageIndex >= len(doc.Images) {
 		return nil, "", fmt.Errorf("image index %d out of range", imageIndex)
 	}
@@ -178,9 +211,10 @@ func (e *gltfMaterialExtractorImpl) loadTexture(textureIndex int) (*common.Impor
 	img := &doc.Images[imageIndex]
 
 	result := &common.ImportedTexture{
-		Name:        img.Name,
-		MimeType:    img.MimeType,
-		SamplerData: samplerData,
+		Name:           img.Name,
+		MimeType:       img.MimeType,
+		SamplerData:    samplerData,
+		DisableMipmaps: disableMipmaps,
 	}
 
 	// Case 1: Image embedded in a buffer view (common in GLB)
@@ -341,6 +375,21 @@ func gltfSamplerToStagingData(s *gltfSampler) *common.SamplerStagingData {
 	return result
 }
 
+// gltfSamplerUsesMipmaps reports whether a glTF sampler's minification filter samples mip levels.
+// An unset filter leaves the choice to the implementation, so mipmapping is assumed.
+//
+// Parameters:
+//   - s: the glTF sampler
+//
+// Returns:
+//   - bool: false if the sampler uses plain NEAREST or LINEAR minification
+func gltfSamplerUsesMipmaps(s *gltfSampler) bool {
+	if s.MinFilter == nil {
+		return true
+	}
+	return *s.MinFilter != gltfFilterNearest && *s.MinFilter != gltfFilterLinear
+}
+
 // gltfWrapToAddressMode converts a glTF wrap mode constant to a wgpu AddressMode.
 //
 // Parameters:
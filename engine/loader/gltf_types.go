@@ -287,6 +287,16 @@ type gltfMaterial struct {
 	// NormalTexture is the normal map.
 	NormalTexture *gltfNormalTextureInfo `json:"normalTexture,omitempty"`
 
+	// AlphaMode is the alpha rendering mode.
+	// "OPAQUE" (default), "MASK", "BLEND"
+	AlphaMode string `json:"alphaMode,omitempty"`
+
+	// AlphaCutoff is the alpha cutoff for MASK mode.
+	AlphaCutoff *float32 `json:"alphaCutoff,omitempty"`
+
+	// DoubleSided indicates if the material is double-sided.
+	DoubleSided bool `json:"doubleSided,omitempty"`
+
 	// TODO: Uncomment when PBR rendering is implemented:
 	// // OcclusionTexture is the occlusion map.
 	// OcclusionTexture *gltfOcclusionTextureInfo `json:"occlusionTexture,omitempty"`
@@ -296,16 +306,6 @@ type gltfMaterial struct {
 	//
 	// // EmissiveFactor is the emissive color (RGB).
 	// EmissiveFactor *[3]float32 `json:"emissiveFactor,omitempty"`
-	//
-	// // AlphaMode is the alpha rendering mode.
-	// // "OPAQUE" (default), "MASK", "BLEND"
-	// AlphaMode string `json:"alphaMode,omitempty"`
-	//
-	// // AlphaCutoff is the alpha cutoff for MASK mode.
-	// AlphaCutoff *float32 `json:"alphaCutoff,omitempty"`
-	//
-	// // DoubleSided indicates if the material is double-sided.
-	// DoubleSided bool `json:"doubleSided,omitempty"`
 }
 
 // gltfPbrMetallicRoughness is the metallic-roughness material model.
@@ -367,6 +367,22 @@ type gltfTexture struct {
 
 	// Source is the image index.
 	Source *int `json:"source,omitempty"`
+
+	// Extensions holds the texture extensions the loader understands.
+	Extensions *gltfTextureExtensions `json:"extensions,omitempty"`
+}
+
+// gltfTextureExtensions holds the supported glTF texture extensions.
+type gltfTextureExtensions struct {
+	// KHRTextureBasisu references a KTX2 image with Basis Universal data.
+	// Reference: https://github.com/KhronosGroup/glTF/tree/main/extensions/2.0/Khronos/KHR_texture_basisu
+	KHRTextureBasisu *gltfTextureBasisu `json:"KHR_texture_basisu,omitempty"`
+}
+
+// gltfTextureBasisu is the KHR_texture_basisu texture extension.
+type gltfTextureBasisu struct {
+	// Source is the index of the KTX2 image.
+	Source *int `json:"source,omitempty"`
 }
 
 // gltfImage is a texture image source.
@@ -33,6 +33,10 @@ type loader struct {
 
 	modelCache map[string]model.Model
 
+	mipmaps       bool
+	maxAnisotropy uint16
+	transcoder    common.KTX2Transcoder
+
 	backend loaderBackend
 }
 
@@ -126,8 +130,10 @@ var _ Loader = &loader{}
 //   - Loader: a new instance of Loader configured with the provided backend and options
 func NewLoader(backendType LoaderBackendType, options ...LoaderBuilderOption) Loader {
 	l := &loader{
-		mu:         sync.RWMutex{},
-		modelCache: make(map[string]model.Model),
+		mu:            sync.RWMutex{},
+		modelCache:    make(map[string]model.Model),
+		mipmaps:       true,
+		maxAnisotropy: 1,
 	}
 
 	switch backendType {
@@ -163,6 +169,7 @@ func (l *loader) Load(path string, fragmentShader shader.Shader) (model.Model, e
 	if err != nil {
 		return nil, err
 	}
+	m.SetSourcePath(path)
 
 	l.mu.Lock()
 	l.modelCache[path] = m
@@ -193,6 +200,7 @@ func (l *loader) LoadMeshOnly(path string, fragmentShader shader.Shader) (model.
 	if err != nil {
 		return nil, err
 	}
+	m.SetSourcePath(path)
 
 	l.mu.Lock()
 	l.modelCache[path] = m
@@ -218,6 +226,7 @@ func (l *loader) LoadReader(name string, r io.Reader, isGLB bool, fragmentShader
 	if err != nil {
 		return nil, err
 	}
+	m.SetSourcePath(name)
 
 	l.mu.Lock()
 	l.modelCache[name] = m
@@ -330,6 +339,9 @@ func (l *loader) importedToModel(imported *model.ImportedModel, fragmentShader s
 			material.WithDiffuseTexture(imp.DiffuseTexture),
 			material.WithNormalTexture(imp.NormalTexture),
 			material.WithMetallicRoughnessTexture(imp.MetallicRoughnessTexture),
+			material.WithAlphaMode(importedAlphaMode(imp.AlphaMode)),
+			material.WithAlphaCutoff(imp.AlphaCutoff),
+			material.WithDoubleSided(imp.DoubleSided),
 			material.WithPipelineKey(imported.Name),
 		)
 
@@ -426,17 +438,12 @@ func (l *loader) initMaterialGPU(mat material.Material, fragmentShader shader.Sh
 		samplerRole := textureSamplerPairs[texRole]
 		samplerBindingIdx, hasSamplerBinding := roleToBinding[samplerRole]
 
-		// Decode texture to RGBA pixels.
-		pixels, width, height, err := tb.tex.Decode()
+		// Decode or transcode the texture into a format the renderer can sample.
+		stagingData, err := tb.tex.Stage(l.renderer.SupportsTextureFormat, l.transcoder)
 		if err != nil {
 			return fmt.Errorf("failed to decode %s texture: %w", texRole, err)
 		}
-
-		stagingData := common.TextureStagingData{
-			Pixels: pixels,
-			Width:  width,
-			Height: height,
-		}
+		stagingData.GenerateMipmaps = l.mipmaps && !tb.tex.DisableMipmaps
 
 		if err := l.renderer.InitTextureView(provider, texBindingIdx, stagingData); err != nil {
 			return fmt.Errorf("failed to init %s texture view: %w", texRole, err)
@@ -458,6 +465,13 @@ func (l *loader) initMaterialGPU(mat material.Material, fragmentShader shader.Sh
 			if tb.tex.SamplerData != nil {
 				samplerData = *tb.tex.SamplerData
 			}
+			// WebGPU only allows anisotropy when the mag, min and mipmap filters are all linear.
+			linear := samplerData.MagFilter != wgpu.FilterModeNearest &&
+				samplerData.MinFilter != wgpu.FilterModeNearest &&
+				samplerData.MipmapFilter != wgpu.MipmapFilterModeNearest
+			if linear && (stagingData.GenerateMipmaps || len(stagingData.MipLevels) > 0) {
+				samplerData.MaxAnisotropy = max(samplerData.MaxAnisotropy, l.maxAnisotropy)
+			}
 			if err := l.renderer.InitSampler(provider, samplerBindingIdx, samplerData); err != nil {
 				return fmt.Errorf("failed to init %s sampler: %w", samplerRole, err)
 			}
@@ -516,11 +530,57 @@ func (l *loader) initMaterialGPU(mat material.Material, fragmentShader shader.Sh
 		}
 	}
 
+	// Locate the optional MaterialParams uniform so it can be sized and filled from the
+	// material's base color and alpha settings.
+	paramsBinding := -1
+	for _, decl := range fragmentShader.Declarations() {
+		if decl.Type != shader.AnnotationTypeBindingGroup || decl.Group == nil || decl.Binding == nil {
+			continue
+		}
+		if *decl.Group == materialGroupIdx && len(decl.Args) > 2 && decl.Args[2] == shader.AnnotationArgMaterialParams {
+			paramsBinding = *decl.Binding
+		}
+	}
+
+	params := material.NewGPUMaterialParams(mat)
+	var sizeOverrides map[int]uint64
+	if paramsBinding >= 0 {
+		sizeOverrides = map[int]uint64{paramsBinding: uint64(params.Size())}
+	}
+
 	// Create the bind group from the shader's layout descriptor for this group.
-	if err := l.renderer.InitBindGroup(provider, descriptor, nil, nil); err != nil {
+	if err := l.renderer.InitBindGroup(provider, descriptor, nil, sizeOverrides); err != nil {
 		return fmt.Errorf("failed to init material bind group: %w", err)
 	}
 
+	if paramsBinding >= 0 {
+		l.renderer.WriteBuffers([]bind_group_provider.BufferWrite{{
+			Provider: provider,
+			Binding:  paramsBinding,
+			Offset:   0,
+			Data:     params.Marshal(),
+		}})
+	}
+
 	mat.SetBindGroupProvider(provider)
 	return nil
 }
+
+// importedAlphaMode maps a glTF alphaMode string to the corresponding material.AlphaMode.
+// Unknown or empty values fall back to AlphaModeOpaque, the glTF default.
+//
+// Parameters:
+//   - mode: the alphaMode string from the imported material ("OPAQUE", "MASK" or "BLEND")
+//
+// Returns:
+//   - material.AlphaMode: the matching alpha mode
+func importedAlphaMode(mode string) material.AlphaMode {
+	switch mode {
+	case "MASK":
+		return material.AlphaModeMask
+	case "BLEND":
+		return material.AlphaModeBlend
+	default:
+		return material.AlphaModeOpaque
+	}
+}
@@ -1,6 +1,7 @@
 package loader
 
 import (
+	"github.com/Carmen-Shannon/oxy-go/common"
 	"github.com/Carmen-Shannon/oxy-go/engine/model"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
 )
@@ -22,6 +23,8 @@ func WithRenderer(r renderer.Renderer) LoaderBuilderOption {
 }
 
 // WithModel is an option builder that pre-populates the model cache with a model.
+// If the model has no source path, the cache key becomes its source path so
+// procedurally built models can be referenced from saved scenes.
 //
 // Parameters:
 //   - key: the cache key for the model
@@ -31,6 +34,54 @@ func WithRenderer(r renderer.Renderer) LoaderBuilderOption {
 //   - LoaderBuilderOption: a function that applies the model option to a loader
 func WithModel(key string, model model.Model) LoaderBuilderOption {
 	return func(l *loader) {
+		if model.SourcePath() == "" {
+			model.SetSourcePath(key)
+		}
 		l.modelCache[key] = model
 	}
 }
+
+// WithMipmaps is an option builder that sets whether material textures get a generated mip chain
+// on upload (default true). Individual textures can opt out with ImportedTexture.DisableMipmaps.
+//
+// Parameters:
+//   - enabled: false to upload only the base level of every material texture
+//
+// Returns:
+//   - LoaderBuilderOption: a function that applies the mipmaps option to a loader
+func WithMipmaps(enabled bool) LoaderBuilderOption {
+	return func(l *loader) {
+		l.mipmaps = enabled
+	}
+}
+
+// WithMaxAnisotropy is an option builder that sets the anisotropic filtering level of material
+// texture samplers (default 1, off). It only applies to mipmapped textures whose samplers filter
+// linearly, and is clamped to 16.
+//
+// Parameters:
+//   - level: the maximum anisotropy, e.g. 4, 8 or 16
+//
+// Returns:
+//   - LoaderBuilderOption: a function that applies the anisotropy option to a loader
+func WithMaxAnisotropy(level uint16) LoaderBuilderOption {
+	return func(l *loader) {
+		l.maxAnisotropy = level
+	}
+}
+
+// WithKTX2Transcoder is an option builder that sets the transcoder for KTX2 textures holding
+// Basis Universal (KHR_texture_basisu) data. Zstandard supercompression is undone before the
+// transcoder is called, so UASTC levels arrive as raw blocks. Without a transcoder, such
+// textures fall back to their glTF PNG/JPEG source if they have one, and fail to load otherwise.
+//
+// Parameters:
+//   - t: the transcoder
+//
+// Returns:
+//   - LoaderBuilderOption: a function that applies the transcoder option to a loader
+func WithKTX2Transcoder(t common.KTX2Transcoder) LoaderBuilderOption {
+	return func(l *loader) {
+		l.transcoder = t
+	}
+}
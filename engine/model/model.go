@@ -9,6 +9,7 @@ import (
 // model is the implementation of the Model interface.
 type model struct {
 	name                  string
+	sourcePath            string
 	skinned               bool
 	skeleton              *Skeleton
 	animations            []*AnimationClip
@@ -33,6 +34,20 @@ type Model interface {
 	//   - string: the model name
 	Name() string
 
+	// SourcePath retrieves the path the Loader produced this model from. Scene
+	// serialization uses it to reference the model in saved files. Empty for models
+	// that were built procedurally rather than loaded.
+	//
+	// Returns:
+	//   - string: the loader path, or an empty string
+	SourcePath() string
+
+	// SetSourcePath sets the path the Loader produced this model from.
+	//
+	// Parameters:
+	//   - path: the loader path or cache name
+	SetSourcePath(path string)
+
 	// Skinned reports whether this model uses skeletal animation.
 	//
 	// Returns:
@@ -190,6 +205,14 @@ func (m *model) Name() string {
 	return m.name
 }
 
+func (m *model) SourcePath() string {
+	return m.sourcePath
+}
+
+func (m *model) SetSourcePath(path string) {
+	m.sourcePath = path
+}
+
 func (m *model) Skinned() bool {
 	return m.skinned
 }
@@ -22,6 +22,19 @@ func WithName(name string) ModelBuilderOption {
 	}
 }
 
+// WithSourcePath is an option builder that sets the path the Model was loaded from.
+//
+// Parameters:
+//   - path: the loader path or cache name
+//
+// Returns:
+//   - ModelBuilderOption: a function that applies the source path option to a model
+func WithSourcePath(path string) ModelBuilderOption {
+	return func(m *model) {
+		m.sourcePath = path
+	}
+}
+
 // WithSkinned is an option builder that sets whether the Model uses skeletal animation.
 //
 // Parameters:
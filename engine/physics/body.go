@@ -0,0 +1,441 @@
+package physics
+
+import (
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/game_object"
+)
+
+// BodyType selects how a Body is moved.
+type BodyType int
+
+const (
+	// BodyDynamic bodies are moved by gravity, forces and contacts.
+	BodyDynamic BodyType = iota
+	// BodyStatic bodies never move and have infinite mass.
+	BodyStatic
+	// BodyKinematic bodies have infinite mass and are moved only by their velocity or, when
+	// bound to a GameObject, by following the object. They push dynamic bodies but are not pushed.
+	BodyKinematic
+)
+
+// Body is a rigid body simulated by a physics World. Positions and orientations are in
+// world space at the body origin; the collider is placed relative to it.
+type Body interface {
+	// ID returns the identifier assigned when the body was added to a World, or 0.
+	//
+	// Returns:
+	//   - uint64: the body ID
+	ID() uint64
+
+	// Type returns how the body is moved.
+	//
+	// Returns:
+	//   - BodyType: the body type
+	Type() BodyType
+
+	// Collider returns the body's collision shape.
+	//
+	// Returns:
+	//   - Collider: the collider, or nil for a body that does not collide
+	Collider() Collider
+
+	// GameObject returns the object the body writes its transform to (or follows, for
+	// kinematic bodies).
+	//
+	// Returns:
+	//   - game_object.GameObject: the bound object, or nil
+	GameObject() game_object.GameObject
+
+	// Trigger returns whether the body only reports contacts without responding to them.
+	//
+	// Returns:
+	//   - bool: true for trigger bodies
+	Trigger() bool
+
+	// Mass returns the body mass in kilograms. Static and kinematic bodies report 0.
+	//
+	// Returns:
+	//   - float32: the mass
+	Mass() float32
+
+	// SetMass sets the mass of a dynamic body and recomputes its inertia. Values <= 0 are ignored.
+	//
+	// Parameters:
+	//   - mass: the new mass
+	SetMass(mass float32)
+
+	// Position returns the world position of the body origin.
+	//
+	// Returns:
+	//   - [3]float32: the position
+	Position() [3]float32
+
+	// SetPosition teleports the body.
+	//
+	// Parameters:
+	//   - x, y, z: the world position
+	SetPosition(x, y, z float32)
+
+	// Rotation returns the body orientation as Euler angles in radians, in the same
+	// Y * X * Z convention as GameObject rotations.
+	//
+	// Returns:
+	//   - [3]float32: the rotation
+	Rotation() [3]float32
+
+	// SetRotation sets the body orientation from Euler angles in radians.
+	//
+	// Parameters:
+	//   - rx, ry, rz: the rotation around each axis
+	SetRotation(rx, ry, rz float32)
+
+	// Orientation returns the body orientation as a unit quaternion (x, y, z, w).
+	//
+	// Returns:
+	//   - [4]float32: the quaternion
+	Orientation() [4]float32
+
+	// LinearVelocity returns the velocity of the center of mass in units per second.
+	//
+	// Returns:
+	//   - [3]float32: the linear velocity
+	LinearVelocity() [3]float32
+
+	// SetLinearVelocity sets the velocity of the center of mass.
+	//
+	// Parameters:
+	//   - x, y, z: the linear velocity
+	SetLinearVelocity(x, y, z float32)
+
+	// AngularVelocity returns the world-space angular velocity in radians per second.
+	//
+	// Returns:
+	//   - [3]float32: the angular velocity
+	AngularVelocity() [3]float32
+
+	// SetAngularVelocity sets the world-space angular velocity.
+	//
+	// Parameters:
+	//   - x, y, z: the angular velocity
+	SetAngularVelocity(x, y, z float32)
+
+	// ApplyForce accumulates a force through the center of mass for the next step.
+	//
+	// Parameters:
+	//   - fx, fy, fz: the force in newtons
+	ApplyForce(fx, fy, fz float32)
+
+	// ApplyTorque accumulates a world-space torque for the next step.
+	//
+	// Parameters:
+	//   - tx, ty, tz: the torque
+	ApplyTorque(tx, ty, tz float32)
+
+	// ApplyImpulse instantly changes the body's momentum by an impulse applied at a world point.
+	//
+	// Parameters:
+	//   - impulse: the impulse in newton-seconds
+	//   - point: the world-space point of application
+	ApplyImpulse(impulse, point [3]float32)
+
+	// Restitution returns the bounciness in [0, 1].
+	//
+	// Returns:
+	//   - float32: the restitution
+	Restitution() float32
+
+	// SetRestitution sets the bounciness. Contacts use the larger value of the two bodies.
+	//
+	// Parameters:
+	//   - restitution: the restitution in [0, 1]
+	SetRestitution(restitution float32)
+
+	// Friction returns the Coulomb friction coefficient.
+	//
+	// Returns:
+	//   - float32: the friction
+	Friction() float32
+
+	// SetFriction sets the friction coefficient. Contacts use the geometric mean of the two bodies.
+	//
+	// Parameters:
+	//   - friction: the friction coefficient
+	SetFriction(friction float32)
+
+	// GravityScale returns the multiplier applied to world gravity.
+	//
+	// Returns:
+	//   - float32: the gravity scale
+	GravityScale() float32
+
+	// SetGravityScale sets the multiplier applied to world gravity.
+	//
+	// Parameters:
+	//   - s: the gravity scale
+	SetGravityScale(s float32)
+
+	// Bounds returns the world-space bounding box of the body's collider.
+	//
+	// Returns:
+	//   - [3]float32: the minimum corner
+	//   - [3]float32: the maximum corner
+	Bounds() (bmin, bmax [3]float32)
+}
+
+// body implements the Body interface.
+type body struct {
+	id       uint64
+	bodyType BodyType
+	col      *collider
+	obj      game_object.GameObject
+	trigger  bool
+
+	mass        float32
+	invMass     float32
+	invInertia  vec3 // local principal inverse inertia
+	restitution float32
+	friction    float32
+	gravity     float32 // gravity scale
+
+	linearDamping  float32
+	angularDamping float32
+
+	position    vec3
+	orientation quat
+	rot         mat3 // cached rotation matrix of orientation
+	velocity    vec3
+	angular     vec3
+
+	force  vec3
+	torque vec3
+
+	explicitTransform bool // set by WithPosition/WithRotation so a bound object does not override them
+}
+
+var _ Body = &body{}
+
+// NewBody creates a new Body configured with the given options. Dynamic bodies default to a
+// mass of 1, restitution 0, friction 0.5 and gravity scale 1.
+//
+// Parameters:
+//   - options: variadic list of BodyBuilderOption functions to configure the Body
+//
+// Returns:
+//   - Body: the newly created Body
+func NewBody(options ...BodyBuilderOption) Body {
+	b := &body{
+		mass:        1,
+		friction:    0.5,
+		gravity:     1,
+		orientation: identityQuat,
+	}
+	for _, opt := range options {
+		opt(b)
+	}
+
+	// A bound object supplies the initial transform unless one was given explicitly.
+	if b.obj != nil && !b.explicitTransform {
+		b.readObject()
+	}
+	b.rot = quatToMat3(b.orientation)
+	b.updateMass()
+	return b
+}
+
+func (b *body) ID() uint64 {
+	return b.id
+}
+
+func (b *body) Type() BodyType {
+	return b.bodyType
+}
+
+func (b *body) Collider() Collider {
+	if b.col == nil {
+		return nil
+	}
+	return b.col
+}
+
+func (b *body) GameObject() game_object.GameObject {
+	return b.obj
+}
+
+func (b *body) Trigger() bool {
+	return b.trigger
+}
+
+func (b *body) Mass() float32 {
+	if b.bodyType != BodyDynamic {
+		return 0
+	}
+	return b.mass
+}
+
+func (b *body) SetMass(mass float32) {
+	if mass <= 0 {
+		return
+	}
+	b.mass = mass
+	b.updateMass()
+}
+
+func (b *body) Position() [3]float32 {
+	return b.position
+}
+
+func (b *body) SetPosition(x, y, z float32) {
+	b.position = vec3{x, y, z}
+}
+
+func (b *body) Rotation() [3]float32 {
+	return quatToEuler(b.orientation)
+}
+
+func (b *body) SetRotation(rx, ry, rz float32) {
+	b.orientation = eulerToQuat(vec3{rx, ry, rz})
+	b.rot = quatToMat3(b.orientation)
+}
+
+func (b *body) Orientation() [4]float32 {
+	return b.orientation
+}
+
+func (b *body) LinearVelocity() [3]float32 {
+	return b.velocity
+}
+
+func (b *body) SetLinearVelocity(x, y, z float32) {
+	b.velocity = vec3{x, y, z}
+}
+
+func (b *body) AngularVelocity() [3]float32 {
+	return b.angular
+}
+
+func (b *body) SetAngularVelocity(x, y, z float32) {
+	b.angular = vec3{x, y, z}
+}
+
+func (b *body) ApplyForce(fx, fy, fz float32) {
+	b.force = add(b.force, vec3{fx, fy, fz})
+}
+
+func (b *body) ApplyTorque(tx, ty, tz float32) {
+	b.torque = add(b.torque, vec3{tx, ty, tz})
+}
+
+func (b *body) ApplyImpulse(impulse, point [3]float32) {
+	if b.invMass == 0 {
+		return
+	}
+	b.velocity = add(b.velocity, scale(impulse, b.invMass))
+	b.angular = add(b.angular, b.invInertiaMul(cross(sub(point, b.position), impulse)))
+}
+
+func (b *body) Restitution() float32 {
+	return b.restitution
+}
+
+func (b *body) SetRestitution(restitution float32) {
+	b.restitution = restitution
+}
+
+func (b *body) Friction() float32 {
+	return b.friction
+}
+
+func (b *body) SetFriction(friction float32) {
+	b.friction = friction
+}
+
+func (b *body) GravityScale() float32 {
+	return b.gravity
+}
+
+func (b *body) SetGravityScale(s float32) {
+	b.gravity = s
+}
+
+func (b *body) Bounds() (bmin, bmax [3]float32) {
+	if b.col == nil {
+		return b.position, b.position
+	}
+	box := b.col.worldBounds(b.position, b.rot)
+	return box.min, box.max
+}
+
+// updateMass recomputes the inverse mass and inertia from the mass, type and collider.
+func (b *body) updateMass() {
+	if b.bodyType != BodyDynamic {
+		b.invMass = 0
+		b.invInertia = vec3{}
+		return
+	}
+	b.invMass = 1 / b.mass
+	b.invInertia = vec3{}
+	if b.col == nil {
+		return
+	}
+	in := b.col.inertia(b.mass)
+	for i := range 3 {
+		if in[i] > 0 {
+			b.invInertia[i] = 1 / in[i]
+		}
+	}
+}
+
+// invInertiaMul multiplies a world-space vector by the world inverse inertia tensor.
+//
+// Parameters:
+//   - v: the world-space vector
+//
+// Returns:
+//   - vec3: R * diag(invInertia) * R^T * v
+func (b *body) invInertiaMul(v vec3) vec3 {
+	return rotate(b.rot, mulComp(b.invInertia, rotateInv(b.rot, v)))
+}
+
+// velocityAt returns the velocity of a world-space point attached to the body.
+//
+// Parameters:
+//   - p: the world-space point
+//
+// Returns:
+//   - vec3: the point velocity
+func (b *body) velocityAt(p vec3) vec3 {
+	return add(b.velocity, cross(b.angular, sub(p, b.position)))
+}
+
+// readObject copies the bound object's world position and rotation into the body.
+func (b *body) readObject() {
+	x, y, z := b.obj.WorldPosition()
+	rx, ry, rz := b.obj.WorldRotation()
+	b.position = vec3{x, y, z}
+	b.orientation = eulerToQuat(vec3{rx, ry, rz})
+	b.rot = quatToMat3(b.orientation)
+}
+
+// writeObject copies the body transform to the bound object. Objects with a parent receive
+// the equivalent local transform; the object's scale is left unchanged.
+func (b *body) writeObject() {
+	parent := b.obj.Parent()
+	if parent == nil {
+		rot := quatToEuler(b.orientation)
+		b.obj.SetPosition(b.position[0], b.position[1], b.position[2])
+		b.obj.SetRotation(rot[0], rot[1], rot[2])
+		return
+	}
+
+	sx, sy, sz := b.obj.WorldScale()
+	world := transformMatrix(b.position, b.orientation, vec3{sx, sy, sz})
+	parentWorld := parent.WorldMatrix()
+	var inv, local [16]float32
+	if !common.Invert4(inv[:], parentWorld[:]) {
+		return
+	}
+	common.Mul4(local[:], inv[:], world)
+	pos, rot, _ := common.DecomposeModelMatrix(local[:])
+	b.obj.SetPosition(pos[0], pos[1], pos[2])
+	b.obj.SetRotation(rot[0], rot[1], rot[2])
+}
@@ -0,0 +1,188 @@
+package physics
+
+import "github.com/Carmen-Shannon/oxy-go/engine/game_object"
+
+// BodyBuilderOption is a functional option for configuring a Body.
+// Use the With* functions to create options that are applied directly to the body instance.
+type BodyBuilderOption func(*body)
+
+// WithBodyType sets how the body is moved (default BodyDynamic).
+//
+// Parameters:
+//   - t: the body type
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithBodyType(t BodyType) BodyBuilderOption {
+	return func(b *body) {
+		b.bodyType = t
+	}
+}
+
+// WithCollider sets the body's collision shape.
+//
+// Parameters:
+//   - c: the collider
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithCollider(c Collider) BodyBuilderOption {
+	return func(b *body) {
+		if c == nil {
+			b.col = nil
+			return
+		}
+		b.col = c.(*collider)
+	}
+}
+
+// WithGameObject binds the body to a GameObject. Dynamic bodies write their transform to the
+// object after every step; kinematic bodies follow the object. Unless WithPosition or
+// WithRotation is given, the body starts at the object's world transform.
+//
+// Parameters:
+//   - obj: the object to bind
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithGameObject(obj game_object.GameObject) BodyBuilderOption {
+	return func(b *body) {
+		b.obj = obj
+	}
+}
+
+// WithMass sets the mass of a dynamic body in kilograms (default 1).
+//
+// Parameters:
+//   - mass: the mass; values <= 0 are ignored
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithMass(mass float32) BodyBuilderOption {
+	return func(b *body) {
+		if mass > 0 {
+			b.mass = mass
+		}
+	}
+}
+
+// WithPosition sets the initial world position.
+//
+// Parameters:
+//   - x, y, z: the position
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithPosition(x, y, z float32) BodyBuilderOption {
+	return func(b *body) {
+		b.position = vec3{x, y, z}
+		b.explicitTransform = true
+	}
+}
+
+// WithRotation sets the initial orientation from Euler angles in radians.
+//
+// Parameters:
+//   - rx, ry, rz: the rotation around each axis
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithRotation(rx, ry, rz float32) BodyBuilderOption {
+	return func(b *body) {
+		b.orientation = eulerToQuat(vec3{rx, ry, rz})
+		b.explicitTransform = true
+	}
+}
+
+// WithVelocity sets the initial linear velocity.
+//
+// Parameters:
+//   - x, y, z: the velocity
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithVelocity(x, y, z float32) BodyBuilderOption {
+	return func(b *body) {
+		b.velocity = vec3{x, y, z}
+	}
+}
+
+// WithAngularVelocity sets the initial world-space angular velocity.
+//
+// Parameters:
+//   - x, y, z: the angular velocity in radians per second
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithAngularVelocity(x, y, z float32) BodyBuilderOption {
+	return func(b *body) {
+		b.angular = vec3{x, y, z}
+	}
+}
+
+// WithRestitution sets the bounciness in [0, 1] (default 0).
+//
+// Parameters:
+//   - restitution: the restitution
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithRestitution(restitution float32) BodyBuilderOption {
+	return func(b *body) {
+		b.restitution = restitution
+	}
+}
+
+// WithFriction sets the Coulomb friction coefficient (default 0.5).
+//
+// Parameters:
+//   - friction: the friction coefficient
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithFriction(friction float32) BodyBuilderOption {
+	return func(b *body) {
+		b.friction = friction
+	}
+}
+
+// WithGravityScale sets the multiplier applied to world gravity (default 1).
+//
+// Parameters:
+//   - s: the gravity scale
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithGravityScale(s float32) BodyBuilderOption {
+	return func(b *body) {
+		b.gravity = s
+	}
+}
+
+// WithDamping sets the linear and angular velocity damping per second (default 0).
+//
+// Parameters:
+//   - linear: the linear damping
+//   - angular: the angular damping
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithDamping(linear, angular float32) BodyBuilderOption {
+	return func(b *body) {
+		b.linearDamping = linear
+		b.angularDamping = angular
+	}
+}
+
+// WithTrigger makes the body a trigger: its contacts are reported but never resolved.
+//
+// Parameters:
+//   - trigger: whether the body is a trigger
+//
+// Returns:
+//   - BodyBuilderOption: option function to apply
+func WithTrigger(trigger bool) BodyBuilderOption {
+	return func(b *body) {
+		b.trigger = trigger
+	}
+}
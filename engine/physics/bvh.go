@@ -0,0 +1,138 @@
+package physics
+
+import (
+	"math"
+	"slices"
+)
+
+// aabb is an axis-aligned bounding box.
+type aabb struct {
+	min, max vec3
+}
+
+// emptyAABB returns an inverted box that any point or box extends.
+func emptyAABB() aabb {
+	inf := float32(math.MaxFloat32)
+	return aabb{vec3{inf, inf, inf}, vec3{-inf, -inf, -inf}}
+}
+
+func (b aabb) extend(p vec3) aabb {
+	return aabb{
+		vec3{min(b.min[0], p[0]), min(b.min[1], p[1]), min(b.min[2], p[2])},
+		vec3{max(b.max[0], p[0]), max(b.max[1], p[1]), max(b.max[2], p[2])},
+	}
+}
+
+func (b aabb) union(o aabb) aabb {
+	return b.extend(o.min).extend(o.max)
+}
+
+func (b aabb) overlaps(o aabb) bool {
+	return b.min[0] <= o.max[0] && b.max[0] >= o.min[0] &&
+		b.min[1] <= o.max[1] && b.max[1] >= o.min[1] &&
+		b.min[2] <= o.max[2] && b.max[2] >= o.min[2]
+}
+
+func (b aabb) center() vec3 {
+	return scale(add(b.min, b.max), 0.5)
+}
+
+// bvhNode is a node of a bounding volume hierarchy. Leaves reference a single item.
+type bvhNode struct {
+	box         aabb
+	left, right int32 // child node indices; unused for leaves
+	item        int32 // item index for leaves, -1 for inner nodes
+}
+
+// bvh is a static bounding volume hierarchy built top-down by splitting items at the median
+// of their centers along the longest axis. The broadphase rebuilds it every step; mesh
+// colliders build it once.
+type bvh struct {
+	nodes []bvhNode
+}
+
+// buildBVH builds a hierarchy over the given boxes; items are identified by their index.
+//
+// Parameters:
+//   - boxes: the item bounds
+//
+// Returns:
+//   - *bvh: the hierarchy
+func buildBVH(boxes []aabb) *bvh {
+	t := &bvh{nodes: make([]bvhNode, 0, max(2*len(boxes)-1, 0))}
+	if len(boxes) == 0 {
+		return t
+	}
+	items := make([]int32, len(boxes))
+	for i := range items {
+		items[i] = int32(i)
+	}
+	t.build(boxes, items)
+	return t
+}
+
+// build appends the subtree for items and returns its node index.
+func (t *bvh) build(boxes []aabb, items []int32) int32 {
+	idx := int32(len(t.nodes))
+	t.nodes = append(t.nodes, bvhNode{item: -1})
+
+	if len(items) == 1 {
+		t.nodes[idx] = bvhNode{box: boxes[items[0]], item: items[0]}
+		return idx
+	}
+
+	box := emptyAABB()
+	centers := emptyAABB()
+	for _, it := range items {
+		box = box.union(boxes[it])
+		centers = centers.extend(boxes[it].center())
+	}
+	extent := sub(centers.max, centers.min)
+	axis := 0
+	if extent[1] > extent[axis] {
+		axis = 1
+	}
+	if extent[2] > extent[axis] {
+		axis = 2
+	}
+	slices.SortFunc(items, func(a, b int32) int {
+		ca, cb := boxes[a].center()[axis], boxes[b].center()[axis]
+		switch {
+		case ca < cb:
+			return -1
+		case ca > cb:
+			return 1
+		}
+		return 0
+	})
+
+	mid := len(items) / 2
+	left := t.build(boxes, items[:mid])
+	right := t.build(boxes, items[mid:])
+	t.nodes[idx] = bvhNode{box: box, left: left, right: right, item: -1}
+	return idx
+}
+
+// query calls fn for every item whose bounds overlap box.
+//
+// Parameters:
+//   - box: the query bounds
+//   - fn: the callback receiving each overlapping item index
+func (t *bvh) query(box aabb, fn func(item int)) {
+	if len(t.nodes) == 0 {
+		return
+	}
+	stack := make([]int32, 1, 64)
+	for len(stack) > 0 {
+		n := &t.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if !n.box.overlaps(box) {
+			continue
+		}
+		if n.item >= 0 {
+			fn(int(n.item))
+			continue
+		}
+		stack = append(stack, n.left, n.right)
+	}
+}
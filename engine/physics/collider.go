@@ -0,0 +1,459 @@
+package physics
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/model"
+)
+
+// ShapeType identifies the geometric shape of a Collider.
+type ShapeType int
+
+const (
+	// ShapeSphere is a sphere around the collider center.
+	ShapeSphere ShapeType = iota
+	// ShapeAABB is a box that stays aligned to the world axes regardless of body rotation.
+	ShapeAABB
+	// ShapeOBB is a box that rotates with its body.
+	ShapeOBB
+	// ShapeCapsule is a segment along the local Y axis swept by a sphere.
+	ShapeCapsule
+	// ShapeMesh is a triangle mesh. Mesh colliders collide with every other shape but not
+	// with other meshes, and are intended for static or kinematic bodies.
+	ShapeMesh
+)
+
+// Collider describes the shape a Body collides with, in the body's local space.
+// Colliders are immutable and may be shared between bodies.
+type Collider interface {
+	// Shape returns the collider's shape type.
+	//
+	// Returns:
+	//   - ShapeType: the shape
+	Shape() ShapeType
+
+	// Offset returns the collider center relative to the body origin, in body space.
+	//
+	// Returns:
+	//   - [3]float32: the local offset
+	Offset() [3]float32
+
+	// Radius returns the radius of a sphere or capsule, or 0 for other shapes.
+	//
+	// Returns:
+	//   - float32: the radius
+	Radius() float32
+
+	// HalfExtents returns the half size of a box along each local axis, or the local
+	// bounding half size of a capsule or mesh.
+	//
+	// Returns:
+	//   - [3]float32: the half extents
+	HalfExtents() [3]float32
+
+	// HalfHeight returns half the length of a capsule's inner segment, or 0 for other shapes.
+	//
+	// Returns:
+	//   - float32: the half height
+	HalfHeight() float32
+
+	// TriangleCount returns the number of triangles of a mesh collider, or 0 for other shapes.
+	//
+	// Returns:
+	//   - int: the triangle count
+	TriangleCount() int
+}
+
+// collider implements the Collider interface for every shape.
+type collider struct {
+	shape       ShapeType
+	offset      vec3
+	radius      float32
+	halfExtents vec3
+	halfHeight  float32
+	mesh        *meshShape
+}
+
+// meshShape holds the triangles of a mesh collider with a BVH over them.
+type meshShape struct {
+	positions []vec3
+	indices   []uint32
+	tree      *bvh
+	center    vec3 // center of the local bounds
+}
+
+var _ Collider = &collider{}
+
+// NewSphereCollider creates a sphere collider.
+//
+// Parameters:
+//   - radius: the sphere radius
+//   - options: optional collider options (offset)
+//
+// Returns:
+//   - Collider: the collider
+func NewSphereCollider(radius float32, options ...ColliderBuilderOption) Collider {
+	return newCollider(&collider{shape: ShapeSphere, radius: radius, halfExtents: vec3{radius, radius, radius}}, options)
+}
+
+// NewAABBCollider creates a world-axis-aligned box collider. The box ignores its body's rotation.
+//
+// Parameters:
+//   - hx, hy, hz: the half size along each world axis
+//   - options: optional collider options (offset)
+//
+// Returns:
+//   - Collider: the collider
+func NewAABBCollider(hx, hy, hz float32, options ...ColliderBuilderOption) Collider {
+	return newCollider(&collider{shape: ShapeAABB, halfExtents: vec3{hx, hy, hz}}, options)
+}
+
+// NewBoxCollider creates an oriented box collider that rotates with its body.
+//
+// Parameters:
+//   - hx, hy, hz: the half size along each local axis
+//   - options: optional collider options (offset)
+//
+// Returns:
+//   - Collider: the collider
+func NewBoxCollider(hx, hy, hz float32, options ...ColliderBuilderOption) Collider {
+	return newCollider(&collider{shape: ShapeOBB, halfExtents: vec3{hx, hy, hz}}, options)
+}
+
+// NewCapsuleCollider creates a capsule collider aligned with the body's local Y axis.
+//
+// Parameters:
+//   - radius: the capsule radius
+//   - halfHeight: half the length of the inner segment, excluding the end caps
+//   - options: optional collider options (offset)
+//
+// Returns:
+//   - Collider: the collider
+func NewCapsuleCollider(radius, halfHeight float32, options ...ColliderBuilderOption) Collider {
+	return newCollider(&collider{
+		shape:       ShapeCapsule,
+		radius:      radius,
+		halfHeight:  halfHeight,
+		halfExtents: vec3{radius, halfHeight + radius, radius},
+	}, options)
+}
+
+// NewMeshCollider creates a triangle mesh collider. Positions are in body space; indices
+// list three vertices per triangle and may be nil for a non-indexed triangle list.
+//
+// Parameters:
+//   - positions: the vertex positions
+//   - indices: the triangle indices, or nil
+//   - options: optional collider options (offset)
+//
+// Returns:
+//   - Collider: the collider
+func NewMeshCollider(positions [][3]float32, indices []uint32, options ...ColliderBuilderOption) Collider {
+	if indices == nil {
+		indices = make([]uint32, len(positions)-len(positions)%3)
+		for i := range indices {
+			indices[i] = uint32(i)
+		}
+	}
+
+	triCount := len(indices) / 3
+	boxes := make([]aabb, 0, triCount)
+	bounds := emptyAABB()
+	for t := range triCount {
+		box := emptyAABB()
+		for k := range 3 {
+			idx := indices[t*3+k]
+			if int(idx) >= len(positions) {
+				panic("physics: mesh collider index out of range")
+			}
+			box = box.extend(positions[idx])
+		}
+		boxes = append(boxes, box)
+		bounds = bounds.union(box)
+	}
+	if triCount == 0 {
+		bounds = aabb{}
+	}
+
+	m := &meshShape{
+		positions: positions,
+		indices:   indices[:triCount*3],
+		tree:      buildBVH(boxes),
+		center:    scale(add(bounds.min, bounds.max), 0.5),
+	}
+	return newCollider(&collider{
+		shape:       ShapeMesh,
+		halfExtents: scale(sub(bounds.max, bounds.min), 0.5),
+		mesh:        m,
+	}, options)
+}
+
+// NewMeshColliderFromModel creates a triangle mesh collider from a Model's vertex and index data.
+//
+// Parameters:
+//   - m: the Model whose mesh to use
+//   - sx, sy, sz: the scale applied to the mesh positions
+//   - options: optional collider options (offset)
+//
+// Returns:
+//   - Collider: the collider
+func NewMeshColliderFromModel(m model.Model, sx, sy, sz float32, options ...ColliderBuilderOption) Collider {
+	positions, indices := ModelPositions(m)
+	for i := range positions {
+		positions[i] = mulComp(positions[i], vec3{sx, sy, sz})
+	}
+	return NewMeshCollider(positions, indices, options...)
+}
+
+// NewSphereColliderFromBounds creates a sphere collider that encloses a bounding box,
+// e.g. ImportedMesh.BoundingMin/BoundingMax. The collider is offset to the box center.
+//
+// Parameters:
+//   - bmin: the minimum corner in model space
+//   - bmax: the maximum corner in model space
+//   - sx, sy, sz: the scale applied to the bounds
+//
+// Returns:
+//   - Collider: the collider
+func NewSphereColliderFromBounds(bmin, bmax [3]float32, sx, sy, sz float32) Collider {
+	c, h := scaledBounds(bmin, bmax, vec3{sx, sy, sz})
+	return NewSphereCollider(length(h), WithOffset(c[0], c[1], c[2]))
+}
+
+// NewAABBColliderFromBounds creates a world-axis-aligned box collider matching a bounding box.
+//
+// Parameters:
+//   - bmin: the minimum corner in model space
+//   - bmax: the maximum corner in model space
+//   - sx, sy, sz: the scale applied to the bounds
+//
+// Returns:
+//   - Collider: the collider
+func NewAABBColliderFromBounds(bmin, bmax [3]float32, sx, sy, sz float32) Collider {
+	c, h := scaledBounds(bmin, bmax, vec3{sx, sy, sz})
+	return NewAABBCollider(h[0], h[1], h[2], WithOffset(c[0], c[1], c[2]))
+}
+
+// NewBoxColliderFromBounds creates an oriented box collider matching a bounding box.
+//
+// Parameters:
+//   - bmin: the minimum corner in model space
+//   - bmax: the maximum corner in model space
+//   - sx, sy, sz: the scale applied to the bounds
+//
+// Returns:
+//   - Collider: the collider
+func NewBoxColliderFromBounds(bmin, bmax [3]float32, sx, sy, sz float32) Collider {
+	c, h := scaledBounds(bmin, bmax, vec3{sx, sy, sz})
+	return NewBoxCollider(h[0], h[1], h[2], WithOffset(c[0], c[1], c[2]))
+}
+
+// NewCapsuleColliderFromBounds creates a Y-axis capsule collider fitted inside a bounding box:
+// the radius is the larger horizontal half extent and the caps touch the top and bottom.
+//
+// Parameters:
+//   - bmin: the minimum corner in model space
+//   - bmax: the maximum corner in model space
+//   - sx, sy, sz: the scale applied to the bounds
+//
+// Returns:
+//   - Collider: the collider
+func NewCapsuleColliderFromBounds(bmin, bmax [3]float32, sx, sy, sz float32) Collider {
+	c, h := scaledBounds(bmin, bmax, vec3{sx, sy, sz})
+	radius := max(h[0], h[2])
+	return NewCapsuleCollider(radius, max(h[1]-radius, 0), WithOffset(c[0], c[1], c[2]))
+}
+
+// MeshBounds returns the union of the bounding boxes of imported meshes.
+//
+// Parameters:
+//   - meshes: the meshes to enclose
+//
+// Returns:
+//   - [3]float32: the minimum corner
+//   - [3]float32: the maximum corner
+func MeshBounds(meshes ...model.ImportedMesh) (bmin, bmax [3]float32) {
+	box := emptyAABB()
+	for _, m := range meshes {
+		box = box.extend(m.BoundingMin).extend(m.BoundingMax)
+	}
+	if len(meshes) == 0 {
+		return
+	}
+	return box.min, box.max
+}
+
+// ModelBounds computes the bounding box of a Model's vertex positions.
+//
+// Parameters:
+//   - m: the Model to measure
+//
+// Returns:
+//   - [3]float32: the minimum corner
+//   - [3]float32: the maximum corner
+func ModelBounds(m model.Model) (bmin, bmax [3]float32) {
+	positions, _ := ModelPositions(m)
+	box := emptyAABB()
+	for _, p := range positions {
+		box = box.extend(p)
+	}
+	if len(positions) == 0 {
+		return
+	}
+	return box.min, box.max
+}
+
+// ModelPositions decodes the vertex positions and triangle indices of a Model's mesh data.
+//
+// Parameters:
+//   - m: the Model to decode
+//
+// Returns:
+//   - [][3]float32: the vertex positions
+//   - []uint32: the triangle indices, or nil if the mesh is not indexed
+func ModelPositions(m model.Model) ([][3]float32, []uint32) {
+	stride := (&model.GPUVertex{}).Size()
+	if m.Skinned() {
+		stride = (&model.GPUSkinnedVertex{}).Size()
+	}
+	data := m.VertexData()
+	positions := make([][3]float32, len(data)/stride)
+	for i := range positions {
+		off := i * stride
+		positions[i] = vec3{
+			math.Float32frombits(binary.LittleEndian.Uint32(data[off:])),
+			math.Float32frombits(binary.LittleEndian.Uint32(data[off+4:])),
+			math.Float32frombits(binary.LittleEndian.Uint32(data[off+8:])),
+		}
+	}
+
+	raw := m.IndexData()
+	if len(raw) == 0 {
+		return positions, nil
+	}
+	indices := make([]uint32, len(raw)/4)
+	for i := range indices {
+		indices[i] = binary.LittleEndian.Uint32(raw[i*4:])
+	}
+	return positions, indices
+}
+
+func (c *collider) Shape() ShapeType {
+	return c.shape
+}
+
+func (c *collider) Offset() [3]float32 {
+	return c.offset
+}
+
+func (c *collider) Radius() float32 {
+	return c.radius
+}
+
+func (c *collider) HalfExtents() [3]float32 {
+	return c.halfExtents
+}
+
+func (c *collider) HalfHeight() float32 {
+	return c.halfHeight
+}
+
+func (c *collider) TriangleCount() int {
+	if c.mesh == nil {
+		return 0
+	}
+	return len(c.mesh.indices) / 3
+}
+
+// newCollider applies options to a collider.
+//
+// Parameters:
+//   - c: the collider to configure
+//   - options: the options to apply
+//
+// Returns:
+//   - Collider: the configured collider
+func newCollider(c *collider, options []ColliderBuilderOption) Collider {
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+// inertia returns the diagonal of the local inertia tensor for a given mass. Mesh colliders
+// use the inertia of their bounding box. The collider offset is not taken into account.
+//
+// Parameters:
+//   - mass: the body mass
+//
+// Returns:
+//   - vec3: the principal moments of inertia
+func (c *collider) inertia(mass float32) vec3 {
+	switch c.shape {
+	case ShapeSphere:
+		i := 0.4 * mass * c.radius * c.radius
+		return vec3{i, i, i}
+	case ShapeCapsule:
+		// Cylinder plus two hemispheres, split by volume.
+		r, h := c.radius, 2*c.halfHeight
+		cylVol := r * r * h
+		capVol := 4.0 / 3.0 * r * r * r
+		mc := mass * cylVol / (cylVol + capVol)
+		ms := mass - mc
+		iy := mc*r*r/2 + ms*0.4*r*r
+		ixz := mc*(r*r/4+h*h/12) + ms*(0.4*r*r+h*h/4+3*h*r/8)
+		return vec3{ixz, iy, ixz}
+	default:
+		x, y, z := 2*c.halfExtents[0], 2*c.halfExtents[1], 2*c.halfExtents[2]
+		k := mass / 12
+		return vec3{k * (y*y + z*z), k * (x*x + z*z), k * (x*x + y*y)}
+	}
+}
+
+// worldBounds returns the world-space bounding box of the collider at a body pose.
+//
+// Parameters:
+//   - pos: the body position
+//   - rot: the body rotation
+//
+// Returns:
+//   - aabb: the world bounds
+func (c *collider) worldBounds(pos vec3, rot mat3) aabb {
+	center := add(pos, rotate(rot, c.offset))
+	switch c.shape {
+	case ShapeSphere:
+		r := vec3{c.radius, c.radius, c.radius}
+		return aabb{sub(center, r), add(center, r)}
+	case ShapeAABB:
+		return aabb{sub(center, c.halfExtents), add(center, c.halfExtents)}
+	case ShapeCapsule:
+		axis := scale(rot[1], c.halfHeight)
+		r := vec3{c.radius, c.radius, c.radius}
+		a, b := add(center, axis), sub(center, axis)
+		return aabb{sub(vec3{min(a[0], b[0]), min(a[1], b[1]), min(a[2], b[2])}, r), add(vec3{max(a[0], b[0]), max(a[1], b[1]), max(a[2], b[2])}, r)}
+	case ShapeMesh:
+		center = add(pos, rotate(rot, add(c.offset, c.mesh.center)))
+	}
+	var e vec3
+	for i := range 3 {
+		e[i] = absf(rot[0][i])*c.halfExtents[0] + absf(rot[1][i])*c.halfExtents[1] + absf(rot[2][i])*c.halfExtents[2]
+	}
+	return aabb{sub(center, e), add(center, e)}
+}
+
+// scaledBounds returns the center and half extents of a scaled bounding box.
+//
+// Parameters:
+//   - bmin: the minimum corner
+//   - bmax: the maximum corner
+//   - s: the scale
+//
+// Returns:
+//   - vec3: the scaled center
+//   - vec3: the scaled, non-negative half extents
+func scaledBounds(bmin, bmax, s vec3) (vec3, vec3) {
+	c := mulComp(scale(add(bmin, bmax), 0.5), s)
+	h := mulComp(scale(sub(bmax, bmin), 0.5), s)
+	return c, vec3{absf(h[0]), absf(h[1]), absf(h[2])}
+}
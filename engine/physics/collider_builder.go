@@ -0,0 +1,18 @@
+package physics
+
+// ColliderBuilderOption is a functional option for configuring a Collider.
+// Use the With* functions to create options that are applied directly to the collider instance.
+type ColliderBuilderOption func(*collider)
+
+// WithOffset moves the collider center away from the body origin, in body space.
+//
+// Parameters:
+//   - x, y, z: the local offset
+//
+// Returns:
+//   - ColliderBuilderOption: option function to apply
+func WithOffset(x, y, z float32) ColliderBuilderOption {
+	return func(c *collider) {
+		c.offset = vec3{x, y, z}
+	}
+}
@@ -0,0 +1,171 @@
+package physics
+
+import (
+	"math"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+)
+
+// vec3 is a 3-component vector used throughout the solver.
+type vec3 = [3]float32
+
+// quat is a unit quaternion stored as (x, y, z, w).
+type quat = [4]float32
+
+// mat3 is a rotation matrix stored as three world-space basis columns.
+type mat3 = [3]vec3
+
+var identityQuat = quat{0, 0, 0, 1}
+
+func add(a, b vec3) vec3 {
+	return vec3{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func sub(a, b vec3) vec3 {
+	return vec3{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func scale(a vec3, s float32) vec3 {
+	return vec3{a[0] * s, a[1] * s, a[2] * s}
+}
+
+func neg(a vec3) vec3 {
+	return vec3{-a[0], -a[1], -a[2]}
+}
+
+func mulComp(a, b vec3) vec3 {
+	return vec3{a[0] * b[0], a[1] * b[1], a[2] * b[2]}
+}
+
+func dot(a, b vec3) float32 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func cross(a, b vec3) vec3 {
+	return vec3{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func length(a vec3) float32 {
+	return float32(math.Sqrt(float64(dot(a, a))))
+}
+
+func normalize(a vec3) vec3 {
+	l := length(a)
+	if l == 0 {
+		return a
+	}
+	return scale(a, 1/l)
+}
+
+func clamp(v, lo, hi float32) float32 {
+	return max(lo, min(v, hi))
+}
+
+func absf(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// orthonormal returns a unit vector perpendicular to n.
+func orthonormal(n vec3) vec3 {
+	if absf(n[0]) > 0.57735 {
+		return normalize(vec3{n[1], -n[0], 0})
+	}
+	return normalize(vec3{0, n[2], -n[1]})
+}
+
+// quatToMat3 converts a unit quaternion to a rotation matrix.
+func quatToMat3(q quat) mat3 {
+	x, y, z, w := q[0], q[1], q[2], q[3]
+	return mat3{
+		{1 - 2*(y*y+z*z), 2 * (x*y + z*w), 2 * (x*z - y*w)},
+		{2 * (x*y - z*w), 1 - 2*(x*x+z*z), 2 * (y*z + x*w)},
+		{2 * (x*z + y*w), 2 * (y*z - x*w), 1 - 2*(x*x+y*y)},
+	}
+}
+
+// mat3ToQuat converts a rotation matrix to a unit quaternion.
+//
+// Reference: https://www.euclideanspace.com/maths/geometry/rotations/conversions/matrixToQuaternion/
+func mat3ToQuat(m mat3) quat {
+	// m[c][r] is the element at row r, column c.
+	m00, m11, m22 := m[0][0], m[1][1], m[2][2]
+	trace := m00 + m11 + m22
+	var q quat
+	switch {
+	case trace > 0:
+		s := float32(math.Sqrt(float64(trace+1))) * 2
+		q = quat{(m[1][2] - m[2][1]) / s, (m[2][0] - m[0][2]) / s, (m[0][1] - m[1][0]) / s, 0.25 * s}
+	case m00 > m11 && m00 > m22:
+		s := float32(math.Sqrt(float64(1+m00-m11-m22))) * 2
+		q = quat{0.25 * s, (m[1][0] + m[0][1]) / s, (m[2][0] + m[0][2]) / s, (m[1][2] - m[2][1]) / s}
+	case m11 > m22:
+		s := float32(math.Sqrt(float64(1+m11-m00-m22))) * 2
+		q = quat{(m[1][0] + m[0][1]) / s, 0.25 * s, (m[2][1] + m[1][2]) / s, (m[2][0] - m[0][2]) / s}
+	default:
+		s := float32(math.Sqrt(float64(1+m22-m00-m11))) * 2
+		q = quat{(m[2][0] + m[0][2]) / s, (m[2][1] + m[1][2]) / s, 0.25 * s, (m[0][1] - m[1][0]) / s}
+	}
+	return normalizeQuat(q)
+}
+
+func normalizeQuat(q quat) quat {
+	l := float32(math.Sqrt(float64(q[0]*q[0] + q[1]*q[1] + q[2]*q[2] + q[3]*q[3])))
+	if l == 0 {
+		return identityQuat
+	}
+	return quat{q[0] / l, q[1] / l, q[2] / l, q[3] / l}
+}
+
+// integrateQuat advances an orientation by an angular velocity over dt.
+func integrateQuat(q quat, w vec3, dt float32) quat {
+	// dq/dt = 0.5 * (w, 0) * q
+	hx, hy, hz := w[0]*dt*0.5, w[1]*dt*0.5, w[2]*dt*0.5
+	return normalizeQuat(quat{
+		q[0] + hx*q[3] + hy*q[2] - hz*q[1],
+		q[1] + hy*q[3] + hz*q[0] - hx*q[2],
+		q[2] + hz*q[3] + hx*q[1] - hy*q[0],
+		q[3] - hx*q[0] - hy*q[1] - hz*q[2],
+	})
+}
+
+// rotate applies a rotation matrix to a vector.
+func rotate(m mat3, v vec3) vec3 {
+	return add(add(scale(m[0], v[0]), scale(m[1], v[1])), scale(m[2], v[2]))
+}
+
+// rotateInv applies the inverse (transpose) of a rotation matrix to a vector.
+func rotateInv(m mat3, v vec3) vec3 {
+	return vec3{dot(m[0], v), dot(m[1], v), dot(m[2], v)}
+}
+
+// eulerToQuat converts engine Euler angles (applied as Y * X * Z, matching
+// common.BuildModelMatrix) to a quaternion.
+func eulerToQuat(rot vec3) quat {
+	var m [16]float32
+	common.BuildModelMatrix(m[:], 0, 0, 0, rot[0], rot[1], rot[2], 1, 1, 1)
+	return mat3ToQuat(mat3{{m[0], m[1], m[2]}, {m[4], m[5], m[6]}, {m[8], m[9], m[10]}})
+}
+
+// quatToEuler converts a quaternion to engine Euler angles (Y * X * Z order).
+func quatToEuler(q quat) vec3 {
+	_, rot, _ := common.DecomposeModelMatrix(transformMatrix(vec3{}, q, vec3{1, 1, 1}))
+	return rot
+}
+
+// transformMatrix builds a column-major 4x4 matrix from a position, orientation and scale.
+func transformMatrix(pos vec3, q quat, s vec3) []float32 {
+	r := quatToMat3(q)
+	return []float32{
+		r[0][0] * s[0], r[0][1] * s[0], r[0][2] * s[0], 0,
+		r[1][0] * s[1], r[1][1] * s[1], r[1][2] * s[1], 0,
+		r[2][0] * s[2], r[2][1] * s[2], r[2][2] * s[2], 0,
+		pos[0], pos[1], pos[2], 1,
+	}
+}
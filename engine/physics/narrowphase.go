@@ -0,0 +1,546 @@
+package physics
+
+import "math"
+
+// ContactPoint is a single point of contact between two bodies.
+type ContactPoint struct {
+	Position [3]float32 // world-space contact point
+	Normal   [3]float32 // unit contact normal, pointing from body A towards body B
+	Depth    float32    // penetration depth along the normal
+}
+
+// Manifold is the set of contact points between two overlapping bodies after a step.
+type Manifold struct {
+	A, B   Body
+	Points []ContactPoint
+}
+
+// shapeInstance is a collider placed in the world for one step.
+type shapeInstance struct {
+	col    *collider
+	center vec3 // world-space collider center
+	rot    mat3 // world rotation; identity for AABB colliders
+	pos    vec3 // body origin, used to place mesh triangles
+	bodyR  mat3 // body rotation, used to place mesh triangles
+	bounds aabb // world bounds of the collider
+}
+
+// newShapeInstance places a body's collider in the world.
+func newShapeInstance(b *body) shapeInstance {
+	s := shapeInstance{
+		col:    b.col,
+		center: add(b.position, rotate(b.rot, b.col.offset)),
+		rot:    b.rot,
+		pos:    b.position,
+		bodyR:  b.rot,
+		bounds: b.col.worldBounds(b.position, b.rot),
+	}
+	if b.col.shape == ShapeAABB {
+		s.rot = mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	}
+	return s
+}
+
+// segment returns the world-space endpoints of a capsule's inner segment.
+func (s *shapeInstance) segment() (vec3, vec3) {
+	axis := scale(s.rot[1], s.col.halfHeight)
+	return sub(s.center, axis), add(s.center, axis)
+}
+
+// collide generates contact points between two shapes with normals pointing from a to b.
+//
+// Parameters:
+//   - a: the first shape
+//   - b: the second shape
+//
+// Returns:
+//   - []ContactPoint: the contacts, or nil if the shapes do not touch
+func collide(a, b *shapeInstance) []ContactPoint {
+	sa, sb := a.col.shape, b.col.shape
+	if sa == ShapeMesh && sb == ShapeMesh {
+		return nil
+	}
+	// Dispatch with the "lower" shape first and flip the normals if the pair was swapped.
+	if shapeOrder(sa) > shapeOrder(sb) {
+		points := collide(b, a)
+		for i := range points {
+			points[i].Normal = neg(points[i].Normal)
+		}
+		return points
+	}
+
+	switch {
+	case sa == ShapeSphere && sb == ShapeSphere:
+		return collideSpheres(a.center, a.col.radius, b.center, b.col.radius)
+	case sa == ShapeSphere && sb == ShapeCapsule:
+		p0, p1 := b.segment()
+		return collideSpheres(a.center, a.col.radius, closestOnSegment(p0, p1, a.center), b.col.radius)
+	case sa == ShapeSphere && isBox(sb):
+		return flip(collideBoxSphere(b, a.center, a.col.radius))
+	case sa == ShapeCapsule && sb == ShapeCapsule:
+		a0, a1 := a.segment()
+		b0, b1 := b.segment()
+		pa, pb := closestSegmentSegment(a0, a1, b0, b1)
+		return collideSpheres(pa, a.col.radius, pb, b.col.radius)
+	case sa == ShapeCapsule && isBox(sb):
+		return flip(collideBoxCapsule(b, a))
+	case isBox(sa) && isBox(sb):
+		return collideBoxes(a, b)
+	case sb == ShapeMesh:
+		return flip(collideMesh(b, a))
+	}
+	return nil
+}
+
+// shapeOrder ranks shapes for pair dispatch.
+func shapeOrder(s ShapeType) int {
+	switch s {
+	case ShapeSphere:
+		return 0
+	case ShapeCapsule:
+		return 1
+	case ShapeAABB, ShapeOBB:
+		return 2
+	}
+	return 3
+}
+
+func isBox(s ShapeType) bool {
+	return s == ShapeAABB || s == ShapeOBB
+}
+
+// flip reverses the normals of contact points in place.
+func flip(points []ContactPoint) []ContactPoint {
+	for i := range points {
+		points[i].Normal = neg(points[i].Normal)
+	}
+	return points
+}
+
+// collideSpheres tests two spheres. The contact point lies midway between the surfaces.
+func collideSpheres(ca vec3, ra float32, cb vec3, rb float32) []ContactPoint {
+	d := sub(cb, ca)
+	dist := length(d)
+	depth := ra + rb - dist
+	if depth < 0 {
+		return nil
+	}
+	n := vec3{0, 1, 0}
+	if dist > 1e-6 {
+		n = scale(d, 1/dist)
+	}
+	p := add(ca, scale(n, ra-depth*0.5))
+	return []ContactPoint{{Position: p, Normal: n, Depth: depth}}
+}
+
+// collideBoxSphere tests a box against a sphere, with the normal pointing from the box to the sphere.
+func collideBoxSphere(box *shapeInstance, c vec3, r float32) []ContactPoint {
+	h := box.col.halfExtents
+	local := rotateInv(box.rot, sub(c, box.center))
+	closest := vec3{clamp(local[0], -h[0], h[0]), clamp(local[1], -h[1], h[1]), clamp(local[2], -h[2], h[2])}
+
+	if closest != local {
+		d := sub(local, closest)
+		dist := length(d)
+		if dist > r {
+			return nil
+		}
+		n := rotate(box.rot, scale(d, 1/dist))
+		return []ContactPoint{{
+			Position: add(box.center, rotate(box.rot, closest)),
+			Normal:   n,
+			Depth:    r - dist,
+		}}
+	}
+
+	// The center is inside the box: push out through the nearest face.
+	axis, best := 0, float32(math.MaxFloat32)
+	for i := range 3 {
+		if d := h[i] - absf(local[i]); d < best {
+			axis, best = i, d
+		}
+	}
+	var ln vec3
+	ln[axis] = 1
+	if local[axis] < 0 {
+		ln[axis] = -1
+	}
+	n := rotate(box.rot, ln)
+	return []ContactPoint{{
+		Position: c,
+		Normal:   n,
+		Depth:    best + r,
+	}}
+}
+
+// collideBoxCapsule tests a box against a capsule by testing spheres at the capsule's end
+// points and at the segment point closest to the box.
+func collideBoxCapsule(box, capsule *shapeInstance) []ContactPoint {
+	p0, p1 := capsule.segment()
+	var points []ContactPoint
+	for _, p := range [3]vec3{p0, p1, closestSegmentBox(p0, p1, box)} {
+		for _, cp := range collideBoxSphere(box, p, capsule.col.radius) {
+			if !hasNearbyPoint(points, cp.Position) {
+				points = append(points, cp)
+			}
+		}
+	}
+	return points
+}
+
+// collideBoxes tests two boxes with the separating axis theorem over the 15 candidate axes.
+// Contacts are the vertices of each box inside the other; edge-edge contacts fall back to a
+// single point between the supporting features.
+//
+// Reference: https://www.geometrictools.com/Documentation/DynamicCollisionDetection.pdf
+func collideBoxes(a, b *shapeInstance) []ContactPoint {
+	d := sub(b.center, a.center)
+	bestDepth := float32(math.MaxFloat32)
+	var bestAxis vec3
+
+	test := func(axis vec3) bool {
+		l := length(axis)
+		if l < 1e-6 {
+			return true // parallel edges produce a degenerate axis
+		}
+		axis = scale(axis, 1/l)
+		ra := projectBox(a, axis)
+		rb := projectBox(b, axis)
+		dist := dot(d, axis)
+		overlap := ra + rb - absf(dist)
+		if overlap < 0 {
+			return false
+		}
+		if overlap < bestDepth {
+			bestDepth = overlap
+			if dist < 0 {
+				axis = neg(axis)
+			}
+			bestAxis = axis
+		}
+		return true
+	}
+
+	for i := range 3 {
+		if !test(a.rot[i]) || !test(b.rot[i]) {
+			return nil
+		}
+	}
+	for i := range 3 {
+		for j := range 3 {
+			if !test(cross(a.rot[i], b.rot[j])) {
+				return nil
+			}
+		}
+	}
+
+	var points []ContactPoint
+	for _, v := range boxVertices(b) {
+		if insideBox(a, v) {
+			points = append(points, ContactPoint{Position: v, Normal: bestAxis, Depth: bestDepth})
+		}
+	}
+	for _, v := range boxVertices(a) {
+		if insideBox(b, v) && !hasNearbyPoint(points, v) {
+			points = append(points, ContactPoint{Position: v, Normal: bestAxis, Depth: bestDepth})
+		}
+	}
+	if len(points) == 0 {
+		pa := supportBox(a, bestAxis)
+		pb := supportBox(b, neg(bestAxis))
+		points = append(points, ContactPoint{Position: scale(add(pa, pb), 0.5), Normal: bestAxis, Depth: bestDepth})
+	}
+	return points
+}
+
+// collideMesh tests a mesh against a convex shape, with normals pointing from the mesh to the shape.
+// Candidate triangles are found with the mesh BVH using the shape's bounds in mesh space.
+func collideMesh(mesh, other *shapeInstance) []ContactPoint {
+	m := mesh.col.mesh
+	origin := add(mesh.pos, rotate(mesh.bodyR, mesh.col.offset))
+
+	// Bring the other shape's world bounds into mesh space.
+	wb := other.bounds
+	local := emptyAABB()
+	for i := range 8 {
+		corner := vec3{wb.min[0], wb.min[1], wb.min[2]}
+		if i&1 != 0 {
+			corner[0] = wb.max[0]
+		}
+		if i&2 != 0 {
+			corner[1] = wb.max[1]
+		}
+		if i&4 != 0 {
+			corner[2] = wb.max[2]
+		}
+		local = local.extend(rotateInv(mesh.bodyR, sub(corner, origin)))
+	}
+
+	var points []ContactPoint
+	m.tree.query(local, func(tri int) {
+		var v [3]vec3
+		for k := range 3 {
+			v[k] = add(origin, rotate(mesh.bodyR, m.positions[m.indices[tri*3+k]]))
+		}
+		var found []ContactPoint
+		switch other.col.shape {
+		case ShapeSphere:
+			found = collideTriangleSphere(v, other.center, other.col.radius)
+		case ShapeCapsule:
+			p0, p1 := other.segment()
+			found = collideTriangleSphere(v, closestSegmentTriangle(p0, p1, v), other.col.radius)
+		default:
+			found = collideTriangleBox(v, other)
+		}
+		for _, cp := range found {
+			if !hasNearbyPoint(points, cp.Position) {
+				points = append(points, cp)
+			}
+		}
+	})
+	return points
+}
+
+// collideTriangleSphere tests a triangle against a sphere, with the normal pointing from the triangle to the sphere.
+func collideTriangleSphere(v [3]vec3, c vec3, r float32) []ContactPoint {
+	closest := closestOnTriangle(v, c)
+	d := sub(c, closest)
+	dist := length(d)
+	if dist > r {
+		return nil
+	}
+	var n vec3
+	if dist > 1e-6 {
+		n = scale(d, 1/dist)
+	} else {
+		n = normalize(cross(sub(v[1], v[0]), sub(v[2], v[0])))
+	}
+	return []ContactPoint{{Position: closest, Normal: n, Depth: r - dist}}
+}
+
+// collideTriangleBox tests a box's vertices against a triangle, with the normal pointing from
+// the triangle to the box. The triangle is treated as double-sided, facing the box center.
+func collideTriangleBox(v [3]vec3, box *shapeInstance) []ContactPoint {
+	n := normalize(cross(sub(v[1], v[0]), sub(v[2], v[0])))
+	if n == (vec3{}) {
+		return nil
+	}
+	if dot(n, sub(box.center, v[0])) < 0 {
+		n = neg(n)
+	}
+	// Ignore vertices deeper than the box itself so thin walls do not pull boxes through.
+	h := box.col.halfExtents
+	maxDepth := 2 * max(h[0], h[1], h[2])
+
+	var points []ContactPoint
+	for _, p := range boxVertices(box) {
+		d := dot(n, sub(p, v[0]))
+		if d >= 0 || -d > maxDepth {
+			continue
+		}
+		proj := sub(p, scale(n, d))
+		if !pointInTriangle(v, n, proj) {
+			continue
+		}
+		points = append(points, ContactPoint{Position: p, Normal: n, Depth: -d})
+	}
+	return points
+}
+
+// projectBox returns the half length of a box's projection onto a unit axis.
+func projectBox(s *shapeInstance, axis vec3) float32 {
+	h := s.col.halfExtents
+	return h[0]*absf(dot(s.rot[0], axis)) + h[1]*absf(dot(s.rot[1], axis)) + h[2]*absf(dot(s.rot[2], axis))
+}
+
+// supportBox returns the box vertex furthest along a direction.
+func supportBox(s *shapeInstance, dir vec3) vec3 {
+	p := s.center
+	for i := range 3 {
+		e := scale(s.rot[i], s.col.halfExtents[i])
+		if dot(s.rot[i], dir) >= 0 {
+			p = add(p, e)
+		} else {
+			p = sub(p, e)
+		}
+	}
+	return p
+}
+
+// boxVertices returns the eight world-space corners of a box.
+func boxVertices(s *shapeInstance) [8]vec3 {
+	var out [8]vec3
+	h := s.col.halfExtents
+	for i := range 8 {
+		l := vec3{-h[0], -h[1], -h[2]}
+		if i&1 != 0 {
+			l[0] = h[0]
+		}
+		if i&2 != 0 {
+			l[1] = h[1]
+		}
+		if i&4 != 0 {
+			l[2] = h[2]
+		}
+		out[i] = add(s.center, rotate(s.rot, l))
+	}
+	return out
+}
+
+// insideBox reports whether a point lies inside a box, with a small tolerance.
+func insideBox(s *shapeInstance, p vec3) bool {
+	local := rotateInv(s.rot, sub(p, s.center))
+	h := s.col.halfExtents
+	const slop = 1e-4
+	return absf(local[0]) <= h[0]+slop && absf(local[1]) <= h[1]+slop && absf(local[2]) <= h[2]+slop
+}
+
+// hasNearbyPoint reports whether a contact already exists close to p.
+func hasNearbyPoint(points []ContactPoint, p vec3) bool {
+	for _, cp := range points {
+		d := sub(cp.Position, p)
+		if dot(d, d) < 1e-6 {
+			return true
+		}
+	}
+	return false
+}
+
+// closestOnSegment returns the point on segment ab closest to p.
+func closestOnSegment(a, b, p vec3) vec3 {
+	ab := sub(b, a)
+	den := dot(ab, ab)
+	if den == 0 {
+		return a
+	}
+	t := clamp(dot(sub(p, a), ab)/den, 0, 1)
+	return add(a, scale(ab, t))
+}
+
+// closestSegmentSegment returns the closest points between segments p1q1 and p2q2.
+//
+// Reference: Ericson, Real-Time Collision Detection, 5.1.9
+func closestSegmentSegment(p1, q1, p2, q2 vec3) (vec3, vec3) {
+	d1, d2 := sub(q1, p1), sub(q2, p2)
+	r := sub(p1, p2)
+	a, e := dot(d1, d1), dot(d2, d2)
+	f := dot(d2, r)
+	const eps = 1e-8
+
+	var s, t float32
+	switch {
+	case a <= eps && e <= eps:
+		return p1, p2
+	case a <= eps:
+		t = clamp(f/e, 0, 1)
+	default:
+		c := dot(d1, r)
+		if e <= eps {
+			s = clamp(-c/a, 0, 1)
+		} else {
+			b := dot(d1, d2)
+			den := a*e - b*b
+			if den != 0 {
+				s = clamp((b*f-c*e)/den, 0, 1)
+			}
+			t = (b*s + f) / e
+			if t < 0 {
+				t, s = 0, clamp(-c/a, 0, 1)
+			} else if t > 1 {
+				t, s = 1, clamp((b-c)/a, 0, 1)
+			}
+		}
+	}
+	return add(p1, scale(d1, s)), add(p2, scale(d2, t))
+}
+
+// closestSegmentBox returns the point on segment ab closest to a box, found by alternating
+// projections between the two convex sets.
+func closestSegmentBox(a, b vec3, box *shapeInstance) vec3 {
+	h := box.col.halfExtents
+	p := scale(add(a, b), 0.5)
+	for range 4 {
+		local := rotateInv(box.rot, sub(p, box.center))
+		q := vec3{clamp(local[0], -h[0], h[0]), clamp(local[1], -h[1], h[1]), clamp(local[2], -h[2], h[2])}
+		p = closestOnSegment(a, b, add(box.center, rotate(box.rot, q)))
+	}
+	return p
+}
+
+// closestSegmentTriangle returns the point on segment ab closest to a triangle.
+func closestSegmentTriangle(a, b vec3, v [3]vec3) vec3 {
+	n := cross(sub(v[1], v[0]), sub(v[2], v[0]))
+	// A segment crossing the triangle touches it at the crossing point.
+	da, db := dot(n, sub(a, v[0])), dot(n, sub(b, v[0]))
+	if (da <= 0) != (db <= 0) {
+		p := add(a, scale(sub(b, a), da/(da-db)))
+		if pointInTriangle(v, normalize(n), p) {
+			return p
+		}
+	}
+
+	best := a
+	bestDist := distSq(a, closestOnTriangle(v, a))
+	if d := distSq(b, closestOnTriangle(v, b)); d < bestDist {
+		best, bestDist = b, d
+	}
+	for i := range 3 {
+		ps, pe := closestSegmentSegment(a, b, v[i], v[(i+1)%3])
+		if d := distSq(ps, pe); d < bestDist {
+			best, bestDist = ps, d
+		}
+	}
+	return best
+}
+
+// closestOnTriangle returns the point on triangle v closest to p.
+//
+// Reference: Ericson, Real-Time Collision Detection, 5.1.5
+func closestOnTriangle(v [3]vec3, p vec3) vec3 {
+	a, b, c := v[0], v[1], v[2]
+	ab, ac, ap := sub(b, a), sub(c, a), sub(p, a)
+	d1, d2 := dot(ab, ap), dot(ac, ap)
+	if d1 <= 0 && d2 <= 0 {
+		return a
+	}
+	bp := sub(p, b)
+	d3, d4 := dot(ab, bp), dot(ac, bp)
+	if d3 >= 0 && d4 <= d3 {
+		return b
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return add(a, scale(ab, d1/(d1-d3)))
+	}
+	cp := sub(p, c)
+	d5, d6 := dot(ab, cp), dot(ac, cp)
+	if d6 >= 0 && d5 <= d6 {
+		return c
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return add(a, scale(ac, d2/(d2-d6)))
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && (d4-d3) >= 0 && (d5-d6) >= 0 {
+		return add(b, scale(sub(c, b), (d4-d3)/((d4-d3)+(d5-d6))))
+	}
+	den := 1 / (va + vb + vc)
+	return add(a, add(scale(ab, vb*den), scale(ac, vc*den)))
+}
+
+// pointInTriangle reports whether a point on the triangle's plane lies inside it.
+func pointInTriangle(v [3]vec3, n, p vec3) bool {
+	for i := range 3 {
+		edge := sub(v[(i+1)%3], v[i])
+		if dot(cross(edge, sub(p, v[i])), n) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func distSq(a, b vec3) float32 {
+	d := sub(a, b)
+	return dot(d, d)
+}
@@ -0,0 +1,287 @@
+package physics
+
+// World simulates a set of rigid bodies. Each Step integrates forces and gravity, finds
+// overlapping pairs with a BVH broadphase, generates contact manifolds in the narrowphase,
+// resolves them with a sequential impulse solver and writes dynamic bodies back to their
+// GameObjects.
+//
+// A World is not safe for concurrent use; step it and modify its bodies from one goroutine,
+// e.g. the engine's tick.
+type World interface {
+	// Add adds a body to the world and assigns it an ID.
+	//
+	// Parameters:
+	//   - b: the body to add
+	//
+	// Returns:
+	//   - uint64: the ID assigned to the body
+	Add(b Body) uint64
+
+	// Remove removes a body from the world.
+	//
+	// Parameters:
+	//   - id: the ID of the body to remove
+	Remove(id uint64)
+
+	// Body returns a body by ID.
+	//
+	// Parameters:
+	//   - id: the body ID
+	//
+	// Returns:
+	//   - Body: the body, or nil if no body has that ID
+	Body(id uint64) Body
+
+	// Bodies returns all bodies in the order they were added.
+	//
+	// Returns:
+	//   - []Body: the bodies
+	Bodies() []Body
+
+	// Gravity returns the world gravity acceleration.
+	//
+	// Returns:
+	//   - [3]float32: the gravity vector
+	Gravity() [3]float32
+
+	// SetGravity sets the world gravity acceleration.
+	//
+	// Parameters:
+	//   - x, y, z: the gravity vector
+	SetGravity(x, y, z float32)
+
+	// Step advances the simulation by dt seconds, split into the configured number of substeps.
+	//
+	// Parameters:
+	//   - dt: the time step in seconds
+	Step(dt float32)
+
+	// Contacts returns the contact manifolds found during the last step, including trigger contacts.
+	//
+	// Returns:
+	//   - []Manifold: the manifolds; valid until the next step
+	Contacts() []Manifold
+
+	// SetContactCallback sets a function called for every manifold at the end of each step.
+	//
+	// Parameters:
+	//   - callback: the function to call, or nil to clear it
+	SetContactCallback(callback func(m Manifold))
+}
+
+// world implements the World interface.
+type world struct {
+	nextID     uint64
+	bodies     []*body
+	byID       map[uint64]*body
+	gravity    vec3
+	iterations int
+	substeps   int
+
+	contacts []Manifold
+	callback func(m Manifold)
+}
+
+var _ World = &world{}
+
+// NewWorld creates a new physics World configured with the given options.
+// By default gravity is (0, -9.81, 0) with 8 solver iterations and a single substep.
+//
+// Parameters:
+//   - options: variadic list of WorldBuilderOption functions to configure the World
+//
+// Returns:
+//   - World: the newly created World
+func NewWorld(options ...WorldBuilderOption) World {
+	w := &world{
+		nextID:     1,
+		byID:       make(map[uint64]*body),
+		gravity:    vec3{0, -9.81, 0},
+		iterations: 8,
+		substeps:   1,
+	}
+	for _, opt := range options {
+		opt(w)
+	}
+	return w
+}
+
+func (w *world) Add(b Body) uint64 {
+	bb := b.(*body)
+	if _, ok := w.byID[bb.id]; ok && bb.id != 0 {
+		return bb.id
+	}
+	bb.id = w.nextID
+	w.nextID++
+	w.bodies = append(w.bodies, bb)
+	w.byID[bb.id] = bb
+	return bb.id
+}
+
+func (w *world) Remove(id uint64) {
+	if _, ok := w.byID[id]; !ok {
+		return
+	}
+	delete(w.byID, id)
+	for i, b := range w.bodies {
+		if b.id == id {
+			w.bodies = append(w.bodies[:i], w.bodies[i+1:]...)
+			break
+		}
+	}
+}
+
+func (w *world) Body(id uint64) Body {
+	if b, ok := w.byID[id]; ok {
+		return b
+	}
+	return nil
+}
+
+func (w *world) Bodies() []Body {
+	out := make([]Body, len(w.bodies))
+	for i, b := range w.bodies {
+		out[i] = b
+	}
+	return out
+}
+
+func (w *world) Gravity() [3]float32 {
+	return w.gravity
+}
+
+func (w *world) SetGravity(x, y, z float32) {
+	w.gravity = vec3{x, y, z}
+}
+
+func (w *world) Step(dt float32) {
+	if dt <= 0 {
+		return
+	}
+
+	// Kinematic bodies bound to an object follow it across the step.
+	for _, b := range w.bodies {
+		if b.bodyType != BodyKinematic || b.obj == nil {
+			continue
+		}
+		prevPos, prevRot := b.position, b.orientation
+		b.readObject()
+		target, targetRot := b.position, b.orientation
+		b.velocity = scale(sub(target, prevPos), 1/dt)
+		b.angular = angularVelocityBetween(prevRot, targetRot, dt)
+		b.position, b.orientation = prevPos, prevRot
+		b.rot = quatToMat3(prevRot)
+	}
+
+	h := dt / float32(w.substeps)
+	for range w.substeps {
+		w.integrateVelocities(h)
+		w.contacts = w.detect()
+		w.solve(h)
+		w.integratePositions(h)
+	}
+
+	for _, b := range w.bodies {
+		b.force, b.torque = vec3{}, vec3{}
+		if b.obj == nil {
+			continue
+		}
+		switch b.bodyType {
+		case BodyDynamic:
+			b.writeObject()
+		case BodyKinematic:
+			// Snap to the object exactly to avoid drift from integrating its velocity.
+			b.readObject()
+		}
+	}
+
+	if w.callback != nil {
+		for _, m := range w.contacts {
+			w.callback(m)
+		}
+	}
+}
+
+func (w *world) Contacts() []Manifold {
+	return w.contacts
+}
+
+func (w *world) SetContactCallback(callback func(m Manifold)) {
+	w.callback = callback
+}
+
+// integrateVelocities applies gravity, forces and damping to dynamic bodies.
+func (w *world) integrateVelocities(h float32) {
+	for _, b := range w.bodies {
+		if b.bodyType != BodyDynamic {
+			continue
+		}
+		acc := add(scale(w.gravity, b.gravity), scale(b.force, b.invMass))
+		b.velocity = add(b.velocity, scale(acc, h))
+		b.angular = add(b.angular, scale(b.invInertiaMul(b.torque), h))
+		b.velocity = scale(b.velocity, 1/(1+h*b.linearDamping))
+		b.angular = scale(b.angular, 1/(1+h*b.angularDamping))
+	}
+}
+
+// integratePositions moves dynamic and kinematic bodies by their velocities.
+func (w *world) integratePositions(h float32) {
+	for _, b := range w.bodies {
+		if b.bodyType == BodyStatic {
+			continue
+		}
+		b.position = add(b.position, scale(b.velocity, h))
+		b.orientation = integrateQuat(b.orientation, b.angular, h)
+		b.rot = quatToMat3(b.orientation)
+	}
+}
+
+// detect finds overlapping pairs with the broadphase and returns their contact manifolds.
+// Pairs of non-dynamic bodies are skipped unless one of them is a trigger.
+func (w *world) detect() []Manifold {
+	var active []*body
+	var boxes []aabb
+	for _, b := range w.bodies {
+		if b.col != nil {
+			active = append(active, b)
+			boxes = append(boxes, b.col.worldBounds(b.position, b.rot))
+		}
+	}
+	tree := buildBVH(boxes)
+
+	var manifolds []Manifold
+	for i, a := range active {
+		tree.query(boxes[i], func(j int) {
+			if j <= i {
+				return
+			}
+			b := active[j]
+			if a.bodyType != BodyDynamic && b.bodyType != BodyDynamic && !a.trigger && !b.trigger {
+				return
+			}
+			sa, sb := newShapeInstance(a), newShapeInstance(b)
+			if points := collide(&sa, &sb); len(points) > 0 {
+				manifolds = append(manifolds, Manifold{A: a, B: b, Points: points})
+			}
+		})
+	}
+	return manifolds
+}
+
+// angularVelocityBetween returns the angular velocity that rotates q0 into q1 over dt.
+func angularVelocityBetween(q0, q1 quat, dt float32) vec3 {
+	// d = q1 * conjugate(q0)
+	x0, y0, z0, w0 := -q0[0], -q0[1], -q0[2], q0[3]
+	x1, y1, z1, w1 := q1[0], q1[1], q1[2], q1[3]
+	d := quat{
+		w1*x0 + x1*w0 + y1*z0 - z1*y0,
+		w1*y0 - x1*z0 + y1*w0 + z1*x0,
+		w1*z0 + x1*y0 - y1*x0 + z1*w0,
+		w1*w0 - x1*x0 - y1*y0 - z1*z0,
+	}
+	if d[3] < 0 {
+		d = quat{-d[0], -d[1], -d[2], -d[3]}
+	}
+	// For small rotations the vector part is sin(angle/2) * axis ~= angle/2 * axis.
+	return scale(vec3{d[0], d[1], d[2]}, 2/dt)
+}
@@ -0,0 +1,50 @@
+package physics
+
+// WorldBuilderOption is a functional option for configuring a World.
+// Use the With* functions to create options that are applied directly to the world instance.
+type WorldBuilderOption func(*world)
+
+// WithGravity sets the world gravity acceleration (default (0, -9.81, 0)).
+//
+// Parameters:
+//   - x, y, z: the gravity vector
+//
+// Returns:
+//   - WorldBuilderOption: option function to apply
+func WithGravity(x, y, z float32) WorldBuilderOption {
+	return func(w *world) {
+		w.gravity = vec3{x, y, z}
+	}
+}
+
+// WithIterations sets the number of velocity solver iterations per substep (default 8).
+// More iterations make stacks more stable at a higher cost.
+//
+// Parameters:
+//   - n: the iteration count; values < 1 are ignored
+//
+// Returns:
+//   - WorldBuilderOption: option function to apply
+func WithIterations(n int) WorldBuilderOption {
+	return func(w *world) {
+		if n > 0 {
+			w.iterations = n
+		}
+	}
+}
+
+// WithSubsteps sets the number of substeps each Step is divided into (default 1).
+// Substepping reduces tunnelling of fast bodies and improves stacking.
+//
+// Parameters:
+//   - n: the substep count; values < 1 are ignored
+//
+// Returns:
+//   - WorldBuilderOption: option function to apply
+func WithSubsteps(n int) WorldBuilderOption {
+	return func(w *world) {
+		if n > 0 {
+			w.substeps = n
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package physics
+
+import "math"
+
+const (
+	// baumgarte is the fraction of penetration corrected per substep.
+	baumgarte = 0.2
+	// penetrationSlop is the penetration allowed without correction, which keeps resting contacts stable.
+	penetrationSlop = 0.005
+	// restitutionThreshold is the closing speed below which contacts do not bounce.
+	restitutionThreshold = 1.0
+)
+
+// contactConstraint is the solver state of a single contact point.
+type contactConstraint struct {
+	a, b        *body
+	ra, rb      vec3 // contact point relative to each body origin
+	normal      vec3
+	tangents    [2]vec3
+	normalMass  float32
+	tangentMass [2]float32
+	bias        float32
+	friction    float32
+
+	normalImpulse  float32
+	tangentImpulse [2]float32
+}
+
+// solve resolves the current contacts with sequential impulses. Velocities are corrected
+// iteratively with accumulated, clamped impulses; penetration is removed with a Baumgarte
+// velocity bias and restitution adds a bounce for fast impacts.
+//
+// Reference: Catto, "Iterative Dynamics with Temporal Coherence", GDC 2005
+func (w *world) solve(h float32) {
+	var constraints []contactConstraint
+	for _, m := range w.contacts {
+		a, b := m.A.(*body), m.B.(*body)
+		if a.trigger || b.trigger {
+			continue
+		}
+		friction := float32(math.Sqrt(float64(a.friction * b.friction)))
+		restitution := max(a.restitution, b.restitution)
+		for _, p := range m.Points {
+			c := contactConstraint{
+				a:        a,
+				b:        b,
+				ra:       sub(p.Position, a.position),
+				rb:       sub(p.Position, b.position),
+				normal:   p.Normal,
+				friction: friction,
+			}
+			c.tangents[0] = orthonormal(p.Normal)
+			c.tangents[1] = cross(p.Normal, c.tangents[0])
+			c.normalMass = c.effectiveMass(c.normal)
+			for i := range 2 {
+				c.tangentMass[i] = c.effectiveMass(c.tangents[i])
+			}
+
+			c.bias = baumgarte / h * max(p.Depth-penetrationSlop, 0)
+			vn := dot(sub(b.velocityAt(p.Position), a.velocityAt(p.Position)), c.normal)
+			if vn < -restitutionThreshold {
+				c.bias = max(c.bias, -restitution*vn)
+			}
+			constraints = append(constraints, c)
+		}
+	}
+
+	for range w.iterations {
+		for i := range constraints {
+			constraints[i].solveVelocity()
+		}
+	}
+}
+
+// effectiveMass returns the inverse of the constraint mass along a direction.
+func (c *contactConstraint) effectiveMass(dir vec3) float32 {
+	k := c.a.invMass + c.b.invMass
+	ca := cross(c.ra, dir)
+	cb := cross(c.rb, dir)
+	k += dot(ca, c.a.invInertiaMul(ca)) + dot(cb, c.b.invInertiaMul(cb))
+	if k == 0 {
+		return 0
+	}
+	return 1 / k
+}
+
+// relativeVelocity returns the velocity of the contact point on b relative to a.
+func (c *contactConstraint) relativeVelocity() vec3 {
+	va := add(c.a.velocity, cross(c.a.angular, c.ra))
+	vb := add(c.b.velocity, cross(c.b.angular, c.rb))
+	return sub(vb, va)
+}
+
+// applyImpulse applies an impulse to b and its negation to a at the contact point.
+func (c *contactConstraint) applyImpulse(p vec3) {
+	c.a.velocity = sub(c.a.velocity, scale(p, c.a.invMass))
+	c.a.angular = sub(c.a.angular, c.a.invInertiaMul(cross(c.ra, p)))
+	c.b.velocity = add(c.b.velocity, scale(p, c.b.invMass))
+	c.b.angular = add(c.b.angular, c.b.invInertiaMul(cross(c.rb, p)))
+}
+
+// solveVelocity runs one iteration of the normal and friction constraints.
+func (c *contactConstraint) solveVelocity() {
+	// Normal: push the bodies apart, never pull them together.
+	vn := dot(c.relativeVelocity(), c.normal)
+	lambda := c.normalMass * (c.bias - vn)
+	prev := c.normalImpulse
+	c.normalImpulse = max(prev+lambda, 0)
+	c.applyImpulse(scale(c.normal, c.normalImpulse-prev))
+
+	// Friction: oppose sliding, bounded by the Coulomb cone approximated per tangent.
+	limit := c.friction * c.normalImpulse
+	for i := range 2 {
+		vt := dot(c.relativeVelocity(), c.tangents[i])
+		lambda := -c.tangentMass[i] * vt
+		prev := c.tangentImpulse[i]
+		c.tangentImpulse[i] = clamp(prev+lambda, -limit, limit)
+		c.applyImpulse(scale(c.tangents[i], c.tangentImpulse[i]-prev))
+	}
+}
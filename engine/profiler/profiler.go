@@ -1,13 +1,40 @@
 package profiler
 
 import (
+	"fmt"
 	"log"
 	"runtime"
+	"strings"
+	"sync"
 	"time"
 )
 
-// Profiler tracks frame rate and memory statistics for performance monitoring.
-// Outputs stats to the log at a configurable interval.
+// Frame phases timed by the engine's render loop, in the order they run.
+const (
+	PhaseCompute      = "compute"
+	PhaseShadows      = "shadows"
+	PhaseLightCulling = "light_culling"
+	PhaseOffscreen    = "offscreen"
+	PhaseDraw         = "draw"
+	PhasePresent      = "present"
+)
+
+// PhaseTiming is the mean time per frame spent in one phase over the last update interval.
+type PhaseTiming struct {
+	Name   string
+	CPU    time.Duration // wall time on the render goroutine
+	GPU    time.Duration // GPU time between timestamps; zero when HasGPU is false
+	HasGPU bool          // false when no GPU timestamps were recorded for the phase
+}
+
+// phaseSamples accumulates one phase's timings between two stats updates.
+type phaseSamples struct {
+	cpu, gpu           time.Duration
+	cpuCount, gpuCount int
+}
+
+// Profiler tracks frame rate, memory statistics and per-phase frame timings for performance
+// monitoring. Outputs stats to the log at a configurable interval.
 type Profiler struct {
 	frameCount     int
 	lastTime       time.Time
@@ -15,6 +42,12 @@ type Profiler struct {
 	memStats       runtime.MemStats
 	lastGCCount    uint32
 	lastTotalAlloc uint64
+
+	// Phase timings are recorded from the render goroutine and may be read from any goroutine.
+	mu           sync.Mutex
+	phaseOrder   []string
+	phases       map[string]*phaseSamples
+	phaseTimings []PhaseTiming
 }
 
 // NewProfiler creates a new Profiler with default settings.
@@ -28,12 +61,103 @@ func NewProfiler() *Profiler {
 		lastTime:       time.Now(),
 		updateInterval: time.Second,
 		memStats:       runtime.MemStats{},
+		phases:         make(map[string]*phaseSamples),
 	}
 }
 
+// RecordCPU adds one frame's CPU time for a phase. Phases are reported in the order they are first recorded.
+//
+// Parameters:
+//   - phase: the phase name
+//   - d: the time spent in the phase this frame
+func (p *Profiler) RecordCPU(phase string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := p.samples(phase)
+	s.cpu += d
+	s.cpuCount++
+}
+
+// RecordGPU adds one frame's GPU time for a phase. GPU timings arrive a frame or two late,
+// so they are averaged separately from the CPU timings of the same phase.
+//
+// Parameters:
+//   - phase: the phase name
+//   - d: the GPU time spent in the phase
+func (p *Profiler) RecordGPU(phase string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := p.samples(phase)
+	s.gpu += d
+	s.gpuCount++
+}
+
+// PhaseTimings returns the mean per-frame phase timings computed at the last stats update.
+//
+// Returns:
+//   - []PhaseTiming: one entry per recorded phase, in recording order; empty before the first update
+func (p *Profiler) PhaseTimings() []PhaseTiming {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PhaseTiming(nil), p.phaseTimings...)
+}
+
+// samples returns the accumulator for a phase, creating it on first use. Must be called with p.mu held.
+//
+// Parameters:
+//   - phase: the phase name
+//
+// Returns:
+//   - *phaseSamples: the phase's accumulator
+func (p *Profiler) samples(phase string) *phaseSamples {
+	s, ok := p.phases[phase]
+	if !ok {
+		s = &phaseSamples{}
+		p.phases[phase] = s
+		p.phaseOrder = append(p.phaseOrder, phase)
+	}
+	return s
+}
+
+// updatePhaseTimings averages the phase samples gathered since the last update into
+// phaseTimings, resets the samples and formats the timings for the log.
+//
+// Returns:
+//   - string: the per-phase timings, or "" if no phase was recorded
+func (p *Profiler) updatePhaseTimings() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.phaseTimings = p.phaseTimings[:0]
+	var sb strings.Builder
+	for _, name := range p.phaseOrder {
+		s := p.phases[name]
+		t := PhaseTiming{Name: name}
+		if s.cpuCount > 0 {
+			t.CPU = s.cpu / time.Duration(s.cpuCount)
+		}
+		if s.gpuCount > 0 {
+			t.GPU = s.gpu / time.Duration(s.gpuCount)
+			t.HasGPU = true
+		}
+		*s = phaseSamples{}
+		p.phaseTimings = append(p.phaseTimings, t)
+
+		if sb.Len() > 0 {
+			sb.WriteString(" | ")
+		}
+		fmt.Fprintf(&sb, "%s: cpu %.2f ms", name, float64(t.CPU.Microseconds())/1000)
+		if t.HasGPU {
+			fmt.Fprintf(&sb, ", gpu %.2f ms", float64(t.GPU.Microseconds())/1000)
+		}
+	}
+	return sb.String()
+}
+
 // Tick should be called once per frame to track frame timing.
 // Logs performance statistics when the update interval has elapsed.
-// Statistics include: FPS, heap usage, allocation rate, GC count/pause times, total memory.
+// Statistics include: FPS, heap usage, allocation rate, GC count/pause times, total memory,
+// and the mean CPU and GPU time of each recorded phase.
 //
 // Returns:
 //   - bool: true if stats were logged this tick, false otherwise
@@ -78,6 +202,9 @@ func (p *Profiler) Tick() bool {
 
 		log.Printf("[Profiler] FPS: %.2f | Heap: %.2f MB | Alloc Rate: %.2f MB/s | GC: %d (last: %d µs, max: %d µs) | Sys: %.2f MB",
 			fps, allocMB, allocRateMB, gcCount, lastPauseUs, maxPauseUs, sysMB)
+		if phases := p.updatePhaseTimings(); phases != "" {
+			log.Printf("[Profiler] Phases: %s", phases)
+		}
 
 		p.frameCount = 0
 		p.lastTime = currentTime
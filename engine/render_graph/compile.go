@@ -0,0 +1,526 @@
+package render_graph
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// compiledAttachment is a render pass attachment with its resolved load and store ops.
+type compiledAttachment struct {
+	resource   string
+	loadOp     wgpu.LoadOp
+	storeOp    wgpu.StoreOp
+	clearColor wgpu.Color
+	clearDepth float32
+}
+
+// compiledPass is an enabled pass in execution order.
+type compiledPass struct {
+	pass  Pass
+	color []compiledAttachment
+	depth *compiledAttachment
+}
+
+// textureKey describes a transient texture allocation. Transients alias only when their keys match.
+type textureKey struct {
+	format        wgpu.TextureFormat
+	width, height uint32
+	sampleCount   uint32
+	usage         wgpu.TextureUsage
+}
+
+// textureSlot is a physical texture shared by transients whose lifetimes do not overlap.
+type textureSlot struct {
+	key     textureKey
+	texture *wgpu.Texture
+	view    *wgpu.TextureView
+}
+
+// bufferKey describes a transient buffer allocation. Transients alias only when their keys match.
+type bufferKey struct {
+	size  uint64
+	usage wgpu.BufferUsage
+}
+
+// bufferSlot is a physical buffer shared by transients whose lifetimes do not overlap.
+type bufferSlot struct {
+	key    bufferKey
+	buffer *wgpu.Buffer
+}
+
+// passAccesses is the set of resources one pass accesses.
+type passAccesses struct {
+	reads  []string
+	writes []string // explicit writes followed by attachments
+}
+
+// lifetime is the span of execution indices over which a resource is accessed.
+type lifetime struct {
+	first, last int
+	firstWrite  int // -1 when the resource is only read
+}
+
+// compile validates the graph and rebuilds the compiled passes, the transient slot plan and the
+// attachment load and store ops. GPU objects are left to allocate. Caller must hold g.mu.
+//
+// Returns:
+//   - error: an error if the graph is invalid
+func (g *renderGraph) compile() error {
+	var active []Pass
+	enabled := make([]bool, len(g.passes))
+	for i, p := range g.passes {
+		enabled[i] = p.Enabled()
+		if enabled[i] {
+			active = append(active, p)
+		}
+	}
+
+	accesses := make([]passAccesses, len(active))
+	for i, p := range active {
+		a, err := g.passAccesses(p)
+		if err != nil {
+			return err
+		}
+		accesses[i] = a
+	}
+
+	order, err := g.sortPasses(active, accesses)
+	if err != nil {
+		return err
+	}
+
+	// Lifetimes over execution indices.
+	lifetimes := make(map[string]*lifetime)
+	touch := func(name string, idx int, write bool) {
+		lt := lifetimes[name]
+		if lt == nil {
+			lt = &lifetime{first: idx, last: idx, firstWrite: -1}
+			lifetimes[name] = lt
+		}
+		lt.last = idx
+		if write && lt.firstWrite < 0 {
+			lt.firstWrite = idx
+		}
+	}
+	for idx, pi := range order {
+		for _, name := range accesses[pi].reads {
+			touch(name, idx, false)
+		}
+		for _, name := range accesses[pi].writes {
+			touch(name, idx, true)
+		}
+	}
+
+	compiled := make([]compiledPass, len(order))
+	for idx, pi := range order {
+		p := active[pi]
+		step := compiledPass{pass: p}
+		for _, a := range p.ColorAttachments() {
+			step.color = append(step.color, g.compileAttachment(a, idx, lifetimes[a.Resource]))
+		}
+		if a, ok := p.DepthAttachment(); ok {
+			ca := g.compileAttachment(a, idx, lifetimes[a.Resource])
+			step.depth = &ca
+		}
+		compiled[idx] = step
+	}
+
+	g.planSlots(active, order, lifetimes)
+	g.compiled = compiled
+	g.compiledEnabled = enabled
+	g.dirty = false
+	g.allocDirty = true
+	return nil
+}
+
+// passAccesses collects and validates the resources a pass accesses. Caller must hold g.mu.
+//
+// Parameters:
+//   - p: the pass
+//
+// Returns:
+//   - passAccesses: the resources the pass reads and writes
+//   - error: an error if the pass accesses an unknown resource or declares invalid attachments
+func (g *renderGraph) passAccesses(p Pass) (passAccesses, error) {
+	var a passAccesses
+	for _, name := range p.Reads() {
+		if g.resources[name] == nil {
+			return a, fmt.Errorf("pass %q reads unknown resource %q", p.Name(), name)
+		}
+		a.reads = append(a.reads, name)
+	}
+	for _, name := range p.Writes() {
+		if g.resources[name] == nil {
+			return a, fmt.Errorf("pass %q writes unknown resource %q", p.Name(), name)
+		}
+		a.writes = append(a.writes, name)
+	}
+
+	colors := p.ColorAttachments()
+	depth, hasDepth := p.DepthAttachment()
+	if p.Type() != PassTypeRender {
+		if len(colors) > 0 || hasDepth {
+			return a, fmt.Errorf("pass %q has attachments but is not a render pass", p.Name())
+		}
+		return a, nil
+	}
+	if len(colors) == 0 && !hasDepth {
+		return a, fmt.Errorf("render pass %q has no attachments", p.Name())
+	}
+	if hasDepth {
+		colors = append(colors, depth)
+	}
+	for _, att := range colors {
+		res := g.resources[att.Resource]
+		if res == nil {
+			return a, fmt.Errorf("pass %q attaches unknown resource %q", p.Name(), att.Resource)
+		}
+		if res.Type() != ResourceTypeTexture {
+			return a, fmt.Errorf("pass %q attaches buffer %q", p.Name(), att.Resource)
+		}
+		a.writes = append(a.writes, att.Resource)
+	}
+	return a, nil
+}
+
+// sortPasses orders the active passes by their resource accesses. Each read depends on the
+// nearest earlier writer in list order, or the first writer when none precedes it; each write
+// depends on the previous writer and on the passes that read the previous writer's output.
+// Ready passes are taken in list order. Caller must hold g.mu.
+//
+// Parameters:
+//   - active: the enabled passes in list order
+//   - accesses: the accesses of each active pass
+//
+// Returns:
+//   - []int: indices into active in execution order
+//   - error: an error if a transient is read but never written, or the dependencies form a cycle
+func (g *renderGraph) sortPasses(active []Pass, accesses []passAccesses) ([]int, error) {
+	writers := make(map[string][]int)
+	for i, a := range accesses {
+		for _, name := range a.writes {
+			if w := writers[name]; len(w) == 0 || w[len(w)-1] != i {
+				writers[name] = append(writers[name], i)
+			}
+		}
+	}
+
+	n := len(active)
+	edges := make(map[[2]int]bool)
+	succ := make([][]int, n)
+	indeg := make([]int, n)
+	addEdge := func(from, to int) {
+		if from == to || edges[[2]int{from, to}] {
+			return
+		}
+		edges[[2]int{from, to}] = true
+		succ[from] = append(succ[from], to)
+		indeg[to]++
+	}
+
+	// readersOf[name][w] lists the passes whose read of name is bound to writer w.
+	readersOf := make(map[string]map[int][]int)
+	for i, a := range accesses {
+		for _, name := range a.reads {
+			producer := -1
+			for _, w := range writers[name] {
+				if w < i {
+					producer = w
+				} else if w > i {
+					if producer < 0 {
+						producer = w
+					}
+					break
+				}
+			}
+			if producer < 0 {
+				if res := g.resources[name]; !res.Imported() && !slices.Contains(a.writes, name) {
+					return nil, fmt.Errorf("pass %q reads transient resource %q that no enabled pass writes", active[i].Name(), name)
+				}
+				continue
+			}
+			addEdge(producer, i)
+			if readersOf[name] == nil {
+				readersOf[name] = make(map[int][]int)
+			}
+			readersOf[name][producer] = append(readersOf[name][producer], i)
+		}
+	}
+	for name, ws := range writers {
+		for k := 1; k < len(ws); k++ {
+			addEdge(ws[k-1], ws[k])
+			for _, r := range readersOf[name][ws[k-1]] {
+				addEdge(r, ws[k])
+			}
+		}
+	}
+
+	order := make([]int, 0, n)
+	done := make([]bool, n)
+	for len(order) < n {
+		next := -1
+		for i := range n {
+			if !done[i] && indeg[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var names []string
+			for i := range n {
+				if !done[i] {
+					names = append(names, active[i].Name())
+				}
+			}
+			return nil, fmt.Errorf("render graph has a dependency cycle between passes %s", strings.Join(names, ", "))
+		}
+		done[next] = true
+		order = append(order, next)
+		for _, s := range succ[next] {
+			indeg[s]--
+		}
+	}
+	return order, nil
+}
+
+// compileAttachment resolves the load and store ops of an attachment. A transient is cleared
+// by the first pass that writes it and loaded by every later one; imported textures are always
+// loaded. The contents are stored when a later pass accesses them or the texture is imported.
+// Caller must hold g.mu.
+//
+// Parameters:
+//   - a: the declared attachment
+//   - idx: the execution index of the pass
+//   - lt: the lifetime of the attachment's resource
+//
+// Returns:
+//   - compiledAttachment: the attachment with its load and store ops
+func (g *renderGraph) compileAttachment(a Attachment, idx int, lt *lifetime) compiledAttachment {
+	imported := g.resources[a.Resource].Imported()
+	ca := compiledAttachment{
+		resource:   a.Resource,
+		loadOp:     wgpu.LoadOpLoad,
+		storeOp:    wgpu.StoreOpDiscard,
+		clearColor: a.ClearColor,
+		clearDepth: a.ClearDepth,
+	}
+	if !imported && lt.firstWrite == idx {
+		ca.loadOp = wgpu.LoadOpClear
+	}
+	if imported || lt.last > idx {
+		ca.storeOp = wgpu.StoreOpStore
+	}
+	return ca
+}
+
+// planSlots assigns every accessed transient to a texture or buffer slot. Transients are taken
+// in order of first access and reuse the first slot with the same key whose previous occupant is
+// no longer accessed. Only the slot keys are planned; allocate creates the GPU objects.
+// Caller must hold g.mu.
+//
+// Parameters:
+//   - active: the enabled passes in list order
+//   - order: indices into active in execution order
+//   - lifetimes: the lifetime of each accessed resource
+func (g *renderGraph) planSlots(active []Pass, order []int, lifetimes map[string]*lifetime) {
+	attached := make(map[string]bool)
+	storage := make(map[string]bool)
+	sampled := make(map[string]bool)
+	for _, pi := range order {
+		p := active[pi]
+		for _, name := range p.Reads() {
+			sampled[name] = true
+		}
+		for _, a := range p.ColorAttachments() {
+			attached[a.Resource] = true
+		}
+		if a, ok := p.DepthAttachment(); ok {
+			attached[a.Resource] = true
+		}
+		if p.Type() == PassTypeCompute {
+			for _, name := range p.Writes() {
+				storage[name] = true
+			}
+		}
+	}
+
+	var transients []string
+	for name := range lifetimes {
+		if !g.resources[name].Imported() {
+			transients = append(transients, name)
+		}
+	}
+	sort.Slice(transients, func(i, j int) bool {
+		a, b := lifetimes[transients[i]], lifetimes[transients[j]]
+		if a.first != b.first {
+			return a.first < b.first
+		}
+		return transients[i] < transients[j]
+	})
+
+	g.textureKeys = g.textureKeys[:0]
+	g.bufferKeys = g.bufferKeys[:0]
+	g.textureAssign = make(map[string]int)
+	g.bufferAssign = make(map[string]int)
+	var textureLastUse, bufferLastUse []int
+
+	for _, name := range transients {
+		res := g.resources[name]
+		lt := lifetimes[name]
+		if res.Type() == ResourceTypeBuffer {
+			key := bufferKey{size: res.BufferSize(), usage: res.BufferUsage()}
+			slot := -1
+			for i, k := range g.bufferKeys {
+				if k == key && bufferLastUse[i] < lt.first {
+					slot = i
+					break
+				}
+			}
+			if slot < 0 {
+				g.bufferKeys = append(g.bufferKeys, key)
+				bufferLastUse = append(bufferLastUse, 0)
+				slot = len(g.bufferKeys) - 1
+			}
+			bufferLastUse[slot] = lt.last
+			g.bufferAssign[name] = slot
+			continue
+		}
+
+		key := textureKey{format: res.Format(), sampleCount: res.SampleCount(), usage: res.TextureUsage()}
+		key.width, key.height = res.Size()
+		if key.width == 0 || key.height == 0 {
+			key.width = max(uint32(float32(g.targetWidth)*res.Scale()), 1)
+			key.height = max(uint32(float32(g.targetHeight)*res.Scale()), 1)
+		}
+		if attached[name] {
+			key.usage |= wgpu.TextureUsageRenderAttachment
+		}
+		if storage[name] {
+			key.usage |= wgpu.TextureUsageStorageBinding
+		}
+		if sampled[name] {
+			key.usage |= wgpu.TextureUsageTextureBinding
+		}
+		if key.usage == 0 {
+			// Only touched by callback passes, which copy into it or bind it themselves.
+			key.usage = wgpu.TextureUsageTextureBinding | wgpu.TextureUsageCopyDst
+		}
+		slot := -1
+		for i, k := range g.textureKeys {
+			if k == key && textureLastUse[i] < lt.first {
+				slot = i
+				break
+			}
+		}
+		if slot < 0 {
+			g.textureKeys = append(g.textureKeys, key)
+			textureLastUse = append(textureLastUse, 0)
+			slot = len(g.textureKeys) - 1
+		}
+		textureLastUse[slot] = lt.last
+		g.textureAssign[name] = slot
+	}
+}
+
+// allocate brings the allocated slots in line with the planned ones, reusing GPU objects whose
+// key is still planned and releasing the rest, then hands each transient the view or buffer of
+// its slot. Caller must hold g.mu.
+//
+// Returns:
+//   - error: an error if a texture or buffer could not be created
+func (g *renderGraph) allocate() error {
+	textureSlots := make([]textureSlot, len(g.textureKeys))
+	for i, key := range g.textureKeys {
+		textureSlots[i].key = key
+		for j := range g.textureSlots {
+			if old := &g.textureSlots[j]; old.texture != nil && old.key == key {
+				textureSlots[i].texture, textureSlots[i].view = old.texture, old.view
+				old.texture, old.view = nil, nil
+				break
+			}
+		}
+	}
+	bufferSlots := make([]bufferSlot, len(g.bufferKeys))
+	for i, key := range g.bufferKeys {
+		bufferSlots[i].key = key
+		for j := range g.bufferSlots {
+			if old := &g.bufferSlots[j]; old.buffer != nil && old.key == key {
+				bufferSlots[i].buffer = old.buffer
+				old.buffer = nil
+				break
+			}
+		}
+	}
+	g.releaseSlots()
+	g.textureSlots = textureSlots
+	g.bufferSlots = bufferSlots
+
+	for i := range g.textureSlots {
+		s := &g.textureSlots[i]
+		if s.texture != nil {
+			continue
+		}
+		tex, view, err := g.renderer.CreateTexture(fmt.Sprintf("Render Graph Texture %d", i),
+			s.key.width, s.key.height, s.key.sampleCount, s.key.format, s.key.usage)
+		if err != nil {
+			return err
+		}
+		s.texture, s.view = tex, view
+	}
+	for i := range g.bufferSlots {
+		s := &g.bufferSlots[i]
+		if s.buffer != nil {
+			continue
+		}
+		buf, err := g.renderer.CreateBuffer(fmt.Sprintf("Render Graph Buffer %d", i), s.key.size, s.key.usage)
+		if err != nil {
+			return err
+		}
+		s.buffer = buf
+	}
+
+	for name, res := range g.resources {
+		if res.Imported() {
+			continue
+		}
+		if slot, ok := g.textureAssign[name]; ok {
+			res.SetTextureView(g.textureSlots[slot].view)
+		} else if slot, ok := g.bufferAssign[name]; ok {
+			res.SetBuffer(g.bufferSlots[slot].buffer)
+		}
+	}
+	g.allocDirty = false
+	return nil
+}
+
+// releaseSlots frees the GPU objects of every allocated slot and clears the views and buffers
+// handed to transients. Caller must hold g.mu.
+func (g *renderGraph) releaseSlots() {
+	for i := range g.textureSlots {
+		s := &g.textureSlots[i]
+		if s.view != nil {
+			s.view.Release()
+		}
+		if s.texture != nil {
+			s.texture.Release()
+		}
+	}
+	for i := range g.bufferSlots {
+		if s := &g.bufferSlots[i]; s.buffer != nil {
+			s.buffer.Release()
+		}
+	}
+	g.textureSlots = nil
+	g.bufferSlots = nil
+	for _, res := range g.resources {
+		if !res.Imported() {
+			res.SetTextureView(nil)
+			res.SetBuffer(nil)
+		}
+	}
+	g.allocDirty = true
+}
@@ -0,0 +1,230 @@
+package render_graph
+
+import (
+	"sync"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// PassType determines what the graph opens around a pass's execute function.
+type PassType int
+
+const (
+	// PassTypeRender begins a render pass with the pass's attachments before execute and ends it
+	// after. Draw calls made through the context's Renderer record into that pass.
+	PassTypeRender PassType = iota
+
+	// PassTypeCompute begins a compute frame before execute and submits it after. Dispatches made
+	// through the context's Renderer are batched into it.
+	PassTypeCompute
+
+	// PassTypeCallback runs execute with nothing open, for passes that manage their own GPU work.
+	PassTypeCallback
+)
+
+// Attachment is a texture a render pass draws into. The graph picks its load and store ops: it is
+// cleared to its clear value when the pass is the first to write a transient texture in the frame
+// and loaded otherwise, and stored only when a later pass accesses it or the texture is imported.
+type Attachment struct {
+	Resource   string
+	ClearColor wgpu.Color // clear value of a color attachment
+	ClearDepth float32    // clear value of a depth attachment
+}
+
+// PassContext is passed to a pass's execute function.
+type PassContext struct {
+	// Renderer is the renderer the graph is executed with.
+	Renderer renderer.Renderer
+
+	graph *renderGraph
+}
+
+// Resource returns a resource of the graph being executed.
+//
+// Parameters:
+//   - name: the resource name
+//
+// Returns:
+//   - Resource: the resource, or nil if the graph has no resource with that name
+func (c PassContext) Resource(name string) Resource {
+	if c.graph == nil {
+		return nil
+	}
+	return c.graph.Resource(name)
+}
+
+// TextureView returns the texture view of a resource for the current frame.
+//
+// Parameters:
+//   - name: the resource name
+//
+// Returns:
+//   - *wgpu.TextureView: the texture view, or nil if the resource does not exist or has none
+func (c PassContext) TextureView(name string) *wgpu.TextureView {
+	if res := c.Resource(name); res != nil {
+		return res.TextureView()
+	}
+	return nil
+}
+
+// Buffer returns the buffer of a resource for the current frame.
+//
+// Parameters:
+//   - name: the resource name
+//
+// Returns:
+//   - *wgpu.Buffer: the buffer, or nil if the resource does not exist or has none
+func (c PassContext) Buffer(name string) *wgpu.Buffer {
+	if res := c.Resource(name); res != nil {
+		return res.Buffer()
+	}
+	return nil
+}
+
+// pass is the implementation of the Pass interface.
+type pass struct {
+	mu               *sync.Mutex
+	name             string
+	passType         PassType
+	enabled          bool
+	reads            []string
+	writes           []string
+	colorAttachments []Attachment
+	depthAttachment  *Attachment
+	execute          func(ctx PassContext) error
+}
+
+// Pass is a node of a RenderGraph. It declares the resources it reads and writes, and the graph
+// runs it after every pass that produces what it reads. Attachments of a render pass count as
+// writes of their resources.
+type Pass interface {
+	// Name returns the pass name, unique within a graph.
+	//
+	// Returns:
+	//   - string: the pass name
+	Name() string
+
+	// Type returns what the graph opens around the pass.
+	//
+	// Returns:
+	//   - PassType: the pass type
+	Type() PassType
+
+	// Enabled returns whether the pass runs.
+	//
+	// Returns:
+	//   - bool: true if the pass is part of the compiled graph
+	Enabled() bool
+
+	// SetEnabled turns the pass on or off. The graph recompiles on its next execution; resources
+	// read by other passes must still have an enabled writer.
+	//
+	// Parameters:
+	//   - enabled: true to run the pass
+	SetEnabled(enabled bool)
+
+	// Reads returns the resources the pass reads, other than through its attachments.
+	//
+	// Returns:
+	//   - []string: the names of the resources read
+	Reads() []string
+
+	// Writes returns the resources the pass writes, other than through its attachments.
+	//
+	// Returns:
+	//   - []string: the names of the resources written
+	Writes() []string
+
+	// ColorAttachments returns the color attachments of a render pass in attachment order.
+	//
+	// Returns:
+	//   - []Attachment: the color attachments
+	ColorAttachments() []Attachment
+
+	// DepthAttachment returns the depth attachment of a render pass.
+	//
+	// Returns:
+	//   - Attachment: the depth attachment
+	//   - bool: false if the pass has no depth attachment
+	DepthAttachment() (Attachment, bool)
+
+	// Execute runs the pass's work. Called by the graph with the pass's render or compute frame open.
+	//
+	// Parameters:
+	//   - ctx: the context of the graph execution
+	//
+	// Returns:
+	//   - error: an error if the pass failed; the remaining passes of the frame still run
+	Execute(ctx PassContext) error
+}
+
+var _ Pass = &pass{}
+
+// NewPass creates a render graph pass. Passes start enabled.
+//
+// Parameters:
+//   - name: the unique name of the pass
+//   - passType: what the graph opens around the pass (render, compute or callback)
+//   - opts: a variadic list of PassBuilderOption functions to configure the pass
+//
+// Returns:
+//   - Pass: a new Pass with the specified configuration
+func NewPass(name string, passType PassType, opts ...PassBuilderOption) Pass {
+	p := &pass{
+		mu:       &sync.Mutex{},
+		name:     name,
+		passType: passType,
+		enabled:  true,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func (p *pass) Name() string {
+	return p.name
+}
+
+func (p *pass) Type() PassType {
+	return p.passType
+}
+
+func (p *pass) Enabled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.enabled
+}
+
+func (p *pass) SetEnabled(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.enabled = enabled
+}
+
+func (p *pass) Reads() []string {
+	return append([]string(nil), p.reads...)
+}
+
+func (p *pass) Writes() []string {
+	return append([]string(nil), p.writes...)
+}
+
+func (p *pass) ColorAttachments() []Attachment {
+	return append([]Attachment(nil), p.colorAttachments...)
+}
+
+func (p *pass) DepthAttachment() (Attachment, bool) {
+	if p.depthAttachment == nil {
+		return Attachment{}, false
+	}
+	return *p.depthAttachment, true
+}
+
+func (p *pass) Execute(ctx PassContext) error {
+	if p.execute == nil {
+		return nil
+	}
+	return p.execute(ctx)
+}
@@ -0,0 +1,88 @@
+package render_graph
+
+import "github.com/cogentcore/webgpu/wgpu"
+
+// PassBuilderOption is a functional option applied to a pass during construction via NewPass.
+type PassBuilderOption func(*pass)
+
+// WithRead declares resources the pass reads, such as textures it samples or buffers it binds.
+// The pass runs after the passes that write them.
+//
+// Parameters:
+//   - names: the names of the resources read
+//
+// Returns:
+//   - PassBuilderOption: a function that adds the reads to the pass
+func WithRead(names ...string) PassBuilderOption {
+	return func(p *pass) {
+		p.reads = append(p.reads, names...)
+	}
+}
+
+// WithWrite declares resources the pass writes other than through attachments, such as storage
+// buffers and storage textures, or imported resources that only order passes.
+//
+// Parameters:
+//   - names: the names of the resources written
+//
+// Returns:
+//   - PassBuilderOption: a function that adds the writes to the pass
+func WithWrite(names ...string) PassBuilderOption {
+	return func(p *pass) {
+		p.writes = append(p.writes, names...)
+	}
+}
+
+// WithColorAttachment appends a color attachment to a render pass.
+//
+// Parameters:
+//   - name: the name of the texture resource to draw into
+//   - clear: the color the attachment is cleared to when the graph clears it
+//
+// Returns:
+//   - PassBuilderOption: a function that adds the color attachment to the pass
+func WithColorAttachment(name string, clear wgpu.Color) PassBuilderOption {
+	return func(p *pass) {
+		p.colorAttachments = append(p.colorAttachments, Attachment{Resource: name, ClearColor: clear})
+	}
+}
+
+// WithDepthAttachment sets the depth attachment of a render pass.
+//
+// Parameters:
+//   - name: the name of the depth texture resource
+//   - clear: the depth the attachment is cleared to when the graph clears it (usually 1)
+//
+// Returns:
+//   - PassBuilderOption: a function that sets the depth attachment of the pass
+func WithDepthAttachment(name string, clear float32) PassBuilderOption {
+	return func(p *pass) {
+		p.depthAttachment = &Attachment{Resource: name, ClearDepth: clear}
+	}
+}
+
+// WithExecute sets the function that records the pass's work.
+//
+// Parameters:
+//   - fn: the function called each frame the pass runs
+//
+// Returns:
+//   - PassBuilderOption: a function that sets the execute function of the pass
+func WithExecute(fn func(ctx PassContext) error) PassBuilderOption {
+	return func(p *pass) {
+		p.execute = fn
+	}
+}
+
+// WithPassEnabled sets whether the pass starts enabled (default true).
+//
+// Parameters:
+//   - enabled: false to add the pass in a disabled state
+//
+// Returns:
+//   - PassBuilderOption: a function that sets the initial enabled state of the pass
+func WithPassEnabled(enabled bool) PassBuilderOption {
+	return func(p *pass) {
+		p.enabled = enabled
+	}
+}
@@ -0,0 +1,433 @@
+package render_graph
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// renderGraph is the implementation of the RenderGraph interface.
+type renderGraph struct {
+	mu        *sync.Mutex
+	passes    []Pass
+	resources map[string]Resource
+
+	// Compiled state, rebuilt whenever the passes, resources, enabled flags, target size or
+	// renderer change. Compiling plans the transient slots; allocate creates their GPU objects
+	// before the next execution. See compile.go.
+	dirty           bool
+	allocDirty      bool
+	compiled        []compiledPass
+	compiledEnabled []bool
+	textureKeys     []textureKey
+	bufferKeys      []bufferKey
+	textureAssign   map[string]int // transient texture name -> index into textureKeys
+	bufferAssign    map[string]int // transient buffer name -> index into bufferKeys
+	textureSlots    []textureSlot
+	bufferSlots     []bufferSlot
+
+	renderer     renderer.Renderer
+	targetWidth  uint32
+	targetHeight uint32
+}
+
+// RenderGraph is a declarative description of a frame. Passes declare the resources they read
+// and write, and the graph derives everything else from those declarations: the order passes
+// run in, which transient textures and buffers to allocate and which of them can share memory,
+// and the load and store ops of every render pass attachment.
+//
+// Passes run in a topological order of their accesses. A pass that reads a resource runs after
+// the nearest pass before it in the list that writes the resource, or, when there is none, after
+// the first pass in the list that writes it. Passes writing the same resource keep their list
+// order, and a write runs after the passes that read the previous write. Ties keep list order,
+// so a graph without dependencies runs exactly in the order its passes were added.
+type RenderGraph interface {
+	// AddPass appends a pass to the graph.
+	//
+	// Parameters:
+	//   - p: the pass to add
+	//
+	// Returns:
+	//   - error: an error if the graph already has a pass with the same name
+	AddPass(p Pass) error
+
+	// InsertPassBefore inserts a pass before another one in the list. The list order only breaks
+	// ties; resource accesses decide the execution order.
+	//
+	// Parameters:
+	//   - before: the name of the pass to insert before
+	//   - p: the pass to insert
+	//
+	// Returns:
+	//   - error: an error if the named pass does not exist or the new pass's name is taken
+	InsertPassBefore(before string, p Pass) error
+
+	// InsertPassAfter inserts a pass after another one in the list. The list order only breaks
+	// ties; resource accesses decide the execution order.
+	//
+	// Parameters:
+	//   - after: the name of the pass to insert after
+	//   - p: the pass to insert
+	//
+	// Returns:
+	//   - error: an error if the named pass does not exist or the new pass's name is taken
+	InsertPassAfter(after string, p Pass) error
+
+	// RemovePass removes the pass with the given name. Does nothing if it does not exist.
+	//
+	// Parameters:
+	//   - name: the name of the pass to remove
+	RemovePass(name string)
+
+	// Pass returns the pass with the given name.
+	//
+	// Parameters:
+	//   - name: the pass name
+	//
+	// Returns:
+	//   - Pass: the pass, or nil if the graph has no pass with that name
+	Pass(name string) Pass
+
+	// Passes returns the passes in list order.
+	//
+	// Returns:
+	//   - []Pass: a copy of the pass list
+	Passes() []Pass
+
+	// AddResource declares a resource that passes can access by name.
+	//
+	// Parameters:
+	//   - res: the resource to add
+	//
+	// Returns:
+	//   - error: an error if the graph already has a resource with the same name
+	AddResource(res Resource) error
+
+	// RemoveResource removes the resource with the given name. Passes still accessing it fail to compile.
+	//
+	// Parameters:
+	//   - name: the name of the resource to remove
+	RemoveResource(name string)
+
+	// Resource returns the resource with the given name.
+	//
+	// Parameters:
+	//   - name: the resource name
+	//
+	// Returns:
+	//   - Resource: the resource, or nil if the graph has no resource with that name
+	Resource(name string) Resource
+
+	// Compile validates the graph and computes the execution order, the transient allocations
+	// and the attachment load and store ops. Execute compiles automatically when anything
+	// changed; call Compile to surface errors early.
+	//
+	// Returns:
+	//   - error: an error if a pass accesses an unknown resource, reads a transient nothing writes, or the accesses form a cycle
+	Compile() error
+
+	// Order returns the names of the enabled passes in execution order, compiling first if needed.
+	//
+	// Returns:
+	//   - []string: the pass names in the order they run, or nil if the graph fails to compile
+	Order() []string
+
+	// Execute runs one frame of the graph with the given renderer. Transients are allocated
+	// through the renderer and sized from its frame target; they are reallocated when the target
+	// is resized. A failing pass does not stop the frame: the remaining passes still run, so a
+	// frame target acquired by an earlier pass is always presented and released.
+	//
+	// Parameters:
+	//   - r: the renderer to run the passes with
+	//   - passDone: called after each pass finishes, or nil
+	//
+	// Returns:
+	//   - error: an error if the graph fails to compile or a transient cannot be allocated, or the errors of every pass that failed
+	Execute(r renderer.Renderer, passDone func(p Pass)) error
+
+	// Release frees every transient texture and buffer. The next Execute allocates them again.
+	Release()
+}
+
+var _ RenderGraph = &renderGraph{}
+
+// NewRenderGraph creates an empty render graph.
+//
+// Parameters:
+//   - opts: a variadic list of RenderGraphBuilderOption functions to configure the graph
+//
+// Returns:
+//   - RenderGraph: a new RenderGraph with the specified passes and resources
+func NewRenderGraph(opts ...RenderGraphBuilderOption) RenderGraph {
+	g := &renderGraph{
+		mu:        &sync.Mutex{},
+		resources: make(map[string]Resource),
+		dirty:     true,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+func (g *renderGraph) AddPass(p Pass) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.insertPass(len(g.passes), p)
+}
+
+func (g *renderGraph) InsertPassBefore(before string, p Pass) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	i := g.passIndex(before)
+	if i < 0 {
+		return fmt.Errorf("pass %q not found", before)
+	}
+	return g.insertPass(i, p)
+}
+
+func (g *renderGraph) InsertPassAfter(after string, p Pass) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	i := g.passIndex(after)
+	if i < 0 {
+		return fmt.Errorf("pass %q not found", after)
+	}
+	return g.insertPass(i+1, p)
+}
+
+func (g *renderGraph) RemovePass(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if i := g.passIndex(name); i >= 0 {
+		g.passes = append(g.passes[:i], g.passes[i+1:]...)
+		g.dirty = true
+	}
+}
+
+func (g *renderGraph) Pass(name string) Pass {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if i := g.passIndex(name); i >= 0 {
+		return g.passes[i]
+	}
+	return nil
+}
+
+func (g *renderGraph) Passes() []Pass {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Pass(nil), g.passes...)
+}
+
+func (g *renderGraph) AddResource(res Resource) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.resources[res.Name()]; exists {
+		return fmt.Errorf("resource %q already exists", res.Name())
+	}
+	g.resources[res.Name()] = res
+	g.dirty = true
+	return nil
+}
+
+func (g *renderGraph) RemoveResource(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.resources[name]; exists {
+		delete(g.resources, name)
+		g.dirty = true
+	}
+}
+
+func (g *renderGraph) Resource(name string) Resource {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.resources[name]
+}
+
+func (g *renderGraph) Compile() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.compile()
+}
+
+func (g *renderGraph) Order() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.dirty || g.enabledChanged() {
+		if err := g.compile(); err != nil {
+			return nil
+		}
+	}
+	order := make([]string, len(g.compiled))
+	for i, step := range g.compiled {
+		order[i] = step.pass.Name()
+	}
+	return order
+}
+
+func (g *renderGraph) Execute(r renderer.Renderer, passDone func(p Pass)) error {
+	g.mu.Lock()
+	if r != g.renderer {
+		g.releaseSlots()
+		g.renderer = r
+		g.dirty = true
+	}
+	if w, h := r.TargetSize(); w != g.targetWidth || h != g.targetHeight {
+		g.targetWidth, g.targetHeight = w, h
+		g.dirty = true
+	}
+	if g.dirty || g.enabledChanged() {
+		if err := g.compile(); err != nil {
+			g.mu.Unlock()
+			return err
+		}
+	}
+	if g.allocDirty {
+		if err := g.allocate(); err != nil {
+			g.mu.Unlock()
+			return err
+		}
+	}
+	steps := g.compiled
+	g.mu.Unlock()
+
+	// Keep running after a failure: a later pass may have to present or release what an
+	// earlier one acquired.
+	ctx := PassContext{Renderer: r, graph: g}
+	var errs []error
+	for _, step := range steps {
+		if err := g.executePass(ctx, step); err != nil {
+			errs = append(errs, fmt.Errorf("render graph pass %q failed: %w", step.pass.Name(), err))
+		}
+		if passDone != nil {
+			passDone(step.pass)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (g *renderGraph) Release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.releaseSlots()
+}
+
+// insertPass inserts a pass at the given list index. Caller must hold g.mu.
+//
+// Parameters:
+//   - i: the list index to insert at
+//   - p: the pass to insert
+//
+// Returns:
+//   - error: an error if the graph already has a pass with the same name
+func (g *renderGraph) insertPass(i int, p Pass) error {
+	if g.passIndex(p.Name()) >= 0 {
+		return fmt.Errorf("pass %q already exists", p.Name())
+	}
+	g.passes = append(g.passes, nil)
+	copy(g.passes[i+1:], g.passes[i:])
+	g.passes[i] = p
+	g.dirty = true
+	return nil
+}
+
+// passIndex returns the list index of the named pass. Caller must hold g.mu.
+//
+// Parameters:
+//   - name: the pass name
+//
+// Returns:
+//   - int: the list index, or -1 if the graph has no pass with that name
+func (g *renderGraph) passIndex(name string) int {
+	for i, p := range g.passes {
+		if p.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// enabledChanged reports whether any pass was enabled or disabled since the last compile.
+// Caller must hold g.mu.
+//
+// Returns:
+//   - bool: true if the compiled graph is out of date
+func (g *renderGraph) enabledChanged() bool {
+	if len(g.compiledEnabled) != len(g.passes) {
+		return true
+	}
+	for i, p := range g.passes {
+		if p.Enabled() != g.compiledEnabled[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// executePass runs one compiled pass, opening a render pass with its attachments or a compute
+// frame around it as its type requires.
+//
+// Parameters:
+//   - ctx: the context passed to the pass
+//   - step: the compiled pass
+//
+// Returns:
+//   - error: an error if an attachment has no texture view, the pass could not be opened, or the pass failed
+func (g *renderGraph) executePass(ctx PassContext, step compiledPass) error {
+	switch step.pass.Type() {
+	case PassTypeRender:
+		desc := &wgpu.RenderPassDescriptor{Label: step.pass.Name()}
+		for _, a := range step.color {
+			view := ctx.TextureView(a.resource)
+			if view == nil {
+				return fmt.Errorf("color attachment %q has no texture view", a.resource)
+			}
+			desc.ColorAttachments = append(desc.ColorAttachments, wgpu.RenderPassColorAttachment{
+				View:       view,
+				LoadOp:     a.loadOp,
+				StoreOp:    a.storeOp,
+				ClearValue: a.clearColor,
+			})
+		}
+		if a := step.depth; a != nil {
+			view := ctx.TextureView(a.resource)
+			if view == nil {
+				return fmt.Errorf("depth attachment %q has no texture view", a.resource)
+			}
+			desc.DepthStencilAttachment = &wgpu.RenderPassDepthStencilAttachment{
+				View:            view,
+				DepthLoadOp:     a.loadOp,
+				DepthStoreOp:    a.storeOp,
+				DepthClearValue: a.clearDepth,
+			}
+		}
+		if err := ctx.Renderer.BeginRenderPass(desc); err != nil {
+			return err
+		}
+		err := step.pass.Execute(ctx)
+		ctx.Renderer.EndRenderPass()
+		return err
+	case PassTypeCompute:
+		if err := ctx.Renderer.BeginComputeFrame(); err != nil {
+			return err
+		}
+		err := step.pass.Execute(ctx)
+		ctx.Renderer.EndComputeFrame()
+		return err
+	default:
+		return step.pass.Execute(ctx)
+	}
+}
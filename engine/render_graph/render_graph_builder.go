@@ -0,0 +1,36 @@
+package render_graph
+
+// RenderGraphBuilderOption is a functional option applied to a render graph during construction via NewRenderGraph.
+type RenderGraphBuilderOption func(*renderGraph)
+
+// WithPass appends a pass to the graph. Panics if the graph already has a pass with the same name.
+//
+// Parameters:
+//   - p: the pass to add
+//
+// Returns:
+//   - RenderGraphBuilderOption: a function that adds the pass to the graph
+func WithPass(p Pass) RenderGraphBuilderOption {
+	return func(g *renderGraph) {
+		if err := g.insertPass(len(g.passes), p); err != nil {
+			panic("render_graph: " + err.Error())
+		}
+	}
+}
+
+// WithResource declares a resource on the graph. Panics if the graph already has a resource with
+// the same name.
+//
+// Parameters:
+//   - res: the resource to add
+//
+// Returns:
+//   - RenderGraphBuilderOption: a function that adds the resource to the graph
+func WithResource(res Resource) RenderGraphBuilderOption {
+	return func(g *renderGraph) {
+		if _, exists := g.resources[res.Name()]; exists {
+			panic("render_graph: resource " + res.Name() + " already exists")
+		}
+		g.resources[res.Name()] = res
+	}
+}
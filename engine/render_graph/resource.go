@@ -0,0 +1,242 @@
+package render_graph
+
+import (
+	"sync"
+
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// ResourceType identifies whether a render graph resource is a texture or a buffer.
+type ResourceType int
+
+const (
+	// ResourceTypeTexture is a 2D texture, used as a pass attachment or bound for sampling or storage.
+	ResourceTypeTexture ResourceType = iota
+
+	// ResourceTypeBuffer is a GPU buffer read or written by passes.
+	ResourceTypeBuffer
+)
+
+// DepthFormat is the format of depth textures declared without WithFormat. It matches the depth
+// format render pipelines are created with.
+const DepthFormat = wgpu.TextureFormatDepth24Plus
+
+// resource is the implementation of the Resource interface.
+type resource struct {
+	mu           *sync.Mutex
+	name         string
+	resourceType ResourceType
+	imported     bool
+
+	format       wgpu.TextureFormat
+	width        uint32
+	height       uint32
+	scale        float32
+	sampleCount  uint32
+	textureUsage wgpu.TextureUsage
+
+	bufferSize  uint64
+	bufferUsage wgpu.BufferUsage
+
+	textureView *wgpu.TextureView
+	buffer      *wgpu.Buffer
+}
+
+// Resource is a named texture or buffer that render graph passes read and write. Passes refer to
+// resources by name; the graph orders passes by these accesses.
+//
+// Transient resources are created by the graph: textures are sized from the frame target unless
+// given a fixed size, and transients whose lifetimes do not overlap share the same GPU memory when
+// their descriptions match. Their contents only live for the frame, from the first pass that
+// writes them to the last pass that accesses them.
+//
+// Imported resources are owned outside the graph, such as the swapchain or a shadow map held by a
+// scene. The graph never allocates them and always preserves their contents. An imported resource
+// may carry no GPU object at all and only express ordering between passes.
+type Resource interface {
+	// Name returns the name passes use to refer to the resource.
+	//
+	// Returns:
+	//   - string: the resource name
+	Name() string
+
+	// Type returns whether the resource is a texture or a buffer.
+	//
+	// Returns:
+	//   - ResourceType: the resource type
+	Type() ResourceType
+
+	// Imported returns whether the resource is owned outside the graph.
+	//
+	// Returns:
+	//   - bool: true for imported resources, false for transients allocated by the graph
+	Imported() bool
+
+	// Format returns the texture format.
+	//
+	// Returns:
+	//   - wgpu.TextureFormat: the texture format, or TextureFormatUndefined for buffers
+	Format() wgpu.TextureFormat
+
+	// Size returns the fixed texture size.
+	//
+	// Returns:
+	//   - uint32: the width in pixels, or 0 to follow the frame target
+	//   - uint32: the height in pixels, or 0 to follow the frame target
+	Size() (uint32, uint32)
+
+	// Scale returns the texture size relative to the frame target, used when no fixed size is set.
+	//
+	// Returns:
+	//   - float32: the scale factor
+	Scale() float32
+
+	// SampleCount returns the texture multisample count.
+	//
+	// Returns:
+	//   - uint32: the sample count (1 for no multisampling)
+	SampleCount() uint32
+
+	// TextureUsage returns the usage flags added to those the graph derives from pass accesses.
+	//
+	// Returns:
+	//   - wgpu.TextureUsage: the extra texture usage flags
+	TextureUsage() wgpu.TextureUsage
+
+	// BufferSize returns the buffer size in bytes.
+	//
+	// Returns:
+	//   - uint64: the buffer size, or 0 for textures
+	BufferSize() uint64
+
+	// BufferUsage returns the buffer usage flags.
+	//
+	// Returns:
+	//   - wgpu.BufferUsage: the buffer usage flags
+	BufferUsage() wgpu.BufferUsage
+
+	// TextureView returns the view of the texture for the current frame. For transients it is only
+	// valid inside the passes that access the resource and may change when the graph recompiles.
+	//
+	// Returns:
+	//   - *wgpu.TextureView: the texture view, or nil if none is set
+	TextureView() *wgpu.TextureView
+
+	// SetTextureView sets the texture view of an imported texture. Set by the graph for transients.
+	//
+	// Parameters:
+	//   - view: the texture view
+	SetTextureView(view *wgpu.TextureView)
+
+	// Buffer returns the buffer for the current frame. For transients it is only valid inside the
+	// passes that access the resource and may change when the graph recompiles.
+	//
+	// Returns:
+	//   - *wgpu.Buffer: the buffer, or nil if none is set
+	Buffer() *wgpu.Buffer
+
+	// SetBuffer sets the buffer of an imported buffer resource. Set by the graph for transients.
+	//
+	// Parameters:
+	//   - buf: the buffer
+	SetBuffer(buf *wgpu.Buffer)
+}
+
+var _ Resource = &resource{}
+
+// NewResource creates a render graph resource. Textures default to a transient, single-sampled,
+// frame-sized RGBA8Unorm texture; buffers default to a transient storage buffer and need a size.
+//
+// Parameters:
+//   - name: the unique name passes use to refer to the resource
+//   - resourceType: the type of resource to create (texture or buffer)
+//   - opts: a variadic list of ResourceBuilderOption functions to configure the resource
+//
+// Returns:
+//   - Resource: a new Resource with the specified configuration
+func NewResource(name string, resourceType ResourceType, opts ...ResourceBuilderOption) Resource {
+	r := &resource{
+		mu:           &sync.Mutex{},
+		name:         name,
+		resourceType: resourceType,
+		scale:        1,
+		sampleCount:  1,
+	}
+	switch resourceType {
+	case ResourceTypeTexture:
+		r.format = wgpu.TextureFormatRGBA8Unorm
+	case ResourceTypeBuffer:
+		r.bufferUsage = wgpu.BufferUsageStorage | wgpu.BufferUsageCopyDst
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *resource) Name() string {
+	return r.name
+}
+
+func (r *resource) Type() ResourceType {
+	return r.resourceType
+}
+
+func (r *resource) Imported() bool {
+	return r.imported
+}
+
+func (r *resource) Format() wgpu.TextureFormat {
+	if r.resourceType != ResourceTypeTexture {
+		return wgpu.TextureFormatUndefined
+	}
+	return r.format
+}
+
+func (r *resource) Size() (uint32, uint32) {
+	return r.width, r.height
+}
+
+func (r *resource) Scale() float32 {
+	return r.scale
+}
+
+func (r *resource) SampleCount() uint32 {
+	return r.sampleCount
+}
+
+func (r *resource) TextureUsage() wgpu.TextureUsage {
+	return r.textureUsage
+}
+
+func (r *resource) BufferSize() uint64 {
+	return r.bufferSize
+}
+
+func (r *resource) BufferUsage() wgpu.BufferUsage {
+	return r.bufferUsage
+}
+
+func (r *resource) TextureView() *wgpu.TextureView {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.textureView
+}
+
+func (r *resource) SetTextureView(view *wgpu.TextureView) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.textureView = view
+}
+
+func (r *resource) Buffer() *wgpu.Buffer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buffer
+}
+
+func (r *resource) SetBuffer(buf *wgpu.Buffer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.buffer = buf
+}
@@ -0,0 +1,117 @@
+package render_graph
+
+import "github.com/cogentcore/webgpu/wgpu"
+
+// ResourceBuilderOption is a functional option applied to a resource during construction via NewResource.
+type ResourceBuilderOption func(*resource)
+
+// WithImported marks the resource as owned outside the graph. The graph does not allocate it and
+// always stores its contents at the end of a pass. Set its GPU object with SetTextureView or
+// SetBuffer, or leave it unset for a resource that only orders passes.
+//
+// Returns:
+//   - ResourceBuilderOption: a function that marks the resource as imported
+func WithImported() ResourceBuilderOption {
+	return func(r *resource) {
+		r.imported = true
+	}
+}
+
+// WithFormat sets the texture format. Depth attachments drawn with engine pipelines must use DepthFormat.
+//
+// Parameters:
+//   - format: the texture format
+//
+// Returns:
+//   - ResourceBuilderOption: a function that sets the texture format
+func WithFormat(format wgpu.TextureFormat) ResourceBuilderOption {
+	return func(r *resource) {
+		r.format = format
+	}
+}
+
+// WithSize gives the texture a fixed size instead of following the frame target.
+//
+// Parameters:
+//   - width: the width in pixels
+//   - height: the height in pixels
+//
+// Returns:
+//   - ResourceBuilderOption: a function that sets the fixed texture size
+func WithSize(width, height uint32) ResourceBuilderOption {
+	return func(r *resource) {
+		r.width = width
+		r.height = height
+	}
+}
+
+// WithScale sizes the texture relative to the frame target, e.g. 0.5 for half resolution.
+// Ignored when a fixed size is set.
+//
+// Parameters:
+//   - scale: the size relative to the frame target (0 is treated as 1)
+//
+// Returns:
+//   - ResourceBuilderOption: a function that sets the texture scale
+func WithScale(scale float32) ResourceBuilderOption {
+	return func(r *resource) {
+		if scale <= 0 {
+			scale = 1
+		}
+		r.scale = scale
+	}
+}
+
+// WithResourceSampleCount sets the texture multisample count. Pipelines drawing into it must be
+// created with a matching pipeline.WithSampleCount.
+//
+// Parameters:
+//   - count: the sample count (1 for no multisampling)
+//
+// Returns:
+//   - ResourceBuilderOption: a function that sets the texture sample count
+func WithResourceSampleCount(count uint32) ResourceBuilderOption {
+	return func(r *resource) {
+		r.sampleCount = max(count, 1)
+	}
+}
+
+// WithTextureUsage adds texture usage flags beyond those the graph derives from pass accesses,
+// such as wgpu.TextureUsageCopySrc for a texture that is read back.
+//
+// Parameters:
+//   - usage: the extra texture usage flags
+//
+// Returns:
+//   - ResourceBuilderOption: a function that adds the texture usage flags
+func WithTextureUsage(usage wgpu.TextureUsage) ResourceBuilderOption {
+	return func(r *resource) {
+		r.textureUsage |= usage
+	}
+}
+
+// WithBufferSize sets the buffer size in bytes. Required for transient buffers.
+//
+// Parameters:
+//   - size: the buffer size in bytes
+//
+// Returns:
+//   - ResourceBuilderOption: a function that sets the buffer size
+func WithBufferSize(size uint64) ResourceBuilderOption {
+	return func(r *resource) {
+		r.bufferSize = size
+	}
+}
+
+// WithBufferUsage replaces the buffer usage flags (default storage and copy destination).
+//
+// Parameters:
+//   - usage: the buffer usage flags
+//
+// Returns:
+//   - ResourceBuilderOption: a function that sets the buffer usage flags
+func WithBufferUsage(usage wgpu.BufferUsage) ResourceBuilderOption {
+	return func(r *resource) {
+		r.bufferUsage = usage
+	}
+}
@@ -0,0 +1,317 @@
+package render_target
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// renderTargetImpl is the implementation of the RenderTarget interface.
+type renderTargetImpl struct {
+	mu *sync.Mutex
+
+	name       string
+	r          renderer.Renderer
+	width      int
+	height     int
+	updateRate float64
+	clearColor wgpu.Color
+
+	colorTexture     *wgpu.Texture
+	colorView        *wgpu.TextureView // single-sample, sampled by materials
+	msaaColorTexture *wgpu.Texture     // nil without MSAA
+	msaaColorView    *wgpu.TextureView
+	depthTexture     *wgpu.Texture
+	depthView        *wgpu.TextureView
+
+	lastRender time.Time
+	rendered   bool
+	active     bool // between Begin and End
+}
+
+// RenderTarget defines the interface for an offscreen color and depth target a scene renders
+// into instead of the frame. Its color texture can then be sampled by the materials of other
+// scenes, for security-camera monitors, mirrors and in-world screens.
+//
+// The textures are created at construction with the renderer's scene format and sample count,
+// so the scene's pipelines draw into them unchanged. With MSAA on, the scene draws into a
+// multisampled color texture that is resolved into the sampled one at the end of the pass.
+// The engine renders every due target in its offscreen pass, before the main pass samples them.
+type RenderTarget interface {
+	// Name returns the name of the render target.
+	//
+	// Returns:
+	//   - string: the name
+	Name() string
+
+	// Size returns the resolution of the render target.
+	//
+	// Returns:
+	//   - int: the width in pixels
+	//   - int: the height in pixels
+	Size() (int, int)
+
+	// UpdateRate returns how many times per second the target is re-rendered.
+	//
+	// Returns:
+	//   - float64: the update rate in hertz, 0 for every frame
+	UpdateRate() float64
+
+	// SetUpdateRate sets how many times per second the target is re-rendered. Between updates
+	// the texture keeps its last image, which saves the cost of a full scene render on
+	// slow-changing displays.
+	//
+	// Parameters:
+	//   - hz: the update rate in hertz, 0 for every frame
+	SetUpdateRate(hz float64)
+
+	// ClearColor returns the color the target is cleared to at the start of each render.
+	//
+	// Returns:
+	//   - wgpu.Color: the clear color
+	ClearColor() wgpu.Color
+
+	// SetClearColor sets the color the target is cleared to at the start of each render.
+	//
+	// Parameters:
+	//   - color: the clear color
+	SetClearColor(color wgpu.Color)
+
+	// Due reports whether the target should be re-rendered at the given time: always before its
+	// first render, and afterwards once per update interval.
+	//
+	// Parameters:
+	//   - now: the current time
+	//
+	// Returns:
+	//   - bool: true if the target should be rendered
+	Due(now time.Time) bool
+
+	// Begin begins a render pass into the target, cleared to the clear color and a depth of 1.
+	// Draws recorded through the renderer go into the target until End.
+	//
+	// Returns:
+	//   - error: an error if a frame or another render pass is in progress
+	Begin() error
+
+	// End ends the render pass begun by Begin and submits it.
+	End()
+
+	// TextureView returns the target's single-sample color view, in the renderer's scene format.
+	// It is owned by the target; use NewTextureView for a view to hand to a bind group provider.
+	//
+	// Returns:
+	//   - *wgpu.TextureView: the color view
+	TextureView() *wgpu.TextureView
+
+	// DepthTextureView returns the target's depth view. It is multisampled when the renderer is.
+	//
+	// Returns:
+	//   - *wgpu.TextureView: the depth view
+	DepthTextureView() *wgpu.TextureView
+
+	// NewTextureView creates a new view of the target's color texture, owned by the caller.
+	// Bind group providers release the views they hold, so each one binding the target gets
+	// its own.
+	//
+	// Returns:
+	//   - *wgpu.TextureView: the new view
+	//   - error: an error if the view could not be created
+	NewTextureView() (*wgpu.TextureView, error)
+
+	// Release releases the target's textures. Views created by NewTextureView stay valid until
+	// their owners release them.
+	Release()
+}
+
+var _ RenderTarget = &renderTargetImpl{}
+
+// NewRenderTarget creates a new RenderTarget and its textures on the renderer's device.
+//
+// Parameters:
+//   - name: the name of the render target, used in texture labels
+//   - r: the renderer whose scene format and sample count the target matches
+//   - width: the width in pixels
+//   - height: the height in pixels
+//   - opts: variadic list of RenderTargetBuilderOption functions to configure the render target
+//
+// Returns:
+//   - RenderTarget: a new RenderTarget instance
+func NewRenderTarget(name string, r renderer.Renderer, width, height int, opts ...RenderTargetBuilderOption) RenderTarget {
+	if r == nil {
+		panic("render_target: NewRenderTarget requires a non-nil Renderer")
+	}
+	if width <= 0 || height <= 0 {
+		panic("render_target: size must be positive")
+	}
+
+	t := &renderTargetImpl{
+		mu:         &sync.Mutex{},
+		name:       name,
+		r:          r,
+		width:      width,
+		height:     height,
+		clearColor: wgpu.Color{R: 0, G: 0, B: 0, A: 1},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	format := r.SceneFormat()
+	samples := r.SampleCount()
+	w, h := uint32(width), uint32(height)
+
+	var err error
+	t.colorTexture, t.colorView, err = r.CreateTexture(name+" Color", w, h, 1, format,
+		wgpu.TextureUsageRenderAttachment|wgpu.TextureUsageTextureBinding)
+	if err != nil {
+		panic(fmt.Sprintf("render_target: failed to create color texture: %v", err))
+	}
+	if samples > 1 {
+		t.msaaColorTexture, t.msaaColorView, err = r.CreateTexture(name+" MSAA Color", w, h, samples, format,
+			wgpu.TextureUsageRenderAttachment)
+		if err != nil {
+			panic(fmt.Sprintf("render_target: failed to create multisampled color texture: %v", err))
+		}
+	}
+	t.depthTexture, t.depthView, err = r.CreateTexture(name+" Depth", w, h, samples, wgpu.TextureFormatDepth24Plus,
+		wgpu.TextureUsageRenderAttachment)
+	if err != nil {
+		panic(fmt.Sprintf("render_target: failed to create depth texture: %v", err))
+	}
+
+	return t
+}
+
+func (t *renderTargetImpl) Name() string {
+	return t.name
+}
+
+func (t *renderTargetImpl) Size() (int, int) {
+	return t.width, t.height
+}
+
+func (t *renderTargetImpl) UpdateRate() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.updateRate
+}
+
+func (t *renderTargetImpl) SetUpdateRate(hz float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.updateRate = max(hz, 0)
+}
+
+func (t *renderTargetImpl) ClearColor() wgpu.Color {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.clearColor
+}
+
+func (t *renderTargetImpl) SetClearColor(color wgpu.Color) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.clearColor = color
+}
+
+func (t *renderTargetImpl) Due(now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.rendered || t.updateRate <= 0 {
+		return true
+	}
+	return now.Sub(t.lastRender).Seconds() >= 1/t.updateRate
+}
+
+func (t *renderTargetImpl) Begin() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.colorView == nil {
+		return fmt.Errorf("render target %q has been released", t.name)
+	}
+
+	color := wgpu.RenderPassColorAttachment{
+		View:       t.colorView,
+		LoadOp:     wgpu.LoadOpClear,
+		StoreOp:    wgpu.StoreOpStore,
+		ClearValue: t.clearColor,
+	}
+	if t.msaaColorView != nil {
+		color.View = t.msaaColorView
+		color.ResolveTarget = t.colorView
+		color.StoreOp = wgpu.StoreOpDiscard
+	}
+
+	err := t.r.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		Label:            t.name + " Render Pass",
+		ColorAttachments: []wgpu.RenderPassColorAttachment{color},
+		DepthStencilAttachment: &wgpu.RenderPassDepthStencilAttachment{
+			View:            t.depthView,
+			DepthLoadOp:     wgpu.LoadOpClear,
+			DepthStoreOp:    wgpu.StoreOpStore,
+			DepthClearValue: 1.0,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to begin render target %q: %w", t.name, err)
+	}
+
+	t.active = true
+	t.lastRender = time.Now()
+	t.rendered = true
+	return nil
+}
+
+func (t *renderTargetImpl) End() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.active {
+		return
+	}
+	t.active = false
+	t.r.EndRenderPass()
+}
+
+func (t *renderTargetImpl) TextureView() *wgpu.TextureView {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.colorView
+}
+
+func (t *renderTargetImpl) DepthTextureView() *wgpu.TextureView {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.depthView
+}
+
+func (t *renderTargetImpl) NewTextureView() (*wgpu.TextureView, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.colorTexture == nil {
+		return nil, fmt.Errorf("render target %q has been released", t.name)
+	}
+	return t.colorTexture.CreateView(nil)
+}
+
+func (t *renderTargetImpl) Release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, view := range []*wgpu.TextureView{t.colorView, t.msaaColorView, t.depthView} {
+		if view != nil {
+			view.Release()
+		}
+	}
+	for _, tex := range []*wgpu.Texture{t.colorTexture, t.msaaColorTexture, t.depthTexture} {
+		if tex != nil {
+			tex.Release()
+		}
+	}
+	t.colorView, t.msaaColorView, t.depthView = nil, nil, nil
+	t.colorTexture, t.msaaColorTexture, t.depthTexture = nil, nil, nil
+}
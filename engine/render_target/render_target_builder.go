@@ -0,0 +1,34 @@
+package render_target
+
+import "github.com/cogentcore/webgpu/wgpu"
+
+// RenderTargetBuilderOption is a function that configures a RenderTarget instance during construction.
+type RenderTargetBuilderOption func(*renderTargetImpl)
+
+// WithUpdateRate is an option builder that sets how many times per second the target is
+// re-rendered. Defaults to 0, every frame.
+//
+// Parameters:
+//   - hz: the update rate in hertz, 0 for every frame
+//
+// Returns:
+//   - RenderTargetBuilderOption: a function that applies the update rate option to a renderTargetImpl
+func WithUpdateRate(hz float64) RenderTargetBuilderOption {
+	return func(t *renderTargetImpl) {
+		t.updateRate = max(hz, 0)
+	}
+}
+
+// WithClearColor is an option builder that sets the color the target is cleared to at the start
+// of each render. Defaults to opaque black.
+//
+// Parameters:
+//   - color: the clear color
+//
+// Returns:
+//   - RenderTargetBuilderOption: a function that applies the clear color option to a renderTargetImpl
+func WithClearColor(color wgpu.Color) RenderTargetBuilderOption {
+	return func(t *renderTargetImpl) {
+		t.clearColor = color
+	}
+}
@@ -282,6 +282,11 @@ type Animator interface {
 	//   - planes: the six frustum planes in GPU-aligned format
 	SetFrustumPlanes(planes [6]GPUFrustumPlane)
 
+	// DisableCulling turns GPU frustum culling off until the next SetFrustumPlanes call.
+	// Without culling, every instance is written to the output slot matching its index,
+	// so individual instances can be drawn by index (e.g. for sorted transparency).
+	DisableCulling()
+
 	// SetBoundingRadius sets the object-space bounding sphere radius used for frustum culling.
 	//
 	// Parameters:
@@ -558,6 +563,10 @@ func (a *animator) SetFrustumPlanes(planes [6]GPUFrustumPlane) {
 	a.backend.SetFrustumPlanes(planes)
 }
 
+func (a *animator) DisableCulling() {
+	a.backend.DisableCulling()
+}
+
 func (a *animator) SetBoundingRadius(radius float32) {
 	a.backend.SetBoundingRadius(radius)
 }
@@ -135,12 +135,12 @@ var GPUGlobalDataSource string
 
 // GPUGlobalData is the GPU-aligned per-frame uniform for the frustum-culled simple compute shader.
 // Matches the WGSL GlobalData struct layout exactly (see GPUGlobalDataSource).
-// Size: 112 bytes (instance_count u32 + delta_time f32 + bounding_radius f32 + pad + 6 × GPUFrustumPlane).
+// Size: 112 bytes (instance_count u32 + delta_time f32 + bounding_radius f32 + cull_enabled u32 + 6 × GPUFrustumPlane).
 type GPUGlobalData struct {
 	InstanceCount  uint32             // offset 0
 	DeltaTime      float32            // offset 4
 	BoundingRadius float32            // offset 8
-	_padding       float32            // offset 12: pad to 16 bytes before planes array
+	CullEnabled    uint32             // offset 12: 1 = cull and compact visible instances, 0 = write instance i to slot i
 	Planes         [6]GPUFrustumPlane // offset 16: 6 × 16 bytes = 96 bytes
 }
 
@@ -161,7 +161,7 @@ func (g *GPUGlobalData) Marshal() []byte {
 	binary.LittleEndian.PutUint32(buf[0:4], g.InstanceCount)
 	binary.LittleEndian.PutUint32(buf[4:8], math.Float32bits(g.DeltaTime))
 	binary.LittleEndian.PutUint32(buf[8:12], math.Float32bits(g.BoundingRadius))
-	binary.LittleEndian.PutUint32(buf[12:16], 0) // _padding
+	binary.LittleEndian.PutUint32(buf[12:16], g.CullEnabled)
 	off := 16
 	for i := range 6 {
 		p := g.Planes[i]
@@ -189,7 +189,7 @@ type GPUAnimationGlobals struct {
 	BoundingRadius     float32            // offset 8
 	ChannelDataOffset  uint32             // offset 12: u32 index into anim_packed where channel headers start
 	KeyframeDataOffset uint32             // offset 16: u32 index into anim_packed where keyframes start
-	_pad1              uint32             // offset 20
+	CullEnabled        uint32             // offset 20: 1 = cull and compact visible instances, 0 = write instance i to slot i
 	_pad2              uint32             // offset 24
 	_pad3              uint32             // offset 28
 	Planes             [6]GPUFrustumPlane // offset 32: 6 × 16 bytes = 96 bytes
@@ -214,7 +214,7 @@ func (g *GPUAnimationGlobals) Marshal() []byte {
 	binary.LittleEndian.PutUint32(buf[8:12], math.Float32bits(g.BoundingRadius))
 	binary.LittleEndian.PutUint32(buf[12:16], g.ChannelDataOffset)
 	binary.LittleEndian.PutUint32(buf[16:20], g.KeyframeDataOffset)
-	binary.LittleEndian.PutUint32(buf[20:24], 0) // _pad1
+	binary.LittleEndian.PutUint32(buf[20:24], g.CullEnabled)
 	binary.LittleEndian.PutUint32(buf[24:28], 0) // _pad2
 	binary.LittleEndian.PutUint32(buf[28:32], 0) // _pad3
 	off := 32
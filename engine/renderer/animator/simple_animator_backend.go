@@ -1,6 +1,7 @@
 package animator
 
 import (
+	"math"
 	"sync"
 
 	"github.com/Carmen-Shannon/oxy-go/common"
@@ -34,6 +35,11 @@ type simpleAnimatorBackendImpl struct {
 	// perFrameSlice is a reusable slice for staging per-instance culling data each frame, to avoid heap allocations.
 	perFrameSlice []GPUGlobalData
 
+	// frameDelta is the delta time of the frame being prepared. The compute shader advances every
+	// instance's rotation by rotation speed × frameDelta after Flush uploads the dirty instances,
+	// and Flush mirrors that step on instanceData so later uploads of an instance keep its spin.
+	frameDelta float32
+
 	// needsRebuild is set to true when the instance capacity changes (e.g. via Grow) and GPU buffers need to be recreated to match the new capacity. This flag is checked by the render thread before rendering and triggers GPU resource reinitialization if set.
 	needsRebuild bool
 
@@ -181,6 +187,11 @@ type simpleAnimatorBackend interface {
 	//   - planes: the six frustum planes in GPU-aligned format
 	SetFrustumPlanes(planes [6]GPUFrustumPlane)
 
+	// DisableCulling turns GPU frustum culling off until the next SetFrustumPlanes call.
+	// Without culling, the compute shader writes every instance to the output slot matching
+	// its index, so individual instances can be drawn by index.
+	DisableCulling()
+
 	// SetBoundingRadius sets the object-space bounding sphere radius used for frustum culling.
 	//
 	// Parameters:
@@ -481,7 +492,13 @@ func (s *simpleAnimatorBackendImpl) SetMaxInstances(maxInstances uint32) {
 func (s *simpleAnimatorBackendImpl) Flush(instanceBinding, _, _ int) uint32 {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if len(s.dirtyIndices) == 0 || s.needsRebuild {
+	if s.needsRebuild {
+		return 0
+	}
+	// Runs after the dirty instances are copied to the staging buffer, so the uploads carry the
+	// rotation the compute shader starts this frame from.
+	defer s.advanceRotations()
+	if len(s.dirtyIndices) == 0 {
 		return 0
 	}
 
@@ -524,10 +541,16 @@ func (s *simpleAnimatorBackendImpl) PrepareFrame(deltaTime float32, binding int)
 		return
 	}
 
+	cullEnabled := uint32(0)
+	if s.cullingEnabled {
+		cullEnabled = 1
+	}
+	s.frameDelta = deltaTime
 	s.perFrameSlice[0] = GPUGlobalData{
 		InstanceCount:  s.instanceCount,
 		DeltaTime:      deltaTime,
 		BoundingRadius: s.boundingRadius,
+		CullEnabled:    cullEnabled,
 		Planes:         s.frustumPlanes,
 	}
 
@@ -543,6 +566,28 @@ func (s *simpleAnimatorBackendImpl) PrepareFrame(deltaTime float32, binding int)
 	})
 }
 
+// advanceRotations applies the rotation step of the compute shader to the CPU-side instance
+// data: each rotation advances by its speed times the frame delta and wraps to [0, 2π).
+// Runs once per prepared frame. Caller must hold s.mu.
+func (s *simpleAnimatorBackendImpl) advanceRotations() {
+	dt := s.frameDelta
+	s.frameDelta = 0
+	if dt == 0 {
+		return
+	}
+	const twoPi = 2 * math.Pi
+	for i := range s.instanceData[:s.instanceCount] {
+		d := &s.instanceData[i]
+		if d.RotSpeed == [3]float32{} {
+			continue
+		}
+		for j := range 3 {
+			r := (d.Rot[j] + d.RotSpeed[j]*dt) / twoPi
+			d.Rot[j] = (r - float32(math.Floor(float64(r)))) * twoPi
+		}
+	}
+}
+
 // enqueueDirty adds an instance index to the dirty queue if not already present.
 // Uses a bitset for O(1) dedup. Caller must hold s.mu.
 func (s *simpleAnimatorBackendImpl) enqueueDirty(index uint32) {
@@ -685,6 +730,12 @@ func (s *simpleAnimatorBackendImpl) SetFrustumPlanes(planes [6]GPUFrustumPlane)
 	s.cullingEnabled = true
 }
 
+func (s *simpleAnimatorBackendImpl) DisableCulling() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cullingEnabled = false
+}
+
 func (s *simpleAnimatorBackendImpl) SetBoundingRadius(radius float32) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
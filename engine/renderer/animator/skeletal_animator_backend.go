@@ -529,12 +529,17 @@ func (s *skeletalAnimatorBackendImpl) PrepareFrame(deltaTime float32, binding in
 		}
 	}
 
+	cullEnabled := uint32(0)
+	if s.cullingEnabled {
+		cullEnabled = 1
+	}
 	s.perFrameSlice[0] = GPUAnimationGlobals{
 		InstanceCount:      s.instanceCount,
 		BoneCount:          s.boneCount,
 		BoundingRadius:     s.boundingRadius,
 		ChannelDataOffset:  s.channelDataOffset,
 		KeyframeDataOffset: s.keyframeDataOffset,
+		CullEnabled:        cullEnabled,
 		Planes:             s.frustumPlanes,
 	}
 
@@ -1015,6 +1020,12 @@ func (s *skeletalAnimatorBackendImpl) SetFrustumPlanes(planes [6]GPUFrustumPlane
 	s.cullingEnabled = true
 }
 
+func (s *skeletalAnimatorBackendImpl) DisableCulling() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cullingEnabled = false
+}
+
 func (s *skeletalAnimatorBackendImpl) SetBoundingRadius(radius float32) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
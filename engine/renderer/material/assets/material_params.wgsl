@@ -0,0 +1,7 @@
+struct MaterialParams {
+    base_color:   vec4<f32>,
+    alpha_cutoff: f32,
+    alpha_mode:   u32,
+    _pad0:        u32,
+    _pad1:        u32,
+};
@@ -76,3 +76,60 @@ func (g *GPUEffectParams) Marshal() []byte {
 	binary.LittleEndian.PutUint32(buf[12:16], math.Float32bits(g.TintColor[3]))
 	return buf
 }
+
+// GPUMaterialParamsSource is the canonical WGSL definition of the MaterialParams struct.
+// Matches GPUMaterialParams layout exactly (32 bytes, std430 aligned).
+//
+//go:embed assets/material_params.wgsl
+var GPUMaterialParamsSource string
+
+// GPUMaterialParams is the GPU-aligned per-material uniform read by the lit fragment shaders.
+// It carries the base color factor and the alpha mode so the shader can apply the cutoff for
+// masked materials.
+// Matches the WGSL MaterialParams struct layout exactly (see GPUMaterialParamsSource).
+// Size: 32 bytes (vec4<f32> + f32 + u32 + 2 × u32 padding, std430 aligned).
+type GPUMaterialParams struct {
+	BaseColor   [4]float32 // offset 0: RGBA base color factor multiplied into the albedo (16 bytes)
+	AlphaCutoff float32    // offset 16: alpha threshold for AlphaModeMask
+	AlphaMode   uint32     // offset 20: 0 = opaque, 1 = mask, 2 = blend
+	_pad0       uint32     // offset 24
+	_pad1       uint32     // offset 28
+}
+
+// NewGPUMaterialParams builds the GPU uniform for the given material.
+//
+// Parameters:
+//   - m: the material to read the base color and alpha settings from
+//
+// Returns:
+//   - GPUMaterialParams: the populated uniform
+func NewGPUMaterialParams(m Material) GPUMaterialParams {
+	return GPUMaterialParams{
+		BaseColor:   m.BaseColor(),
+		AlphaCutoff: m.AlphaCutoff(),
+		AlphaMode:   uint32(m.AlphaMode()),
+	}
+}
+
+// Size returns the size of the GPUMaterialParams struct in bytes.
+//
+// Returns:
+//   - int: the size of the struct in bytes.
+func (g *GPUMaterialParams) Size() int {
+	return int(unsafe.Sizeof(*g))
+}
+
+// Marshal serializes the GPUMaterialParams struct into a byte buffer suitable for GPU upload.
+//
+// Returns:
+//   - []byte: 32-byte buffer ready for GPU upload.
+func (g *GPUMaterialParams) Marshal() []byte {
+	buf := make([]byte, 32)
+	binary.LittleEndian.PutUint32(buf[0:4], math.Float32bits(g.BaseColor[0]))
+	binary.LittleEndian.PutUint32(buf[4:8], math.Float32bits(g.BaseColor[1]))
+	binary.LittleEndian.PutUint32(buf[8:12], math.Float32bits(g.BaseColor[2]))
+	binary.LittleEndian.PutUint32(buf[12:16], math.Float32bits(g.BaseColor[3]))
+	binary.LittleEndian.PutUint32(buf[16:20], math.Float32bits(g.AlphaCutoff))
+	binary.LittleEndian.PutUint32(buf[20:24], g.AlphaMode)
+	return buf
+}
@@ -5,12 +5,33 @@ import (
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
 )
 
+// AlphaMode selects how a material's alpha channel is interpreted when drawing.
+// The values mirror the glTF alphaMode property.
+type AlphaMode uint32
+
+const (
+	// AlphaModeOpaque ignores alpha; the surface is fully opaque. This is the default.
+	AlphaModeOpaque AlphaMode = iota
+
+	// AlphaModeMask discards fragments whose alpha is below the material's alpha cutoff
+	// and renders the rest as opaque. Used for foliage, fences and decals.
+	AlphaModeMask
+
+	// AlphaModeBlend blends the surface over what is behind it. Blended materials are drawn
+	// after all opaque and masked geometry, either sorted back-to-front or through the
+	// weighted blended order-independent transparency pass.
+	AlphaModeBlend
+)
+
 // material is the implementation of the Material interface.
 type material struct {
 	name                     string
 	baseColor                [4]float32
 	metallic                 float32
 	roughness                float32
+	alphaMode                AlphaMode
+	alphaCutoff              float32
+	doubleSided              bool
 	diffuseTexture           *common.ImportedTexture
 	normalTexture            *common.ImportedTexture
 	metallicRoughnessTexture *common.ImportedTexture
@@ -52,6 +73,26 @@ type Material interface {
 	//   - float32: the roughness factor
 	Roughness() float32
 
+	// AlphaMode retrieves how the material's alpha channel is interpreted.
+	//
+	// Returns:
+	//   - AlphaMode: the alpha mode (opaque, mask or blend)
+	AlphaMode() AlphaMode
+
+	// AlphaCutoff retrieves the alpha threshold used by AlphaModeMask. Fragments with alpha
+	// below the cutoff are discarded. Ignored by the other alpha modes.
+	//
+	// Returns:
+	//   - float32: the alpha cutoff
+	AlphaCutoff() float32
+
+	// DoubleSided reports whether back faces of the material are rendered.
+	// Single-sided materials are drawn with back-face culling.
+	//
+	// Returns:
+	//   - bool: true if back-face culling is disabled for this material
+	DoubleSided() bool
+
 	// DiffuseTexture retrieves the diffuse/albedo texture data reference, or nil if none is set.
 	//
 	// Returns:
@@ -106,9 +147,11 @@ var _ Material = &material{}
 //   - Material: a new Material instance
 func NewMaterial(options ...MaterialBuilderOption) Material {
 	m := &material{
-		baseColor: [4]float32{1, 1, 1, 1},
-		metallic:  0.0,
-		roughness: 1.0,
+		baseColor:   [4]float32{1, 1, 1, 1},
+		metallic:    0.0,
+		roughness:   1.0,
+		alphaMode:   AlphaModeOpaque,
+		alphaCutoff: 0.5,
 	}
 	for _, opt := range options {
 		opt(m)
@@ -132,6 +175,18 @@ func (m *material) Roughness() float32 {
 	return m.roughness
 }
 
+func (m *material) AlphaMode() AlphaMode {
+	return m.alphaMode
+}
+
+func (m *material) AlphaCutoff() float32 {
+	return m.alphaCutoff
+}
+
+func (m *material) DoubleSided() bool {
+	return m.doubleSided
+}
+
 func (m *material) DiffuseTexture() *common.ImportedTexture {
 	return m.diffuseTexture
 }
@@ -60,6 +60,46 @@ func WithRoughness(roughness float32) MaterialBuilderOption {
 	}
 }
 
+// WithAlphaMode is an option builder that sets how the material's alpha channel is interpreted.
+//
+// Parameters:
+//   - mode: the alpha mode (AlphaModeOpaque, AlphaModeMask or AlphaModeBlend)
+//
+// Returns:
+//   - MaterialBuilderOption: a function that applies the alpha mode option to a material
+func WithAlphaMode(mode AlphaMode) MaterialBuilderOption {
+	return func(m *material) {
+		m.alphaMode = mode
+	}
+}
+
+// WithAlphaCutoff is an option builder that sets the alpha threshold used by AlphaModeMask.
+// Defaults to 0.5, matching the glTF default.
+//
+// Parameters:
+//   - cutoff: fragments with alpha below this value are discarded
+//
+// Returns:
+//   - MaterialBuilderOption: a function that applies the alpha cutoff option to a material
+func WithAlphaCutoff(cutoff float32) MaterialBuilderOption {
+	return func(m *material) {
+		m.alphaCutoff = cutoff
+	}
+}
+
+// WithDoubleSided is an option builder that controls whether back faces of the material are rendered.
+//
+// Parameters:
+//   - doubleSided: true to disable back-face culling for the material
+//
+// Returns:
+//   - MaterialBuilderOption: a function that applies the double-sided option to a material
+func WithDoubleSided(doubleSided bool) MaterialBuilderOption {
+	return func(m *material) {
+		m.doubleSided = doubleSided
+	}
+}
+
 // WithDiffuseTexture is an option builder that sets the diffuse/albedo texture reference.
 //
 // Parameters:
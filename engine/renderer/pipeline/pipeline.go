@@ -46,6 +46,10 @@ type pipeline struct {
 	frontFace           wgpu.FrontFace
 	writeMask           wgpu.ColorWriteMask
 	blendState          *wgpu.BlendState
+	fragmentEntryPoint  string
+	weightedBlended     bool
+	colorFormats        []wgpu.TextureFormat // nil targets the renderer's scene format
+	sampleCount         uint32               // 0 uses the renderer's MSAA sample count
 }
 
 // Pipeline defines the interface for a GPU pipeline, encapsulating either a render pipeline
@@ -140,6 +144,44 @@ type Pipeline interface {
 	//   - *wgpu.BlendState: the blend state for this pipeline, or nil if blending is not enabled
 	BlendState() *wgpu.BlendState
 
+	// FragmentEntryPoint returns the fragment entry point override for this pipeline.
+	//
+	// Returns:
+	//   - string: the fragment entry point name, or empty to use the fragment shader's default entry point
+	FragmentEntryPoint() string
+
+	// WeightedBlended returns whether this pipeline renders into the weighted blended
+	// order-independent transparency targets instead of the scene color target.
+	//
+	// Returns:
+	//   - bool: true if the pipeline writes accumulation and revealage outputs
+	WeightedBlended() bool
+
+	// ColorFormats returns the color target formats this pipeline renders into, for pipelines
+	// drawn in render passes other than the main frame pass.
+	//
+	// Returns:
+	//   - []wgpu.TextureFormat: the color target formats, empty for a depth-only pipeline, or nil to render into the renderer's scene color format
+	ColorFormats() []wgpu.TextureFormat
+
+	// SampleCount returns the multisample count this pipeline renders with.
+	//
+	// Returns:
+	//   - uint32: the sample count, or 0 to use the renderer's MSAA sample count
+	SampleCount() uint32
+
+	// Derive creates a new, unregistered Pipeline that copies this pipeline's shaders and
+	// configuration and then applies the given options on top. Used to build variants of a
+	// pipeline that differ only in fixed-function state (blend, depth write, cull mode).
+	//
+	// Parameters:
+	//   - pipelineKey: the unique key for the derived pipeline
+	//   - opts: a variadic list of PipelineBuilderOption functions applied after the copied configuration
+	//
+	// Returns:
+	//   - Pipeline: the derived pipeline, which must be registered before use
+	Derive(pipelineKey string, opts ...PipelineBuilderOption) Pipeline
+
 	// SetRenderPipeline sets the render pipeline
 	//
 	// Parameters:
@@ -253,6 +295,40 @@ func (p *pipeline) BlendState() *wgpu.BlendState {
 	return p.blendState
 }
 
+func (p *pipeline) FragmentEntryPoint() string {
+	return p.fragmentEntryPoint
+}
+
+func (p *pipeline) WeightedBlended() bool {
+	return p.weightedBlended
+}
+
+func (p *pipeline) ColorFormats() []wgpu.TextureFormat {
+	return p.colorFormats
+}
+
+func (p *pipeline) SampleCount() uint32 {
+	return p.sampleCount
+}
+
+func (p *pipeline) Derive(pipelineKey string, opts ...PipelineBuilderOption) Pipeline {
+	d := *p
+	d.pipelineKey = pipelineKey
+	d.renderPipeline = nil
+	d.computePipeline = nil
+	if p.blendState != nil {
+		bs := *p.blendState
+		d.blendState = &bs
+	}
+	if p.colorFormats != nil {
+		d.colorFormats = append([]wgpu.TextureFormat{}, p.colorFormats...)
+	}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	return &d
+}
+
 func (p *pipeline) Shader(shaderType shader.ShaderType) shader.Shader {
 	switch shaderType {
 	case shader.ShaderTypeVertex:
@@ -165,3 +165,64 @@ func WithBlendState(blendState *wgpu.BlendState) PipelineBuilderOption {
 		p.blendState = blendState
 	}
 }
+
+// WithFragmentEntryPoint overrides the fragment shader entry point used by this pipeline. Useful
+// when one fragment shader declares several entry points, such as a forward output and a weighted
+// blended transparency output.
+//
+// Parameters:
+//   - entryPoint: the fragment entry point function name (empty uses the shader's first @fragment function)
+//
+// Returns:
+//   - PipelineBuilderOption: a function that sets the fragment entry point for this pipeline
+func WithFragmentEntryPoint(entryPoint string) PipelineBuilderOption {
+	return func(p *pipeline) {
+		p.fragmentEntryPoint = entryPoint
+	}
+}
+
+// WithWeightedBlended configures this pipeline to render into the weighted blended order-independent
+// transparency targets: an additive RGBA16Float accumulation target and a multiplicative R8Unorm
+// revealage target. The fragment entry point must write both outputs. Blend state and write mask
+// settings are ignored when enabled.
+//
+// Parameters:
+//   - enabled: true to render into the weighted blended transparency targets
+//
+// Returns:
+//   - PipelineBuilderOption: a function that sets the weighted blended flag for this pipeline
+func WithWeightedBlended(enabled bool) PipelineBuilderOption {
+	return func(p *pipeline) {
+		p.weightedBlended = enabled
+	}
+}
+
+// WithColorFormats sets the color target formats this pipeline renders into, replacing the
+// renderer's scene color format. Used for pipelines drawn in render graph passes whose
+// attachments differ from the main frame pass. Every target uses the pipeline's write mask
+// and, when blending is enabled, its blend state. Pass no formats for a depth-only pipeline.
+//
+// Parameters:
+//   - formats: the color attachment formats in attachment order
+//
+// Returns:
+//   - PipelineBuilderOption: a function that sets the color target formats for this pipeline
+func WithColorFormats(formats ...wgpu.TextureFormat) PipelineBuilderOption {
+	return func(p *pipeline) {
+		p.colorFormats = append([]wgpu.TextureFormat{}, formats...)
+	}
+}
+
+// WithSampleCount sets the multisample count this pipeline renders with, replacing the
+// renderer's MSAA sample count. Must match the sample count of the attachments it draws into.
+//
+// Parameters:
+//   - count: the sample count (1 for no multisampling)
+//
+// Returns:
+//   - PipelineBuilderOption: a function that sets the sample count for this pipeline
+func WithSampleCount(count uint32) PipelineBuilderOption {
+	return func(p *pipeline) {
+		p.sampleCount = count
+	}
+}
@@ -0,0 +1,163 @@
+package renderer
+
+import (
+	"sync"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+)
+
+// PostPass is a single fullscreen pass of a PostEffect. The renderer draws a fullscreen
+// triangle with the shared post vertex shader and runs Shader over every pixel of the pass
+// target. The fragment shader receives its inputs through @oxy:provider annotations with the
+// post_process identity and a post_* binding role (see README_POST_PROCESS.md).
+type PostPass struct {
+	// Shader is the fragment shader of the pass. Its key must be unique among post shaders,
+	// as pipelines are cached by shader key.
+	Shader shader.Shader
+
+	// Scale is the pass target resolution relative to the frame, e.g. 0.5 for half resolution.
+	// Zero is treated as 1.
+	Scale float32
+}
+
+// postEffect is the implementation of the PostEffect interface.
+type postEffect struct {
+	mu      *sync.Mutex
+	name    string
+	enabled bool
+	passes  []PostPass
+	params  []byte
+	texture *common.TextureStagingData
+}
+
+// PostEffect is an ordered list of fullscreen passes applied to the rendered frame after the
+// main render pass. Effects are chained by the Renderer: the first pass of the first enabled
+// effect reads the scene color target and every pass reads the output of the one before it.
+//
+// Each effect owns an optional parameter block, uploaded to a uniform buffer and bound to
+// post_params bindings, and an optional RGBA8 texture bound to post_texture bindings. Both may
+// be changed at any time; the renderer uploads them before the next frame that uses them.
+type PostEffect interface {
+	// Name returns the effect name, used to find and remove the effect on a Renderer.
+	//
+	// Returns:
+	//   - string: the effect name
+	Name() string
+
+	// Enabled returns whether the effect runs.
+	//
+	// Returns:
+	//   - bool: true if the effect is applied to the frame
+	Enabled() bool
+
+	// SetEnabled turns the effect on or off. Disabled effects are skipped by the chain.
+	//
+	// Parameters:
+	//   - enabled: true to apply the effect
+	SetEnabled(enabled bool)
+
+	// Passes returns the fullscreen passes of the effect in the order they run.
+	//
+	// Returns:
+	//   - []PostPass: the passes
+	Passes() []PostPass
+
+	// Params returns a copy of the effect's parameter bytes.
+	//
+	// Returns:
+	//   - []byte: the parameter block, or nil if the effect has none
+	Params() []byte
+
+	// SetParams replaces the effect's parameter bytes. The block must match the layout of the
+	// uniform struct bound with the post_params role.
+	//
+	// Parameters:
+	//   - data: the new parameter block
+	SetParams(data []byte)
+
+	// Texture returns the effect's texture data.
+	//
+	// Returns:
+	//   - *common.TextureStagingData: the texture, or nil if the effect has none
+	Texture() *common.TextureStagingData
+
+	// SetTexture replaces the effect's texture. The pixels are uploaded as RGBA8Unorm (not sRGB).
+	//
+	// Parameters:
+	//   - data: the RGBA pixel data and dimensions
+	SetTexture(data common.TextureStagingData)
+}
+
+var _ PostEffect = &postEffect{}
+
+// NewPostEffect creates a new PostEffect with the given name, configured with the given options.
+// Effects are enabled by default and must have at least one pass.
+//
+// Parameters:
+//   - name: the effect name
+//   - options: variadic list of PostEffectBuilderOption functions to configure the effect
+//
+// Returns:
+//   - PostEffect: the newly created effect
+func NewPostEffect(name string, options ...PostEffectBuilderOption) PostEffect {
+	e := &postEffect{
+		mu:      &sync.Mutex{},
+		name:    name,
+		enabled: true,
+	}
+	for _, opt := range options {
+		opt(e)
+	}
+	if len(e.passes) == 0 {
+		panic("renderer: post effect " + name + " must have at least one pass")
+	}
+	return e
+}
+
+func (e *postEffect) Name() string {
+	return e.name
+}
+
+func (e *postEffect) Enabled() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.enabled
+}
+
+func (e *postEffect) SetEnabled(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.enabled = enabled
+}
+
+func (e *postEffect) Passes() []PostPass {
+	return e.passes
+}
+
+func (e *postEffect) Params() []byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.params == nil {
+		return nil
+	}
+	return append([]byte(nil), e.params...)
+}
+
+func (e *postEffect) SetParams(data []byte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params = append([]byte(nil), data...)
+}
+
+func (e *postEffect) Texture() *common.TextureStagingData {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.texture
+}
+
+func (e *postEffect) SetTexture(data common.TextureStagingData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.texture = &data
+}
@@ -0,0 +1,62 @@
+package renderer
+
+import (
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+)
+
+// PostEffectBuilderOption is a functional option applied to a post effect during construction via NewPostEffect.
+type PostEffectBuilderOption func(*postEffect)
+
+// WithPostPass appends a fullscreen pass to the effect. Passes run in the order they are added.
+//
+// Parameters:
+//   - fragment: the fragment shader of the pass
+//   - scale: the pass resolution relative to the frame (0 or 1 for full resolution)
+//
+// Returns:
+//   - PostEffectBuilderOption: a function that applies the pass option to a post effect
+func WithPostPass(fragment shader.Shader, scale float32) PostEffectBuilderOption {
+	return func(e *postEffect) {
+		e.passes = append(e.passes, PostPass{Shader: fragment, Scale: scale})
+	}
+}
+
+// WithPostParams sets the initial parameter block bound to post_params bindings.
+//
+// Parameters:
+//   - data: the parameter bytes, laid out like the WGSL uniform struct
+//
+// Returns:
+//   - PostEffectBuilderOption: a function that applies the params option to a post effect
+func WithPostParams(data []byte) PostEffectBuilderOption {
+	return func(e *postEffect) {
+		e.params = append([]byte(nil), data...)
+	}
+}
+
+// WithPostTexture sets the initial texture bound to post_texture bindings.
+//
+// Parameters:
+//   - data: the RGBA pixel data and dimensions
+//
+// Returns:
+//   - PostEffectBuilderOption: a function that applies the texture option to a post effect
+func WithPostTexture(data common.TextureStagingData) PostEffectBuilderOption {
+	return func(e *postEffect) {
+		e.texture = &data
+	}
+}
+
+// WithPostEnabled sets whether the effect starts enabled (default true).
+//
+// Parameters:
+//   - enabled: false to add the effect in a disabled state
+//
+// Returns:
+//   - PostEffectBuilderOption: a function that applies the enabled option to a post effect
+func WithPostEnabled(enabled bool) PostEffectBuilderOption {
+	return func(e *postEffect) {
+		e.enabled = enabled
+	}
+}
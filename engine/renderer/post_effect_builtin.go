@@ -0,0 +1,528 @@
+package renderer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/post_process"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+)
+
+// TonemapOperator selects the curve used by the tonemapping effect to map HDR color to display range.
+type TonemapOperator uint32
+
+const (
+	// TonemapACES uses the Narkowicz fit of the ACES filmic curve. This is the default.
+	TonemapACES TonemapOperator = iota
+
+	// TonemapReinhard uses the extended Reinhard curve, mapping the white point to pure white.
+	TonemapReinhard
+
+	// TonemapNone applies exposure only and clamps to [0, 1].
+	TonemapNone
+)
+
+// TonemapEffect is a PostEffect that maps the HDR scene color to display range. It should run
+// after effects that work on HDR color (such as bloom) and before effects that expect display
+// range color (such as FXAA and color grading).
+type TonemapEffect interface {
+	PostEffect
+
+	// Exposure returns the linear exposure multiplier.
+	//
+	// Returns:
+	//   - float32: the exposure
+	Exposure() float32
+
+	// SetExposure sets the linear exposure multiplier applied before the curve.
+	//
+	// Parameters:
+	//   - exposure: the exposure (1 leaves the scene color unchanged)
+	SetExposure(exposure float32)
+
+	// Operator returns the tonemapping curve.
+	//
+	// Returns:
+	//   - TonemapOperator: the curve
+	Operator() TonemapOperator
+
+	// SetOperator sets the tonemapping curve.
+	//
+	// Parameters:
+	//   - op: the curve
+	SetOperator(op TonemapOperator)
+
+	// WhitePoint returns the input value mapped to pure white by TonemapReinhard.
+	//
+	// Returns:
+	//   - float32: the white point
+	WhitePoint() float32
+
+	// SetWhitePoint sets the input value mapped to pure white by TonemapReinhard.
+	//
+	// Parameters:
+	//   - white: the white point
+	SetWhitePoint(white float32)
+}
+
+// tonemapEffect is the implementation of the TonemapEffect interface.
+type tonemapEffect struct {
+	PostEffect
+	mu     *sync.Mutex
+	params post_process.GPUTonemapParams
+}
+
+var _ TonemapEffect = &tonemapEffect{}
+
+// NewTonemapEffect creates a tonemapping effect named "tonemap" with a white point of 4.
+//
+// Parameters:
+//   - op: the tonemapping curve
+//   - exposure: the linear exposure multiplier
+//
+// Returns:
+//   - TonemapEffect: the effect
+func NewTonemapEffect(op TonemapOperator, exposure float32) TonemapEffect {
+	e := &tonemapEffect{
+		mu: &sync.Mutex{},
+		params: post_process.GPUTonemapParams{
+			Exposure:   exposure,
+			Operator:   uint32(op),
+			WhitePoint: 4,
+		},
+	}
+	e.PostEffect = NewPostEffect("tonemap",
+		WithPostPass(shader.NewShaderFromSource("post_tonemap", shader.ShaderTypeFragment, post_process.TonemapShaderSource), 1),
+		WithPostParams(e.params.Marshal()),
+	)
+	return e
+}
+
+func (e *tonemapEffect) Exposure() float32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params.Exposure
+}
+
+func (e *tonemapEffect) SetExposure(exposure float32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.Exposure = exposure
+	e.SetParams(e.params.Marshal())
+}
+
+func (e *tonemapEffect) Operator() TonemapOperator {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return TonemapOperator(e.params.Operator)
+}
+
+func (e *tonemapEffect) SetOperator(op TonemapOperator) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.Operator = uint32(op)
+	e.SetParams(e.params.Marshal())
+}
+
+func (e *tonemapEffect) WhitePoint() float32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params.WhitePoint
+}
+
+func (e *tonemapEffect) SetWhitePoint(white float32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.WhitePoint = white
+	e.SetParams(e.params.Marshal())
+}
+
+// BloomEffect is a PostEffect that makes bright areas glow. It extracts pixels above a
+// threshold at half resolution, blurs them with a separable Gaussian and adds the result back
+// onto the image. Run it on HDR color, before tonemapping.
+type BloomEffect interface {
+	PostEffect
+
+	// Threshold returns the brightness above which pixels bloom.
+	//
+	// Returns:
+	//   - float32: the threshold
+	Threshold() float32
+
+	// SetThreshold sets the brightness above which pixels bloom.
+	//
+	// Parameters:
+	//   - threshold: the threshold, in scene color units
+	SetThreshold(threshold float32)
+
+	// Knee returns the width of the soft transition around the threshold.
+	//
+	// Returns:
+	//   - float32: the knee
+	Knee() float32
+
+	// SetKnee sets the width of the soft transition around the threshold.
+	//
+	// Parameters:
+	//   - knee: the knee (0 for a hard cut-off)
+	SetKnee(knee float32)
+
+	// Intensity returns the strength of the bloom added to the image.
+	//
+	// Returns:
+	//   - float32: the intensity
+	Intensity() float32
+
+	// SetIntensity sets the strength of the bloom added to the image.
+	//
+	// Parameters:
+	//   - intensity: the intensity
+	SetIntensity(intensity float32)
+
+	// Radius returns the blur tap spacing.
+	//
+	// Returns:
+	//   - float32: the radius, in half-resolution texels
+	Radius() float32
+
+	// SetRadius sets the blur tap spacing. Larger values spread the glow further.
+	//
+	// Parameters:
+	//   - radius: the radius, in half-resolution texels
+	SetRadius(radius float32)
+}
+
+// bloomEffect is the implementation of the BloomEffect interface.
+type bloomEffect struct {
+	PostEffect
+	mu     *sync.Mutex
+	params post_process.GPUBloomParams
+}
+
+var _ BloomEffect = &bloomEffect{}
+
+// NewBloomEffect creates a bloom effect named "bloom" with a knee of half the threshold and a
+// radius of 1. It runs four passes: a half-resolution bright pass, horizontal and vertical
+// blurs, and a full-resolution composite.
+//
+// Parameters:
+//   - threshold: the brightness above which pixels bloom
+//   - intensity: the strength of the bloom added to the image
+//
+// Returns:
+//   - BloomEffect: the effect
+func NewBloomEffect(threshold, intensity float32) BloomEffect {
+	e := &bloomEffect{
+		mu: &sync.Mutex{},
+		params: post_process.GPUBloomParams{
+			Threshold: threshold,
+			Knee:      threshold * 0.5,
+			Intensity: intensity,
+			Radius:    1,
+		},
+	}
+	e.PostEffect = NewPostEffect("bloom",
+		WithPostPass(shader.NewShaderFromSource("post_bloom_prefilter", shader.ShaderTypeFragment, post_process.BloomPrefilterShaderSource), 0.5),
+		WithPostPass(shader.NewShaderFromSource("post_bloom_blur_h", shader.ShaderTypeFragment, post_process.BloomBlurHShaderSource), 0.5),
+		WithPostPass(shader.NewShaderFromSource("post_bloom_blur_v", shader.ShaderTypeFragment, post_process.BloomBlurVShaderSource), 0.5),
+		WithPostPass(shader.NewShaderFromSource("post_bloom_composite", shader.ShaderTypeFragment, post_process.BloomCompositeShaderSource), 1),
+		WithPostParams(e.params.Marshal()),
+	)
+	return e
+}
+
+func (e *bloomEffect) Threshold() float32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params.Threshold
+}
+
+func (e *bloomEffect) SetThreshold(threshold float32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.Threshold = threshold
+	e.SetParams(e.params.Marshal())
+}
+
+func (e *bloomEffect) Knee() float32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params.Knee
+}
+
+func (e *bloomEffect) SetKnee(knee float32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.Knee = knee
+	e.SetParams(e.params.Marshal())
+}
+
+func (e *bloomEffect) Intensity() float32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params.Intensity
+}
+
+func (e *bloomEffect) SetIntensity(intensity float32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.Intensity = intensity
+	e.SetParams(e.params.Marshal())
+}
+
+func (e *bloomEffect) Radius() float32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params.Radius
+}
+
+func (e *bloomEffect) SetRadius(radius float32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.Radius = radius
+	e.SetParams(e.params.Marshal())
+}
+
+// FXAAEffect is a PostEffect that applies fast approximate anti-aliasing. Run it on display
+// range color, after tonemapping.
+type FXAAEffect interface {
+	PostEffect
+
+	// EdgeThreshold returns the minimum contrast, relative to the brightest neighbour, treated as an edge.
+	//
+	// Returns:
+	//   - float32: the relative threshold
+	EdgeThreshold() float32
+
+	// SetEdgeThreshold sets the minimum contrast, relative to the brightest neighbour, treated as an edge.
+	// Lower values smooth more edges at the cost of blurring detail.
+	//
+	// Parameters:
+	//   - threshold: the relative threshold (default 0.166)
+	SetEdgeThreshold(threshold float32)
+
+	// EdgeThresholdMin returns the absolute contrast below which pixels are skipped.
+	//
+	// Returns:
+	//   - float32: the absolute threshold
+	EdgeThresholdMin() float32
+
+	// SetEdgeThresholdMin sets the absolute contrast below which pixels are skipped, so dark areas are left alone.
+	//
+	// Parameters:
+	//   - threshold: the absolute threshold (default 0.0833)
+	SetEdgeThresholdMin(threshold float32)
+
+	// Subpixel returns the amount of sub-pixel aliasing removal.
+	//
+	// Returns:
+	//   - float32: the amount in [0, 1]
+	Subpixel() float32
+
+	// SetSubpixel sets the amount of sub-pixel aliasing removal.
+	//
+	// Parameters:
+	//   - amount: the amount in [0, 1] (default 0.75)
+	SetSubpixel(amount float32)
+}
+
+// fxaaEffect is the implementation of the FXAAEffect interface.
+type fxaaEffect struct {
+	PostEffect
+	mu     *sync.Mutex
+	params post_process.GPUFXAAParams
+}
+
+var _ FXAAEffect = &fxaaEffect{}
+
+// NewFXAAEffect creates an FXAA effect named "fxaa" with the default quality settings.
+//
+// Returns:
+//   - FXAAEffect: the effect
+func NewFXAAEffect() FXAAEffect {
+	e := &fxaaEffect{
+		mu: &sync.Mutex{},
+		params: post_process.GPUFXAAParams{
+			EdgeThreshold:    0.166,
+			EdgeThresholdMin: 0.0833,
+			Subpixel:         0.75,
+		},
+	}
+	e.PostEffect = NewPostEffect("fxaa",
+		WithPostPass(shader.NewShaderFromSource("post_fxaa", shader.ShaderTypeFragment, post_process.FXAAShaderSource), 1),
+		WithPostParams(e.params.Marshal()),
+	)
+	return e
+}
+
+func (e *fxaaEffect) EdgeThreshold() float32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params.EdgeThreshold
+}
+
+func (e *fxaaEffect) SetEdgeThreshold(threshold float32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.EdgeThreshold = threshold
+	e.SetParams(e.params.Marshal())
+}
+
+func (e *fxaaEffect) EdgeThresholdMin() float32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params.EdgeThresholdMin
+}
+
+func (e *fxaaEffect) SetEdgeThresholdMin(threshold float32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.EdgeThresholdMin = threshold
+	e.SetParams(e.params.Marshal())
+}
+
+func (e *fxaaEffect) Subpixel() float32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params.Subpixel
+}
+
+func (e *fxaaEffect) SetSubpixel(amount float32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.Subpixel = amount
+	e.SetParams(e.params.Marshal())
+}
+
+// ColorGradeEffect is a PostEffect that remaps color through a 3D lookup table. The LUT is an
+// RGBA strip of N slices of N x N texels (N*N wide, N high), authored in sRGB: red increases
+// along x within a slice, green along y and blue from slice to slice. Run it on display range
+// color, after tonemapping.
+type ColorGradeEffect interface {
+	PostEffect
+
+	// SetLUT replaces the lookup table.
+	//
+	// Parameters:
+	//   - lut: the LUT strip, N*N x N RGBA pixels
+	//
+	// Returns:
+	//   - error: an error if the LUT dimensions are not N*N x N
+	SetLUT(lut common.TextureStagingData) error
+
+	// Intensity returns the blend between the original and graded color.
+	//
+	// Returns:
+	//   - float32: the intensity in [0, 1]
+	Intensity() float32
+
+	// SetIntensity sets the blend between the original (0) and graded (1) color.
+	//
+	// Parameters:
+	//   - intensity: the intensity in [0, 1]
+	SetIntensity(intensity float32)
+}
+
+// colorGradeEffect is the implementation of the ColorGradeEffect interface.
+type colorGradeEffect struct {
+	PostEffect
+	mu     *sync.Mutex
+	params post_process.GPUColorGradeParams
+}
+
+var _ ColorGradeEffect = &colorGradeEffect{}
+
+// NewColorGradeEffect creates a color grading effect named "color_grade" at full intensity.
+// Use IdentityLUT as a starting point for authoring.
+//
+// Parameters:
+//   - lut: the LUT strip, N*N x N RGBA pixels
+//
+// Returns:
+//   - ColorGradeEffect: the effect
+//   - error: an error if the LUT dimensions are not N*N x N
+func NewColorGradeEffect(lut common.TextureStagingData) (ColorGradeEffect, error) {
+	if err := validateLUT(lut); err != nil {
+		return nil, err
+	}
+	e := &colorGradeEffect{
+		mu: &sync.Mutex{},
+		params: post_process.GPUColorGradeParams{
+			LUTSize:   float32(lut.Height),
+			Intensity: 1,
+		},
+	}
+	e.PostEffect = NewPostEffect("color_grade",
+		WithPostPass(shader.NewShaderFromSource("post_color_grade", shader.ShaderTypeFragment, post_process.ColorGradeShaderSource), 1),
+		WithPostParams(e.params.Marshal()),
+		WithPostTexture(lut),
+	)
+	return e, nil
+}
+
+func (e *colorGradeEffect) SetLUT(lut common.TextureStagingData) error {
+	if err := validateLUT(lut); err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.LUTSize = float32(lut.Height)
+	e.SetTexture(lut)
+	e.SetParams(e.params.Marshal())
+	return nil
+}
+
+func (e *colorGradeEffect) Intensity() float32 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.params.Intensity
+}
+
+func (e *colorGradeEffect) SetIntensity(intensity float32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.params.Intensity = intensity
+	e.SetParams(e.params.Marshal())
+}
+
+// IdentityLUT builds a color grading LUT strip that leaves colors unchanged. Edit its pixels,
+// or export it to an image editor and grade it there, to author a custom look.
+//
+// Parameters:
+//   - size: the LUT edge length N (16 or 32 are typical)
+//
+// Returns:
+//   - common.TextureStagingData: the N*N x N RGBA strip
+func IdentityLUT(size int) common.TextureStagingData {
+	width := size * size
+	pixels := make([]byte, width*size*4)
+	scale := 255 / float32(size-1)
+	for g := range size {
+		for b := range size {
+			for r := range size {
+				i := (g*width + b*size + r) * 4
+				pixels[i] = byte(float32(r)*scale + 0.5)
+				pixels[i+1] = byte(float32(g)*scale + 0.5)
+				pixels[i+2] = byte(float32(b)*scale + 0.5)
+				pixels[i+3] = 255
+			}
+		}
+	}
+	return common.TextureStagingData{
+		Pixels: pixels,
+		Width:  uint32(width),
+		Height: uint32(size),
+	}
+}
+
+// validateLUT checks that a LUT strip is N*N x N with N >= 2 and holds enough pixel data.
+func validateLUT(lut common.TextureStagingData) error {
+	if lut.Height < 2 || lut.Width != lut.Height*lut.Height {
+		return fmt.Errorf("renderer: color grade LUT must be N*N x N pixels, got %dx%d", lut.Width, lut.Height)
+	}
+	if len(lut.Pixels) < int(lut.Width*lut.Height*4) {
+		return fmt.Errorf("renderer: color grade LUT has %d bytes, need %d", len(lut.Pixels), lut.Width*lut.Height*4)
+	}
+	return nil
+}
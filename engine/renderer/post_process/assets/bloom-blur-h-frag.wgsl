@@ -0,0 +1,34 @@
+// Bloom horizontal blur pass
+//
+// Separable 9-tap Gaussian blur along the horizontal axis, sampled with five
+// bilinear taps. The radius parameter scales the tap spacing.
+
+struct FragmentInput {
+    @location(0) uv: vec2<f32>,
+};
+
+//@oxy:provider 0 0 post_process post_input
+@group(0) @binding(0) var input_texture: texture_2d<f32>;
+//@oxy:provider 0 1 post_process post_sampler
+@group(0) @binding(1) var input_sampler: sampler;
+
+//@oxy:include bloom_params
+//@oxy:provider 0 2 post_process post_params
+@group(0) @binding(2) var<uniform> params: BloomParams;
+
+const direction = vec2<f32>(1.0, 0.0);
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    let texel_step = direction * params.radius / vec2<f32>(textureDimensions(input_texture));
+
+    let near = texel_step * 1.3846153846;
+    let far = texel_step * 3.2307692308;
+
+    var color = textureSample(input_texture, input_sampler, in.uv).rgb * 0.2270270270;
+    color += textureSample(input_texture, input_sampler, in.uv + near).rgb * 0.3162162162;
+    color += textureSample(input_texture, input_sampler, in.uv - near).rgb * 0.3162162162;
+    color += textureSample(input_texture, input_sampler, in.uv + far).rgb * 0.0702702703;
+    color += textureSample(input_texture, input_sampler, in.uv - far).rgb * 0.0702702703;
+    return vec4<f32>(color, 1.0);
+}
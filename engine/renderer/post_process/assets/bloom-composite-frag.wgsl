@@ -0,0 +1,26 @@
+// Bloom composite pass
+//
+// Upsamples the blurred bright pass and adds it back onto the effect's source
+// image, scaled by the intensity.
+
+struct FragmentInput {
+    @location(0) uv: vec2<f32>,
+};
+
+//@oxy:provider 0 0 post_process post_input
+@group(0) @binding(0) var bloom_texture: texture_2d<f32>;
+//@oxy:provider 0 1 post_process post_sampler
+@group(0) @binding(1) var input_sampler: sampler;
+//@oxy:provider 0 2 post_process post_source
+@group(0) @binding(2) var source_texture: texture_2d<f32>;
+
+//@oxy:include bloom_params
+//@oxy:provider 0 3 post_process post_params
+@group(0) @binding(3) var<uniform> params: BloomParams;
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    let source = textureSample(source_texture, input_sampler, in.uv);
+    let bloom = textureSample(bloom_texture, input_sampler, in.uv).rgb;
+    return vec4<f32>(source.rgb + bloom * params.intensity, source.a);
+}
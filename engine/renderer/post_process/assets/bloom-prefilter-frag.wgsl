@@ -0,0 +1,37 @@
+// Bloom prefilter pass
+//
+// Runs at half resolution. Downsamples the input with a 4x4 box filter (four
+// bilinear taps) and keeps only the energy above the threshold, with a soft knee
+// so the cut-off does not produce hard edges.
+
+struct FragmentInput {
+    @location(0) uv: vec2<f32>,
+};
+
+//@oxy:provider 0 0 post_process post_input
+@group(0) @binding(0) var input_texture: texture_2d<f32>;
+//@oxy:provider 0 1 post_process post_sampler
+@group(0) @binding(1) var input_sampler: sampler;
+
+//@oxy:include bloom_params
+//@oxy:provider 0 2 post_process post_params
+@group(0) @binding(2) var<uniform> params: BloomParams;
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    let texel = 1.0 / vec2<f32>(textureDimensions(input_texture));
+
+    var color = textureSample(input_texture, input_sampler, in.uv + vec2<f32>(-texel.x, -texel.y)).rgb;
+    color += textureSample(input_texture, input_sampler, in.uv + vec2<f32>(texel.x, -texel.y)).rgb;
+    color += textureSample(input_texture, input_sampler, in.uv + vec2<f32>(-texel.x, texel.y)).rgb;
+    color += textureSample(input_texture, input_sampler, in.uv + vec2<f32>(texel.x, texel.y)).rgb;
+    color *= 0.25;
+
+    let brightness = max(color.r, max(color.g, color.b));
+    let knee = max(params.knee, 1e-4);
+    var soft = clamp(brightness - params.threshold + knee, 0.0, 2.0 * knee);
+    soft = soft * soft / (4.0 * knee);
+    let contribution = max(soft, brightness - params.threshold) / max(brightness, 1e-4);
+
+    return vec4<f32>(color * contribution, 1.0);
+}
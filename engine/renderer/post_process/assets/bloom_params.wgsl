@@ -0,0 +1,6 @@
+struct BloomParams {
+    threshold: f32,
+    knee: f32,
+    intensity: f32,
+    radius: f32,
+};
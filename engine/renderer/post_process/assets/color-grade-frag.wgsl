@@ -0,0 +1,54 @@
+// Color grading post effect
+//
+// Remaps colour through a 3D lookup table stored as a horizontal strip of N
+// slices, each N x N, giving an N*N x N texture. Red runs along x within a slice,
+// green along y, and blue selects the slice. The LUT is authored in sRGB, so the
+// linear input is encoded before the lookup and decoded afterwards. Two slices
+// are sampled and blended to interpolate along blue.
+
+struct FragmentInput {
+    @location(0) uv: vec2<f32>,
+};
+
+//@oxy:provider 0 0 post_process post_input
+@group(0) @binding(0) var input_texture: texture_2d<f32>;
+//@oxy:provider 0 1 post_process post_sampler
+@group(0) @binding(1) var input_sampler: sampler;
+//@oxy:provider 0 2 post_process post_texture
+@group(0) @binding(2) var lut_texture: texture_2d<f32>;
+
+//@oxy:include color_grade_params
+//@oxy:provider 0 3 post_process post_params
+@group(0) @binding(3) var<uniform> params: ColorGradeParams;
+
+fn linear_to_srgb(c: vec3<f32>) -> vec3<f32> {
+    let low = c * 12.92;
+    let high = 1.055 * pow(c, vec3<f32>(1.0 / 2.4)) - 0.055;
+    return select(high, low, c <= vec3<f32>(0.0031308));
+}
+
+fn srgb_to_linear(c: vec3<f32>) -> vec3<f32> {
+    let low = c / 12.92;
+    let high = pow((c + 0.055) / 1.055, vec3<f32>(2.4));
+    return select(high, low, c <= vec3<f32>(0.04045));
+}
+
+fn lut_lookup(c: vec3<f32>) -> vec3<f32> {
+    let size = max(params.lut_size, 2.0);
+    let scaled = clamp(c, vec3<f32>(0.0), vec3<f32>(1.0)) * (size - 1.0);
+    let slice = floor(scaled.b);
+    let next = min(slice + 1.0, size - 1.0);
+    let width = size * size;
+
+    let y = (scaled.g + 0.5) / size;
+    let a = textureSampleLevel(lut_texture, input_sampler, vec2<f32>((slice * size + scaled.r + 0.5) / width, y), 0.0).rgb;
+    let b = textureSampleLevel(lut_texture, input_sampler, vec2<f32>((next * size + scaled.r + 0.5) / width, y), 0.0).rgb;
+    return mix(a, b, scaled.b - slice);
+}
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    let color = textureSample(input_texture, input_sampler, in.uv);
+    let graded = srgb_to_linear(lut_lookup(linear_to_srgb(clamp(color.rgb, vec3<f32>(0.0), vec3<f32>(1.0)))));
+    return vec4<f32>(mix(color.rgb, graded, params.intensity), color.a);
+}
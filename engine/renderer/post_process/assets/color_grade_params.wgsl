@@ -0,0 +1,6 @@
+struct ColorGradeParams {
+    lut_size: f32,
+    intensity: f32,
+    _pad0: f32,
+    _pad1: f32,
+};
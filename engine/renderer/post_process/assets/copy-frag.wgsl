@@ -0,0 +1,19 @@
+// Copy post pass
+//
+// Samples the previous output and writes it unchanged. Used to move the last
+// effect's output onto the frame target when their sizes or formats differ, and
+// to present the HDR scene target when no effects are enabled.
+
+struct FragmentInput {
+    @location(0) uv: vec2<f32>,
+};
+
+//@oxy:provider 0 0 post_process post_input
+@group(0) @binding(0) var input_texture: texture_2d<f32>;
+//@oxy:provider 0 1 post_process post_sampler
+@group(0) @binding(1) var input_sampler: sampler;
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    return textureSample(input_texture, input_sampler, in.uv);
+}
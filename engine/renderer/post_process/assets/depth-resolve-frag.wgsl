@@ -0,0 +1,11 @@
+// Depth resolve pass
+//
+// Copies sample 0 of the multisampled scene depth buffer into a single-sample
+// depth target so post effects can read it as texture_depth_2d.
+
+@group(0) @binding(0) var depth_texture: texture_depth_multisampled_2d;
+
+@fragment
+fn fs_main(@builtin(position) position: vec4<f32>) -> @builtin(frag_depth) f32 {
+    return textureLoad(depth_texture, vec2<i32>(position.xy), 0);
+}
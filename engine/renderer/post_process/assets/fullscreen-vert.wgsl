@@ -0,0 +1,20 @@
+// Fullscreen triangle vertex shader
+//
+// Emits a single triangle covering the whole target from the vertex index alone,
+// so post passes need no vertex buffer. UVs run from (0, 0) at the top-left to
+// (1, 1) at the bottom-right, matching texture coordinates.
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) uv: vec2<f32>,
+};
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> VertexOutput {
+    let uv = vec2<f32>(f32((index << 1u) & 2u), f32(index & 2u));
+
+    var out: VertexOutput;
+    out.position = vec4<f32>(uv.x * 2.0 - 1.0, 1.0 - uv.y * 2.0, 0.0, 1.0);
+    out.uv = uv;
+    return out;
+}
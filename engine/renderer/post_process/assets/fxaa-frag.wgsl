@@ -0,0 +1,153 @@
+// FXAA post effect
+//
+// Fast approximate anti-aliasing after FXAA 3.11. Finds high-contrast edges from
+// the luma of the centre pixel and its neighbours, walks along the edge to find
+// its ends, and resamples across the edge to blend the staircase away. A second
+// sub-pixel term softens single-pixel features. Run it on display-range colour,
+// i.e. after tonemapping.
+
+struct FragmentInput {
+    @location(0) uv: vec2<f32>,
+};
+
+//@oxy:provider 0 0 post_process post_input
+@group(0) @binding(0) var input_texture: texture_2d<f32>;
+//@oxy:provider 0 1 post_process post_sampler
+@group(0) @binding(1) var input_sampler: sampler;
+
+//@oxy:include fxaa_params
+//@oxy:provider 0 2 post_process post_params
+@group(0) @binding(2) var<uniform> params: FXAAParams;
+
+const edge_search_steps = 10;
+
+fn luma(color: vec3<f32>) -> f32 {
+    return sqrt(dot(color, vec3<f32>(0.299, 0.587, 0.114)));
+}
+
+fn sample_luma(uv: vec2<f32>) -> f32 {
+    return luma(textureSampleLevel(input_texture, input_sampler, uv, 0.0).rgb);
+}
+
+fn edge_step_size(i: i32) -> f32 {
+    if i < 3 {
+        return 1.0;
+    }
+    if i < 4 {
+        return 1.5;
+    }
+    if i < 8 {
+        return 2.0;
+    }
+    if i < 9 {
+        return 4.0;
+    }
+    return 8.0;
+}
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    let texel = 1.0 / vec2<f32>(textureDimensions(input_texture));
+    let center = textureSampleLevel(input_texture, input_sampler, in.uv, 0.0);
+
+    let m = luma(center.rgb);
+    let n = sample_luma(in.uv + vec2<f32>(0.0, -texel.y));
+    let s = sample_luma(in.uv + vec2<f32>(0.0, texel.y));
+    let e = sample_luma(in.uv + vec2<f32>(texel.x, 0.0));
+    let w = sample_luma(in.uv + vec2<f32>(-texel.x, 0.0));
+
+    let luma_max = max(max(max(n, s), max(e, w)), m);
+    let luma_min = min(min(min(n, s), min(e, w)), m);
+    let range = luma_max - luma_min;
+    if range < max(params.edge_threshold_min, luma_max * params.edge_threshold) {
+        return center;
+    }
+
+    let nw = sample_luma(in.uv + vec2<f32>(-texel.x, -texel.y));
+    let ne = sample_luma(in.uv + vec2<f32>(texel.x, -texel.y));
+    let sw = sample_luma(in.uv + vec2<f32>(-texel.x, texel.y));
+    let se = sample_luma(in.uv + vec2<f32>(texel.x, texel.y));
+
+    // Sub-pixel blend: how much the centre differs from its 3x3 neighbourhood.
+    let average = (2.0 * (n + s + e + w) + nw + ne + sw + se) / 12.0;
+    var sub_blend = smoothstep(0.0, 1.0, clamp(abs(average - m) / range, 0.0, 1.0));
+    sub_blend = sub_blend * sub_blend * params.subpixel;
+
+    // Edge orientation: a horizontal edge has its gradient along y.
+    let horizontal = 2.0 * abs(n + s - 2.0 * m) + abs(ne + se - 2.0 * e) + abs(nw + sw - 2.0 * w);
+    let vertical = 2.0 * abs(e + w - 2.0 * m) + abs(ne + nw - 2.0 * n) + abs(se + sw - 2.0 * s);
+    let is_horizontal = horizontal >= vertical;
+
+    var pixel_step = select(texel.x, texel.y, is_horizontal);
+    let positive = select(e, s, is_horizontal);
+    let negative = select(w, n, is_horizontal);
+    let positive_gradient = abs(positive - m);
+    let negative_gradient = abs(negative - m);
+
+    var opposite = positive;
+    var gradient = positive_gradient;
+    if positive_gradient < negative_gradient {
+        pixel_step = -pixel_step;
+        opposite = negative;
+        gradient = negative_gradient;
+    }
+
+    // Walk along the edge in both directions until the luma leaves the edge.
+    var edge_uv = in.uv;
+    var edge_step = vec2<f32>(texel.x, 0.0);
+    if is_horizontal {
+        edge_uv.y += pixel_step * 0.5;
+    } else {
+        edge_uv.x += pixel_step * 0.5;
+        edge_step = vec2<f32>(0.0, texel.y);
+    }
+    let edge_luma = (m + opposite) * 0.5;
+    let gradient_threshold = gradient * 0.25;
+
+    var positive_uv = edge_uv + edge_step;
+    var positive_delta = sample_luma(positive_uv) - edge_luma;
+    var positive_end = abs(positive_delta) >= gradient_threshold;
+    for (var i = 1; i < edge_search_steps && !positive_end; i++) {
+        positive_uv += edge_step * edge_step_size(i);
+        positive_delta = sample_luma(positive_uv) - edge_luma;
+        positive_end = abs(positive_delta) >= gradient_threshold;
+    }
+
+    var negative_uv = edge_uv - edge_step;
+    var negative_delta = sample_luma(negative_uv) - edge_luma;
+    var negative_end = abs(negative_delta) >= gradient_threshold;
+    for (var i = 1; i < edge_search_steps && !negative_end; i++) {
+        negative_uv -= edge_step * edge_step_size(i);
+        negative_delta = sample_luma(negative_uv) - edge_luma;
+        negative_end = abs(negative_delta) >= gradient_threshold;
+    }
+
+    var positive_distance = positive_uv.y - in.uv.y;
+    var negative_distance = in.uv.y - negative_uv.y;
+    if is_horizontal {
+        positive_distance = positive_uv.x - in.uv.x;
+        negative_distance = in.uv.x - negative_uv.x;
+    }
+
+    var shortest = positive_distance;
+    var delta_sign = positive_delta >= 0.0;
+    if negative_distance < positive_distance {
+        shortest = negative_distance;
+        delta_sign = negative_delta >= 0.0;
+    }
+
+    // Only blend when the pixel lies on the side of the edge that moves away from it.
+    var edge_blend = 0.0;
+    if delta_sign != (m - edge_luma >= 0.0) {
+        edge_blend = 0.5 - shortest / (positive_distance + negative_distance);
+    }
+
+    let blend = max(sub_blend, edge_blend);
+    var uv = in.uv;
+    if is_horizontal {
+        uv.y += pixel_step * blend;
+    } else {
+        uv.x += pixel_step * blend;
+    }
+    return vec4<f32>(textureSampleLevel(input_texture, input_sampler, uv, 0.0).rgb, center.a);
+}
@@ -0,0 +1,6 @@
+struct FXAAParams {
+    edge_threshold: f32,
+    edge_threshold_min: f32,
+    subpixel: f32,
+    _pad: f32,
+};
@@ -0,0 +1,24 @@
+// Weighted blended OIT composite pass
+//
+// Resolves the accumulation and revealage targets written by the weighted
+// blended transparency pass over the scene color. The pipeline blends with
+// SrcAlpha / OneMinusSrcAlpha, so the output alpha is the total coverage
+// of the transparent layers (1 - revealage).
+
+@group(0) @binding(0) var accum_texture: texture_2d<f32>;
+@group(0) @binding(1) var reveal_texture: texture_2d<f32>;
+
+@fragment
+fn fs_main(@builtin(position) position: vec4<f32>) -> @location(0) vec4<f32> {
+    let coord = vec2<i32>(position.xy);
+    let reveal = textureLoad(reveal_texture, coord, 0).r;
+
+    // No transparent surface covered this pixel
+    if reveal >= 0.9999 {
+        discard;
+    }
+
+    let accum = textureLoad(accum_texture, coord, 0);
+    let average = accum.rgb / max(accum.a, 1e-5);
+    return vec4<f32>(average, 1.0 - reveal);
+}
@@ -0,0 +1,54 @@
+// Tonemapping post effect
+//
+// Scales the HDR scene color by the exposure and compresses it into display
+// range with the selected operator:
+//   0 = ACES filmic (Narkowicz fit)
+//   1 = extended Reinhard with a configurable white point
+//   2 = none (clamp)
+
+struct FragmentInput {
+    @location(0) uv: vec2<f32>,
+};
+
+//@oxy:provider 0 0 post_process post_input
+@group(0) @binding(0) var input_texture: texture_2d<f32>;
+//@oxy:provider 0 1 post_process post_sampler
+@group(0) @binding(1) var input_sampler: sampler;
+
+//@oxy:include tonemap_params
+//@oxy:provider 0 2 post_process post_params
+@group(0) @binding(2) var<uniform> params: TonemapParams;
+
+fn tonemap_aces(x: vec3<f32>) -> vec3<f32> {
+    let a = 2.51;
+    let b = 0.03;
+    let c = 2.43;
+    let d = 0.59;
+    let e = 0.14;
+    return clamp((x * (a * x + b)) / (x * (c * x + d) + e), vec3<f32>(0.0), vec3<f32>(1.0));
+}
+
+fn tonemap_reinhard(x: vec3<f32>, white: f32) -> vec3<f32> {
+    let w2 = max(white * white, 1e-4);
+    return clamp(x * (1.0 + x / w2) / (1.0 + x), vec3<f32>(0.0), vec3<f32>(1.0));
+}
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    let color = textureSample(input_texture, input_sampler, in.uv);
+    let exposed = max(color.rgb * params.exposure, vec3<f32>(0.0));
+
+    var mapped: vec3<f32>;
+    switch params.operator {
+        case 0u: {
+            mapped = tonemap_aces(exposed);
+        }
+        case 1u: {
+            mapped = tonemap_reinhard(exposed, params.white_point);
+        }
+        default: {
+            mapped = clamp(exposed, vec3<f32>(0.0), vec3<f32>(1.0));
+        }
+    }
+    return vec4<f32>(mapped, color.a);
+}
@@ -0,0 +1,6 @@
+struct TonemapParams {
+    exposure: f32,
+    operator: u32,
+    white_point: f32,
+    _pad: f32,
+};
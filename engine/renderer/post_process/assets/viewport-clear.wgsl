@@ -0,0 +1,17 @@
+// Viewport clear pass
+//
+// Emits a single triangle covering the viewport at the far plane. With depth
+// writes enabled and an Always compare it resets the depth buffer; the color
+// target blends with the blend constant (SrcFactor Constant, DstFactor Zero),
+// so the clear color is set per draw without a bind group.
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> @builtin(position) vec4<f32> {
+    let uv = vec2<f32>(f32((index << 1u) & 2u), f32(index & 2u));
+    return vec4<f32>(uv.x * 2.0 - 1.0, 1.0 - uv.y * 2.0, 1.0, 1.0);
+}
+
+@fragment
+fn fs_main() -> @location(0) vec4<f32> {
+    return vec4<f32>(1.0);
+}
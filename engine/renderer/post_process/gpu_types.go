@@ -0,0 +1,152 @@
+package post_process
+
+import (
+	_ "embed"
+	"encoding/binary"
+	"math"
+	"unsafe"
+)
+
+// GPUTonemapParamsSource is the canonical WGSL definition of the TonemapParams struct.
+// Matches GPUTonemapParams layout exactly (16 bytes, std430 aligned).
+//
+//go:embed assets/tonemap_params.wgsl
+var GPUTonemapParamsSource string
+
+// GPUTonemapParams is the GPU-aligned uniform for the tonemapping post effect.
+// Matches the WGSL TonemapParams struct layout exactly (see GPUTonemapParamsSource).
+// Size: 16 bytes.
+type GPUTonemapParams struct {
+	Exposure   float32 // offset 0: linear multiplier applied to the scene color before tonemapping
+	Operator   uint32  // offset 4: tonemapping curve: 0 = ACES, 1 = Reinhard, 2 = none
+	WhitePoint float32 // offset 8: smallest input mapped to pure white by the Reinhard operator
+	_pad       float32 // offset 12: padding to 16 bytes
+}
+
+// Size returns the size of the GPUTonemapParams struct in bytes.
+//
+// Returns:
+//   - int: the size of the struct in bytes.
+func (g *GPUTonemapParams) Size() int {
+	return int(unsafe.Sizeof(*g))
+}
+
+// Marshal serializes the GPUTonemapParams struct into a byte buffer suitable for GPU upload.
+//
+// Returns:
+//   - []byte: 16-byte buffer ready for GPU upload.
+func (g *GPUTonemapParams) Marshal() []byte {
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint32(buf[0:4], math.Float32bits(g.Exposure))
+	binary.LittleEndian.PutUint32(buf[4:8], g.Operator)
+	binary.LittleEndian.PutUint32(buf[8:12], math.Float32bits(g.WhitePoint))
+	return buf
+}
+
+// GPUBloomParamsSource is the canonical WGSL definition of the BloomParams struct.
+// Matches GPUBloomParams layout exactly (16 bytes, std430 aligned).
+//
+//go:embed assets/bloom_params.wgsl
+var GPUBloomParamsSource string
+
+// GPUBloomParams is the GPU-aligned uniform for the bloom post effect passes.
+// Matches the WGSL BloomParams struct layout exactly (see GPUBloomParamsSource).
+// Size: 16 bytes.
+type GPUBloomParams struct {
+	Threshold float32 // offset 0: brightness above which pixels contribute to bloom
+	Knee      float32 // offset 4: width of the soft transition around the threshold
+	Intensity float32 // offset 8: scale of the blurred bloom added back onto the image
+	Radius    float32 // offset 12: blur tap spacing in texels of the half-resolution target
+}
+
+// Size returns the size of the GPUBloomParams struct in bytes.
+//
+// Returns:
+//   - int: the size of the struct in bytes.
+func (g *GPUBloomParams) Size() int {
+	return int(unsafe.Sizeof(*g))
+}
+
+// Marshal serializes the GPUBloomParams struct into a byte buffer suitable for GPU upload.
+//
+// Returns:
+//   - []byte: 16-byte buffer ready for GPU upload.
+func (g *GPUBloomParams) Marshal() []byte {
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint32(buf[0:4], math.Float32bits(g.Threshold))
+	binary.LittleEndian.PutUint32(buf[4:8], math.Float32bits(g.Knee))
+	binary.LittleEndian.PutUint32(buf[8:12], math.Float32bits(g.Intensity))
+	binary.LittleEndian.PutUint32(buf[12:16], math.Float32bits(g.Radius))
+	return buf
+}
+
+// GPUFXAAParamsSource is the canonical WGSL definition of the FXAAParams struct.
+// Matches GPUFXAAParams layout exactly (16 bytes, std430 aligned).
+//
+//go:embed assets/fxaa_params.wgsl
+var GPUFXAAParamsSource string
+
+// GPUFXAAParams is the GPU-aligned uniform for the FXAA post effect.
+// Matches the WGSL FXAAParams struct layout exactly (see GPUFXAAParamsSource).
+// Size: 16 bytes.
+type GPUFXAAParams struct {
+	EdgeThreshold    float32 // offset 0: minimum local contrast, relative to the brightest neighbour, treated as an edge
+	EdgeThresholdMin float32 // offset 4: absolute contrast below which dark areas are skipped
+	Subpixel         float32 // offset 8: amount of sub-pixel aliasing removal in [0, 1]
+	_pad             float32 // offset 12: padding to 16 bytes
+}
+
+// Size returns the size of the GPUFXAAParams struct in bytes.
+//
+// Returns:
+//   - int: the size of the struct in bytes.
+func (g *GPUFXAAParams) Size() int {
+	return int(unsafe.Sizeof(*g))
+}
+
+// Marshal serializes the GPUFXAAParams struct into a byte buffer suitable for GPU upload.
+//
+// Returns:
+//   - []byte: 16-byte buffer ready for GPU upload.
+func (g *GPUFXAAParams) Marshal() []byte {
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint32(buf[0:4], math.Float32bits(g.EdgeThreshold))
+	binary.LittleEndian.PutUint32(buf[4:8], math.Float32bits(g.EdgeThresholdMin))
+	binary.LittleEndian.PutUint32(buf[8:12], math.Float32bits(g.Subpixel))
+	return buf
+}
+
+// GPUColorGradeParamsSource is the canonical WGSL definition of the ColorGradeParams struct.
+// Matches GPUColorGradeParams layout exactly (16 bytes, std430 aligned).
+//
+//go:embed assets/color_grade_params.wgsl
+var GPUColorGradeParamsSource string
+
+// GPUColorGradeParams is the GPU-aligned uniform for the color grading post effect.
+// Matches the WGSL ColorGradeParams struct layout exactly (see GPUColorGradeParamsSource).
+// Size: 16 bytes.
+type GPUColorGradeParams struct {
+	LUTSize   float32 // offset 0: edge length N of the N*N x N lookup table strip
+	Intensity float32 // offset 4: blend between the original (0) and graded (1) color
+	_pad0     float32 // offset 8: padding
+	_pad1     float32 // offset 12: padding to 16 bytes
+}
+
+// Size returns the size of the GPUColorGradeParams struct in bytes.
+//
+// Returns:
+//   - int: the size of the struct in bytes.
+func (g *GPUColorGradeParams) Size() int {
+	return int(unsafe.Sizeof(*g))
+}
+
+// Marshal serializes the GPUColorGradeParams struct into a byte buffer suitable for GPU upload.
+//
+// Returns:
+//   - []byte: 16-byte buffer ready for GPU upload.
+func (g *GPUColorGradeParams) Marshal() []byte {
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint32(buf[0:4], math.Float32bits(g.LUTSize))
+	binary.LittleEndian.PutUint32(buf[4:8], math.Float32bits(g.Intensity))
+	return buf
+}
@@ -0,0 +1,70 @@
+package post_process
+
+import (
+	_ "embed"
+)
+
+// FullscreenVertexSource is the vertex shader shared by every post pass. It draws a single
+// triangle covering the target from the vertex index, with no vertex buffer, and passes the
+// target UV to the fragment stage at @location(0).
+//
+//go:embed assets/fullscreen-vert.wgsl
+var FullscreenVertexSource string
+
+// CopyShaderSource is the fragment shader that copies the previous output unchanged.
+//
+//go:embed assets/copy-frag.wgsl
+var CopyShaderSource string
+
+// DepthResolveShaderSource is the fragment shader that copies sample 0 of a multisampled
+// depth buffer into a single-sample depth target.
+//
+//go:embed assets/depth-resolve-frag.wgsl
+var DepthResolveShaderSource string
+
+// TonemapShaderSource is the fragment shader of the tonemapping effect (ACES, Reinhard or none).
+//
+//go:embed assets/tonemap-frag.wgsl
+var TonemapShaderSource string
+
+// BloomPrefilterShaderSource is the fragment shader of the bloom bright pass, run at half resolution.
+//
+//go:embed assets/bloom-prefilter-frag.wgsl
+var BloomPrefilterShaderSource string
+
+// BloomBlurHShaderSource is the fragment shader of the horizontal bloom blur.
+//
+//go:embed assets/bloom-blur-h-frag.wgsl
+var BloomBlurHShaderSource string
+
+// BloomBlurVShaderSource is the fragment shader of the vertical bloom blur.
+//
+//go:embed assets/bloom-blur-v-frag.wgsl
+var BloomBlurVShaderSource string
+
+// BloomCompositeShaderSource is the fragment shader that adds the blurred bloom back onto the source image.
+//
+//go:embed assets/bloom-composite-frag.wgsl
+var BloomCompositeShaderSource string
+
+// FXAAShaderSource is the fragment shader of the FXAA effect.
+//
+//go:embed assets/fxaa-frag.wgsl
+var FXAAShaderSource string
+
+// ColorGradeShaderSource is the fragment shader of the LUT color grading effect.
+//
+//go:embed assets/color-grade-frag.wgsl
+var ColorGradeShaderSource string
+
+// OITCompositeShaderSource is the fragment shader that composites the weighted blended
+// transparency accumulation and revealage targets over the scene color.
+//
+//go:embed assets/oit-composite-frag.wgsl
+var OITCompositeShaderSource string
+
+// ViewportClearShaderSource holds the vertex (vs_main) and fragment (fs_main) entry points of the
+// pass that clears a viewport of the main render pass to a color and the far-plane depth.
+//
+//go:embed assets/viewport-clear.wgsl
+var ViewportClearShaderSource string
@@ -2,6 +2,7 @@ package renderer
 
 import (
 	"fmt"
+	"image"
 	"sync"
 
 	"github.com/Carmen-Shannon/oxy-go/common"
@@ -24,6 +25,11 @@ type renderer struct {
 	forceFallbackAdapter bool
 	pendingPresentMode   *PresentMode
 	pendingMSAA          *MSAASampleCount
+	hdr                  bool
+	oit                  bool
+	gpuTimestamps        bool
+
+	postEffects []PostEffect
 }
 
 // Renderer defines the interface for the rendering system.
@@ -109,7 +115,7 @@ type Renderer interface {
 
 	// InitTextureView creates a GPU texture from staging data and stores the resulting texture view
 	// on the given BindGroupProvider at the specified binding index. Must be called before InitBindGroup
-	// for any texture bindings.
+	// for any texture bindings. If stagingData.GenerateMipmaps is set, a full mip chain is generated.
 	//
 	// Parameters:
 	//   - provider: the BindGroupProvider to store the created texture view on
@@ -132,6 +138,28 @@ type Renderer interface {
 	//   - error: an error if sampler creation fails
 	InitSampler(provider bind_group_provider.BindGroupProvider, bindingKey int, samplerStagingData common.SamplerStagingData) error
 
+	// InitCubeTextureView uploads the six faces of a cubemap, with any precomputed mip levels, and stores
+	// a cube texture view on the provider for WGSL texture_cube bindings.
+	//
+	// Parameters:
+	//   - provider: the BindGroupProvider to store the texture view on
+	//   - bindingKey: the binding index for the texture
+	//   - stagingData: the face data, size and format of the cubemap
+	//
+	// Returns:
+	//   - error: an error if texture creation fails
+	InitCubeTextureView(provider bind_group_provider.BindGroupProvider, bindingKey int, stagingData common.CubemapStagingData) error
+
+	// SupportsTextureFormat reports whether textures of the given format can be created and sampled.
+	// Block-compressed formats depend on the adapter's BC, ETC2 and ASTC texture compression features.
+	//
+	// Parameters:
+	//   - format: the texture format
+	//
+	// Returns:
+	//   - bool: true if InitTextureView accepts staging data in this format
+	SupportsTextureFormat(format wgpu.TextureFormat) bool
+
 	// WriteBuffers writes all staged buffer writes to the GPU queue.
 	// Each BufferWrite targets a specific buffer on a BindGroupProvider at a given binding and offset.
 	//
@@ -181,6 +209,49 @@ type Renderer interface {
 	//   - error: an error if the pipeline is not found
 	DrawCall(pipelineKey string, meshProvider bind_group_provider.BindGroupProvider, instanceCount uint32, bindGroups []bind_group_provider.BindGroupProvider) error
 
+	// DrawProcedural encodes a draw command with no vertex or index buffer within the current render pass.
+	// The pipeline's vertex shader must generate its vertices from @builtin(vertex_index), as the
+	// skybox and fullscreen passes do.
+	//
+	// Parameters:
+	//   - pipelineKey: the unique identifier for the cached render Pipeline to use
+	//   - vertexCount: the number of vertices to draw
+	//   - instanceCount: the number of instances to draw
+	//   - bindGroups: a slice of BindGroupProviders whose BindGroups will be set on the render pass
+	//
+	// Returns:
+	//   - error: an error if the pipeline is not found
+	DrawProcedural(pipelineKey string, vertexCount, instanceCount uint32, bindGroups []bind_group_provider.BindGroupProvider) error
+
+	// DrawProceduralRange encodes a draw of a contiguous range of vertices with no vertex or index buffer
+	// within the current render pass. The vertex shader's @builtin(vertex_index) starts at firstVertex, so
+	// passes that pull their vertices from a storage buffer can draw parts of it with different pipelines.
+	//
+	// Parameters:
+	//   - pipelineKey: the unique identifier for the cached render Pipeline to use
+	//   - firstVertex: the index of the first vertex to draw
+	//   - vertexCount: the number of vertices to draw
+	//   - bindGroups: a slice of BindGroupProviders whose BindGroups will be set on the render pass
+	//
+	// Returns:
+	//   - error: an error if the pipeline is not found
+	DrawProceduralRange(pipelineKey string, firstVertex, vertexCount uint32, bindGroups []bind_group_provider.BindGroupProvider) error
+
+	// DrawCallInstances encodes an instanced draw of a contiguous range of instances within the current
+	// render pass. Used to draw instances one at a time in a sorted order; the vertex shader's
+	// @builtin(instance_index) starts at firstInstance.
+	//
+	// Parameters:
+	//   - pipelineKey: the unique identifier for the cached render Pipeline to use
+	//   - meshProvider: the BindGroupProvider holding vertex and index buffers
+	//   - firstInstance: the index of the first instance to draw
+	//   - instanceCount: the number of instances to draw
+	//   - bindGroups: a slice of BindGroupProviders whose BindGroups will be set on the render pass
+	//
+	// Returns:
+	//   - error: an error if the pipeline is not found
+	DrawCallInstances(pipelineKey string, meshProvider bind_group_provider.BindGroupProvider, firstInstance, instanceCount uint32, bindGroups []bind_group_provider.BindGroupProvider) error
+
 	// DrawCallIndirect encodes a single indirect instanced draw command within the current render pass.
 	// The instance count is read from the indirectBuffer on the GPU, allowing the compute shader to
 	// control how many instances are drawn without CPU readback.
@@ -195,7 +266,8 @@ type Renderer interface {
 	//   - error: an error if the pipeline is not found
 	DrawCallIndirect(pipelineKey string, meshProvider bind_group_provider.BindGroupProvider, indirectBuffer *wgpu.Buffer, bindGroups []bind_group_provider.BindGroupProvider) error
 
-	// EndFrame ends the current render pass and submits the command buffer to the GPU.
+	// EndFrame ends the current render pass, runs the enabled post effects, and submits the
+	// command buffer to the GPU.
 	// Does not present the surface — call Present() after EndFrame to display the frame.
 	// Must be called after BeginFrame and all DrawCall invocations within a single frame.
 	EndFrame()
@@ -211,19 +283,22 @@ type Renderer interface {
 	//   - mode: the PresentMode to use (VSync, Uncapped, or TripleBuffered)
 	SetPresentMode(mode PresentMode)
 
-	// CreateShadowDepthTexture creates a Depth32Float texture and view for shadow mapping.
-	// The texture has sample count 1 (no MSAA) and can be sampled as a depth texture
-	// in the lit fragment shader.
+	// CreateShadowDepthTexture creates a Depth32Float texture array and views for shadow mapping.
+	// The texture has sample count 1 (no MSAA) and one layer per shadow cascade. The array
+	// view is sampled as a texture_depth_2d_array in the lit fragment shader, while each
+	// layer view is the depth attachment of one shadow render pass.
 	//
 	// Parameters:
 	//   - width: shadow map width in texels
 	//   - height: shadow map height in texels
+	//   - layers: number of array layers
 	//
 	// Returns:
-	//   - *wgpu.TextureView: the depth texture view for the shadow render pass
+	//   - *wgpu.TextureView: the 2D array view over all layers, for sampling
+	//   - []*wgpu.TextureView: one single-layer view per layer, for the shadow render passes
 	//   - *wgpu.Texture: the underlying texture (caller must release when done)
 	//   - error: an error if texture creation fails
-	CreateShadowDepthTexture(width, height int) (*wgpu.TextureView, *wgpu.Texture, error)
+	CreateShadowDepthTexture(width, height, layers int) (*wgpu.TextureView, []*wgpu.TextureView, *wgpu.Texture, error)
 
 	// CreateComparisonSampler creates a comparison sampler suitable for PCF shadow mapping.
 	//
@@ -255,6 +330,15 @@ type Renderer interface {
 	//   - depthView: the shadow map depth texture view to render into
 	BeginShadowPass(depthView *wgpu.TextureView)
 
+	// SetShadowViewport restricts the current shadow pass to a rectangle of its depth target,
+	// used to render into one tile of a shadow atlas. Applies until the next call or the end
+	// of the pass; a new pass starts with the full target.
+	//
+	// Parameters:
+	//   - x, y: top-left corner of the rectangle in texels
+	//   - width, height: size of the rectangle in texels
+	SetShadowViewport(x, y, width, height uint32)
+
 	// ShadowDrawCall encodes a single instanced draw command within the current shadow pass.
 	//
 	// Parameters:
@@ -285,6 +369,217 @@ type Renderer interface {
 
 	// EndShadowFrame finishes the shadow command encoder and submits to the GPU queue.
 	EndShadowFrame()
+
+	// Headless returns whether this Renderer draws into an offscreen target rather than a window surface.
+	//
+	// Returns:
+	//   - bool: true if created via NewHeadlessRenderer
+	Headless() bool
+
+	// HDR returns whether the main render pass draws into an RGBA16Float scene target.
+	// HDR color is only brought into display range by a tonemapping effect.
+	//
+	// Returns:
+	//   - bool: true if created with WithHDR(true)
+	HDR() bool
+
+	// SceneFormat returns the color format the main render pass draws in: RGBA16Float when HDR is
+	// enabled, otherwise the surface format. Render pipelines are created for this format.
+	//
+	// Returns:
+	//   - wgpu.TextureFormat: the scene color format
+	SceneFormat() wgpu.TextureFormat
+
+	// SampleCount returns the multisample count of the main render pass and of the render
+	// pipelines created for it.
+	//
+	// Returns:
+	//   - uint32: the MSAA sample count (1 when MSAA is off)
+	SampleCount() uint32
+
+	// OrderIndependentTransparency returns whether blended materials can be drawn through the
+	// weighted blended transparency pass instead of being sorted back-to-front.
+	//
+	// Returns:
+	//   - bool: true if created with WithOrderIndependentTransparency(true)
+	OrderIndependentTransparency() bool
+
+	// BeginTransparentPass suspends the main render pass and begins the weighted blended
+	// transparency pass. Draws until EndTransparentPass must use pipelines created with
+	// pipeline.WithWeightedBlended(true). Does nothing when order-independent transparency is disabled.
+	//
+	// Returns:
+	//   - error: an error if no frame is in progress or the transparency targets could not be created
+	BeginTransparentPass() error
+
+	// EndTransparentPass ends the weighted blended transparency pass, composites the transparent
+	// layers over the scene color, and resumes the main render pass for any later draws.
+	EndTransparentPass()
+
+	// GPUTimingSupported returns whether GPU timestamps are recorded. False when the renderer was
+	// not created with WithGPUTimestamps(true), the adapter lacks the timestamp query feature, or
+	// the device rejected a timestamp; the other GPU timing methods then do nothing.
+	//
+	// Returns:
+	//   - bool: true if GPU timestamps are recorded
+	GPUTimingSupported() bool
+
+	// BeginGPUTimings starts timing a frame. Call once per frame before its first phase.
+	BeginGPUTimings()
+
+	// MarkGPUTiming ends a timed phase. The phase covers all GPU work submitted between the
+	// previous mark (or BeginGPUTimings) and this call.
+	//
+	// Parameters:
+	//   - phase: the name of the phase that just ended
+	MarkGPUTiming(phase string)
+
+	// EndGPUTimings finishes timing a frame and starts reading its timestamps back asynchronously.
+	EndGPUTimings()
+
+	// GPUTimings returns the phase durations of the newest timed frame that has finished on the
+	// GPU since the last call. Results typically lag the current frame by one to two frames.
+	// Never blocks. The binding does not expose the queue's timestamp period, so ticks are taken
+	// to be nanoseconds; on adapters with another period the durations are scaled by it.
+	//
+	// Returns:
+	//   - []GPUTiming: the phase durations in the order they were marked, or nil if none is ready
+	GPUTimings() []GPUTiming
+
+	// TargetSize returns the size of the frame target: the window surface, or the offscreen
+	// texture of a headless renderer.
+	//
+	// Returns:
+	//   - uint32: the target width in pixels
+	//   - uint32: the target height in pixels
+	TargetSize() (uint32, uint32)
+
+	// CreateTexture creates a single-level 2D texture and a view of it for a render target managed
+	// by the caller, such as a render graph transient. The caller releases both.
+	//
+	// Parameters:
+	//   - label: the debug label of the texture
+	//   - width: the texture width in pixels
+	//   - height: the texture height in pixels
+	//   - sampleCount: the multisample count (1 for no multisampling)
+	//   - format: the texture format
+	//   - usage: the texture usage flags
+	//
+	// Returns:
+	//   - *wgpu.Texture: the created texture
+	//   - *wgpu.TextureView: a view of the whole texture
+	//   - error: an error if the texture could not be created
+	CreateTexture(label string, width, height, sampleCount uint32, format wgpu.TextureFormat, usage wgpu.TextureUsage) (*wgpu.Texture, *wgpu.TextureView, error)
+
+	// CreateBuffer creates a GPU buffer managed by the caller. The caller releases it.
+	//
+	// Parameters:
+	//   - label: the debug label of the buffer
+	//   - size: the buffer size in bytes
+	//   - usage: the buffer usage flags
+	//
+	// Returns:
+	//   - *wgpu.Buffer: the created buffer
+	//   - error: an error if the buffer could not be created
+	CreateBuffer(label string, size uint64, usage wgpu.BufferUsage) (*wgpu.Buffer, error)
+
+	// BeginRenderPass begins a render pass into the given attachments, outside the main frame pass.
+	// DrawCall, DrawProcedural and the other draw methods record into it until EndRenderPass; their
+	// pipelines must be created with color formats and a sample count matching the attachments
+	// (see pipeline.WithColorFormats and pipeline.WithSampleCount) and a Depth24Plus depth attachment.
+	//
+	// Parameters:
+	//   - desc: the render pass descriptor with the color and depth attachments
+	//
+	// Returns:
+	//   - error: an error if a frame or another render pass is in progress
+	BeginRenderPass(desc *wgpu.RenderPassDescriptor) error
+
+	// EndRenderPass ends the render pass begun by BeginRenderPass and submits it.
+	EndRenderPass()
+
+	// SetViewport sets the rectangle of the target that draws in the current render pass map to.
+	// The viewport applies until the pass ends and carries over into the transparent pass and the
+	// main pass resumed after it. Every frame and render pass starts with the full target.
+	//
+	// Parameters:
+	//   - x: the left edge in pixels
+	//   - y: the top edge in pixels
+	//   - width: the width in pixels
+	//   - height: the height in pixels
+	SetViewport(x, y, width, height float32)
+
+	// SetScissorRect restricts the pixels draws in the current render pass may write to a
+	// rectangle, which must lie inside the target. Like SetViewport it carries over into the
+	// transparent pass and resets with each frame and render pass.
+	//
+	// Parameters:
+	//   - x: the left edge in pixels
+	//   - y: the top edge in pixels
+	//   - width: the width in pixels
+	//   - height: the height in pixels
+	SetScissorRect(x, y, width, height uint32)
+
+	// ClearViewport clears the current viewport and scissor rectangle of the open pass by drawing
+	// over it, leaving the rest of the target untouched. Must be called between BeginFrame and
+	// EndFrame, or in a render pass whose attachments have the main pass's formats and sample count.
+	//
+	// Parameters:
+	//   - color: the clear color, or nil to keep the color target
+	//   - depth: true to reset the depth buffer to the far plane
+	//
+	// Returns:
+	//   - error: an error if no frame is in progress or the clear pipeline could not be created
+	ClearViewport(color *wgpu.Color, depth bool) error
+
+	// PostEffect returns the post effect with the given name.
+	//
+	// Parameters:
+	//   - name: the effect name
+	//
+	// Returns:
+	//   - PostEffect: the effect, or nil if none has that name
+	PostEffect(name string) PostEffect
+
+	// PostEffects returns the post effect chain in the order the effects run.
+	//
+	// Returns:
+	//   - []PostEffect: the effects
+	PostEffects() []PostEffect
+
+	// AddPostEffect appends an effect to the end of the post effect chain. Its pipelines are
+	// created immediately so shader errors are reported here rather than during a frame.
+	//
+	// Parameters:
+	//   - e: the effect to add
+	//
+	// Returns:
+	//   - error: an error if the effect's pipelines could not be created
+	AddPostEffect(e PostEffect) error
+
+	// RemovePostEffect removes the effect with the given name from the chain and releases its GPU resources.
+	//
+	// Parameters:
+	//   - name: the effect name
+	RemovePostEffect(name string)
+
+	// SetPostEffects replaces the whole post effect chain, e.g. to reorder it.
+	//
+	// Parameters:
+	//   - effects: the effects in the order they run
+	//
+	// Returns:
+	//   - error: an error if an effect's pipelines could not be created; the chain is unchanged
+	SetPostEffects(effects []PostEffect) error
+
+	// ReadPixels copies the most recently rendered frame back to the CPU as an RGBA image.
+	// Call after EndFrame; blocks until the GPU copy has completed.
+	// Only supported on headless renderers.
+	//
+	// Returns:
+	//   - *image.RGBA: the rendered frame in sRGB
+	//   - error: an error if the renderer is not headless or the readback fails
+	ReadPixels() (*image.RGBA, error)
 }
 
 var _ Renderer = &renderer{}
@@ -299,7 +594,8 @@ var _ Renderer = &renderer{}
 //
 // Returns:
 //   - Renderer: a new instance of Renderer configured with the specified backend and options
-func NewRenderer(backendType RendererBackendType, window window.Window, options ...RendererBuilderOption) Renderer {
+//   - error: an error if a post effect passed with WithPostEffects cannot be registered
+func NewRenderer(backendType RendererBackendType, window window.Window, options ...RendererBuilderOption) (Renderer, error) {
 	r := &renderer{
 		mu:            &sync.Mutex{},
 		pipelineCache: make(map[string]pipeline.Pipeline),
@@ -321,7 +617,7 @@ func NewRenderer(backendType RendererBackendType, window window.Window, options
 	case BackendTypeWGPU:
 		fallthrough
 	default:
-		r.backend = newWGPURendererBackend(window.SurfaceDescriptor(), r.forceFallbackAdapter, msaa)
+		r.backend = newWGPURendererBackend(window.SurfaceDescriptor(), r.forceFallbackAdapter, msaa, r.hdr, r.oit, r.gpuTimestamps)
 	}
 
 	if r.pendingPresentMode != nil {
@@ -329,7 +625,57 @@ func NewRenderer(backendType RendererBackendType, window window.Window, options
 	}
 
 	r.backend.ConfigureSurface(window.Width(), window.Height())
-	return r
+	if err := r.SetPostEffects(r.postEffects); err != nil {
+		r.backend.Release()
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewHeadlessRenderer creates a new Renderer that draws into an offscreen color and depth
+// target of the given size instead of a window surface. The full frame lifecycle (compute,
+// shadow, light culling, and draw) works without a window; Present is a no-op and the
+// finished frame can be read back with ReadPixels. Combine with WithForceSoftwareRenderer
+// to render on machines without a GPU.
+//
+// Parameters:
+//   - backendType: the type of rendering backend to use (e.g., WGPU)
+//   - width: the offscreen target width in pixels
+//   - height: the offscreen target height in pixels
+//   - options: variadic list of RendererBuilderOption functions to configure the Renderer
+//
+// Returns:
+//   - Renderer: a new headless Renderer
+//   - error: an error if a post effect passed with WithPostEffects cannot be registered
+func NewHeadlessRenderer(backendType RendererBackendType, width, height int, options ...RendererBuilderOption) (Renderer, error) {
+	r := &renderer{
+		mu:            &sync.Mutex{},
+		pipelineCache: make(map[string]pipeline.Pipeline),
+		backendType:   backendType,
+	}
+
+	for _, opt := range options {
+		opt(r)
+	}
+
+	msaa := MSAA4x // default
+	if r.pendingMSAA != nil {
+		msaa = *r.pendingMSAA
+	}
+
+	switch backendType {
+	case BackendTypeWGPU:
+		fallthrough
+	default:
+		r.backend = newHeadlessWGPURendererBackend(r.forceFallbackAdapter, msaa, r.hdr, r.oit, r.gpuTimestamps)
+	}
+
+	r.backend.ConfigureSurface(width, height)
+	if err := r.SetPostEffects(r.postEffects); err != nil {
+		r.backend.Release()
+		return nil, err
+	}
+	return r, nil
 }
 
 func (r *renderer) Resize(width, height int) {
@@ -403,6 +749,14 @@ func (r *renderer) InitSampler(provider bind_group_provider.BindGroupProvider, b
 	return r.backend.InitSampler(provider, bindingKey, samplerStagingData)
 }
 
+func (r *renderer) InitCubeTextureView(provider bind_group_provider.BindGroupProvider, bindingKey int, stagingData common.CubemapStagingData) error {
+	return r.backend.InitCubeTextureView(provider, bindingKey, stagingData)
+}
+
+func (r *renderer) SupportsTextureFormat(format wgpu.TextureFormat) bool {
+	return r.backend.SupportsTextureFormat(format)
+}
+
 func (r *renderer) WriteBuffers(writes []bind_group_provider.BufferWrite) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -446,6 +800,45 @@ func (r *renderer) DrawCall(pipelineKey string, meshProvider bind_group_provider
 	return nil
 }
 
+func (r *renderer) DrawProcedural(pipelineKey string, vertexCount, instanceCount uint32, bindGroups []bind_group_provider.BindGroupProvider) error {
+	r.mu.Lock()
+	p, exists := r.pipelineCache[pipelineKey]
+	r.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("render pipeline %q not found in cache", pipelineKey)
+	}
+
+	r.backend.DrawProcedural(p, vertexCount, instanceCount, bindGroups)
+	return nil
+}
+
+func (r *renderer) DrawProceduralRange(pipelineKey string, firstVertex, vertexCount uint32, bindGroups []bind_group_provider.BindGroupProvider) error {
+	r.mu.Lock()
+	p, exists := r.pipelineCache[pipelineKey]
+	r.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("render pipeline %q not found in cache", pipelineKey)
+	}
+
+	r.backend.DrawProceduralRange(p, firstVertex, vertexCount, bindGroups)
+	return nil
+}
+
+func (r *renderer) DrawCallInstances(pipelineKey string, meshProvider bind_group_provider.BindGroupProvider, firstInstance, instanceCount uint32, bindGroups []bind_group_provider.BindGroupProvider) error {
+	r.mu.Lock()
+	p, exists := r.pipelineCache[pipelineKey]
+	r.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("render pipeline %q not found in cache", pipelineKey)
+	}
+
+	r.backend.DrawCallInstances(p, meshProvider, firstInstance, instanceCount, bindGroups)
+	return nil
+}
+
 func (r *renderer) DrawCallIndirect(pipelineKey string, meshProvider bind_group_provider.BindGroupProvider, indirectBuffer *wgpu.Buffer, bindGroups []bind_group_provider.BindGroupProvider) error {
 	r.mu.Lock()
 	p, exists := r.pipelineCache[pipelineKey]
@@ -467,8 +860,8 @@ func (r *renderer) Present() {
 	r.backend.Present()
 }
 
-func (r *renderer) CreateShadowDepthTexture(width, height int) (*wgpu.TextureView, *wgpu.Texture, error) {
-	return r.backend.CreateShadowDepthTexture(width, height)
+func (r *renderer) CreateShadowDepthTexture(width, height, layers int) (*wgpu.TextureView, []*wgpu.TextureView, *wgpu.Texture, error) {
+	return r.backend.CreateShadowDepthTexture(width, height, layers)
 }
 
 func (r *renderer) CreateComparisonSampler() (*wgpu.Sampler, error) {
@@ -499,6 +892,10 @@ func (r *renderer) BeginShadowPass(depthView *wgpu.TextureView) {
 	r.backend.BeginShadowPass(depthView)
 }
 
+func (r *renderer) SetShadowViewport(x, y, width, height uint32) {
+	r.backend.SetShadowViewport(x, y, width, height)
+}
+
 func (r *renderer) ShadowDrawCall(pipelineKey string, meshProvider bind_group_provider.BindGroupProvider, instanceCount uint32, bindGroups []bind_group_provider.BindGroupProvider) error {
 	r.mu.Lock()
 	p, exists := r.pipelineCache[pipelineKey]
@@ -532,3 +929,140 @@ func (r *renderer) EndShadowPass() {
 func (r *renderer) EndShadowFrame() {
 	r.backend.EndShadowFrame()
 }
+
+func (r *renderer) Headless() bool {
+	return r.backend.Headless()
+}
+
+func (r *renderer) ReadPixels() (*image.RGBA, error) {
+	return r.backend.ReadPixels()
+}
+
+func (r *renderer) HDR() bool {
+	return r.backend.HDR()
+}
+
+func (r *renderer) SceneFormat() wgpu.TextureFormat {
+	return r.backend.SceneFormat()
+}
+
+func (r *renderer) SampleCount() uint32 {
+	return r.backend.SampleCount()
+}
+
+func (r *renderer) OrderIndependentTransparency() bool {
+	return r.backend.OrderIndependentTransparency()
+}
+
+func (r *renderer) BeginTransparentPass() error {
+	return r.backend.BeginTransparentPass()
+}
+
+func (r *renderer) EndTransparentPass() {
+	r.backend.EndTransparentPass()
+}
+
+func (r *renderer) GPUTimingSupported() bool {
+	return r.backend.GPUTimingSupported()
+}
+
+func (r *renderer) BeginGPUTimings() {
+	r.backend.BeginGPUTimings()
+}
+
+func (r *renderer) MarkGPUTiming(phase string) {
+	r.backend.MarkGPUTiming(phase)
+}
+
+func (r *renderer) EndGPUTimings() {
+	r.backend.EndGPUTimings()
+}
+
+func (r *renderer) GPUTimings() []GPUTiming {
+	return r.backend.GPUTimings()
+}
+
+func (r *renderer) TargetSize() (uint32, uint32) {
+	return r.backend.TargetSize()
+}
+
+func (r *renderer) CreateTexture(label string, width, height, sampleCount uint32, format wgpu.TextureFormat, usage wgpu.TextureUsage) (*wgpu.Texture, *wgpu.TextureView, error) {
+	return r.backend.CreateTexture(label, width, height, sampleCount, format, usage)
+}
+
+func (r *renderer) CreateBuffer(label string, size uint64, usage wgpu.BufferUsage) (*wgpu.Buffer, error) {
+	return r.backend.CreateBuffer(label, size, usage)
+}
+
+func (r *renderer) BeginRenderPass(desc *wgpu.RenderPassDescriptor) error {
+	return r.backend.BeginRenderPass(desc)
+}
+
+func (r *renderer) EndRenderPass() {
+	r.backend.EndRenderPass()
+}
+
+func (r *renderer) SetViewport(x, y, width, height float32) {
+	r.backend.SetViewport(x, y, width, height)
+}
+
+func (r *renderer) SetScissorRect(x, y, width, height uint32) {
+	r.backend.SetScissorRect(x, y, width, height)
+}
+
+func (r *renderer) ClearViewport(color *wgpu.Color, depth bool) error {
+	return r.backend.ClearViewport(color, depth)
+}
+
+func (r *renderer) PostEffect(name string) PostEffect {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.postEffects {
+		if e.Name() == name {
+			return e
+		}
+	}
+	return nil
+}
+
+func (r *renderer) PostEffects() []PostEffect {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]PostEffect(nil), r.postEffects...)
+}
+
+func (r *renderer) AddPostEffect(e PostEffect) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.backend.RegisterPostEffect(e); err != nil {
+		return err
+	}
+	r.postEffects = append(r.postEffects, e)
+	r.backend.SetPostEffects(r.postEffects)
+	return nil
+}
+
+func (r *renderer) RemovePostEffect(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, e := range r.postEffects {
+		if e.Name() == name {
+			r.postEffects = append(r.postEffects[:i:i], r.postEffects[i+1:]...)
+			break
+		}
+	}
+	r.backend.SetPostEffects(r.postEffects)
+}
+
+func (r *renderer) SetPostEffects(effects []PostEffect) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range effects {
+		if err := r.backend.RegisterPostEffect(e); err != nil {
+			return err
+		}
+	}
+	r.postEffects = append([]PostEffect(nil), effects...)
+	r.backend.SetPostEffects(r.postEffects)
+	return nil
+}
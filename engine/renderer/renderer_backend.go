@@ -1,5 +1,7 @@
 package renderer
 
+import "time"
+
 // RendererBackendType identifies the GPU backend implementation used by the Renderer.
 type RendererBackendType int
 
@@ -40,6 +42,12 @@ const (
 	MSAA16x MSAASampleCount = 16
 )
 
+// GPUTiming is the GPU time spent in one named phase of a frame, measured between two timestamps.
+type GPUTiming struct {
+	Phase    string
+	Duration time.Duration
+}
+
 // RendererBackend is the top-level backend interface for the Renderer.
 // It embeds the concrete backend interface for the selected GPU API.
 type RendererBackend interface {
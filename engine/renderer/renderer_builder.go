@@ -77,3 +77,65 @@ func WithForceSoftwareRenderer(force bool) RendererBuilderOption {
 		r.forceFallbackAdapter = force
 	}
 }
+
+// WithHDR renders the main pass into an RGBA16Float scene target instead of the surface format,
+// so lighting values above 1 survive until post-processing. Add a tonemapping effect to map the
+// result to display range; without post effects the HDR target is copied to the surface and clamped.
+//
+// Parameters:
+//   - enabled: true to render in HDR
+//
+// Returns:
+//   - RendererBuilderOption: a function that applies the HDR option to a renderer
+func WithHDR(enabled bool) RendererBuilderOption {
+	return func(r *renderer) {
+		r.hdr = enabled
+	}
+}
+
+// WithOrderIndependentTransparency enables weighted blended order-independent transparency. Blended
+// materials are then drawn in a single instanced pass into accumulation and revealage targets and
+// composited over the scene, instead of being sorted and drawn back-to-front one instance at a time.
+// Requires the fragment shader of a blended material to declare the fs_oit entry point; materials
+// whose shader lacks it fall back to sorting.
+//
+// Parameters:
+//   - enabled: true to enable order-independent transparency
+//
+// Returns:
+//   - RendererBuilderOption: a function that applies the order-independent transparency option to a renderer
+func WithOrderIndependentTransparency(enabled bool) RendererBuilderOption {
+	return func(r *renderer) {
+		r.oit = enabled
+	}
+}
+
+// WithGPUTimestamps requests GPU timestamp queries so the GPU time of each frame phase can be
+// measured with BeginGPUTimings, MarkGPUTiming and EndGPUTimings. Has no effect on adapters
+// without the timestamp query feature; check GPUTimingSupported.
+//
+// Parameters:
+//   - enabled: true to request GPU timestamps
+//
+// Returns:
+//   - RendererBuilderOption: a function that applies the GPU timestamp option to a renderer
+func WithGPUTimestamps(enabled bool) RendererBuilderOption {
+	return func(r *renderer) {
+		r.gpuTimestamps = enabled
+	}
+}
+
+// WithPostEffects sets the initial post effect chain. Effects run in the given order after the
+// main render pass. NewRenderer and NewHeadlessRenderer return an error, after releasing the GPU
+// device, if an effect's pipelines cannot be created.
+//
+// Parameters:
+//   - effects: the effects in the order they run
+//
+// Returns:
+//   - RendererBuilderOption: a function that applies the post effects option to a renderer
+func WithPostEffects(effects ...PostEffect) RendererBuilderOption {
+	return func(r *renderer) {
+		r.postEffects = append(r.postEffects, effects...)
+	}
+}
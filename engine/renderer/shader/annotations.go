@@ -122,6 +122,11 @@ const (
 	// Source: engine/renderer/material/assets/effect_params.wgsl
 	AnnotationArgEffectParams AnnotationArg = "effect_params"
 
+	// AnnotationArgMaterialParams identifies the MaterialParams struct carrying the base color
+	// factor and alpha mode of a material.
+	// Source: engine/renderer/material/assets/material_params.wgsl
+	AnnotationArgMaterialParams AnnotationArg = "material_params"
+
 	// AnnotationArgLight identifies the Light struct for per-light GPU data.
 	// Source: engine/light/assets/light.wgsl
 	AnnotationArgLight AnnotationArg = "light"
@@ -142,6 +147,10 @@ const (
 	// Source: engine/light/assets/shadow_uniform.wgsl
 	AnnotationArgShadowUniform AnnotationArg = "shadow_uniform"
 
+	// AnnotationArgLocalShadow identifies the LocalShadow struct describing one shadow atlas tile of a point or spot light.
+	// Source: engine/light/assets/local_shadow.wgsl
+	AnnotationArgLocalShadow AnnotationArg = "local_shadow"
+
 	// AnnotationArgTileUniforms identifies the TileUniforms struct for Forward+ tile configuration.
 	// Source: engine/light/assets/tile_uniforms.wgsl
 	AnnotationArgTileUniforms AnnotationArg = "tile_uniforms"
@@ -181,6 +190,30 @@ const (
 	// AnnotationArgBoneInfo identifies the BoneInfo struct holding per-bone inverse bind matrices and hierarchy data.
 	// Source: engine/renderer/animator/assets/bone_info.wgsl
 	AnnotationArgBoneInfo AnnotationArg = "bone_info"
+
+	// AnnotationArgTonemapParams identifies the TonemapParams struct for the tonemapping post effect.
+	// Source: engine/renderer/post_process/assets/tonemap_params.wgsl
+	AnnotationArgTonemapParams AnnotationArg = "tonemap_params"
+
+	// AnnotationArgBloomParams identifies the BloomParams struct shared by the bloom post passes.
+	// Source: engine/renderer/post_process/assets/bloom_params.wgsl
+	AnnotationArgBloomParams AnnotationArg = "bloom_params"
+
+	// AnnotationArgFXAAParams identifies the FXAAParams struct for the FXAA post effect.
+	// Source: engine/renderer/post_process/assets/fxaa_params.wgsl
+	AnnotationArgFXAAParams AnnotationArg = "fxaa_params"
+
+	// AnnotationArgColorGradeParams identifies the ColorGradeParams struct for the LUT color grading post effect.
+	// Source: engine/renderer/post_process/assets/color_grade_params.wgsl
+	AnnotationArgColorGradeParams AnnotationArg = "color_grade_params"
+
+	// AnnotationArgEnvironmentParams identifies the EnvironmentParams struct holding the irradiance spherical harmonics for image-based lighting.
+	// Source: engine/environment/assets/environment_params.wgsl
+	AnnotationArgEnvironmentParams AnnotationArg = "environment_params"
+
+	// AnnotationArgSkyboxParams identifies the SkyboxParams struct for the skybox pass.
+	// Source: engine/environment/assets/skybox_params.wgsl
+	AnnotationArgSkyboxParams AnnotationArg = "skybox_params"
 )
 
 // ── Address space arguments ────────────────────────────────────────────────────
@@ -230,13 +263,50 @@ const (
 
 	// AnnotationArgAnimatorScratch identifies the scratch bone matrix workspace buffer used during skeletal animation blending.
 	AnnotationArgAnimatorScratch AnnotationArg = "animator_scratch"
+
+	// AnnotationArgPostProcess identifies the renderer's post-processing provider (previous pass output, scene depth,
+	// sampler, effect parameters and effect texture). Used by post effect fragment shaders; each binding carries a post_* role.
+	AnnotationArgPostProcess AnnotationArg = "post_process"
+
+	// AnnotationArgEnvironment identifies the scene environment provider for lit shaders (prefiltered specular cubemap,
+	// BRDF lookup table, sampler and EnvironmentParams uniform). Each texture and sampler binding carries an env_* role.
+	AnnotationArgEnvironment AnnotationArg = "environment"
+
+	// AnnotationArgSkybox identifies the scene's skybox provider (sky cubemap, sampler and SkyboxParams uniform).
+	// Used by the built-in skybox shader; each texture and sampler binding carries a skybox_* role.
+	AnnotationArgSkybox AnnotationArg = "skybox"
+
+	// AnnotationArgRenderTarget identifies a render target bound to a model by Scene.BindRenderTarget (the target's
+	// color texture and a sampler). Each binding carries a render_target_* role.
+	AnnotationArgRenderTarget AnnotationArg = "render_target"
+
+	// AnnotationArgDebugDraw identifies the debug draw vertex buffer (a storage array of DebugVertex line
+	// vertices pulled by vertex index). Used by the built-in debug draw shader.
+	AnnotationArgDebugDraw AnnotationArg = "debug_draw"
+
+	// AnnotationArgText identifies the text renderer's providers: the TextParams uniform and TextGlyph
+	// storage array of the vertex shader, and the glyph atlas of the fragment shader. Used by the
+	// built-in text shaders; the atlas texture and sampler bindings carry a text_* role.
+	AnnotationArgText AnnotationArg = "text"
+
+	// AnnotationArgUI identifies the UI's providers: the UIParams uniform and UIQuad storage array of
+	// the vertex shader, and the glyph atlas of the fragment shader. Used by the built-in UI shaders;
+	// the atlas texture and sampler bindings carry the same text_* roles as the text renderer's.
+	AnnotationArgUI AnnotationArg = "ui"
+
+	// AnnotationArgSprite identifies the sprite batch's providers: the SpriteParams uniform and
+	// SpriteInstance storage array of the vertex shader, and the sprite texture of the fragment shader.
+	// Used by the built-in sprite shaders; the texture and sampler bindings carry a sprite_* role.
+	AnnotationArgSprite AnnotationArg = "sprite"
 )
 
-// ── Material binding role arguments ────────────────────────────────────────────
-// These qualify individual bindings within a material provider group. They appear
-// as the optional fourth argument of an @oxy:provider annotation when the provider
-// identity is "material", telling the loader which texture or sampler role each
-// binding fulfils without relying on variable-name string matching.
+// ── Binding role arguments ─────────────────────────────────────────────────────
+// These qualify individual bindings within a multi-binding provider group. They appear
+// as the optional fourth argument of an @oxy:provider annotation, telling the loader
+// (for "material"), the renderer (for "post_process"), the scene (for "environment",
+// "skybox" and "render_target"), the text renderer (for "text"), the UI (for "ui") or the
+// sprite batch (for "sprite") which resource each binding fulfils without relying on
+// variable-name string matching.
 
 const (
 	// AnnotationArgDiffuseTexture identifies a diffuse / base-color texture binding.
@@ -256,6 +326,60 @@ const (
 
 	// AnnotationArgMetallicRoughnessSampler identifies the sampler paired with the metallic-roughness texture.
 	AnnotationArgMetallicRoughnessSampler AnnotationArg = "metallic_roughness_sampler"
+
+	// AnnotationArgPostInput identifies the output of the previous post pass (texture_2d<f32>).
+	// For the first pass of the chain this is the scene color target.
+	AnnotationArgPostInput AnnotationArg = "post_input"
+
+	// AnnotationArgPostSource identifies the input of the current effect's first pass (texture_2d<f32>),
+	// so later passes of a multi-pass effect can combine their result with the original image.
+	AnnotationArgPostSource AnnotationArg = "post_source"
+
+	// AnnotationArgPostDepth identifies the scene depth buffer (texture_depth_2d), resolved to a single sample when MSAA is on.
+	AnnotationArgPostDepth AnnotationArg = "post_depth"
+
+	// AnnotationArgPostSampler identifies the renderer's shared linear clamp-to-edge sampler.
+	AnnotationArgPostSampler AnnotationArg = "post_sampler"
+
+	// AnnotationArgPostTexture identifies the effect's own texture (texture_2d<f32>), e.g. a color grading LUT.
+	AnnotationArgPostTexture AnnotationArg = "post_texture"
+
+	// AnnotationArgPostParams identifies the effect's parameter uniform buffer.
+	AnnotationArgPostParams AnnotationArg = "post_params"
+
+	// AnnotationArgEnvSpecular identifies the prefiltered specular environment cubemap (texture_cube<f32>).
+	// Mip level m holds the environment convolved for roughness m / EnvironmentParams.specular_max_lod.
+	AnnotationArgEnvSpecular AnnotationArg = "env_specular"
+
+	// AnnotationArgEnvBRDFLUT identifies the split-sum BRDF lookup table (texture_2d<f32>), sampled at (N·V, roughness).
+	AnnotationArgEnvBRDFLUT AnnotationArg = "env_brdf_lut"
+
+	// AnnotationArgEnvSampler identifies the linear clamp-to-edge sampler shared by the environment textures.
+	AnnotationArgEnvSampler AnnotationArg = "env_sampler"
+
+	// AnnotationArgSkyboxTexture identifies the sky cubemap (texture_cube<f32>) drawn by the skybox pass.
+	AnnotationArgSkyboxTexture AnnotationArg = "skybox_texture"
+
+	// AnnotationArgSkyboxSampler identifies the sampler paired with the sky cubemap.
+	AnnotationArgSkyboxSampler AnnotationArg = "skybox_sampler"
+
+	// AnnotationArgRenderTargetTexture identifies a render target's color texture (texture_2d<f32>).
+	AnnotationArgRenderTargetTexture AnnotationArg = "render_target_texture"
+
+	// AnnotationArgRenderTargetSampler identifies the linear clamp-to-edge sampler paired with the render target texture.
+	AnnotationArgRenderTargetSampler AnnotationArg = "render_target_sampler"
+
+	// AnnotationArgTextAtlas identifies the signed distance field glyph atlas (texture_2d<f32>, one channel).
+	AnnotationArgTextAtlas AnnotationArg = "text_atlas"
+
+	// AnnotationArgTextSampler identifies the linear clamp-to-edge sampler paired with the glyph atlas.
+	AnnotationArgTextSampler AnnotationArg = "text_sampler"
+
+	// AnnotationArgSpriteTexture identifies the texture of a batch of sprites (texture_2d<f32>).
+	AnnotationArgSpriteTexture AnnotationArg = "sprite_texture"
+
+	// AnnotationArgSpriteSampler identifies the clamp-to-edge sampler paired with the sprite texture.
+	AnnotationArgSpriteSampler AnnotationArg = "sprite_sampler"
 )
 
 // validStructTypes lists all AnnotationArg values that are accepted as struct type
@@ -267,11 +391,13 @@ var validStructTypes = []AnnotationArg{
 	annotationArgSkinnedVertex,
 	AnnotationArgOverlayParams,
 	AnnotationArgEffectParams,
+	AnnotationArgMaterialParams,
 	AnnotationArgLight,
 	AnnotationArgLightHeader,
 	annotationArgLightCullUniforms,
 	AnnotationArgShadowData,
 	AnnotationArgShadowUniform,
+	AnnotationArgLocalShadow,
 	AnnotationArgTileUniforms,
 	AnnotationArgAnimationData,
 	AnnotationArgSkeletalAnimationData,
@@ -282,6 +408,12 @@ var validStructTypes = []AnnotationArg{
 	AnnotationArgBoneInfo,
 	AnnotationArgInstanceData,
 	AnnotationArgModelData,
+	AnnotationArgTonemapParams,
+	AnnotationArgBloomParams,
+	AnnotationArgFXAAParams,
+	AnnotationArgColorGradeParams,
+	AnnotationArgEnvironmentParams,
+	AnnotationArgSkyboxParams,
 }
 
 // validAddressSpaces lists all AnnotationArg values that are accepted as address
@@ -306,11 +438,19 @@ var validProviderIdentities = []AnnotationArg{
 	AnnotationArgAnimatorOutput,
 	AnnotationArgAnimatorPacked,
 	AnnotationArgAnimatorScratch,
+	AnnotationArgPostProcess,
+	AnnotationArgEnvironment,
+	AnnotationArgSkybox,
+	AnnotationArgRenderTarget,
+	AnnotationArgDebugDraw,
+	AnnotationArgText,
+	AnnotationArgUI,
+	AnnotationArgSprite,
 }
 
 // validBindingRoles lists all AnnotationArg values that are accepted as binding
 // role qualifiers in @oxy:provider annotations. These identify the semantic purpose
-// of individual bindings within a material provider group.
+// of individual bindings within a material, post_process, environment, skybox, render_target, text, ui or sprite provider group.
 var validBindingRoles = []AnnotationArg{
 	AnnotationArgDiffuseTexture,
 	AnnotationArgDiffuseSampler,
@@ -318,6 +458,23 @@ var validBindingRoles = []AnnotationArg{
 	AnnotationArgNormalSampler,
 	AnnotationArgMetallicRoughnessTexture,
 	AnnotationArgMetallicRoughnessSampler,
+	AnnotationArgPostInput,
+	AnnotationArgPostSource,
+	AnnotationArgPostDepth,
+	AnnotationArgPostSampler,
+	AnnotationArgPostTexture,
+	AnnotationArgPostParams,
+	AnnotationArgEnvSpecular,
+	AnnotationArgEnvBRDFLUT,
+	AnnotationArgEnvSampler,
+	AnnotationArgSkyboxTexture,
+	AnnotationArgSkyboxSampler,
+	AnnotationArgRenderTargetTexture,
+	AnnotationArgRenderTargetSampler,
+	AnnotationArgTextAtlas,
+	AnnotationArgTextSampler,
+	AnnotationArgSpriteTexture,
+	AnnotationArgSpriteSampler,
 }
 
 // parseAnnotation attempts to parse a single line of WGSL source as an @oxy: annotation.
@@ -17,10 +17,12 @@ import (
 	"strings"
 
 	"github.com/Carmen-Shannon/oxy-go/engine/camera"
+	"github.com/Carmen-Shannon/oxy-go/engine/environment"
 	"github.com/Carmen-Shannon/oxy-go/engine/light"
 	"github.com/Carmen-Shannon/oxy-go/engine/model"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/animator"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/material"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/post_process"
 )
 
 // registryEntry pairs a WGSL struct source string (embedded from a .wgsl asset file)
@@ -93,10 +95,12 @@ func NewPreProcessor() PreProcessor {
 			annotationArgSkinnedVertex:         {Source: model.GPUSkinnedVertexSource, Type: "VertexInput"},
 			AnnotationArgOverlayParams:         {Source: material.GPUOverlayParamsSource, Type: "OverlayParams"},
 			AnnotationArgEffectParams:          {Source: material.GPUEffectParamsSource, Type: "EffectParams"},
+			AnnotationArgMaterialParams:        {Source: material.GPUMaterialParamsSource, Type: "MaterialParams"},
 			AnnotationArgLight:                 {Source: light.GPULightSource, Type: "Light"},
 			AnnotationArgLightHeader:           {Source: light.GPULightHeaderSource, Type: "LightHeader"},
 			AnnotationArgShadowData:            {Source: light.GPUShadowDataSource, Type: "ShadowData"},
 			AnnotationArgShadowUniform:         {Source: light.GPUShadowUniformSource, Type: "ShadowUniform"},
+			AnnotationArgLocalShadow:           {Source: light.GPULocalShadowSource, Type: "LocalShadow"},
 			annotationArgLightCullUniforms:     {Source: light.GPULightCullUniformsSource, Type: "LightCullUniforms"},
 			AnnotationArgTileUniforms:          {Source: light.GPUTileUniformsSource, Type: "TileUniforms"},
 			AnnotationArgAnimationData:         {Source: animator.GPUAnimationDataSource, Type: "AnimationData"},
@@ -108,6 +112,12 @@ func NewPreProcessor() PreProcessor {
 			AnnotationArgBoneInfo:              {Source: animator.GPUBoneInfoSource, Type: "BoneInfo"},
 			AnnotationArgInstanceData:          {Source: animator.GPUInstanceDataSource, Type: "InstanceData"},
 			AnnotationArgModelData:             {Source: model.GPUModelDataSource, Type: "ModelData"},
+			AnnotationArgTonemapParams:         {Source: post_process.GPUTonemapParamsSource, Type: "TonemapParams"},
+			AnnotationArgBloomParams:           {Source: post_process.GPUBloomParamsSource, Type: "BloomParams"},
+			AnnotationArgFXAAParams:            {Source: post_process.GPUFXAAParamsSource, Type: "FXAAParams"},
+			AnnotationArgColorGradeParams:      {Source: post_process.GPUColorGradeParamsSource, Type: "ColorGradeParams"},
+			AnnotationArgEnvironmentParams:     {Source: environment.GPUEnvironmentParamsSource, Type: "EnvironmentParams"},
+			AnnotationArgSkyboxParams:          {Source: environment.GPUSkyboxParamsSource, Type: "SkyboxParams"},
 		},
 		addressSpaceRegistry: map[AnnotationArg]string{
 			annotationArgStorageTypeUniform:   "var<uniform>",
@@ -3,6 +3,7 @@ package shader
 import (
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/cogentcore/webgpu/wgpu"
 )
@@ -21,6 +22,11 @@ const (
 	ShaderTypeFragment
 )
 
+// WeightedBlendedEntryPoint is the name of the optional fragment entry point used by the weighted
+// blended order-independent transparency pass. A fragment shader that declares it alongside its
+// default entry point can render blended materials into the accumulation and revealage targets.
+const WeightedBlendedEntryPoint = "fs_oit"
+
 // shader is the implementation of the Shader interface.
 // It holds all of the persistent shader data required for pipeline creation and material binding.
 type shader struct {
@@ -32,6 +38,7 @@ type shader struct {
 	vertexLayouts              map[int][]wgpu.VertexBufferLayout
 	workGroupSize              [3]uint32
 	entryPoint                 string
+	entryPoints                []string
 	module                     *wgpu.ShaderModuleDescriptor
 
 	pp PreProcessor
@@ -119,6 +126,16 @@ type Shader interface {
 	//   - string: the entry point name (e.g. "main")
 	EntryPoint() string
 
+	// HasEntryPoint reports whether the shader declares an entry point of its stage with the given name.
+	// The default entry point returned by EntryPoint is the first one declared in the source.
+	//
+	// Parameters:
+	//   - name: the entry point function name to look for
+	//
+	// Returns:
+	//   - bool: true if the shader declares the entry point
+	HasEntryPoint(name string) bool
+
 	// WorkgroupSize returns the workgroup size dimensions for compute shaders.
 	// Returns [0, 0, 0] for non-compute shaders and [1, 1, 1] as the default when
 	// @workgroup_size is not specified.
@@ -177,6 +194,33 @@ func NewShader(key string, shaderType ShaderType, sourcePath string) Shader {
 	return s
 }
 
+// NewShaderFromSource creates a new Shader from WGSL source held in memory, such as a source
+// embedded with go:embed. The source is pre-processed and parsed exactly like NewShader.
+//
+// Parameters:
+//   - key: a unique identifier for the shader, used for caching and lookups
+//   - shaderType: the type of shader (vertex, fragment or compute), used for validation and pipeline setup
+//   - source: the raw WGSL source, which may contain @oxy annotations
+//
+// Returns:
+//   - Shader: a new Shader instance with the provided configuration
+func NewShaderFromSource(key string, shaderType ShaderType, source string) Shader {
+	if source == "" {
+		panic(fmt.Sprintf("shader: %s must have a non-empty source", key))
+	}
+	s := &shader{
+		key:                        key,
+		shaderType:                 shaderType,
+		bindGroupLayoutDescriptors: make(map[int]wgpu.BindGroupLayoutDescriptor),
+		bindingVarNames:            make(map[int]map[int]string),
+		vertexLayouts:              make(map[int][]wgpu.VertexBufferLayout),
+		workGroupSize:              [3]uint32{0, 0, 0},
+		pp:                         NewPreProcessor(),
+	}
+	s.parseSource(source, key)
+	return s
+}
+
 func (s *shader) Key() string {
 	return s.key
 }
@@ -197,6 +241,10 @@ func (s *shader) EntryPoint() string {
 	return s.entryPoint
 }
 
+func (s *shader) HasEntryPoint(name string) bool {
+	return slices.Contains(s.entryPoints, name)
+}
+
 func (s *shader) WorkgroupSize() [3]uint32 {
 	return s.workGroupSize
 }
@@ -252,18 +300,25 @@ func (s *shader) Declarations() []Annotation {
 	return s.pp.Declarations()
 }
 
-// parseSource sets the WGSL source, builds the shader module descriptor, parses the
-// entry point name, and extracts layout metadata appropriate for the shader type.
-// Vertex shaders get vertex buffer layouts parsed. Compute shaders get workgroup size
-// parsed. All shader types get bind group layout descriptors parsed.
+// parseSourceFromPath reads the WGSL source from a file and parses it with parseSource.
 func (s *shader) parseSourceFromPath(path string) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		panic(fmt.Sprintf("shader: failed to read source file %q: %v", path, err))
 	}
-	s.source, err = s.pp.Process(string(data))
+	s.parseSource(string(data), path)
+}
+
+// parseSource sets the WGSL source, builds the shader module descriptor, parses the
+// entry point name, and extracts layout metadata appropriate for the shader type.
+// Vertex shaders get vertex buffer layouts parsed. Compute shaders get workgroup size
+// parsed. All shader types get bind group layout descriptors parsed. The origin names
+// the source in error messages.
+func (s *shader) parseSource(raw, origin string) {
+	var err error
+	s.source, err = s.pp.Process(raw)
 	if err != nil {
-		panic(fmt.Sprintf("shader: failed to pre-process shader source %q: %v", path, err))
+		panic(fmt.Sprintf("shader: failed to pre-process shader source %q: %v", origin, err))
 	}
 	s.module = &wgpu.ShaderModuleDescriptor{
 		Label: s.key,
@@ -271,7 +326,10 @@ func (s *shader) parseSourceFromPath(path string) {
 			Code: s.source,
 		},
 	}
-	s.entryPoint = parseEntryPoint(s.source, s.shaderType)
+	s.entryPoints = parseEntryPoints(s.source, s.shaderType)
+	if len(s.entryPoints) > 0 {
+		s.entryPoint = s.entryPoints[0]
+	}
 	if s.shaderType == ShaderTypeVertex {
 		s.vertexLayouts = parseVertexLayouts(s.source)
 	}
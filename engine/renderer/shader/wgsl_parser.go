@@ -258,16 +258,16 @@ func parseWorkgroupSize(source string) [3]uint32 {
 	return result
 }
 
-// parseEntryPoint extracts the entry point function name for the given shader type
-// from WGSL source. Returns an empty string if no matching entry point annotation is found.
+// parseEntryPoints extracts every entry point function name for the given shader type
+// from WGSL source, in declaration order. Returns nil if no matching entry point is found.
 //
 // Parameters:
 //   - source: the raw WGSL source code string
 //   - shaderType: the shader type to search for (ShaderTypeVertex, ShaderTypeFragment, or ShaderTypeCompute)
 //
 // Returns:
-//   - string: the entry point function name, or empty string if not found
-func parseEntryPoint(source string, shaderType ShaderType) string {
+//   - []string: the entry point function names, or nil if none were found
+func parseEntryPoints(source string, shaderType ShaderType) []string {
 	cleaned := stripComments(source)
 
 	var re *regexp.Regexp
@@ -279,13 +279,14 @@ func parseEntryPoint(source string, shaderType ShaderType) string {
 	case ShaderTypeCompute:
 		re = computeEntryRegex
 	default:
-		return ""
+		return nil
 	}
 
-	if match := re.FindStringSubmatch(cleaned); match != nil {
-		return match[1]
+	var names []string
+	for _, match := range re.FindAllStringSubmatch(cleaned, -1) {
+		names = append(names, match[1])
 	}
-	return ""
+	return names
 }
 
 // parseStructBlocks finds all struct { ... } blocks in the cleaned WGSL source
@@ -0,0 +1,271 @@
+package renderer
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+const (
+	// gpuTimerMaxQueries is the number of timestamps a frame can record: the frame start
+	// marker plus one per timed phase. Marks past the limit are dropped.
+	gpuTimerMaxQueries = 16
+
+	// gpuTimerReadbacks is the number of frames whose timestamps can be in flight at once.
+	// A frame that finds every readback buffer still waiting on the GPU is not timed.
+	gpuTimerReadbacks = 3
+
+	// gpuTimestampPeriod is the length of one timestamp tick in nanoseconds. The wgpu binding
+	// does not expose the queue's timestamp period, so ticks are taken to be nanoseconds.
+	gpuTimestampPeriod = 1.0
+)
+
+// gpuTimerReadback is a mappable copy of one frame's resolved timestamps.
+type gpuTimerReadback struct {
+	buffer  *wgpu.Buffer
+	phases  []string // the phase each timestamp after the first one ends
+	pending bool     // MapAsync issued, callback not yet fired
+	mapped  bool     // mapped and waiting to be read by GPUTimings
+	frame   uint64
+}
+
+func (b *wgpuRendererBackendImpl) GPUTimingSupported() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.gpuTimestamps
+}
+
+func (b *wgpuRendererBackendImpl) BeginGPUTimings() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.gpuTimestamps {
+		return
+	}
+	if err := b.ensureGPUTimerResources(); err != nil {
+		b.disableGPUTimings()
+		return
+	}
+	b.gpuTimerQueries = 0
+	b.gpuTimerPhases = b.gpuTimerPhases[:0]
+	b.writeGPUTimestamp()
+}
+
+func (b *wgpuRendererBackendImpl) MarkGPUTiming(phase string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.gpuTimestamps || b.gpuTimerQueries == 0 || b.gpuTimerQueries >= gpuTimerMaxQueries {
+		return
+	}
+	if b.writeGPUTimestamp() {
+		b.gpuTimerPhases = append(b.gpuTimerPhases, phase)
+	}
+}
+
+func (b *wgpuRendererBackendImpl) EndGPUTimings() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := b.gpuTimerQueries
+	b.gpuTimerQueries = 0
+	if !b.gpuTimestamps || count < 2 {
+		return
+	}
+
+	var rb *gpuTimerReadback
+	for i := range b.gpuTimerReadbacks {
+		if r := &b.gpuTimerReadbacks[i]; !r.pending && !r.mapped {
+			rb = r
+			break
+		}
+	}
+	if rb == nil {
+		return
+	}
+
+	encoder, err := b.device.CreateCommandEncoder(nil)
+	if err != nil {
+		return
+	}
+	size := uint64(count) * 8
+	if err := encoder.ResolveQuerySet(b.gpuTimerQuerySet, 0, count, b.gpuTimerResolve, 0); err != nil {
+		encoder.Release()
+		b.disableGPUTimings()
+		return
+	}
+	if err := encoder.CopyBufferToBuffer(b.gpuTimerResolve, 0, rb.buffer, 0, size); err != nil {
+		encoder.Release()
+		return
+	}
+	commandBuffer, err := encoder.Finish(nil)
+	encoder.Release()
+	if err != nil {
+		return
+	}
+	b.queue.Submit(commandBuffer)
+	commandBuffer.Release()
+
+	b.gpuTimerFrame++
+	rb.phases = append(rb.phases[:0], b.gpuTimerPhases...)
+	rb.frame = b.gpuTimerFrame
+	rb.pending = true
+	// The callback fires from within a later Poll or Submit, both of which run with b.mu held.
+	err = rb.buffer.MapAsync(wgpu.MapModeRead, 0, size, func(s wgpu.BufferMapAsyncStatus) {
+		rb.pending = false
+		rb.mapped = s == wgpu.BufferMapAsyncStatusSuccess
+	})
+	if err != nil {
+		rb.pending = false
+	}
+}
+
+func (b *wgpuRendererBackendImpl) GPUTimings() []GPUTiming {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.gpuTimerQuerySet == nil {
+		return nil
+	}
+	b.device.Poll(false, nil)
+
+	// Read the newest mapped frame and recycle any older ones.
+	var newest *gpuTimerReadback
+	for i := range b.gpuTimerReadbacks {
+		r := &b.gpuTimerReadbacks[i]
+		if r.mapped && (newest == nil || r.frame > newest.frame) {
+			newest = r
+		}
+	}
+	if newest == nil {
+		return nil
+	}
+
+	count := len(newest.phases) + 1
+	mapped := newest.buffer.GetMappedRange(0, uint(count*8))
+	timings := make([]GPUTiming, 0, len(newest.phases))
+	prev := binary.LittleEndian.Uint64(mapped)
+	for i, phase := range newest.phases {
+		ts := binary.LittleEndian.Uint64(mapped[(i+1)*8:])
+		var d time.Duration
+		if ts > prev {
+			d = time.Duration(float64(ts-prev) * gpuTimestampPeriod)
+		}
+		timings = append(timings, GPUTiming{Phase: phase, Duration: d})
+		prev = ts
+	}
+
+	for i := range b.gpuTimerReadbacks {
+		if r := &b.gpuTimerReadbacks[i]; r.mapped {
+			r.buffer.Unmap()
+			r.mapped = false
+		}
+	}
+	return timings
+}
+
+// writeGPUTimestamp submits a command buffer that writes the next timestamp query. Queue
+// submissions execute in order, so the timestamp lands after all work submitted before it.
+// Disables GPU timings if the device rejects the write. Caller must hold b.mu.
+//
+// Returns:
+//   - bool: true if the timestamp was written
+func (b *wgpuRendererBackendImpl) writeGPUTimestamp() bool {
+	encoder, err := b.device.CreateCommandEncoder(nil)
+	if err != nil {
+		return false
+	}
+	if err := encoder.WriteTimestamp(b.gpuTimerQuerySet, b.gpuTimerQueries); err != nil {
+		encoder.Release()
+		b.disableGPUTimings()
+		return false
+	}
+	commandBuffer, err := encoder.Finish(nil)
+	encoder.Release()
+	if err != nil {
+		return false
+	}
+	b.queue.Submit(commandBuffer)
+	commandBuffer.Release()
+	b.gpuTimerQueries++
+	return true
+}
+
+// ensureGPUTimerResources creates the timestamp query set, the resolve buffer and the readback
+// buffers on first use. Caller must hold b.mu.
+//
+// Returns:
+//   - error: an error if a resource could not be created
+func (b *wgpuRendererBackendImpl) ensureGPUTimerResources() error {
+	if b.gpuTimerQuerySet != nil {
+		return nil
+	}
+
+	querySet, err := b.device.CreateQuerySet(&wgpu.QuerySetDescriptor{
+		Label: "GPU Timer Queries",
+		Type:  wgpu.QueryTypeTimestamp,
+		Count: gpuTimerMaxQueries,
+	})
+	if err != nil {
+		return err
+	}
+	size := uint64(gpuTimerMaxQueries) * 8
+	resolve, err := b.device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "GPU Timer Resolve",
+		Size:  size,
+		Usage: wgpu.BufferUsageQueryResolve | wgpu.BufferUsageCopySrc,
+	})
+	if err != nil {
+		querySet.Release()
+		return err
+	}
+
+	b.gpuTimerQuerySet = querySet
+	b.gpuTimerResolve = resolve
+	b.gpuTimerReadbacks = make([]gpuTimerReadback, gpuTimerReadbacks)
+	for i := range b.gpuTimerReadbacks {
+		buf, err := b.device.CreateBuffer(&wgpu.BufferDescriptor{
+			Label: "GPU Timer Readback",
+			Size:  size,
+			Usage: wgpu.BufferUsageMapRead | wgpu.BufferUsageCopyDst,
+		})
+		if err != nil {
+			b.releaseGPUTimerResources()
+			return err
+		}
+		b.gpuTimerReadbacks[i].buffer = buf
+	}
+	return nil
+}
+
+// disableGPUTimings turns GPU timings off for the rest of the backend's life, for adapters that
+// expose the timestamp feature but reject timestamps written outside a pass. Caller must hold b.mu.
+func (b *wgpuRendererBackendImpl) disableGPUTimings() {
+	b.gpuTimestamps = false
+	b.gpuTimerQueries = 0
+	b.releaseGPUTimerResources()
+}
+
+// releaseGPUTimerResources frees the timestamp query set and its buffers. Caller must hold b.mu.
+func (b *wgpuRendererBackendImpl) releaseGPUTimerResources() {
+	for i := range b.gpuTimerReadbacks {
+		r := &b.gpuTimerReadbacks[i]
+		if r.buffer == nil {
+			continue
+		}
+		if r.mapped {
+			r.buffer.Unmap()
+		}
+		r.buffer.Release()
+	}
+	b.gpuTimerReadbacks = nil
+	if b.gpuTimerResolve != nil {
+		b.gpuTimerResolve.Release()
+		b.gpuTimerResolve = nil
+	}
+	if b.gpuTimerQuerySet != nil {
+		b.gpuTimerQuerySet.Release()
+		b.gpuTimerQuerySet = nil
+	}
+}
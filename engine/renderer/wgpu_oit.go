@@ -0,0 +1,363 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/post_process"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+const (
+	// oitAccumFormat is the format of the weighted blended accumulation target. It sums
+	// premultiplied color and alpha scaled by the depth weight, so it needs float range.
+	oitAccumFormat = wgpu.TextureFormatRGBA16Float
+
+	// oitRevealFormat is the format of the weighted blended revealage target, the product
+	// of (1 - alpha) over every transparent layer.
+	oitRevealFormat = wgpu.TextureFormatR8Unorm
+)
+
+// oitTarget is one weighted blended transparency render target. When MSAA is enabled the pass
+// draws into the multisampled texture and resolves into the single-sample one; otherwise only
+// the single-sample texture exists and is drawn into directly.
+type oitTarget struct {
+	msaaTexture *wgpu.Texture
+	msaaView    *wgpu.TextureView
+	texture     *wgpu.Texture
+	view        *wgpu.TextureView
+}
+
+func (b *wgpuRendererBackendImpl) OrderIndependentTransparency() bool {
+	return b.oit
+}
+
+func (b *wgpuRendererBackendImpl) BeginTransparentPass() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.oit {
+		return nil
+	}
+	if b.framePass == nil {
+		return fmt.Errorf("no frame in progress")
+	}
+	if b.renderPassEncoder != nil {
+		return fmt.Errorf("weighted blended transparency is only available in the main frame pass")
+	}
+	if err := b.ensureOITResources(); err != nil {
+		return err
+	}
+
+	b.framePass.End()
+	b.framePass = b.frameEncoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		ColorAttachments: []wgpu.RenderPassColorAttachment{
+			b.oitAttachment(b.oitAccum, wgpu.Color{}),
+			b.oitAttachment(b.oitReveal, wgpu.Color{R: 1, G: 1, B: 1, A: 1}),
+		},
+		DepthStencilAttachment: &wgpu.RenderPassDepthStencilAttachment{
+			View:         b.depthTextureView,
+			DepthLoadOp:  wgpu.LoadOpLoad,
+			DepthStoreOp: wgpu.StoreOpStore,
+		},
+	})
+	b.applyViewport()
+	b.oitPassActive = true
+	return nil
+}
+
+func (b *wgpuRendererBackendImpl) EndTransparentPass() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.oitPassActive {
+		return
+	}
+	b.oitPassActive = false
+
+	b.framePass.End()
+
+	// Resume the main pass on top of what was already drawn and composite the
+	// transparent layers over it. Later draws in the frame land in this pass.
+	color := b.renderPassDescriptor.ColorAttachments[0]
+	color.LoadOp = wgpu.LoadOpLoad
+	depth := *b.renderPassDescriptor.DepthStencilAttachment
+	depth.DepthLoadOp = wgpu.LoadOpLoad
+	b.framePass = b.frameEncoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		ColorAttachments:       []wgpu.RenderPassColorAttachment{color},
+		DepthStencilAttachment: &depth,
+	})
+	b.applyViewport()
+	b.framePass.SetPipeline(b.oitCompositePipeline)
+	b.framePass.SetBindGroup(0, b.oitBindGroup, nil)
+	b.framePass.Draw(3, 1, 0, 0)
+}
+
+// oitAttachment builds the color attachment for one transparency target, cleared to the given
+// value. Multisampled targets resolve into their single-sample texture and are not stored.
+// Caller must hold b.mu.
+//
+// Parameters:
+//   - t: the transparency target
+//   - clear: the clear value
+//
+// Returns:
+//   - wgpu.RenderPassColorAttachment: the attachment
+func (b *wgpuRendererBackendImpl) oitAttachment(t *oitTarget, clear wgpu.Color) wgpu.RenderPassColorAttachment {
+	if t.msaaView != nil {
+		return wgpu.RenderPassColorAttachment{
+			View:          t.msaaView,
+			ResolveTarget: t.view,
+			LoadOp:        wgpu.LoadOpClear,
+			StoreOp:       wgpu.StoreOpDiscard,
+			ClearValue:    clear,
+		}
+	}
+	return wgpu.RenderPassColorAttachment{
+		View:       t.view,
+		LoadOp:     wgpu.LoadOpClear,
+		StoreOp:    wgpu.StoreOpStore,
+		ClearValue: clear,
+	}
+}
+
+// oitColorTargets returns the color target states used by weighted blended pipelines: an additive
+// accumulation target and a revealage target that multiplies by (1 - alpha).
+//
+// Returns:
+//   - []wgpu.ColorTargetState: the accumulation and revealage target states
+func oitColorTargets() []wgpu.ColorTargetState {
+	additive := wgpu.BlendComponent{
+		SrcFactor: wgpu.BlendFactorOne,
+		DstFactor: wgpu.BlendFactorOne,
+		Operation: wgpu.BlendOperationAdd,
+	}
+	reveal := wgpu.BlendComponent{
+		SrcFactor: wgpu.BlendFactorZero,
+		DstFactor: wgpu.BlendFactorOneMinusSrc,
+		Operation: wgpu.BlendOperationAdd,
+	}
+	return []wgpu.ColorTargetState{
+		{
+			Format:    oitAccumFormat,
+			Blend:     &wgpu.BlendState{Color: additive, Alpha: additive},
+			WriteMask: wgpu.ColorWriteMaskAll,
+		},
+		{
+			Format:    oitRevealFormat,
+			Blend:     &wgpu.BlendState{Color: reveal, Alpha: reveal},
+			WriteMask: wgpu.ColorWriteMaskAll,
+		},
+	}
+}
+
+// ensureOITResources creates the composite pipeline on first use and the size-dependent
+// transparency targets and composite bind group after each resize. Caller must hold b.mu.
+//
+// Returns:
+//   - error: an error if a resource could not be created
+func (b *wgpuRendererBackendImpl) ensureOITResources() error {
+	if err := b.ensurePostResources(); err != nil {
+		return err
+	}
+	if b.oitCompositePipeline == nil {
+		if err := b.createOITCompositePipeline(); err != nil {
+			return err
+		}
+	}
+	if b.oitAccum != nil {
+		return nil
+	}
+
+	accum, err := b.createOITTarget("OIT Accumulation", oitAccumFormat)
+	if err != nil {
+		return err
+	}
+	reveal, err := b.createOITTarget("OIT Revealage", oitRevealFormat)
+	if err != nil {
+		accum.release()
+		return err
+	}
+	bg, err := b.device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+		Label:  "OIT Composite Bind Group",
+		Layout: b.oitCompositeLayout,
+		Entries: []wgpu.BindGroupEntry{
+			{Binding: 0, TextureView: accum.view},
+			{Binding: 1, TextureView: reveal.view},
+		},
+	})
+	if err != nil {
+		accum.release()
+		reveal.release()
+		return err
+	}
+
+	b.oitAccum = accum
+	b.oitReveal = reveal
+	b.oitBindGroup = bg
+	return nil
+}
+
+// createOITTarget creates a transparency target of the given format at the current target size,
+// with a multisampled companion when MSAA is enabled. Caller must hold b.mu.
+//
+// Parameters:
+//   - label: the texture label
+//   - format: the texture format
+//
+// Returns:
+//   - *oitTarget: the target
+//   - error: an error if a texture or view could not be created
+func (b *wgpuRendererBackendImpl) createOITTarget(label string, format wgpu.TextureFormat) (*oitTarget, error) {
+	create := func(label string, sampleCount uint32, usage wgpu.TextureUsage) (*wgpu.Texture, *wgpu.TextureView, error) {
+		tex, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
+			Label: label,
+			Size: wgpu.Extent3D{
+				Width:              b.targetWidth,
+				Height:             b.targetHeight,
+				DepthOrArrayLayers: 1,
+			},
+			MipLevelCount: 1,
+			SampleCount:   sampleCount,
+			Dimension:     wgpu.TextureDimension2D,
+			Format:        format,
+			Usage:         usage,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		view, err := tex.CreateView(nil)
+		if err != nil {
+			tex.Release()
+			return nil, nil, err
+		}
+		return tex, view, nil
+	}
+
+	t := &oitTarget{}
+	var err error
+	t.texture, t.view, err = create(label+" Texture", 1, wgpu.TextureUsageRenderAttachment|wgpu.TextureUsageTextureBinding)
+	if err != nil {
+		return nil, err
+	}
+	if b.sampleCount > 1 {
+		t.msaaTexture, t.msaaView, err = create(label+" MSAA Texture", uint32(b.sampleCount), wgpu.TextureUsageRenderAttachment)
+		if err != nil {
+			t.release()
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// createOITCompositePipeline creates the fullscreen pipeline that blends the resolved transparency
+// targets over the scene color inside the resumed main pass. Caller must hold b.mu.
+//
+// Returns:
+//   - error: an error if the shader module, layout or pipeline could not be created
+func (b *wgpuRendererBackendImpl) createOITCompositePipeline() error {
+	s := shader.NewShaderFromSource("oit_composite", shader.ShaderTypeFragment, post_process.OITCompositeShaderSource)
+	fs, err := b.device.CreateShaderModule(s.Module())
+	if err != nil {
+		return err
+	}
+	desc := s.BindGroupLayoutDescriptor(0)
+	layout, err := b.device.CreateBindGroupLayout(&desc)
+	if err != nil {
+		return fmt.Errorf("failed to create OIT composite bind group layout: %w", err)
+	}
+	pipelineLayout, err := b.device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label:            s.Key(),
+		BindGroupLayouts: []*wgpu.BindGroupLayout{layout},
+	})
+	if err != nil {
+		return err
+	}
+
+	created, err := b.device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  s.Key() + " Render Pipeline",
+		Layout: pipelineLayout,
+		Vertex: wgpu.VertexState{
+			Module:     b.postVertexModule,
+			EntryPoint: b.postVertexShader.EntryPoint(),
+		},
+		Fragment: &wgpu.FragmentState{
+			Module:     fs,
+			EntryPoint: s.EntryPoint(),
+			Targets: []wgpu.ColorTargetState{
+				{
+					Format: b.sceneFormat(),
+					Blend: &wgpu.BlendState{
+						Color: wgpu.BlendComponent{
+							SrcFactor: wgpu.BlendFactorSrcAlpha,
+							DstFactor: wgpu.BlendFactorOneMinusSrcAlpha,
+							Operation: wgpu.BlendOperationAdd,
+						},
+						Alpha: wgpu.BlendComponent{
+							SrcFactor: wgpu.BlendFactorOne,
+							DstFactor: wgpu.BlendFactorOneMinusSrcAlpha,
+							Operation: wgpu.BlendOperationAdd,
+						},
+					},
+					WriteMask: wgpu.ColorWriteMaskAll,
+				},
+			},
+		},
+		Primitive: wgpu.PrimitiveState{
+			Topology:  wgpu.PrimitiveTopologyTriangleList,
+			FrontFace: wgpu.FrontFaceCCW,
+			CullMode:  wgpu.CullModeNone,
+		},
+		Multisample: wgpu.MultisampleState{
+			Count: uint32(b.sampleCount),
+			Mask:  0xFFFFFFFF,
+		},
+		DepthStencil: &wgpu.DepthStencilState{
+			Format:            wgpu.TextureFormatDepth24Plus,
+			DepthWriteEnabled: false,
+			DepthCompare:      wgpu.CompareFunctionAlways,
+			StencilFront: wgpu.StencilFaceState{
+				Compare: wgpu.CompareFunctionAlways,
+			},
+			StencilBack: wgpu.StencilFaceState{
+				Compare: wgpu.CompareFunctionAlways,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	b.oitCompositeLayout = layout
+	b.oitCompositePipeline = created
+	return nil
+}
+
+// releaseOITTargets releases the size-dependent transparency targets and their composite bind
+// group so they are recreated at the new size on next use. Caller must hold b.mu.
+func (b *wgpuRendererBackendImpl) releaseOITTargets() {
+	if b.oitBindGroup != nil {
+		b.oitBindGroup.Release()
+		b.oitBindGroup = nil
+	}
+	if b.oitAccum != nil {
+		b.oitAccum.release()
+		b.oitAccum = nil
+	}
+	if b.oitReveal != nil {
+		b.oitReveal.release()
+		b.oitReveal = nil
+	}
+}
+
+// release frees the target's textures and views.
+func (t *oitTarget) release() {
+	if t.msaaView != nil {
+		t.msaaView.Release()
+		t.msaaTexture.Release()
+	}
+	if t.view != nil {
+		t.view.Release()
+		t.texture.Release()
+	}
+}
@@ -0,0 +1,821 @@
+package renderer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/post_process"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// hdrFormat is the color format of the HDR scene target and of intermediate post targets.
+const hdrFormat = wgpu.TextureFormatRGBA16Float
+
+// postTarget is a pooled intermediate color target written by one post pass and read by the next.
+type postTarget struct {
+	texture *wgpu.Texture
+	view    *wgpu.TextureView
+	width   uint32
+	height  uint32
+}
+
+// postPipeline is a cached render pipeline for one post pass shader and target format,
+// together with the bind group layouts used to build its per-frame bind groups.
+type postPipeline struct {
+	pipeline *wgpu.RenderPipeline
+	layouts  map[int]*wgpu.BindGroupLayout
+}
+
+// postEffectState holds the GPU resources of a registered PostEffect. The params buffer and
+// texture are re-uploaded when the effect's data differs from what was last uploaded.
+type postEffectState struct {
+	params          *wgpu.Buffer
+	paramsSize      uint64
+	uploadedParams  []byte
+	texture         *wgpu.Texture
+	textureView     *wgpu.TextureView
+	uploadedTexture *common.TextureStagingData
+}
+
+// postBindings are the resources available to a post pass, bound by binding role.
+type postBindings struct {
+	input   *wgpu.TextureView
+	source  *wgpu.TextureView
+	depth   *wgpu.TextureView
+	texture *wgpu.TextureView
+	params  *wgpu.Buffer
+}
+
+func (b *wgpuRendererBackendImpl) HDR() bool {
+	return b.hdr
+}
+
+func (b *wgpuRendererBackendImpl) SceneFormat() wgpu.TextureFormat {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sceneFormat()
+}
+
+func (b *wgpuRendererBackendImpl) SetPostEffects(effects []PostEffect) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	keep := make(map[PostEffect]bool, len(effects))
+	for _, e := range effects {
+		keep[e] = true
+	}
+	for e, state := range b.postStates {
+		if !keep[e] {
+			state.release()
+			delete(b.postStates, e)
+		}
+	}
+	b.postEffects = append([]PostEffect(nil), effects...)
+}
+
+func (b *wgpuRendererBackendImpl) RegisterPostEffect(e PostEffect) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.ensurePostResources(); err != nil {
+		return err
+	}
+	for _, pass := range e.Passes() {
+		if pass.Shader == nil || pass.Shader.ShaderType() != shader.ShaderTypeFragment {
+			return fmt.Errorf("renderer: post effect %q has a pass without a fragment shader", e.Name())
+		}
+		// Any pass may write an intermediate target or, as the last pass of the chain, the frame target.
+		if _, err := b.postPipelineFor(pass.Shader, hdrFormat); err != nil {
+			return err
+		}
+		if _, err := b.postPipelineFor(pass.Shader, *b.surfaceFormat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sceneFormat returns the color format the main render pass draws in: RGBA16Float when HDR is
+// enabled, otherwise the surface format. Render pipelines and the MSAA texture use this format.
+//
+// Returns:
+//   - wgpu.TextureFormat: the scene color format
+func (b *wgpuRendererBackendImpl) sceneFormat() wgpu.TextureFormat {
+	if b.hdr {
+		return hdrFormat
+	}
+	return *b.surfaceFormat
+}
+
+// beginPostFrame decides whether the frame renders through the post-processing chain and
+// returns the view the main render pass should draw or resolve into. When post-processing is
+// active this is the scene color target and depth is stored for post passes; otherwise it is
+// the frame target itself. Caller must hold b.mu.
+//
+// Parameters:
+//   - final: the frame target view (swapchain or headless offscreen view)
+//
+// Returns:
+//   - *wgpu.TextureView: the color view for the main render pass
+func (b *wgpuRendererBackendImpl) beginPostFrame(final *wgpu.TextureView) *wgpu.TextureView {
+	b.postActive = b.hdr
+	for _, e := range b.postEffects {
+		if e.Enabled() {
+			b.postActive = true
+			break
+		}
+	}
+
+	depthStoreOp := wgpu.StoreOpDiscard
+	if b.oit {
+		depthStoreOp = wgpu.StoreOpStore // the transparency pass and resumed main pass load it
+	}
+	target := final
+	if b.postActive {
+		if b.sceneColorView == nil {
+			b.createSceneColorTarget()
+		}
+		target = b.sceneColorView
+		depthStoreOp = wgpu.StoreOpStore
+	}
+	b.renderPassDescriptor.DepthStencilAttachment.DepthStoreOp = depthStoreOp
+	return target
+}
+
+// encodePostProcess encodes the enabled post effects into the frame encoder after the main
+// render pass has ended. Passes ping-pong between pooled intermediate targets; the last pass
+// writes the frame target directly when it runs at full resolution, otherwise a copy pass does.
+// A pass whose pipeline cannot be created is skipped. Caller must hold b.mu.
+//
+// Parameters:
+//   - encoder: the frame command encoder
+//   - final: the frame target view
+func (b *wgpuRendererBackendImpl) encodePostProcess(encoder *wgpu.CommandEncoder, final *wgpu.TextureView) {
+	if err := b.ensurePostResources(); err != nil {
+		return
+	}
+
+	type postStep struct {
+		effect PostEffect
+		pass   PostPass
+		first  bool
+	}
+	var steps []postStep
+	needsDepth := false
+	for _, e := range b.postEffects {
+		if !e.Enabled() {
+			continue
+		}
+		for i, pass := range e.Passes() {
+			steps = append(steps, postStep{effect: e, pass: pass, first: i == 0})
+			if usesPostRole(pass.Shader, shader.AnnotationArgPostDepth) {
+				needsDepth = true
+			}
+		}
+	}
+
+	var depth *wgpu.TextureView
+	if needsDepth {
+		depth = b.resolveSceneDepth(encoder)
+	}
+
+	input := b.sceneColorView
+	source := input
+	for i, step := range steps {
+		if step.first {
+			source = input
+		}
+		scale := step.pass.Scale
+		if scale <= 0 {
+			scale = 1
+		}
+
+		target, format := final, *b.surfaceFormat
+		if i < len(steps)-1 || scale != 1 {
+			width := max(uint32(float32(b.targetWidth)*scale), 1)
+			height := max(uint32(float32(b.targetHeight)*scale), 1)
+			target, format = b.acquirePostTarget(width, height, input, source).view, hdrFormat
+		}
+
+		pp, err := b.postPipelineFor(step.pass.Shader, format)
+		if err != nil {
+			continue
+		}
+		state := b.effectState(step.effect)
+		bindings := postBindings{
+			input:   input,
+			source:  source,
+			depth:   depth,
+			texture: b.postDefaultView,
+			params:  state.params,
+		}
+		if state.textureView != nil {
+			bindings.texture = state.textureView
+		}
+		if err := b.encodePostPass(encoder, pp, step.pass.Shader, target, bindings); err != nil {
+			continue
+		}
+		input = target
+	}
+
+	if input != final {
+		pp, err := b.postPipelineFor(b.postCopyShader, *b.surfaceFormat)
+		if err != nil {
+			return
+		}
+		_ = b.encodePostPass(encoder, pp, b.postCopyShader, final, postBindings{input: input, source: input})
+	}
+}
+
+// encodePostPass builds the bind groups for a post pass from its shader's post_process
+// declarations and encodes a fullscreen draw into the target. The bind groups are released
+// after the frame is submitted. Caller must hold b.mu.
+//
+// Parameters:
+//   - encoder: the frame command encoder
+//   - pp: the pass pipeline
+//   - s: the pass fragment shader
+//   - target: the view to render into
+//   - bindings: the resources to bind by role
+//
+// Returns:
+//   - error: an error if a bind group could not be created
+func (b *wgpuRendererBackendImpl) encodePostPass(encoder *wgpu.CommandEncoder, pp *postPipeline, s shader.Shader, target *wgpu.TextureView, bindings postBindings) error {
+	entries := make(map[int][]wgpu.BindGroupEntry)
+	for _, d := range s.Declarations() {
+		if d.Type != shader.AnnotationTypeProvider || d.Args[0] != shader.AnnotationArgPostProcess || len(d.Args) < 2 {
+			continue
+		}
+		entry := wgpu.BindGroupEntry{Binding: uint32(*d.Binding)}
+		switch d.Args[1] {
+		case shader.AnnotationArgPostInput:
+			entry.TextureView = bindings.input
+		case shader.AnnotationArgPostSource:
+			entry.TextureView = bindings.source
+		case shader.AnnotationArgPostDepth:
+			entry.TextureView = bindings.depth
+		case shader.AnnotationArgPostTexture:
+			entry.TextureView = bindings.texture
+		case shader.AnnotationArgPostSampler:
+			entry.Sampler = b.postSampler
+		case shader.AnnotationArgPostParams:
+			entry.Buffer = bindings.params
+			entry.Size = wgpu.WholeSize
+		}
+		entries[*d.Group] = append(entries[*d.Group], entry)
+	}
+
+	groups := make(map[int]*wgpu.BindGroup, len(pp.layouts))
+	for g, layout := range pp.layouts {
+		bg, err := b.device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+			Label:   s.Key() + " Post Bind Group",
+			Layout:  layout,
+			Entries: entries[g],
+		})
+		if err != nil {
+			return err
+		}
+		b.postFrameBindGroups = append(b.postFrameBindGroups, bg)
+		groups[g] = bg
+	}
+
+	pass := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		ColorAttachments: []wgpu.RenderPassColorAttachment{
+			{
+				View:    target,
+				LoadOp:  wgpu.LoadOpClear,
+				StoreOp: wgpu.StoreOpStore,
+			},
+		},
+	})
+	pass.SetPipeline(pp.pipeline)
+	for g, bg := range groups {
+		pass.SetBindGroup(uint32(g), bg, nil)
+	}
+	pass.Draw(3, 1, 0, 0)
+	pass.End()
+	return nil
+}
+
+// resolveSceneDepth returns a single-sample view of the scene depth buffer for post_depth
+// bindings. Without MSAA this is the depth buffer itself; with MSAA, sample 0 of every pixel
+// is copied into a Depth32Float target by a fullscreen pass. Caller must hold b.mu.
+//
+// Parameters:
+//   - encoder: the frame command encoder
+//
+// Returns:
+//   - *wgpu.TextureView: the depth view, or nil if the resolve pass could not be created
+func (b *wgpuRendererBackendImpl) resolveSceneDepth(encoder *wgpu.CommandEncoder) *wgpu.TextureView {
+	if b.sampleCount <= 1 {
+		return b.depthTextureView
+	}
+
+	if b.depthResolvePipeline == nil {
+		if err := b.createDepthResolvePipeline(); err != nil {
+			return nil
+		}
+	}
+	if b.depthResolveView == nil {
+		tex, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
+			Label: "Depth Resolve Texture",
+			Size: wgpu.Extent3D{
+				Width:              b.targetWidth,
+				Height:             b.targetHeight,
+				DepthOrArrayLayers: 1,
+			},
+			MipLevelCount: 1,
+			SampleCount:   1,
+			Dimension:     wgpu.TextureDimension2D,
+			Format:        wgpu.TextureFormatDepth32Float,
+			Usage:         wgpu.TextureUsageRenderAttachment | wgpu.TextureUsageTextureBinding,
+		})
+		if err != nil {
+			return nil
+		}
+		view, err := tex.CreateView(nil)
+		if err != nil {
+			tex.Release()
+			return nil
+		}
+		b.depthResolveTexture = tex
+		b.depthResolveView = view
+	}
+
+	bg, err := b.device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+		Label:  "Depth Resolve Bind Group",
+		Layout: b.depthResolveLayout,
+		Entries: []wgpu.BindGroupEntry{
+			{Binding: 0, TextureView: b.depthTextureView},
+		},
+	})
+	if err != nil {
+		return nil
+	}
+	b.postFrameBindGroups = append(b.postFrameBindGroups, bg)
+
+	pass := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		DepthStencilAttachment: &wgpu.RenderPassDepthStencilAttachment{
+			View:            b.depthResolveView,
+			DepthLoadOp:     wgpu.LoadOpClear,
+			DepthStoreOp:    wgpu.StoreOpStore,
+			DepthClearValue: 1.0,
+		},
+	})
+	pass.SetPipeline(b.depthResolvePipeline)
+	pass.SetBindGroup(0, bg, nil)
+	pass.Draw(3, 1, 0, 0)
+	pass.End()
+
+	return b.depthResolveView
+}
+
+// createDepthResolvePipeline creates the depth-only pipeline that copies the multisampled
+// scene depth into the resolve target. Caller must hold b.mu.
+//
+// Returns:
+//   - error: an error if the shader module, layout or pipeline could not be created
+func (b *wgpuRendererBackendImpl) createDepthResolvePipeline() error {
+	s := shader.NewShaderFromSource("post_depth_resolve", shader.ShaderTypeFragment, post_process.DepthResolveShaderSource)
+	fs, err := b.device.CreateShaderModule(s.Module())
+	if err != nil {
+		return err
+	}
+	desc := s.BindGroupLayoutDescriptor(0)
+	layout, err := b.device.CreateBindGroupLayout(&desc)
+	if err != nil {
+		return fmt.Errorf("failed to create depth resolve bind group layout: %w", err)
+	}
+	pipelineLayout, err := b.device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label:            s.Key(),
+		BindGroupLayouts: []*wgpu.BindGroupLayout{layout},
+	})
+	if err != nil {
+		return err
+	}
+
+	created, err := b.device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  s.Key() + " Render Pipeline",
+		Layout: pipelineLayout,
+		Vertex: wgpu.VertexState{
+			Module:     b.postVertexModule,
+			EntryPoint: b.postVertexShader.EntryPoint(),
+		},
+		Fragment: &wgpu.FragmentState{
+			Module:     fs,
+			EntryPoint: s.EntryPoint(),
+		},
+		Primitive: wgpu.PrimitiveState{
+			Topology:  wgpu.PrimitiveTopologyTriangleList,
+			FrontFace: wgpu.FrontFaceCCW,
+			CullMode:  wgpu.CullModeNone,
+		},
+		Multisample: wgpu.MultisampleState{
+			Count: 1,
+			Mask:  0xFFFFFFFF,
+		},
+		DepthStencil: &wgpu.DepthStencilState{
+			Format:            wgpu.TextureFormatDepth32Float,
+			DepthWriteEnabled: true,
+			DepthCompare:      wgpu.CompareFunctionAlways,
+			StencilFront: wgpu.StencilFaceState{
+				Compare: wgpu.CompareFunctionAlways,
+			},
+			StencilBack: wgpu.StencilFaceState{
+				Compare: wgpu.CompareFunctionAlways,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	b.depthResolveLayout = layout
+	b.depthResolvePipeline = created
+	return nil
+}
+
+// postPipelineFor returns the cached pipeline for a post pass shader writing the given format,
+// creating it on first use. Every binding of the shader must carry a post_process role.
+// Caller must hold b.mu.
+//
+// Parameters:
+//   - s: the pass fragment shader
+//   - format: the color format of the pass target
+//
+// Returns:
+//   - *postPipeline: the pipeline and its bind group layouts
+//   - error: an error if the shader has unbound bindings or pipeline creation fails
+func (b *wgpuRendererBackendImpl) postPipelineFor(s shader.Shader, format wgpu.TextureFormat) (*postPipeline, error) {
+	key := fmt.Sprintf("%s|%d", s.Key(), format)
+	if pp, ok := b.postPipelines[key]; ok {
+		return pp, nil
+	}
+
+	roles := make(map[[2]int]bool)
+	for _, d := range s.Declarations() {
+		if d.Type == shader.AnnotationTypeProvider && d.Args[0] == shader.AnnotationArgPostProcess && len(d.Args) == 2 {
+			roles[[2]int{*d.Group, *d.Binding}] = true
+		}
+	}
+	descriptors := s.BindGroupLayoutDescriptors()
+	for g, desc := range descriptors {
+		for _, entry := range desc.Entries {
+			if !roles[[2]int{g, int(entry.Binding)}] {
+				return nil, fmt.Errorf("renderer: post shader %q binding @group(%d) @binding(%d) has no post_process role", s.Key(), g, entry.Binding)
+			}
+		}
+	}
+
+	fs, err := b.device.CreateShaderModule(s.Module())
+	if err != nil {
+		return nil, err
+	}
+
+	maxGroup := -1
+	for g := range descriptors {
+		if g > maxGroup {
+			maxGroup = g
+		}
+	}
+	layouts := make(map[int]*wgpu.BindGroupLayout, len(descriptors))
+	bindGroupLayouts := make([]*wgpu.BindGroupLayout, maxGroup+1)
+	for g, desc := range descriptors {
+		layout, layoutErr := b.device.CreateBindGroupLayout(&desc)
+		if layoutErr != nil {
+			return nil, fmt.Errorf("failed to create bind group layout for group %d: %w", g, layoutErr)
+		}
+		layouts[g] = layout
+		bindGroupLayouts[g] = layout
+	}
+
+	pipelineLayout, err := b.device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label:            s.Key(),
+		BindGroupLayouts: bindGroupLayouts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := b.device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  s.Key() + " Post Pipeline",
+		Layout: pipelineLayout,
+		Vertex: wgpu.VertexState{
+			Module:     b.postVertexModule,
+			EntryPoint: b.postVertexShader.EntryPoint(),
+		},
+		Fragment: &wgpu.FragmentState{
+			Module:     fs,
+			EntryPoint: s.EntryPoint(),
+			Targets: []wgpu.ColorTargetState{
+				{
+					Format:    format,
+					WriteMask: wgpu.ColorWriteMaskAll,
+				},
+			},
+		},
+		Primitive: wgpu.PrimitiveState{
+			Topology:  wgpu.PrimitiveTopologyTriangleList,
+			FrontFace: wgpu.FrontFaceCCW,
+			CullMode:  wgpu.CullModeNone,
+		},
+		Multisample: wgpu.MultisampleState{
+			Count: 1,
+			Mask:  0xFFFFFFFF,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pp := &postPipeline{pipeline: created, layouts: layouts}
+	b.postPipelines[key] = pp
+	return pp, nil
+}
+
+// effectState returns the GPU state of an effect, creating it on first use, and uploads
+// the effect's params and texture if they changed since the last frame. Caller must hold b.mu.
+//
+// Parameters:
+//   - e: the effect
+//
+// Returns:
+//   - *postEffectState: the effect's GPU state
+func (b *wgpuRendererBackendImpl) effectState(e PostEffect) *postEffectState {
+	state, ok := b.postStates[e]
+	if !ok {
+		state = &postEffectState{}
+		b.postStates[e] = state
+	}
+
+	// Uniform buffers are never empty and writes must be 4-byte aligned.
+	params := e.Params()
+	for len(params)%4 != 0 {
+		params = append(params, 0)
+	}
+	size := max(uint64(len(params)+15)&^15, 16)
+	if state.params == nil || state.paramsSize < size {
+		if state.params != nil {
+			state.params.Release()
+		}
+		buf, err := b.device.CreateBuffer(&wgpu.BufferDescriptor{
+			Label: e.Name() + " Post Params Buffer",
+			Size:  size,
+			Usage: wgpu.BufferUsageUniform | wgpu.BufferUsageCopyDst,
+		})
+		if err != nil {
+			panic(err)
+		}
+		state.params = buf
+		state.paramsSize = size
+		state.uploadedParams = nil
+	}
+	if len(params) > 0 && !bytes.Equal(params, state.uploadedParams) {
+		b.queue.WriteBuffer(state.params, 0, params)
+		state.uploadedParams = params
+	}
+
+	if tex := e.Texture(); tex != nil && tex != state.uploadedTexture {
+		if state.texture == nil || state.uploadedTexture == nil ||
+			state.uploadedTexture.Width != tex.Width || state.uploadedTexture.Height != tex.Height {
+			if state.textureView != nil {
+				state.textureView.Release()
+				state.texture.Release()
+			}
+			state.texture, state.textureView = b.createPostTexture(e.Name()+" Post Texture", tex.Width, tex.Height)
+		}
+		b.writePostTexture(state.texture, *tex)
+		state.uploadedTexture = tex
+	}
+	return state
+}
+
+// acquirePostTarget returns a pooled intermediate target of the given size that is not one of
+// the views in use, creating a new one if none is free. Caller must hold b.mu.
+//
+// Parameters:
+//   - width: the target width in pixels
+//   - height: the target height in pixels
+//   - inUse: views that are read by the pass and must not be written
+//
+// Returns:
+//   - *postTarget: the target
+func (b *wgpuRendererBackendImpl) acquirePostTarget(width, height uint32, inUse ...*wgpu.TextureView) *postTarget {
+	for _, t := range b.postTargets {
+		if t.width != width || t.height != height {
+			continue
+		}
+		free := true
+		for _, v := range inUse {
+			if t.view == v {
+				free = false
+				break
+			}
+		}
+		if free {
+			return t
+		}
+	}
+
+	tex, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
+		Label: "Post Target Texture",
+		Size: wgpu.Extent3D{
+			Width:              width,
+			Height:             height,
+			DepthOrArrayLayers: 1,
+		},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        hdrFormat,
+		Usage:         wgpu.TextureUsageRenderAttachment | wgpu.TextureUsageTextureBinding,
+	})
+	if err != nil {
+		panic(err)
+	}
+	view, err := tex.CreateView(nil)
+	if err != nil {
+		panic(err)
+	}
+	t := &postTarget{texture: tex, view: view, width: width, height: height}
+	b.postTargets = append(b.postTargets, t)
+	return t
+}
+
+// ensurePostResources lazily creates the resources shared by all post passes: the fullscreen
+// vertex shader, the linear clamp sampler, the copy shader and the 1x1 white default texture.
+// Caller must hold b.mu.
+//
+// Returns:
+//   - error: an error if a resource could not be created
+func (b *wgpuRendererBackendImpl) ensurePostResources() error {
+	if b.postVertexModule != nil {
+		return nil
+	}
+
+	vertex := shader.NewShaderFromSource("post_fullscreen_vert", shader.ShaderTypeVertex, post_process.FullscreenVertexSource)
+	module, err := b.device.CreateShaderModule(vertex.Module())
+	if err != nil {
+		return err
+	}
+
+	samp, err := b.device.CreateSampler(&wgpu.SamplerDescriptor{
+		Label:         "Post Sampler",
+		AddressModeU:  wgpu.AddressModeClampToEdge,
+		AddressModeV:  wgpu.AddressModeClampToEdge,
+		AddressModeW:  wgpu.AddressModeClampToEdge,
+		MagFilter:     wgpu.FilterModeLinear,
+		MinFilter:     wgpu.FilterModeLinear,
+		MipmapFilter:  wgpu.MipmapFilterModeNearest,
+		LodMaxClamp:   32.0,
+		MaxAnisotropy: 1,
+	})
+	if err != nil {
+		return err
+	}
+
+	tex, view := b.createPostTexture("Post Default Texture", 1, 1)
+	b.writePostTexture(tex, common.TextureStagingData{Pixels: []byte{255, 255, 255, 255}, Width: 1, Height: 1})
+
+	b.postVertexShader = vertex
+	b.postVertexModule = module
+	b.postSampler = samp
+	b.postDefaultTexture = tex
+	b.postDefaultView = view
+	b.postCopyShader = shader.NewShaderFromSource("post_copy", shader.ShaderTypeFragment, post_process.CopyShaderSource)
+	if b.postPipelines == nil {
+		b.postPipelines = make(map[string]*postPipeline)
+	}
+	if b.postStates == nil {
+		b.postStates = make(map[PostEffect]*postEffectState)
+	}
+	return nil
+}
+
+// createSceneColorTarget creates the single-sample scene color target that the main render
+// pass draws or resolves into while post-processing is active. Caller must hold b.mu.
+func (b *wgpuRendererBackendImpl) createSceneColorTarget() {
+	tex, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
+		Label: "Scene Color Texture",
+		Size: wgpu.Extent3D{
+			Width:              b.targetWidth,
+			Height:             b.targetHeight,
+			DepthOrArrayLayers: 1,
+		},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        b.sceneFormat(),
+		Usage:         wgpu.TextureUsageRenderAttachment | wgpu.TextureUsageTextureBinding,
+	})
+	if err != nil {
+		panic(err)
+	}
+	b.sceneColorTexture = tex
+	b.sceneColorView, err = tex.CreateView(nil)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// releasePostTargets releases the size-dependent post-processing targets so they are recreated
+// at the new size on the next frame. Caller must hold b.mu.
+func (b *wgpuRendererBackendImpl) releasePostTargets() {
+	if b.sceneColorView != nil {
+		b.sceneColorView.Release()
+		b.sceneColorTexture.Release()
+		b.sceneColorView, b.sceneColorTexture = nil, nil
+	}
+	if b.depthResolveView != nil {
+		b.depthResolveView.Release()
+		b.depthResolveTexture.Release()
+		b.depthResolveView, b.depthResolveTexture = nil, nil
+	}
+	for _, t := range b.postTargets {
+		t.view.Release()
+		t.texture.Release()
+	}
+	b.postTargets = nil
+}
+
+// releasePostFrame releases the bind groups created for the frame's post passes.
+// Caller must hold b.mu.
+func (b *wgpuRendererBackendImpl) releasePostFrame() {
+	for _, bg := range b.postFrameBindGroups {
+		bg.Release()
+	}
+	b.postFrameBindGroups = b.postFrameBindGroups[:0]
+}
+
+// createPostTexture creates an RGBA8Unorm texture for post_texture bindings. Caller must hold b.mu.
+//
+// Parameters:
+//   - label: the texture label
+//   - width: the texture width in pixels
+//   - height: the texture height in pixels
+//
+// Returns:
+//   - *wgpu.Texture: the texture
+//   - *wgpu.TextureView: a view of the whole texture
+func (b *wgpuRendererBackendImpl) createPostTexture(label string, width, height uint32) (*wgpu.Texture, *wgpu.TextureView) {
+	tex, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:     label,
+		Usage:     wgpu.TextureUsageTextureBinding | wgpu.TextureUsageCopyDst,
+		Dimension: wgpu.TextureDimension2D,
+		Size: wgpu.Extent3D{
+			Width:              width,
+			Height:             height,
+			DepthOrArrayLayers: 1,
+		},
+		Format:        wgpu.TextureFormatRGBA8Unorm,
+		MipLevelCount: 1,
+		SampleCount:   1,
+	})
+	if err != nil {
+		panic(err)
+	}
+	view, err := tex.CreateView(nil)
+	if err != nil {
+		panic(err)
+	}
+	return tex, view
+}
+
+// writePostTexture uploads RGBA pixel data to a post texture. Caller must hold b.mu.
+//
+// Parameters:
+//   - tex: the destination texture
+//   - data: the pixel data and dimensions
+func (b *wgpuRendererBackendImpl) writePostTexture(tex *wgpu.Texture, data common.TextureStagingData) {
+	b.writeTextureLevel(tex, wgpu.TextureFormatRGBA8Unorm, 0, 0, data.Pixels, data.Width, data.Height)
+}
+
+// usesPostRole reports whether a post shader declares a binding with the given role.
+//
+// Parameters:
+//   - s: the post pass fragment shader
+//   - role: the post_process binding role
+//
+// Returns:
+//   - bool: true if the shader declares the role
+func usesPostRole(s shader.Shader, role shader.AnnotationArg) bool {
+	for _, d := range s.Declarations() {
+		if d.Type == shader.AnnotationTypeProvider && d.Args[0] == shader.AnnotationArgPostProcess && len(d.Args) == 2 && d.Args[1] == role {
+			return true
+		}
+	}
+	return false
+}
+
+// release frees the effect's GPU resources.
+func (s *postEffectState) release() {
+	if s.params != nil {
+		s.params.Release()
+	}
+	if s.textureView != nil {
+		s.textureView.Release()
+		s.texture.Release()
+	}
+}
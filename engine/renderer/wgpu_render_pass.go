@@ -0,0 +1,98 @@
+package renderer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+func (b *wgpuRendererBackendImpl) TargetSize() (uint32, uint32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.targetWidth, b.targetHeight
+}
+
+func (b *wgpuRendererBackendImpl) SampleCount() uint32 {
+	return uint32(b.sampleCount)
+}
+
+func (b *wgpuRendererBackendImpl) CreateTexture(label string, width, height, sampleCount uint32, format wgpu.TextureFormat, usage wgpu.TextureUsage) (*wgpu.Texture, *wgpu.TextureView, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tex, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
+		Label: label,
+		Size: wgpu.Extent3D{
+			Width:              max(width, 1),
+			Height:             max(height, 1),
+			DepthOrArrayLayers: 1,
+		},
+		MipLevelCount: 1,
+		SampleCount:   max(sampleCount, 1),
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        format,
+		Usage:         usage,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create texture %q: %w", label, err)
+	}
+	view, err := tex.CreateView(nil)
+	if err != nil {
+		tex.Release()
+		return nil, nil, fmt.Errorf("failed to create view of texture %q: %w", label, err)
+	}
+	return tex, view, nil
+}
+
+func (b *wgpuRendererBackendImpl) CreateBuffer(label string, size uint64, usage wgpu.BufferUsage) (*wgpu.Buffer, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	buf, err := b.device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: label,
+		Size:  size,
+		Usage: usage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create buffer %q: %w", label, err)
+	}
+	return buf, nil
+}
+
+func (b *wgpuRendererBackendImpl) BeginRenderPass(desc *wgpu.RenderPassDescriptor) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.framePass != nil {
+		return errors.New("a render pass is already in progress")
+	}
+	encoder, err := b.device.CreateCommandEncoder(nil)
+	if err != nil {
+		return err
+	}
+	b.renderPassEncoder = encoder
+	b.framePass = encoder.BeginRenderPass(desc)
+	b.resetViewport()
+	return nil
+}
+
+func (b *wgpuRendererBackendImpl) EndRenderPass() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.renderPassEncoder == nil {
+		return
+	}
+	b.framePass.End()
+	b.framePass = nil
+
+	commandBuffer, err := b.renderPassEncoder.Finish(nil)
+	b.renderPassEncoder.Release()
+	b.renderPassEncoder = nil
+	if err != nil {
+		return
+	}
+	b.queue.Submit(commandBuffer)
+	commandBuffer.Release()
+}
@@ -3,6 +3,7 @@ package renderer
 import (
 	"errors"
 	"fmt"
+	"image"
 	"runtime"
 	"sort"
 	"sync"
@@ -40,11 +41,80 @@ type wgpuRendererBackendImpl struct {
 	// Compute frame state for batching all compute dispatches into a single GPU submission
 	computeFrameEncoder *wgpu.CommandEncoder
 
+	// Standalone render pass state. BeginRenderPass records into its own command encoder and
+	// points framePass at the new pass so draw calls target it. See wgpu_render_pass.go.
+	renderPassEncoder *wgpu.CommandEncoder
+
+	// Viewport and scissor of the open pass, re-applied to the passes the transparency path
+	// begins mid-frame. viewportSet and scissorSet are cleared whenever a frame or render pass
+	// begins, so it starts with the full target. viewportClearPipelines holds the pipelines
+	// ClearViewport draws with, keyed by which of color and depth they write.
+	viewport               [4]float32
+	viewportSet            bool
+	scissor                [4]uint32
+	scissorSet             bool
+	viewportClearModule    *wgpu.ShaderModule
+	viewportClearPipelines map[viewportClearKey]*wgpu.RenderPipeline
+
 	// Shadow pass state for rendering depth-only passes from a light's perspective.
 	// Shadow passes use their own command encoder, a Depth32Float texture (no color),
 	// sample count 1 (no MSAA), and front-face culling to reduce self-shadowing.
 	shadowFrameEncoder *wgpu.CommandEncoder
 	shadowPass         *wgpu.RenderPassEncoder
+
+	// Headless state. When headless is set there is no surface: the main render pass
+	// targets (or resolves into) offscreenTexture, which can be read back via ReadPixels.
+	headless         bool
+	offscreenTexture *wgpu.Texture
+	offscreenView    *wgpu.TextureView
+	targetWidth      uint32
+	targetHeight     uint32
+
+	// Post-processing state. When hdr is set or any post effect is enabled, the main render
+	// pass draws (or resolves) into sceneColorView instead of the frame target, and EndFrame
+	// runs the effect chain from it into the frame target. See wgpu_post_process.go.
+	hdr                  bool
+	postActive           bool
+	postEffects          []PostEffect
+	postStates           map[PostEffect]*postEffectState
+	postPipelines        map[string]*postPipeline
+	postTargets          []*postTarget
+	postFrameBindGroups  []*wgpu.BindGroup
+	postVertexShader     shader.Shader
+	postVertexModule     *wgpu.ShaderModule
+	postCopyShader       shader.Shader
+	postSampler          *wgpu.Sampler
+	postDefaultTexture   *wgpu.Texture
+	postDefaultView      *wgpu.TextureView
+	sceneColorTexture    *wgpu.Texture
+	sceneColorView       *wgpu.TextureView
+	depthResolveTexture  *wgpu.Texture
+	depthResolveView     *wgpu.TextureView
+	depthResolveLayout   *wgpu.BindGroupLayout
+	depthResolvePipeline *wgpu.RenderPipeline
+
+	// Weighted blended transparency state. When oit is set, BeginTransparentPass suspends the
+	// main pass and renders blended draws into the accumulation and revealage targets, and
+	// EndTransparentPass composites them over the scene color and resumes the main pass.
+	// See wgpu_oit.go.
+	oit                  bool
+	oitPassActive        bool
+	oitAccum             *oitTarget
+	oitReveal            *oitTarget
+	oitBindGroup         *wgpu.BindGroup
+	oitCompositeLayout   *wgpu.BindGroupLayout
+	oitCompositePipeline *wgpu.RenderPipeline
+
+	// GPU timestamp state. gpuTimestamps is set when timings were requested and the device has
+	// the timestamp query feature; each frame's timestamps are resolved into one of a small ring
+	// of readback buffers and mapped asynchronously. See wgpu_gpu_timer.go.
+	gpuTimestamps     bool
+	gpuTimerQuerySet  *wgpu.QuerySet
+	gpuTimerResolve   *wgpu.Buffer
+	gpuTimerReadbacks []gpuTimerReadback
+	gpuTimerQueries   uint32
+	gpuTimerPhases    []string
+	gpuTimerFrame     uint64
 }
 
 type wgpuRendererBackend interface {
@@ -141,6 +211,7 @@ type wgpuRendererBackend interface {
 	InitBindGroup(provider bind_group_provider.BindGroupProvider, descriptor wgpu.BindGroupLayoutDescriptor, bufferUsageOverrides map[int]wgpu.BufferUsage, bufferSizeOverrides map[int]uint64) error
 
 	// InitTextureView creates a GPU texture and texture view based on the provided staging data, and stores the view on the given BindGroupProvider.
+	// If stagingData.GenerateMipmaps is set, the mip chain is generated on the GPU, or on the CPU if the GPU path fails.
 	//
 	// Parameters:
 	//   - provider: the BindGroupProvider to store the created texture view on
@@ -151,6 +222,18 @@ type wgpuRendererBackend interface {
 	//   - error: an error if the texture view could not be created or initialized, otherwise nil
 	InitTextureView(provider bind_group_provider.BindGroupProvider, bindingKey int, stagingData common.TextureStagingData) error
 
+	// InitCubeTextureView creates a six-layer GPU texture from the provided cubemap staging data, and stores a cube
+	// view of it on the given BindGroupProvider.
+	//
+	// Parameters:
+	//   - provider: the BindGroupProvider to store the created texture view on
+	//   - bindingKey: the integer key identifying the bind group layout entry for this texture
+	//   - stagingData: the CubemapStagingData containing the face data of every mip level
+	//
+	// Returns:
+	//   - error: an error if the texture view could not be created or initialized, otherwise nil
+	InitCubeTextureView(provider bind_group_provider.BindGroupProvider, bindingKey int, stagingData common.CubemapStagingData) error
+
 	// InitSampler creates a GPU sampler based on the provided staging data, and stores it on the given BindGroupProvider.
 	//
 	// Parameters:
@@ -162,6 +245,15 @@ type wgpuRendererBackend interface {
 	//   - error: an error if the sampler could not be created or initialized, otherwise nil
 	InitSampler(provider bind_group_provider.BindGroupProvider, bindingKey int, samplerStagingData common.SamplerStagingData) error
 
+	// SupportsTextureFormat reports whether the device can create and sample textures of the given format.
+	//
+	// Parameters:
+	//   - format: the texture format
+	//
+	// Returns:
+	//   - bool: true if the format is usable, false if it needs a texture compression feature the device lacks
+	SupportsTextureFormat(format wgpu.TextureFormat) bool
+
 	// WriteBuffers writes all staged buffer writes to the GPU queue.
 	// Each BufferWrite targets a specific buffer on a BindGroupProvider at a given binding and offset.
 	//
@@ -186,6 +278,39 @@ type wgpuRendererBackend interface {
 	//   - bindGroups: a slice of BindGroupProviders whose BindGroups will be set on the render pass
 	DrawCall(p pipeline.Pipeline, meshProvider bind_group_provider.BindGroupProvider, instanceCount uint32, bindGroups []bind_group_provider.BindGroupProvider)
 
+	// DrawProcedural encodes a non-indexed draw command with no vertex buffer within the current render pass.
+	// The vertex shader generates its vertices from the vertex index, e.g. a fullscreen triangle.
+	//
+	// Parameters:
+	//   - p: the cached Pipeline containing the render pipeline to use
+	//   - vertexCount: the number of vertices to draw
+	//   - instanceCount: the number of instances to draw
+	//   - bindGroups: a slice of BindGroupProviders whose BindGroups will be set on the render pass
+	DrawProcedural(p pipeline.Pipeline, vertexCount, instanceCount uint32, bindGroups []bind_group_provider.BindGroupProvider)
+
+	// DrawProceduralRange encodes a single-instance non-indexed draw of a contiguous range of vertices with
+	// no vertex buffer within the current render pass. The range start is passed as the first vertex, so
+	// @builtin(vertex_index) in the vertex shader starts at firstVertex.
+	//
+	// Parameters:
+	//   - p: the cached Pipeline containing the render pipeline to use
+	//   - firstVertex: the index of the first vertex to draw
+	//   - vertexCount: the number of vertices to draw
+	//   - bindGroups: a slice of BindGroupProviders whose BindGroups will be set on the render pass
+	DrawProceduralRange(p pipeline.Pipeline, firstVertex, vertexCount uint32, bindGroups []bind_group_provider.BindGroupProvider)
+
+	// DrawCallInstances encodes an instanced draw of a contiguous range of instances within the current
+	// render pass. The range start is passed as the first instance, so @builtin(instance_index) in the
+	// vertex shader addresses the same per-instance data as a full draw.
+	//
+	// Parameters:
+	//   - p: the cached Pipeline containing the render pipeline to use
+	//   - meshProvider: the BindGroupProvider holding vertex and index buffers
+	//   - firstInstance: the index of the first instance to draw
+	//   - instanceCount: the number of instances to draw
+	//   - bindGroups: a slice of BindGroupProviders whose BindGroups will be set on the render pass
+	DrawCallInstances(p pipeline.Pipeline, meshProvider bind_group_provider.BindGroupProvider, firstInstance, instanceCount uint32, bindGroups []bind_group_provider.BindGroupProvider)
+
 	// DrawCallIndirect encodes a single indirect instanced draw command within the current render pass.
 	// The instance count is read from the indirectBuffer on the GPU, allowing the compute shader to
 	// control how many instances are drawn without CPU readback.
@@ -197,7 +322,8 @@ type wgpuRendererBackend interface {
 	//   - bindGroups: a slice of BindGroupProviders whose BindGroups will be set on the render pass
 	DrawCallIndirect(p pipeline.Pipeline, meshProvider bind_group_provider.BindGroupProvider, indirectBuffer *wgpu.Buffer, bindGroups []bind_group_provider.BindGroupProvider)
 
-	// EndFrame ends the current render pass and submits the command buffer to the GPU.
+	// EndFrame ends the current render pass, encodes the post effect chain when post-processing
+	// is active, and submits the command buffer to the GPU.
 	// Does not present the surface — call Present() after EndFrame to display the frame.
 	// Must be called after BeginFrame and all DrawCall invocations.
 	EndFrame()
@@ -206,19 +332,22 @@ type wgpuRendererBackend interface {
 	// Must be called once per frame after EndFrame.
 	Present()
 
-	// CreateShadowDepthTexture creates a Depth32Float texture and view for shadow mapping.
-	// The texture has sample count 1 (no MSAA) and can be sampled as a depth texture
-	// in the lit fragment shader.
+	// CreateShadowDepthTexture creates a Depth32Float texture array and views for shadow mapping.
+	// The texture has sample count 1 (no MSAA) and one layer per shadow cascade. The array
+	// view is sampled as a texture_depth_2d_array in the lit fragment shader, while each
+	// layer view is the depth attachment of one shadow render pass.
 	//
 	// Parameters:
 	//   - width: shadow map width in texels
 	//   - height: shadow map height in texels
+	//   - layers: number of array layers
 	//
 	// Returns:
-	//   - *wgpu.TextureView: the depth texture view for the shadow render pass
+	//   - *wgpu.TextureView: the 2D array view over all layers, for sampling
+	//   - []*wgpu.TextureView: one single-layer view per layer, for the shadow render passes
 	//   - *wgpu.Texture: the underlying texture (caller must release when done)
 	//   - error: an error if texture creation fails
-	CreateShadowDepthTexture(width, height int) (*wgpu.TextureView, *wgpu.Texture, error)
+	CreateShadowDepthTexture(width, height, layers int) (*wgpu.TextureView, []*wgpu.TextureView, *wgpu.Texture, error)
 
 	// CreateComparisonSampler creates a comparison sampler suitable for PCF shadow mapping.
 	// Uses CompareFunction Less for standard shadow depth comparison.
@@ -254,6 +383,14 @@ type wgpuRendererBackend interface {
 	//   - depthView: the shadow map depth texture view to render into
 	BeginShadowPass(depthView *wgpu.TextureView)
 
+	// SetShadowViewport sets the viewport and scissor rectangle of the current shadow pass.
+	// Must be called between BeginShadowPass and EndShadowPass.
+	//
+	// Parameters:
+	//   - x, y: top-left corner of the rectangle in texels
+	//   - width, height: size of the rectangle in texels
+	SetShadowViewport(x, y, width, height uint32)
+
 	// ShadowDrawCall encodes a single instanced draw command within the current shadow pass.
 	//
 	// Parameters:
@@ -278,17 +415,233 @@ type wgpuRendererBackend interface {
 
 	// EndShadowFrame finishes the shadow command encoder and submits to the GPU queue.
 	EndShadowFrame()
+
+	// Headless returns whether the backend renders into an offscreen texture instead of a surface.
+	//
+	// Returns:
+	//   - bool: true if the backend was created without a surface
+	Headless() bool
+
+	// HDR returns whether the main render pass draws into an RGBA16Float scene target.
+	//
+	// Returns:
+	//   - bool: true if the backend was created with HDR enabled
+	HDR() bool
+
+	// SceneFormat returns the color format of the main render pass and its pipelines.
+	//
+	// Returns:
+	//   - wgpu.TextureFormat: RGBA16Float when HDR is enabled, otherwise the surface format
+	SceneFormat() wgpu.TextureFormat
+
+	// SampleCount returns the MSAA sample count of the main render pass and its pipelines.
+	//
+	// Returns:
+	//   - uint32: the sample count
+	SampleCount() uint32
+
+	// OrderIndependentTransparency returns whether blended materials can be drawn through the
+	// weighted blended transparency pass.
+	//
+	// Returns:
+	//   - bool: true if the backend was created with order-independent transparency enabled
+	OrderIndependentTransparency() bool
+
+	// BeginTransparentPass ends the main render pass and begins the weighted blended transparency
+	// pass, which renders into the accumulation and revealage targets and tests against the scene
+	// depth without writing it. Draws between BeginTransparentPass and EndTransparentPass must use
+	// weighted blended pipelines. Does nothing when order-independent transparency is disabled.
+	//
+	// Returns:
+	//   - error: an error if no frame is in progress or the transparency targets could not be created
+	BeginTransparentPass() error
+
+	// EndTransparentPass ends the weighted blended transparency pass, resumes the main render pass
+	// and composites the transparent layers over the scene color. Does nothing if no transparency
+	// pass is active.
+	EndTransparentPass()
+
+	// GPUTimingSupported returns whether GPU timestamps are recorded. False when timings were not
+	// requested, the adapter lacks the timestamp query feature, or the device rejected a timestamp.
+	//
+	// Returns:
+	//   - bool: true if BeginGPUTimings, MarkGPUTiming and EndGPUTimings record timestamps
+	GPUTimingSupported() bool
+
+	// BeginGPUTimings starts timing a frame by recording a timestamp after all work submitted so far.
+	BeginGPUTimings()
+
+	// MarkGPUTiming records a timestamp after all work submitted so far, ending the named phase
+	// that began at the previous timestamp.
+	//
+	// Parameters:
+	//   - phase: the name of the phase that just ended
+	MarkGPUTiming(phase string)
+
+	// EndGPUTimings resolves the frame's timestamps and starts mapping them for readback without
+	// waiting for the GPU. The frame is dropped if every readback buffer is still in flight.
+	EndGPUTimings()
+
+	// GPUTimings returns the phase durations of the newest frame whose timestamps have been read
+	// back since the last call. Never blocks.
+	//
+	// Returns:
+	//   - []GPUTiming: the phase durations in the order they were marked, or nil if no new frame is available
+	GPUTimings() []GPUTiming
+
+	// TargetSize returns the size of the frame target: the configured surface, or the offscreen
+	// texture of a headless backend.
+	//
+	// Returns:
+	//   - uint32: the target width in pixels
+	//   - uint32: the target height in pixels
+	TargetSize() (uint32, uint32)
+
+	// CreateTexture creates a single-level 2D texture and a view of it, for render targets owned
+	// outside the backend such as render graph transients. The caller releases both.
+	//
+	// Parameters:
+	//   - label: the debug label of the texture
+	//   - width: the texture width in pixels
+	//   - height: the texture height in pixels
+	//   - sampleCount: the multisample count (1 for no multisampling)
+	//   - format: the texture format
+	//   - usage: the texture usage flags
+	//
+	// Returns:
+	//   - *wgpu.Texture: the created texture
+	//   - *wgpu.TextureView: a view of the whole texture
+	//   - error: an error if the texture or view could not be created
+	CreateTexture(label string, width, height, sampleCount uint32, format wgpu.TextureFormat, usage wgpu.TextureUsage) (*wgpu.Texture, *wgpu.TextureView, error)
+
+	// CreateBuffer creates a GPU buffer owned outside the backend. The caller releases it.
+	//
+	// Parameters:
+	//   - label: the debug label of the buffer
+	//   - size: the buffer size in bytes
+	//   - usage: the buffer usage flags
+	//
+	// Returns:
+	//   - *wgpu.Buffer: the created buffer
+	//   - error: an error if the buffer could not be created
+	CreateBuffer(label string, size uint64, usage wgpu.BufferUsage) (*wgpu.Buffer, error)
+
+	// BeginRenderPass begins a render pass with the given attachments on its own command encoder.
+	// Draw calls until EndRenderPass record into it and must use pipelines whose color formats,
+	// sample count and depth format match the attachments. Fails while the main frame pass or
+	// another render pass is open.
+	//
+	// Parameters:
+	//   - desc: the render pass descriptor with the color and depth attachments
+	//
+	// Returns:
+	//   - error: an error if a render pass is already open or the command encoder could not be created
+	BeginRenderPass(desc *wgpu.RenderPassDescriptor) error
+
+	// EndRenderPass ends the render pass begun by BeginRenderPass and submits it to the GPU queue.
+	// Does nothing if no render pass is open.
+	EndRenderPass()
+
+	// SetViewport sets the viewport of the open frame or render pass and remembers it, so passes
+	// begun later in the frame by the transparency path start with the same viewport.
+	//
+	// Parameters:
+	//   - x: the left edge in pixels
+	//   - y: the top edge in pixels
+	//   - width: the width in pixels
+	//   - height: the height in pixels
+	SetViewport(x, y, width, height float32)
+
+	// SetScissorRect sets the scissor rectangle of the open frame or render pass and remembers it
+	// like SetViewport.
+	//
+	// Parameters:
+	//   - x: the left edge in pixels
+	//   - y: the top edge in pixels
+	//   - width: the width in pixels
+	//   - height: the height in pixels
+	SetScissorRect(x, y, width, height uint32)
+
+	// ClearViewport draws a triangle over the current viewport of the open pass that writes the
+	// clear color, the far-plane depth, or both, restricted by the scissor rectangle.
+	//
+	// Parameters:
+	//   - color: the clear color, or nil to keep the color target
+	//   - depth: true to reset the depth buffer to the far plane
+	//
+	// Returns:
+	//   - error: an error if no frame is in progress or the clear pipeline could not be created
+	ClearViewport(color *wgpu.Color, depth bool) error
+
+	// SetPostEffects replaces the ordered post effect chain run by EndFrame. GPU resources of
+	// effects that are no longer in the chain are released.
+	//
+	// Parameters:
+	//   - effects: the effects in the order they run
+	SetPostEffects(effects []PostEffect)
+
+	// RegisterPostEffect creates the pipelines for every pass of a post effect, for both
+	// intermediate and frame targets, so shader errors surface before the effect is used.
+	//
+	// Parameters:
+	//   - e: the effect to register
+	//
+	// Returns:
+	//   - error: an error if a pass shader is invalid or pipeline creation fails
+	RegisterPostEffect(e PostEffect) error
+
+	// ReadPixels copies the offscreen color target back to the CPU and returns it as an RGBA image.
+	// Blocks until the GPU copy has completed. Only supported on headless backends, and should be
+	// called after EndFrame so the copy observes the finished frame.
+	//
+	// Returns:
+	//   - *image.RGBA: the rendered frame
+	//   - error: an error if the backend is not headless or the readback fails
+	ReadPixels() (*image.RGBA, error)
+
+	// Release frees the backend's render targets, post-processing, transparency and GPU timer
+	// resources, and its device, queue, surface, adapter and instance. The backend must not be used after.
+	Release()
 }
 
 var _ RendererBackend = &wgpuRendererBackendImpl{}
 
-func newWGPURendererBackend(surfaceDescriptor *wgpu.SurfaceDescriptor, forceFallbackAdapter bool, sampleCount MSAASampleCount) wgpuRendererBackend {
+// featureTimestampQueryInsidePasses is wgpu-native's feature for timestamps written inside
+// passes. The binding has no constant for it, so its native enum value is used directly. The
+// bundled wgpu-native has no separate inside-encoders feature: CommandEncoder.WriteTimestamp
+// only needs the core timestamp query feature.
+const featureTimestampQueryInsidePasses wgpu.FeatureName = 0x0003000D
+
+// deviceFeatures returns the optional features to request from the adapter: every texture
+// compression family it offers, plus timestamp queries when GPU timings are requested and available.
+// The native inside-passes timestamp feature is requested alongside when the adapter offers it.
+//
+// Parameters:
+//   - a: the adapter the device is requested from
+//   - timestamps: true to request the timestamp query feature
+//
+// Returns:
+//   - []wgpu.FeatureName: the features to require on the device
+func deviceFeatures(a *wgpu.Adapter, timestamps bool) []wgpu.FeatureName {
+	features := textureCompressionFeatures(a)
+	if timestamps && a.HasFeature(wgpu.FeatureNameTimestampQuery) {
+		features = append(features, wgpu.FeatureNameTimestampQuery)
+		if a.HasFeature(featureTimestampQueryInsidePasses) {
+			features = append(features, featureTimestampQueryInsidePasses)
+		}
+	}
+	return features
+}
+
+func newWGPURendererBackend(surfaceDescriptor *wgpu.SurfaceDescriptor, forceFallbackAdapter bool, sampleCount MSAASampleCount, hdr, oit, timestamps bool) wgpuRendererBackend {
 	runtime.LockOSThread()
 	w := &wgpuRendererBackendImpl{
 		mu:          &sync.Mutex{},
 		instance:    wgpu.CreateInstance(nil),
 		presentMode: wgpu.PresentModeImmediate,
 		sampleCount: sampleCount,
+		hdr:         hdr,
+		oit:         oit,
 	}
 	w.SetSurface(w.instance.CreateSurface(surfaceDescriptor))
 
@@ -307,7 +660,8 @@ func newWGPURendererBackend(surfaceDescriptor *wgpu.SurfaceDescriptor, forceFall
 	limits.MaxBindGroups = 8
 
 	d, err := a.RequestDevice(&wgpu.DeviceDescriptor{
-		Label: "Main Device",
+		Label:            "Main Device",
+		RequiredFeatures: deviceFeatures(a, timestamps),
 		RequiredLimits: &wgpu.RequiredLimits{
 			Limits: limits,
 		},
@@ -317,6 +671,60 @@ func newWGPURendererBackend(surfaceDescriptor *wgpu.SurfaceDescriptor, forceFall
 	}
 	w.SetDevice(d)
 	w.SetQueue(d.GetQueue())
+	w.gpuTimestamps = timestamps && d.HasFeature(wgpu.FeatureNameTimestampQuery)
+
+	return w
+}
+
+// newHeadlessWGPURendererBackend creates a wgpu backend without a surface. The main render
+// pass targets an offscreen RGBA8UnormSrgb texture created by ConfigureSurface, so compute,
+// shadow, culling, and draw phases all run without a window.
+//
+// Parameters:
+//   - forceFallbackAdapter: true to request the CPU/software fallback adapter
+//   - sampleCount: the MSAA sample count for the main render pass
+//   - hdr: true to render the main pass into an RGBA16Float scene target
+//   - oit: true to enable the weighted blended transparency pass
+//   - timestamps: true to record GPU timestamps when the adapter supports them
+//
+// Returns:
+//   - wgpuRendererBackend: the headless backend
+func newHeadlessWGPURendererBackend(forceFallbackAdapter bool, sampleCount MSAASampleCount, hdr, oit, timestamps bool) wgpuRendererBackend {
+	runtime.LockOSThread()
+	w := &wgpuRendererBackendImpl{
+		mu:          &sync.Mutex{},
+		instance:    wgpu.CreateInstance(nil),
+		presentMode: wgpu.PresentModeImmediate,
+		sampleCount: sampleCount,
+		headless:    true,
+		hdr:         hdr,
+		oit:         oit,
+	}
+
+	a, err := w.instance.RequestAdapter(&wgpu.RequestAdapterOptions{
+		ForceFallbackAdapter: forceFallbackAdapter,
+	})
+	if err != nil {
+		panic(err)
+	}
+	w.SetAdapter(a)
+
+	limits := wgpu.DefaultLimits()
+	limits.MaxBindGroups = 8
+
+	d, err := a.RequestDevice(&wgpu.DeviceDescriptor{
+		Label:            "Headless Device",
+		RequiredFeatures: deviceFeatures(a, timestamps),
+		RequiredLimits: &wgpu.RequiredLimits{
+			Limits: limits,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	w.SetDevice(d)
+	w.SetQueue(d.GetQueue())
+	w.gpuTimestamps = timestamps && d.HasFeature(wgpu.FeatureNameTimestampQuery)
 
 	return w
 }
@@ -325,24 +733,33 @@ func (b *wgpuRendererBackendImpl) ConfigureSurface(width, height int) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	capabilities := b.surface.GetCapabilities(b.adapter)
-	b.surfaceFormat = &capabilities.Formats[0]
-
-	b.surface.Configure(b.adapter, b.device, &wgpu.SurfaceConfiguration{
-		Usage:       wgpu.TextureUsageRenderAttachment,
-		Format:      *b.surfaceFormat,
-		Width:       uint32(width),
-		Height:      uint32(height),
-		PresentMode: b.presentMode,
-		AlphaMode:   capabilities.AlphaModes[0],
-	})
+	if b.headless {
+		b.configureOffscreenTarget(width, height)
+	} else {
+		capabilities := b.surface.GetCapabilities(b.adapter)
+		b.surfaceFormat = &capabilities.Formats[0]
+
+		b.surface.Configure(b.adapter, b.device, &wgpu.SurfaceConfiguration{
+			Usage:       wgpu.TextureUsageRenderAttachment,
+			Format:      *b.surfaceFormat,
+			Width:       uint32(width),
+			Height:      uint32(height),
+			PresentMode: b.presentMode,
+			AlphaMode:   capabilities.AlphaModes[0],
+		})
+	}
+	b.targetWidth = uint32(width)
+	b.targetHeight = uint32(height)
+	b.releasePostTargets()
+	b.releaseOITTargets()
 
 	count := uint32(b.sampleCount)
 	msaaEnabled := count > 1
 
 	if msaaEnabled {
 		// Create the MSAA texture that the render pass draws into; the resolved
-		// result is written to the swapchain view as the ResolveTarget.
+		// result is written to the swapchain view (or the scene color target
+		// when post-processing) as the ResolveTarget.
 		msaaTexture, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
 			Label: "MSAA Texture",
 			Size: wgpu.Extent3D{
@@ -353,7 +770,7 @@ func (b *wgpuRendererBackendImpl) ConfigureSurface(width, height int) {
 			MipLevelCount: 1,
 			SampleCount:   count,
 			Dimension:     wgpu.TextureDimension2D,
-			Format:        *b.surfaceFormat,
+			Format:        b.sceneFormat(),
 			Usage:         wgpu.TextureUsageRenderAttachment,
 		})
 		if err != nil {
@@ -368,7 +785,8 @@ func (b *wgpuRendererBackendImpl) ConfigureSurface(width, height int) {
 		b.msaaTextureView = nil
 	}
 
-	// Depth texture sample count must match the color attachment.
+	// Depth texture sample count must match the color attachment. It is also
+	// bindable so post effects can read scene depth.
 	depthTexture, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
 		Label: "Depth Texture",
 		Size: wgpu.Extent3D{
@@ -380,7 +798,7 @@ func (b *wgpuRendererBackendImpl) ConfigureSurface(width, height int) {
 		SampleCount:   count,
 		Dimension:     wgpu.TextureDimension2D,
 		Format:        wgpu.TextureFormatDepth24Plus,
-		Usage:         wgpu.TextureUsageRenderAttachment,
+		Usage:         wgpu.TextureUsageRenderAttachment | wgpu.TextureUsageTextureBinding,
 	})
 	if err != nil {
 		panic(err)
@@ -395,8 +813,8 @@ func (b *wgpuRendererBackendImpl) ConfigureSurface(width, height int) {
 	// set per-frame to the swapchain view. When disabled, View is set
 	// per-frame to the swapchain view and ResolveTarget remains nil.
 	storeOp := wgpu.StoreOpStore
-	if msaaEnabled {
-		storeOp = wgpu.StoreOpDiscard // Don't store MSAA data, just resolve
+	if msaaEnabled && !b.oit {
+		storeOp = wgpu.StoreOpDiscard // Don't store MSAA data, just resolve; kept when the transparency pass resumes the main pass
 	}
 	b.renderPassDescriptor = &wgpu.RenderPassDescriptor{
 		ColorAttachments: []wgpu.RenderPassColorAttachment{
@@ -413,7 +831,7 @@ func (b *wgpuRendererBackendImpl) ConfigureSurface(width, height int) {
 		DepthStencilAttachment: &wgpu.RenderPassDepthStencilAttachment{
 			View:            b.depthTextureView, // Persistent until resize
 			DepthLoadOp:     wgpu.LoadOpClear,
-			DepthStoreOp:    wgpu.StoreOpDiscard, // Depth not needed after resolving
+			DepthStoreOp:    wgpu.StoreOpDiscard, // Stored per-frame only when post effects read it
 			DepthClearValue: 1.0,
 		},
 	}
@@ -496,6 +914,37 @@ func (b *wgpuRendererBackendImpl) RegisterRenderPipeline(p pipeline.Pipeline) er
 	vertexShader := p.Shader(shader.ShaderTypeVertex)
 	fragmentShader := p.Shader(shader.ShaderTypeFragment)
 
+	fragmentEntryPoint := fragmentShader.EntryPoint()
+	if p.FragmentEntryPoint() != "" {
+		fragmentEntryPoint = p.FragmentEntryPoint()
+	}
+
+	colorTargets := []wgpu.ColorTargetState{
+		{
+			Format:    b.sceneFormat(),
+			WriteMask: p.WriteMask(),
+		},
+	}
+	if p.BlendEnabled() {
+		colorTargets[0].Blend = p.BlendState()
+	}
+	if p.WeightedBlended() {
+		colorTargets = oitColorTargets()
+	}
+	if formats := p.ColorFormats(); formats != nil {
+		colorTargets = make([]wgpu.ColorTargetState, len(formats))
+		for i, format := range formats {
+			colorTargets[i] = wgpu.ColorTargetState{Format: format, WriteMask: p.WriteMask()}
+			if p.BlendEnabled() {
+				colorTargets[i].Blend = p.BlendState()
+			}
+		}
+	}
+	sampleCount := uint32(b.sampleCount)
+	if p.SampleCount() > 0 {
+		sampleCount = p.SampleCount()
+	}
+
 	vs, err := b.device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{
 		Label: vertexShader.Key(),
 		WGSLDescriptor: &wgpu.ShaderModuleWGSLDescriptor{
@@ -554,19 +1003,8 @@ func (b *wgpuRendererBackendImpl) RegisterRenderPipeline(p pipeline.Pipeline) er
 		},
 		Fragment: &wgpu.FragmentState{
 			Module:     fs,
-			EntryPoint: fragmentShader.EntryPoint(),
-			Targets: []wgpu.ColorTargetState{
-				func() wgpu.ColorTargetState {
-					state := wgpu.ColorTargetState{
-						Format:    *b.surfaceFormat,
-						WriteMask: p.WriteMask(),
-					}
-					if p.BlendEnabled() {
-						state.Blend = p.BlendState()
-					}
-					return state
-				}(),
-			},
+			EntryPoint: fragmentEntryPoint,
+			Targets:    colorTargets,
 		},
 		Primitive: wgpu.PrimitiveState{
 			Topology:  p.Topology(),
@@ -574,7 +1012,7 @@ func (b *wgpuRendererBackendImpl) RegisterRenderPipeline(p pipeline.Pipeline) er
 			CullMode:  p.CullMode(),
 		},
 		Multisample: wgpu.MultisampleState{
-			Count: uint32(b.sampleCount),
+			Count: sampleCount,
 			Mask:  0xFFFFFFFF,
 		},
 		DepthStencil: func() *wgpu.DepthStencilState {
@@ -801,42 +1239,48 @@ func (b *wgpuRendererBackendImpl) InitTextureView(provider bind_group_provider.B
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	format := common.Coalesce(stagingData.Format, wgpu.TextureFormatRGBA8UnormSrgb)
+	levels := uint32(1)
+	usage := wgpu.TextureUsageTextureBinding | wgpu.TextureUsageCopyDst
+	generate := stagingData.GenerateMipmaps && len(stagingData.MipLevels) == 0 &&
+		(format == wgpu.TextureFormatRGBA8UnormSrgb || format == wgpu.TextureFormatRGBA8Unorm)
+	if len(stagingData.MipLevels) > 0 {
+		levels += uint32(len(stagingData.MipLevels))
+	} else if generate {
+		levels = common.MipLevelCount(stagingData.Width, stagingData.Height)
+		usage |= wgpu.TextureUsageRenderAttachment
+	}
+
 	tex, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
 		Label:     provider.Label() + " Texture",
-		Usage:     wgpu.TextureUsageTextureBinding | wgpu.TextureUsageCopyDst,
+		Usage:     usage,
 		Dimension: wgpu.TextureDimension2D,
 		Size: wgpu.Extent3D{
 			Width:              stagingData.Width,
 			Height:             stagingData.Height,
 			DepthOrArrayLayers: 1,
 		},
-		Format:        wgpu.TextureFormatRGBA8UnormSrgb,
-		MipLevelCount: 1,
+		Format:        format,
+		MipLevelCount: levels,
 		SampleCount:   1,
 	})
 	if err != nil {
 		return err
 	}
 
-	b.queue.WriteTexture(
-		&wgpu.ImageCopyTexture{
-			Texture:  tex,
-			MipLevel: 0,
-			Origin:   wgpu.Origin3D{},
-			Aspect:   wgpu.TextureAspectAll,
-		},
-		stagingData.Pixels,
-		&wgpu.TextureDataLayout{
-			Offset:       0,
-			BytesPerRow:  stagingData.Width * 4,
-			RowsPerImage: stagingData.Height,
-		},
-		&wgpu.Extent3D{
-			Width:              stagingData.Width,
-			Height:             stagingData.Height,
-			DepthOrArrayLayers: 1,
-		},
-	)
+	b.writeTextureLevel(tex, format, 0, 0, stagingData.Pixels, stagingData.Width, stagingData.Height)
+	for i, pixels := range stagingData.MipLevels {
+		level := uint32(i + 1)
+		b.writeTextureLevel(tex, format, level, 0, pixels, max(stagingData.Width>>level, 1), max(stagingData.Height>>level, 1))
+	}
+
+	// Prefer the GPU blit chain; fall back to box-filtering on the CPU if the
+	// blit pipeline cannot be created for this device.
+	if generate && levels > 1 {
+		if err := b.generateMipmaps(tex, format, levels); err != nil {
+			b.generateMipmapsCPU(tex, format, stagingData, levels)
+		}
+	}
 
 	view, err := tex.CreateView(nil)
 	if err != nil {
@@ -847,21 +1291,82 @@ func (b *wgpuRendererBackendImpl) InitTextureView(provider bind_group_provider.B
 	return nil
 }
 
+func (b *wgpuRendererBackendImpl) InitCubeTextureView(provider bind_group_provider.BindGroupProvider, bindingKey int, stagingData common.CubemapStagingData) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	format := common.Coalesce(stagingData.Format, wgpu.TextureFormatRGBA8UnormSrgb)
+	levels := uint32(1 + len(stagingData.MipLevels))
+
+	tex, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:     provider.Label() + " Cube Texture",
+		Usage:     wgpu.TextureUsageTextureBinding | wgpu.TextureUsageCopyDst,
+		Dimension: wgpu.TextureDimension2D,
+		Size: wgpu.Extent3D{
+			Width:              stagingData.Size,
+			Height:             stagingData.Size,
+			DepthOrArrayLayers: 6,
+		},
+		Format:        format,
+		MipLevelCount: levels,
+		SampleCount:   1,
+	})
+	if err != nil {
+		return err
+	}
+
+	for face, pixels := range stagingData.Faces {
+		b.writeTextureLevel(tex, format, 0, uint32(face), pixels, stagingData.Size, stagingData.Size)
+	}
+	for i, faces := range stagingData.MipLevels {
+		level := uint32(i + 1)
+		size := max(stagingData.Size>>level, 1)
+		for face, pixels := range faces {
+			b.writeTextureLevel(tex, format, level, uint32(face), pixels, size, size)
+		}
+	}
+
+	view, err := tex.CreateView(&wgpu.TextureViewDescriptor{
+		Label:           provider.Label() + " Cube View",
+		Format:          format,
+		Dimension:       wgpu.TextureViewDimensionCube,
+		BaseMipLevel:    0,
+		MipLevelCount:   levels,
+		BaseArrayLayer:  0,
+		ArrayLayerCount: 6,
+		Aspect:          wgpu.TextureAspectAll,
+	})
+	if err != nil {
+		return err
+	}
+	provider.SetTextureView(bindingKey, view)
+
+	return nil
+}
+
 func (b *wgpuRendererBackendImpl) InitSampler(provider bind_group_provider.BindGroupProvider, bindingKey int, samplerStagingData common.SamplerStagingData) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	// The staging fields are used as given: the zero filter is Nearest and a zero LodMaxClamp
+	// samples only the base level. WebGPU rejects anisotropy above 1 unless every filter is linear.
+	s := samplerStagingData
+	anisotropy := min(max(s.MaxAnisotropy, 1), maxSamplerAnisotropy)
+	if s.MagFilter != wgpu.FilterModeLinear || s.MinFilter != wgpu.FilterModeLinear || s.MipmapFilter != wgpu.MipmapFilterModeLinear {
+		anisotropy = 1
+	}
+
 	samp, err := b.device.CreateSampler(&wgpu.SamplerDescriptor{
 		Label:         provider.Label() + " Sampler",
-		AddressModeU:  common.Coalesce(samplerStagingData.AddressModeU, wgpu.AddressModeRepeat),
-		AddressModeV:  common.Coalesce(samplerStagingData.AddressModeV, wgpu.AddressModeRepeat),
-		AddressModeW:  common.Coalesce(samplerStagingData.AddressModeW, wgpu.AddressModeRepeat),
-		MagFilter:     common.Coalesce(samplerStagingData.MagFilter, wgpu.FilterModeLinear),
-		MinFilter:     common.Coalesce(samplerStagingData.MinFilter, wgpu.FilterModeLinear),
-		MipmapFilter:  common.Coalesce(samplerStagingData.MipmapFilter, wgpu.MipmapFilterModeLinear),
-		LodMinClamp:   common.Coalesce(samplerStagingData.LodMinClamp, 0.0),
-		LodMaxClamp:   common.Coalesce(samplerStagingData.LodMaxClamp, 32.0),
-		MaxAnisotropy: common.Coalesce(samplerStagingData.MaxAnisotropy, 1),
+		AddressModeU:  s.AddressModeU,
+		AddressModeV:  s.AddressModeV,
+		AddressModeW:  s.AddressModeW,
+		MagFilter:     s.MagFilter,
+		MinFilter:     s.MinFilter,
+		MipmapFilter:  s.MipmapFilter,
+		LodMinClamp:   s.LodMinClamp,
+		LodMaxClamp:   s.LodMaxClamp,
+		MaxAnisotropy: anisotropy,
 		Compare:       samplerStagingData.Compare,
 	})
 	if err != nil {
@@ -872,6 +1377,18 @@ func (b *wgpuRendererBackendImpl) InitSampler(provider bind_group_provider.BindG
 	return nil
 }
 
+func (b *wgpuRendererBackendImpl) SupportsTextureFormat(format wgpu.TextureFormat) bool {
+	switch {
+	case format >= wgpu.TextureFormatBC1RGBAUnorm && format <= wgpu.TextureFormatBC7RGBAUnormSrgb:
+		return b.device.HasFeature(wgpu.FeatureNameTextureCompressionBC)
+	case format >= wgpu.TextureFormatETC2RGB8Unorm && format <= wgpu.TextureFormatEACRG11Snorm:
+		return b.device.HasFeature(wgpu.FeatureNameTextureCompressionETC2)
+	case format >= wgpu.TextureFormatASTC4x4Unorm && format <= wgpu.TextureFormatASTC12x12UnormSrgb:
+		return b.device.HasFeature(wgpu.FeatureNameTextureCompressionASTC)
+	}
+	return true
+}
+
 func (b *wgpuRendererBackendImpl) WriteBuffers(writes []bind_group_provider.BufferWrite) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -896,6 +1413,28 @@ func (b *wgpuRendererBackendImpl) BeginFrame() error {
 		return fmt.Errorf("previous frame surface not yet presented")
 	}
 
+	// Headless backends render straight into the persistent offscreen view; there is
+	// no swapchain image to acquire or release.
+	if b.headless {
+		if b.framePass != nil {
+			return fmt.Errorf("previous frame not yet ended")
+		}
+		encoder, err := b.device.CreateCommandEncoder(nil)
+		if err != nil {
+			return err
+		}
+		target := b.beginPostFrame(b.offscreenView)
+		if b.sampleCount > 1 {
+			b.renderPassDescriptor.ColorAttachments[0].ResolveTarget = target
+		} else {
+			b.renderPassDescriptor.ColorAttachments[0].View = target
+		}
+		b.frameEncoder = encoder
+		b.framePass = encoder.BeginRenderPass(b.renderPassDescriptor)
+		b.resetViewport()
+		return nil
+	}
+
 	surfaceTexture, err := b.surface.GetCurrentTexture()
 	if err != nil {
 		return err
@@ -917,10 +1456,13 @@ func (b *wgpuRendererBackendImpl) BeginFrame() error {
 	// When MSAA is enabled, the MSAA texture is the color attachment View and
 	// the swapchain view is the ResolveTarget. When MSAA is off, the swapchain
 	// view is the color attachment View directly and ResolveTarget is nil.
+	// With post-processing active, the scene color target takes the place of
+	// the swapchain view.
+	target := b.beginPostFrame(view)
 	if b.sampleCount > 1 {
-		b.renderPassDescriptor.ColorAttachments[0].ResolveTarget = view
+		b.renderPassDescriptor.ColorAttachments[0].ResolveTarget = target
 	} else {
-		b.renderPassDescriptor.ColorAttachments[0].View = view
+		b.renderPassDescriptor.ColorAttachments[0].View = target
 	}
 	pass := encoder.BeginRenderPass(b.renderPassDescriptor)
 
@@ -928,6 +1470,7 @@ func (b *wgpuRendererBackendImpl) BeginFrame() error {
 	b.framePass = pass
 	b.frameSurface = surfaceTexture
 	b.frameView = view
+	b.resetViewport()
 
 	return nil
 }
@@ -953,6 +1496,63 @@ func (b *wgpuRendererBackendImpl) DrawCall(
 	b.framePass.DrawIndexed(uint32(meshProvider.IndexCount()), instanceCount, 0, 0, 0)
 }
 
+func (b *wgpuRendererBackendImpl) DrawProcedural(
+	p pipeline.Pipeline,
+	vertexCount, instanceCount uint32,
+	bindGroups []bind_group_provider.BindGroupProvider,
+) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	renderPipeline := p.Pipeline().(*wgpu.RenderPipeline)
+	b.framePass.SetPipeline(renderPipeline)
+
+	for i, bg := range bindGroups {
+		b.framePass.SetBindGroup(uint32(i), bg.BindGroup(), nil)
+	}
+
+	b.framePass.Draw(vertexCount, instanceCount, 0, 0)
+}
+
+func (b *wgpuRendererBackendImpl) DrawProceduralRange(
+	p pipeline.Pipeline,
+	firstVertex, vertexCount uint32,
+	bindGroups []bind_group_provider.BindGroupProvider,
+) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	renderPipeline := p.Pipeline().(*wgpu.RenderPipeline)
+	b.framePass.SetPipeline(renderPipeline)
+
+	for i, bg := range bindGroups {
+		b.framePass.SetBindGroup(uint32(i), bg.BindGroup(), nil)
+	}
+
+	b.framePass.Draw(vertexCount, 1, firstVertex, 0)
+}
+
+func (b *wgpuRendererBackendImpl) DrawCallInstances(
+	p pipeline.Pipeline,
+	meshProvider bind_group_provider.BindGroupProvider,
+	firstInstance, instanceCount uint32,
+	bindGroups []bind_group_provider.BindGroupProvider,
+) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	renderPipeline := p.Pipeline().(*wgpu.RenderPipeline)
+	b.framePass.SetPipeline(renderPipeline)
+
+	for i, bg := range bindGroups {
+		b.framePass.SetBindGroup(uint32(i), bg.BindGroup(), nil)
+	}
+
+	b.framePass.SetVertexBuffer(0, meshProvider.VertexBuffer(), 0, wgpu.WholeSize)
+	b.framePass.SetIndexBuffer(meshProvider.IndexBuffer(), wgpu.IndexFormatUint32, 0, wgpu.WholeSize)
+	b.framePass.DrawIndexed(uint32(meshProvider.IndexCount()), instanceCount, 0, 0, firstInstance)
+}
+
 func (b *wgpuRendererBackendImpl) DrawCallIndirect(
 	p pipeline.Pipeline,
 	meshProvider bind_group_provider.BindGroupProvider,
@@ -979,12 +1579,26 @@ func (b *wgpuRendererBackendImpl) EndFrame() {
 	defer b.mu.Unlock()
 
 	b.framePass.End()
+	b.oitPassActive = false
+
+	if b.postActive {
+		final := b.frameView
+		if b.headless {
+			final = b.offscreenView
+		}
+		b.encodePostProcess(b.frameEncoder, final)
+	}
+	defer b.releasePostFrame()
 
 	commandBuffer, err := b.frameEncoder.Finish(nil)
 	if err != nil {
 		b.frameEncoder.Release()
-		b.frameView.Release()
-		b.frameSurface.Release()
+		if b.frameView != nil {
+			b.frameView.Release()
+		}
+		if b.frameSurface != nil {
+			b.frameSurface.Release()
+		}
 		b.frameEncoder = nil
 		b.framePass = nil
 		b.frameSurface = nil
@@ -1062,16 +1676,17 @@ func (b *wgpuRendererBackendImpl) SetSurface(surface *wgpu.Surface) {
 	b.surface = surface
 }
 
-func (b *wgpuRendererBackendImpl) CreateShadowDepthTexture(width, height int) (*wgpu.TextureView, *wgpu.Texture, error) {
+func (b *wgpuRendererBackendImpl) CreateShadowDepthTexture(width, height, layers int) (*wgpu.TextureView, []*wgpu.TextureView, *wgpu.Texture, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	layers = max(layers, 1)
 	tex, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
 		Label: "Shadow Depth Texture",
 		Size: wgpu.Extent3D{
 			Width:              uint32(width),
 			Height:             uint32(height),
-			DepthOrArrayLayers: 1,
+			DepthOrArrayLayers: uint32(layers),
 		},
 		MipLevelCount: 1,
 		SampleCount:   1,
@@ -1080,16 +1695,48 @@ func (b *wgpuRendererBackendImpl) CreateShadowDepthTexture(width, height int) (*
 		Usage:         wgpu.TextureUsageRenderAttachment | wgpu.TextureUsageTextureBinding,
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create shadow depth texture: %w", err)
-	}
-
-	view, err := tex.CreateView(nil)
+		return nil, nil, nil, fmt.Errorf("failed to create shadow depth texture: %w", err)
+	}
+
+	// An explicit 2D array dimension keeps a single-layer texture bindable as texture_depth_2d_array.
+	arrayView, err := tex.CreateView(&wgpu.TextureViewDescriptor{
+		Label:           "Shadow Depth Array View",
+		Format:          wgpu.TextureFormatDepth32Float,
+		Dimension:       wgpu.TextureViewDimension2DArray,
+		BaseMipLevel:    0,
+		MipLevelCount:   1,
+		BaseArrayLayer:  0,
+		ArrayLayerCount: uint32(layers),
+		Aspect:          wgpu.TextureAspectAll,
+	})
 	if err != nil {
 		tex.Release()
-		return nil, nil, fmt.Errorf("failed to create shadow depth texture view: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to create shadow depth texture view: %w", err)
+	}
+
+	layerViews := make([]*wgpu.TextureView, layers)
+	for i := range layers {
+		layerViews[i], err = tex.CreateView(&wgpu.TextureViewDescriptor{
+			Label:           fmt.Sprintf("Shadow Depth Layer %d View", i),
+			Format:          wgpu.TextureFormatDepth32Float,
+			Dimension:       wgpu.TextureViewDimension2D,
+			BaseMipLevel:    0,
+			MipLevelCount:   1,
+			BaseArrayLayer:  uint32(i),
+			ArrayLayerCount: 1,
+			Aspect:          wgpu.TextureAspectAll,
+		})
+		if err != nil {
+			for _, v := range layerViews[:i] {
+				v.Release()
+			}
+			arrayView.Release()
+			tex.Release()
+			return nil, nil, nil, fmt.Errorf("failed to create shadow depth layer view: %w", err)
+		}
 	}
 
-	return view, tex, nil
+	return arrayView, layerViews, tex, nil
 }
 
 func (b *wgpuRendererBackendImpl) CreateComparisonSampler() (*wgpu.Sampler, error) {
@@ -1233,6 +1880,18 @@ func (b *wgpuRendererBackendImpl) BeginShadowPass(depthView *wgpu.TextureView) {
 	b.shadowPass = pass
 }
 
+func (b *wgpuRendererBackendImpl) SetShadowViewport(x, y, width, height uint32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.shadowPass == nil {
+		return
+	}
+
+	b.shadowPass.SetViewport(float32(x), float32(y), float32(width), float32(height), 0, 1)
+	b.shadowPass.SetScissorRect(x, y, width, height)
+}
+
 func (b *wgpuRendererBackendImpl) ShadowDrawCall(
 	p pipeline.Pipeline,
 	meshProvider bind_group_provider.BindGroupProvider,
@@ -1316,6 +1975,178 @@ func (b *wgpuRendererBackendImpl) EndShadowFrame() {
 	b.shadowFrameEncoder = nil
 }
 
+func (b *wgpuRendererBackendImpl) Headless() bool {
+	return b.headless
+}
+
+func (b *wgpuRendererBackendImpl) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.releasePostFrame()
+	b.releasePostTargets()
+	b.releaseOITTargets()
+	b.releaseGPUTimerResources()
+	for effect, s := range b.postStates {
+		s.release()
+		delete(b.postStates, effect)
+	}
+	if b.offscreenView != nil {
+		b.offscreenView.Release()
+		b.offscreenTexture.Release()
+		b.offscreenView, b.offscreenTexture = nil, nil
+	}
+	if b.msaaTextureView != nil {
+		b.msaaTextureView.Release()
+		b.msaaTextureView = nil
+	}
+	if b.depthTextureView != nil {
+		b.depthTextureView.Release()
+		b.depthTextureView = nil
+	}
+	if b.queue != nil {
+		b.queue.Release()
+		b.queue = nil
+	}
+	if b.device != nil {
+		b.device.Release()
+		b.device = nil
+	}
+	if b.surface != nil {
+		b.surface.Release()
+		b.surface = nil
+	}
+	if b.adapter != nil {
+		b.adapter.Release()
+		b.adapter = nil
+	}
+	if b.instance != nil {
+		b.instance.Release()
+		b.instance = nil
+	}
+}
+
+func (b *wgpuRendererBackendImpl) ReadPixels() (*image.RGBA, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.headless || b.offscreenTexture == nil {
+		return nil, errors.New("ReadPixels requires a headless renderer with a configured target")
+	}
+
+	width, height := b.targetWidth, b.targetHeight
+	// Texture-to-buffer copies require each row to start on a 256-byte boundary.
+	unpadded := width * 4
+	padded := (unpadded + 255) &^ 255
+	size := uint64(padded) * uint64(height)
+
+	readback, err := b.device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "Readback Buffer",
+		Size:  size,
+		Usage: wgpu.BufferUsageMapRead | wgpu.BufferUsageCopyDst,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer readback.Release()
+
+	encoder, err := b.device.CreateCommandEncoder(nil)
+	if err != nil {
+		return nil, err
+	}
+	encoder.CopyTextureToBuffer(
+		&wgpu.ImageCopyTexture{
+			Texture:  b.offscreenTexture,
+			MipLevel: 0,
+			Origin:   wgpu.Origin3D{},
+			Aspect:   wgpu.TextureAspectAll,
+		},
+		&wgpu.ImageCopyBuffer{
+			Buffer: readback,
+			Layout: wgpu.TextureDataLayout{
+				Offset:       0,
+				BytesPerRow:  padded,
+				RowsPerImage: height,
+			},
+		},
+		&wgpu.Extent3D{
+			Width:              width,
+			Height:             height,
+			DepthOrArrayLayers: 1,
+		},
+	)
+	commandBuffer, err := encoder.Finish(nil)
+	encoder.Release()
+	if err != nil {
+		return nil, err
+	}
+	b.queue.Submit(commandBuffer)
+	commandBuffer.Release()
+
+	status := wgpu.BufferMapAsyncStatusUnknown
+	readback.MapAsync(wgpu.MapModeRead, 0, size, func(s wgpu.BufferMapAsyncStatus) {
+		status = s
+	})
+	// Block until the copy and map have completed; the callback fires from within Poll.
+	b.device.Poll(true, nil)
+	if status != wgpu.BufferMapAsyncStatusSuccess {
+		return nil, fmt.Errorf("failed to map readback buffer: status %d", status)
+	}
+
+	mapped := readback.GetMappedRange(0, uint(size))
+	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	for y := range height {
+		src := mapped[uint32(y)*padded : uint32(y)*padded+unpadded]
+		copy(img.Pix[int(y)*img.Stride:], src)
+	}
+	readback.Unmap()
+
+	return img, nil
+}
+
+// configureOffscreenTarget (re)creates the headless color target at the given size.
+// The texture is RGBA8UnormSrgb so readback bytes map directly onto image.RGBA.
+// Caller must hold b.mu.
+//
+// Parameters:
+//   - width: target width in pixels
+//   - height: target height in pixels
+func (b *wgpuRendererBackendImpl) configureOffscreenTarget(width, height int) {
+	if b.offscreenView != nil {
+		b.offscreenView.Release()
+		b.offscreenView = nil
+	}
+	if b.offscreenTexture != nil {
+		b.offscreenTexture.Release()
+		b.offscreenTexture = nil
+	}
+
+	format := wgpu.TextureFormatRGBA8UnormSrgb
+	b.surfaceFormat = &format
+
+	tex, err := b.device.CreateTexture(&wgpu.TextureDescriptor{
+		Label: "Offscreen Color Texture",
+		Size: wgpu.Extent3D{
+			Width:              uint32(width),
+			Height:             uint32(height),
+			DepthOrArrayLayers: 1,
+		},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        format,
+		Usage:         wgpu.TextureUsageRenderAttachment | wgpu.TextureUsageCopySrc | wgpu.TextureUsageTextureBinding,
+	})
+	if err != nil {
+		panic(err)
+	}
+	b.offscreenTexture = tex
+	b.offscreenView, err = tex.CreateView(nil)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // mergeBindGroupLayouts merges the bind group layout descriptors from a vertex and fragment shader
 // into a unified set of descriptors suitable for a render pipeline layout.
 //
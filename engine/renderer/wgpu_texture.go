@@ -0,0 +1,162 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// maxSamplerAnisotropy is the highest anisotropy level WebGPU accepts; larger requests are clamped.
+const maxSamplerAnisotropy = 16
+
+// generateMipmaps fills mip levels 1..levels-1 of a texture by repeatedly blitting each level
+// into the next with the post-process copy pass. Each destination pixel center falls on the
+// shared corner of a 2x2 source block, so the linear sampler averages the block, in linear
+// space for sRGB formats.
+// The texture must have been created with RenderAttachment usage. Caller must hold b.mu.
+//
+// Parameters:
+//   - tex: the texture, with level 0 already uploaded
+//   - format: the texture format
+//   - levels: the texture's mip level count
+//
+// Returns:
+//   - error: an error if the blit pipeline or a level view could not be created
+func (b *wgpuRendererBackendImpl) generateMipmaps(tex *wgpu.Texture, format wgpu.TextureFormat, levels uint32) error {
+	if err := b.ensurePostResources(); err != nil {
+		return err
+	}
+	pp, err := b.postPipelineFor(b.postCopyShader, format)
+	if err != nil {
+		return err
+	}
+
+	views := make([]*wgpu.TextureView, 0, levels)
+	defer func() {
+		for _, v := range views {
+			v.Release()
+		}
+	}()
+	for level := range levels {
+		view, err := tex.CreateView(&wgpu.TextureViewDescriptor{
+			Label:           fmt.Sprintf("Mip Level %d", level),
+			Format:          format,
+			Dimension:       wgpu.TextureViewDimension2D,
+			BaseMipLevel:    level,
+			MipLevelCount:   1,
+			BaseArrayLayer:  0,
+			ArrayLayerCount: 1,
+			Aspect:          wgpu.TextureAspectAll,
+		})
+		if err != nil {
+			return err
+		}
+		views = append(views, view)
+	}
+
+	encoder, err := b.device.CreateCommandEncoder(nil)
+	if err != nil {
+		return err
+	}
+	defer encoder.Release()
+
+	// encodePostPass records its bind groups with the frame's; release only ours.
+	start := len(b.postFrameBindGroups)
+	defer func() {
+		for _, bg := range b.postFrameBindGroups[start:] {
+			bg.Release()
+		}
+		b.postFrameBindGroups = b.postFrameBindGroups[:start]
+	}()
+
+	for level := 1; level < len(views); level++ {
+		src := views[level-1]
+		if err := b.encodePostPass(encoder, pp, b.postCopyShader, views[level], postBindings{input: src, source: src}); err != nil {
+			return err
+		}
+	}
+
+	commandBuffer, err := encoder.Finish(nil)
+	if err != nil {
+		return err
+	}
+	b.queue.Submit(commandBuffer)
+	commandBuffer.Release()
+	return nil
+}
+
+// generateMipmapsCPU fills mip levels 1..levels-1 of an RGBA8 texture by box-filtering the
+// staging pixels on the CPU. Used when the GPU blit path is unavailable. Caller must hold b.mu.
+//
+// Parameters:
+//   - tex: the texture, with level 0 already uploaded
+//   - format: the texture format, RGBA8Unorm or RGBA8UnormSrgb
+//   - data: the base level pixels and dimensions
+//   - levels: the texture's mip level count
+func (b *wgpuRendererBackendImpl) generateMipmapsCPU(tex *wgpu.Texture, format wgpu.TextureFormat, data common.TextureStagingData, levels uint32) {
+	pixels, width, height := data.Pixels, data.Width, data.Height
+	for level := uint32(1); level < levels; level++ {
+		pixels, width, height = common.DownsampleRGBA(pixels, width, height, common.IsSRGBFormat(format))
+		b.writeTextureLevel(tex, format, level, 0, pixels, width, height)
+	}
+}
+
+// writeTextureLevel uploads pixel data to one mip level of one layer of a 2D texture, such as a
+// cube face. Block-compressed levels are copied as whole blocks, so levels smaller than a block
+// use the block's physical size. Caller must hold b.mu.
+//
+// Parameters:
+//   - tex: the destination texture
+//   - format: the texture format
+//   - level: the mip level to write
+//   - layer: the array layer to write (0 for plain 2D textures)
+//   - pixels: the pixel data or blocks, row-major
+//   - width: the level width in pixels
+//   - height: the level height in pixels
+func (b *wgpuRendererBackendImpl) writeTextureLevel(tex *wgpu.Texture, format wgpu.TextureFormat, level, layer uint32, pixels []byte, width, height uint32) {
+	blockWidth, blockHeight, blockBytes := common.TextureBlockSize(format)
+	blocksX := (width + blockWidth - 1) / blockWidth
+	blocksY := (height + blockHeight - 1) / blockHeight
+	b.queue.WriteTexture(
+		&wgpu.ImageCopyTexture{
+			Texture:  tex,
+			MipLevel: level,
+			Origin:   wgpu.Origin3D{Z: layer},
+			Aspect:   wgpu.TextureAspectAll,
+		},
+		pixels,
+		&wgpu.TextureDataLayout{
+			Offset:       0,
+			BytesPerRow:  blocksX * blockBytes,
+			RowsPerImage: blocksY,
+		},
+		&wgpu.Extent3D{
+			Width:              blocksX * blockWidth,
+			Height:             blocksY * blockHeight,
+			DepthOrArrayLayers: 1,
+		},
+	)
+}
+
+// textureCompressionFeatures returns the texture compression features the adapter offers, so the
+// device can be created with every compressed format family the hardware can sample.
+//
+// Parameters:
+//   - a: the adapter the device is requested from
+//
+// Returns:
+//   - []wgpu.FeatureName: the supported BC, ETC2 and ASTC features
+func textureCompressionFeatures(a *wgpu.Adapter) []wgpu.FeatureName {
+	var features []wgpu.FeatureName
+	for _, f := range []wgpu.FeatureName{
+		wgpu.FeatureNameTextureCompressionBC,
+		wgpu.FeatureNameTextureCompressionETC2,
+		wgpu.FeatureNameTextureCompressionASTC,
+	} {
+		if a.HasFeature(f) {
+			features = append(features, f)
+		}
+	}
+	return features
+}
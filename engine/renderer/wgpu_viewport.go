@@ -0,0 +1,176 @@
+package renderer
+
+import (
+	"fmt"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/post_process"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// viewportClearKey selects a viewport clear pipeline by which targets it writes.
+type viewportClearKey struct {
+	color bool
+	depth bool
+}
+
+func (b *wgpuRendererBackendImpl) SetViewport(x, y, width, height float32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.viewport = [4]float32{x, y, width, height}
+	b.viewportSet = true
+	if b.framePass != nil {
+		b.framePass.SetViewport(x, y, width, height, 0, 1)
+	}
+}
+
+func (b *wgpuRendererBackendImpl) SetScissorRect(x, y, width, height uint32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.scissor = [4]uint32{x, y, width, height}
+	b.scissorSet = true
+	if b.framePass != nil {
+		b.framePass.SetScissorRect(x, y, width, height)
+	}
+}
+
+func (b *wgpuRendererBackendImpl) ClearViewport(color *wgpu.Color, depth bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.framePass == nil {
+		return fmt.Errorf("no frame or render pass in progress")
+	}
+	if color == nil && !depth {
+		return nil
+	}
+
+	p, err := b.viewportClearPipeline(viewportClearKey{color: color != nil, depth: depth})
+	if err != nil {
+		return err
+	}
+	b.framePass.SetPipeline(p)
+	if color != nil {
+		b.framePass.SetBlendConstant(color)
+	}
+	b.framePass.Draw(3, 1, 0, 0)
+	return nil
+}
+
+// applyViewport re-applies the remembered viewport and scissor rectangle to the open pass, used
+// after the transparency path ends one pass of the frame and begins another. Caller must hold b.mu.
+func (b *wgpuRendererBackendImpl) applyViewport() {
+	if b.framePass == nil {
+		return
+	}
+	if b.viewportSet {
+		b.framePass.SetViewport(b.viewport[0], b.viewport[1], b.viewport[2], b.viewport[3], 0, 1)
+	}
+	if b.scissorSet {
+		b.framePass.SetScissorRect(b.scissor[0], b.scissor[1], b.scissor[2], b.scissor[3])
+	}
+}
+
+// resetViewport forgets the remembered viewport and scissor rectangle so the next pass covers
+// the whole target. Caller must hold b.mu.
+func (b *wgpuRendererBackendImpl) resetViewport() {
+	b.viewportSet = false
+	b.scissorSet = false
+}
+
+// viewportClearPipeline returns the pipeline that clears the given targets of the main pass,
+// creating it on first use. Caller must hold b.mu.
+//
+// Parameters:
+//   - key: which of the color and depth targets the pipeline writes
+//
+// Returns:
+//   - *wgpu.RenderPipeline: the clear pipeline
+//   - error: an error if the shader module, layout or pipeline could not be created
+func (b *wgpuRendererBackendImpl) viewportClearPipeline(key viewportClearKey) (*wgpu.RenderPipeline, error) {
+	if p, ok := b.viewportClearPipelines[key]; ok {
+		return p, nil
+	}
+
+	if b.viewportClearModule == nil {
+		module, err := b.device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{
+			Label: "viewport_clear",
+			WGSLDescriptor: &wgpu.ShaderModuleWGSLDescriptor{
+				Code: post_process.ViewportClearShaderSource,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		b.viewportClearModule = module
+	}
+
+	layout, err := b.device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label: "viewport_clear",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The fragment output is 1, so blending it with the blend constant as the source factor and
+	// zero as the destination factor writes the constant.
+	constant := wgpu.BlendComponent{
+		SrcFactor: wgpu.BlendFactorConstant,
+		DstFactor: wgpu.BlendFactorZero,
+		Operation: wgpu.BlendOperationAdd,
+	}
+	writeMask := wgpu.ColorWriteMaskNone
+	if key.color {
+		writeMask = wgpu.ColorWriteMaskAll
+	}
+
+	created, err := b.device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  "viewport_clear Render Pipeline",
+		Layout: layout,
+		Vertex: wgpu.VertexState{
+			Module:     b.viewportClearModule,
+			EntryPoint: "vs_main",
+		},
+		Fragment: &wgpu.FragmentState{
+			Module:     b.viewportClearModule,
+			EntryPoint: "fs_main",
+			Targets: []wgpu.ColorTargetState{
+				{
+					Format:    b.sceneFormat(),
+					Blend:     &wgpu.BlendState{Color: constant, Alpha: constant},
+					WriteMask: writeMask,
+				},
+			},
+		},
+		Primitive: wgpu.PrimitiveState{
+			Topology:  wgpu.PrimitiveTopologyTriangleList,
+			FrontFace: wgpu.FrontFaceCCW,
+			CullMode:  wgpu.CullModeNone,
+		},
+		Multisample: wgpu.MultisampleState{
+			Count: uint32(b.sampleCount),
+			Mask:  0xFFFFFFFF,
+		},
+		DepthStencil: &wgpu.DepthStencilState{
+			Format:            wgpu.TextureFormatDepth24Plus,
+			DepthWriteEnabled: key.depth,
+			DepthCompare:      wgpu.CompareFunctionAlways,
+			StencilFront: wgpu.StencilFaceState{
+				Compare: wgpu.CompareFunctionAlways,
+			},
+			StencilBack: wgpu.StencilFaceState{
+				Compare: wgpu.CompareFunctionAlways,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if b.viewportClearPipelines == nil {
+		b.viewportClearPipelines = make(map[viewportClearKey]*wgpu.RenderPipeline)
+	}
+	b.viewportClearPipelines[key] = created
+	return created, nil
+}
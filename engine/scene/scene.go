@@ -2,7 +2,9 @@ package scene
 
 import (
 	"fmt"
+	"io"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -11,14 +13,22 @@ import (
 	"github.com/Carmen-Shannon/automation/tools/worker"
 	"github.com/Carmen-Shannon/oxy-go/common"
 	"github.com/Carmen-Shannon/oxy-go/engine/camera"
+	"github.com/Carmen-Shannon/oxy-go/engine/debug_draw"
+	"github.com/Carmen-Shannon/oxy-go/engine/environment"
 	"github.com/Carmen-Shannon/oxy-go/engine/game_object"
 	"github.com/Carmen-Shannon/oxy-go/engine/light"
+	"github.com/Carmen-Shannon/oxy-go/engine/loader"
 	"github.com/Carmen-Shannon/oxy-go/engine/model"
+	"github.com/Carmen-Shannon/oxy-go/engine/render_target"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/animator"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/material"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/pipeline"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+	"github.com/Carmen-Shannon/oxy-go/engine/sprite"
+	"github.com/Carmen-Shannon/oxy-go/engine/text"
+	"github.com/Carmen-Shannon/oxy-go/engine/ui"
 	"github.com/cogentcore/webgpu/wgpu"
 )
 
@@ -59,6 +69,179 @@ type Scene interface {
 	//   - r: the new renderer
 	SetRenderer(r renderer.Renderer)
 
+	// Viewport returns the normalized rectangle of the render target the scene draws into.
+	//
+	// Returns:
+	//   - Viewport: the scene's viewport
+	Viewport() Viewport
+
+	// SetViewport sets the normalized rectangle of the render target the scene draws into and
+	// sets the camera's aspect ratio to match it on the renderer's current target. Scenes sharing
+	// a renderer can each take a part of the target for split-screen or picture-in-picture.
+	//
+	// Parameters:
+	//   - v: the new viewport
+	SetViewport(v Viewport)
+
+	// Scissor returns the normalized rectangle the scene's draws are clipped to, or nil if they
+	// are clipped to the viewport.
+	//
+	// Returns:
+	//   - *Viewport: a copy of the scissor rectangle or nil
+	Scissor() *Viewport
+
+	// SetScissor sets the normalized rectangle of the render target the scene's draws are clipped
+	// to, independent of the viewport they are mapped into. Pass nil to clip to the viewport.
+	//
+	// Parameters:
+	//   - rect: the scissor rectangle or nil
+	SetScissor(rect *Viewport)
+
+	// ClearDepth returns whether DrawCalls resets the depth buffer inside the scene's viewport
+	// before drawing.
+	//
+	// Returns:
+	//   - bool: true if depth is cleared
+	ClearDepth() bool
+
+	// SetClearDepth sets whether DrawCalls resets the depth buffer inside the scene's viewport
+	// before drawing, so an overlay scene drawn after another on the same renderer is not
+	// depth-tested against it.
+	//
+	// Parameters:
+	//   - clear: true to clear depth
+	SetClearDepth(clear bool)
+
+	// ClearColor returns the color DrawCalls fills the scene's viewport with before drawing, or
+	// nil if the color target is kept.
+	//
+	// Returns:
+	//   - *wgpu.Color: a copy of the clear color or nil
+	ClearColor() *wgpu.Color
+
+	// SetClearColor sets the color DrawCalls fills the scene's viewport with before drawing.
+	// Pass nil to draw over what earlier scenes left in the viewport.
+	//
+	// Parameters:
+	//   - color: the clear color or nil
+	SetClearColor(color *wgpu.Color)
+
+	// Resize sets the camera's aspect ratio to that of the scene's viewport on a target of the
+	// given size. The engine calls this when the window is resized.
+	//
+	// Parameters:
+	//   - width: the new target width in pixels
+	//   - height: the new target height in pixels
+	Resize(width, height int)
+
+	// RenderTarget returns the offscreen target the scene renders into instead of the frame, or
+	// nil if it renders into the frame. See WithRenderTarget.
+	//
+	// Returns:
+	//   - render_target.RenderTarget: the scene's render target or nil
+	RenderTarget() render_target.RenderTarget
+
+	// BindRenderTarget binds a render target's color texture to every render material of a model
+	// in this scene. A material whose fragment shader declares a render_target provider group
+	// samples it there; any other material gets it in place of its diffuse texture. The model
+	// must already have been added, so its pipelines are registered.
+	//
+	// Parameters:
+	//   - mdl: the model to bind the render target to
+	//   - rt: the render target, typically that of another scene
+	//
+	// Returns:
+	//   - error: error if a material's pipeline is missing, its shader has neither binding, or GPU resource creation fails
+	BindRenderTarget(mdl model.Model, rt render_target.RenderTarget) error
+
+	// SpriteBatch returns the sprite batch the scene draws after its geometry, or nil.
+	//
+	// Returns:
+	//   - sprite.SpriteBatch: the scene's sprite batch or nil
+	SpriteBatch() sprite.SpriteBatch
+
+	// SetSpriteBatch sets the sprite batch the scene draws after its opaque and transparent
+	// geometry and before its debug draw, with the scene's camera. A sprite batch rewrites its
+	// buffers every draw, so it must not be shared between scenes. Pass nil to detach it.
+	//
+	// Parameters:
+	//   - b: the sprite batch or nil
+	SetSpriteBatch(b sprite.SpriteBatch)
+
+	// AddSpriteAnimation registers a sprite-sheet animation that the engine advances every tick
+	// with the tick step, so it follows the engine's time scale and stops while the engine is
+	// paused. Register each animation with one scene only, or it is advanced once per scene.
+	// Adding an animation that is already registered has no effect.
+	//
+	// Parameters:
+	//   - a: the animation to advance
+	AddSpriteAnimation(a sprite.Animation)
+
+	// RemoveSpriteAnimation unregisters a sprite-sheet animation by reference. The animation
+	// keeps its current frame.
+	//
+	// Parameters:
+	//   - a: the animation to remove
+	RemoveSpriteAnimation(a sprite.Animation)
+
+	// SpriteAnimations returns the sprite-sheet animations registered with the scene.
+	//
+	// Returns:
+	//   - []sprite.Animation: a copy of the scene's animation list
+	SpriteAnimations() []sprite.Animation
+
+	// TickSpriteAnimations advances every registered sprite-sheet animation by a time step.
+	// Called by the engine once per tick after the tick callback; call it directly when the
+	// scene is not driven by an engine.
+	//
+	// Parameters:
+	//   - dt: the time step in seconds
+	TickSpriteAnimations(dt float32)
+
+	// DebugDraw returns the debug draw the scene draws at the end of DrawCalls, or nil.
+	//
+	// Returns:
+	//   - debug_draw.DebugDraw: the scene's debug draw or nil
+	DebugDraw() debug_draw.DebugDraw
+
+	// SetDebugDraw sets the debug draw the scene draws at the end of DrawCalls, over its opaque and
+	// transparent geometry, with the scene's camera and viewport. A debug draw can be shared
+	// between scenes; each draws its lines with its own camera. Pass nil to detach it.
+	//
+	// Parameters:
+	//   - dd: the debug draw or nil
+	SetDebugDraw(dd debug_draw.DebugDraw)
+
+	// TextRenderer returns the text renderer the scene draws at the end of DrawCalls, or nil.
+	//
+	// Returns:
+	//   - text.TextRenderer: the scene's text renderer or nil
+	TextRenderer() text.TextRenderer
+
+	// SetTextRenderer sets the text renderer the scene draws at the end of DrawCalls, after its debug
+	// draw, with the scene's camera and viewport. Screen-space text is positioned in pixels from the
+	// top-left of the viewport. A text renderer rewrites its buffers every draw, so it must not be
+	// shared between scenes. Pass nil to detach it.
+	//
+	// Parameters:
+	//   - tr: the text renderer or nil
+	SetTextRenderer(tr text.TextRenderer)
+
+	// UI returns the UI the scene draws at the end of DrawCalls, or nil.
+	//
+	// Returns:
+	//   - ui.UI: the scene's UI or nil
+	UI() ui.UI
+
+	// SetUI sets the UI the scene draws at the end of DrawCalls, over everything else including its
+	// text renderer, sized to the scene's viewport. A UI is usually given its own overlay scene
+	// layered after the world scenes. A UI rewrites its buffers every draw, so it must not be shared
+	// between scenes. Pass nil to detach it.
+	//
+	// Parameters:
+	//   - u: the UI or nil
+	SetUI(u ui.UI)
+
 	// Count returns the number of persisted GameObjects in the scene's registry. Does not include ephemeral objects.
 	//
 	// Returns:
@@ -85,12 +268,20 @@ type Scene interface {
 	//   - computeShader: the compute shader to use for this object's Animator
 	//   - vertexShader: the vertex shader to use for this object's render pipeline
 	//   - fragmentShader: the fragment shader to use for this object's render pipeline
-	//   - pipelineOpts: optional pipeline builder options for the render pipeline (e.g., blending)
+	//   - pipelineOpts: optional pipeline builder options for the render pipeline (e.g., cull mode); blending follows each material's alpha mode
 	//
 	// Returns:
 	//   - uint64: the assigned object ID
 	Add(obj game_object.GameObject, computeShader, vertexShader, fragmentShader shader.Shader, pipelineOpts ...pipeline.PipelineBuilderOption) uint64
 
+	// ReserveID allocates an object ID that Add will never assign automatically.
+	// Use it to create a GameObject with a known ID (via game_object.WithID) before the
+	// object is added, e.g. when an external system owns the ID space.
+	//
+	// Returns:
+	//   - uint64: the reserved object ID
+	ReserveID() uint64
+
 	// Get retrieves a non-ephemeral GameObject by its ID.
 	// Returns nil if not found.
 	//
@@ -102,16 +293,82 @@ type Scene interface {
 	Get(id uint64) game_object.GameObject
 
 	// Remove removes a non-ephemeral GameObject from the registry by ID
-	// and swap-removes the instance data from its animator.
+	// and swap-removes the instance data from its animator. The object is detached
+	// from its parent and its children become roots that keep their world transforms.
 	//
 	// Parameters:
 	//   - id: the object's unique ID
 	Remove(id uint64)
 
+	// Raycast returns the closest enabled, non-ephemeral object hit by the ray. Each object is
+	// first tested against its Model's bounding sphere in world space, then against the exact
+	// triangles of the Model's vertex and index data. Rotation integrated on the GPU from
+	// RotationSpeed is not reflected; objects are tested at their simulation transform.
+	//
+	// Parameters:
+	//   - ray: the world-space ray, e.g. from camera.Camera.ScreenPointToRay
+	//   - maxDist: the maximum hit distance; values <= 0 are unlimited
+	//   - opts: optional raycast options (skinned mode, object filter)
+	//
+	// Returns:
+	//   - RaycastHit: the closest hit
+	//   - bool: true if anything was hit
+	Raycast(ray common.Ray, maxDist float32, opts ...RaycastOption) (RaycastHit, bool)
+
+	// RaycastAll returns every object hit by the ray, sorted by distance. Each object
+	// contributes at most its closest hit.
+	//
+	// Parameters:
+	//   - ray: the world-space ray
+	//   - maxDist: the maximum hit distance; values <= 0 are unlimited
+	//   - opts: optional raycast options (skinned mode, object filter)
+	//
+	// Returns:
+	//   - []RaycastHit: the hits, closest first
+	RaycastAll(ray common.Ray, maxDist float32, opts ...RaycastOption) []RaycastHit
+
 	// Clear removes all objects and animators from the scene.
 	// Does not release GPU resources.
 	Clear()
 
+	// Save writes the scene's persistent state to w as indented, versioned JSON: every
+	// non-ephemeral GameObject with its transform, the Models they use (referenced by loader
+	// path) and the shaders those Models were added with (referenced by key), non-ephemeral
+	// lights including object-attached ones, the ambient color, shadow settings, and the
+	// camera with its controller state. Pipeline options passed to Add are not saved.
+	//
+	// Parameters:
+	//   - w: the writer to encode the scene to
+	//
+	// Returns:
+	//   - error: error if encoding fails or a saved object's Model was not produced by a Loader
+	Save(w io.Writer) error
+
+	// SaveBinary writes the same state as Save in a compact binary encoding.
+	//
+	// Parameters:
+	//   - w: the writer to encode the scene to
+	//
+	// Returns:
+	//   - error: error if encoding fails or a saved object's Model was not produced by a Loader
+	SaveBinary(w io.Writer) error
+
+	// Load replaces the scene's objects, lights, ambient color, and shadow settings with
+	// those read from r, and restores the camera and its controller in place. Both the JSON
+	// and binary formats are accepted. Models are resolved from the loader's cache by path,
+	// loading them if needed, and shaders are looked up by key. Nothing in the scene is
+	// changed if the file cannot be decoded or a model or shader cannot be resolved.
+	// Object IDs are preserved.
+	//
+	// Parameters:
+	//   - r: the reader to decode the scene from
+	//   - l: the loader used to resolve model paths
+	//   - shaders: the shaders referenced by the file, keyed by shader.Shader.Key()
+	//
+	// Returns:
+	//   - error: error if decoding fails, the file version is unsupported, or a reference cannot be resolved
+	Load(r io.Reader, l loader.Loader, shaders map[string]shader.Shader) error
+
 	// PrepareCompute updates camera matrices, advances animation state,
 	// uploads staged buffer writes, and dispatches all compute shaders for this scene.
 	// Must be called within a BeginComputeFrame/EndComputeFrame block on the renderer.
@@ -120,6 +377,29 @@ type Scene interface {
 	//   - deltaTime: elapsed time since the last frame in seconds
 	PrepareCompute(deltaTime float32)
 
+	// SyncTransforms writes the world transform of every registered GameObject whose
+	// transform, or an ancestor's, changed since the last sync into its animator instance.
+	// Children of registered objects are synced with them even if they are ephemeral.
+	// The engine calls this after each fixed tick; call it directly when driving the
+	// scene without the engine.
+	SyncTransforms()
+
+	// BeginSimulationStep restores every registered GameObject to its latest simulation
+	// state, undoing any interpolated transform written for rendering. The engine calls
+	// this immediately before each fixed tick.
+	BeginSimulationStep()
+
+	// EndSimulationStep snapshots the transform of every registered GameObject as the
+	// newest simulation state. The engine calls this immediately after each fixed tick.
+	EndSimulationStep()
+
+	// InterpolateTransforms blends every registered GameObject between its last two
+	// simulation states and stages the result for rendering.
+	//
+	// Parameters:
+	//   - alpha: blend factor in [0, 1] between the previous and latest simulation state
+	InterpolateTransforms(alpha float32)
+
 	// CullingDisabled returns whether GPU frustum culling is explicitly disabled for this scene.
 	// When true, the scene will not distribute frustum planes to animators, keeping them in
 	// non-culled mode even when a camera is present.
@@ -137,6 +417,11 @@ type Scene interface {
 	SetCullingDisabled(disabled bool)
 
 	// DrawCalls issues instanced draw calls for each registered animator.
+	// The scene's viewport and scissor rectangle are applied first, and the viewport is
+	// cleared when the scene has a clear color or clears depth.
+	// Opaque and masked materials are drawn first. Blended materials follow in a transparent
+	// pass: through weighted blended order-independent transparency when the renderer and
+	// shader support it, otherwise sorted back-to-front per instance.
 	// Must be called within a BeginFrame/EndFrame block on the renderer.
 	//
 	// Returns:
@@ -201,49 +486,58 @@ type Scene interface {
 	InitLightBindGroup(fragmentShader shader.Shader)
 
 	// InitShadowMap initializes the shadow mapping resources for the scene. Creates
-	// the shadow depth texture, comparison sampler, shadow data uniform BGP, and
-	// registers shadow pipelines for both static and skinned models. The shadow
-	// depth vertex shaders are used to build pipelines that render depth-only passes
-	// from the directional light's perspective.
+	// the shadow depth texture array (one layer per cascade), the point/spot light
+	// shadow atlas, comparison sampler, one shadow uniform BGP per cascade and per
+	// atlas tile, and registers shadow pipelines for both static and skinned models. The shadow depth vertex shaders are used to build pipelines
+	// that render depth-only passes from the directional light's perspective.
 	//
 	// Parameters:
 	//   - shadowVertexShader: the shadow depth vertex shader for static models
 	//   - shadowSkinnedVertexShader: the shadow depth vertex shader for skinned models (may be nil if no skinned models)
 	InitShadowMap(shadowVertexShader, shadowSkinnedVertexShader shader.Shader)
 
-	// PrepareShadows computes the directional light's view-projection, updates the
-	// shadow uniform buffer, and renders the depth-only shadow pass for all drawables.
+	// PrepareShadows splits the camera frustum into cascades, computes a texel-snapped
+	// view-projection for each from the directional light, updates the shadow uniform
+	// buffers, and renders one depth-only shadow pass per cascade for all drawables.
+	// Shadow-casting point and spot lights are then rendered into the shadow atlas,
+	// one perspective tile per spot light and six cube-face tiles per point light, up
+	// to the per-frame atlas budget.
 	// Must be called after PrepareCompute and before BeginFrame each frame.
-	// No-ops if no shadow map has been initialized or no shadow-casting directional
-	// light exists.
+	// No-ops if no shadow map has been initialized or no shadow-casting light exists.
 	PrepareShadows()
 
-	// ShadowDepthTextureView returns the shadow map depth texture view, or nil if
-	// shadow mapping has not been initialized.
+	// ShadowDepthTextureView returns the 2D array view over all shadow cascades, or nil
+	// if shadow mapping has not been initialized.
 	//
 	// Returns:
-	//   - *wgpu.TextureView: the shadow depth texture view or nil
+	//   - *wgpu.TextureView: the shadow depth texture array view or nil
 	ShadowDepthTextureView() *wgpu.TextureView
 
-	// ShadowDataBindGroupProvider returns the BGP holding the shadow uniform data
-	// (light VP matrix, texel size, bias), or nil if not initialized.
+	// ShadowDataBindGroupProvider returns the BGP holding a cascade's shadow uniform
+	// (its light VP matrix) used during the depth pass, or nil if not initialized or
+	// the cascade is out of range.
+	//
+	// Parameters:
+	//   - cascade: the cascade index
 	//
 	// Returns:
 	//   - bind_group_provider.BindGroupProvider: the shadow data BGP or nil
-	ShadowDataBindGroupProvider() bind_group_provider.BindGroupProvider
+	ShadowDataBindGroupProvider(cascade int) bind_group_provider.BindGroupProvider
 
 	// ShadowLitBindGroupProvider returns the BGP used by lit fragment shaders
-	// to sample the shadow map. It holds the shadow depth texture, comparison
-	// sampler, and shadow uniform buffer. Returns nil if not initialized.
+	// to sample the shadow maps. It holds the cascade texture array, comparison
+	// sampler, shadow uniform buffer, and, when the shader declares them, the
+	// shadow atlas and local shadow storage buffer. Returns nil if not initialized.
 	//
 	// Returns:
 	//   - bind_group_provider.BindGroupProvider: the shadow lit BGP or nil
 	ShadowLitBindGroupProvider() bind_group_provider.BindGroupProvider
 
 	// InitShadowLitBindGroup initializes the bind group provider that lit fragment
-	// shaders use to sample the shadow map. It pre-sets the shadow depth texture view
-	// and comparison sampler from InitShadowMap, then creates a uniform buffer for the
-	// shadow data. Must be called after InitShadowMap.
+	// shaders use to sample the shadow maps. It pre-sets the cascade texture array
+	// (texture_depth_2d_array bindings), shadow atlas (texture_depth_2d bindings), and
+	// comparison sampler from InitShadowMap, then creates a uniform buffer for the
+	// shadow data and a storage buffer for the local shadows. Must be called after InitShadowMap.
 	//
 	// Parameters:
 	//   - litFragmentShader: the lit fragment shader providing the shadow bind group layout
@@ -281,6 +575,24 @@ type Scene interface {
 	//   - screenWidth: screen width in pixels
 	//   - screenHeight: screen height in pixels
 	InitLighting(litFragShader, shadowVertShader, shadowSkinnedVertShader, cullComputeShader shader.Shader, screenWidth, screenHeight int)
+
+	// Environment returns the scene's environment, or nil if none has been initialized.
+	//
+	// Returns:
+	//   - environment.Environment: the environment or nil
+	Environment() environment.Environment
+
+	// InitEnvironment attaches an environment to the scene. It uploads the sky cubemap and
+	// registers the skybox pipeline, which DrawCalls runs before any geometry while the skybox
+	// is enabled. When a lit fragment shader is given, its environment provider group is bound to
+	// the prefiltered specular cubemap, the BRDF lookup table, a clamp-to-edge sampler and the
+	// EnvironmentParams uniform, which PrepareCompute rewrites each frame. Calling it again
+	// replaces the previous environment.
+	//
+	// Parameters:
+	//   - env: the environment to attach
+	//   - litFragmentShader: the lit fragment shader declaring the environment provider group (may be nil for a skybox only)
+	InitEnvironment(env environment.Environment, litFragmentShader shader.Shader)
 }
 
 type scene struct {
@@ -290,14 +602,31 @@ type scene struct {
 	active bool
 
 	animatorPool map[model.Model][]animator.Animator
+	modelShaders map[model.Model]modelShaderKeys   // shader keys each model was first added with, for Save
 	registry     map[uint64]game_object.GameObject // non-ephemeral objects by ID
-	nextID       uint64
+	nextID       uint64                            // next ID handed out; only accessed through sync/atomic
 
 	cam camera.Camera
 	r   renderer.Renderer
 
 	cullingDisabled bool // when true, skips frustum plane distribution to animators
 
+	// Viewport state applied by DrawCalls. See scene_viewport.go.
+	viewport   Viewport
+	scissor    *Viewport   // nil clips to the viewport
+	clearDepth bool        // reset depth inside the viewport before drawing
+	clearColor *wgpu.Color // fill the viewport before drawing, nil keeps the color target
+
+	// Offscreen rendering state. See scene_render_target.go.
+	renderTarget     render_target.RenderTarget                                  // nil renders into the frame
+	renderTargetBGPs map[material.Material]bind_group_provider.BindGroupProvider // render_target provider groups bound by BindRenderTarget
+
+	spriteBatch  sprite.SpriteBatch   // drawn after the transparent pass, nil for none
+	spriteAnims  []sprite.Animation   // advanced by TickSpriteAnimations every engine tick
+	debugDraw    debug_draw.DebugDraw // drawn at the end of DrawCalls, nil for none
+	textRenderer text.TextRenderer    // drawn after the debug draw, nil for none
+	ui           ui.UI                // drawn after the text renderer, nil for none
+
 	// Lighting state.
 	lights       []light.Light
 	lightObjects []game_object.GameObject // objects with attached lights (ephemeral and non-ephemeral)
@@ -306,19 +635,33 @@ type scene struct {
 
 	// Shadow mapping state.
 	shadowDepthTexture     *wgpu.Texture
-	shadowDepthTextureView *wgpu.TextureView
+	shadowDepthTextureView *wgpu.TextureView   // 2D array view over all cascades, sampled in the lit pass
+	shadowLayerViews       []*wgpu.TextureView // one depth attachment per cascade
 	shadowComparisonSamp   *wgpu.Sampler
-	shadowDataBGP          bind_group_provider.BindGroupProvider // used during the shadow depth pass
-	shadowLitBGP           bind_group_provider.BindGroupProvider // used during the lit pass (texture + sampler + uniform)
-	shadowPipelineKey      string                                // pipeline key for static models
-	shadowSkinnedPipeKey   string                                // pipeline key for skinned models
-	shadowHalfExtent       float32
+	shadowDataBGPs         []bind_group_provider.BindGroupProvider // one per cascade, used during the shadow depth pass
+	shadowLitBGP           bind_group_provider.BindGroupProvider   // used during the lit pass (texture array + sampler + uniform)
+	shadowPipelineKey      string                                  // pipeline key for static models
+	shadowSkinnedPipeKey   string                                  // pipeline key for skinned models
+	shadowDistance         float32
+	shadowCascades         int
+	shadowSplitLambda      float32
+	shadowCascadeBlend     float32
 	shadowNear             float32
 	shadowFar              float32
 	shadowBias             float32
 	shadowNormalBiasScale  float32
 	shadowMapResolution    int
 
+	// Shadow atlas state for point and spot lights.
+	shadowAtlasTexture    *wgpu.Texture
+	shadowAtlasView       *wgpu.TextureView
+	shadowAtlasBGPs       []bind_group_provider.BindGroupProvider // one per atlas tile, used during the shadow depth pass
+	shadowAtlasResolution int
+	shadowAtlasTileSize   int
+	shadowAtlasBudget     int
+	shadowLitDataBinding  int // binding of the ShadowData uniform in shadowLitBGP
+	shadowLitAtlasBinding int // binding of the LocalShadow storage buffer in shadowLitBGP, -1 if absent
+
 	// Forward+ light culling state.
 	lightCullBGP         bind_group_provider.BindGroupProvider // compute shader BGP
 	tileLitBGP           bind_group_provider.BindGroupProvider // fragment shader BGP (@group(5))
@@ -328,10 +671,21 @@ type scene struct {
 	screenWidth          int
 	screenHeight         int
 
+	// Environment state.
+	env               environment.Environment
+	envLitBGP         bind_group_provider.BindGroupProvider // lit pass (specular cubemap + BRDF LUT + sampler + params)
+	skyboxBGP         bind_group_provider.BindGroupProvider // skybox pass (sky cubemap + sampler + params)
+	skyboxPipelineKey string
+
 	// Pre-allocated slices reused each frame to avoid per-frame allocations.
 	writePool          []bind_group_provider.BufferWrite       // reusable coalesced buffer write slice
 	drawBindGroupsPool []bind_group_provider.BindGroupProvider // reusable bind group slice for DrawCalls
 
+	// Blended materials deferred by DrawCalls to the transparent pass, reused each frame.
+	transparentBatches    []transparentBatch
+	transparentBindGroups []bind_group_provider.BindGroupProvider
+	transparentDraws      []transparentDraw
+
 	// computePool manages a bounded set of reusable goroutines for the parallel
 	// CPU prep phase of PrepareCompute. Workers persist across frames, avoiding
 	// per-frame goroutine spawn/teardown overhead.
@@ -375,22 +729,35 @@ func NewScene(name string, cam camera.Camera, r renderer.Renderer, vertexShader
 		cam:                   cam,
 		r:                     r,
 		animatorPool:          make(map[model.Model][]animator.Animator),
+		modelShaders:          make(map[model.Model]modelShaderKeys),
 		registry:              make(map[uint64]game_object.GameObject),
 		nextID:                1,
+		viewport:              FullViewport,
 		computeWorkers:        max(runtime.NumCPU()-1, 1),
 		drawBindGroupsPool:    make([]bind_group_provider.BindGroupProvider, 0, 3),
-		shadowHalfExtent:      light.DefaultShadowHalfExtent,
+		shadowDistance:        light.DefaultShadowDistance,
+		shadowCascades:        light.DefaultShadowCascades,
+		shadowSplitLambda:     light.DefaultShadowSplitLambda,
+		shadowCascadeBlend:    light.DefaultShadowCascadeBlend,
 		shadowNear:            light.DefaultShadowNear,
 		shadowFar:             light.DefaultShadowFar,
 		shadowBias:            light.DefaultShadowBias,
 		shadowNormalBiasScale: light.DefaultShadowNormalBiasScale,
 		shadowMapResolution:   light.ShadowMapResolution,
+		shadowAtlasResolution: light.ShadowAtlasResolution,
+		shadowAtlasTileSize:   light.ShadowAtlasTileResolution,
+		shadowAtlasBudget:     light.DefaultShadowAtlasBudget,
 	}
 
 	for _, option := range options {
 		option(s)
 	}
 
+	// A camera drawing into part of the target, or into a render target, takes its aspect ratio.
+	if s.viewport != FullViewport || s.renderTarget != nil {
+		s.syncAspect()
+	}
+
 	// Initialize the compute pool after options so WithComputeWorkers can override the default.
 	// Queue size of 256 accommodates typical animator group counts with headroom.
 	s.computePool = worker.NewDynamicWorkerPool(s.computeWorkers, 256, 1*time.Second)
@@ -474,6 +841,84 @@ func (s *scene) SetCullingDisabled(disabled bool) {
 	s.cullingDisabled = disabled
 }
 
+func (s *scene) SpriteBatch() sprite.SpriteBatch {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.spriteBatch
+}
+
+func (s *scene) SetSpriteBatch(b sprite.SpriteBatch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spriteBatch = b
+}
+
+func (s *scene) AddSpriteAnimation(a sprite.Animation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a == nil || slices.Contains(s.spriteAnims, a) {
+		return
+	}
+	s.spriteAnims = append(s.spriteAnims, a)
+}
+
+func (s *scene) RemoveSpriteAnimation(a sprite.Animation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := slices.Index(s.spriteAnims, a); i >= 0 {
+		s.spriteAnims = slices.Delete(s.spriteAnims, i, i+1)
+	}
+}
+
+func (s *scene) SpriteAnimations() []sprite.Animation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.spriteAnims)
+}
+
+func (s *scene) TickSpriteAnimations(dt float32) {
+	// Tick outside the scene lock; each animation has its own.
+	for _, a := range s.SpriteAnimations() {
+		a.Tick(dt)
+	}
+}
+
+func (s *scene) DebugDraw() debug_draw.DebugDraw {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.debugDraw
+}
+
+func (s *scene) SetDebugDraw(dd debug_draw.DebugDraw) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.debugDraw = dd
+}
+
+func (s *scene) TextRenderer() text.TextRenderer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.textRenderer
+}
+
+func (s *scene) SetTextRenderer(tr text.TextRenderer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.textRenderer = tr
+}
+
+func (s *scene) UI() ui.UI {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ui
+}
+
+func (s *scene) SetUI(u ui.UI) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ui = u
+}
+
 func (s *scene) AddLight(l light.Light) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -593,14 +1038,15 @@ func (s *scene) InitShadowMap(shadowVertexShader, shadowSkinnedVertexShader shad
 		return
 	}
 
-	// Create shadow depth texture.
+	// Create the shadow depth texture array, one layer per cascade.
 	res := s.shadowMapResolution
-	view, tex, err := s.r.CreateShadowDepthTexture(res, res)
+	view, layerViews, tex, err := s.r.CreateShadowDepthTexture(res, res, s.shadowCascades)
 	if err != nil {
 		panic(fmt.Sprintf("scene: failed to create shadow depth texture: %v", err))
 	}
 	s.shadowDepthTexture = tex
 	s.shadowDepthTextureView = view
+	s.shadowLayerViews = layerViews
 
 	// Create comparison sampler for PCF in the lit fragment shader.
 	samp, err := s.r.CreateComparisonSampler()
@@ -609,9 +1055,10 @@ func (s *scene) InitShadowMap(shadowVertexShader, shadowSkinnedVertexShader shad
 	}
 	s.shadowComparisonSamp = samp
 
-	// Create shadow data BGP — holds the light VP matrix + texel size + bias.
-	// The layout is derived from the shadow vertex shader's group(0) which has the
-	// shadow_uniform binding.
+	// Create one shadow data BGP per cascade — each holds that cascade's light VP
+	// matrix. Separate buffers are needed because every cascade's depth pass is
+	// recorded into the same submit. The layout is derived from the shadow vertex
+	// shader's group(0) which has the shadow_uniform binding.
 	shadowGroup := 0
 	for i, names := range shadowVertexShader.BindGroupVarNames() {
 		for _, name := range names {
@@ -621,19 +1068,41 @@ func (s *scene) InitShadowMap(shadowVertexShader, shadowSkinnedVertexShader shad
 			}
 		}
 	}
-	bgp := bind_group_provider.NewBindGroupProvider(s.name + "_shadow_data")
 	desc := shadowVertexShader.BindGroupLayoutDescriptor(shadowGroup)
-	// Override buffer size to 80 bytes (GPUShadowData: mat4x4 + vec2 + f32 + f32).
+	// Override buffer size to 64 bytes (GPUShadowUniform: mat4x4).
 	sizeOverrides := make(map[int]uint64)
 	for _, entry := range desc.Entries {
 		if entry.Buffer.Type == wgpu.BufferBindingTypeUniform {
-			sizeOverrides[int(entry.Binding)] = 80
+			sizeOverrides[int(entry.Binding)] = 64
 		}
 	}
-	if err := s.r.InitBindGroup(bgp, desc, nil, sizeOverrides); err != nil {
-		panic(fmt.Sprintf("scene: failed to init shadow data bind group: %v", err))
+	s.shadowDataBGPs = make([]bind_group_provider.BindGroupProvider, len(layerViews))
+	for i := range s.shadowDataBGPs {
+		bgp := bind_group_provider.NewBindGroupProvider(fmt.Sprintf("%s_shadow_data_%d", s.name, i))
+		if err := s.r.InitBindGroup(bgp, desc, nil, sizeOverrides); err != nil {
+			panic(fmt.Sprintf("scene: failed to init shadow data bind group: %v", err))
+		}
+		s.shadowDataBGPs[i] = bgp
+	}
+
+	// Create the shadow atlas for point and spot lights: a single depth texture split
+	// into square tiles, each rendered through its own shadow data BGP.
+	arrayView, atlasViews, atlasTex, err := s.r.CreateShadowDepthTexture(s.shadowAtlasResolution, s.shadowAtlasResolution, 1)
+	if err != nil {
+		panic(fmt.Sprintf("scene: failed to create shadow atlas texture: %v", err))
+	}
+	arrayView.Release() // the atlas is sampled as a plain texture_depth_2d
+	s.shadowAtlasTexture = atlasTex
+	s.shadowAtlasView = atlasViews[0]
+	cols := max(s.shadowAtlasResolution/s.shadowAtlasTileSize, 1)
+	s.shadowAtlasBGPs = make([]bind_group_provider.BindGroupProvider, min(cols*cols, light.MaxShadowAtlasTiles))
+	for i := range s.shadowAtlasBGPs {
+		bgp := bind_group_provider.NewBindGroupProvider(fmt.Sprintf("%s_shadow_atlas_%d", s.name, i))
+		if err := s.r.InitBindGroup(bgp, desc, nil, sizeOverrides); err != nil {
+			panic(fmt.Sprintf("scene: failed to init shadow atlas bind group: %v", err))
+		}
+		s.shadowAtlasBGPs[i] = bgp
 	}
-	s.shadowDataBGP = bgp
 
 	// Register shadow pipeline for static models.
 	staticKey := "shadow_depth_static"
@@ -668,10 +1137,13 @@ func (s *scene) ShadowDepthTextureView() *wgpu.TextureView {
 	return s.shadowDepthTextureView
 }
 
-func (s *scene) ShadowDataBindGroupProvider() bind_group_provider.BindGroupProvider {
+func (s *scene) ShadowDataBindGroupProvider(cascade int) bind_group_provider.BindGroupProvider {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.shadowDataBGP
+	if cascade < 0 || cascade >= len(s.shadowDataBGPs) {
+		return nil
+	}
+	return s.shadowDataBGPs[cascade]
 }
 
 func (s *scene) ShadowLitBindGroupProvider() bind_group_provider.BindGroupProvider {
@@ -710,24 +1182,37 @@ func (s *scene) InitShadowLitBindGroup(litFragmentShader shader.Shader) {
 
 	bgp := bind_group_provider.NewBindGroupProvider(s.name + "_shadow_lit")
 
-	// Pre-set the shadow depth texture view and comparison sampler on the BGP
+	// Pre-set the shadow depth texture views and comparison sampler on the BGP
 	// so that InitBindGroup can find them when creating the bind group entries.
+	// The cascade array and the atlas are told apart by their view dimension.
 	desc := litFragmentShader.BindGroupLayoutDescriptor(shadowGroup)
 	for _, entry := range desc.Entries {
 		binding := int(entry.Binding)
 		if entry.Texture.SampleType != wgpu.TextureSampleTypeUndefined {
-			bgp.SetTextureView(binding, s.shadowDepthTextureView)
+			if entry.Texture.ViewDimension == wgpu.TextureViewDimension2D && s.shadowAtlasView != nil {
+				bgp.SetTextureView(binding, s.shadowAtlasView)
+			} else {
+				bgp.SetTextureView(binding, s.shadowDepthTextureView)
+			}
 		}
 		if entry.Sampler.Type != wgpu.SamplerBindingTypeUndefined {
 			bgp.SetSampler(binding, s.shadowComparisonSamp)
 		}
 	}
 
-	// Override the uniform buffer size to 80 bytes (GPUShadowData).
+	// Override the uniform buffer size to 320 bytes (GPUShadowData) and size the
+	// storage buffer for a full atlas of GPULocalShadow entries.
+	s.shadowLitDataBinding = 0
+	s.shadowLitAtlasBinding = -1
 	sizeOverrides := make(map[int]uint64)
 	for _, entry := range desc.Entries {
-		if entry.Buffer.Type == wgpu.BufferBindingTypeUniform {
-			sizeOverrides[int(entry.Binding)] = 80
+		switch entry.Buffer.Type {
+		case wgpu.BufferBindingTypeUniform:
+			sizeOverrides[int(entry.Binding)] = 320
+			s.shadowLitDataBinding = int(entry.Binding)
+		case wgpu.BufferBindingTypeReadOnlyStorage:
+			sizeOverrides[int(entry.Binding)] = uint64(light.MaxShadowAtlasTiles) * 96 // 96 bytes per GPULocalShadow
+			s.shadowLitAtlasBinding = int(entry.Binding)
 		}
 	}
 
@@ -741,7 +1226,7 @@ func (s *scene) PrepareShadows() {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if s.shadowDepthTextureView == nil || s.shadowDataBGP == nil || s.r == nil {
+	if s.shadowDepthTextureView == nil || len(s.shadowDataBGPs) == 0 || s.r == nil || s.cam == nil {
 		return
 	}
 
@@ -753,62 +1238,107 @@ func (s *scene) PrepareShadows() {
 			break
 		}
 	}
-	if shadowLight == nil {
+	localShadows, _ := s.allocateShadowAtlas()
+	if shadowLight == nil && len(localShadows) == 0 {
 		return
 	}
 
-	// Compute light VP matrix centered on the camera's look-at target.
-	// Using target (not position) keeps the shadow frustum centered on the
-	// scene content even when the camera orbits far away.
-	centerX, centerY, centerZ := float32(0), float32(0), float32(0)
-	if s.cam != nil {
-		if ctrl := s.cam.Controller(); ctrl != nil {
-			centerX, centerY, centerZ = ctrl.Target()
+	var writes []bind_group_provider.BufferWrite
+	cascades := 0
+	if shadowLight != nil {
+		// Split the camera frustum up to the shadow distance and fit a light VP to
+		// each slice. The cascade count is bounded by the layers allocated in InitShadowMap.
+		cascades = min(s.shadowCascades, len(s.shadowDataBGPs))
+		texelSize := 1.0 / float32(s.shadowMapResolution)
+		shadowData := light.GPUShadowData{
+			CascadeSplits: light.CascadeSplits(cascades, s.cam.Near(), min(s.shadowDistance, s.cam.Far()), s.shadowSplitLambda),
+			CascadeCount:  uint32(cascades),
+			TexelSize:     [2]float32{texelSize, texelSize},
+			Bias:          s.shadowBias,
+			BlendWidth:    s.shadowCascadeBlend,
+		}
+		shadowData.ComputeCascades(
+			shadowLight.Direction(),
+			s.cam.ViewMatrix(), s.cam.ProjectionMatrix(),
+			s.cam.Near(), s.cam.Far(),
+			s.shadowNear, s.shadowFar,
+			s.shadowNormalBiasScale, s.shadowMapResolution,
+		)
+
+		// Build and write the per-cascade depth pass uniforms.
+		for c := range cascades {
+			uniform := light.GPUShadowUniform{LightVP: shadowData.CascadeVP[c]}
+			writes = append(writes, bind_group_provider.BufferWrite{
+				Provider: s.shadowDataBGPs[c],
+				Binding:  0,
+				Offset:   0,
+				Data:     uniform.Marshal(),
+			})
+		}
+		// Also write the full cascade data to the lit-pass shadow BGP.
+		if s.shadowLitBGP != nil {
+			writes = append(writes, bind_group_provider.BufferWrite{
+				Provider: s.shadowLitBGP,
+				Binding:  s.shadowLitDataBinding,
+				Offset:   0,
+				Data:     shadowData.Marshal(),
+			})
 		}
 	}
-	// Build and write shadow uniform data.
-	texelSize := 1.0 / float32(s.shadowMapResolution)
-	shadowData := light.GPUShadowData{
-		TexelSize: [2]float32{texelSize, texelSize},
-		Bias:      s.shadowBias,
-	}
-	shadowData.ComputeDirectionalLightVP(
-		shadowLight.Direction(),
-		centerX, centerY, centerZ,
-		s.shadowHalfExtent, s.shadowNear, s.shadowFar,
-	)
-	shadowData.ComputeNormalBias(s.shadowHalfExtent, s.shadowNormalBiasScale, s.shadowMapResolution)
-	shadowBytes := shadowData.Marshal()
-	writes := []bind_group_provider.BufferWrite{
-		{
-			Provider: s.shadowDataBGP,
-			Binding:  0,
-			Offset:   0,
-			Data:     shadowBytes,
-		},
-	}
-	// Also write to the lit-pass shadow BGP if it has a uniform buffer.
-	if s.shadowLitBGP != nil {
-		for binding, buf := range s.shadowLitBGP.Buffers() {
-			if buf != nil {
-				writes = append(writes, bind_group_provider.BufferWrite{
-					Provider: s.shadowLitBGP,
-					Binding:  binding,
-					Offset:   0,
-					Data:     shadowBytes,
-				})
-				break // only one uniform buffer expected
-			}
+
+	// Write one depth pass uniform per allocated atlas tile, and the tile list the
+	// lit shader indexes with GPULight.ShadowIndex.
+	if len(localShadows) > 0 {
+		localBytes := make([]byte, 0, len(localShadows)*96)
+		for i := range localShadows {
+			uniform := light.GPUShadowUniform{LightVP: localShadows[i].ViewProj}
+			writes = append(writes, bind_group_provider.BufferWrite{
+				Provider: s.shadowAtlasBGPs[i],
+				Binding:  0,
+				Offset:   0,
+				Data:     uniform.Marshal(),
+			})
+			localBytes = append(localBytes, localShadows[i].Marshal()...)
+		}
+		if s.shadowLitBGP != nil && s.shadowLitAtlasBinding >= 0 {
+			writes = append(writes, bind_group_provider.BufferWrite{
+				Provider: s.shadowLitBGP,
+				Binding:  s.shadowLitAtlasBinding,
+				Offset:   0,
+				Data:     localBytes,
+			})
 		}
 	}
 	s.r.WriteBuffers(writes)
 
-	// Execute shadow depth pass.
+	// Execute one shadow depth pass per cascade, then a single pass over the atlas
+	// with the viewport moved to each tile, all within one shadow frame.
 	if err := s.r.BeginShadowFrame(); err != nil {
 		return
 	}
-	s.r.BeginShadowPass(s.shadowDepthTextureView)
+	for c := range cascades {
+		s.r.BeginShadowPass(s.shadowLayerViews[c])
+		s.drawShadowCasters(s.shadowDataBGPs[c])
+		s.r.EndShadowPass()
+	}
+	if len(localShadows) > 0 {
+		s.r.BeginShadowPass(s.shadowAtlasView)
+		for i := range localShadows {
+			x, y, size := s.shadowAtlasTile(i)
+			s.r.SetShadowViewport(x, y, size, size)
+			s.drawShadowCasters(s.shadowAtlasBGPs[i])
+		}
+		s.r.EndShadowPass()
+	}
+	s.r.EndShadowFrame()
+}
 
+// drawShadowCasters encodes a shadow draw call for every animator with live instances
+// into the current shadow pass. Must be called with s.mu held.
+//
+// Parameters:
+//   - shadowDataBGP: the cascade's or atlas tile's shadow uniform BGP bound at group(0)
+func (s *scene) drawShadowCasters(shadowDataBGP bind_group_provider.BindGroupProvider) {
 	for _, anim := range s.animatorPool {
 		for _, a := range anim {
 			if a.InstanceCount() == 0 {
@@ -837,7 +1367,7 @@ func (s *scene) PrepareShadows() {
 			//   group(0) = shadow data BGP (light VP uniform)
 			//   group(1) = output BGP (instance/bone matrices from compute shader)
 			shadowBindGroups := []bind_group_provider.BindGroupProvider{
-				s.shadowDataBGP,
+				shadowDataBGP,
 				a.OutputBindGroupProvider(),
 			}
 
@@ -874,9 +1404,6 @@ func (s *scene) PrepareShadows() {
 			_ = s.r.ShadowDrawCall(pipeKey, meshProvider, uint32(a.InstanceCount()), shadowBindGroups)
 		}
 	}
-
-	s.r.EndShadowPass()
-	s.r.EndShadowFrame()
 }
 
 func (s *scene) InitLightCullResources(cullComputeShader, litFragmentShader shader.Shader, screenWidth, screenHeight int) {
@@ -899,7 +1426,7 @@ func (s *scene) InitLightCullResources(cullComputeShader, litFragmentShader shad
 	numTiles := uint64(tileCountX) * uint64(tileCountY)
 
 	// ── 1. Create compute BGP (cull shader's @group(0)) ────────────────
-	// binding 0: cull_uniforms (uniform, 160 bytes)
+	// binding 0: cull_uniforms (uniform, 176 bytes)
 	// binding 1: cull_lights (storage, read) — shared from lightsBGP binding 1
 	// binding 2: tile_light_counts (storage, rw) — new buffer
 	// binding 3: tile_light_indices (storage, rw) — new buffer
@@ -912,7 +1439,7 @@ func (s *scene) InitLightCullResources(cullComputeShader, litFragmentShader shad
 
 	cullDesc := cullComputeShader.BindGroupLayoutDescriptor(0)
 	sizeOverrides := map[int]uint64{
-		0: 160,                                           // LightCullUniforms
+		0: 176,                                           // LightCullUniforms
 		2: numTiles * 4,                                  // tile_light_counts: one u32 per tile
 		3: numTiles * uint64(light.MaxLightsPerTile) * 4, // tile_light_indices
 	}
@@ -1071,6 +1598,7 @@ func (s *scene) PrepareLightCulling() {
 		LightCount:   lightCount,
 		Near:         s.cam.Near(),
 		Far:          s.cam.Far(),
+		Viewport:     s.viewportRect(),
 	}
 	s.r.WriteBuffers([]bind_group_provider.BufferWrite{
 		{Provider: s.lightCullBGP, Binding: 0, Offset: 0, Data: uniforms.Marshal()},
@@ -1107,6 +1635,138 @@ func (s *scene) InitLighting(litFragShader, shadowVertShader, shadowSkinnedVertS
 	s.reinitCameraBGPForLitPipeline(litFragShader)
 }
 
+func (s *scene) Environment() environment.Environment {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.env
+}
+
+func (s *scene) InitEnvironment(env environment.Environment, litFragmentShader shader.Shader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.r == nil || env == nil {
+		return
+	}
+
+	clampSampler := common.SamplerStagingData{
+		AddressModeU:  wgpu.AddressModeClampToEdge,
+		AddressModeV:  wgpu.AddressModeClampToEdge,
+		AddressModeW:  wgpu.AddressModeClampToEdge,
+		MagFilter:     wgpu.FilterModeLinear,
+		MinFilter:     wgpu.FilterModeLinear,
+		MipmapFilter:  wgpu.MipmapFilterModeLinear,
+		LodMaxClamp:   32,
+		MaxAnisotropy: 1,
+	}
+
+	// Register the skybox pipeline once. It draws a fullscreen triangle with no vertex
+	// buffer, and ignores depth so that the geometry drawn after it always covers it.
+	if s.skyboxPipelineKey == "" {
+		key := "skybox"
+		p := pipeline.NewPipeline(key, pipeline.PipelineTypeRender,
+			pipeline.WithVertexShader(shader.NewShaderFromSource("skybox_vert", shader.ShaderTypeVertex, environment.SkyboxVertexSource)),
+			pipeline.WithFragmentShader(shader.NewShaderFromSource("skybox_frag", shader.ShaderTypeFragment, environment.SkyboxFragmentSource)),
+			pipeline.WithDepthTestEnabled(false),
+			pipeline.WithDepthWriteEnabled(false),
+			pipeline.WithCullMode(wgpu.CullModeNone),
+		)
+		if err := s.r.RegisterPipelines(p); err != nil {
+			panic(fmt.Sprintf("scene: failed to register skybox pipeline: %v", err))
+		}
+		s.skyboxPipelineKey = key
+	}
+
+	// Skybox BGP: the sky cubemap with its full mip chain (for SkyboxBlur), a sampler
+	// and the SkyboxParams uniform.
+	skyboxShader := s.r.Pipeline(s.skyboxPipelineKey).Shader(shader.ShaderTypeFragment)
+	skyboxBGP := bind_group_provider.NewBindGroupProvider(s.name + "_skybox")
+	for _, decl := range skyboxShader.Declarations() {
+		if decl.Type != shader.AnnotationTypeProvider || decl.Args[0] != shader.AnnotationArgSkybox || len(decl.Args) < 2 {
+			continue
+		}
+		switch decl.Args[1] {
+		case shader.AnnotationArgSkyboxTexture:
+			if err := s.r.InitCubeTextureView(skyboxBGP, *decl.Binding, env.Sky().StagingData()); err != nil {
+				panic(fmt.Sprintf("scene: failed to upload skybox cubemap: %v", err))
+			}
+		case shader.AnnotationArgSkyboxSampler:
+			if err := s.r.InitSampler(skyboxBGP, *decl.Binding, clampSampler); err != nil {
+				panic(fmt.Sprintf("scene: failed to create skybox sampler: %v", err))
+			}
+		}
+	}
+	skyboxDesc := skyboxShader.BindGroupLayoutDescriptor(0)
+	skyboxSizes := make(map[int]uint64)
+	for _, entry := range skyboxDesc.Entries {
+		if entry.Buffer.Type == wgpu.BufferBindingTypeUniform {
+			skyboxSizes[int(entry.Binding)] = 80 // GPUSkyboxParams
+		}
+	}
+	if err := s.r.InitBindGroup(skyboxBGP, skyboxDesc, nil, skyboxSizes); err != nil {
+		panic(fmt.Sprintf("scene: failed to init skybox bind group: %v", err))
+	}
+
+	// Lit environment BGP: located by the environment provider annotation (or the
+	// EnvironmentParams struct) in the lit fragment shader.
+	var envLitBGP bind_group_provider.BindGroupProvider
+	if litFragmentShader != nil {
+		envGroup := -1
+		for _, decl := range litFragmentShader.Declarations() {
+			if decl.Group == nil {
+				continue
+			}
+			if (decl.Type == shader.AnnotationTypeProvider && decl.Args[0] == shader.AnnotationArgEnvironment) ||
+				(decl.Type == shader.AnnotationTypeBindingGroup && decl.Args[2] == shader.AnnotationArgEnvironmentParams) {
+				envGroup = *decl.Group
+				break
+			}
+		}
+
+		if envGroup >= 0 {
+			envLitBGP = bind_group_provider.NewBindGroupProvider(s.name + "_environment")
+			for _, decl := range litFragmentShader.Declarations() {
+				if decl.Type != shader.AnnotationTypeProvider || decl.Args[0] != shader.AnnotationArgEnvironment || len(decl.Args) < 2 || *decl.Group != envGroup {
+					continue
+				}
+				var err error
+				switch decl.Args[1] {
+				case shader.AnnotationArgEnvSpecular:
+					err = s.r.InitCubeTextureView(envLitBGP, *decl.Binding, env.Specular().StagingData())
+				case shader.AnnotationArgEnvBRDFLUT:
+					err = s.r.InitTextureView(envLitBGP, *decl.Binding, environment.BRDFLUTStagingData())
+				case shader.AnnotationArgEnvSampler:
+					err = s.r.InitSampler(envLitBGP, *decl.Binding, clampSampler)
+				}
+				if err != nil {
+					panic(fmt.Sprintf("scene: failed to init environment %s: %v", decl.Args[1], err))
+				}
+			}
+
+			envDesc := litFragmentShader.BindGroupLayoutDescriptor(envGroup)
+			envSizes := make(map[int]uint64)
+			for _, entry := range envDesc.Entries {
+				if entry.Buffer.Type == wgpu.BufferBindingTypeUniform {
+					envSizes[int(entry.Binding)] = 160 // GPUEnvironmentParams
+				}
+			}
+			if err := s.r.InitBindGroup(envLitBGP, envDesc, nil, envSizes); err != nil {
+				panic(fmt.Sprintf("scene: failed to init environment bind group: %v", err))
+			}
+		}
+	}
+
+	if s.skyboxBGP != nil {
+		s.skyboxBGP.Release()
+	}
+	if s.envLitBGP != nil {
+		s.envLitBGP.Release()
+	}
+	s.env = env
+	s.skyboxBGP = skyboxBGP
+	s.envLitBGP = envLitBGP
+}
+
 func (s *scene) Count() int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -1128,7 +1788,21 @@ func (s *scene) CountEphemeral() int {
 func (s *scene) Add(obj game_object.GameObject, computeShader, vertexShader, fragmentShader shader.Shader, pipelineOpts ...pipeline.PipelineBuilderOption) uint64 {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.addLocked(obj, computeShader, vertexShader, fragmentShader, pipelineOpts...)
+}
 
+// addLocked implements Add. Caller must hold s.mu write lock.
+//
+// Parameters:
+//   - obj: the GameObject to add
+//   - computeShader: the compute shader to use for this object's Animator
+//   - vertexShader: the vertex shader to use for this object's render pipeline
+//   - fragmentShader: the fragment shader to use for this object's render pipeline
+//   - pipelineOpts: optional pipeline builder options for the render pipeline
+//
+// Returns:
+//   - uint64: the assigned object ID
+func (s *scene) addLocked(obj game_object.GameObject, computeShader, vertexShader, fragmentShader shader.Shader, pipelineOpts ...pipeline.PipelineBuilderOption) uint64 {
 	if s.r == nil {
 		panic("scene: cannot Add without a Renderer attached")
 	}
@@ -1149,6 +1823,11 @@ func (s *scene) Add(obj game_object.GameObject, computeShader, vertexShader, fra
 		anim = s.createAnimator(mdl, computeShader, vertexShader, fragmentShader, pipelineOpts...)
 		animPool = []animator.Animator{anim}
 		s.animatorPool[mdl] = animPool
+		s.modelShaders[mdl] = modelShaderKeys{
+			Compute:  computeShader.Key(),
+			Vertex:   vertexShader.Key(),
+			Fragment: fragmentShader.Key(),
+		}
 	} else {
 		for _, a := range animPool {
 			if a.InstanceCount() < a.MaxInstances() {
@@ -1163,12 +1842,6 @@ func (s *scene) Add(obj game_object.GameObject, computeShader, vertexShader, fra
 		}
 	}
 
-	// Capture initial transform from the GameObject BEFORE wiring the animator.
-	// TransformData returns the builder-supplied initial values (position, scale,
-	// rotation, rotation speed) when the animator is nil. Once SetAnimator is called,
-	// it would read from the animator's zero-initialized instance slot instead.
-	pos, scale, rot, rotSpeed := obj.TransformData()
-
 	// Wire the object to the animator and add an instance
 	obj.SetAnimator(anim)
 	idx, err := anim.AddInstance()
@@ -1177,8 +1850,8 @@ func (s *scene) Add(obj game_object.GameObject, computeShader, vertexShader, fra
 	}
 	obj.SetAnimatorInstanceID(int(idx))
 
-	// Push initial transform data from the GameObject into the animator slot
-	anim.SetInstanceData(idx, pos, scale, rotSpeed, rot)
+	// Push the object's world transform (and any children already in a scene) into the animator slot
+	obj.SyncAnimator()
 
 	// Persist non-ephemeral objects in the registry
 	if !obj.Ephemeral() {
@@ -1195,6 +1868,24 @@ func (s *scene) Add(obj game_object.GameObject, computeShader, vertexShader, fra
 	return obj.ID()
 }
 
+func (s *scene) ReserveID() uint64 {
+	return atomic.AddUint64(&s.nextID, 1) - 1
+}
+
+// reserveIDsThrough raises the next ID above id, so IDs restored from a file are never
+// handed out again. IDs reserved concurrently are not lowered.
+//
+// Parameters:
+//   - id: the highest ID in use
+func (s *scene) reserveIDsThrough(id uint64) {
+	for {
+		next := atomic.LoadUint64(&s.nextID)
+		if id < next || atomic.CompareAndSwapUint64(&s.nextID, next, id+1) {
+			return
+		}
+	}
+}
+
 func (s *scene) Get(id uint64) game_object.GameObject {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -1212,6 +1903,12 @@ func (s *scene) Remove(id uint64) {
 
 	delete(s.registry, id)
 
+	// Children outlive their parent as roots, keeping their world transforms.
+	for _, child := range obj.Children() {
+		child.SetParent(nil)
+	}
+	obj.SetParent(nil)
+
 	// Remove attached light from scene tracking lists
 	if l := obj.Light(); l != nil {
 		for i, existing := range s.lights {
@@ -1253,6 +1950,7 @@ func (s *scene) Clear() {
 	defer s.mu.Unlock()
 
 	s.animatorPool = make(map[model.Model][]animator.Animator)
+	s.modelShaders = make(map[model.Model]modelShaderKeys)
 	s.registry = make(map[uint64]game_object.GameObject)
 	s.lightObjects = nil
 }
@@ -1533,19 +2231,63 @@ func (s *scene) createAnimator(mdl model.Model, computeShader, vertexShader, fra
 	}
 	anim.Model().SetComputePipelineKey(cp.PipelineKey())
 
-	// Register render pipeline with the model name as key, matching Material.PipelineKey()
+	// Register render pipeline with the model name as key, matching Material.PipelineKey().
+	// Back faces are culled by default; double-sided materials draw with a variant that is not.
 	renderOpts := append([]pipeline.PipelineBuilderOption{
 		pipeline.WithVertexShader(vertexShader),
 		pipeline.WithFragmentShader(fragmentShader),
+		pipeline.WithCullMode(wgpu.CullModeBack),
 	}, pipelineOpts...)
 	rp := pipeline.NewPipeline(mdl.Name(), pipeline.PipelineTypeRender, renderOpts...)
 	if err := s.r.RegisterPipelines(rp); err != nil {
 		panic(fmt.Sprintf("scene: failed to register render pipeline for model %q: %v", mdl.Name(), err))
 	}
 
+	// Register the blend and double-sided variants up front so the first frame does not build them.
+	for _, mat := range mdl.RenderMaterials() {
+		if mat.PipelineKey() != rp.PipelineKey() {
+			continue
+		}
+		if _, err := s.materialPipelineKey(mat, rp); err != nil {
+			panic(fmt.Sprintf("scene: failed to register render pipeline variant for model %q: %v", mdl.Name(), err))
+		}
+	}
+
 	return anim
 }
 
+func (s *scene) BeginSimulationStep() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, obj := range s.registry {
+		obj.RestoreTransform()
+	}
+}
+
+func (s *scene) SyncTransforms() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, obj := range s.registry {
+		obj.SyncAnimator()
+	}
+}
+
+func (s *scene) EndSimulationStep() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, obj := range s.registry {
+		obj.SnapshotTransform()
+	}
+}
+
+func (s *scene) InterpolateTransforms(alpha float32) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, obj := range s.registry {
+		obj.InterpolateTransform(alpha)
+	}
+}
+
 func (s *scene) PrepareCompute(deltaTime float32) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -1586,17 +2328,51 @@ func (s *scene) PrepareCompute(deltaTime float32) {
 		hasFrustum = !s.cullingDisabled
 	}
 
-	// Sync attached lights: copy each game object's world position to its light.
+	// Sync attached lights: copy each game object's rendered world position to its light.
+	// The animator instance holds the (possibly interpolated) world transform being drawn.
 	for _, obj := range s.lightObjects {
 		if l := obj.Light(); l != nil && obj.Enabled() {
-			x, y, z := obj.Position()
-			l.SetPosition(x, y, z)
+			if anim := obj.Animator(); anim != nil && obj.AnimatorInstanceID() >= 0 {
+				pos, _ := anim.InstanceTransform(uint32(obj.AnimatorInstanceID()))
+				l.SetPosition(pos[0], pos[1], pos[2])
+			} else {
+				x, y, z := obj.WorldPosition()
+				l.SetPosition(x, y, z)
+			}
+		}
+	}
+
+	// Write the environment uniforms each frame so intensity changes and camera
+	// rotation take effect immediately.
+	if s.env != nil && s.cam != nil {
+		var writes []bind_group_provider.BufferWrite
+		if s.skyboxBGP != nil {
+			params := s.env.SkyboxParams(s.cam.ViewMatrix(), s.cam.ProjectionMatrix())
+			for binding, buf := range s.skyboxBGP.Buffers() {
+				if buf != nil {
+					writes = append(writes, bind_group_provider.BufferWrite{Provider: s.skyboxBGP, Binding: binding, Offset: 0, Data: params.Marshal()})
+					break // only one uniform buffer expected
+				}
+			}
+		}
+		if s.envLitBGP != nil {
+			params := s.env.EnvironmentParams()
+			for binding, buf := range s.envLitBGP.Buffers() {
+				if buf != nil {
+					writes = append(writes, bind_group_provider.BufferWrite{Provider: s.envLitBGP, Binding: binding, Offset: 0, Data: params.Marshal()})
+					break // only one uniform buffer expected
+				}
+			}
+		}
+		if len(writes) > 0 {
+			s.r.WriteBuffers(writes)
 		}
 	}
 
 	// Write light buffer to GPU each frame when a light BGP is initialized.
 	if s.lightsBGP != nil {
-		lightData := light.MarshalLightBuffer(s.lights, s.ambientColor)
+		_, shadowIndices := s.allocateShadowAtlas()
+		lightData := light.MarshalLightBuffer(s.lights, s.ambientColor, shadowIndices)
 		writes := []bind_group_provider.BufferWrite{
 			{
 				Provider: s.lightsBGP,
@@ -1653,6 +2429,7 @@ func (s *scene) PrepareCompute(deltaTime float32) {
 			if shdr == nil {
 				continue
 			}
+			sorted := s.sortsTransparency(a.Model())
 
 			wg.Add(1)
 			aCap := a // capture for closure
@@ -1686,8 +2463,12 @@ func (s *scene) PrepareCompute(deltaTime float32) {
 
 					// Feed frustum planes to the animator for GPU-side culling.
 					// This must happen before PrepareFrame so the uniform data includes the planes.
-					if hasFrustum {
+					// Models with sorted transparent materials draw instances by index, so they
+					// keep every instance in its own output slot and are culled on the CPU instead.
+					if hasFrustum && !sorted {
 						aCap.SetFrustumPlanes(gpuPlanes)
+					} else {
+						aCap.DisableCulling()
 					}
 
 					aCap.PrepareFrame(deltaTime, uniformBinding)
@@ -1766,6 +2547,20 @@ func (s *scene) DrawCalls() error {
 		return fmt.Errorf("scene %q has no renderer attached", s.name)
 	}
 
+	s.transparentBatches = s.transparentBatches[:0]
+	s.transparentBindGroups = s.transparentBindGroups[:0]
+
+	if err := s.applyViewport(); err != nil {
+		return err
+	}
+
+	// The skybox goes first; it does not test or write depth, so all geometry covers it.
+	if s.env != nil && s.env.SkyboxEnabled() && s.skyboxBGP != nil {
+		if err := s.r.DrawProcedural(s.skyboxPipelineKey, 3, 1, []bind_group_provider.BindGroupProvider{s.skyboxBGP}); err != nil {
+			return fmt.Errorf("skybox draw failed in scene %q: %w", s.name, err)
+		}
+	}
+
 	for _, anim := range s.animatorPool {
 		for _, a := range anim {
 			if a.InstanceCount() == 0 {
@@ -1787,16 +2582,21 @@ func (s *scene) DrawCalls() error {
 			}
 
 			for _, mat := range mats {
-				pipelineKey := mat.PipelineKey()
-				if pipelineKey == "" {
+				if mat.PipelineKey() == "" {
 					continue
 				}
 
 				// Look up the render pipeline to discover bind group layouts from both shaders.
-				rp := s.r.Pipeline(pipelineKey)
+				rp := s.r.Pipeline(mat.PipelineKey())
 				if rp == nil {
 					continue
 				}
+
+				// Swap in the blend or double-sided variant the material's alpha mode calls for.
+				pipelineKey, err := s.materialPipelineKey(mat, rp)
+				if err != nil {
+					return fmt.Errorf("pipeline variant failed for material in scene %q: %w", s.name, err)
+				}
 				renderShader := rp.Shader(shader.ShaderTypeVertex)
 				if renderShader == nil {
 					continue
@@ -1853,6 +2653,14 @@ func (s *scene) DrawCalls() error {
 							}
 						case shader.AnnotationArgAnimator:
 							provider = a.OutputBindGroupProvider()
+						case shader.AnnotationArgEnvironment:
+							if s.envLitBGP != nil {
+								provider = s.envLitBGP
+							}
+						case shader.AnnotationArgRenderTarget:
+							if bgp, ok := s.renderTargetBGPs[mat]; ok {
+								provider = bgp
+							}
 						}
 					case shader.AnnotationTypeBindingGroup:
 						typeArg := string(decl.Args[2])
@@ -1870,7 +2678,7 @@ func (s *scene) DrawCalls() error {
 							if s.lightsBGP != nil {
 								provider = s.lightsBGP
 							}
-						case shader.AnnotationArgShadowData, shader.AnnotationArgShadowUniform:
+						case shader.AnnotationArgShadowData, shader.AnnotationArgShadowUniform, shader.AnnotationArgLocalShadow:
 							if s.shadowLitBGP != nil {
 								provider = s.shadowLitBGP
 							}
@@ -1882,6 +2690,12 @@ func (s *scene) DrawCalls() error {
 							if ep := mdl.EffectProvider(); ep != nil {
 								provider = ep
 							}
+						case shader.AnnotationArgEnvironmentParams:
+							if s.envLitBGP != nil {
+								provider = s.envLitBGP
+							}
+						case shader.AnnotationArgMaterialParams:
+							provider = mat.BindGroupProvider()
 						}
 					}
 
@@ -1906,6 +2720,7 @@ func (s *scene) DrawCalls() error {
 
 				// Use indirect draw when GPU frustum culling is active — the compute shader writes
 				// the visible instance count into the indirect args buffer, avoiding CPU readback.
+				var indBuf *wgpu.Buffer
 				if a.CullingEnabled() {
 					var indirectBinding int
 					if key := mdl.ComputePipelineKey(); key != "" {
@@ -1924,12 +2739,30 @@ func (s *scene) DrawCalls() error {
 							}
 						}
 					}
-					if indBuf := a.IndirectBuffer(indirectBinding); indBuf != nil {
-						if err := s.r.DrawCallIndirect(pipelineKey, meshProvider, indBuf, bindGroups); err != nil {
-							return fmt.Errorf("indirect draw call failed for animator in scene %q: %w", s.name, err)
-						}
-						continue
+					indBuf = a.IndirectBuffer(indirectBinding)
+				}
+
+				// Blended materials are drawn after everything else, in the transparent pass.
+				if mat.AlphaMode() == material.AlphaModeBlend {
+					start := len(s.transparentBindGroups)
+					s.transparentBindGroups = append(s.transparentBindGroups, bindGroups...)
+					s.transparentBatches = append(s.transparentBatches, transparentBatch{
+						pipelineKey:     pipelineKey,
+						meshProvider:    meshProvider,
+						bindGroupStart:  start,
+						bindGroupEnd:    len(s.transparentBindGroups),
+						animator:        a,
+						indirectBuffer:  indBuf,
+						weightedBlended: s.r.Pipeline(pipelineKey).WeightedBlended(),
+					})
+					continue
+				}
+
+				if indBuf != nil {
+					if err := s.r.DrawCallIndirect(pipelineKey, meshProvider, indBuf, bindGroups); err != nil {
+						return fmt.Errorf("indirect draw call failed for animator in scene %q: %w", s.name, err)
 					}
+					continue
 				}
 
 				if err := s.r.DrawCall(pipelineKey, meshProvider, uint32(a.InstanceCount()), bindGroups); err != nil {
@@ -1939,5 +2772,38 @@ func (s *scene) DrawCalls() error {
 		}
 	}
 
+	if err := s.drawTransparent(); err != nil {
+		return err
+	}
+
+	// Sprites are layered over the scene's geometry in their own sort order.
+	if s.spriteBatch != nil {
+		if err := s.spriteBatch.Draw(s.r, s.cam); err != nil {
+			return fmt.Errorf("sprite draw failed in scene %q: %w", s.name, err)
+		}
+	}
+
+	// Debug lines go last, over the scene's geometry.
+	if s.debugDraw != nil {
+		if err := s.debugDraw.Draw(s.r, s.cam); err != nil {
+			return fmt.Errorf("debug draw failed in scene %q: %w", s.name, err)
+		}
+	}
+
+	// Text goes over everything, debug lines included.
+	if s.textRenderer != nil {
+		vp := s.viewportRect()
+		if err := s.textRenderer.Draw(s.r, s.cam, vp[2], vp[3]); err != nil {
+			return fmt.Errorf("text draw failed in scene %q: %w", s.name, err)
+		}
+	}
+
+	// The UI goes on top of everything.
+	if s.ui != nil {
+		vp := s.viewportRect()
+		if err := s.ui.Draw(s.r, vp[2], vp[3]); err != nil {
+			return fmt.Errorf("ui draw failed in scene %q: %w", s.name, err)
+		}
+	}
 	return nil
 }
@@ -1,7 +1,16 @@
 package scene
 
 import (
+	"sync/atomic"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/debug_draw"
 	"github.com/Carmen-Shannon/oxy-go/engine/game_object"
+	"github.com/Carmen-Shannon/oxy-go/engine/light"
+	"github.com/Carmen-Shannon/oxy-go/engine/render_target"
+	"github.com/Carmen-Shannon/oxy-go/engine/sprite"
+	"github.com/Carmen-Shannon/oxy-go/engine/text"
+	"github.com/Carmen-Shannon/oxy-go/engine/ui"
+	"github.com/cogentcore/webgpu/wgpu"
 )
 
 // SceneBuilderOption is a functional option for configuring a Scene.
@@ -34,8 +43,7 @@ func WithObjects(objects ...game_object.GameObject) SceneBuilderOption {
 	return func(s *scene) {
 		for _, obj := range objects {
 			if obj.ID() == 0 {
-				obj.SetID(s.nextID)
-				s.nextID++
+				obj.SetID(atomic.AddUint64(&s.nextID, 1) - 1)
 			}
 			if !obj.Ephemeral() {
 				s.registry[obj.ID()] = obj
@@ -79,23 +87,199 @@ func WithCullingDisabled(disabled bool) SceneBuilderOption {
 	}
 }
 
-// WithShadowHalfExtent sets the orthographic half-extent of the directional shadow
-// frustum in world units. Larger values capture more of the scene but reduce shadow
-// resolution. Default is light.DefaultShadowHalfExtent (40.0).
+// WithViewport sets the normalized rectangle of the render target the scene draws into, and the
+// camera's aspect ratio to match it. Default is FullViewport.
+//
+// Parameters:
+//   - v: the viewport, e.g. Viewport{X: 0.5, Y: 0, Width: 0.5, Height: 1} for the right half
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithViewport(v Viewport) SceneBuilderOption {
+	return func(s *scene) {
+		s.viewport = v
+	}
+}
+
+// WithScissor clips the scene's draws to a normalized rectangle of the render target instead of
+// its viewport.
+//
+// Parameters:
+//   - rect: the scissor rectangle
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithScissor(rect Viewport) SceneBuilderOption {
+	return func(s *scene) {
+		s.scissor = &rect
+	}
+}
+
+// WithClearDepth makes DrawCalls reset the depth buffer inside the scene's viewport before
+// drawing, so a HUD or overlay scene at a higher z-index is not depth-tested against the scenes
+// drawn before it. Default is false.
+//
+// Parameters:
+//   - clear: true to clear depth
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithClearDepth(clear bool) SceneBuilderOption {
+	return func(s *scene) {
+		s.clearDepth = clear
+	}
+}
+
+// WithClearColor makes DrawCalls fill the scene's viewport with a color before drawing, e.g.
+// for the background of a picture-in-picture view. By default the color target is kept.
+//
+// Parameters:
+//   - color: the clear color
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithClearColor(color wgpu.Color) SceneBuilderOption {
+	return func(s *scene) {
+		s.clearColor = &color
+	}
+}
+
+// WithRenderTarget makes the scene render into an offscreen target instead of the frame. The
+// engine draws it in its offscreen pass, before the main pass, whenever the target is due, and
+// skips it in the main pass. The viewport and the camera's aspect ratio follow the target's
+// size, and blended materials are sorted rather than drawn with weighted blended transparency.
+// The screenWidth and screenHeight given to InitLighting must cover the target's size.
+//
+// Parameters:
+//   - rt: the render target
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithRenderTarget(rt render_target.RenderTarget) SceneBuilderOption {
+	return func(s *scene) {
+		s.renderTarget = rt
+	}
+}
+
+// WithSpriteBatch attaches a sprite batch that the scene draws with its camera after its opaque and
+// transparent geometry, before its debug draw.
+//
+// Parameters:
+//   - b: the sprite batch
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithSpriteBatch(b sprite.SpriteBatch) SceneBuilderOption {
+	return func(s *scene) {
+		s.spriteBatch = b
+	}
+}
+
+// WithDebugDraw attaches a debug draw that the scene draws at the end of DrawCalls with its camera.
+//
+// Parameters:
+//   - dd: the debug draw
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithDebugDraw(dd debug_draw.DebugDraw) SceneBuilderOption {
+	return func(s *scene) {
+		s.debugDraw = dd
+	}
+}
+
+// WithTextRenderer attaches a text renderer that the scene draws at the end of DrawCalls, after
+// its debug draw, with its camera and viewport.
 //
 // Parameters:
-//   - halfExtent: half-size of the shadow frustum in world units
+//   - tr: the text renderer
 //
 // Returns:
 //   - SceneBuilderOption: option function to apply
-func WithShadowHalfExtent(halfExtent float32) SceneBuilderOption {
+func WithTextRenderer(tr text.TextRenderer) SceneBuilderOption {
 	return func(s *scene) {
-		s.shadowHalfExtent = halfExtent
+		s.textRenderer = tr
+	}
+}
+
+// WithUI attaches a UI that the scene draws at the end of DrawCalls, over everything else, sized
+// to its viewport.
+//
+// Parameters:
+//   - u: the UI
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithUI(u ui.UI) SceneBuilderOption {
+	return func(s *scene) {
+		s.ui = u
+	}
+}
+
+// WithShadowDistance sets the view-space distance from the camera up to which directional
+// light shadows are rendered. The shadow cascades divide the range between the camera near
+// plane and this distance, so larger values shadow more of the scene at lower resolution.
+// Default is light.DefaultShadowDistance (100.0).
+//
+// Parameters:
+//   - distance: shadow distance in world units
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithShadowDistance(distance float32) SceneBuilderOption {
+	return func(s *scene) {
+		s.shadowDistance = distance
+	}
+}
+
+// WithShadowCascades sets the number of cascades the directional shadow is split into,
+// clamped to [1, light.MaxShadowCascades]. Must be set before InitShadowMap / InitLighting
+// is called, as the depth texture array is allocated once. Default is light.DefaultShadowCascades (4).
+//
+// Parameters:
+//   - cascades: number of shadow cascades
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithShadowCascades(cascades int) SceneBuilderOption {
+	return func(s *scene) {
+		s.shadowCascades = max(min(cascades, light.MaxShadowCascades), 1)
+	}
+}
+
+// WithShadowSplitLambda sets the blend between logarithmic (1) and uniform (0) cascade
+// split distances. Higher values spend more resolution close to the camera.
+// Default is light.DefaultShadowSplitLambda (0.75).
+//
+// Parameters:
+//   - lambda: split blend factor in [0, 1]
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithShadowSplitLambda(lambda float32) SceneBuilderOption {
+	return func(s *scene) {
+		s.shadowSplitLambda = max(min(lambda, 1), 0)
+	}
+}
+
+// WithShadowCascadeBlend sets the fraction of each cascade's depth range over which
+// shadows cross-fade into the next cascade, hiding the seam between them. 0 disables
+// blending. Default is light.DefaultShadowCascadeBlend (0.1).
+//
+// Parameters:
+//   - blend: blend fraction in [0, 1]
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithShadowCascadeBlend(blend float32) SceneBuilderOption {
+	return func(s *scene) {
+		s.shadowCascadeBlend = max(min(blend, 1), 0)
 	}
 }
 
 // WithShadowNearFar sets the near and far planes for the directional shadow projection.
-// Default is light.DefaultShadowNear (0.1) and light.DefaultShadowFar (200.0).
+// Each cascade's light-space depth range spans at least far, so it bounds how far
+// behind a cascade shadow casters are still captured. Default is light.DefaultShadowNear (0.1) and light.DefaultShadowFar (200.0).
 //
 // Parameters:
 //   - near: near plane distance
@@ -140,8 +324,8 @@ func WithShadowNormalBiasScale(scale float32) SceneBuilderOption {
 	}
 }
 
-// WithShadowMapResolution sets the width and height in texels of the shadow
-// depth texture. Higher values produce sharper shadows at the cost of more
+// WithShadowMapResolution sets the width and height in texels of each layer of
+// the shadow depth texture array. Higher values produce sharper shadows at the cost of more
 // GPU memory and fill-rate. Must be set before InitShadowMap / InitLighting
 // is called, as the texture is allocated once. Default is light.ShadowMapResolution (2048).
 //
@@ -155,3 +339,39 @@ func WithShadowMapResolution(resolution int) SceneBuilderOption {
 		s.shadowMapResolution = resolution
 	}
 }
+
+// WithShadowAtlas sets the size of the shadow atlas that point and spot light shadows
+// are packed into and the size of its square tiles. A spot light uses one tile and a
+// point light six, so smaller tiles fit more lights at lower shadow resolution; at most
+// light.MaxShadowAtlasTiles tiles are used. Must be set before InitShadowMap / InitLighting
+// is called, as the atlas is allocated once. Default is light.ShadowAtlasResolution (4096)
+// with light.ShadowAtlasTileResolution (512) tiles.
+//
+// Parameters:
+//   - resolution: atlas width and height in texels
+//   - tileSize: tile width and height in texels, clamped to the resolution
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithShadowAtlas(resolution, tileSize int) SceneBuilderOption {
+	return func(s *scene) {
+		s.shadowAtlasResolution = max(resolution, 1)
+		s.shadowAtlasTileSize = max(min(tileSize, s.shadowAtlasResolution), 1)
+	}
+}
+
+// WithShadowAtlasBudget sets the number of shadow atlas tiles rendered per frame. Each
+// tile is a depth pass over all shadow casters, so this bounds the cost of point and
+// spot light shadows. Lights closest to the camera are served first; lights that do not
+// fit are rendered unshadowed that frame. Default is light.DefaultShadowAtlasBudget (16).
+//
+// Parameters:
+//   - tiles: the tile budget per frame, 0 to disable point and spot light shadows
+//
+// Returns:
+//   - SceneBuilderOption: option function to apply
+func WithShadowAtlasBudget(tiles int) SceneBuilderOption {
+	return func(s *scene) {
+		s.shadowAtlasBudget = max(tiles, 0)
+	}
+}
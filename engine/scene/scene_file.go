@@ -0,0 +1,577 @@
+package scene
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/camera"
+	"github.com/Carmen-Shannon/oxy-go/engine/game_object"
+	"github.com/Carmen-Shannon/oxy-go/engine/light"
+	"github.com/Carmen-Shannon/oxy-go/engine/loader"
+	"github.com/Carmen-Shannon/oxy-go/engine/model"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/animator"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+)
+
+// FileVersion is the scene file format version written by Save and SaveBinary.
+// Load rejects files with a newer version.
+const FileVersion = 3
+
+// binaryMagic prefixes every file written by SaveBinary so Load can tell it apart from JSON.
+var binaryMagic = []byte("OXYSCENE")
+
+// modelShaderKeys records the shader keys a Model was first added to the scene with.
+type modelShaderKeys struct {
+	Compute  string `json:"compute"`
+	Vertex   string `json:"vertex"`
+	Fragment string `json:"fragment"`
+}
+
+// sceneFile is the root of the serialized scene document shared by the JSON and binary formats.
+type sceneFile struct {
+	Version      int          `json:"version"`
+	Name         string       `json:"name"`
+	AmbientColor [3]float32   `json:"ambientColor"`
+	Shadow       shadowFile   `json:"shadow"`
+	Camera       cameraFile   `json:"camera"`
+	Models       []modelFile  `json:"models,omitempty"`
+	Objects      []objectFile `json:"objects,omitempty"`
+	Lights       []lightFile  `json:"lights,omitempty"`
+}
+
+// shadowFile holds the scene's shadow projection settings. Version 1 files predate
+// cascaded shadows and version 2 files predate the shadow atlas; missing fields load
+// with the defaults.
+type shadowFile struct {
+	Distance        float32 `json:"distance"`
+	Cascades        int     `json:"cascades"`
+	SplitLambda     float32 `json:"splitLambda"`
+	CascadeBlend    float32 `json:"cascadeBlend"`
+	Near            float32 `json:"near"`
+	Far             float32 `json:"far"`
+	Bias            float32 `json:"bias"`
+	NormalBiasScale float32 `json:"normalBiasScale"`
+	MapResolution   int     `json:"mapResolution"`
+	AtlasResolution int     `json:"atlasResolution,omitempty"`
+	AtlasTileSize   int     `json:"atlasTileSize,omitempty"`
+	AtlasBudget     int     `json:"atlasBudget"`
+}
+
+// cameraFile holds the camera's projection settings and optional controller state.
+// The aspect ratio is not stored because it follows the window size.
+type cameraFile struct {
+	Up           [3]float32      `json:"up"`
+	Orthographic bool            `json:"orthographic,omitempty"`
+	Fov          float32         `json:"fov"`
+	OrthoSize    float32         `json:"orthoSize,omitempty"`
+	Near         float32         `json:"near"`
+	Far          float32         `json:"far"`
+	Controller   *controllerFile `json:"controller,omitempty"`
+}
+
+// controllerFile holds the full state of a CameraController.
+type controllerFile struct {
+	Position         [3]float32 `json:"position"`
+	Target           [3]float32 `json:"target"`
+	Radius           float32    `json:"radius"`
+	Azimuth          float32    `json:"azimuth"`
+	Elevation        float32    `json:"elevation"`
+	MinRadius        float32    `json:"minRadius"`
+	MaxRadius        float32    `json:"maxRadius"`
+	MinElevation     float32    `json:"minElevation"`
+	MaxElevation     float32    `json:"maxElevation"`
+	OrbitSpeed       float32    `json:"orbitSpeed"`
+	MouseSensitivity float32    `json:"mouseSensitivity"`
+	ZoomSpeed        float32    `json:"zoomSpeed"`
+	PanSpeed         float32    `json:"panSpeed"`
+}
+
+// modelFile references a Model by its loader path along with the shaders it renders with.
+type modelFile struct {
+	Path    string          `json:"path"`
+	Shaders modelShaderKeys `json:"shaders"`
+}
+
+// objectFile holds a non-ephemeral GameObject. Model is the path of an entry in sceneFile.Models.
+// Parent is the ID of the parent object, or zero for a root; the transform is local to the parent.
+type objectFile struct {
+	ID            uint64     `json:"id"`
+	Parent        uint64     `json:"parent,omitempty"`
+	Model         string     `json:"model"`
+	Enabled       bool       `json:"enabled"`
+	Position      [3]float32 `json:"position"`
+	Rotation      [3]float32 `json:"rotation"`
+	RotationSpeed [3]float32 `json:"rotationSpeed"`
+	Scale         [3]float32 `json:"scale"`
+}
+
+// lightFile holds a light. Object is the ID of the GameObject the light is attached to,
+// or zero for a free-standing light. Cone angles are stored in degrees.
+type lightFile struct {
+	Type         string     `json:"type"`
+	Object       uint64     `json:"object,omitempty"`
+	Position     [3]float32 `json:"position"`
+	Direction    [3]float32 `json:"direction"`
+	Color        [3]float32 `json:"color"`
+	Intensity    float32    `json:"intensity"`
+	Range        float32    `json:"range"`
+	InnerConeDeg float32    `json:"innerConeDeg"`
+	OuterConeDeg float32    `json:"outerConeDeg"`
+	Enabled      bool       `json:"enabled"`
+	CastsShadows bool       `json:"castsShadows"`
+}
+
+// lightTypeNames maps light types to their names in scene files.
+var lightTypeNames = map[light.LightType]string{
+	light.LightTypeDirectional: "directional",
+	light.LightTypePoint:       "point",
+	light.LightTypeSpot:        "spot",
+}
+
+func (s *scene) Save(w io.Writer) error {
+	f, err := s.snapshot()
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		return fmt.Errorf("scene: failed to encode scene: %w", err)
+	}
+	return nil
+}
+
+func (s *scene) SaveBinary(w io.Writer) error {
+	f, err := s.snapshot()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(binaryMagic); err != nil {
+		return fmt.Errorf("scene: failed to write scene header: %w", err)
+	}
+	if err := gob.NewEncoder(w).Encode(f); err != nil {
+		return fmt.Errorf("scene: failed to encode scene: %w", err)
+	}
+	return nil
+}
+
+func (s *scene) Load(r io.Reader, l loader.Loader, shaders map[string]shader.Shader) error {
+	var f sceneFile
+	br := bufio.NewReader(r)
+	if header, err := br.Peek(len(binaryMagic)); err == nil && bytes.Equal(header, binaryMagic) {
+		if _, err := br.Discard(len(binaryMagic)); err != nil {
+			return fmt.Errorf("scene: failed to read scene header: %w", err)
+		}
+		if err := gob.NewDecoder(br).Decode(&f); err != nil {
+			return fmt.Errorf("scene: failed to decode scene: %w", err)
+		}
+	} else if err := json.NewDecoder(br).Decode(&f); err != nil {
+		return fmt.Errorf("scene: failed to decode scene: %w", err)
+	}
+
+	if f.Version < 1 || f.Version > FileVersion {
+		return fmt.Errorf("scene: unsupported scene file version %d (supported: 1-%d)", f.Version, FileVersion)
+	}
+
+	// Resolve every model and shader before touching the scene so a bad file leaves it intact.
+	type resolvedModel struct {
+		mdl                       model.Model
+		compute, vertex, fragment shader.Shader
+	}
+	models := make(map[string]resolvedModel, len(f.Models))
+	for _, mf := range f.Models {
+		var rm resolvedModel
+		for _, ref := range []struct {
+			key string
+			dst *shader.Shader
+		}{
+			{mf.Shaders.Compute, &rm.compute},
+			{mf.Shaders.Vertex, &rm.vertex},
+			{mf.Shaders.Fragment, &rm.fragment},
+		} {
+			sh, ok := shaders[ref.key]
+			if !ok || sh == nil {
+				return fmt.Errorf("scene: model %q references unknown shader %q", mf.Path, ref.key)
+			}
+			*ref.dst = sh
+		}
+
+		rm.mdl = l.Get(mf.Path)
+		if rm.mdl == nil {
+			mdl, err := l.Load(mf.Path, rm.fragment)
+			if err != nil {
+				return fmt.Errorf("scene: failed to load model %q: %w", mf.Path, err)
+			}
+			rm.mdl = mdl
+		}
+		models[mf.Path] = rm
+	}
+	ids := make(map[uint64]bool, len(f.Objects))
+	for _, of := range f.Objects {
+		if _, ok := models[of.Model]; !ok {
+			return fmt.Errorf("scene: object %d references unknown model %q", of.ID, of.Model)
+		}
+		ids[of.ID] = true
+	}
+	parents := make(map[uint64]uint64, len(f.Objects))
+	for _, of := range f.Objects {
+		if of.Parent != 0 && !ids[of.Parent] {
+			return fmt.Errorf("scene: object %d references unknown parent %d", of.ID, of.Parent)
+		}
+		parents[of.ID] = of.Parent
+	}
+	for id := range parents {
+		for p, depth := parents[id], 0; p != 0; p, depth = parents[p], depth+1 {
+			if p == id || depth > len(parents) {
+				return fmt.Errorf("scene: object %d is its own ancestor", id)
+			}
+		}
+	}
+
+	lights := make([]light.Light, len(f.Lights))
+	attached := make(map[uint64]light.Light)
+	for i, lf := range f.Lights {
+		lt, err := parseLightType(lf.Type)
+		if err != nil {
+			return err
+		}
+		lights[i] = light.NewLight(lt,
+			light.WithPosition(lf.Position[0], lf.Position[1], lf.Position[2]),
+			light.WithDirection(lf.Direction[0], lf.Direction[1], lf.Direction[2]),
+			light.WithColor(lf.Color[0], lf.Color[1], lf.Color[2]),
+			light.WithIntensity(lf.Intensity),
+			light.WithRange(lf.Range),
+			light.WithSpotCone(lf.InnerConeDeg, lf.OuterConeDeg),
+			light.WithEnabled(lf.Enabled),
+			light.WithCastsShadows(lf.CastsShadows),
+		)
+		if lf.Object != 0 {
+			attached[lf.Object] = lights[i]
+		}
+	}
+
+	s.mu.Lock()
+	s.animatorPool = make(map[model.Model][]animator.Animator)
+	s.modelShaders = make(map[model.Model]modelShaderKeys)
+	s.registry = make(map[uint64]game_object.GameObject)
+	s.lightObjects = nil
+	s.lights = nil
+
+	s.name = f.Name
+	s.ambientColor = f.AmbientColor
+	s.shadowDistance = light.DefaultShadowDistance
+	if f.Shadow.Distance > 0 {
+		s.shadowDistance = f.Shadow.Distance
+	}
+	s.shadowSplitLambda = light.DefaultShadowSplitLambda
+	s.shadowCascadeBlend = light.DefaultShadowCascadeBlend
+	if f.Version >= 2 {
+		s.shadowSplitLambda = f.Shadow.SplitLambda
+		s.shadowCascadeBlend = f.Shadow.CascadeBlend
+	}
+	s.shadowNear = f.Shadow.Near
+	s.shadowFar = f.Shadow.Far
+	s.shadowBias = f.Shadow.Bias
+	s.shadowNormalBiasScale = f.Shadow.NormalBiasScale
+	// The shadow depth textures are sized once by InitShadowMap; a new resolution,
+	// cascade count, or atlas layout only applies if the shadow maps have not been created yet.
+	if s.shadowDepthTexture == nil && f.Shadow.MapResolution > 0 {
+		s.shadowMapResolution = f.Shadow.MapResolution
+	}
+	if s.shadowDepthTexture == nil && f.Shadow.Cascades > 0 {
+		s.shadowCascades = min(f.Shadow.Cascades, light.MaxShadowCascades)
+	}
+	if s.shadowAtlasTexture == nil && f.Shadow.AtlasResolution > 0 && f.Shadow.AtlasTileSize > 0 {
+		s.shadowAtlasResolution = f.Shadow.AtlasResolution
+		s.shadowAtlasTileSize = min(f.Shadow.AtlasTileSize, f.Shadow.AtlasResolution)
+	}
+	s.shadowAtlasBudget = light.DefaultShadowAtlasBudget
+	if f.Version >= 3 {
+		s.shadowAtlasBudget = max(f.Shadow.AtlasBudget, 0)
+	}
+
+	objects := make([]game_object.GameObject, len(f.Objects))
+	byID := make(map[uint64]game_object.GameObject, len(f.Objects))
+	for i, of := range f.Objects {
+		rm := models[of.Model]
+		opts := []game_object.GameObjectBuilderOption{
+			game_object.WithID(of.ID),
+			game_object.WithEnabled(of.Enabled),
+			game_object.WithModel(rm.mdl),
+			game_object.WithPosition(of.Position[0], of.Position[1], of.Position[2]),
+			game_object.WithRotation(of.Rotation[0], of.Rotation[1], of.Rotation[2]),
+			game_object.WithRotationSpeed(of.RotationSpeed[0], of.RotationSpeed[1], of.RotationSpeed[2]),
+			game_object.WithScale(of.Scale[0], of.Scale[1], of.Scale[2]),
+		}
+		if lt, ok := attached[of.ID]; ok {
+			opts = append(opts, game_object.WithLight(lt))
+		}
+		objects[i] = game_object.NewGameObject(opts...)
+		byID[of.ID] = objects[i]
+	}
+
+	// Link parents before adding so every object enters its animator at its world transform.
+	// SetParent keeps the world transform, so the saved local transform is re-applied after it.
+	for i, of := range f.Objects {
+		if of.Parent == 0 {
+			continue
+		}
+		obj := objects[i]
+		obj.SetParent(byID[of.Parent])
+		obj.SetPosition(of.Position[0], of.Position[1], of.Position[2])
+		obj.SetRotation(of.Rotation[0], of.Rotation[1], of.Rotation[2])
+		obj.SetScale(of.Scale[0], of.Scale[1], of.Scale[2])
+	}
+
+	for i, of := range f.Objects {
+		rm := models[of.Model]
+		s.addLocked(objects[i], rm.compute, rm.vertex, rm.fragment)
+		s.reserveIDsThrough(of.ID)
+	}
+
+	// addLocked registers attached lights in object order; restore the saved light order.
+	s.lights = lights
+	cam := s.cam
+	s.mu.Unlock()
+
+	if cam != nil {
+		applyCameraFile(cam, f.Camera)
+	}
+	return nil
+}
+
+// snapshot captures the scene's persistent state as a sceneFile. Ephemeral objects and
+// ephemeral lights are skipped.
+//
+// Returns:
+//   - sceneFile: the captured scene document
+//   - error: error if a persisted object's Model has no loader path
+func (s *scene) snapshot() (sceneFile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f := sceneFile{
+		Version:      FileVersion,
+		Name:         s.name,
+		AmbientColor: s.ambientColor,
+		Shadow: shadowFile{
+			Distance:        s.shadowDistance,
+			Cascades:        s.shadowCascades,
+			SplitLambda:     s.shadowSplitLambda,
+			CascadeBlend:    s.shadowCascadeBlend,
+			Near:            s.shadowNear,
+			Far:             s.shadowFar,
+			Bias:            s.shadowBias,
+			NormalBiasScale: s.shadowNormalBiasScale,
+			MapResolution:   s.shadowMapResolution,
+			AtlasResolution: s.shadowAtlasResolution,
+			AtlasTileSize:   s.shadowAtlasTileSize,
+			AtlasBudget:     s.shadowAtlasBudget,
+		},
+	}
+	if s.cam != nil {
+		f.Camera = captureCameraFile(s.cam)
+	}
+
+	ids := make([]uint64, 0, len(s.registry))
+	for id := range s.registry {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	seenModels := make(map[string]bool)
+	for _, id := range ids {
+		obj := s.registry[id]
+		mdl := obj.Model()
+		path := mdl.SourcePath()
+		if path == "" {
+			return sceneFile{}, fmt.Errorf("scene: object %d uses model %q which was not produced by a Loader", id, mdl.Name())
+		}
+		if !seenModels[path] {
+			seenModels[path] = true
+			f.Models = append(f.Models, modelFile{Path: path, Shaders: s.modelShaders[mdl]})
+		}
+
+		pos, scale, rot, rotSpeed := obj.TransformData()
+		var parentID uint64
+		if parent := obj.Parent(); parent != nil {
+			if saved, ok := s.registry[parent.ID()]; ok && saved == parent {
+				parentID = parent.ID()
+			} else {
+				// The parent is not saved, so store the world transform and load the object as a root.
+				pos[0], pos[1], pos[2] = obj.WorldPosition()
+				rot[0], rot[1], rot[2] = obj.WorldRotation()
+				scale[0], scale[1], scale[2] = obj.WorldScale()
+			}
+		}
+		f.Objects = append(f.Objects, objectFile{
+			ID:            id,
+			Parent:        parentID,
+			Model:         path,
+			Enabled:       obj.Enabled(),
+			Position:      pos,
+			Rotation:      rot,
+			RotationSpeed: rotSpeed,
+			Scale:         scale,
+		})
+	}
+
+	owners := make(map[light.Light]game_object.GameObject, len(s.lightObjects))
+	for _, obj := range s.lightObjects {
+		owners[obj.Light()] = obj
+	}
+	for _, l := range s.lights {
+		if l.Ephemeral() {
+			continue
+		}
+		lf := captureLightFile(l)
+		if owner, ok := owners[l]; ok {
+			if owner.Ephemeral() {
+				continue
+			}
+			lf.Object = owner.ID()
+		}
+		f.Lights = append(f.Lights, lf)
+	}
+
+	return f, nil
+}
+
+// captureCameraFile reads a camera's projection settings and controller state.
+//
+// Parameters:
+//   - cam: the camera to capture
+//
+// Returns:
+//   - cameraFile: the captured camera state
+func captureCameraFile(cam camera.Camera) cameraFile {
+	var cf cameraFile
+	cf.Up[0], cf.Up[1], cf.Up[2] = cam.Up()
+	cf.Orthographic = cam.Projection() == camera.ProjectionOrthographic
+	cf.Fov = cam.Fov()
+	cf.OrthoSize = cam.OrthoSize()
+	cf.Near = cam.Near()
+	cf.Far = cam.Far()
+
+	if ctrl := cam.Controller(); ctrl != nil {
+		c := &controllerFile{
+			Radius:           ctrl.Radius(),
+			Azimuth:          ctrl.Azimuth(),
+			Elevation:        ctrl.Elevation(),
+			MinRadius:        ctrl.MinRadius(),
+			MaxRadius:        ctrl.MaxRadius(),
+			MinElevation:     ctrl.MinElevation(),
+			MaxElevation:     ctrl.MaxElevation(),
+			OrbitSpeed:       ctrl.OrbitSpeed(),
+			MouseSensitivity: ctrl.MouseSensitivity(),
+			ZoomSpeed:        ctrl.ZoomSpeed(),
+			PanSpeed:         ctrl.PanSpeed(),
+		}
+		c.Position[0], c.Position[1], c.Position[2] = ctrl.Position()
+		c.Target[0], c.Target[1], c.Target[2] = ctrl.Target()
+		cf.Controller = c
+	}
+	return cf
+}
+
+// applyCameraFile restores a camera's projection settings and controller state. The existing
+// controller is updated in place so callers holding a reference keep driving the same camera;
+// a new controller is created if the camera has none.
+//
+// Parameters:
+//   - cam: the camera to update
+//   - cf: the saved camera state
+func applyCameraFile(cam camera.Camera, cf cameraFile) {
+	cam.SetUp(cf.Up[0], cf.Up[1], cf.Up[2])
+	if cf.Orthographic {
+		cam.SetProjection(camera.ProjectionOrthographic)
+	} else {
+		cam.SetProjection(camera.ProjectionPerspective)
+	}
+	cam.SetFov(cf.Fov)
+	if cf.OrthoSize > 0 {
+		cam.SetOrthoSize(cf.OrthoSize)
+	}
+	cam.SetNear(cf.Near)
+	cam.SetFar(cf.Far)
+
+	c := cf.Controller
+	if c == nil {
+		return
+	}
+	ctrl := cam.Controller()
+	if ctrl == nil {
+		ctrl = camera.NewCameraController()
+		cam.SetController(ctrl)
+	}
+	// Bounds first so the radius and elevation below are not clamped to the old range.
+	ctrl.SetRadiusBounds(c.MinRadius, c.MaxRadius)
+	ctrl.SetElevationBounds(c.MinElevation, c.MaxElevation)
+	ctrl.SetOrbitSpeed(c.OrbitSpeed)
+	ctrl.SetMouseSensitivity(c.MouseSensitivity)
+	ctrl.SetZoomSpeed(c.ZoomSpeed)
+	ctrl.SetPanSpeed(c.PanSpeed)
+	ctrl.SetTarget(c.Target[0], c.Target[1], c.Target[2])
+	ctrl.SetAzimuth(c.Azimuth)
+	ctrl.SetElevation(c.Elevation)
+	ctrl.SetRadius(c.Radius)
+	// Panning moves the position without touching the orbit angles, so restore it last.
+	ctrl.SetPosition(c.Position[0], c.Position[1], c.Position[2])
+}
+
+// captureLightFile reads a light's properties, converting its stored cone cosines back to degrees.
+//
+// Parameters:
+//   - l: the light to capture
+//
+// Returns:
+//   - lightFile: the captured light
+func captureLightFile(l light.Light) lightFile {
+	return lightFile{
+		Type:         lightTypeNames[l.Type()],
+		Position:     l.Position(),
+		Direction:    l.Direction(),
+		Color:        l.Color(),
+		Intensity:    l.Intensity(),
+		Range:        l.Range(),
+		InnerConeDeg: coneDegrees(l.InnerCone()),
+		OuterConeDeg: coneDegrees(l.OuterCone()),
+		Enabled:      l.Enabled(),
+		CastsShadows: l.CastsShadows(),
+	}
+}
+
+// parseLightType resolves a light type name from a scene file.
+//
+// Parameters:
+//   - name: the light type name
+//
+// Returns:
+//   - light.LightType: the matching light type
+//   - error: error if the name is unknown
+func parseLightType(name string) (light.LightType, error) {
+	for lt, n := range lightTypeNames {
+		if n == name {
+			return lt, nil
+		}
+	}
+	return 0, fmt.Errorf("scene: unknown light type %q", name)
+}
+
+// coneDegrees converts a cone cosine to its half-angle in degrees.
+//
+// Parameters:
+//   - cos: the cosine of the cone half-angle
+//
+// Returns:
+//   - float32: the half-angle in degrees
+func coneDegrees(cos float32) float32 {
+	c := math.Max(-1, math.Min(1, float64(cos)))
+	return float32(math.Acos(c) * 180 / math.Pi)
+}
@@ -0,0 +1,316 @@
+package scene
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/game_object"
+	"github.com/Carmen-Shannon/oxy-go/engine/model"
+)
+
+// Vertex strides of the mesh formats produced by the loader; positions are the first three floats.
+var (
+	vertexStride        = (&model.GPUVertex{}).Size()
+	skinnedVertexStride = (&model.GPUSkinnedVertex{}).Size()
+)
+
+// SkinnedRaycastMode selects what a raycast tests skinned models against. Skinning runs on
+// the GPU, so the animated triangles are not available on the CPU.
+type SkinnedRaycastMode int
+
+const (
+	// SkinnedRaycastBindPose tests the exact triangles of the mesh in its bind pose.
+	SkinnedRaycastBindPose SkinnedRaycastMode = iota
+	// SkinnedRaycastBounds tests the animator's bounding sphere, which is sized to contain
+	// every animated pose. Hits report a sphere normal and no triangle.
+	SkinnedRaycastBounds
+)
+
+// RaycastHit describes a ray intersection with a scene object.
+type RaycastHit struct {
+	ObjectID      uint64     // ID of the object that was hit
+	Distance      float32    // distance from the ray origin along its direction
+	Position      [3]float32 // world-space hit point
+	Normal        [3]float32 // world-space surface normal, facing the ray origin
+	TriangleIndex int        // index of the triangle in the model's index data, or -1 for a bounds hit
+}
+
+// raycastConfig holds the options of a single raycast.
+type raycastConfig struct {
+	skinnedMode SkinnedRaycastMode
+	filter      func(obj game_object.GameObject) bool
+}
+
+// RaycastOption is a functional option for configuring a raycast.
+type RaycastOption func(*raycastConfig)
+
+// WithSkinnedRaycastMode sets what skinned models are tested against (default SkinnedRaycastBindPose).
+//
+// Parameters:
+//   - mode: the skinned raycast mode
+//
+// Returns:
+//   - RaycastOption: option function to apply
+func WithSkinnedRaycastMode(mode SkinnedRaycastMode) RaycastOption {
+	return func(c *raycastConfig) {
+		c.skinnedMode = mode
+	}
+}
+
+// WithRaycastFilter restricts a raycast to objects for which the filter returns true.
+//
+// Parameters:
+//   - filter: the predicate applied to each candidate object
+//
+// Returns:
+//   - RaycastOption: option function to apply
+func WithRaycastFilter(filter func(obj game_object.GameObject) bool) RaycastOption {
+	return func(c *raycastConfig) {
+		c.filter = filter
+	}
+}
+
+func (s *scene) Raycast(ray common.Ray, maxDist float32, opts ...RaycastOption) (RaycastHit, bool) {
+	hits := s.raycast(ray, maxDist, true, opts)
+	if len(hits) == 0 {
+		return RaycastHit{}, false
+	}
+	return hits[0], true
+}
+
+func (s *scene) RaycastAll(ray common.Ray, maxDist float32, opts ...RaycastOption) []RaycastHit {
+	return s.raycast(ray, maxDist, false, opts)
+}
+
+// raycast tests every enabled, non-ephemeral object against the ray: a bounding sphere test
+// per instance first, then exact triangle tests against the model's mesh data.
+//
+// Parameters:
+//   - ray: the world-space ray
+//   - maxDist: the maximum hit distance; values <= 0 are unlimited
+//   - closestOnly: whether to keep only the closest hit
+//   - opts: the raycast options
+//
+// Returns:
+//   - []RaycastHit: the hits sorted by distance
+func (s *scene) raycast(ray common.Ray, maxDist float32, closestOnly bool, opts []RaycastOption) []RaycastHit {
+	cfg := raycastConfig{skinnedMode: SkinnedRaycastBindPose}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if maxDist <= 0 {
+		maxDist = math.MaxFloat32
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var hits []RaycastHit
+	for id, obj := range s.registry {
+		if !obj.Enabled() || obj.Model() == nil {
+			continue
+		}
+		if cfg.filter != nil && !cfg.filter(obj) {
+			continue
+		}
+		hit, ok := raycastObject(ray, maxDist, obj, &cfg)
+		if !ok {
+			continue
+		}
+		hit.ObjectID = id
+		if closestOnly {
+			maxDist = hit.Distance
+			hits = hits[:0]
+		}
+		hits = append(hits, hit)
+	}
+
+	slices.SortFunc(hits, func(a, b RaycastHit) int {
+		switch {
+		case a.Distance < b.Distance:
+			return -1
+		case a.Distance > b.Distance:
+			return 1
+		}
+		return 0
+	})
+	return hits
+}
+
+// raycastObject tests a single object against the ray.
+//
+// Parameters:
+//   - ray: the world-space ray
+//   - maxDist: the maximum hit distance
+//   - obj: the object to test
+//   - cfg: the raycast options
+//
+// Returns:
+//   - RaycastHit: the closest hit on the object, without ObjectID
+//   - bool: true if the object was hit within maxDist
+func raycastObject(ray common.Ray, maxDist float32, obj game_object.GameObject, cfg *raycastConfig) (RaycastHit, bool) {
+	mdl := obj.Model()
+	world := obj.WorldMatrix()
+	center := [3]float32{world[12], world[13], world[14]}
+
+	radius := mdl.BoundingRadius()
+	useBounds := mdl.Skinned() && cfg.skinnedMode == SkinnedRaycastBounds
+	if useBounds {
+		if anim := obj.Animator(); anim != nil && anim.BoundingRadius() > 0 {
+			radius = anim.BoundingRadius()
+		}
+	}
+
+	// Broad phase: the bounding sphere scaled by the largest world axis scale.
+	if radius > 0 {
+		t, ok := ray.IntersectSphere(center, radius*maxAxisScale(world))
+		if !ok || t > maxDist {
+			return RaycastHit{}, false
+		}
+		if useBounds {
+			p := ray.At(t)
+			n := [3]float32{p[0] - center[0], p[1] - center[1], p[2] - center[2]}
+			if t == 0 {
+				n = [3]float32{-ray.Direction[0], -ray.Direction[1], -ray.Direction[2]}
+			}
+			return RaycastHit{Distance: t, Position: p, Normal: normalize(n), TriangleIndex: -1}, true
+		}
+	} else if useBounds {
+		return RaycastHit{}, false
+	}
+
+	// Narrow phase in model space. The local ray direction is not renormalized, so local
+	// hit distances equal world distances.
+	var inv [16]float32
+	if !common.Invert4(inv[:], world[:]) {
+		return RaycastHit{}, false
+	}
+	local := ray.Transform(inv[:])
+
+	stride := vertexStride
+	if mdl.Skinned() {
+		stride = skinnedVertexStride
+	}
+	vertices := mdl.VertexData()
+	indices := mdl.IndexData()
+	vertexCount := len(vertices) / stride
+
+	triCount := vertexCount / 3
+	if len(indices) > 0 {
+		triCount = len(indices) / 12
+	}
+
+	best := maxDist
+	bestTri := -1
+	var bestNormal [3]float32
+	for tri := range triCount {
+		var idx [3]int
+		for k := range idx {
+			if len(indices) > 0 {
+				idx[k] = int(binary.LittleEndian.Uint32(indices[(tri*3+k)*4:]))
+			} else {
+				idx[k] = tri*3 + k
+			}
+		}
+		if idx[0] >= vertexCount || idx[1] >= vertexCount || idx[2] >= vertexCount {
+			continue
+		}
+		a := readPosition(vertices, idx[0]*stride)
+		b := readPosition(vertices, idx[1]*stride)
+		c := readPosition(vertices, idx[2]*stride)
+		t, ok := local.IntersectTriangle(a, b, c)
+		if !ok || t > best {
+			continue
+		}
+		best = t
+		bestTri = tri
+		bestNormal = triangleNormal(a, b, c)
+	}
+	if bestTri < 0 {
+		return RaycastHit{}, false
+	}
+
+	// Normals transform by the inverse transpose of the world matrix.
+	n := [3]float32{
+		inv[0]*bestNormal[0] + inv[1]*bestNormal[1] + inv[2]*bestNormal[2],
+		inv[4]*bestNormal[0] + inv[5]*bestNormal[1] + inv[6]*bestNormal[2],
+		inv[8]*bestNormal[0] + inv[9]*bestNormal[1] + inv[10]*bestNormal[2],
+	}
+	n = normalize(n)
+	if n[0]*ray.Direction[0]+n[1]*ray.Direction[1]+n[2]*ray.Direction[2] > 0 {
+		n = [3]float32{-n[0], -n[1], -n[2]}
+	}
+
+	return RaycastHit{
+		Distance:      best,
+		Position:      ray.At(best),
+		Normal:        n,
+		TriangleIndex: bestTri,
+	}, true
+}
+
+// readPosition decodes the position at the start of a vertex.
+//
+// Parameters:
+//   - data: the raw vertex data
+//   - offset: the byte offset of the vertex
+//
+// Returns:
+//   - [3]float32: the model-space position
+func readPosition(data []byte, offset int) [3]float32 {
+	return [3]float32{
+		math.Float32frombits(binary.LittleEndian.Uint32(data[offset:])),
+		math.Float32frombits(binary.LittleEndian.Uint32(data[offset+4:])),
+		math.Float32frombits(binary.LittleEndian.Uint32(data[offset+8:])),
+	}
+}
+
+// triangleNormal returns the unnormalized face normal of a counter-clockwise triangle.
+//
+// Parameters:
+//   - a, b, c: the triangle vertices
+//
+// Returns:
+//   - [3]float32: the face normal
+func triangleNormal(a, b, c [3]float32) [3]float32 {
+	e1 := [3]float32{b[0] - a[0], b[1] - a[1], b[2] - a[2]}
+	e2 := [3]float32{c[0] - a[0], c[1] - a[1], c[2] - a[2]}
+	return [3]float32{
+		e1[1]*e2[2] - e1[2]*e2[1],
+		e1[2]*e2[0] - e1[0]*e2[2],
+		e1[0]*e2[1] - e1[1]*e2[0],
+	}
+}
+
+// maxAxisScale returns the length of the longest basis vector of a column-major matrix.
+//
+// Parameters:
+//   - m: the world matrix
+//
+// Returns:
+//   - float32: the largest axis scale
+func maxAxisScale(m [16]float32) float32 {
+	var out float32
+	for col := range 3 {
+		x, y, z := m[col*4], m[col*4+1], m[col*4+2]
+		out = max(out, float32(math.Sqrt(float64(x*x+y*y+z*z))))
+	}
+	return out
+}
+
+// normalize returns v scaled to unit length, or v unchanged if it has zero length.
+//
+// Parameters:
+//   - v: the vector
+//
+// Returns:
+//   - [3]float32: the normalized vector
+func normalize(v [3]float32) [3]float32 {
+	l := float32(math.Sqrt(float64(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])))
+	if l == 0 {
+		return v
+	}
+	return [3]float32{v[0] / l, v[1] / l, v[2] / l}
+}
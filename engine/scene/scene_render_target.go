@@ -0,0 +1,151 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/model"
+	"github.com/Carmen-Shannon/oxy-go/engine/render_target"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/material"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// renderTargetSampler samples render targets bound through a render_target provider group.
+// A render target has a single mip level, so no mip filter is needed.
+var renderTargetSampler = common.SamplerStagingData{
+	AddressModeU:  wgpu.AddressModeClampToEdge,
+	AddressModeV:  wgpu.AddressModeClampToEdge,
+	AddressModeW:  wgpu.AddressModeClampToEdge,
+	MagFilter:     wgpu.FilterModeLinear,
+	MinFilter:     wgpu.FilterModeLinear,
+	MipmapFilter:  wgpu.MipmapFilterModeLinear,
+	LodMaxClamp:   32,
+	MaxAnisotropy: 1,
+}
+
+func (s *scene) RenderTarget() render_target.RenderTarget {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.renderTarget
+}
+
+func (s *scene) BindRenderTarget(mdl model.Model, rt render_target.RenderTarget) error {
+	if mdl == nil || rt == nil {
+		return fmt.Errorf("scene %q: BindRenderTarget requires a model and a render target", s.name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, mat := range mdl.RenderMaterials() {
+		if mat.PipelineKey() == "" {
+			continue
+		}
+		rp := s.r.Pipeline(mat.PipelineKey())
+		if rp == nil {
+			return fmt.Errorf("scene %q: pipeline %q is not registered", s.name, mat.PipelineKey())
+		}
+		frag := rp.Shader(shader.ShaderTypeFragment)
+		if frag == nil {
+			continue
+		}
+		if err := s.bindRenderTarget(mat, frag, rt); err != nil {
+			return fmt.Errorf("scene %q: failed to bind render target %q: %w", s.name, rt.Name(), err)
+		}
+	}
+	return nil
+}
+
+// bindRenderTarget binds a render target to one material, through the fragment shader's
+// render_target provider group if it declares one and otherwise in place of the material's
+// diffuse texture. Must be called with s.mu held.
+//
+// Parameters:
+//   - mat: the material to bind the render target to
+//   - frag: the fragment shader of the material's pipeline
+//   - rt: the render target
+//
+// Returns:
+//   - error: error if the shader has neither binding or GPU resource creation fails
+func (s *scene) bindRenderTarget(mat material.Material, frag shader.Shader, rt render_target.RenderTarget) error {
+	rtGroup, diffuseGroup, diffuseBinding := -1, -1, -1
+	for _, decl := range frag.Declarations() {
+		if decl.Type != shader.AnnotationTypeProvider || decl.Group == nil {
+			continue
+		}
+		switch {
+		case decl.Args[0] == shader.AnnotationArgRenderTarget && rtGroup < 0:
+			rtGroup = *decl.Group
+		case decl.Args[0] == shader.AnnotationArgMaterial && len(decl.Args) > 1 &&
+			decl.Args[1] == shader.AnnotationArgDiffuseTexture && decl.Binding != nil:
+			diffuseGroup, diffuseBinding = *decl.Group, *decl.Binding
+		}
+	}
+
+	switch {
+	case rtGroup >= 0:
+		// A render_target provider group gets a provider of its own, so the material keeps its textures.
+		bgp := bind_group_provider.NewBindGroupProvider(s.name + "_render_target_" + rt.Name())
+		for _, decl := range frag.Declarations() {
+			if decl.Type != shader.AnnotationTypeProvider || decl.Args[0] != shader.AnnotationArgRenderTarget ||
+				len(decl.Args) < 2 || decl.Binding == nil || *decl.Group != rtGroup {
+				continue
+			}
+			switch decl.Args[1] {
+			case shader.AnnotationArgRenderTargetTexture:
+				view, err := rt.NewTextureView()
+				if err != nil {
+					bgp.Release()
+					return err
+				}
+				bgp.SetTextureView(*decl.Binding, view)
+			case shader.AnnotationArgRenderTargetSampler:
+				if err := s.r.InitSampler(bgp, *decl.Binding, renderTargetSampler); err != nil {
+					bgp.Release()
+					return err
+				}
+			}
+		}
+		if err := s.r.InitBindGroup(bgp, frag.BindGroupLayoutDescriptor(rtGroup), nil, nil); err != nil {
+			bgp.Release()
+			return err
+		}
+
+		if s.renderTargetBGPs == nil {
+			s.renderTargetBGPs = make(map[material.Material]bind_group_provider.BindGroupProvider)
+		}
+		if old, ok := s.renderTargetBGPs[mat]; ok {
+			old.Release()
+		}
+		s.renderTargetBGPs[mat] = bgp
+		return nil
+
+	case diffuseBinding >= 0:
+		// Swap the diffuse texture view and rebuild the bind group around the material's other
+		// resources; InitBindGroup reuses the existing layout and buffers.
+		provider := mat.BindGroupProvider()
+		if provider == nil || provider.BindGroupLayout() == nil {
+			return fmt.Errorf("material %q has no GPU resources", mat.Name())
+		}
+		view, err := rt.NewTextureView()
+		if err != nil {
+			return err
+		}
+		if old := provider.TextureView(diffuseBinding); old != nil {
+			old.Release()
+		}
+		provider.SetTextureView(diffuseBinding, view)
+		oldBindGroup := provider.BindGroup()
+		if err := s.r.InitBindGroup(provider, frag.BindGroupLayoutDescriptor(diffuseGroup), nil, nil); err != nil {
+			return err
+		}
+		if oldBindGroup != nil {
+			oldBindGroup.Release()
+		}
+		return nil
+	}
+
+	return fmt.Errorf("fragment shader declares neither a render_target provider nor a material diffuse_texture")
+}
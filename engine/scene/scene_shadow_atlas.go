@@ -0,0 +1,99 @@
+package scene
+
+import (
+	"math"
+	"slices"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/light"
+)
+
+// shadowAtlasTile returns the texel rectangle of a shadow atlas tile. Tiles are laid out
+// row-major from the top-left corner of the atlas.
+//
+// Parameters:
+//   - tile: the tile index
+//
+// Returns:
+//   - x, y: top-left corner of the tile in texels
+//   - size: width and height of the tile in texels
+func (s *scene) shadowAtlasTile(tile int) (x, y, size uint32) {
+	cols := max(s.shadowAtlasResolution/s.shadowAtlasTileSize, 1)
+	size = uint32(s.shadowAtlasTileSize)
+	return uint32(tile%cols) * size, uint32(tile/cols) * size, size
+}
+
+// allocateShadowAtlas picks this frame's shadowed point and spot lights and assigns them
+// shadow atlas tiles. Enabled, shadow-casting lights are ranked by how close their range
+// comes to the camera and granted tiles in that order until the per-frame budget runs out;
+// a point light needs six tiles and a spot light one. Lights that do not fit are rendered
+// unshadowed. The result only depends on the lights and camera, so PrepareCompute and
+// PrepareShadows agree on it within a frame. Must be called with s.mu held.
+//
+// Returns:
+//   - []light.GPULocalShadow: one entry per allocated tile, indexed by tile
+//   - map[light.Light]int32: the first tile of each shadowed light
+func (s *scene) allocateShadowAtlas() ([]light.GPULocalShadow, map[light.Light]int32) {
+	if s.shadowAtlasView == nil || len(s.shadowAtlasBGPs) == 0 {
+		return nil, nil
+	}
+
+	var camX, camY, camZ float32
+	if s.cam != nil {
+		if ctrl := s.cam.Controller(); ctrl != nil {
+			camX, camY, camZ = ctrl.Position()
+		}
+	}
+
+	type candidate struct {
+		l        light.Light
+		distance float32
+	}
+	var candidates []candidate
+	for _, l := range s.lights {
+		if !l.Enabled() || !l.CastsShadows() || light.ShadowTileCount(l.Type()) == 0 || l.Range() <= 0 {
+			continue
+		}
+		p := l.Position()
+		dx, dy, dz := p[0]-camX, p[1]-camY, p[2]-camZ
+		dist := float32(math.Sqrt(float64(dx*dx + dy*dy + dz*dz)))
+		candidates = append(candidates, candidate{l: l, distance: max(dist-l.Range(), 0)})
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.distance < b.distance:
+			return -1
+		case a.distance > b.distance:
+			return 1
+		}
+		return 0
+	})
+
+	budget := min(s.shadowAtlasBudget, len(s.shadowAtlasBGPs))
+	atlasRes := float32(s.shadowAtlasResolution)
+	texelSize := 1.0 / atlasRes
+
+	var shadows []light.GPULocalShadow
+	indices := make(map[light.Light]int32)
+	for _, c := range candidates {
+		tiles := light.ShadowTileCount(c.l.Type())
+		if len(shadows)+tiles > budget {
+			continue // a cheaper light further down the list may still fit
+		}
+		viewProjs, tanHalfFov := light.LocalShadowViewProjections(c.l, light.DefaultLocalShadowNear)
+		indices[c.l] = int32(len(shadows))
+		for _, vp := range viewProjs {
+			x, y, size := s.shadowAtlasTile(len(shadows))
+			shadows = append(shadows, light.GPULocalShadow{
+				ViewProj:   vp,
+				AtlasRect:  [4]float32{float32(x) / atlasRes, float32(y) / atlasRes, float32(size) / atlasRes, float32(size) / atlasRes},
+				NormalBias: 2 * tanHalfFov / float32(size) * s.shadowNormalBiasScale,
+				Bias:       light.DefaultLocalShadowBias,
+				TexelSize:  texelSize,
+			})
+		}
+	}
+	return shadows, indices
+}
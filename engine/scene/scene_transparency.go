@@ -0,0 +1,206 @@
+package scene
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/model"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/animator"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/material"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/pipeline"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// transparentBatch is one blended material of one animator, deferred by DrawCalls until
+// every opaque and masked material has been drawn.
+type transparentBatch struct {
+	pipelineKey     string
+	meshProvider    bind_group_provider.BindGroupProvider
+	bindGroupStart  int // first entry of the batch's bind groups in scene.transparentBindGroups
+	bindGroupEnd    int
+	animator        animator.Animator
+	indirectBuffer  *wgpu.Buffer // GPU-culled instance count, nil when the animator is not culled
+	weightedBlended bool
+}
+
+// transparentDraw is a single instance of a sorted transparent batch.
+type transparentDraw struct {
+	batch    int
+	instance uint32
+	depth    float32
+}
+
+// materialPipelineKey resolves the render pipeline variant a material draws with. Opaque,
+// single-sided materials use the model's pipeline unchanged. Blended materials get a variant
+// without depth writes, either weighted blended (when the renderer and fragment shader
+// support it) or alpha blended, and double-sided materials get a variant without face
+// culling. Variants are derived from the base pipeline and registered on first use.
+// Must be called with s.mu held.
+//
+// Parameters:
+//   - mat: the material being drawn
+//   - base: the model's render pipeline
+//
+// Returns:
+//   - string: the pipeline key to draw the material with
+//   - error: error if the variant pipeline fails to register
+func (s *scene) materialPipelineKey(mat material.Material, base pipeline.Pipeline) (string, error) {
+	key := base.PipelineKey()
+	var opts []pipeline.PipelineBuilderOption
+
+	if mat.AlphaMode() == material.AlphaModeBlend {
+		if s.weightedBlended(base) {
+			key += "_oit"
+			opts = append(opts,
+				pipeline.WithWeightedBlended(true),
+				pipeline.WithFragmentEntryPoint(shader.WeightedBlendedEntryPoint),
+				pipeline.WithDepthWriteEnabled(false),
+			)
+		} else {
+			key += "_blend"
+			opts = append(opts,
+				pipeline.WithBlendEnabled(true),
+				pipeline.WithDepthWriteEnabled(false),
+			)
+		}
+	}
+	if mat.DoubleSided() && base.CullMode() != wgpu.CullModeNone {
+		key += "_double_sided"
+		opts = append(opts, pipeline.WithCullMode(wgpu.CullModeNone))
+	}
+
+	if len(opts) == 0 || s.r.Pipeline(key) != nil {
+		return key, nil
+	}
+	if err := s.r.RegisterPipelines(base.Derive(key, opts...)); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// weightedBlended reports whether blended materials drawn with the given pipeline go through
+// the weighted blended transparency pass instead of being sorted. Must be called with s.mu held.
+//
+// Parameters:
+//   - base: the model's render pipeline
+//
+// Returns:
+//   - bool: true if the renderer has order-independent transparency enabled and the fragment shader has a weighted blended entry point
+func (s *scene) weightedBlended(base pipeline.Pipeline) bool {
+	// The weighted blended targets belong to the main pass, so scenes rendering offscreen sort.
+	if !s.r.OrderIndependentTransparency() || s.renderTarget != nil {
+		return false
+	}
+	frag := base.Shader(shader.ShaderTypeFragment)
+	return frag != nil && frag.HasEntryPoint(shader.WeightedBlendedEntryPoint)
+}
+
+// sortsTransparency reports whether any of a model's materials is blended and drawn through
+// the sorted transparent pass. Sorted instances are drawn one at a time by index, so the
+// model's animator must not compact its output with GPU culling. Must be called with s.mu held.
+//
+// Parameters:
+//   - mdl: the model to check
+//
+// Returns:
+//   - bool: true if the model has a sorted blended material
+func (s *scene) sortsTransparency(mdl model.Model) bool {
+	if mdl == nil {
+		return false
+	}
+	for _, mat := range mdl.RenderMaterials() {
+		if mat.AlphaMode() != material.AlphaModeBlend {
+			continue
+		}
+		if base := s.r.Pipeline(mat.PipelineKey()); base != nil && !s.weightedBlended(base) {
+			return true
+		}
+	}
+	return false
+}
+
+// drawTransparent draws the blended batches collected by DrawCalls. Weighted blended batches
+// are accumulated in the order-independent transparency pass and composited in one go.
+// The remaining batches are expanded to one entry per visible instance, sorted back-to-front
+// by view depth and drawn one instance at a time. Must be called with s.mu held.
+//
+// Returns:
+//   - error: error if a draw call fails
+func (s *scene) drawTransparent() error {
+	if len(s.transparentBatches) == 0 {
+		return nil
+	}
+
+	hasOIT := false
+	for _, b := range s.transparentBatches {
+		if b.weightedBlended {
+			hasOIT = true
+			break
+		}
+	}
+	if hasOIT {
+		if err := s.r.BeginTransparentPass(); err != nil {
+			return fmt.Errorf("transparent pass failed in scene %q: %w", s.name, err)
+		}
+		for _, b := range s.transparentBatches {
+			if !b.weightedBlended {
+				continue
+			}
+			bindGroups := s.transparentBindGroups[b.bindGroupStart:b.bindGroupEnd]
+			var err error
+			if b.indirectBuffer != nil {
+				err = s.r.DrawCallIndirect(b.pipelineKey, b.meshProvider, b.indirectBuffer, bindGroups)
+			} else {
+				err = s.r.DrawCall(b.pipelineKey, b.meshProvider, uint32(b.animator.InstanceCount()), bindGroups)
+			}
+			if err != nil {
+				s.r.EndTransparentPass()
+				return fmt.Errorf("transparent draw call failed in scene %q: %w", s.name, err)
+			}
+		}
+		s.r.EndTransparentPass()
+	}
+
+	var view [16]float32
+	var frustum common.Frustum
+	hasFrustum := false
+	if s.cam != nil {
+		view = s.cam.ViewMatrix()
+		vp := s.cam.ViewProjectionMatrix()
+		frustum = common.ExtractFrustumFromMatrix(vp[:])
+		hasFrustum = !s.cullingDisabled
+	}
+
+	draws := s.transparentDraws[:0]
+	for i, b := range s.transparentBatches {
+		if b.weightedBlended {
+			continue
+		}
+		radius := b.animator.BoundingRadius()
+		for inst := range uint32(b.animator.InstanceCount()) {
+			pos, scale := b.animator.InstanceTransform(inst)
+			if hasFrustum && !frustum.IntersectsSphere(pos, radius*max(scale[0], scale[1], scale[2])) {
+				continue
+			}
+			// The view matrix is column-major and looks down -Z, so distance in front of the camera is -z.
+			depth := -(view[2]*pos[0] + view[6]*pos[1] + view[10]*pos[2] + view[14])
+			draws = append(draws, transparentDraw{batch: i, instance: inst, depth: depth})
+		}
+	}
+	s.transparentDraws = draws
+
+	sort.SliceStable(draws, func(i, j int) bool {
+		return draws[i].depth > draws[j].depth
+	})
+	for _, d := range draws {
+		b := s.transparentBatches[d.batch]
+		bindGroups := s.transparentBindGroups[b.bindGroupStart:b.bindGroupEnd]
+		if err := s.r.DrawCallInstances(b.pipelineKey, b.meshProvider, d.instance, 1, bindGroups); err != nil {
+			return fmt.Errorf("transparent draw call failed in scene %q: %w", s.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// Viewport is a rectangle of the render target in normalized coordinates, with the origin at the
+// top-left corner and 1 spanning the full target width or height. It stays valid when the
+// target is resized.
+type Viewport struct {
+	X      float32 `json:"x"`
+	Y      float32 `json:"y"`
+	Width  float32 `json:"width"`
+	Height float32 `json:"height"`
+}
+
+// FullViewport covers the whole render target. Scenes draw into it by default.
+var FullViewport = Viewport{X: 0, Y: 0, Width: 1, Height: 1}
+
+// Rect returns the viewport in pixels of a target of the given size, clamped to the target.
+//
+// Parameters:
+//   - width: the target width in pixels
+//   - height: the target height in pixels
+//
+// Returns:
+//   - float32: the left edge in pixels
+//   - float32: the top edge in pixels
+//   - float32: the width in pixels
+//   - float32: the height in pixels
+func (v Viewport) Rect(width, height float32) (float32, float32, float32, float32) {
+	x0 := min(max(v.X, 0), 1) * width
+	y0 := min(max(v.Y, 0), 1) * height
+	x1 := min(max(v.X+v.Width, 0), 1) * width
+	y1 := min(max(v.Y+v.Height, 0), 1) * height
+	return x0, y0, max(x1-x0, 0), max(y1-y0, 0)
+}
+
+// Aspect returns the width-to-height ratio of the viewport on a target of the given size, or 1
+// if the viewport or target is empty.
+//
+// Parameters:
+//   - width: the target width in pixels
+//   - height: the target height in pixels
+//
+// Returns:
+//   - float32: the aspect ratio
+func (v Viewport) Aspect(width, height int) float32 {
+	_, _, w, h := v.Rect(float32(width), float32(height))
+	if w <= 0 || h <= 0 {
+		return 1
+	}
+	return w / h
+}
+
+// Contains reports whether a window position lies inside the viewport on a target of the given
+// size. Use it with Rect to route input to the scene under the cursor, and pass the position
+// relative to Rect's origin together with its size to camera.Camera.ScreenPointToRay for picking.
+//
+// Parameters:
+//   - x: the horizontal position in pixels from the left edge
+//   - y: the vertical position in pixels from the top edge
+//   - width: the target width in pixels
+//   - height: the target height in pixels
+//
+// Returns:
+//   - bool: true if the position is inside the viewport
+func (v Viewport) Contains(x, y, width, height float32) bool {
+	rx, ry, rw, rh := v.Rect(width, height)
+	return x >= rx && x < rx+rw && y >= ry && y < ry+rh
+}
+
+func (s *scene) Viewport() Viewport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.viewport
+}
+
+func (s *scene) SetViewport(v Viewport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.viewport = v
+	s.syncAspect()
+}
+
+func (s *scene) Scissor() *Viewport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.scissor == nil {
+		return nil
+	}
+	rect := *s.scissor
+	return &rect
+}
+
+func (s *scene) SetScissor(rect *Viewport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rect == nil {
+		s.scissor = nil
+		return
+	}
+	r := *rect
+	s.scissor = &r
+}
+
+func (s *scene) ClearDepth() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clearDepth
+}
+
+func (s *scene) SetClearDepth(clear bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clearDepth = clear
+}
+
+func (s *scene) ClearColor() *wgpu.Color {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.clearColor == nil {
+		return nil
+	}
+	c := *s.clearColor
+	return &c
+}
+
+func (s *scene) SetClearColor(color *wgpu.Color) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if color == nil {
+		s.clearColor = nil
+		return
+	}
+	c := *color
+	s.clearColor = &c
+}
+
+func (s *scene) Resize(width, height int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// A scene rendering offscreen keeps the aspect ratio of its render target.
+	if s.renderTarget != nil {
+		return
+	}
+	if s.cam != nil && width > 0 && height > 0 {
+		s.cam.SetAspect(s.viewport.Aspect(width, height))
+	}
+}
+
+// targetSize returns the size of the target the scene draws into: its render target if it has
+// one, otherwise the renderer's current target. Must be called with s.mu held.
+//
+// Returns:
+//   - uint32: the target width in pixels
+//   - uint32: the target height in pixels
+func (s *scene) targetSize() (uint32, uint32) {
+	if s.renderTarget != nil {
+		width, height := s.renderTarget.Size()
+		return uint32(width), uint32(height)
+	}
+	return s.r.TargetSize()
+}
+
+// syncAspect sets the camera's aspect ratio to that of the viewport on the scene's target.
+// Must be called with s.mu held.
+func (s *scene) syncAspect() {
+	if s.cam == nil || s.r == nil {
+		return
+	}
+	width, height := s.targetSize()
+	if width == 0 || height == 0 {
+		return
+	}
+	s.cam.SetAspect(s.viewport.Aspect(int(width), int(height)))
+}
+
+// viewportRect returns the scene's viewport in pixels of the scene's target.
+// Must be called with s.mu held.
+//
+// Returns:
+//   - [4]float32: the left edge, top edge, width and height in pixels
+func (s *scene) viewportRect() [4]float32 {
+	width, height := s.targetSize()
+	x, y, w, h := s.viewport.Rect(float32(width), float32(height))
+	return [4]float32{x, y, w, h}
+}
+
+// applyViewport sets the scene's viewport and scissor rectangle on the renderer's frame pass and
+// clears the depth and color targets inside them when the scene asks for it. Every scene sets
+// both, so a scene drawn after a split-screen scene is not confined to its rectangle.
+// Must be called with s.mu held.
+//
+// Returns:
+//   - error: error if the viewport is empty or the clear fails
+func (s *scene) applyViewport() error {
+	width, height := s.targetSize()
+	vp := s.viewportRect()
+	if vp[2] <= 0 || vp[3] <= 0 {
+		return fmt.Errorf("scene %q has an empty viewport", s.name)
+	}
+	s.r.SetViewport(vp[0], vp[1], vp[2], vp[3])
+
+	scissor := s.viewport
+	if s.scissor != nil {
+		scissor = *s.scissor
+	}
+	x, y, w, h := scissor.Rect(float32(width), float32(height))
+	// Round to whole pixels without stepping outside the target.
+	x0, y0 := uint32(x+0.5), uint32(y+0.5)
+	x1, y1 := min(uint32(x+w+0.5), width), min(uint32(y+h+0.5), height)
+	s.r.SetScissorRect(x0, y0, max(x1, x0)-x0, max(y1, y0)-y0)
+
+	if s.clearDepth || s.clearColor != nil {
+		if err := s.r.ClearViewport(s.clearColor, s.clearDepth); err != nil {
+			return fmt.Errorf("viewport clear failed in scene %q: %w", s.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package sprite
+
+import (
+	"sync"
+)
+
+// LoopMode selects what an animation does after its last frame.
+type LoopMode int
+
+const (
+	// LoopRepeat starts over from the first frame.
+	LoopRepeat LoopMode = iota
+
+	// LoopOnce stops on the last frame and reports the animation finished.
+	LoopOnce
+
+	// LoopPingPong plays the frames backwards to the first frame and then forwards again.
+	LoopPingPong
+)
+
+// animationImpl is the implementation of the Animation interface.
+type animationImpl struct {
+	mu       *sync.Mutex
+	frames   []Region
+	fps      float32
+	loop     LoopMode
+	speed    float32
+	index    int
+	backward bool    // ping-pong direction
+	elapsed  float32 // time spent on the current frame in seconds
+	playing  bool
+	finished bool
+}
+
+// Animation defines the interface for sprite-sheet animation: a sequence of texture regions
+// shown at a fixed frame rate. An animation registered with Scene.AddSpriteAnimation is advanced
+// by the engine every tick, so it runs at the same rate whatever the render frame rate is and
+// follows the engine's pause and time scale; Tick advances it by hand otherwise. Its current
+// frame is drawn with SpriteBatch.Add. An Animation is safe for concurrent use.
+type Animation interface {
+	// Frames returns the frames of the animation.
+	//
+	// Returns:
+	//   - []Region: the frames in playing order
+	Frames() []Region
+
+	// Frame returns the frame currently shown.
+	//
+	// Returns:
+	//   - Region: the current frame, or the zero Region for an animation without frames
+	Frame() Region
+
+	// FrameIndex returns the index of the frame currently shown.
+	//
+	// Returns:
+	//   - int: the frame index
+	FrameIndex() int
+
+	// Tick advances the animation by a time step, skipping frames if the step is longer than a
+	// frame. Does nothing while the animation is paused or finished.
+	//
+	// Parameters:
+	//   - dt: the time step in seconds
+	Tick(dt float32)
+
+	// Play resumes the animation. A finished animation starts over.
+	Play()
+
+	// Pause stops the animation on its current frame.
+	Pause()
+
+	// Playing returns whether Tick advances the animation.
+	//
+	// Returns:
+	//   - bool: true if the animation is playing
+	Playing() bool
+
+	// Finished returns whether a LoopOnce animation has played its last frame.
+	//
+	// Returns:
+	//   - bool: true if the animation is finished
+	Finished() bool
+
+	// Reset returns to the first frame without changing whether the animation is playing.
+	Reset()
+
+	// Speed returns the playback speed multiplier.
+	//
+	// Returns:
+	//   - float32: the speed, 1 for the animation's frame rate
+	Speed() float32
+
+	// SetSpeed sets the playback speed multiplier.
+	//
+	// Parameters:
+	//   - speed: the speed, 1 for the animation's frame rate; negative values are clamped to 0
+	SetSpeed(speed float32)
+}
+
+var _ Animation = &animationImpl{}
+
+// NewAnimation creates a playing animation of texture regions, such as the frames returned by
+// Texture.Grid.
+//
+// Parameters:
+//   - frames: the frames in playing order
+//   - fps: the frame rate in frames per second
+//   - opts: variadic list of AnimationBuilderOption functions
+//
+// Returns:
+//   - Animation: a new Animation instance
+func NewAnimation(frames []Region, fps float32, opts ...AnimationBuilderOption) Animation {
+	a := &animationImpl{
+		mu:      &sync.Mutex{},
+		frames:  frames,
+		fps:     fps,
+		speed:   1,
+		playing: true,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func (a *animationImpl) Frames() []Region {
+	return a.frames
+}
+
+func (a *animationImpl) Frame() Region {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.frames) == 0 {
+		return Region{}
+	}
+	return a.frames[a.index]
+}
+
+func (a *animationImpl) FrameIndex() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.index
+}
+
+func (a *animationImpl) Tick(dt float32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.playing || a.finished || len(a.frames) < 2 || a.fps <= 0 {
+		return
+	}
+
+	frameTime := 1 / a.fps
+	a.elapsed += dt * a.speed
+	steps := int(a.elapsed / frameTime)
+	a.elapsed -= float32(steps) * frameTime
+
+	n := len(a.frames)
+	switch a.loop {
+	case LoopRepeat:
+		a.index = (a.index + steps) % n
+	case LoopOnce:
+		// The last frame is shown for a whole frame before the animation finishes.
+		if a.index+steps >= n {
+			a.index = n - 1
+			a.elapsed = 0
+			a.playing = false
+			a.finished = true
+			return
+		}
+		a.index += steps
+	case LoopPingPong:
+		// A full cycle visits every frame twice except the first and last.
+		for range steps % (2*n - 2) {
+			if a.backward && a.index == 0 || !a.backward && a.index == n-1 {
+				a.backward = !a.backward
+			}
+			if a.backward {
+				a.index--
+			} else {
+				a.index++
+			}
+		}
+	}
+}
+
+func (a *animationImpl) Play() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.finished {
+		a.reset()
+	}
+	a.playing = true
+}
+
+func (a *animationImpl) Pause() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.playing = false
+}
+
+func (a *animationImpl) Playing() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.playing
+}
+
+func (a *animationImpl) Finished() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.finished
+}
+
+func (a *animationImpl) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.reset()
+}
+
+func (a *animationImpl) Speed() float32 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.speed
+}
+
+func (a *animationImpl) SetSpeed(speed float32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.speed = max(speed, 0)
+}
+
+// reset returns to the first frame and clears the finished state. Caller must hold a.mu.
+func (a *animationImpl) reset() {
+	a.index = 0
+	a.elapsed = 0
+	a.backward = false
+	a.finished = false
+}
@@ -0,0 +1,42 @@
+package sprite
+
+// AnimationBuilderOption is a function that configures an Animation instance during construction.
+type AnimationBuilderOption func(*animationImpl)
+
+// WithLoopMode is an option builder that sets what the animation does after its last frame.
+// Defaults to LoopRepeat.
+//
+// Parameters:
+//   - mode: the loop mode
+//
+// Returns:
+//   - AnimationBuilderOption: a function that applies the loop mode option to an animationImpl
+func WithLoopMode(mode LoopMode) AnimationBuilderOption {
+	return func(a *animationImpl) {
+		a.loop = mode
+	}
+}
+
+// WithSpeed is an option builder that sets the playback speed multiplier. Defaults to 1.
+//
+// Parameters:
+//   - speed: the speed multiplier; negative values are clamped to 0
+//
+// Returns:
+//   - AnimationBuilderOption: a function that applies the speed option to an animationImpl
+func WithSpeed(speed float32) AnimationBuilderOption {
+	return func(a *animationImpl) {
+		a.speed = max(speed, 0)
+	}
+}
+
+// WithPaused is an option builder that creates the animation paused on its first frame.
+// Defaults to playing.
+//
+// Returns:
+//   - AnimationBuilderOption: a function that applies the paused option to an animationImpl
+func WithPaused() AnimationBuilderOption {
+	return func(a *animationImpl) {
+		a.playing = false
+	}
+}
@@ -0,0 +1,27 @@
+// Sprite fragment shader
+//
+// Samples the sprite's texture and multiplies it by the sprite's tint. Sprites of
+// textures marked for nearest sampling snap their UV to the center of the texel,
+// so the linear sampler returns that texel unfiltered. Alpha blends over the scene.
+//
+// Bind group layout:
+//   @group(1) sprite — sprite texture, sampler
+
+struct FragmentInput {
+    @location(0) uv: vec2<f32>,
+    @location(1) color: vec4<f32>,
+    @location(2) @interpolate(flat) flags: u32,
+};
+
+//@oxy:provider 1 0 sprite sprite_texture
+@group(1) @binding(0) var sprite_texture: texture_2d<f32>;
+//@oxy:provider 1 1 sprite sprite_sampler
+@group(1) @binding(1) var sprite_sampler: sampler;
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    let size = vec2<f32>(textureDimensions(sprite_texture));
+    let snapped = (floor(in.uv * size) + 0.5) / size;
+    let uv = select(in.uv, snapped, (in.flags & 1u) != 0u);
+    return textureSample(sprite_texture, sprite_sampler, uv) * in.color;
+}
@@ -0,0 +1,59 @@
+// Sprite vertex shader
+//
+// Pulls sprites from a storage buffer, six vertices per sprite, so a batch needs
+// no vertex buffer. Each quad is sized around its origin, rotated counter-clockwise
+// and placed in the world's XY plane with Y up, then projected with the camera's
+// view-projection.
+//
+// Bind group layout:
+//   @group(0) sprite — SpriteParams uniform, SpriteInstance storage array
+
+struct SpriteParams {
+    view_proj: mat4x4<f32>,
+};
+
+struct SpriteInstance {
+    position: vec4<f32>,     // xyz = world position of the origin, w = rotation in radians
+    size: vec4<f32>,         // xy = size in world units, zw = origin from (0, 0) bottom-left to (1, 1) top-right
+    uv: vec4<f32>,           // xy = texture UV of the top-left corner, zw = bottom-right
+    color: u32,
+    flags: u32,              // bit 0 = nearest sampling
+    _pad0: u32,
+    _pad1: u32,
+};
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) uv: vec2<f32>,
+    @location(1) color: vec4<f32>,
+    @location(2) @interpolate(flat) flags: u32,
+};
+
+//@oxy:provider 0 0 sprite
+@group(0) @binding(0) var<uniform> params: SpriteParams;
+//@oxy:provider 0 1 sprite
+@group(0) @binding(1) var<storage, read> sprites: array<SpriteInstance>;
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> VertexOutput {
+    let s = sprites[index / 6u];
+
+    // Two triangles: (0,0) (1,0) (0,1) and (1,0) (1,1) (0,1), from the bottom-left corner.
+    var corners = array<vec2<f32>, 6>(
+        vec2<f32>(0.0, 0.0), vec2<f32>(1.0, 0.0), vec2<f32>(0.0, 1.0),
+        vec2<f32>(1.0, 0.0), vec2<f32>(1.0, 1.0), vec2<f32>(0.0, 1.0),
+    );
+    let corner = corners[index % 6u];
+    let local = (corner - s.size.zw) * s.size.xy;
+    let c = cos(s.position.w);
+    let sn = sin(s.position.w);
+    let rotated = vec2<f32>(local.x * c - local.y * sn, local.x * sn + local.y * c);
+
+    var out: VertexOutput;
+    out.position = params.view_proj * vec4<f32>(s.position.xy + rotated, s.position.z, 1.0);
+    // Texture rows run top to bottom, so the bottom of the quad samples v1.
+    out.uv = vec2<f32>(mix(s.uv.x, s.uv.z, corner.x), mix(s.uv.w, s.uv.y, corner.y));
+    out.color = unpack4x8unorm(s.color);
+    out.flags = s.flags;
+    return out;
+}
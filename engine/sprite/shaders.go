@@ -0,0 +1,17 @@
+package sprite
+
+import (
+	_ "embed"
+)
+
+// VertexSource is the vertex shader of the sprite pass. It pulls each sprite from the
+// SpriteInstance storage buffer by vertex index and places its rotated quad in the world's XY plane.
+//
+//go:embed assets/sprite-vert.wgsl
+var VertexSource string
+
+// FragmentSource is the fragment shader of the sprite pass. It samples the sprite's texture region
+// and multiplies it by the sprite's tint.
+//
+//go:embed assets/sprite-frag.wgsl
+var FragmentSource string
@@ -0,0 +1,101 @@
+package sprite
+
+// spriteConfig holds the options of one SpriteBatch.Add call.
+type spriteConfig struct {
+	size     *[2]float32
+	rotation float32
+	tint     [4]float32
+	layer    int
+	origin   [2]float32
+	flipX    bool
+	flipY    bool
+}
+
+// SpriteOption configures the placement and appearance of a sprite.
+type SpriteOption func(*spriteConfig)
+
+// WithSize sets the size of the sprite in world units. Default: the region size in pixels divided
+// by the batch's pixels per unit.
+//
+// Parameters:
+//   - width: the width in world units
+//   - height: the height in world units
+//
+// Returns:
+//   - SpriteOption: the option
+func WithSize(width, height float32) SpriteOption {
+	return func(c *spriteConfig) {
+		c.size = &[2]float32{width, height}
+	}
+}
+
+// WithRotation rotates the sprite counter-clockwise around its origin. Default: 0.
+//
+// Parameters:
+//   - radians: the rotation in radians
+//
+// Returns:
+//   - SpriteOption: the option
+func WithRotation(radians float32) SpriteOption {
+	return func(c *spriteConfig) {
+		c.rotation = radians
+	}
+}
+
+// WithTint multiplies the sprite's texels by a color. Default: opaque white.
+//
+// Parameters:
+//   - color: the linear RGBA tint
+//
+// Returns:
+//   - SpriteOption: the option
+func WithTint(color [4]float32) SpriteOption {
+	return func(c *spriteConfig) {
+		c.tint = color
+	}
+}
+
+// WithLayer sets the sorting layer of the sprite. Sprites on higher layers are drawn over sprites
+// on lower layers. Default: 0.
+//
+// Parameters:
+//   - layer: the sorting layer
+//
+// Returns:
+//   - SpriteOption: the option
+func WithLayer(layer int) SpriteOption {
+	return func(c *spriteConfig) {
+		c.layer = layer
+	}
+}
+
+// WithOrigin sets the point of the sprite placed at its position and rotated around, from (0, 0)
+// at the bottom-left to (1, 1) at the top-right. Default: (0.5, 0.5), the center.
+//
+// Parameters:
+//   - x: the horizontal origin
+//   - y: the vertical origin
+//
+// Returns:
+//   - SpriteOption: the option
+func WithOrigin(x, y float32) SpriteOption {
+	return func(c *spriteConfig) {
+		c.origin = [2]float32{x, y}
+	}
+}
+
+// WithFlip mirrors the sprite's image horizontally, vertically or both, without moving its origin.
+// Default: no flip.
+//
+// Parameters:
+//   - x: whether to mirror left to right
+//   - y: whether to mirror top to bottom
+//
+// Returns:
+//   - SpriteOption: the option
+func WithFlip(x, y bool) SpriteOption {
+	return func(c *spriteConfig) {
+		c.flipX = x
+		c.flipY = y
+	}
+}
@@ -0,0 +1,204 @@
+package sprite
+
+import (
+	"sync"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/camera"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+)
+
+// defaultMaxSprites is the default capacity of the sprite buffer.
+const defaultMaxSprites = 16384
+
+// queuedSprite is one sprite queued for the next Draw.
+type queuedSprite struct {
+	texture  Texture
+	order    uint64 // upload order of the texture, the second sort key after the layer
+	layer    int
+	position [2]float32
+	size     [2]float32
+	origin   [2]float32
+	rotation float32
+	uv       [4]float32
+	color    uint32 // RGBA packed as four unorm8 values, red in the low byte
+}
+
+// batchTexture is the GPU state of a texture drawn by a batch.
+type batchTexture struct {
+	order uint64
+	bgp   bind_group_provider.BindGroupProvider
+}
+
+// spriteBatchImpl is the implementation of the SpriteBatch interface.
+type spriteBatchImpl struct {
+	mu            *sync.Mutex
+	maxSprites    int
+	pixelsPerUnit float32
+	sprites       []queuedSprite
+
+	r              renderer.Renderer
+	spriteBGP      bind_group_provider.BindGroupProvider
+	textures       map[Texture]*batchTexture
+	textureCount   uint64
+	textureBinding int // binding of the sprite texture in the fragment shader's group
+	samplerBinding int // binding of the sprite sampler in the fragment shader's group
+	spriteData     []byte
+}
+
+// SpriteBatch defines the interface for drawing textured quads in large batches. Sprites are
+// queued from render or tick callbacks and drawn at the end of a scene's geometry with the
+// scene's camera, usually an orthographic one.
+//
+// Sprites lie in the world's XY plane with Y up. They are sorted by layer, then grouped by
+// texture, and every run of sprites sharing a texture is one draw call over a single buffer
+// written once per frame. Sprites are alpha blended in that order without depth testing.
+// A SpriteBatch is safe for concurrent use.
+type SpriteBatch interface {
+	// Add queues a sprite for the next Draw.
+	//
+	// Parameters:
+	//   - region: the texture region to show
+	//   - x: the world X position of the sprite's origin
+	//   - y: the world Y position of the sprite's origin
+	//   - opts: variadic list of SpriteOption functions
+	Add(region Region, x, y float32, opts ...SpriteOption)
+
+	// Count returns the number of queued sprites.
+	//
+	// Returns:
+	//   - int: the number of queued sprites
+	Count() int
+
+	// Clear drops every queued sprite.
+	Clear()
+
+	// Draw uploads textures drawn for the first time, writes the queued sprites to the GPU in
+	// layer and texture order and draws them into the current render pass with the camera's
+	// view-projection, then drops them. Pipelines and buffers are created on the first call.
+	// Sprites beyond the sprite capacity are not drawn.
+	//
+	// Parameters:
+	//   - r: the renderer recording the current render pass
+	//   - cam: the camera to draw with
+	//
+	// Returns:
+	//   - error: an error if GPU resource creation or a draw fails
+	Draw(r renderer.Renderer, cam camera.Camera) error
+
+	// ReleaseTexture releases the GPU copy of a texture the batch no longer draws. It is
+	// uploaded again if it is drawn later.
+	//
+	// Parameters:
+	//   - tex: the texture to release
+	ReleaseTexture(tex Texture)
+
+	// Release releases the GPU resources, including every uploaded texture. They are recreated
+	// by the next Draw. The shared pipeline stays registered.
+	Release()
+}
+
+var _ SpriteBatch = &spriteBatchImpl{}
+
+// NewSpriteBatch creates a new SpriteBatch. GPU resources are created by the first Draw.
+//
+// Parameters:
+//   - opts: variadic list of SpriteBatchBuilderOption functions to configure the batch
+//
+// Returns:
+//   - SpriteBatch: a new SpriteBatch instance
+func NewSpriteBatch(opts ...SpriteBatchBuilderOption) SpriteBatch {
+	b := &spriteBatchImpl{
+		mu:             &sync.Mutex{},
+		maxSprites:     defaultMaxSprites,
+		pixelsPerUnit:  1,
+		textures:       make(map[Texture]*batchTexture),
+		textureBinding: -1,
+		samplerBinding: -1,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func (b *spriteBatchImpl) Add(region Region, x, y float32, opts ...SpriteOption) {
+	if region.Texture == nil {
+		return
+	}
+	cfg := spriteConfig{
+		tint:   [4]float32{1, 1, 1, 1},
+		origin: [2]float32{0.5, 0.5},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	uv := region.UV
+	if cfg.flipX {
+		uv[0], uv[2] = uv[2], uv[0]
+	}
+	if cfg.flipY {
+		uv[1], uv[3] = uv[3], uv[1]
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	size := [2]float32{region.Width / b.pixelsPerUnit, region.Height / b.pixelsPerUnit}
+	if cfg.size != nil {
+		size = *cfg.size
+	}
+	b.sprites = append(b.sprites, queuedSprite{
+		texture:  region.Texture,
+		layer:    cfg.layer,
+		position: [2]float32{x, y},
+		size:     size,
+		origin:   cfg.origin,
+		rotation: cfg.rotation,
+		uv:       uv,
+		color:    packColor(cfg.tint),
+	})
+}
+
+func (b *spriteBatchImpl) Count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.sprites)
+}
+
+func (b *spriteBatchImpl) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.dropSprites()
+}
+
+func (b *spriteBatchImpl) ReleaseTexture(tex Texture) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if bt, ok := b.textures[tex]; ok {
+		bt.bgp.Release()
+		delete(b.textures, tex)
+	}
+}
+
+func (b *spriteBatchImpl) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.releaseGPU()
+}
+
+// packColor packs a linear RGBA color into four unorm8 values with red in the low byte,
+// matching WGSL's unpack4x8unorm.
+//
+// Parameters:
+//   - c: the color, each channel clamped to [0, 1]
+//
+// Returns:
+//   - uint32: the packed color
+func packColor(c [4]float32) uint32 {
+	var packed uint32
+	for i, v := range c {
+		packed |= uint32(max(0, min(v, 1))*255+0.5) << (8 * i)
+	}
+	return packed
+}
@@ -0,0 +1,35 @@
+package sprite
+
+// SpriteBatchBuilderOption is a function that configures a SpriteBatch instance during construction.
+type SpriteBatchBuilderOption func(*spriteBatchImpl)
+
+// WithMaxSprites is an option builder that sets the capacity of the sprite buffer. Sprites beyond
+// it are not drawn. Defaults to 16384.
+//
+// Parameters:
+//   - n: the maximum number of sprites drawn per frame
+//
+// Returns:
+//   - SpriteBatchBuilderOption: a function that applies the sprite capacity option to a spriteBatchImpl
+func WithMaxSprites(n int) SpriteBatchBuilderOption {
+	return func(b *spriteBatchImpl) {
+		b.maxSprites = max(n, 1)
+	}
+}
+
+// WithPixelsPerUnit is an option builder that sets how many texture pixels make one world unit for
+// sprites added without WithSize. With an orthographic camera whose OrthoSize is the viewport
+// height in pixels, the default of 1 draws sprites at their pixel size. Defaults to 1.
+//
+// Parameters:
+//   - ppu: the texture pixels per world unit
+//
+// Returns:
+//   - SpriteBatchBuilderOption: a function that applies the pixels per unit option to a spriteBatchImpl
+func WithPixelsPerUnit(ppu float32) SpriteBatchBuilderOption {
+	return func(b *spriteBatchImpl) {
+		if ppu > 0 {
+			b.pixelsPerUnit = ppu
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package sprite
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/camera"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/pipeline"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// PipelineKey is the pipeline key of the sprite pass.
+const PipelineKey = "sprite"
+
+// Bindings of the sprite vertex shader's group 0.
+const (
+	paramsBinding = 0
+	spriteBinding = 1
+)
+
+// Groups of the sprite shaders: the vertex shader's params and sprites, and the fragment
+// shader's texture and sampler.
+const (
+	spriteGroup  = 0
+	textureGroup = 1
+)
+
+const (
+	// paramsSize is the size of SpriteParams in bytes: a view-projection matrix.
+	paramsSize = 64
+
+	// spriteSize is the size of a SpriteInstance in bytes.
+	spriteSize = 64
+
+	// verticesPerSprite is the number of vertices of a sprite quad, two triangles.
+	verticesPerSprite = 6
+)
+
+func (b *spriteBatchImpl) Draw(r renderer.Renderer, cam camera.Camera) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer b.dropSprites()
+
+	if len(b.sprites) == 0 || r == nil || cam == nil {
+		return nil
+	}
+	if err := b.initGPU(r); err != nil {
+		return err
+	}
+
+	// Textures are uploaded on first use; their upload order groups sprites by texture
+	// within a layer.
+	for i := range b.sprites {
+		bt, err := b.texture(b.sprites[i].texture)
+		if err != nil {
+			return err
+		}
+		b.sprites[i].order = bt.order
+	}
+	slices.SortStableFunc(b.sprites, func(x, y queuedSprite) int {
+		if c := cmp.Compare(x.layer, y.layer); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.order, y.order)
+	})
+
+	count := min(len(b.sprites), b.maxSprites)
+	data := b.spriteData[:0]
+	for _, s := range b.sprites[:count] {
+		data = appendSprite(data, s)
+	}
+	b.spriteData = data
+
+	viewProj := cam.ViewProjectionMatrix()
+	params := make([]byte, 0, paramsSize)
+	for _, v := range viewProj {
+		params = binary.LittleEndian.AppendUint32(params, math.Float32bits(v))
+	}
+	r.WriteBuffers([]bind_group_provider.BufferWrite{
+		{Provider: b.spriteBGP, Binding: paramsBinding, Offset: 0, Data: params},
+		{Provider: b.spriteBGP, Binding: spriteBinding, Offset: 0, Data: data},
+	})
+
+	// One draw per run of consecutive sprites sharing a texture.
+	for start := 0; start < count; {
+		end := start + 1
+		for end < count && b.sprites[end].texture == b.sprites[start].texture {
+			end++
+		}
+		bindGroups := []bind_group_provider.BindGroupProvider{b.spriteBGP, b.textures[b.sprites[start].texture].bgp}
+		first := uint32(start * verticesPerSprite)
+		if err := r.DrawProceduralRange(PipelineKey, first, uint32((end-start)*verticesPerSprite), bindGroups); err != nil {
+			return fmt.Errorf("sprite draw failed: %w", err)
+		}
+		start = end
+	}
+	return nil
+}
+
+// dropSprites drops the queued sprites and their texture references. Caller must hold b.mu.
+func (b *spriteBatchImpl) dropSprites() {
+	clear(b.sprites)
+	b.sprites = b.sprites[:0]
+}
+
+// initGPU registers the sprite pipeline and creates the sprite buffers on first use, or again
+// when drawing with a different renderer. Caller must hold b.mu.
+//
+// Parameters:
+//   - r: the renderer to create the resources on
+//
+// Returns:
+//   - error: an error if pipeline registration or bind group creation fails
+func (b *spriteBatchImpl) initGPU(r renderer.Renderer) error {
+	if b.spriteBGP != nil && b.r == r {
+		return nil
+	}
+	b.releaseGPU()
+
+	// RegisterPipelines skips keys that are already cached, so every SpriteBatch shares the
+	// pipeline registered by the first.
+	if err := r.RegisterPipelines(newSpritePipeline()); err != nil {
+		return fmt.Errorf("failed to register sprite pipeline: %w", err)
+	}
+
+	vert := r.Pipeline(PipelineKey).Shader(shader.ShaderTypeVertex)
+	spriteBGP := bind_group_provider.NewBindGroupProvider("sprite")
+	sizes := map[int]uint64{
+		paramsBinding: paramsSize,
+		spriteBinding: uint64(b.maxSprites) * spriteSize,
+	}
+	if err := r.InitBindGroup(spriteBGP, vert.BindGroupLayoutDescriptor(spriteGroup), nil, sizes); err != nil {
+		spriteBGP.Release()
+		return fmt.Errorf("failed to init sprite bind group: %w", err)
+	}
+
+	// The texture and sampler are located by their binding roles in the fragment shader.
+	b.textureBinding, b.samplerBinding = -1, -1
+	frag := r.Pipeline(PipelineKey).Shader(shader.ShaderTypeFragment)
+	for _, decl := range frag.Declarations() {
+		if decl.Type != shader.AnnotationTypeProvider || decl.Args[0] != shader.AnnotationArgSprite || len(decl.Args) < 2 || decl.Binding == nil {
+			continue
+		}
+		switch decl.Args[1] {
+		case shader.AnnotationArgSpriteTexture:
+			b.textureBinding = *decl.Binding
+		case shader.AnnotationArgSpriteSampler:
+			b.samplerBinding = *decl.Binding
+		}
+	}
+	if b.textureBinding < 0 || b.samplerBinding < 0 {
+		spriteBGP.Release()
+		return fmt.Errorf("sprite fragment shader does not declare sprite_texture and sprite_sampler bindings")
+	}
+
+	b.r = r
+	b.spriteBGP = spriteBGP
+	return nil
+}
+
+// texture returns the GPU state of a texture, uploading it and creating its bind group the
+// first time it is drawn. Caller must hold b.mu.
+//
+// Parameters:
+//   - tex: the texture
+//
+// Returns:
+//   - *batchTexture: the texture's GPU state
+//   - error: an error if the texture or bind group cannot be created
+func (b *spriteBatchImpl) texture(tex Texture) (*batchTexture, error) {
+	if bt, ok := b.textures[tex]; ok {
+		return bt, nil
+	}
+
+	bgp := bind_group_provider.NewBindGroupProvider("sprite_texture_" + tex.Name())
+	err := b.r.InitTextureView(bgp, b.textureBinding, common.TextureStagingData{
+		Pixels: tex.Pixels(),
+		Width:  uint32(tex.Width()),
+		Height: uint32(tex.Height()),
+	})
+	if err == nil {
+		err = b.r.InitSampler(bgp, b.samplerBinding, common.SamplerStagingData{
+			AddressModeU:  wgpu.AddressModeClampToEdge,
+			AddressModeV:  wgpu.AddressModeClampToEdge,
+			AddressModeW:  wgpu.AddressModeClampToEdge,
+			MagFilter:     wgpu.FilterModeLinear,
+			MinFilter:     wgpu.FilterModeLinear,
+			MipmapFilter:  wgpu.MipmapFilterModeLinear,
+			LodMaxClamp:   32,
+			MaxAnisotropy: 1,
+		})
+	}
+	if err == nil {
+		frag := b.r.Pipeline(PipelineKey).Shader(shader.ShaderTypeFragment)
+		err = b.r.InitBindGroup(bgp, frag.BindGroupLayoutDescriptor(textureGroup), nil, nil)
+	}
+	if err != nil {
+		bgp.Release()
+		return nil, fmt.Errorf("failed to upload sprite texture %q: %w", tex.Name(), err)
+	}
+
+	b.textureCount++
+	bt := &batchTexture{order: b.textureCount, bgp: bgp}
+	b.textures[tex] = bt
+	return bt, nil
+}
+
+// releaseGPU releases the sprite buffers and every uploaded texture. Caller must hold b.mu.
+func (b *spriteBatchImpl) releaseGPU() {
+	if b.spriteBGP != nil {
+		b.spriteBGP.Release()
+	}
+	for tex, bt := range b.textures {
+		bt.bgp.Release()
+		delete(b.textures, tex)
+	}
+	b.spriteBGP = nil
+	b.r = nil
+}
+
+// newSpritePipeline creates the pipeline drawing sprite quads with alpha blending and without
+// depth testing or writing, so sprites are layered in draw order.
+//
+// Returns:
+//   - pipeline.Pipeline: the pipeline to register
+func newSpritePipeline() pipeline.Pipeline {
+	return pipeline.NewPipeline(PipelineKey, pipeline.PipelineTypeRender,
+		pipeline.WithVertexShader(shader.NewShaderFromSource(PipelineKey+"_vert", shader.ShaderTypeVertex, VertexSource)),
+		pipeline.WithFragmentShader(shader.NewShaderFromSource(PipelineKey+"_frag", shader.ShaderTypeFragment, FragmentSource)),
+		pipeline.WithDepthTestEnabled(false),
+		pipeline.WithDepthWriteEnabled(false),
+		pipeline.WithBlendEnabled(true),
+		pipeline.WithCullMode(wgpu.CullModeNone),
+	)
+}
+
+// appendSprite appends one packed SpriteInstance.
+//
+// Parameters:
+//   - buf: the buffer to append to
+//   - s: the sprite
+//
+// Returns:
+//   - []byte: the buffer with the sprite appended
+func appendSprite(buf []byte, s queuedSprite) []byte {
+	var flags uint32
+	if s.texture.Nearest() {
+		flags = 1
+	}
+	for _, v := range [12]float32{
+		s.position[0], s.position[1], 0, s.rotation,
+		s.size[0], s.size[1], s.origin[0], s.origin[1],
+		s.uv[0], s.uv[1], s.uv[2], s.uv[3],
+	} {
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(v))
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, s.color)
+	buf = binary.LittleEndian.AppendUint32(buf, flags)
+	buf = binary.LittleEndian.AppendUint32(buf, 0)
+	return binary.LittleEndian.AppendUint32(buf, 0)
+}
@@ -0,0 +1,190 @@
+package sprite
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+)
+
+// Region is a rectangle of a texture that a sprite shows: a whole image, a frame of a sprite
+// sheet or an entry of a texture atlas.
+type Region struct {
+	Texture Texture
+	UV      [4]float32 // u0, v0 of the top-left corner and u1, v1 of the bottom-right
+	Width   float32    // width in texture pixels
+	Height  float32    // height in texture pixels
+}
+
+// Texture is an RGBA image that sprites are drawn from. It is uploaded to the GPU by the first
+// SpriteBatch draw that uses it, and can be shared by any number of batches.
+type Texture interface {
+	// Name returns the texture name: the file name without its extension for loaded textures.
+	//
+	// Returns:
+	//   - string: the texture name
+	Name() string
+
+	// Width returns the width of the texture in pixels.
+	//
+	// Returns:
+	//   - int: the width
+	Width() int
+
+	// Height returns the height of the texture in pixels.
+	//
+	// Returns:
+	//   - int: the height
+	Height() int
+
+	// Pixels returns the RGBA pixels of the texture, 4 bytes per pixel, rows top to bottom.
+	// The slice must not be modified.
+	//
+	// Returns:
+	//   - []byte: the pixel data
+	Pixels() []byte
+
+	// Nearest returns whether sprites of the texture are sampled without filtering, keeping
+	// pixel art sharp when scaled up.
+	//
+	// Returns:
+	//   - bool: true for nearest sampling, false for linear
+	Nearest() bool
+
+	// Full returns the region covering the whole texture.
+	//
+	// Returns:
+	//   - Region: the full region
+	Full() Region
+
+	// Region returns a rectangle of the texture, such as an entry of a texture atlas.
+	//
+	// Parameters:
+	//   - x: the left edge in pixels
+	//   - y: the top edge in pixels
+	//   - width: the width in pixels
+	//   - height: the height in pixels
+	//
+	// Returns:
+	//   - Region: the region
+	Region(x, y, width, height int) Region
+
+	// Grid splits the texture into equal frames, left to right and top to bottom, as in a
+	// sprite sheet. Partial frames at the right and bottom edges are left out.
+	//
+	// Parameters:
+	//   - frameWidth: the frame width in pixels
+	//   - frameHeight: the frame height in pixels
+	//
+	// Returns:
+	//   - []Region: the frames in reading order
+	Grid(frameWidth, frameHeight int) []Region
+}
+
+// textureImpl is the implementation of the Texture interface. It is immutable after creation.
+type textureImpl struct {
+	name    string
+	width   int
+	height  int
+	pixels  []byte
+	nearest bool
+}
+
+var _ Texture = &textureImpl{}
+
+// NewTexture creates a texture from RGBA pixels.
+//
+// Parameters:
+//   - name: the texture name
+//   - pixels: the RGBA pixels, 4 bytes per pixel, rows top to bottom; the texture keeps the slice
+//   - width: the width in pixels
+//   - height: the height in pixels
+//   - options: optional builder options
+//
+// Returns:
+//   - Texture: the new texture
+//   - error: an error if the size is not positive or does not match the pixel data
+func NewTexture(name string, pixels []byte, width, height int, options ...TextureBuilderOption) (Texture, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("sprite texture %q has invalid size %dx%d", name, width, height)
+	}
+	if len(pixels) != width*height*4 {
+		return nil, fmt.Errorf("sprite texture %q has %d bytes of pixels, expected %d", name, len(pixels), width*height*4)
+	}
+	t := &textureImpl{
+		name:   name,
+		width:  width,
+		height: height,
+		pixels: pixels,
+	}
+	for _, opt := range options {
+		opt(t)
+	}
+	return t, nil
+}
+
+// LoadTexture loads a PNG or JPEG image as a texture, named after the file.
+//
+// Parameters:
+//   - path: the image file path
+//   - options: optional builder options
+//
+// Returns:
+//   - Texture: the loaded texture
+//   - error: an error if the file cannot be read or decoded
+func LoadTexture(path string, options ...TextureBuilderOption) (Texture, error) {
+	pixels, width, height, err := (&common.ImportedTexture{Path: path}).Decode()
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return NewTexture(name, pixels, int(width), int(height), options...)
+}
+
+func (t *textureImpl) Name() string {
+	return t.name
+}
+
+func (t *textureImpl) Width() int {
+	return t.width
+}
+
+func (t *textureImpl) Height() int {
+	return t.height
+}
+
+func (t *textureImpl) Pixels() []byte {
+	return t.pixels
+}
+
+func (t *textureImpl) Nearest() bool {
+	return t.nearest
+}
+
+func (t *textureImpl) Full() Region {
+	return t.Region(0, 0, t.width, t.height)
+}
+
+func (t *textureImpl) Region(x, y, width, height int) Region {
+	w, h := float32(t.width), float32(t.height)
+	return Region{
+		Texture: t,
+		UV:      [4]float32{float32(x) / w, float32(y) / h, float32(x+width) / w, float32(y+height) / h},
+		Width:   float32(width),
+		Height:  float32(height),
+	}
+}
+
+func (t *textureImpl) Grid(frameWidth, frameHeight int) []Region {
+	if frameWidth <= 0 || frameHeight <= 0 {
+		return nil
+	}
+	var frames []Region
+	for y := 0; y+frameHeight <= t.height; y += frameHeight {
+		for x := 0; x+frameWidth <= t.width; x += frameWidth {
+			frames = append(frames, t.Region(x, y, frameWidth, frameHeight))
+		}
+	}
+	return frames
+}
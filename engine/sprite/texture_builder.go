@@ -0,0 +1,15 @@
+package sprite
+
+// TextureBuilderOption is a function that configures a Texture instance during construction.
+type TextureBuilderOption func(*textureImpl)
+
+// WithNearest is an option builder that samples sprites of the texture without filtering, so pixel
+// art stays sharp when scaled up. Defaults to linear filtering.
+//
+// Returns:
+//   - TextureBuilderOption: a function that applies the nearest sampling option to a textureImpl
+func WithNearest() TextureBuilderOption {
+	return func(t *textureImpl) {
+		t.nearest = true
+	}
+}
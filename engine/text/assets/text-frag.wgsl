@@ -0,0 +1,37 @@
+// Text fragment shader
+//
+// Reads the glyph's signed distance from the atlas, where 0.5 is the outline and
+// larger values are inside, and resolves the fill and optional outline with an
+// edge about one screen pixel wide at any text size. Alpha blends over the scene.
+//
+// Bind group layout:
+//   @group(1) text — SDF atlas texture, sampler
+
+struct FragmentInput {
+    @location(0) uv: vec2<f32>,
+    @location(1) color: vec4<f32>,
+    @location(2) outline_color: vec4<f32>,
+    @location(3) outline_width: f32,
+};
+
+//@oxy:provider 1 0 text text_atlas
+@group(1) @binding(0) var atlas_texture: texture_2d<f32>;
+//@oxy:provider 1 1 text text_sampler
+@group(1) @binding(1) var atlas_sampler: sampler;
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    let d = textureSample(atlas_texture, atlas_sampler, in.uv).r;
+    let aa = max(fwidth(d) * 0.5, 1e-4);
+    let fill = smoothstep(0.5 - aa, 0.5 + aa, d);
+
+    if in.outline_width <= 0.0 {
+        return vec4<f32>(in.color.rgb, in.color.a * fill);
+    }
+
+    // The outline covers the band between the glyph edge and outline_width outside it.
+    let edge = 0.5 - in.outline_width;
+    let coverage = smoothstep(edge - aa, edge + aa, d);
+    let color = mix(in.outline_color, in.color, fill);
+    return vec4<f32>(color.rgb, color.a * coverage);
+}
@@ -0,0 +1,66 @@
+// Text vertex shader
+//
+// Pulls glyph quads from a storage buffer, six vertices per glyph, so the text of a
+// frame needs no vertex buffer. Screen-space glyphs are placed in pixels from the
+// top-left of the viewport; world-space glyphs are billboarded around their anchor
+// along the camera's right and up vectors.
+//
+// Bind group layout:
+//   @group(0) text — TextParams uniform, TextGlyph storage array
+
+struct TextParams {
+    view_proj: mat4x4<f32>,
+    camera_right: vec4<f32>,
+    camera_up: vec4<f32>,
+    viewport: vec4<f32>,     // xy = viewport size in pixels
+};
+
+struct TextGlyph {
+    anchor: vec4<f32>,       // xyz = screen position in pixels or world position, w = 1 for world space
+    rect: vec4<f32>,         // xy = quad offset from the anchor, zw = quad size, in pixels with Y down
+    uv: vec4<f32>,           // xy = atlas UV of the top-left corner, zw = bottom-right
+    color: u32,
+    outline_color: u32,
+    outline_width: f32,      // in distance field units
+    scale: f32,              // world units per pixel
+};
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) uv: vec2<f32>,
+    @location(1) color: vec4<f32>,
+    @location(2) outline_color: vec4<f32>,
+    @location(3) outline_width: f32,
+};
+
+//@oxy:provider 0 0 text
+@group(0) @binding(0) var<uniform> params: TextParams;
+//@oxy:provider 0 1 text
+@group(0) @binding(1) var<storage, read> glyphs: array<TextGlyph>;
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> VertexOutput {
+    let g = glyphs[index / 6u];
+
+    // Two triangles: (0,0) (1,0) (0,1) and (1,0) (1,1) (0,1).
+    var corners = array<vec2<f32>, 6>(
+        vec2<f32>(0.0, 0.0), vec2<f32>(1.0, 0.0), vec2<f32>(0.0, 1.0),
+        vec2<f32>(1.0, 0.0), vec2<f32>(1.0, 1.0), vec2<f32>(0.0, 1.0),
+    );
+    let corner = corners[index % 6u];
+    let local = g.rect.xy + corner * g.rect.zw;
+
+    var out: VertexOutput;
+    if g.anchor.w < 0.5 {
+        let px = g.anchor.xy + local;
+        out.position = vec4<f32>(px.x / params.viewport.x * 2.0 - 1.0, 1.0 - px.y / params.viewport.y * 2.0, 0.0, 1.0);
+    } else {
+        let offset = (params.camera_right.xyz * local.x - params.camera_up.xyz * local.y) * g.scale;
+        out.position = params.view_proj * vec4<f32>(g.anchor.xyz + offset, 1.0);
+    }
+    out.uv = mix(g.uv.xy, g.uv.zw, corner);
+    out.color = unpack4x8unorm(g.color);
+    out.outline_color = unpack4x8unorm(g.outline_color);
+    out.outline_width = g.outline_width;
+    return out;
+}
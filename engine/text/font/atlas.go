@@ -0,0 +1,223 @@
+package font
+
+import (
+	"sync"
+)
+
+// AtlasGlyph is the placement of a rasterized glyph in an atlas. Sizes and offsets are in atlas
+// pixels at the atlas glyph size, and scale linearly to other font sizes.
+type AtlasGlyph struct {
+	Width  int        // bitmap width, 0 for glyphs without an outline
+	Height int        // bitmap height
+	Left   float32    // offset of the bitmap's left edge from the glyph origin
+	Top    float32    // offset of the bitmap's top edge above the baseline
+	UV     [4]float32 // texture coordinates of the bitmap: u0, v0, u1, v1
+}
+
+// atlasImpl is the implementation of the Atlas interface.
+type atlasImpl struct {
+	mu        *sync.Mutex
+	font      Font
+	glyphSize float32
+	spread    int
+	size      int
+	preload   string
+
+	pixels  []byte
+	glyphs  map[uint16]AtlasGlyph
+	full    map[uint16]bool // glyphs that did not fit
+	version uint64
+
+	// Shelf packing state: glyphs are placed left to right on shelves stacked top to bottom.
+	shelfX, shelfY, shelfHeight int
+}
+
+// Atlas defines the interface for a signed distance field glyph atlas. Glyphs of one font are
+// rasterized into a single-channel texture on first use and packed in shelves. The distance
+// field lets one atlas draw sharp text at any size, with outlines and shadows.
+// An Atlas is safe for concurrent use.
+type Atlas interface {
+	// Font returns the font the atlas rasterizes.
+	//
+	// Returns:
+	//   - Font: the font
+	Font() Font
+
+	// GlyphSize returns the font size in pixels glyphs are rasterized at.
+	//
+	// Returns:
+	//   - float32: the glyph size
+	GlyphSize() float32
+
+	// Spread returns the distance in atlas pixels over which the distance field ramps from inside
+	// to outside a glyph, which is also the padding around each glyph.
+	//
+	// Returns:
+	//   - int: the spread
+	Spread() int
+
+	// Size returns the width and height of the atlas texture in pixels.
+	//
+	// Returns:
+	//   - int: the atlas size
+	Size() int
+
+	// Glyph returns the placement of a glyph, rasterizing it into the atlas on first use.
+	//
+	// Parameters:
+	//   - glyph: the glyph index
+	//
+	// Returns:
+	//   - AtlasGlyph: the glyph placement
+	//   - bool: false if the glyph has no outline that fits in the atlas
+	Glyph(glyph uint16) (AtlasGlyph, bool)
+
+	// Pixels returns a copy of the atlas texture as R8 pixels, rows top to bottom, and the
+	// version of the atlas it shows.
+	//
+	// Returns:
+	//   - []byte: the pixels
+	//   - uint64: the atlas version
+	Pixels() ([]byte, uint64)
+
+	// Version returns a number that changes whenever a glyph is added to the atlas.
+	//
+	// Returns:
+	//   - uint64: the atlas version
+	Version() uint64
+}
+
+var _ Atlas = &atlasImpl{}
+
+// NewAtlas creates an empty glyph atlas for a font.
+//
+// Parameters:
+//   - font: the font to rasterize
+//   - options: optional builder options
+//
+// Returns:
+//   - Atlas: the new atlas
+func NewAtlas(font Font, options ...AtlasBuilderOption) Atlas {
+	a := &atlasImpl{
+		mu:        &sync.Mutex{},
+		font:      font,
+		glyphSize: 48,
+		spread:    6,
+		size:      1024,
+		glyphs:    make(map[uint16]AtlasGlyph),
+		full:      make(map[uint16]bool),
+	}
+	for _, opt := range options {
+		opt(a)
+	}
+	a.pixels = make([]byte, a.size*a.size)
+	for _, r := range a.preload {
+		a.Glyph(font.GlyphIndex(r))
+	}
+	return a
+}
+
+func (a *atlasImpl) Font() Font {
+	return a.font
+}
+
+func (a *atlasImpl) GlyphSize() float32 {
+	return a.glyphSize
+}
+
+func (a *atlasImpl) Spread() int {
+	return a.spread
+}
+
+func (a *atlasImpl) Size() int {
+	return a.size
+}
+
+func (a *atlasImpl) Glyph(glyph uint16) (AtlasGlyph, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if g, ok := a.glyphs[glyph]; ok {
+		return g, g.Width > 0
+	}
+	if a.full[glyph] {
+		return AtlasGlyph{}, false
+	}
+
+	segments, err := a.font.Outline(glyph)
+	if err != nil {
+		// A glyph that fails to decode is drawn as blank rather than failing the whole string.
+		segments = nil
+	}
+	bmp := rasterizeSDF(segments, a.glyphSize/float32(a.font.UnitsPerEm()), a.spread)
+	if bmp.width == 0 {
+		a.glyphs[glyph] = AtlasGlyph{}
+		return AtlasGlyph{}, false
+	}
+
+	x, y, ok := a.place(bmp.width, bmp.height)
+	if !ok {
+		a.full[glyph] = true
+		return AtlasGlyph{}, false
+	}
+	for row := range bmp.height {
+		copy(a.pixels[(y+row)*a.size+x:], bmp.pixels[row*bmp.width:(row+1)*bmp.width])
+	}
+	a.version++
+
+	size := float32(a.size)
+	g := AtlasGlyph{
+		Width:  bmp.width,
+		Height: bmp.height,
+		Left:   bmp.left,
+		Top:    bmp.top,
+		UV: [4]float32{
+			float32(x) / size,
+			float32(y) / size,
+			float32(x+bmp.width) / size,
+			float32(y+bmp.height) / size,
+		},
+	}
+	a.glyphs[glyph] = g
+	return g, true
+}
+
+func (a *atlasImpl) Pixels() ([]byte, uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]byte(nil), a.pixels...), a.version
+}
+
+func (a *atlasImpl) Version() uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.version
+}
+
+// place reserves a rectangle on the current shelf, or on a new shelf below it, leaving a
+// one-pixel gap so linear filtering does not bleed between glyphs. Caller must hold a.mu.
+//
+// Parameters:
+//   - w: the rectangle width
+//   - h: the rectangle height
+//
+// Returns:
+//   - int: the X coordinate of the rectangle
+//   - int: the Y coordinate of the rectangle
+//   - bool: false if the atlas is full
+func (a *atlasImpl) place(w, h int) (int, int, bool) {
+	if w+1 > a.size || h+1 > a.size {
+		return 0, 0, false
+	}
+	if a.shelfX+w+1 > a.size {
+		a.shelfY += a.shelfHeight
+		a.shelfX, a.shelfHeight = 0, 0
+	}
+	if a.shelfY+h+1 > a.size {
+		return 0, 0, false
+	}
+	x, y := a.shelfX+1, a.shelfY+1
+	a.shelfX += w + 1
+	a.shelfHeight = max(a.shelfHeight, h+1)
+	return x, y, true
+}
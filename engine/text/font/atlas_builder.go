@@ -0,0 +1,63 @@
+package font
+
+// AtlasBuilderOption is a function that configures an Atlas instance during construction.
+type AtlasBuilderOption func(*atlasImpl)
+
+// WithGlyphSize is an option builder that sets the font size in pixels glyphs are rasterized at.
+// Larger sizes keep sharp corners at large draw sizes but fill the atlas sooner. Defaults to 48.
+//
+// Parameters:
+//   - px: the rasterization size in pixels
+//
+// Returns:
+//   - AtlasBuilderOption: a function that applies the glyph size option to an atlasImpl
+func WithGlyphSize(px float32) AtlasBuilderOption {
+	return func(a *atlasImpl) {
+		if px > 0 {
+			a.glyphSize = px
+		}
+	}
+}
+
+// WithSpread is an option builder that sets the distance in atlas pixels the distance field
+// covers on each side of a glyph's outline. It limits the widest outline and the softest edge
+// that can be drawn. Defaults to 6.
+//
+// Parameters:
+//   - px: the spread in atlas pixels
+//
+// Returns:
+//   - AtlasBuilderOption: a function that applies the spread option to an atlasImpl
+func WithSpread(px int) AtlasBuilderOption {
+	return func(a *atlasImpl) {
+		a.spread = max(px, 1)
+	}
+}
+
+// WithAtlasSize is an option builder that sets the width and height of the atlas texture.
+// Defaults to 1024.
+//
+// Parameters:
+//   - px: the atlas size in pixels
+//
+// Returns:
+//   - AtlasBuilderOption: a function that applies the atlas size option to an atlasImpl
+func WithAtlasSize(px int) AtlasBuilderOption {
+	return func(a *atlasImpl) {
+		a.size = max(px, 64)
+	}
+}
+
+// WithPreload is an option builder that rasterizes the glyphs of a set of runes when the atlas is
+// created, instead of on first use.
+//
+// Parameters:
+//   - runes: the runes to rasterize, e.g. the printable ASCII range
+//
+// Returns:
+//   - AtlasBuilderOption: a function that applies the preload option to an atlasImpl
+func WithPreload(runes string) AtlasBuilderOption {
+	return func(a *atlasImpl) {
+		a.preload = runes
+	}
+}
@@ -0,0 +1,520 @@
+package font
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SegmentOp identifies the kind of a glyph outline segment.
+type SegmentOp int
+
+const (
+	// SegmentMoveTo starts a new contour at Points[0].
+	SegmentMoveTo SegmentOp = iota
+	// SegmentLineTo draws a straight line to Points[0].
+	SegmentLineTo
+	// SegmentQuadTo draws a quadratic Bézier curve through the control point Points[0] to Points[1].
+	SegmentQuadTo
+	// SegmentCubeTo draws a cubic Bézier curve through the control points Points[0] and Points[1] to Points[2].
+	SegmentCubeTo
+)
+
+// Segment is one step of a glyph outline, in font units with the Y axis pointing up.
+// Contours are implicitly closed.
+type Segment struct {
+	Op     SegmentOp
+	Points [3][2]float32
+}
+
+// fontImpl is the implementation of the Font interface.
+type fontImpl struct {
+	name string
+	data []byte
+	cff  bool // outlines in a CFF table rather than glyf/loca
+
+	unitsPerEm int
+	ascent     int
+	descent    int
+	lineGap    int
+	numGlyphs  int
+
+	tables    map[string][]byte
+	advances  []uint16
+	locaLong  bool
+	cmap      cmapLookup
+	cffFont   *cffFont
+	kernPairs map[uint32]int16 // legacy kern table pairs keyed by left<<16 | right
+	gpos      []pairPosTable   // GPOS pair adjustment subtables of the kern feature
+
+	kernMu    *sync.Mutex
+	kernCache map[uint32]int16 // resolved GPOS kerning by pair
+}
+
+// Font defines the interface for a parsed TrueType or OpenType font. It maps runes to glyphs
+// and provides glyph metrics, pair kerning and outlines in font units.
+//
+// TrueType outlines (glyf) and CFF outlines are both supported. Kerning is read from the GPOS
+// kern feature's pair adjustments when present, and from the legacy kern table otherwise.
+// A Font is safe for concurrent use.
+type Font interface {
+	// Name returns the name the font was loaded under.
+	//
+	// Returns:
+	//   - string: the font name
+	Name() string
+
+	// UnitsPerEm returns the number of font units per em square.
+	//
+	// Returns:
+	//   - int: the units per em
+	UnitsPerEm() int
+
+	// Ascent returns the distance from the baseline to the top of the line, in font units.
+	//
+	// Returns:
+	//   - int: the ascent, positive above the baseline
+	Ascent() int
+
+	// Descent returns the distance from the baseline to the bottom of the line, in font units.
+	//
+	// Returns:
+	//   - int: the descent, negative below the baseline
+	Descent() int
+
+	// LineGap returns the extra spacing between lines recommended by the font, in font units.
+	//
+	// Returns:
+	//   - int: the line gap
+	LineGap() int
+
+	// NumGlyphs returns the number of glyphs in the font.
+	//
+	// Returns:
+	//   - int: the glyph count
+	NumGlyphs() int
+
+	// GlyphIndex returns the glyph that represents a rune.
+	//
+	// Parameters:
+	//   - r: the rune
+	//
+	// Returns:
+	//   - uint16: the glyph index, 0 (.notdef) if the font has no glyph for the rune
+	GlyphIndex(r rune) uint16
+
+	// Advance returns the horizontal advance of a glyph, in font units.
+	//
+	// Parameters:
+	//   - glyph: the glyph index
+	//
+	// Returns:
+	//   - int: the advance width
+	Advance(glyph uint16) int
+
+	// Kerning returns the horizontal adjustment between two consecutive glyphs, in font units.
+	//
+	// Parameters:
+	//   - left: the glyph on the left
+	//   - right: the glyph on the right
+	//
+	// Returns:
+	//   - int: the adjustment added to the left glyph's advance, usually negative
+	Kerning(left, right uint16) int
+
+	// Outline returns the outline of a glyph, in font units with the Y axis pointing up.
+	//
+	// Parameters:
+	//   - glyph: the glyph index
+	//
+	// Returns:
+	//   - []Segment: the outline segments, empty for blank glyphs such as space
+	//   - error: an error if the glyph data is malformed
+	Outline(glyph uint16) ([]Segment, error)
+}
+
+var _ Font = &fontImpl{}
+
+// LoadFont reads and parses a TrueType (.ttf) or OpenType (.otf) font file. The font is
+// named after the file, without its extension. Collections (.ttc) load their first font.
+//
+// Parameters:
+//   - path: the path of the font file
+//
+// Returns:
+//   - Font: the parsed font
+//   - error: an error if the file cannot be read or parsed
+func LoadFont(path string) (Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font %q: %w", path, err)
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return ParseFont(name, data)
+}
+
+// ParseFont parses a TrueType or OpenType font from memory, e.g. one embedded with go:embed.
+// The data is retained and must not be modified afterwards.
+//
+// Parameters:
+//   - name: the name of the font
+//   - data: the font file contents
+//
+// Returns:
+//   - Font: the parsed font
+//   - error: an error if the data is not a supported font
+func ParseFont(name string, data []byte) (Font, error) {
+	f := &fontImpl{
+		name:      name,
+		data:      data,
+		tables:    make(map[string][]byte),
+		kernMu:    &sync.Mutex{},
+		kernCache: make(map[uint32]int16),
+	}
+	if err := f.parse(); err != nil {
+		return nil, fmt.Errorf("failed to parse font %q: %w", name, err)
+	}
+	return f, nil
+}
+
+func (f *fontImpl) Name() string {
+	return f.name
+}
+
+func (f *fontImpl) UnitsPerEm() int {
+	return f.unitsPerEm
+}
+
+func (f *fontImpl) Ascent() int {
+	return f.ascent
+}
+
+func (f *fontImpl) Descent() int {
+	return f.descent
+}
+
+func (f *fontImpl) LineGap() int {
+	return f.lineGap
+}
+
+func (f *fontImpl) NumGlyphs() int {
+	return f.numGlyphs
+}
+
+func (f *fontImpl) GlyphIndex(r rune) uint16 {
+	if f.cmap == nil {
+		return 0
+	}
+	return f.cmap.lookup(uint32(r))
+}
+
+func (f *fontImpl) Advance(glyph uint16) int {
+	if len(f.advances) == 0 {
+		return 0
+	}
+	if int(glyph) >= len(f.advances) {
+		// Glyphs past the last long metric share its advance.
+		return int(f.advances[len(f.advances)-1])
+	}
+	return int(f.advances[glyph])
+}
+
+func (f *fontImpl) Kerning(left, right uint16) int {
+	key := uint32(left)<<16 | uint32(right)
+	if len(f.gpos) == 0 {
+		return int(f.kernPairs[key])
+	}
+
+	f.kernMu.Lock()
+	defer f.kernMu.Unlock()
+	if v, ok := f.kernCache[key]; ok {
+		return int(v)
+	}
+	var v int16
+	for _, table := range f.gpos {
+		if adj, ok := table.lookup(left, right); ok {
+			v = adj
+			break
+		}
+	}
+	f.kernCache[key] = v
+	return int(v)
+}
+
+func (f *fontImpl) Outline(glyph uint16) ([]Segment, error) {
+	if int(glyph) >= f.numGlyphs {
+		return nil, fmt.Errorf("glyph %d out of range", glyph)
+	}
+	if f.cff {
+		return f.cffFont.outline(glyph)
+	}
+	return f.glyfOutline(glyph, 0)
+}
+
+// parse reads the table directory and the tables every font needs.
+//
+// Returns:
+//   - error: an error if a required table is missing or malformed
+func (f *fontImpl) parse() error {
+	data := f.data
+	if len(data) < 12 {
+		return fmt.Errorf("file too short")
+	}
+
+	offset := 0
+	if string(data[:4]) == "ttcf" {
+		// A collection: use its first font.
+		if len(data) < 16 {
+			return fmt.Errorf("truncated collection header")
+		}
+		offset = int(binary.BigEndian.Uint32(data[12:]))
+		if offset+12 > len(data) {
+			return fmt.Errorf("collection font offset out of range")
+		}
+	}
+
+	switch version := binary.BigEndian.Uint32(data[offset:]); version {
+	case 0x00010000, 0x74727565: // 1.0 and 'true'
+	case 0x4F54544F: // 'OTTO'
+		f.cff = true
+	default:
+		return fmt.Errorf("unsupported sfnt version %#08x", version)
+	}
+
+	numTables := int(binary.BigEndian.Uint16(data[offset+4:]))
+	if offset+12+numTables*16 > len(data) {
+		return fmt.Errorf("truncated table directory")
+	}
+	for i := range numTables {
+		rec := data[offset+12+i*16:]
+		tag := string(rec[:4])
+		start := int(binary.BigEndian.Uint32(rec[8:]))
+		length := int(binary.BigEndian.Uint32(rec[12:]))
+		if start < 0 || length < 0 || start+length > len(data) {
+			return fmt.Errorf("table %q out of range", tag)
+		}
+		f.tables[tag] = data[start : start+length]
+	}
+
+	for _, tag := range []string{"head", "hhea", "hmtx", "maxp", "cmap"} {
+		if f.tables[tag] == nil {
+			return fmt.Errorf("missing %s table", tag)
+		}
+	}
+
+	head := f.tables["head"]
+	if len(head) < 54 {
+		return fmt.Errorf("truncated head table")
+	}
+	f.unitsPerEm = int(binary.BigEndian.Uint16(head[18:]))
+	if f.unitsPerEm == 0 {
+		return fmt.Errorf("invalid unitsPerEm")
+	}
+	f.locaLong = int16(binary.BigEndian.Uint16(head[50:])) != 0
+
+	maxp := f.tables["maxp"]
+	if len(maxp) < 6 {
+		return fmt.Errorf("truncated maxp table")
+	}
+	f.numGlyphs = int(binary.BigEndian.Uint16(maxp[4:]))
+
+	hhea := f.tables["hhea"]
+	if len(hhea) < 36 {
+		return fmt.Errorf("truncated hhea table")
+	}
+	f.ascent = int(int16(binary.BigEndian.Uint16(hhea[4:])))
+	f.descent = int(int16(binary.BigEndian.Uint16(hhea[6:])))
+	f.lineGap = int(int16(binary.BigEndian.Uint16(hhea[8:])))
+	numMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+
+	hmtx := f.tables["hmtx"]
+	if numMetrics == 0 || len(hmtx) < numMetrics*4 {
+		return fmt.Errorf("truncated hmtx table")
+	}
+	f.advances = make([]uint16, numMetrics)
+	for i := range f.advances {
+		f.advances[i] = binary.BigEndian.Uint16(hmtx[i*4:])
+	}
+
+	cmap, err := parseCmap(f.tables["cmap"])
+	if err != nil {
+		return err
+	}
+	f.cmap = cmap
+
+	if f.cff {
+		if f.tables["CFF "] == nil {
+			return fmt.Errorf("missing CFF table")
+		}
+		cff, err := parseCFF(f.tables["CFF "])
+		if err != nil {
+			return err
+		}
+		f.cffFont = cff
+	} else if f.tables["glyf"] == nil || f.tables["loca"] == nil {
+		return fmt.Errorf("missing glyf or loca table")
+	}
+
+	// Kerning is optional; a malformed table only loses it.
+	if gpos := f.tables["GPOS"]; gpos != nil {
+		f.gpos = parseGPOSKerning(gpos)
+	}
+	if kern := f.tables["kern"]; kern != nil && len(f.gpos) == 0 {
+		f.kernPairs = parseKern(kern)
+	}
+	return nil
+}
+
+// cmapLookup maps character codes to glyph indices.
+type cmapLookup interface {
+	lookup(c uint32) uint16
+}
+
+// cmapFormat4 is a segment mapping of the Basic Multilingual Plane.
+type cmapFormat4 struct {
+	data          []byte // the subtable
+	segCount      int
+	endCodes      int // offsets of the arrays in data
+	startCodes    int
+	idDeltas      int
+	idRangeOffset int
+}
+
+func (c *cmapFormat4) lookup(code uint32) uint16 {
+	if code > 0xFFFF {
+		return 0
+	}
+	u16 := func(off int) uint16 {
+		if off+2 > len(c.data) {
+			return 0
+		}
+		return binary.BigEndian.Uint16(c.data[off:])
+	}
+
+	// Segments are sorted by end code; find the first that ends at or after the code.
+	i := sort.Search(c.segCount, func(i int) bool { return uint32(u16(c.endCodes+i*2)) >= code })
+	if i == c.segCount {
+		return 0
+	}
+	start := uint32(u16(c.startCodes + i*2))
+	if code < start {
+		return 0
+	}
+	delta := u16(c.idDeltas + i*2)
+	rangeOffsetPos := c.idRangeOffset + i*2
+	rangeOffset := u16(rangeOffsetPos)
+	if rangeOffset == 0 {
+		return uint16(code) + delta
+	}
+	glyph := u16(rangeOffsetPos + int(rangeOffset) + int(code-start)*2)
+	if glyph == 0 {
+		return 0
+	}
+	return glyph + delta
+}
+
+// cmapGroup is one sequential map group of a format 12 subtable.
+type cmapGroup struct {
+	start, end, glyph uint32
+}
+
+// cmapFormat12 is a segmented coverage of the full Unicode range.
+type cmapFormat12 struct {
+	groups []cmapGroup
+}
+
+func (c *cmapFormat12) lookup(code uint32) uint16 {
+	i := sort.Search(len(c.groups), func(i int) bool { return c.groups[i].end >= code })
+	if i == len(c.groups) || code < c.groups[i].start {
+		return 0
+	}
+	return uint16(c.groups[i].glyph + code - c.groups[i].start)
+}
+
+// parseCmap picks the best Unicode subtable of a cmap table, preferring full Unicode coverage.
+//
+// Parameters:
+//   - data: the cmap table
+//
+// Returns:
+//   - cmapLookup: the lookup of the chosen subtable
+//   - error: an error if the table has no supported Unicode subtable
+func parseCmap(data []byte) (cmapLookup, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("truncated cmap table")
+	}
+	numTables := int(binary.BigEndian.Uint16(data[2:]))
+	if len(data) < 4+numTables*8 {
+		return nil, fmt.Errorf("truncated cmap table")
+	}
+
+	best, bestRank := -1, 0
+	for i := range numTables {
+		rec := data[4+i*8:]
+		platform := binary.BigEndian.Uint16(rec)
+		encoding := binary.BigEndian.Uint16(rec[2:])
+		offset := int(binary.BigEndian.Uint32(rec[4:]))
+		if offset+2 > len(data) {
+			continue
+		}
+		format := binary.BigEndian.Uint16(data[offset:])
+
+		rank := 0
+		switch {
+		case format == 12 && (platform == 0 || (platform == 3 && encoding == 10)):
+			rank = 2
+		case format == 4 && (platform == 0 || (platform == 3 && (encoding == 1 || encoding == 0))):
+			rank = 1
+		}
+		if rank > bestRank {
+			best, bestRank = offset, rank
+		}
+	}
+	if best < 0 {
+		return nil, fmt.Errorf("no supported Unicode cmap subtable")
+	}
+
+	sub := data[best:]
+	switch binary.BigEndian.Uint16(sub) {
+	case 4:
+		if len(sub) < 14 {
+			return nil, fmt.Errorf("truncated cmap format 4 subtable")
+		}
+		segCount := int(binary.BigEndian.Uint16(sub[6:])) / 2
+		c := &cmapFormat4{
+			data:     sub,
+			segCount: segCount,
+			endCodes: 14,
+		}
+		c.startCodes = c.endCodes + segCount*2 + 2 // skip reservedPad
+		c.idDeltas = c.startCodes + segCount*2
+		c.idRangeOffset = c.idDeltas + segCount*2
+		if len(sub) < c.idRangeOffset+segCount*2 {
+			return nil, fmt.Errorf("truncated cmap format 4 subtable")
+		}
+		return c, nil
+
+	default: // 12
+		if len(sub) < 16 {
+			return nil, fmt.Errorf("truncated cmap format 12 subtable")
+		}
+		n := int(binary.BigEndian.Uint32(sub[12:]))
+		if n < 0 || len(sub) < 16+n*12 {
+			return nil, fmt.Errorf("truncated cmap format 12 subtable")
+		}
+		c := &cmapFormat12{groups: make([]cmapGroup, n)}
+		for i := range c.groups {
+			g := sub[16+i*12:]
+			c.groups[i] = cmapGroup{
+				start: binary.BigEndian.Uint32(g),
+				end:   binary.BigEndian.Uint32(g[4:]),
+				glyph: binary.BigEndian.Uint32(g[8:]),
+			}
+		}
+		return c, nil
+	}
+}
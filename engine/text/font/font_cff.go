@@ -0,0 +1,673 @@
+package font
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// CFF DICT operators. Two-byte operators are stored as 1200 + the second byte.
+const (
+	cffOpCharStrings = 17
+	cffOpPrivate     = 18
+	cffOpSubrs       = 19
+	cffOpROS         = 1230
+	cffOpFDArray     = 1236
+	cffOpFDSelect    = 1237
+)
+
+// Type 2 charstring limits.
+const (
+	cffMaxStack     = 48
+	cffMaxCallDepth = 10
+)
+
+// cffFont holds the parsed parts of a CFF table needed to decode glyph outlines.
+type cffFont struct {
+	charStrings [][]byte
+	globalSubrs [][]byte
+	localSubrs  [][][]byte // per font dict; a single entry for non-CID fonts
+	fdSelect    []uint8    // font dict of each glyph, nil for non-CID fonts
+}
+
+// parseCFF parses the header, INDEXes and DICTs of a CFF table.
+//
+// Parameters:
+//   - data: the CFF table
+//
+// Returns:
+//   - *cffFont: the parsed font program
+//   - error: an error if the table is malformed
+func parseCFF(data []byte) (*cffFont, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("truncated CFF header")
+	}
+	p := int(data[2]) // hdrSize
+
+	var err error
+	if _, p, err = readCFFIndex(data, p); err != nil { // Name INDEX
+		return nil, err
+	}
+	topDicts, p, err := readCFFIndex(data, p)
+	if err != nil {
+		return nil, err
+	}
+	if len(topDicts) == 0 {
+		return nil, fmt.Errorf("empty CFF top DICT INDEX")
+	}
+	if _, p, err = readCFFIndex(data, p); err != nil { // String INDEX
+		return nil, err
+	}
+	globalSubrs, _, err := readCFFIndex(data, p)
+	if err != nil {
+		return nil, err
+	}
+
+	top, err := parseCFFDict(topDicts[0])
+	if err != nil {
+		return nil, err
+	}
+	csOffset, ok := top[cffOpCharStrings]
+	if !ok || len(csOffset) == 0 {
+		return nil, fmt.Errorf("CFF top DICT has no CharStrings")
+	}
+	charStrings, _, err := readCFFIndex(data, int(csOffset[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &cffFont{charStrings: charStrings, globalSubrs: globalSubrs}
+
+	if _, cid := top[cffOpROS]; cid {
+		// CID-keyed fonts keep a private DICT, and so local subrs, per font dict.
+		fdArray, ok := top[cffOpFDArray]
+		if !ok || len(fdArray) == 0 {
+			return nil, fmt.Errorf("CID-keyed CFF has no FDArray")
+		}
+		fontDicts, _, err := readCFFIndex(data, int(fdArray[0]))
+		if err != nil {
+			return nil, err
+		}
+		for _, fd := range fontDicts {
+			dict, err := parseCFFDict(fd)
+			if err != nil {
+				return nil, err
+			}
+			subrs, err := readCFFLocalSubrs(data, dict)
+			if err != nil {
+				return nil, err
+			}
+			c.localSubrs = append(c.localSubrs, subrs)
+		}
+		fdSelect, ok := top[cffOpFDSelect]
+		if !ok || len(fdSelect) == 0 {
+			return nil, fmt.Errorf("CID-keyed CFF has no FDSelect")
+		}
+		if c.fdSelect, err = readCFFFDSelect(data, int(fdSelect[0]), len(charStrings)); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	subrs, err := readCFFLocalSubrs(data, top)
+	if err != nil {
+		return nil, err
+	}
+	c.localSubrs = [][][]byte{subrs}
+	return c, nil
+}
+
+// readCFFIndex reads a CFF INDEX structure.
+//
+// Parameters:
+//   - data: the CFF table
+//   - p: the offset of the INDEX
+//
+// Returns:
+//   - [][]byte: the objects of the INDEX
+//   - int: the offset after the INDEX
+//   - error: an error if the INDEX is malformed
+func readCFFIndex(data []byte, p int) ([][]byte, int, error) {
+	if p < 0 || p+2 > len(data) {
+		return nil, 0, fmt.Errorf("CFF INDEX out of range")
+	}
+	count := int(binary.BigEndian.Uint16(data[p:]))
+	if count == 0 {
+		return nil, p + 2, nil
+	}
+	if p+3 > len(data) {
+		return nil, 0, fmt.Errorf("truncated CFF INDEX")
+	}
+	offSize := int(data[p+2])
+	if offSize < 1 || offSize > 4 {
+		return nil, 0, fmt.Errorf("invalid CFF INDEX offset size %d", offSize)
+	}
+	offsets := p + 3
+	base := offsets + (count+1)*offSize - 1 // offsets are 1-based from the byte before the data
+	if base >= len(data) {
+		return nil, 0, fmt.Errorf("truncated CFF INDEX")
+	}
+	readOffset := func(i int) int {
+		var v int
+		for _, b := range data[offsets+i*offSize : offsets+(i+1)*offSize] {
+			v = v<<8 | int(b)
+		}
+		return base + v
+	}
+
+	objects := make([][]byte, count)
+	for i := range objects {
+		start, end := readOffset(i), readOffset(i+1)
+		if start > end || end > len(data) {
+			return nil, 0, fmt.Errorf("CFF INDEX object out of range")
+		}
+		objects[i] = data[start:end]
+	}
+	return objects, readOffset(count), nil
+}
+
+// parseCFFDict parses a CFF DICT into its operand lists by operator.
+//
+// Parameters:
+//   - data: the DICT data
+//
+// Returns:
+//   - map[int][]float64: the operands of each operator
+//   - error: an error if the DICT is malformed
+func parseCFFDict(data []byte) (map[int][]float64, error) {
+	dict := make(map[int][]float64)
+	var operands []float64
+	for p := 0; p < len(data); {
+		b0 := data[p]
+		switch {
+		case b0 <= 21:
+			op := int(b0)
+			p++
+			if b0 == 12 {
+				if p >= len(data) {
+					return nil, fmt.Errorf("truncated CFF DICT operator")
+				}
+				op = 1200 + int(data[p])
+				p++
+			}
+			dict[op] = operands
+			operands = nil
+		case b0 == 28:
+			if p+3 > len(data) {
+				return nil, fmt.Errorf("truncated CFF DICT operand")
+			}
+			operands = append(operands, float64(int16(binary.BigEndian.Uint16(data[p+1:]))))
+			p += 3
+		case b0 == 29:
+			if p+5 > len(data) {
+				return nil, fmt.Errorf("truncated CFF DICT operand")
+			}
+			operands = append(operands, float64(int32(binary.BigEndian.Uint32(data[p+1:]))))
+			p += 5
+		case b0 == 30:
+			// Real numbers are nibble encoded; only their extent matters for the operators used here.
+			p++
+			for p < len(data) {
+				b := data[p]
+				p++
+				if b&0x0F == 0x0F || b>>4 == 0x0F {
+					break
+				}
+			}
+			operands = append(operands, 0)
+		case b0 >= 32 && b0 <= 246:
+			operands = append(operands, float64(int(b0)-139))
+			p++
+		case b0 >= 247 && b0 <= 250:
+			if p+2 > len(data) {
+				return nil, fmt.Errorf("truncated CFF DICT operand")
+			}
+			operands = append(operands, float64((int(b0)-247)*256+int(data[p+1])+108))
+			p += 2
+		case b0 >= 251 && b0 <= 254:
+			if p+2 > len(data) {
+				return nil, fmt.Errorf("truncated CFF DICT operand")
+			}
+			operands = append(operands, float64(-(int(b0)-251)*256-int(data[p+1])-108))
+			p += 2
+		default:
+			return nil, fmt.Errorf("invalid CFF DICT byte %d", b0)
+		}
+	}
+	return dict, nil
+}
+
+// readCFFLocalSubrs reads the local subroutines of the private DICT a top or font DICT points to.
+//
+// Parameters:
+//   - data: the CFF table
+//   - dict: the top or font DICT
+//
+// Returns:
+//   - [][]byte: the local subroutines, nil if there are none
+//   - error: an error if the private DICT or subroutines are malformed
+func readCFFLocalSubrs(data []byte, dict map[int][]float64) ([][]byte, error) {
+	private, ok := dict[cffOpPrivate]
+	if !ok || len(private) < 2 {
+		return nil, nil
+	}
+	size, offset := int(private[0]), int(private[1])
+	if offset < 0 || size < 0 || offset+size > len(data) {
+		return nil, fmt.Errorf("CFF private DICT out of range")
+	}
+	privateDict, err := parseCFFDict(data[offset : offset+size])
+	if err != nil {
+		return nil, err
+	}
+	subrs, ok := privateDict[cffOpSubrs]
+	if !ok || len(subrs) == 0 {
+		return nil, nil
+	}
+	// The Subrs offset is relative to the private DICT.
+	objects, _, err := readCFFIndex(data, offset+int(subrs[0]))
+	return objects, err
+}
+
+// readCFFFDSelect reads the font dict index of every glyph of a CID-keyed font.
+//
+// Parameters:
+//   - data: the CFF table
+//   - p: the offset of the FDSelect structure
+//   - numGlyphs: the number of glyphs
+//
+// Returns:
+//   - []uint8: the font dict index of each glyph
+//   - error: an error if the structure is malformed or uses an unknown format
+func readCFFFDSelect(data []byte, p, numGlyphs int) ([]uint8, error) {
+	if p < 0 || p >= len(data) {
+		return nil, fmt.Errorf("CFF FDSelect out of range")
+	}
+	out := make([]uint8, numGlyphs)
+	switch data[p] {
+	case 0:
+		if p+1+numGlyphs > len(data) {
+			return nil, fmt.Errorf("truncated CFF FDSelect")
+		}
+		copy(out, data[p+1:])
+	case 3:
+		if p+3 > len(data) {
+			return nil, fmt.Errorf("truncated CFF FDSelect")
+		}
+		nRanges := int(binary.BigEndian.Uint16(data[p+1:]))
+		ranges := p + 3
+		if ranges+nRanges*3+2 > len(data) {
+			return nil, fmt.Errorf("truncated CFF FDSelect")
+		}
+		for i := range nRanges {
+			first := int(binary.BigEndian.Uint16(data[ranges+i*3:]))
+			fd := data[ranges+i*3+2]
+			next := int(binary.BigEndian.Uint16(data[ranges+(i+1)*3:])) // the sentinel after the last range
+			for g := first; g < next && g < numGlyphs; g++ {
+				out[g] = fd
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported CFF FDSelect format %d", data[p])
+	}
+	return out, nil
+}
+
+// cffSubrBias returns the bias added to subroutine numbers for a subroutine INDEX of the given size.
+//
+// Parameters:
+//   - count: the number of subroutines
+//
+// Returns:
+//   - int: the bias
+func cffSubrBias(count int) int {
+	switch {
+	case count < 1240:
+		return 107
+	case count < 33900:
+		return 1131
+	default:
+		return 32768
+	}
+}
+
+// cffInterpreter runs Type 2 charstrings to build a glyph outline.
+type cffInterpreter struct {
+	font        *cffFont
+	localSubrs  [][]byte
+	stack       []float64
+	x, y        float64
+	nStems      int
+	widthParsed bool
+	out         []Segment
+}
+
+// outline decodes the outline of a glyph from its charstring.
+//
+// Parameters:
+//   - glyph: the glyph index
+//
+// Returns:
+//   - []Segment: the outline in font units
+//   - error: an error if the charstring is malformed
+func (c *cffFont) outline(glyph uint16) ([]Segment, error) {
+	if int(glyph) >= len(c.charStrings) {
+		return nil, fmt.Errorf("glyph %d has no charstring", glyph)
+	}
+	in := &cffInterpreter{font: c, stack: make([]float64, 0, cffMaxStack)}
+	fd := 0
+	if c.fdSelect != nil {
+		fd = int(c.fdSelect[glyph])
+	}
+	if fd < len(c.localSubrs) {
+		in.localSubrs = c.localSubrs[fd]
+	}
+	if _, err := in.run(c.charStrings[glyph], 0); err != nil {
+		return nil, fmt.Errorf("glyph %d: %w", glyph, err)
+	}
+	return in.out, nil
+}
+
+// run interprets one charstring or subroutine.
+//
+// Parameters:
+//   - code: the charstring
+//   - depth: the subroutine call depth
+//
+// Returns:
+//   - bool: true if endchar was reached
+//   - error: an error if the charstring is malformed
+func (in *cffInterpreter) run(code []byte, depth int) (bool, error) {
+	if depth > cffMaxCallDepth {
+		return false, fmt.Errorf("charstring subroutines nested too deeply")
+	}
+
+	for p := 0; p < len(code); {
+		b0 := code[p]
+		p++
+
+		// Operands.
+		switch {
+		case b0 == 28:
+			if p+2 > len(code) {
+				return false, fmt.Errorf("truncated charstring operand")
+			}
+			in.push(float64(int16(binary.BigEndian.Uint16(code[p:]))))
+			p += 2
+			continue
+		case b0 >= 32 && b0 <= 246:
+			in.push(float64(int(b0) - 139))
+			continue
+		case b0 >= 247 && b0 <= 250:
+			if p >= len(code) {
+				return false, fmt.Errorf("truncated charstring operand")
+			}
+			in.push(float64((int(b0)-247)*256 + int(code[p]) + 108))
+			p++
+			continue
+		case b0 >= 251 && b0 <= 254:
+			if p >= len(code) {
+				return false, fmt.Errorf("truncated charstring operand")
+			}
+			in.push(float64(-(int(b0)-251)*256 - int(code[p]) - 108))
+			p++
+			continue
+		case b0 == 255:
+			if p+4 > len(code) {
+				return false, fmt.Errorf("truncated charstring operand")
+			}
+			in.push(float64(int32(binary.BigEndian.Uint32(code[p:]))) / 65536)
+			p += 4
+			continue
+		}
+
+		// Operators.
+		s := in.stack
+		switch b0 {
+		case 1, 3, 18, 23: // hstem, vstem, hstemhm, vstemhm
+			in.parseWidth(len(s)%2 == 1)
+			in.nStems += len(in.stack) / 2
+		case 19, 20: // hintmask, cntrmask
+			// Operands before the mask are an implied vstem.
+			in.parseWidth(len(s)%2 == 1)
+			in.nStems += len(in.stack) / 2
+			p += (in.nStems + 7) / 8
+		case 21: // rmoveto
+			in.parseWidth(len(s) > 2)
+			if len(in.stack) < 2 {
+				return false, fmt.Errorf("rmoveto needs 2 operands")
+			}
+			in.moveTo(in.x+in.stack[0], in.y+in.stack[1])
+		case 22: // hmoveto
+			in.parseWidth(len(s) > 1)
+			if len(in.stack) < 1 {
+				return false, fmt.Errorf("hmoveto needs 1 operand")
+			}
+			in.moveTo(in.x+in.stack[0], in.y)
+		case 4: // vmoveto
+			in.parseWidth(len(s) > 1)
+			if len(in.stack) < 1 {
+				return false, fmt.Errorf("vmoveto needs 1 operand")
+			}
+			in.moveTo(in.x, in.y+in.stack[0])
+		case 5: // rlineto
+			for i := 0; i+1 < len(s); i += 2 {
+				in.lineTo(in.x+s[i], in.y+s[i+1])
+			}
+		case 6, 7: // hlineto, vlineto alternate starting horizontally or vertically
+			horizontal := b0 == 6
+			for _, d := range s {
+				if horizontal {
+					in.lineTo(in.x+d, in.y)
+				} else {
+					in.lineTo(in.x, in.y+d)
+				}
+				horizontal = !horizontal
+			}
+		case 8: // rrcurveto
+			for i := 0; i+5 < len(s); i += 6 {
+				in.curveRel(s[i], s[i+1], s[i+2], s[i+3], s[i+4], s[i+5])
+			}
+		case 24: // rcurveline
+			i := 0
+			for ; i+5 < len(s)-2; i += 6 {
+				in.curveRel(s[i], s[i+1], s[i+2], s[i+3], s[i+4], s[i+5])
+			}
+			if i+1 < len(s) {
+				in.lineTo(in.x+s[i], in.y+s[i+1])
+			}
+		case 25: // rlinecurve
+			i := 0
+			for ; i+1 < len(s)-6; i += 2 {
+				in.lineTo(in.x+s[i], in.y+s[i+1])
+			}
+			if i+5 < len(s) {
+				in.curveRel(s[i], s[i+1], s[i+2], s[i+3], s[i+4], s[i+5])
+			}
+		case 26: // vvcurveto
+			i := 0
+			var dx1 float64
+			if len(s)%4 == 1 {
+				dx1 = s[0]
+				i = 1
+			}
+			for ; i+3 < len(s); i += 4 {
+				in.curveRel(dx1, s[i], s[i+1], s[i+2], 0, s[i+3])
+				dx1 = 0
+			}
+		case 27: // hhcurveto
+			i := 0
+			var dy1 float64
+			if len(s)%4 == 1 {
+				dy1 = s[0]
+				i = 1
+			}
+			for ; i+3 < len(s); i += 4 {
+				in.curveRel(s[i], dy1, s[i+1], s[i+2], s[i+3], 0)
+				dy1 = 0
+			}
+		case 30, 31: // vhcurveto, hvcurveto alternate their starting tangent
+			horizontal := b0 == 31
+			for i := 0; i+3 < len(s); i += 4 {
+				var last float64
+				if len(s)-i == 5 {
+					last = s[i+4]
+				}
+				if horizontal {
+					in.curveRel(s[i], 0, s[i+1], s[i+2], last, s[i+3])
+				} else {
+					in.curveRel(0, s[i], s[i+1], s[i+2], s[i+3], last)
+				}
+				horizontal = !horizontal
+			}
+		case 10, 29: // callsubr, callgsubr
+			if len(s) == 0 {
+				return false, fmt.Errorf("subroutine call without an index")
+			}
+			subrs := in.localSubrs
+			if b0 == 29 {
+				subrs = in.font.globalSubrs
+			}
+			index := int(s[len(s)-1]) + cffSubrBias(len(subrs))
+			in.stack = s[:len(s)-1]
+			if index < 0 || index >= len(subrs) {
+				return false, fmt.Errorf("subroutine %d out of range", index)
+			}
+			ended, err := in.run(subrs[index], depth+1)
+			if err != nil || ended {
+				return ended, err
+			}
+			continue // the subroutine's operands stay on the stack
+		case 11: // return
+			return false, nil
+		case 14: // endchar
+			in.parseWidth(len(s) == 1 || len(s) == 5)
+			return true, nil
+		case 12:
+			if p >= len(code) {
+				return false, fmt.Errorf("truncated escape operator")
+			}
+			b1 := code[p]
+			p++
+			if err := in.flex(b1); err != nil {
+				return false, err
+			}
+		default:
+			return false, fmt.Errorf("unsupported charstring operator %d", b0)
+		}
+		in.stack = in.stack[:0]
+	}
+	return false, nil
+}
+
+// flex runs one of the escaped flex operators, drawn as their two curves.
+//
+// Parameters:
+//   - op: the second byte of the escaped operator
+//
+// Returns:
+//   - error: an error if the operator is not a flex operator or lacks operands
+func (in *cffInterpreter) flex(op byte) error {
+	s := in.stack
+	switch op {
+	case 35: // flex
+		if len(s) < 12 {
+			return fmt.Errorf("flex needs 12 operands")
+		}
+		in.curveRel(s[0], s[1], s[2], s[3], s[4], s[5])
+		in.curveRel(s[6], s[7], s[8], s[9], s[10], s[11])
+	case 34: // hflex
+		if len(s) < 7 {
+			return fmt.Errorf("hflex needs 7 operands")
+		}
+		in.curveRel(s[0], 0, s[1], s[2], s[3], 0)
+		in.curveRel(s[4], 0, s[5], -s[2], s[6], 0)
+	case 36: // hflex1
+		if len(s) < 9 {
+			return fmt.Errorf("hflex1 needs 9 operands")
+		}
+		in.curveRel(s[0], s[1], s[2], s[3], s[4], 0)
+		in.curveRel(s[5], 0, s[6], s[7], s[8], -(s[1] + s[3] + s[7]))
+	case 37: // flex1
+		if len(s) < 11 {
+			return fmt.Errorf("flex1 needs 11 operands")
+		}
+		// The last point returns to the start along the flex's shorter direction.
+		var dx, dy float64
+		for i := 0; i < 10; i += 2 {
+			dx += s[i]
+			dy += s[i+1]
+		}
+		x6, y6 := s[10], -dy
+		if math.Abs(dy) >= math.Abs(dx) {
+			x6, y6 = -dx, s[10]
+		}
+		in.curveRel(s[0], s[1], s[2], s[3], s[4], s[5])
+		in.curveRel(s[6], s[7], s[8], s[9], x6, y6)
+	default:
+		return fmt.Errorf("unsupported charstring operator 12 %d", op)
+	}
+	return nil
+}
+
+// push pushes an operand, dropping it if the stack is full.
+//
+// Parameters:
+//   - v: the operand
+func (in *cffInterpreter) push(v float64) {
+	if len(in.stack) < cffMaxStack {
+		in.stack = append(in.stack, v)
+	}
+}
+
+// parseWidth drops the optional advance width operand that precedes the first stack-clearing
+// operator. Advances are read from hmtx instead.
+//
+// Parameters:
+//   - hasWidth: whether the operator has one operand more than it takes
+func (in *cffInterpreter) parseWidth(hasWidth bool) {
+	if in.widthParsed {
+		return
+	}
+	in.widthParsed = true
+	if hasWidth && len(in.stack) > 0 {
+		in.stack = in.stack[1:]
+	}
+}
+
+// moveTo starts a new contour, implicitly closing the previous one.
+//
+// Parameters:
+//   - x: the X coordinate of the new start point
+//   - y: the Y coordinate of the new start point
+func (in *cffInterpreter) moveTo(x, y float64) {
+	in.x, in.y = x, y
+	in.out = append(in.out, Segment{Op: SegmentMoveTo, Points: [3][2]float32{{float32(x), float32(y)}}})
+}
+
+// lineTo draws a line to an absolute point.
+//
+// Parameters:
+//   - x: the X coordinate
+//   - y: the Y coordinate
+func (in *cffInterpreter) lineTo(x, y float64) {
+	in.x, in.y = x, y
+	in.out = append(in.out, Segment{Op: SegmentLineTo, Points: [3][2]float32{{float32(x), float32(y)}}})
+}
+
+// curveRel draws a cubic curve given as three relative control point deltas.
+//
+// Parameters:
+//   - dxa, dya: the delta from the current point to the first control point
+//   - dxb, dyb: the delta from the first to the second control point
+//   - dxc, dyc: the delta from the second control point to the end point
+func (in *cffInterpreter) curveRel(dxa, dya, dxb, dyb, dxc, dyc float64) {
+	ax, ay := in.x+dxa, in.y+dya
+	bx, by := ax+dxb, ay+dyb
+	cx, cy := bx+dxc, by+dyc
+	in.x, in.y = cx, cy
+	in.out = append(in.out, Segment{Op: SegmentCubeTo, Points: [3][2]float32{
+		{float32(ax), float32(ay)},
+		{float32(bx), float32(by)},
+		{float32(cx), float32(cy)},
+	}})
+}
@@ -0,0 +1,352 @@
+package font
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Simple glyph point flags.
+const (
+	glyfOnCurve      = 0x01
+	glyfXShort       = 0x02
+	glyfYShort       = 0x04
+	glyfRepeat       = 0x08
+	glyfXSameOrPlus  = 0x10
+	glyfYSameOrPlus  = 0x20
+	maxCompoundDepth = 8
+)
+
+// Composite glyph component flags.
+const (
+	compArgsAreWords   = 0x0001
+	compArgsAreXY      = 0x0002
+	compHaveScale      = 0x0008
+	compMoreComponents = 0x0020
+	compHaveXYScale    = 0x0040
+	compHaveTwoByTwo   = 0x0080
+)
+
+// glyfData returns the glyf table data of a glyph.
+//
+// Parameters:
+//   - glyph: the glyph index
+//
+// Returns:
+//   - []byte: the glyph data, empty for blank glyphs
+//   - error: an error if loca points outside the glyf table
+func (f *fontImpl) glyfData(glyph uint16) ([]byte, error) {
+	loca := f.tables["loca"]
+	var start, end int
+	if f.locaLong {
+		if int(glyph)*4+8 > len(loca) {
+			return nil, fmt.Errorf("glyph %d missing from loca", glyph)
+		}
+		start = int(binary.BigEndian.Uint32(loca[int(glyph)*4:]))
+		end = int(binary.BigEndian.Uint32(loca[int(glyph)*4+4:]))
+	} else {
+		if int(glyph)*2+4 > len(loca) {
+			return nil, fmt.Errorf("glyph %d missing from loca", glyph)
+		}
+		start = int(binary.BigEndian.Uint16(loca[int(glyph)*2:])) * 2
+		end = int(binary.BigEndian.Uint16(loca[int(glyph)*2+2:])) * 2
+	}
+	glyf := f.tables["glyf"]
+	if start > end || end > len(glyf) {
+		return nil, fmt.Errorf("glyph %d out of glyf range", glyph)
+	}
+	return glyf[start:end], nil
+}
+
+// glyfOutline decodes the TrueType outline of a glyph, resolving composite glyphs.
+//
+// Parameters:
+//   - glyph: the glyph index
+//   - depth: the composite nesting depth, to stop reference cycles
+//
+// Returns:
+//   - []Segment: the outline in font units
+//   - error: an error if the glyph data is malformed
+func (f *fontImpl) glyfOutline(glyph uint16, depth int) ([]Segment, error) {
+	data, err := f.glyfData(glyph)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if len(data) < 10 {
+		return nil, fmt.Errorf("glyph %d: truncated header", glyph)
+	}
+
+	numContours := int(int16(binary.BigEndian.Uint16(data)))
+	if numContours >= 0 {
+		return parseSimpleGlyph(data, numContours)
+	}
+	if depth >= maxCompoundDepth {
+		return nil, fmt.Errorf("glyph %d: composite glyphs nested too deeply", glyph)
+	}
+	return f.parseCompositeGlyph(data, depth)
+}
+
+// parseSimpleGlyph decodes the contours of a simple glyph into quadratic segments.
+//
+// Parameters:
+//   - data: the glyph data
+//   - numContours: the number of contours
+//
+// Returns:
+//   - []Segment: the outline in font units
+//   - error: an error if the glyph data is malformed
+func parseSimpleGlyph(data []byte, numContours int) ([]Segment, error) {
+	p := 10
+	if len(data) < p+numContours*2+2 {
+		return nil, fmt.Errorf("truncated contour end points")
+	}
+	ends := make([]int, numContours)
+	for i := range ends {
+		ends[i] = int(binary.BigEndian.Uint16(data[p+i*2:]))
+	}
+	p += numContours * 2
+	if numContours == 0 {
+		return nil, nil
+	}
+	numPoints := ends[numContours-1] + 1
+
+	instructionLength := int(binary.BigEndian.Uint16(data[p:]))
+	p += 2 + instructionLength
+
+	flags := make([]byte, numPoints)
+	for i := 0; i < numPoints; {
+		if p >= len(data) {
+			return nil, fmt.Errorf("truncated flags")
+		}
+		flag := data[p]
+		p++
+		flags[i] = flag
+		i++
+		if flag&glyfRepeat != 0 {
+			if p >= len(data) {
+				return nil, fmt.Errorf("truncated flags")
+			}
+			count := int(data[p])
+			p++
+			for ; count > 0 && i < numPoints; count-- {
+				flags[i] = flag
+				i++
+			}
+		}
+	}
+
+	xs := make([]float32, numPoints)
+	ys := make([]float32, numPoints)
+	var err error
+	if p, err = readCoordinates(data, p, flags, xs, glyfXShort, glyfXSameOrPlus); err != nil {
+		return nil, err
+	}
+	if _, err = readCoordinates(data, p, flags, ys, glyfYShort, glyfYSameOrPlus); err != nil {
+		return nil, err
+	}
+
+	var out []Segment
+	start := 0
+	for _, end := range ends {
+		if end < start || end >= numPoints {
+			return nil, fmt.Errorf("invalid contour end point")
+		}
+		out = appendQuadContour(out, flags[start:end+1], xs[start:end+1], ys[start:end+1])
+		start = end + 1
+	}
+	return out, nil
+}
+
+// readCoordinates decodes the delta-encoded X or Y coordinates of a simple glyph.
+//
+// Parameters:
+//   - data: the glyph data
+//   - p: the offset of the coordinates
+//   - flags: the point flags
+//   - out: the absolute coordinates to fill
+//   - shortBit: the flag bit marking a one-byte delta
+//   - sameBit: the flag bit marking a positive short delta or a repeated coordinate
+//
+// Returns:
+//   - int: the offset after the coordinates
+//   - error: an error if the data is truncated
+func readCoordinates(data []byte, p int, flags []byte, out []float32, shortBit, sameBit byte) (int, error) {
+	var v int
+	for i, flag := range flags {
+		switch {
+		case flag&shortBit != 0:
+			if p >= len(data) {
+				return 0, fmt.Errorf("truncated coordinates")
+			}
+			d := int(data[p])
+			p++
+			if flag&sameBit == 0 {
+				d = -d
+			}
+			v += d
+		case flag&sameBit == 0:
+			if p+2 > len(data) {
+				return 0, fmt.Errorf("truncated coordinates")
+			}
+			v += int(int16(binary.BigEndian.Uint16(data[p:])))
+			p += 2
+		}
+		out[i] = float32(v)
+	}
+	return p, nil
+}
+
+// appendQuadContour appends one closed TrueType contour, inserting the implied on-curve
+// points between consecutive off-curve points.
+//
+// Parameters:
+//   - out: the segments to append to
+//   - flags: the point flags of the contour
+//   - xs: the X coordinates of the contour
+//   - ys: the Y coordinates of the contour
+//
+// Returns:
+//   - []Segment: the segments with the contour appended
+func appendQuadContour(out []Segment, flags []byte, xs, ys []float32) []Segment {
+	n := len(flags)
+	if n == 0 {
+		return out
+	}
+	pt := func(i int) [2]float32 { return [2]float32{xs[i%n], ys[i%n]} }
+	on := func(i int) bool { return flags[i%n]&glyfOnCurve != 0 }
+	mid := func(a, b [2]float32) [2]float32 { return [2]float32{(a[0] + b[0]) / 2, (a[1] + b[1]) / 2} }
+
+	// Start at an on-curve point and walk around to it again, which closes the contour. A
+	// contour of only off-curve points starts at the midpoint of its first two points instead.
+	first := -1
+	for i := range n {
+		if on(i) {
+			first = i
+			break
+		}
+	}
+	var startPt, ctrl [2]float32
+	haveCtrl := false
+	begin, count := first+1, n
+	if first < 0 {
+		startPt = mid(pt(0), pt(1))
+		ctrl = pt(1)
+		haveCtrl = true
+		begin, count = 2, n-1
+	} else {
+		startPt = pt(first)
+	}
+	out = append(out, Segment{Op: SegmentMoveTo, Points: [3][2]float32{startPt}})
+
+	for k := range count {
+		i := begin + k
+		p := pt(i)
+		if on(i) {
+			if haveCtrl {
+				out = append(out, Segment{Op: SegmentQuadTo, Points: [3][2]float32{ctrl, p}})
+				haveCtrl = false
+			} else {
+				out = append(out, Segment{Op: SegmentLineTo, Points: [3][2]float32{p}})
+			}
+			continue
+		}
+		if haveCtrl {
+			m := mid(ctrl, p)
+			out = append(out, Segment{Op: SegmentQuadTo, Points: [3][2]float32{ctrl, m}})
+		}
+		ctrl = p
+		haveCtrl = true
+	}
+
+	if first < 0 {
+		out = append(out, Segment{Op: SegmentQuadTo, Points: [3][2]float32{ctrl, startPt}})
+	}
+	return out
+}
+
+// parseCompositeGlyph decodes a composite glyph by transforming and concatenating the
+// outlines of its components.
+//
+// Parameters:
+//   - data: the glyph data
+//   - depth: the composite nesting depth
+//
+// Returns:
+//   - []Segment: the outline in font units
+//   - error: an error if the glyph data is malformed
+func (f *fontImpl) parseCompositeGlyph(data []byte, depth int) ([]Segment, error) {
+	var out []Segment
+	p := 10
+	for {
+		if p+4 > len(data) {
+			return nil, fmt.Errorf("truncated composite component")
+		}
+		flags := binary.BigEndian.Uint16(data[p:])
+		component := binary.BigEndian.Uint16(data[p+2:])
+		p += 4
+
+		var dx, dy float32
+		if flags&compArgsAreWords != 0 {
+			if p+4 > len(data) {
+				return nil, fmt.Errorf("truncated composite arguments")
+			}
+			dx = float32(int16(binary.BigEndian.Uint16(data[p:])))
+			dy = float32(int16(binary.BigEndian.Uint16(data[p+2:])))
+			p += 4
+		} else {
+			if p+2 > len(data) {
+				return nil, fmt.Errorf("truncated composite arguments")
+			}
+			dx = float32(int8(data[p]))
+			dy = float32(int8(data[p+1]))
+			p += 2
+		}
+		if flags&compArgsAreXY == 0 {
+			// Point-matched placement is rare in practice; place the component unshifted.
+			dx, dy = 0, 0
+		}
+
+		// The 2x2 transform, in F2Dot14.
+		a, b, c, d := float32(1), float32(0), float32(0), float32(1)
+		f2dot14 := func(off int) float32 { return float32(int16(binary.BigEndian.Uint16(data[off:]))) / 16384 }
+		switch {
+		case flags&compHaveScale != 0:
+			if p+2 > len(data) {
+				return nil, fmt.Errorf("truncated composite scale")
+			}
+			a = f2dot14(p)
+			d = a
+			p += 2
+		case flags&compHaveXYScale != 0:
+			if p+4 > len(data) {
+				return nil, fmt.Errorf("truncated composite scale")
+			}
+			a, d = f2dot14(p), f2dot14(p+2)
+			p += 4
+		case flags&compHaveTwoByTwo != 0:
+			if p+8 > len(data) {
+				return nil, fmt.Errorf("truncated composite transform")
+			}
+			a, b, c, d = f2dot14(p), f2dot14(p+2), f2dot14(p+4), f2dot14(p+6)
+			p += 8
+		}
+
+		segments, err := f.glyfOutline(component, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range segments {
+			for i := range s.Points {
+				x, y := s.Points[i][0], s.Points[i][1]
+				s.Points[i] = [2]float32{a*x + c*y + dx, b*x + d*y + dy}
+			}
+			out = append(out, s)
+		}
+
+		if flags&compMoreComponents == 0 {
+			return out, nil
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package font
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"sort"
+)
+
+// GPOS lookup types used for kerning.
+const (
+	gposLookupPairPos   = 2
+	gposLookupExtension = 9
+)
+
+// ValueRecord format bits.
+const (
+	valueXPlacement = 0x0001
+	valueYPlacement = 0x0002
+	valueXAdvance   = 0x0004
+)
+
+// pairPosTable is one GPOS pair adjustment subtable, read lazily from the table data.
+type pairPosTable struct {
+	data     []byte // the subtable
+	format   int
+	coverage []byte
+	vf1, vf2 uint16 // value formats of the first and second glyph
+
+	// Format 2: class-based pairs.
+	classDef1, classDef2 []byte
+	class2Count          int
+}
+
+// parseGPOSKerning collects the pair adjustment subtables of the GPOS kern feature.
+// Malformed parts of the table are skipped.
+//
+// Parameters:
+//   - data: the GPOS table
+//
+// Returns:
+//   - []pairPosTable: the pair adjustment subtables, in lookup order
+func parseGPOSKerning(data []byte) []pairPosTable {
+	featureList := sub(data, int(u16(data, 6)))
+	lookupList := sub(data, int(u16(data, 8)))
+	if featureList == nil || lookupList == nil {
+		return nil
+	}
+
+	// A lookup can be referenced by the kern feature of several scripts; use it once.
+	lookups := make(map[uint16]bool)
+	featureCount := int(u16(featureList, 0))
+	for i := range featureCount {
+		rec := 2 + i*6
+		if rec+6 > len(featureList) || string(featureList[rec:rec+4]) != "kern" {
+			continue
+		}
+		feature := sub(featureList, int(u16(featureList, rec+4)))
+		for j := range int(u16(feature, 2)) {
+			lookups[u16(feature, 4+j*2)] = true
+		}
+	}
+	indices := make([]int, 0, len(lookups))
+	for index := range lookups {
+		indices = append(indices, int(index))
+	}
+	sort.Ints(indices)
+
+	var tables []pairPosTable
+	for _, index := range indices {
+		lookup := sub(lookupList, int(u16(lookupList, 2+index*2)))
+		lookupType := u16(lookup, 0)
+		for k := range int(u16(lookup, 4)) {
+			st := sub(lookup, int(u16(lookup, 6+k*2)))
+			stType := lookupType
+			if stType == gposLookupExtension {
+				stType = u16(st, 2)
+				st = sub(st, int(u32(st, 4)))
+			}
+			if stType != gposLookupPairPos {
+				continue
+			}
+			if t, ok := parsePairPos(st); ok {
+				tables = append(tables, t)
+			}
+		}
+	}
+	return tables
+}
+
+// parsePairPos reads the header of a pair adjustment subtable.
+//
+// Parameters:
+//   - data: the subtable
+//
+// Returns:
+//   - pairPosTable: the subtable
+//   - bool: false if the subtable is malformed or of an unknown format
+func parsePairPos(data []byte) (pairPosTable, bool) {
+	if len(data) < 10 {
+		return pairPosTable{}, false
+	}
+	t := pairPosTable{
+		data:     data,
+		format:   int(u16(data, 0)),
+		coverage: sub(data, int(u16(data, 2))),
+		vf1:      u16(data, 4),
+		vf2:      u16(data, 6),
+	}
+	if t.coverage == nil || t.vf1&valueXAdvance == 0 {
+		// Only the first glyph's advance is used for kerning.
+		return pairPosTable{}, false
+	}
+	switch t.format {
+	case 1:
+		return t, true
+	case 2:
+		if len(data) < 16 {
+			return pairPosTable{}, false
+		}
+		t.classDef1 = sub(data, int(u16(data, 8)))
+		t.classDef2 = sub(data, int(u16(data, 10)))
+		t.class2Count = int(u16(data, 14))
+		return t, t.classDef1 != nil && t.classDef2 != nil
+	}
+	return pairPosTable{}, false
+}
+
+// lookup returns the X advance adjustment of the first glyph of a pair.
+//
+// Parameters:
+//   - left: the first glyph
+//   - right: the second glyph
+//
+// Returns:
+//   - int16: the adjustment in font units
+//   - bool: true if the subtable covers the pair
+func (t pairPosTable) lookup(left, right uint16) (int16, bool) {
+	coverageIndex, ok := coverageLookup(t.coverage, left)
+	if !ok {
+		return 0, false
+	}
+	size1 := valueRecordSize(t.vf1)
+	size2 := valueRecordSize(t.vf2)
+	advance := bits.OnesCount16(t.vf1&(valueXPlacement|valueYPlacement)) * 2
+
+	if t.format == 1 {
+		if coverageIndex >= int(u16(t.data, 8)) {
+			return 0, false
+		}
+		pairSet := sub(t.data, int(u16(t.data, 10+coverageIndex*2)))
+		count := int(u16(pairSet, 0))
+		stride := 2 + size1 + size2
+		// Pair value records are sorted by their second glyph.
+		i := sort.Search(count, func(i int) bool { return u16(pairSet, 2+i*stride) >= right })
+		if i == count || u16(pairSet, 2+i*stride) != right {
+			return 0, false
+		}
+		return int16(u16(pairSet, 2+i*stride+2+advance)), true
+	}
+
+	class1 := classLookup(t.classDef1, left)
+	class2 := classLookup(t.classDef2, right)
+	if class1 >= int(u16(t.data, 12)) || class2 >= t.class2Count {
+		return 0, false
+	}
+	rec := 16 + (class1*t.class2Count+class2)*(size1+size2)
+	return int16(u16(t.data, rec+advance)), true
+}
+
+// valueRecordSize returns the size in bytes of a ValueRecord with the given format.
+//
+// Parameters:
+//   - format: the value format bits
+//
+// Returns:
+//   - int: the record size
+func valueRecordSize(format uint16) int {
+	return bits.OnesCount16(format) * 2
+}
+
+// coverageLookup returns the coverage index of a glyph.
+//
+// Parameters:
+//   - data: the coverage table
+//   - glyph: the glyph
+//
+// Returns:
+//   - int: the coverage index
+//   - bool: false if the glyph is not covered
+func coverageLookup(data []byte, glyph uint16) (int, bool) {
+	switch u16(data, 0) {
+	case 1:
+		count := int(u16(data, 2))
+		i := sort.Search(count, func(i int) bool { return u16(data, 4+i*2) >= glyph })
+		if i < count && u16(data, 4+i*2) == glyph {
+			return i, true
+		}
+	case 2:
+		count := int(u16(data, 2))
+		i := sort.Search(count, func(i int) bool { return u16(data, 4+i*6+2) >= glyph })
+		if i < count && u16(data, 4+i*6) <= glyph {
+			start := u16(data, 4+i*6)
+			return int(u16(data, 4+i*6+4)) + int(glyph-start), true
+		}
+	}
+	return 0, false
+}
+
+// classLookup returns the class of a glyph.
+//
+// Parameters:
+//   - data: the class definition table
+//   - glyph: the glyph
+//
+// Returns:
+//   - int: the class, 0 for glyphs the table does not list
+func classLookup(data []byte, glyph uint16) int {
+	switch u16(data, 0) {
+	case 1:
+		start := u16(data, 2)
+		if glyph >= start && int(glyph-start) < int(u16(data, 4)) {
+			return int(u16(data, 6+int(glyph-start)*2))
+		}
+	case 2:
+		count := int(u16(data, 2))
+		i := sort.Search(count, func(i int) bool { return u16(data, 4+i*6+2) >= glyph })
+		if i < count && u16(data, 4+i*6) <= glyph {
+			return int(u16(data, 4+i*6+4))
+		}
+	}
+	return 0
+}
+
+// parseKern reads the horizontal format 0 subtables of a legacy kern table.
+// Malformed subtables are skipped.
+//
+// Parameters:
+//   - data: the kern table
+//
+// Returns:
+//   - map[uint32]int16: the kerning of each pair, keyed by left<<16 | right
+func parseKern(data []byte) map[uint32]int16 {
+	pairs := make(map[uint32]int16)
+	if u16(data, 0) != 0 {
+		// Only the Windows table layout is supported.
+		return pairs
+	}
+	p := 4
+	for range int(u16(data, 2)) {
+		length := int(u16(data, p+2))
+		coverage := u16(data, p+4)
+		format := coverage >> 8
+		// Horizontal, not minimum values, not cross-stream.
+		if format == 0 && coverage&0x07 == 0x01 {
+			count := int(u16(data, p+6))
+			for i := range count {
+				rec := p + 14 + i*6
+				if rec+6 > len(data) {
+					break
+				}
+				key := uint32(u16(data, rec))<<16 | uint32(u16(data, rec+2))
+				pairs[key] += int16(u16(data, rec+4))
+			}
+		}
+		if length < 6 {
+			break
+		}
+		p += length
+	}
+	return pairs
+}
+
+// u16 reads a big-endian uint16, returning 0 past the end of the data.
+func u16(data []byte, off int) uint16 {
+	if off < 0 || off+2 > len(data) {
+		return 0
+	}
+	return binary.BigEndian.Uint16(data[off:])
+}
+
+// u32 reads a big-endian uint32, returning 0 past the end of the data.
+func u32(data []byte, off int) uint32 {
+	if off < 0 || off+4 > len(data) {
+		return 0
+	}
+	return binary.BigEndian.Uint32(data[off:])
+}
+
+// sub returns the data from an offset on, or nil if the offset is zero or out of range.
+func sub(data []byte, off int) []byte {
+	if off <= 0 || off >= len(data) {
+		return nil
+	}
+	return data[off:]
+}
@@ -0,0 +1,239 @@
+package font
+
+import (
+	"unicode"
+)
+
+// tabWidth is the width of a tab in spaces.
+const tabWidth = 4
+
+// Align is the horizontal alignment of the lines of a text layout.
+type Align int
+
+const (
+	// AlignLeft aligns lines to the left edge of the layout box.
+	AlignLeft Align = iota
+	// AlignCenter centers lines in the layout box.
+	AlignCenter
+	// AlignRight aligns lines to the right edge of the layout box.
+	AlignRight
+)
+
+// layoutConfig holds the options of one Layout call.
+type layoutConfig struct {
+	size       float32
+	maxWidth   float32
+	align      Align
+	lineHeight float32
+}
+
+// LayoutOption configures the layout of a string.
+type LayoutOption func(*layoutConfig)
+
+// WithSize sets the font size: the height of the em square in pixels.
+// Default: 16.
+//
+// Parameters:
+//   - px: the font size in pixels
+//
+// Returns:
+//   - LayoutOption: the option
+func WithSize(px float32) LayoutOption {
+	return func(c *layoutConfig) {
+		if px > 0 {
+			c.size = px
+		}
+	}
+}
+
+// WithMaxWidth wraps lines that would be wider than a width, breaking between words and
+// within words longer than a line. Default: 0, no wrapping.
+//
+// Parameters:
+//   - px: the maximum line width in pixels
+//
+// Returns:
+//   - LayoutOption: the option
+func WithMaxWidth(px float32) LayoutOption {
+	return func(c *layoutConfig) {
+		c.maxWidth = max(px, 0)
+	}
+}
+
+// WithAlign sets the horizontal alignment of the lines, within the maximum width when one is
+// set and within the widest line otherwise. Default: AlignLeft.
+//
+// Parameters:
+//   - align: the alignment
+//
+// Returns:
+//   - LayoutOption: the option
+func WithAlign(align Align) LayoutOption {
+	return func(c *layoutConfig) {
+		c.align = align
+	}
+}
+
+// WithLineHeight scales the font's line spacing. Default: 1.
+//
+// Parameters:
+//   - multiplier: the line spacing multiplier
+//
+// Returns:
+//   - LayoutOption: the option
+func WithLineHeight(multiplier float32) LayoutOption {
+	return func(c *layoutConfig) {
+		if multiplier > 0 {
+			c.lineHeight = multiplier
+		}
+	}
+}
+
+// LayoutGlyph is one positioned glyph of a text layout.
+type LayoutGlyph struct {
+	Glyph   uint16  // glyph index in the font
+	Rune    rune    // the rune the glyph represents
+	Index   int     // byte offset of the rune in the laid out string
+	X       float32 // pen position of the glyph origin, in pixels from the left of the layout box
+	Y       float32 // baseline of the glyph's line, in pixels down from the top of the layout box
+	Advance float32 // horizontal advance in pixels
+	Line    int     // index of the glyph's line
+}
+
+// LayoutLine is one line of a text layout.
+type LayoutLine struct {
+	Start    int     // index of the line's first glyph
+	End      int     // index after the line's last glyph
+	Width    float32 // width in pixels, excluding trailing spaces
+	Baseline float32 // baseline in pixels down from the top of the layout box
+}
+
+// TextLayout is a string laid out in lines of positioned glyphs, in pixels with the Y axis
+// pointing down from the top-left of the layout box.
+type TextLayout struct {
+	Glyphs     []LayoutGlyph
+	Lines      []LayoutLine // at least one, empty for an empty string
+	Width      float32      // width of the widest line
+	Height     float32      // height of all lines
+	Size       float32      // font size in pixels
+	LineHeight float32      // distance between baselines in pixels
+}
+
+// Layout lays out a UTF-8 string with a font. Consecutive glyphs are kerned, '\n' starts a new
+// line, tabs are four spaces wide, and lines longer than WithMaxWidth are wrapped between words,
+// or within a word that does not fit on a line of its own. Invalid UTF-8 is laid out as
+// U+FFFD. Layout needs no GPU.
+//
+// Parameters:
+//   - f: the font
+//   - s: the string
+//   - opts: optional layout options
+//
+// Returns:
+//   - TextLayout: the laid out glyphs and lines
+func Layout(f Font, s string, opts ...LayoutOption) TextLayout {
+	cfg := layoutConfig{size: 16, lineHeight: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	scale := cfg.size / float32(f.UnitsPerEm())
+	out := TextLayout{
+		Size:       cfg.size,
+		LineHeight: float32(f.Ascent()-f.Descent()+f.LineGap()) * scale * cfg.lineHeight,
+	}
+
+	space := f.GlyphIndex(' ')
+	spaceAdvance := float32(f.Advance(space)) * scale
+
+	lineStarts := []int{0}
+	var penX float32
+	prev := -1
+	wrapAt := -1 // index of the first glyph after the last space on the line
+	for i, r := range s {
+		if r == '\n' {
+			lineStarts = append(lineStarts, len(out.Glyphs))
+			penX, prev, wrapAt = 0, -1, -1
+			continue
+		}
+		if r == '\r' {
+			continue
+		}
+
+		g := f.GlyphIndex(r)
+		advance := float32(f.Advance(g)) * scale
+		if r == '\t' {
+			g = space
+			advance = spaceAdvance * tabWidth
+		}
+		x := penX
+		if prev >= 0 {
+			x += float32(f.Kerning(uint16(prev), g)) * scale
+		}
+
+		lineStart := lineStarts[len(lineStarts)-1]
+		if cfg.maxWidth > 0 && !unicode.IsSpace(r) && x+advance > cfg.maxWidth && len(out.Glyphs) > lineStart {
+			if wrapAt > lineStart {
+				// Move the word being laid out to a new line.
+				shift := x
+				if wrapAt < len(out.Glyphs) {
+					shift = out.Glyphs[wrapAt].X
+				}
+				for j := wrapAt; j < len(out.Glyphs); j++ {
+					out.Glyphs[j].X -= shift
+				}
+				lineStarts = append(lineStarts, wrapAt)
+				x -= shift
+			} else {
+				// The word does not fit on a line of its own; break it here.
+				lineStarts = append(lineStarts, len(out.Glyphs))
+				x = 0
+			}
+			wrapAt = -1
+		}
+
+		out.Glyphs = append(out.Glyphs, LayoutGlyph{Glyph: g, Rune: r, Index: i, X: x, Advance: advance})
+		penX = x + advance
+		prev = int(g)
+		if unicode.IsSpace(r) {
+			wrapAt = len(out.Glyphs)
+		}
+	}
+
+	ascent := float32(f.Ascent()) * scale
+	out.Lines = make([]LayoutLine, len(lineStarts))
+	for k, start := range lineStarts {
+		end := len(out.Glyphs)
+		if k+1 < len(lineStarts) {
+			end = lineStarts[k+1]
+		}
+		line := LayoutLine{Start: start, End: end, Baseline: ascent + float32(k)*out.LineHeight}
+		for _, g := range out.Glyphs[start:end] {
+			if !unicode.IsSpace(g.Rune) {
+				line.Width = max(line.Width, g.X+g.Advance)
+			}
+		}
+		out.Lines[k] = line
+		out.Width = max(out.Width, line.Width)
+	}
+	out.Height = float32(len(out.Lines)) * out.LineHeight
+
+	box := out.Width
+	if cfg.maxWidth > 0 {
+		box = cfg.maxWidth
+	}
+	for k, line := range out.Lines {
+		var offset float32
+		switch cfg.align {
+		case AlignCenter:
+			offset = (box - line.Width) / 2
+		case AlignRight:
+			offset = box - line.Width
+		}
+		for j := line.Start; j < line.End; j++ {
+			out.Glyphs[j].X += offset
+			out.Glyphs[j].Y = line.Baseline
+			out.Glyphs[j].Line = k
+		}
+	}
+	return out
+}
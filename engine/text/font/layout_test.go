@@ -0,0 +1,221 @@
+package font
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+// testFont is a Font with 100 units per em, every glyph 10 units wide and the glyph index
+// equal to the rune, so a layout at size 100 is measured in font units.
+type testFont struct {
+	kerning map[[2]uint16]int
+}
+
+var _ Font = &testFont{}
+
+func (f *testFont) Name() string    { return "test" }
+func (f *testFont) UnitsPerEm() int { return 100 }
+func (f *testFont) Ascent() int     { return 80 }
+func (f *testFont) Descent() int    { return -20 }
+func (f *testFont) LineGap() int    { return 0 }
+func (f *testFont) NumGlyphs() int  { return 0x10000 }
+
+func (f *testFont) GlyphIndex(r rune) uint16 {
+	if r < 0 || r > 0xFFFF {
+		return 0
+	}
+	return uint16(r)
+}
+
+func (f *testFont) Advance(glyph uint16) int {
+	return 10
+}
+
+func (f *testFont) Kerning(left, right uint16) int {
+	return f.kerning[[2]uint16{left, right}]
+}
+
+func (f *testFont) Outline(glyph uint16) ([]Segment, error) {
+	return nil, nil
+}
+
+// lineText returns the runes of one line of a layout.
+func lineText(l TextLayout, line int) string {
+	var s []rune
+	for _, g := range l.Glyphs[l.Lines[line].Start:l.Lines[line].End] {
+		s = append(s, g.Rune)
+	}
+	return string(s)
+}
+
+// glyphX returns the X positions of the glyphs of a layout.
+func glyphX(l TextLayout) []float32 {
+	xs := make([]float32, len(l.Glyphs))
+	for i, g := range l.Glyphs {
+		xs[i] = g.X
+	}
+	return xs
+}
+
+func equalX(a, b []float32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLayoutMetrics(t *testing.T) {
+	l := Layout(&testFont{}, "abc", WithSize(50))
+	if l.Size != 50 || l.LineHeight != 50 {
+		t.Fatalf("size %v, line height %v, want 50, 50", l.Size, l.LineHeight)
+	}
+	if want := []float32{0, 5, 10}; !equalX(glyphX(l), want) {
+		t.Errorf("glyph X %v, want %v", glyphX(l), want)
+	}
+	if l.Width != 15 || l.Height != 50 {
+		t.Errorf("size %vx%v, want 15x50", l.Width, l.Height)
+	}
+
+	l = Layout(&testFont{}, "", WithSize(100))
+	if len(l.Lines) != 1 || len(l.Glyphs) != 0 || l.Height != 100 {
+		t.Errorf("empty layout: %d lines, %d glyphs, height %v, want 1, 0, 100", len(l.Lines), len(l.Glyphs), l.Height)
+	}
+}
+
+func TestLayoutKerning(t *testing.T) {
+	f := &testFont{kerning: map[[2]uint16]int{{'A', 'V'}: -3, {'V', 'A'}: -2}}
+
+	l := Layout(f, "AVAB", WithSize(100))
+	if want := []float32{0, 7, 15, 25}; !equalX(glyphX(l), want) {
+		t.Errorf("glyph X %v, want %v", glyphX(l), want)
+	}
+	if l.Width != 35 {
+		t.Errorf("width %v, want 35", l.Width)
+	}
+
+	// Kerning only applies within a line.
+	l = Layout(f, "A\nV", WithSize(100))
+	if l.Glyphs[1].X != 0 {
+		t.Errorf("glyph after newline at X %v, want 0", l.Glyphs[1].X)
+	}
+}
+
+func TestLayoutNewlines(t *testing.T) {
+	l := Layout(&testFont{}, "ab\ncd\r\n\nef\n", WithSize(100))
+	want := []string{"ab", "cd", "", "ef", ""}
+	if len(l.Lines) != len(want) {
+		t.Fatalf("%d lines, want %d", len(l.Lines), len(want))
+	}
+	for i, s := range want {
+		if got := lineText(l, i); got != s {
+			t.Errorf("line %d is %q, want %q", i, got, s)
+		}
+		if base := 80 + float32(i)*100; l.Lines[i].Baseline != base {
+			t.Errorf("line %d baseline %v, want %v", i, l.Lines[i].Baseline, base)
+		}
+	}
+	for _, g := range l.Glyphs {
+		if g.Y != l.Lines[g.Line].Baseline {
+			t.Errorf("glyph %q at Y %v, want its line's baseline %v", g.Rune, g.Y, l.Lines[g.Line].Baseline)
+		}
+	}
+	if want := []float32{0, 10, 0, 10, 0, 10}; !equalX(glyphX(l), want) {
+		t.Errorf("glyph X %v, want %v", glyphX(l), want)
+	}
+	if l.Height != 500 {
+		t.Errorf("height %v, want 500", l.Height)
+	}
+}
+
+func TestLayoutWrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		maxWidth float32
+		lines    []string
+		widths   []float32
+	}{
+		{"no wrap", "aa bb cc", 0, []string{"aa bb cc"}, []float32{80}},
+		{"fits", "aa bb", 50, []string{"aa bb"}, []float32{50}},
+		{"between words", "aa bb cc", 55, []string{"aa bb ", "cc"}, []float32{50, 20}},
+		{"each word", "aa bb cc", 25, []string{"aa ", "bb ", "cc"}, []float32{20, 20, 20}},
+		{"within a word", "abcdefgh", 35, []string{"abc", "def", "gh"}, []float32{30, 30, 20}},
+		{"long word after short", "a bcdef", 35, []string{"a ", "bcd", "ef"}, []float32{10, 30, 20}},
+		{"narrower than a glyph", "ab", 5, []string{"a", "b"}, []float32{10, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Layout(&testFont{}, tt.s, WithSize(100), WithMaxWidth(tt.maxWidth))
+			if len(l.Lines) != len(tt.lines) {
+				t.Fatalf("%d lines, want %d", len(l.Lines), len(tt.lines))
+			}
+			for i, s := range tt.lines {
+				if got := lineText(l, i); got != s {
+					t.Errorf("line %d is %q, want %q", i, got, s)
+				}
+				if l.Lines[i].Width != tt.widths[i] {
+					t.Errorf("line %d width %v, want %v", i, l.Lines[i].Width, tt.widths[i])
+				}
+				if first := l.Glyphs[l.Lines[i].Start]; first.X != 0 {
+					t.Errorf("line %d starts at X %v, want 0", i, first.X)
+				}
+			}
+		})
+	}
+}
+
+func TestLayoutAlign(t *testing.T) {
+	tests := []struct {
+		name     string
+		align    Align
+		maxWidth float32
+		x        []float32 // X of the first glyph of each line
+	}{
+		{"left", AlignLeft, 0, []float32{0, 0}},
+		{"center", AlignCenter, 0, []float32{10, 0}},
+		{"right", AlignRight, 0, []float32{20, 0}},
+		{"center in max width", AlignCenter, 100, []float32{40, 30}},
+		{"right in max width", AlignRight, 100, []float32{80, 60}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Layout(&testFont{}, "ab\nabcd", WithSize(100), WithAlign(tt.align), WithMaxWidth(tt.maxWidth))
+			for i, line := range l.Lines {
+				if got := l.Glyphs[line.Start].X; got != tt.x[i] {
+					t.Errorf("line %d starts at X %v, want %v", i, got, tt.x[i])
+				}
+			}
+		})
+	}
+
+	// Trailing spaces of a wrapped line do not count towards its alignment.
+	l := Layout(&testFont{}, "aa bb", WithSize(100), WithMaxWidth(40), WithAlign(AlignRight))
+	if got := l.Glyphs[0].X; got != 20 {
+		t.Errorf("wrapped line starts at X %v, want 20", got)
+	}
+}
+
+func TestLayoutInvalidUTF8(t *testing.T) {
+	l := Layout(&testFont{}, "a\xffb\xc3", WithSize(100))
+	want := []struct {
+		r     rune
+		index int
+	}{{'a', 0}, {utf8.RuneError, 1}, {'b', 2}, {utf8.RuneError, 3}}
+	if len(l.Glyphs) != len(want) {
+		t.Fatalf("%d glyphs, want %d", len(l.Glyphs), len(want))
+	}
+	for i, w := range want {
+		g := l.Glyphs[i]
+		if g.Rune != w.r || g.Index != w.index || g.Glyph != uint16(w.r) {
+			t.Errorf("glyph %d is %q (glyph %d) at byte %d, want %q at byte %d", i, g.Rune, g.Glyph, g.Index, w.r, w.index)
+		}
+	}
+	if want := []float32{0, 10, 20, 30}; !equalX(glyphX(l), want) {
+		t.Errorf("glyph X %v, want %v", glyphX(l), want)
+	}
+}
@@ -0,0 +1,206 @@
+package font
+
+import (
+	"math"
+)
+
+// sdfBitmap is a glyph rasterized as a signed distance field.
+type sdfBitmap struct {
+	width, height int
+	left, top     float32 // offset of the bitmap's top-left corner from the glyph origin, Y up
+	pixels        []byte  // one byte per pixel, rows top to bottom
+}
+
+// edge is a straight piece of a flattened outline, in pixels.
+type edge struct {
+	ax, ay, bx, by float32
+}
+
+// rasterizeSDF rasterizes a glyph outline into a signed distance field. Each pixel stores the
+// distance from its center to the outline, 128 on the outline itself, rising towards 255 inside
+// the glyph and falling towards 0 outside, saturating at spread pixels from the outline.
+//
+// Parameters:
+//   - segments: the outline in font units
+//   - scale: pixels per font unit
+//   - spread: the distance range in pixels, also the padding around the glyph
+//
+// Returns:
+//   - sdfBitmap: the distance field, empty for glyphs without an outline
+func rasterizeSDF(segments []Segment, scale float32, spread int) sdfBitmap {
+	edges := flatten(segments, scale)
+	if len(edges) == 0 {
+		return sdfBitmap{}
+	}
+
+	minX, minY := float32(math.MaxFloat32), float32(math.MaxFloat32)
+	maxX, maxY := float32(-math.MaxFloat32), float32(-math.MaxFloat32)
+	for _, e := range edges {
+		minX, maxX = min(minX, e.ax, e.bx), max(maxX, e.ax, e.bx)
+		minY, maxY = min(minY, e.ay, e.by), max(maxY, e.ay, e.by)
+	}
+	x0 := int(math.Floor(float64(minX))) - spread
+	y0 := int(math.Floor(float64(minY))) - spread
+	x1 := int(math.Ceil(float64(maxX))) + spread
+	y1 := int(math.Ceil(float64(maxY))) + spread
+
+	bmp := sdfBitmap{
+		width:  x1 - x0,
+		height: y1 - y0,
+		left:   float32(x0),
+		top:    float32(y1),
+	}
+	bmp.pixels = make([]byte, bmp.width*bmp.height)
+
+	for row := range bmp.height {
+		// Rows run top to bottom while the outline's Y axis points up.
+		py := float32(y1-row) - 0.5
+		for col := range bmp.width {
+			px := float32(x0+col) + 0.5
+			dist := float32(math.Sqrt(float64(minDistanceSq(edges, px, py))))
+			if winding(edges, px, py) != 0 {
+				dist = -dist
+			}
+			v := clamp(0.5-dist/float32(2*spread), 0, 1)
+			bmp.pixels[row*bmp.width+col] = byte(v*255 + 0.5)
+		}
+	}
+	return bmp
+}
+
+// flatten converts an outline into straight edges in pixels, subdividing curves by their
+// control polygon length.
+//
+// Parameters:
+//   - segments: the outline in font units
+//   - scale: pixels per font unit
+//
+// Returns:
+//   - []edge: the closed edge loops of the outline
+func flatten(segments []Segment, scale float32) []edge {
+	var edges []edge
+	var startX, startY, x, y float32
+	closeContour := func() {
+		if x != startX || y != startY {
+			edges = append(edges, edge{x, y, startX, startY})
+		}
+	}
+	lineTo := func(nx, ny float32) {
+		if nx != x || ny != y {
+			edges = append(edges, edge{x, y, nx, ny})
+		}
+		x, y = nx, ny
+	}
+
+	open := false
+	for _, s := range segments {
+		p := s.Points
+		for i := range p {
+			p[i][0] *= scale
+			p[i][1] *= scale
+		}
+		switch s.Op {
+		case SegmentMoveTo:
+			if open {
+				closeContour()
+			}
+			startX, startY = p[0][0], p[0][1]
+			x, y = startX, startY
+			open = true
+		case SegmentLineTo:
+			lineTo(p[0][0], p[0][1])
+		case SegmentQuadTo:
+			x0, y0 := x, y
+			n := curveSteps(x0, y0, p[:2])
+			for i := 1; i <= n; i++ {
+				t := float32(i) / float32(n)
+				u := 1 - t
+				lineTo(u*u*x0+2*u*t*p[0][0]+t*t*p[1][0], u*u*y0+2*u*t*p[0][1]+t*t*p[1][1])
+			}
+		case SegmentCubeTo:
+			x0, y0 := x, y
+			n := curveSteps(x0, y0, p[:3])
+			for i := 1; i <= n; i++ {
+				t := float32(i) / float32(n)
+				u := 1 - t
+				a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+				lineTo(a*x0+b*p[0][0]+c*p[1][0]+d*p[2][0], a*y0+b*p[0][1]+c*p[1][1]+d*p[2][1])
+			}
+		}
+	}
+	if open {
+		closeContour()
+	}
+	return edges
+}
+
+// curveSteps returns the number of edges to flatten a curve into, about one per two pixels
+// of its control polygon.
+//
+// Parameters:
+//   - x: the X coordinate of the curve's start point
+//   - y: the Y coordinate of the curve's start point
+//   - points: the control points and end point
+//
+// Returns:
+//   - int: the number of edges, between 1 and 32
+func curveSteps(x, y float32, points [][2]float32) int {
+	var length float64
+	for _, p := range points {
+		length += math.Hypot(float64(p[0]-x), float64(p[1]-y))
+		x, y = p[0], p[1]
+	}
+	return min(max(int(length/2), 1), 32)
+}
+
+// minDistanceSq returns the squared distance from a point to the nearest edge.
+//
+// Parameters:
+//   - edges: the edges
+//   - px: the X coordinate of the point
+//   - py: the Y coordinate of the point
+//
+// Returns:
+//   - float32: the squared distance
+func minDistanceSq(edges []edge, px, py float32) float32 {
+	best := float32(math.MaxFloat32)
+	for _, e := range edges {
+		dx, dy := e.bx-e.ax, e.by-e.ay
+		t := float32(0)
+		if lenSq := dx*dx + dy*dy; lenSq > 0 {
+			t = clamp(((px-e.ax)*dx+(py-e.ay)*dy)/lenSq, 0, 1)
+		}
+		cx, cy := e.ax+t*dx-px, e.ay+t*dy-py
+		best = min(best, cx*cx+cy*cy)
+	}
+	return best
+}
+
+// winding returns the non-zero winding number of the outline around a point.
+//
+// Parameters:
+//   - edges: the closed edge loops
+//   - px: the X coordinate of the point
+//   - py: the Y coordinate of the point
+//
+// Returns:
+//   - int: the winding number, 0 outside the glyph
+func winding(edges []edge, px, py float32) int {
+	w := 0
+	for _, e := range edges {
+		// The cross product's sign tells which side of the edge the point is on.
+		side := (e.bx-e.ax)*(py-e.ay) - (px-e.ax)*(e.by-e.ay)
+		if e.ay <= py {
+			if e.by > py && side > 0 {
+				w++
+			}
+		} else if e.by <= py && side < 0 {
+			w--
+		}
+	}
+	return w
+}
+
+func clamp(x, lo, hi float32) float32 {
+	return min(max(x, lo), hi)
+}
@@ -0,0 +1,17 @@
+package text
+
+import (
+	_ "embed"
+)
+
+// VertexSource is the vertex shader of the text pass. It pulls each glyph quad from the TextGlyph
+// storage buffer by vertex index and places it in screen space or as a camera-facing billboard.
+//
+//go:embed assets/text-vert.wgsl
+var VertexSource string
+
+// FragmentSource is the fragment shader of the text pass. It shades the glyph, its outline and
+// its anti-aliased edges from the signed distance field atlas.
+//
+//go:embed assets/text-frag.wgsl
+var FragmentSource string
@@ -0,0 +1,224 @@
+package text
+
+import (
+	"time"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/text/font"
+)
+
+// textConfig holds the options of one Measure, DrawScreen or DrawWorld call.
+type textConfig struct {
+	size         float32
+	maxWidth     float32
+	align        font.Align
+	lineHeight   float32
+	color        [4]float32
+	outlineColor [4]float32
+	outlineWidth float32
+	shadowColor  [4]float32
+	shadowOffset [2]float32
+	shadow       bool
+	worldScale   float32
+	anchor       *[2]float32
+	depthTest    bool
+	lifetime     time.Duration
+}
+
+// TextOption configures the layout and appearance of a string.
+type TextOption func(*textConfig)
+
+// WithSize sets the font size: the height of the em square in pixels.
+// Default: 16.
+//
+// Parameters:
+//   - px: the font size in pixels
+//
+// Returns:
+//   - TextOption: the option
+func WithSize(px float32) TextOption {
+	return func(c *textConfig) {
+		if px > 0 {
+			c.size = px
+		}
+	}
+}
+
+// WithMaxWidth wraps lines that would be wider than a width, breaking between words and
+// within words longer than a line. Default: 0, no wrapping.
+//
+// Parameters:
+//   - px: the maximum line width in pixels
+//
+// Returns:
+//   - TextOption: the option
+func WithMaxWidth(px float32) TextOption {
+	return func(c *textConfig) {
+		c.maxWidth = max(px, 0)
+	}
+}
+
+// WithAlign sets the horizontal alignment of the lines, within the maximum width when one is
+// set and within the widest line otherwise. Default: font.AlignLeft.
+//
+// Parameters:
+//   - align: the alignment
+//
+// Returns:
+//   - TextOption: the option
+func WithAlign(align font.Align) TextOption {
+	return func(c *textConfig) {
+		c.align = align
+	}
+}
+
+// WithLineHeight scales the font's line spacing. Default: 1.
+//
+// Parameters:
+//   - multiplier: the line spacing multiplier
+//
+// Returns:
+//   - TextOption: the option
+func WithLineHeight(multiplier float32) TextOption {
+	return func(c *textConfig) {
+		if multiplier > 0 {
+			c.lineHeight = multiplier
+		}
+	}
+}
+
+// WithColor sets the fill color as linear RGBA. Default: opaque white.
+//
+// Parameters:
+//   - color: the fill color
+//
+// Returns:
+//   - TextOption: the option
+func WithColor(color [4]float32) TextOption {
+	return func(c *textConfig) {
+		c.color = color
+	}
+}
+
+// WithOutline draws an outline around the glyphs. The width is limited by the atlas spread.
+// Default: no outline.
+//
+// Parameters:
+//   - color: the outline color as linear RGBA
+//   - px: the outline width in pixels at the font size
+//
+// Returns:
+//   - TextOption: the option
+func WithOutline(color [4]float32, px float32) TextOption {
+	return func(c *textConfig) {
+		c.outlineColor = color
+		c.outlineWidth = max(px, 0)
+	}
+}
+
+// WithShadow draws a drop shadow under the text, including its outline. Default: no shadow.
+//
+// Parameters:
+//   - color: the shadow color as linear RGBA
+//   - offset: the shadow offset in pixels at the font size, X right and Y down
+//
+// Returns:
+//   - TextOption: the option
+func WithShadow(color [4]float32, offset [2]float32) TextOption {
+	return func(c *textConfig) {
+		c.shadowColor = color
+		c.shadowOffset = offset
+		c.shadow = true
+	}
+}
+
+// WithWorldScale sets the world units per pixel of text drawn in world space.
+// Default: 0.01, so 100 pixels of text span one world unit.
+//
+// Parameters:
+//   - unitsPerPixel: the world size of one pixel
+//
+// Returns:
+//   - TextOption: the option
+func WithWorldScale(unitsPerPixel float32) TextOption {
+	return func(c *textConfig) {
+		if unitsPerPixel > 0 {
+			c.worldScale = unitsPerPixel
+		}
+	}
+}
+
+// WithAnchor sets the point of the layout box placed at the draw position, as fractions of its
+// width and height from the top-left corner. Default: (0, 0) on screen, the top-left corner,
+// and (0.5, 1) in world space, centered above the position.
+//
+// Parameters:
+//   - anchor: the anchor point, (0, 0) top-left to (1, 1) bottom-right
+//
+// Returns:
+//   - TextOption: the option
+func WithAnchor(anchor [2]float32) TextOption {
+	return func(c *textConfig) {
+		c.anchor = &anchor
+	}
+}
+
+// WithDepthTest sets whether world-space text is hidden behind scene geometry. Screen-space
+// text is always drawn over the scene. Default: true.
+//
+// Parameters:
+//   - enabled: whether the text is depth tested
+//
+// Returns:
+//   - TextOption: the option
+func WithDepthTest(enabled bool) TextOption {
+	return func(c *textConfig) {
+		c.depthTest = enabled
+	}
+}
+
+// WithLifetime keeps drawn text for a duration. Default: 0, drawn by the next Draw only.
+//
+// Parameters:
+//   - d: how long the text is drawn
+//
+// Returns:
+//   - TextOption: the option
+func WithLifetime(d time.Duration) TextOption {
+	return func(c *textConfig) {
+		c.lifetime = max(d, 0)
+	}
+}
+
+// newTextConfig applies options over the defaults.
+//
+// Parameters:
+//   - opts: the options
+//
+// Returns:
+//   - textConfig: the resolved options
+func newTextConfig(opts []TextOption) textConfig {
+	c := textConfig{
+		size:       16,
+		lineHeight: 1,
+		color:      [4]float32{1, 1, 1, 1},
+		worldScale: 0.01,
+		depthTest:  true,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// layoutOptions returns the layout options of a resolved text configuration.
+//
+// Returns:
+//   - []font.LayoutOption: the size, wrap width, alignment and line height options
+func (c textConfig) layoutOptions() []font.LayoutOption {
+	return []font.LayoutOption{
+		font.WithSize(c.size),
+		font.WithMaxWidth(c.maxWidth),
+		font.WithAlign(c.align),
+		font.WithLineHeight(c.lineHeight),
+	}
+}
@@ -0,0 +1,271 @@
+package text
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/camera"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+	"github.com/Carmen-Shannon/oxy-go/engine/text/font"
+)
+
+// defaultMaxGlyphs is the default capacity of the glyph buffer.
+const defaultMaxGlyphs = 16384
+
+// textGlyph is one queued glyph quad.
+type textGlyph struct {
+	anchor       [3]float32 // screen position in pixels, or world position
+	world        bool
+	rect         [4]float32 // top-left offset and size of the quad in pixels from the anchor, Y down
+	uv           [4]float32
+	color        uint32 // RGBA packed as four unorm8 values, red in the low byte
+	outlineColor uint32
+	outlineWidth float32 // in distance field units, 0 for no outline
+	scale        float32 // world units per pixel, 1 on screen
+	depthTest    bool
+	expires      time.Time // zero for glyphs drawn by the next Draw only
+}
+
+// textRendererImpl is the implementation of the TextRenderer interface.
+type textRendererImpl struct {
+	mu        *sync.Mutex
+	atlas     font.Atlas
+	maxGlyphs int
+	glyphs    []textGlyph
+
+	r            renderer.Renderer
+	glyphBGP     bind_group_provider.BindGroupProvider
+	atlasBGP     bind_group_provider.BindGroupProvider
+	atlasBinding int    // binding of the atlas texture in the fragment shader's group
+	atlasVersion uint64 // atlas version last uploaded
+	glyphData    []byte
+}
+
+// TextRenderer defines the interface for drawing strings with a glyph atlas. Strings are laid
+// out and queued from tick or render callbacks, in screen space or as billboards in world
+// space, and drawn in one batch at the end of a scene's draw.
+//
+// Screen-space text is positioned in pixels from the top-left of the scene's viewport and
+// drawn over the scene. World-space text always faces the camera and is hidden behind scene
+// geometry unless drawn with WithDepthTest(false). Glyphs missing from the atlas are rasterized
+// on first use. A TextRenderer is safe for concurrent use.
+type TextRenderer interface {
+	// Atlas returns the glyph atlas the renderer draws with.
+	//
+	// Returns:
+	//   - font.Atlas: the atlas
+	Atlas() font.Atlas
+
+	// Measure lays out a string with the atlas font without drawing it.
+	//
+	// Parameters:
+	//   - s: the string
+	//   - opts: optional layout options
+	//
+	// Returns:
+	//   - font.TextLayout: the laid out glyphs and lines
+	Measure(s string, opts ...TextOption) font.TextLayout
+
+	// DrawScreen queues a string in screen space. The WithAnchor point of its layout box is
+	// placed at the position, the top-left corner by default.
+	//
+	// Parameters:
+	//   - s: the string
+	//   - x: the X position in pixels from the left of the viewport
+	//   - y: the Y position in pixels from the top of the viewport
+	//   - opts: optional text options
+	DrawScreen(s string, x, y float32, opts ...TextOption)
+
+	// DrawWorld queues a string as a camera-facing billboard in world space. The WithAnchor
+	// point of its layout box is placed at the position, the bottom center by default, and
+	// WithWorldScale sets its world size.
+	//
+	// Parameters:
+	//   - s: the string
+	//   - position: the world position
+	//   - opts: optional text options
+	DrawWorld(s string, position [3]float32, opts ...TextOption)
+
+	// GlyphCount returns the number of glyphs currently queued, including shadow copies.
+	//
+	// Returns:
+	//   - int: the queued glyph count
+	GlyphCount() int
+
+	// Clear drops all queued text, including text whose lifetime has not ended.
+	Clear()
+
+	// Draw uploads the atlas if glyphs were added to it, writes the queued glyphs to the GPU and
+	// draws them into the current render pass, then drops the text whose lifetime has ended.
+	// Pipelines and buffers are created on the first call. Glyphs beyond the glyph capacity are
+	// not drawn, and world-space text is not drawn without a camera.
+	//
+	// Parameters:
+	//   - r: the renderer recording the current render pass
+	//   - cam: the camera world-space text is drawn with
+	//   - width: the width of the viewport in pixels
+	//   - height: the height of the viewport in pixels
+	//
+	// Returns:
+	//   - error: an error if GPU resource creation or a draw fails
+	Draw(r renderer.Renderer, cam camera.Camera, width, height float32) error
+
+	// Release releases the renderer's GPU resources. The shared pipelines stay registered.
+	Release()
+}
+
+var _ TextRenderer = &textRendererImpl{}
+
+// NewTextRenderer creates a text renderer drawing with a glyph atlas. It creates no GPU
+// resources; those are created by the first Draw.
+//
+// Parameters:
+//   - atlas: the glyph atlas
+//   - opts: optional builder options
+//
+// Returns:
+//   - TextRenderer: the new text renderer
+func NewTextRenderer(atlas font.Atlas, opts ...TextRendererBuilderOption) TextRenderer {
+	t := &textRendererImpl{
+		mu:        &sync.Mutex{},
+		atlas:     atlas,
+		maxGlyphs: defaultMaxGlyphs,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *textRendererImpl) Atlas() font.Atlas {
+	return t.atlas
+}
+
+func (t *textRendererImpl) Measure(s string, opts ...TextOption) font.TextLayout {
+	return font.Layout(t.atlas.Font(), s, newTextConfig(opts).layoutOptions()...)
+}
+
+func (t *textRendererImpl) DrawScreen(s string, x, y float32, opts ...TextOption) {
+	cfg := newTextConfig(opts)
+	anchor := [2]float32{0, 0}
+	if cfg.anchor != nil {
+		anchor = *cfg.anchor
+	}
+	t.push(s, cfg, [3]float32{x, y, 0}, false, anchor)
+}
+
+func (t *textRendererImpl) DrawWorld(s string, position [3]float32, opts ...TextOption) {
+	cfg := newTextConfig(opts)
+	anchor := [2]float32{0.5, 1}
+	if cfg.anchor != nil {
+		anchor = *cfg.anchor
+	}
+	t.push(s, cfg, position, true, anchor)
+}
+
+func (t *textRendererImpl) GlyphCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.glyphs)
+}
+
+func (t *textRendererImpl) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.glyphs = t.glyphs[:0]
+}
+
+// push lays out a string and queues a quad for each of its visible glyphs, preceded by their
+// shadow copies.
+//
+// Parameters:
+//   - s: the string
+//   - cfg: the resolved text options
+//   - position: the screen or world position of the anchor
+//   - world: whether the text is drawn in world space
+//   - anchor: the anchor point as fractions of the layout box
+func (t *textRendererImpl) push(s string, cfg textConfig, position [3]float32, world bool, anchor [2]float32) {
+	layout := font.Layout(t.atlas.Font(), s, cfg.layoutOptions()...)
+	if len(layout.Glyphs) == 0 {
+		return
+	}
+
+	// The layout box is the wrap width when wrapping, so alignment stays inside it.
+	boxWidth := layout.Width
+	if cfg.maxWidth > 0 {
+		boxWidth = cfg.maxWidth
+	}
+	originX, originY := -anchor[0]*boxWidth, -anchor[1]*layout.Height
+
+	// Atlas glyphs are rasterized at the atlas glyph size; k scales them to the font size.
+	k := cfg.size / t.atlas.GlyphSize()
+	var outlineWidth float32
+	if cfg.outlineWidth > 0 {
+		outlineWidth = min(cfg.outlineWidth/k/float32(2*t.atlas.Spread()), 0.5)
+	}
+	scale := float32(1)
+	if world {
+		scale = cfg.worldScale
+	}
+	var expires time.Time
+	if cfg.lifetime > 0 {
+		expires = time.Now().Add(cfg.lifetime)
+	}
+
+	base := textGlyph{
+		anchor:       position,
+		world:        world,
+		color:        packColor(cfg.color),
+		outlineColor: packColor(cfg.outlineColor),
+		outlineWidth: outlineWidth,
+		scale:        scale,
+		depthTest:    world && cfg.depthTest,
+		expires:      expires,
+	}
+	quads := make([]textGlyph, 0, len(layout.Glyphs))
+	for _, lg := range layout.Glyphs {
+		ag, ok := t.atlas.Glyph(lg.Glyph)
+		if !ok {
+			continue
+		}
+		q := base
+		q.rect = [4]float32{
+			originX + lg.X + ag.Left*k,
+			originY + lg.Y - ag.Top*k,
+			float32(ag.Width) * k,
+			float32(ag.Height) * k,
+		}
+		q.uv = ag.UV
+		quads = append(quads, q)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cfg.shadow {
+		shadowColor := packColor(cfg.shadowColor)
+		for _, q := range quads {
+			q.rect[0] += cfg.shadowOffset[0]
+			q.rect[1] += cfg.shadowOffset[1]
+			q.color, q.outlineColor = shadowColor, shadowColor
+			t.glyphs = append(t.glyphs, q)
+		}
+	}
+	t.glyphs = append(t.glyphs, quads...)
+}
+
+// prune drops the glyphs whose lifetime has ended by the given time. Glyphs without a
+// lifetime are always dropped. Caller must hold t.mu.
+//
+// Parameters:
+//   - now: the current time
+func (t *textRendererImpl) prune(now time.Time) {
+	kept := t.glyphs[:0]
+	for _, g := range t.glyphs {
+		if !g.expires.IsZero() && now.Before(g.expires) {
+			kept = append(kept, g)
+		}
+	}
+	clear(t.glyphs[len(kept):])
+	t.glyphs = kept
+}
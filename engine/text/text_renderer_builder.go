@@ -0,0 +1,18 @@
+package text
+
+// TextRendererBuilderOption is a function that configures a TextRenderer instance during construction.
+type TextRendererBuilderOption func(*textRendererImpl)
+
+// WithMaxGlyphs is an option builder that sets the capacity of the glyph buffer, counting the
+// shadow copies of shadowed glyphs. Glyphs beyond it are not drawn. Defaults to 16384.
+//
+// Parameters:
+//   - n: the maximum number of glyphs drawn per frame
+//
+// Returns:
+//   - TextRendererBuilderOption: a function that applies the glyph capacity option to a textRendererImpl
+func WithMaxGlyphs(n int) TextRendererBuilderOption {
+	return func(t *textRendererImpl) {
+		t.maxGlyphs = max(n, 1)
+	}
+}
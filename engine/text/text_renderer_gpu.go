@@ -0,0 +1,347 @@
+package text
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/camera"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/pipeline"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+const (
+	// DepthPipelineKey is the pipeline key of the depth-tested world-space text pass.
+	DepthPipelineKey = "text_depth"
+
+	// OverlayPipelineKey is the pipeline key of the text pass drawn over the scene: screen-space
+	// text and world-space text without depth testing.
+	OverlayPipelineKey = "text_overlay"
+)
+
+// Bindings of the text vertex shader's group 0.
+const (
+	paramsBinding = 0
+	glyphBinding  = 1
+)
+
+// Groups of the text shaders: the vertex shader's params and glyphs, and the fragment
+// shader's atlas texture and sampler.
+const (
+	glyphGroup = 0
+	atlasGroup = 1
+)
+
+const (
+	// paramsSize is the size of TextParams in bytes: a view-projection matrix, the camera's
+	// right and up vectors and the viewport size.
+	paramsSize = 112
+
+	// glyphSize is the size of a TextGlyph in bytes.
+	glyphSize = 64
+
+	// verticesPerGlyph is the number of vertices of a glyph quad, two triangles.
+	verticesPerGlyph = 6
+)
+
+func (t *textRendererImpl) Draw(r renderer.Renderer, cam camera.Camera, width, height float32) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	defer t.prune(now)
+
+	if len(t.glyphs) == 0 || r == nil || width <= 0 || height <= 0 {
+		return nil
+	}
+	if err := t.initGPU(r); err != nil {
+		return err
+	}
+	if err := t.uploadAtlas(); err != nil {
+		return err
+	}
+
+	// Depth-tested glyphs go first and overlay glyphs after them, so each pipeline draws one
+	// contiguous range of the buffer. Queue order is kept within each range, so later text is
+	// drawn over earlier text.
+	data := t.glyphData[:0]
+	var depthGlyphs, overlayGlyphs int
+	for _, g := range t.glyphs {
+		if g.depthTest && cam != nil && depthGlyphs < t.maxGlyphs {
+			data = appendGlyph(data, g)
+			depthGlyphs++
+		}
+	}
+	for _, g := range t.glyphs {
+		if !g.depthTest && (cam != nil || !g.world) && depthGlyphs+overlayGlyphs < t.maxGlyphs {
+			data = appendGlyph(data, g)
+			overlayGlyphs++
+		}
+	}
+	t.glyphData = data
+	if depthGlyphs+overlayGlyphs == 0 {
+		return nil
+	}
+
+	r.WriteBuffers([]bind_group_provider.BufferWrite{
+		{Provider: t.glyphBGP, Binding: paramsBinding, Offset: 0, Data: marshalParams(cam, width, height)},
+		{Provider: t.glyphBGP, Binding: glyphBinding, Offset: 0, Data: data},
+	})
+
+	bindGroups := []bind_group_provider.BindGroupProvider{t.glyphBGP, t.atlasBGP}
+	if depthGlyphs > 0 {
+		if err := r.DrawProceduralRange(DepthPipelineKey, 0, uint32(depthGlyphs*verticesPerGlyph), bindGroups); err != nil {
+			return fmt.Errorf("text draw failed: %w", err)
+		}
+	}
+	if overlayGlyphs > 0 {
+		first := uint32(depthGlyphs * verticesPerGlyph)
+		if err := r.DrawProceduralRange(OverlayPipelineKey, first, uint32(overlayGlyphs*verticesPerGlyph), bindGroups); err != nil {
+			return fmt.Errorf("text draw failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func (t *textRendererImpl) Release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.releaseGPU()
+}
+
+// initGPU registers the text pipelines and creates the bind groups on first use, or again when
+// drawing with a different renderer. Caller must hold t.mu.
+//
+// Parameters:
+//   - r: the renderer to create the resources on
+//
+// Returns:
+//   - error: an error if pipeline registration or bind group creation fails
+func (t *textRendererImpl) initGPU(r renderer.Renderer) error {
+	if t.glyphBGP != nil && t.r == r {
+		return nil
+	}
+	t.releaseGPU()
+
+	// RegisterPipelines skips keys that are already cached, so every TextRenderer shares the
+	// pipelines registered by the first.
+	err := r.RegisterPipelines(
+		newTextPipeline(DepthPipelineKey, true),
+		newTextPipeline(OverlayPipelineKey, false),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register text pipelines: %w", err)
+	}
+
+	vert := r.Pipeline(DepthPipelineKey).Shader(shader.ShaderTypeVertex)
+	glyphBGP := bind_group_provider.NewBindGroupProvider("text")
+	sizes := map[int]uint64{
+		paramsBinding: paramsSize,
+		glyphBinding:  uint64(t.maxGlyphs) * glyphSize,
+	}
+	if err := r.InitBindGroup(glyphBGP, vert.BindGroupLayoutDescriptor(glyphGroup), nil, sizes); err != nil {
+		glyphBGP.Release()
+		return fmt.Errorf("failed to init text bind group: %w", err)
+	}
+
+	// The atlas texture and sampler are located by their binding roles in the fragment shader.
+	frag := r.Pipeline(DepthPipelineKey).Shader(shader.ShaderTypeFragment)
+	atlasBGP := bind_group_provider.NewBindGroupProvider("text_atlas")
+	pixels, version := t.atlas.Pixels()
+	size := uint32(t.atlas.Size())
+	t.atlasBinding = -1
+	for _, decl := range frag.Declarations() {
+		if decl.Type != shader.AnnotationTypeProvider || decl.Args[0] != shader.AnnotationArgText || len(decl.Args) < 2 || decl.Binding == nil {
+			continue
+		}
+		switch decl.Args[1] {
+		case shader.AnnotationArgTextAtlas:
+			err = r.InitTextureView(atlasBGP, *decl.Binding, common.TextureStagingData{
+				Pixels: pixels,
+				Width:  size,
+				Height: size,
+				Format: wgpu.TextureFormatR8Unorm,
+			})
+			t.atlasBinding = *decl.Binding
+		case shader.AnnotationArgTextSampler:
+			err = r.InitSampler(atlasBGP, *decl.Binding, common.SamplerStagingData{
+				AddressModeU:  wgpu.AddressModeClampToEdge,
+				AddressModeV:  wgpu.AddressModeClampToEdge,
+				AddressModeW:  wgpu.AddressModeClampToEdge,
+				MagFilter:     wgpu.FilterModeLinear,
+				MinFilter:     wgpu.FilterModeLinear,
+				MipmapFilter:  wgpu.MipmapFilterModeLinear,
+				LodMaxClamp:   32,
+				MaxAnisotropy: 1,
+			})
+		}
+		if err != nil {
+			glyphBGP.Release()
+			atlasBGP.Release()
+			return fmt.Errorf("failed to init text atlas: %w", err)
+		}
+	}
+	if err := r.InitBindGroup(atlasBGP, frag.BindGroupLayoutDescriptor(atlasGroup), nil, nil); err != nil {
+		glyphBGP.Release()
+		atlasBGP.Release()
+		return fmt.Errorf("failed to init text atlas bind group: %w", err)
+	}
+
+	t.r = r
+	t.glyphBGP = glyphBGP
+	t.atlasBGP = atlasBGP
+	t.atlasVersion = version
+	return nil
+}
+
+// uploadAtlas re-uploads the atlas texture if glyphs were added since the last upload, and
+// rebuilds the atlas bind group around the new texture view. Caller must hold t.mu.
+//
+// Returns:
+//   - error: an error if the texture or bind group cannot be created
+func (t *textRendererImpl) uploadAtlas() error {
+	if t.atlas.Version() == t.atlasVersion || t.atlasBinding < 0 {
+		return nil
+	}
+	pixels, version := t.atlas.Pixels()
+	size := uint32(t.atlas.Size())
+
+	oldView := t.atlasBGP.TextureView(t.atlasBinding)
+	err := t.r.InitTextureView(t.atlasBGP, t.atlasBinding, common.TextureStagingData{
+		Pixels: pixels,
+		Width:  size,
+		Height: size,
+		Format: wgpu.TextureFormatR8Unorm,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload text atlas: %w", err)
+	}
+	if oldView != nil {
+		oldView.Release()
+	}
+
+	// InitBindGroup reuses the existing layout and sampler.
+	frag := t.r.Pipeline(DepthPipelineKey).Shader(shader.ShaderTypeFragment)
+	oldBindGroup := t.atlasBGP.BindGroup()
+	if err := t.r.InitBindGroup(t.atlasBGP, frag.BindGroupLayoutDescriptor(atlasGroup), nil, nil); err != nil {
+		return fmt.Errorf("failed to rebuild text atlas bind group: %w", err)
+	}
+	if oldBindGroup != nil {
+		oldBindGroup.Release()
+	}
+	t.atlasVersion = version
+	return nil
+}
+
+// releaseGPU releases the bind groups, buffers and atlas texture. Caller must hold t.mu.
+func (t *textRendererImpl) releaseGPU() {
+	if t.glyphBGP != nil {
+		t.glyphBGP.Release()
+	}
+	if t.atlasBGP != nil {
+		t.atlasBGP.Release()
+	}
+	t.glyphBGP = nil
+	t.atlasBGP = nil
+	t.r = nil
+}
+
+// newTextPipeline creates a pipeline drawing glyph quads with alpha blending. Neither pipeline
+// writes depth, so text never hides scene geometry or other text.
+//
+// Parameters:
+//   - key: the pipeline key
+//   - depthTest: whether glyphs are hidden behind scene geometry
+//
+// Returns:
+//   - pipeline.Pipeline: the pipeline to register
+func newTextPipeline(key string, depthTest bool) pipeline.Pipeline {
+	return pipeline.NewPipeline(key, pipeline.PipelineTypeRender,
+		pipeline.WithVertexShader(shader.NewShaderFromSource(key+"_vert", shader.ShaderTypeVertex, VertexSource)),
+		pipeline.WithFragmentShader(shader.NewShaderFromSource(key+"_frag", shader.ShaderTypeFragment, FragmentSource)),
+		pipeline.WithDepthTestEnabled(depthTest),
+		pipeline.WithDepthWriteEnabled(false),
+		pipeline.WithBlendEnabled(true),
+		pipeline.WithCullMode(wgpu.CullModeNone),
+	)
+}
+
+// marshalParams packs the TextParams uniform: the camera's view-projection, its right and up
+// vectors for billboarding, and the viewport size.
+//
+// Parameters:
+//   - cam: the camera, nil when only screen-space text is drawn
+//   - width: the viewport width in pixels
+//   - height: the viewport height in pixels
+//
+// Returns:
+//   - []byte: the uniform data
+func marshalParams(cam camera.Camera, width, height float32) []byte {
+	var viewProj, view [16]float32
+	common.Identity(viewProj[:])
+	common.Identity(view[:])
+	if cam != nil {
+		viewProj = cam.ViewProjectionMatrix()
+		view = cam.ViewMatrix()
+	}
+
+	// The rows of the view matrix's rotation are the camera's axes in world space.
+	values := make([]float32, 0, paramsSize/4)
+	values = append(values, viewProj[:]...)
+	values = append(values, view[0], view[4], view[8], 0)
+	values = append(values, view[1], view[5], view[9], 0)
+	values = append(values, width, height, 0, 0)
+
+	buf := make([]byte, 0, paramsSize)
+	for _, v := range values {
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(v))
+	}
+	return buf
+}
+
+// appendGlyph appends one packed TextGlyph.
+//
+// Parameters:
+//   - buf: the buffer to append to
+//   - g: the glyph quad
+//
+// Returns:
+//   - []byte: the buffer with the glyph appended
+func appendGlyph(buf []byte, g textGlyph) []byte {
+	var mode float32
+	if g.world {
+		mode = 1
+	}
+	for _, v := range [12]float32{
+		g.anchor[0], g.anchor[1], g.anchor[2], mode,
+		g.rect[0], g.rect[1], g.rect[2], g.rect[3],
+		g.uv[0], g.uv[1], g.uv[2], g.uv[3],
+	} {
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(v))
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, g.color)
+	buf = binary.LittleEndian.AppendUint32(buf, g.outlineColor)
+	buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(g.outlineWidth))
+	return binary.LittleEndian.AppendUint32(buf, math.Float32bits(g.scale))
+}
+
+// packColor packs a linear RGBA color into four unorm8 values with red in the low byte,
+// matching WGSL's unpack4x8unorm.
+//
+// Parameters:
+//   - c: the color, each channel clamped to [0, 1]
+//
+// Returns:
+//   - uint32: the packed color
+func packColor(c [4]float32) uint32 {
+	var packed uint32
+	for i, v := range c {
+		packed |= uint32(min(max(v, 0), 1)*255+0.5) << (8 * i)
+	}
+	return packed
+}
@@ -0,0 +1,43 @@
+// UI fragment shader
+//
+// Discards fragments outside the quad's clip rectangle, then shades glyphs from
+// the signed distance field atlas and solid quads as rounded rectangles, both with
+// edges about one screen pixel wide. Alpha blends over the scene.
+//
+// Bind group layout:
+//   @group(1) ui — SDF atlas texture, sampler
+
+struct FragmentInput {
+    @location(0) uv: vec2<f32>,
+    @location(1) color: vec4<f32>,
+    @location(2) px: vec2<f32>,
+    @location(3) local: vec2<f32>,
+    @location(4) @interpolate(flat) clip: vec4<f32>,
+    @location(5) @interpolate(flat) shape: vec4<f32>, // xy = half size, z = corner radius, w = mode
+};
+
+//@oxy:provider 1 0 ui text_atlas
+@group(1) @binding(0) var atlas_texture: texture_2d<f32>;
+//@oxy:provider 1 1 ui text_sampler
+@group(1) @binding(1) var atlas_sampler: sampler;
+
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    // Sampling and derivatives must happen in uniform control flow, before any discard.
+    let d = textureSample(atlas_texture, atlas_sampler, in.uv).r;
+    let aa = max(fwidth(d) * 0.5, 1e-4);
+
+    if any(in.px < in.clip.xy) || any(in.px >= in.clip.zw) {
+        discard;
+    }
+
+    if in.shape.w > 0.5 {
+        return vec4<f32>(in.color.rgb, in.color.a * smoothstep(0.5 - aa, 0.5 + aa, d));
+    }
+
+    // Signed distance to the rounded rectangle, negative inside.
+    let r = in.shape.z;
+    let q = abs(in.local) - in.shape.xy + vec2<f32>(r);
+    let dist = length(max(q, vec2<f32>(0.0))) + min(max(q.x, q.y), 0.0) - r;
+    return vec4<f32>(in.color.rgb, in.color.a * clamp(0.5 - dist, 0.0, 1.0));
+}
@@ -0,0 +1,61 @@
+// UI vertex shader
+//
+// Pulls UI quads from a storage buffer, six vertices per quad, so a frame of UI
+// needs no vertex buffer. Quads are placed in pixels from the top-left of the
+// viewport and carry their clip rectangle and rounded-rectangle shape through to
+// the fragment shader.
+//
+// Bind group layout:
+//   @group(0) ui — UIParams uniform, UIQuad storage array
+
+struct UIParams {
+    viewport: vec4<f32>,     // xy = viewport size in pixels
+};
+
+struct UIQuad {
+    rect: vec4<f32>,         // xy = top-left, zw = size, in pixels with Y down
+    uv: vec4<f32>,           // xy = atlas UV of the top-left corner, zw = bottom-right
+    clip: vec4<f32>,         // xy = top-left, zw = bottom-right of the clip rectangle in pixels
+    color: u32,
+    mode: u32,               // 0 = solid rectangle, 1 = glyph
+    radius: f32,             // corner radius of a solid rectangle in pixels
+    _pad: u32,
+};
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) uv: vec2<f32>,
+    @location(1) color: vec4<f32>,
+    @location(2) px: vec2<f32>,
+    @location(3) local: vec2<f32>,
+    @location(4) @interpolate(flat) clip: vec4<f32>,
+    @location(5) @interpolate(flat) shape: vec4<f32>,
+};
+
+//@oxy:provider 0 0 ui
+@group(0) @binding(0) var<uniform> params: UIParams;
+//@oxy:provider 0 1 ui
+@group(0) @binding(1) var<storage, read> quads: array<UIQuad>;
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> VertexOutput {
+    let q = quads[index / 6u];
+
+    // Two triangles: (0,0) (1,0) (0,1) and (1,0) (1,1) (0,1).
+    var corners = array<vec2<f32>, 6>(
+        vec2<f32>(0.0, 0.0), vec2<f32>(1.0, 0.0), vec2<f32>(0.0, 1.0),
+        vec2<f32>(1.0, 0.0), vec2<f32>(1.0, 1.0), vec2<f32>(0.0, 1.0),
+    );
+    let corner = corners[index % 6u];
+    let px = q.rect.xy + corner * q.rect.zw;
+
+    var out: VertexOutput;
+    out.position = vec4<f32>(px.x / params.viewport.x * 2.0 - 1.0, 1.0 - px.y / params.viewport.y * 2.0, 0.0, 1.0);
+    out.uv = mix(q.uv.xy, q.uv.zw, corner);
+    out.color = unpack4x8unorm(q.color);
+    out.px = px;
+    out.local = (corner - vec2<f32>(0.5)) * q.rect.zw;
+    out.clip = q.clip;
+    out.shape = vec4<f32>(q.rect.zw * 0.5, q.radius, f32(q.mode));
+    return out;
+}
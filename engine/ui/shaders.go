@@ -0,0 +1,17 @@
+package ui
+
+import (
+	_ "embed"
+)
+
+// VertexSource is the vertex shader of the UI pass. It pulls each quad from the UIQuad storage
+// buffer by vertex index and places it in pixels from the top-left of the viewport.
+//
+//go:embed assets/ui-vert.wgsl
+var VertexSource string
+
+// FragmentSource is the fragment shader of the UI pass. It clips each quad to its clip rectangle
+// and shades glyphs from the signed distance field atlas and solid quads as rounded rectangles.
+//
+//go:embed assets/ui-frag.wgsl
+var FragmentSource string
@@ -0,0 +1,54 @@
+package ui
+
+// Theme holds the sizes and colors widgets are drawn with. Sizes are in pixels and colors are
+// linear RGBA.
+type Theme struct {
+	FontSize       float32 // size of widget text
+	Padding        float32 // space between a widget's edge and its text, and around panel content
+	Spacing        float32 // space between consecutive widgets
+	WindowPadding  float32 // space between a window's edge and its content
+	CornerRadius   float32 // corner radius of windows, panels and widgets
+	LabelWidth     float32 // fraction of a slider, text field or dropdown row taken by its label
+	ScrollbarWidth float32 // width of the scrollbar of windows and panels that overflow
+
+	Text             [4]float32
+	WindowBackground [4]float32
+	TitleBackground  [4]float32 // title bar of windows behind the front window
+	TitleActive      [4]float32 // title bar of the front window
+	PanelBackground  [4]float32
+	PopupBackground  [4]float32
+	Widget           [4]float32 // background of buttons, boxes, tracks and fields
+	WidgetHovered    [4]float32
+	WidgetActive     [4]float32 // widgets held down and focused text fields
+	Accent           [4]float32 // check marks, slider grabs, carets and scrollbar thumbs
+	Border           [4]float32 // separators and the window resize grip
+}
+
+// DefaultTheme returns the theme a UI uses unless built with WithTheme: a dark theme with
+// 16 pixel text.
+//
+// Returns:
+//   - Theme: the default theme
+func DefaultTheme() Theme {
+	return Theme{
+		FontSize:       16,
+		Padding:        6,
+		Spacing:        4,
+		WindowPadding:  8,
+		CornerRadius:   4,
+		LabelWidth:     0.4,
+		ScrollbarWidth: 6,
+
+		Text:             [4]float32{0.9, 0.9, 0.9, 1},
+		WindowBackground: [4]float32{0.06, 0.06, 0.07, 0.94},
+		TitleBackground:  [4]float32{0.1, 0.1, 0.12, 1},
+		TitleActive:      [4]float32{0.16, 0.29, 0.48, 1},
+		PanelBackground:  [4]float32{0.1, 0.1, 0.11, 1},
+		PopupBackground:  [4]float32{0.08, 0.08, 0.09, 0.98},
+		Widget:           [4]float32{0.16, 0.17, 0.2, 1},
+		WidgetHovered:    [4]float32{0.24, 0.26, 0.31, 1},
+		WidgetActive:     [4]float32{0.3, 0.33, 0.4, 1},
+		Accent:           [4]float32{0.26, 0.59, 0.98, 1},
+		Border:           [4]float32{0.3, 0.3, 0.34, 1},
+	}
+}
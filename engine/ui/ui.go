@@ -0,0 +1,617 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+	"github.com/Carmen-Shannon/oxy-go/engine/text/font"
+	"github.com/Carmen-Shannon/oxy-go/engine/window"
+)
+
+// defaultMaxQuads is the default capacity of the quad buffer.
+const defaultMaxQuads = 16384
+
+// popupRoot is the root ID of the open dropdown's popup list, which is hit tested before
+// every window.
+const popupRoot = 1
+
+// Rect is a rectangle in pixels from the top-left of the viewport, with the Y axis pointing down.
+type Rect struct {
+	X      float32
+	Y      float32
+	Width  float32
+	Height float32
+}
+
+func (r Rect) contains(x, y float32) bool {
+	return x >= r.X && y >= r.Y && x < r.X+r.Width && y < r.Y+r.Height
+}
+
+func (r Rect) intersect(o Rect) Rect {
+	x0, y0 := max(r.X, o.X), max(r.Y, o.Y)
+	x1, y1 := min(r.X+r.Width, o.X+o.Width), min(r.Y+r.Height, o.Y+o.Height)
+	return Rect{X: x0, Y: y0, Width: max(x1-x0, 0), Height: max(y1-y0, 0)}
+}
+
+// noClip is the clip rectangle of quads drawn outside any window, such as popups.
+var noClip = Rect{X: -1e9, Y: -1e9, Width: 2e9, Height: 2e9}
+
+// container is the state of a window or panel that is kept across frames, keyed by its ID.
+type container struct {
+	id            uint64
+	window        bool
+	rect          Rect // outer rectangle of a window, moved and resized by the user
+	body          Rect // content area of the current frame
+	scroll        float32
+	contentHeight float32 // height of the content laid out in the previous frame
+	collapsed     bool
+	z             int    // stacking order of windows, larger in front
+	lastFrame     uint64 // frame the window was last submitted in
+	quads         []quad // quads of a window and everything in it, in drawing order
+}
+
+// layout is the placement state of an open window or panel during a frame. Widgets are placed
+// top to bottom, or left to right in cells of the current row.
+type layout struct {
+	c    *container
+	root *container // the window the container is in
+	body Rect       // content area
+	clip Rect       // visible part of the content area
+	top  float32    // top of the content, moved up by the scroll offset
+	y    float32    // top of the next widget outside rows
+	maxY float32    // bottom of the lowest widget
+
+	row                   []float32 // cell widths of the open row, nil outside rows
+	col                   int
+	rowX, rowY, rowHeight float32
+}
+
+// keyEvent is a key press or a typed character, kept in the order they were received.
+type keyEvent struct {
+	key  uint32
+	char rune // 0 for key presses
+}
+
+// pendingInput collects the input events received from the window between frames.
+type pendingInput struct {
+	x, y     float32
+	down     bool
+	presses  int
+	releases int
+	scroll   float32
+	keys     []keyEvent
+}
+
+// frameInput is the input of the current frame, folded from the pending events by Begin.
+type frameInput struct {
+	x, y     float32
+	dx, dy   float32 // cursor motion since the previous frame
+	down     bool
+	pressed  bool
+	released bool
+	scroll   float32
+	keys     []keyEvent
+}
+
+// uiImpl is the implementation of the UI interface.
+type uiImpl struct {
+	mu       *sync.Mutex
+	atlas    font.Atlas
+	theme    Theme
+	maxQuads int
+	win      window.Window
+
+	pending pendingInput
+	in      frameInput
+
+	inFrame    bool
+	frame      uint64
+	containers map[uint64]*container
+	stack      []layout
+	order      []*container // windows submitted this frame
+	overlay    []quad       // popups, drawn over every window
+	zTop       int
+
+	hoverRoot uint64 // window or popup under the cursor, 0 for none
+	appMouse  bool   // the held mouse button was pressed outside the UI
+	active    uint64 // widget held down with the mouse
+	focus     uint64 // text field receiving keyboard input
+	caret     int    // byte offset of the caret in the focused text field
+	textShift float32
+	popupID   uint64 // dropdown whose popup list is open
+	popupRect Rect
+
+	focusSeen, popupSeen bool
+
+	wantsMouse    bool
+	wantsKeyboard bool
+	viewHeight    float32 // viewport height of the last draw, for placing popups
+
+	quads     []quad // quads of the last completed frame
+	r         renderer.Renderer
+	quadBGP   bind_group_provider.BindGroupProvider
+	atlasBGP  bind_group_provider.BindGroupProvider
+	atlasBind int
+	atlasVer  uint64
+	quadData  []byte
+}
+
+// UI defines the interface for an immediate-mode user interface drawn over a scene. Each frame,
+// the application calls Begin, declares its windows and widgets, and calls End; widgets report
+// clicks and edits through their return values, and the UI keeps only the state that must
+// outlive a frame, such as window positions, scroll offsets and the focused text field.
+//
+// Widgets are placed top to bottom inside windows and panels, or side by side inside rows, and
+// are identified by their label within their window or panel. Text after "##" in a label is
+// part of its ID but is not shown, so widgets with the same text can be told apart.
+//
+// A UI receives mouse and keyboard input as a window.InputListener, and reports whether it
+// captured the mouse or keyboard so the rest of the application can ignore that input. It is
+// drawn by the scene it is attached to, at the end of DrawCalls. A UI is safe for concurrent use.
+type UI interface {
+	window.InputListener
+	window.CharListener
+	window.KeyRepeatListener
+
+	// Attach subscribes the UI to a window's input events, detaching it from any previous window.
+	//
+	// Parameters:
+	//   - w: the window to observe
+	Attach(w window.Window)
+
+	// Detach unsubscribes the UI from its current window, if any.
+	Detach()
+
+	// Theme returns the sizes and colors widgets are drawn with.
+	//
+	// Returns:
+	//   - Theme: the theme
+	Theme() Theme
+
+	// SetTheme sets the sizes and colors widgets are drawn with from the next frame on.
+	//
+	// Parameters:
+	//   - theme: the theme
+	SetTheme(theme Theme)
+
+	// Begin starts a frame, applying the input received since the previous frame. Windows and
+	// widgets are declared between Begin and End, usually in the engine's render callback.
+	Begin()
+
+	// End finishes a frame. The frame is drawn by the next Draw, and WantsMouse and
+	// WantsKeyboard report the input it captured.
+	End()
+
+	// BeginWindow starts a window. The rectangle places and sizes the window the first time it is
+	// submitted; after that the user can move it by its title bar, resize it by its bottom-right
+	// corner and collapse it with the button in its title bar. Windows are drawn in the order
+	// they were last clicked. Content that does not fit scrolls. A window is hidden in frames
+	// it is not submitted in.
+	//
+	// Parameters:
+	//   - title: the window title, which also identifies the window
+	//   - rect: the initial position and size of the window
+	//
+	// Returns:
+	//   - bool: true if the window is expanded, in which case its content must be declared and
+	//     EndWindow called; false if it is collapsed
+	BeginWindow(title string, rect Rect) bool
+
+	// EndWindow finishes the window started by the last BeginWindow that returned true.
+	EndWindow()
+
+	// BeginPanel starts a panel: a container with its own background and scrolling, placed like a
+	// widget in the current window, panel or row.
+	//
+	// Parameters:
+	//   - id: the panel ID within the current window or panel
+	//   - height: the panel height in pixels, or 0 to fit its content
+	BeginPanel(id string, height float32)
+
+	// EndPanel finishes the panel started by the last BeginPanel.
+	EndPanel()
+
+	// BeginRow starts a row: the following widgets are placed side by side in cells of the given
+	// widths, wrapping to another row of the same cells when they are all used.
+	//
+	// Parameters:
+	//   - widths: the cell widths in pixels; cells of width 0 share the width left over by the
+	//     others. No widths gives one cell of the full width
+	BeginRow(widths ...float32)
+
+	// EndRow finishes the row started by the last BeginRow.
+	EndRow()
+
+	// Label places a line of text, wrapped to the width of its cell.
+	//
+	// Parameters:
+	//   - s: the text
+	Label(s string)
+
+	// Separator places a horizontal line.
+	Separator()
+
+	// Button places a push button.
+	//
+	// Parameters:
+	//   - label: the button label
+	//
+	// Returns:
+	//   - bool: true if the button was clicked this frame
+	Button(label string) bool
+
+	// Checkbox places a check box that toggles a value when clicked.
+	//
+	// Parameters:
+	//   - label: the label shown next to the box
+	//   - value: the value to show and toggle
+	//
+	// Returns:
+	//   - bool: true if the value was toggled this frame
+	Checkbox(label string, value *bool) bool
+
+	// Slider places a horizontal slider that sets a value in a range while dragged.
+	//
+	// Parameters:
+	//   - label: the label shown left of the slider, or "" for none
+	//   - value: the value to show and set
+	//   - lo: the value at the left end
+	//   - hi: the value at the right end
+	//
+	// Returns:
+	//   - bool: true if the value changed this frame
+	Slider(label string, value *float32, lo, hi float32) bool
+
+	// TextField places a single-line text field. Clicking it focuses it and places the caret;
+	// typed characters are inserted at the caret, and Backspace, Delete, Left, Right, Home and
+	// End edit and move it. Enter or a click elsewhere removes the focus.
+	//
+	// Parameters:
+	//   - label: the label shown left of the field, or "" for none
+	//   - value: the text to show and edit
+	//
+	// Returns:
+	//   - bool: true if the text changed this frame
+	TextField(label string, value *string) bool
+
+	// Dropdown places a button showing the selected item that opens a list of items when clicked.
+	//
+	// Parameters:
+	//   - label: the label shown left of the dropdown, or "" for none
+	//   - selected: the index of the selected item, set when an item is picked
+	//   - items: the items to pick from
+	//
+	// Returns:
+	//   - bool: true if an item was picked this frame
+	Dropdown(label string, selected *int, items []string) bool
+
+	// WantsMouse returns whether the last frame captured the mouse: the cursor is over a window
+	// or popup, or a widget is being dragged. Camera controllers and other mouse handling should
+	// ignore the mouse while it returns true. A press that starts outside the UI is never
+	// captured, even if the cursor is then dragged over a window.
+	//
+	// Returns:
+	//   - bool: true if mouse input belongs to the UI
+	WantsMouse() bool
+
+	// WantsKeyboard returns whether a text field has the keyboard focus. Keyboard shortcuts and
+	// movement keys should be ignored while it returns true.
+	//
+	// Returns:
+	//   - bool: true if keyboard input belongs to the UI
+	WantsKeyboard() bool
+
+	// Draw uploads the glyph atlas if glyphs were added to it, writes the quads of the last
+	// completed frame to the GPU and draws them into the current render pass. Pipelines and
+	// buffers are created on the first call. Quads beyond the quad capacity are not drawn.
+	//
+	// Parameters:
+	//   - r: the renderer recording the current render pass
+	//   - width: the width of the viewport in pixels
+	//   - height: the height of the viewport in pixels
+	//
+	// Returns:
+	//   - error: an error if GPU resource creation or the draw fails
+	Draw(r renderer.Renderer, width, height float32) error
+
+	// Release releases the UI's GPU resources. The shared pipeline stays registered.
+	Release()
+}
+
+var _ UI = &uiImpl{}
+
+// NewUI creates a user interface whose text is drawn with a glyph atlas. It creates no GPU
+// resources; those are created by the first Draw.
+//
+// Parameters:
+//   - atlas: the glyph atlas widget text is drawn with
+//   - options: optional builder options
+//
+// Returns:
+//   - UI: the new user interface
+func NewUI(atlas font.Atlas, options ...UIBuilderOption) UI {
+	u := &uiImpl{
+		mu:         &sync.Mutex{},
+		atlas:      atlas,
+		theme:      DefaultTheme(),
+		maxQuads:   defaultMaxQuads,
+		containers: make(map[uint64]*container),
+		atlasBind:  -1,
+	}
+	for _, opt := range options {
+		opt(u)
+	}
+	if u.win != nil {
+		u.win.AddInputListener(u)
+	}
+	return u
+}
+
+func (u *uiImpl) Attach(w window.Window) {
+	u.Detach()
+
+	u.mu.Lock()
+	u.win = w
+	u.mu.Unlock()
+
+	if w != nil {
+		w.AddInputListener(u)
+	}
+}
+
+func (u *uiImpl) Detach() {
+	u.mu.Lock()
+	w := u.win
+	u.win = nil
+	u.mu.Unlock()
+
+	if w != nil {
+		w.RemoveInputListener(u)
+	}
+}
+
+func (u *uiImpl) Theme() Theme {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.theme
+}
+
+func (u *uiImpl) SetTheme(theme Theme) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.theme = theme
+}
+
+func (u *uiImpl) Begin() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.inFrame {
+		panic("ui: Begin called twice without End")
+	}
+	u.inFrame = true
+	u.frame++
+
+	p := &u.pending
+	u.in = frameInput{
+		x:        p.x,
+		y:        p.y,
+		dx:       p.x - u.in.x,
+		dy:       p.y - u.in.y,
+		down:     p.down,
+		pressed:  p.presses > 0,
+		released: p.releases > 0,
+		scroll:   p.scroll,
+		keys:     append(u.in.keys[:0], p.keys...),
+	}
+	p.presses, p.releases, p.scroll = 0, 0, 0
+	p.keys = p.keys[:0]
+
+	// The popup list and windows are hit tested where they were drawn last frame.
+	u.hoverRoot = 0
+	if u.popupID != 0 && u.popupRect.contains(u.in.x, u.in.y) {
+		u.hoverRoot = popupRoot
+	} else {
+		var top *container
+		for _, c := range u.containers {
+			if c.window && c.lastFrame == u.frame-1 && c.hitRect(u.rowHeight()).contains(u.in.x, u.in.y) && (top == nil || c.z > top.z) {
+				top = c
+			}
+		}
+		if top != nil {
+			u.hoverRoot = top.id
+		}
+	}
+
+	// A drag that starts outside the UI belongs to the application until the button is released.
+	if u.in.pressed {
+		u.appMouse = u.hoverRoot == 0
+	}
+	if u.appMouse {
+		if u.in.down {
+			u.hoverRoot = 0
+		} else {
+			u.appMouse = false
+		}
+	}
+
+	if u.in.pressed {
+		if c, ok := u.containers[u.hoverRoot]; ok {
+			u.zTop++
+			c.z = u.zTop
+		} else if u.hoverRoot == 0 {
+			u.focus = 0
+		}
+	}
+
+	u.stack = u.stack[:0]
+	u.order = u.order[:0]
+	u.overlay = u.overlay[:0]
+	u.focusSeen, u.popupSeen = false, false
+}
+
+func (u *uiImpl) End() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !u.inFrame {
+		panic("ui: End called without Begin")
+	}
+	if len(u.stack) > 0 {
+		panic("ui: End called with an unfinished window or panel")
+	}
+	u.inFrame = false
+
+	if !u.in.down {
+		u.active = 0
+	}
+	if !u.focusSeen {
+		u.focus = 0
+	}
+	if !u.popupSeen {
+		u.popupID = 0
+	}
+
+	slices.SortStableFunc(u.order, func(a, b *container) int { return a.z - b.z })
+	quads := u.quads[:0]
+	for _, c := range u.order {
+		quads = append(quads, c.quads...)
+	}
+	u.quads = append(quads, u.overlay...)
+
+	u.wantsMouse = u.hoverRoot != 0 || u.active != 0
+	u.wantsKeyboard = u.focus != 0
+}
+
+func (u *uiImpl) WantsMouse() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.wantsMouse
+}
+
+func (u *uiImpl) WantsKeyboard() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.wantsKeyboard
+}
+
+// hitRect returns the rectangle a window is hit tested with: only its title bar when it is
+// collapsed.
+//
+// Parameters:
+//   - titleHeight: the height of the title bar
+//
+// Returns:
+//   - Rect: the hit test rectangle
+func (c *container) hitRect(titleHeight float32) Rect {
+	if c.collapsed {
+		return Rect{X: c.rect.X, Y: c.rect.Y, Width: c.rect.Width, Height: titleHeight}
+	}
+	return c.rect
+}
+
+// hit updates the active widget for a widget rectangle and reports how the mouse interacts with
+// it. A widget is hovered when its root is under the cursor, the cursor is inside its clip and
+// rectangle and no other widget is held. Caller must hold u.mu.
+//
+// Parameters:
+//   - id: the widget ID
+//   - r: the widget rectangle
+//   - root: the ID of the window or popup the widget is in
+//   - clip: the visible area of the widget's container
+//
+// Returns:
+//   - bool: whether the cursor is over the widget
+//   - bool: whether the mouse button was pressed on the widget this frame
+//   - bool: whether the widget was clicked: pressed on it and released over it
+func (u *uiImpl) hit(id uint64, r Rect, root uint64, clip Rect) (bool, bool, bool) {
+	over := u.hoverRoot == root && clip.contains(u.in.x, u.in.y) && r.contains(u.in.x, u.in.y)
+	hovered := over && (u.active == 0 || u.active == id)
+	pressed := false
+	if hovered && u.in.pressed && u.active == 0 {
+		u.active = id
+		pressed = true
+	}
+	clicked := over && u.in.released && u.active == id
+	return hovered, pressed, clicked
+}
+
+// hitCell calls hit for a widget of the current layout. Caller must hold u.mu.
+//
+// Parameters:
+//   - id: the widget ID
+//   - r: the widget rectangle
+//
+// Returns:
+//   - bool: whether the cursor is over the widget
+//   - bool: whether the mouse button was pressed on the widget this frame
+//   - bool: whether the widget was clicked this frame
+func (u *uiImpl) hitCell(id uint64, r Rect) (bool, bool, bool) {
+	l := u.top()
+	return u.hit(id, r, l.root.id, l.clip)
+}
+
+// rowHeight returns the height of a widget row: one line of text and its padding.
+// Caller must hold u.mu.
+//
+// Returns:
+//   - float32: the row height in pixels
+func (u *uiImpl) rowHeight() float32 {
+	return u.theme.FontSize + 2*u.theme.Padding
+}
+
+// widgetColor returns the background color of a widget for its interaction state.
+// Caller must hold u.mu.
+//
+// Parameters:
+//   - id: the widget ID
+//   - hovered: whether the cursor is over the widget
+//
+// Returns:
+//   - [4]float32: the background color
+func (u *uiImpl) widgetColor(id uint64, hovered bool) [4]float32 {
+	switch {
+	case u.active == id:
+		return u.theme.WidgetActive
+	case hovered:
+		return u.theme.WidgetHovered
+	}
+	return u.theme.Widget
+}
+
+// hashID derives an ID from a parent ID and a key with 64-bit FNV-1a. IDs 0 and popupRoot are
+// reserved.
+//
+// Parameters:
+//   - parent: the parent ID, 0 at the top level
+//   - key: the key within the parent
+//
+// Returns:
+//   - uint64: the ID
+func hashID(parent uint64, key string) uint64 {
+	const prime = 1099511628211
+	h := uint64(14695981039346656037)
+	for i := range 8 {
+		h = (h ^ (parent >> (8 * i) & 0xff)) * prime
+	}
+	for i := 0; i < len(key); i++ {
+		h = (h ^ uint64(key[i])) * prime
+	}
+	if h <= popupRoot {
+		h += popupRoot + 1
+	}
+	return h
+}
+
+// splitLabel returns the part of a label that is shown: the text before "##".
+//
+// Parameters:
+//   - label: the label
+//
+// Returns:
+//   - string: the shown text
+func splitLabel(label string) string {
+	shown, _, _ := strings.Cut(label, "##")
+	return shown
+}
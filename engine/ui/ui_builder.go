@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"github.com/Carmen-Shannon/oxy-go/engine/window"
+)
+
+// UIBuilderOption is a function that configures a UI instance during construction.
+type UIBuilderOption func(*uiImpl)
+
+// WithTheme is an option builder that sets the initial theme of the UI. Defaults to DefaultTheme.
+//
+// Parameters:
+//   - theme: the theme
+//
+// Returns:
+//   - UIBuilderOption: a function that applies the theme option to a uiImpl
+func WithTheme(theme Theme) UIBuilderOption {
+	return func(u *uiImpl) {
+		u.theme = theme
+	}
+}
+
+// WithMaxQuads is an option builder that sets the capacity of the quad buffer. A glyph or a filled
+// rectangle takes one quad each; quads beyond the capacity are not drawn. Defaults to 16384.
+//
+// Parameters:
+//   - n: the maximum number of quads drawn per frame
+//
+// Returns:
+//   - UIBuilderOption: a function that applies the quad capacity option to a uiImpl
+func WithMaxQuads(n int) UIBuilderOption {
+	return func(u *uiImpl) {
+		u.maxQuads = max(n, 1)
+	}
+}
+
+// WithWindow is an option builder that attaches the UI to a window's input on construction,
+// the same as calling Attach afterwards.
+//
+// Parameters:
+//   - w: the window to receive input from
+//
+// Returns:
+//   - UIBuilderOption: a function that applies the window option to a uiImpl
+func WithWindow(w window.Window) UIBuilderOption {
+	return func(u *uiImpl) {
+		u.win = w
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"github.com/Carmen-Shannon/oxy-go/engine/text/font"
+)
+
+// quad is one rectangle of a frame: a solid rounded rectangle or a glyph from the atlas.
+type quad struct {
+	rect   Rect
+	uv     [4]float32 // atlas UVs of a glyph: u0, v0, u1, v1
+	clip   Rect       // the quad is only drawn inside the clip rectangle
+	color  uint32     // RGBA packed as four unorm8 values, red in the low byte
+	glyph  bool
+	radius float32 // corner radius of a solid rectangle
+}
+
+// fill appends a solid rectangle. Caller must hold u.mu.
+//
+// Parameters:
+//   - dst: the quad list to append to
+//   - r: the rectangle
+//   - color: the fill color
+//   - radius: the corner radius
+//   - clip: the clip rectangle
+func (u *uiImpl) fill(dst *[]quad, r Rect, color [4]float32, radius float32, clip Rect) {
+	if r.Width <= 0 || r.Height <= 0 {
+		return
+	}
+	*dst = append(*dst, quad{rect: r, clip: clip, color: packColor(color), radius: min(radius, r.Width/2, r.Height/2)})
+}
+
+// layoutText lays out a string at the theme's font size. Caller must hold u.mu.
+//
+// Parameters:
+//   - s: the string
+//   - maxWidth: the wrap width, 0 for a single line
+//
+// Returns:
+//   - font.TextLayout: the laid out glyphs
+func (u *uiImpl) layoutText(s string, maxWidth float32) font.TextLayout {
+	return font.Layout(u.atlas.Font(), s, font.WithSize(u.theme.FontSize), font.WithMaxWidth(maxWidth))
+}
+
+// drawLayout appends the glyph quads of laid out text. Caller must hold u.mu.
+//
+// Parameters:
+//   - dst: the quad list to append to
+//   - lay: the laid out text
+//   - x: the left of the layout box
+//   - y: the top of the layout box
+//   - color: the text color
+//   - clip: the clip rectangle
+func (u *uiImpl) drawLayout(dst *[]quad, lay font.TextLayout, x, y float32, color [4]float32, clip Rect) {
+	// Atlas glyphs are rasterized at the atlas glyph size; k scales them to the font size.
+	k := lay.Size / u.atlas.GlyphSize()
+	packed := packColor(color)
+	for _, lg := range lay.Glyphs {
+		ag, ok := u.atlas.Glyph(lg.Glyph)
+		if !ok {
+			continue
+		}
+		*dst = append(*dst, quad{
+			rect: Rect{
+				X:      x + lg.X + ag.Left*k,
+				Y:      y + lg.Y - ag.Top*k,
+				Width:  float32(ag.Width) * k,
+				Height: float32(ag.Height) * k,
+			},
+			uv:    ag.UV,
+			clip:  clip,
+			color: packed,
+			glyph: true,
+		})
+	}
+}
+
+// textIn appends a single line of text aligned inside a rectangle, inset by the theme padding
+// when left or right aligned, vertically centered and clipped to the rectangle.
+// Caller must hold u.mu.
+//
+// Parameters:
+//   - dst: the quad list to append to
+//   - s: the text
+//   - r: the rectangle
+//   - align: the horizontal alignment
+//   - color: the text color
+//   - clip: the clip rectangle of the rectangle's container
+func (u *uiImpl) textIn(dst *[]quad, s string, r Rect, align font.Align, color [4]float32, clip Rect) {
+	if s == "" {
+		return
+	}
+	lay := u.layoutText(s, 0)
+	x := r.X + u.theme.Padding
+	switch align {
+	case font.AlignCenter:
+		x = r.X + (r.Width-lay.Width)/2
+	case font.AlignRight:
+		x = r.X + r.Width - u.theme.Padding - lay.Width
+	}
+	u.drawLayout(dst, lay, x, r.Y+(r.Height-lay.Height)/2, color, clip.intersect(r))
+}
+
+// packColor packs a linear RGBA color into four unorm8 values with red in the low byte,
+// matching WGSL's unpack4x8unorm.
+//
+// Parameters:
+//   - c: the color, each channel clamped to [0, 1]
+//
+// Returns:
+//   - uint32: the packed color
+func packColor(c [4]float32) uint32 {
+	var packed uint32
+	for i, v := range c {
+		packed |= uint32(clamp(v, 0, 1)*255+0.5) << (8 * i)
+	}
+	return packed
+}
+
+func clamp(v, lo, hi float32) float32 {
+	return max(lo, min(v, hi))
+}
@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/pipeline"
+	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
+	"github.com/cogentcore/webgpu/wgpu"
+)
+
+// PipelineKey is the pipeline key of the UI pass.
+const PipelineKey = "ui"
+
+// Bindings of the UI vertex shader's group 0.
+const (
+	paramsBinding = 0
+	quadBinding   = 1
+)
+
+// Groups of the UI shaders: the vertex shader's params and quads, and the fragment shader's
+// atlas texture and sampler.
+const (
+	quadGroup  = 0
+	atlasGroup = 1
+)
+
+const (
+	// paramsSize is the size of UIParams in bytes: the viewport size, padded to a vec4.
+	paramsSize = 16
+
+	// quadSize is the size of a UIQuad in bytes.
+	quadSize = 64
+
+	// verticesPerQuad is the number of vertices of a quad, two triangles.
+	verticesPerQuad = 6
+)
+
+func (u *uiImpl) Draw(r renderer.Renderer, width, height float32) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.viewHeight = height
+	if len(u.quads) == 0 || r == nil || width <= 0 || height <= 0 {
+		return nil
+	}
+	if err := u.initGPU(r); err != nil {
+		return err
+	}
+	if err := u.uploadAtlas(); err != nil {
+		return err
+	}
+
+	count := min(len(u.quads), u.maxQuads)
+	data := u.quadData[:0]
+	for _, q := range u.quads[:count] {
+		data = appendQuad(data, q)
+	}
+	u.quadData = data
+
+	params := make([]byte, 0, paramsSize)
+	for _, v := range [4]float32{width, height, 0, 0} {
+		params = binary.LittleEndian.AppendUint32(params, math.Float32bits(v))
+	}
+	r.WriteBuffers([]bind_group_provider.BufferWrite{
+		{Provider: u.quadBGP, Binding: paramsBinding, Offset: 0, Data: params},
+		{Provider: u.quadBGP, Binding: quadBinding, Offset: 0, Data: data},
+	})
+
+	bindGroups := []bind_group_provider.BindGroupProvider{u.quadBGP, u.atlasBGP}
+	if err := r.DrawProceduralRange(PipelineKey, 0, uint32(count*verticesPerQuad), bindGroups); err != nil {
+		return fmt.Errorf("ui draw failed: %w", err)
+	}
+	return nil
+}
+
+func (u *uiImpl) Release() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.releaseGPU()
+}
+
+// initGPU registers the UI pipeline and creates the bind groups on first use, or again when
+// drawing with a different renderer. Caller must hold u.mu.
+//
+// Parameters:
+//   - r: the renderer to create the resources on
+//
+// Returns:
+//   - error: an error if pipeline registration or bind group creation fails
+func (u *uiImpl) initGPU(r renderer.Renderer) error {
+	if u.quadBGP != nil && u.r == r {
+		return nil
+	}
+	u.releaseGPU()
+
+	// RegisterPipelines skips keys that are already cached, so every UI shares the pipeline
+	// registered by the first.
+	if err := r.RegisterPipelines(newUIPipeline()); err != nil {
+		return fmt.Errorf("failed to register ui pipeline: %w", err)
+	}
+
+	vert := r.Pipeline(PipelineKey).Shader(shader.ShaderTypeVertex)
+	quadBGP := bind_group_provider.NewBindGroupProvider("ui")
+	sizes := map[int]uint64{
+		paramsBinding: paramsSize,
+		quadBinding:   uint64(u.maxQuads) * quadSize,
+	}
+	if err := r.InitBindGroup(quadBGP, vert.BindGroupLayoutDescriptor(quadGroup), nil, sizes); err != nil {
+		quadBGP.Release()
+		return fmt.Errorf("failed to init ui bind group: %w", err)
+	}
+
+	// The atlas texture and sampler are located by their binding roles in the fragment shader.
+	frag := r.Pipeline(PipelineKey).Shader(shader.ShaderTypeFragment)
+	atlasBGP := bind_group_provider.NewBindGroupProvider("ui_atlas")
+	pixels, version := u.atlas.Pixels()
+	size := uint32(u.atlas.Size())
+	u.atlasBind = -1
+	var err error
+	for _, decl := range frag.Declarations() {
+		if decl.Type != shader.AnnotationTypeProvider || decl.Args[0] != shader.AnnotationArgUI || len(decl.Args) < 2 || decl.Binding == nil {
+			continue
+		}
+		switch decl.Args[1] {
+		case shader.AnnotationArgTextAtlas:
+			err = r.InitTextureView(atlasBGP, *decl.Binding, common.TextureStagingData{
+				Pixels: pixels,
+				Width:  size,
+				Height: size,
+				Format: wgpu.TextureFormatR8Unorm,
+			})
+			u.atlasBind = *decl.Binding
+		case shader.AnnotationArgTextSampler:
+			err = r.InitSampler(atlasBGP, *decl.Binding, common.SamplerStagingData{
+				AddressModeU:  wgpu.AddressModeClampToEdge,
+				AddressModeV:  wgpu.AddressModeClampToEdge,
+				AddressModeW:  wgpu.AddressModeClampToEdge,
+				MagFilter:     wgpu.FilterModeLinear,
+				MinFilter:     wgpu.FilterModeLinear,
+				MipmapFilter:  wgpu.MipmapFilterModeLinear,
+				LodMaxClamp:   32,
+				MaxAnisotropy: 1,
+			})
+		}
+		if err != nil {
+			quadBGP.Release()
+			atlasBGP.Release()
+			return fmt.Errorf("failed to init ui atlas: %w", err)
+		}
+	}
+	if err := r.InitBindGroup(atlasBGP, frag.BindGroupLayoutDescriptor(atlasGroup), nil, nil); err != nil {
+		quadBGP.Release()
+		atlasBGP.Release()
+		return fmt.Errorf("failed to init ui atlas bind group: %w", err)
+	}
+
+	u.r = r
+	u.quadBGP = quadBGP
+	u.atlasBGP = atlasBGP
+	u.atlasVer = version
+	return nil
+}
+
+// uploadAtlas re-uploads the atlas texture if glyphs were added since the last upload, and
+// rebuilds the atlas bind group around the new texture view. Caller must hold u.mu.
+//
+// Returns:
+//   - error: an error if the texture or bind group cannot be created
+func (u *uiImpl) uploadAtlas() error {
+	if u.atlas.Version() == u.atlasVer || u.atlasBind < 0 {
+		return nil
+	}
+	pixels, version := u.atlas.Pixels()
+	size := uint32(u.atlas.Size())
+
+	oldView := u.atlasBGP.TextureView(u.atlasBind)
+	err := u.r.InitTextureView(u.atlasBGP, u.atlasBind, common.TextureStagingData{
+		Pixels: pixels,
+		Width:  size,
+		Height: size,
+		Format: wgpu.TextureFormatR8Unorm,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload ui atlas: %w", err)
+	}
+	if oldView != nil {
+		oldView.Release()
+	}
+
+	// InitBindGroup reuses the existing layout and sampler.
+	frag := u.r.Pipeline(PipelineKey).Shader(shader.ShaderTypeFragment)
+	oldBindGroup := u.atlasBGP.BindGroup()
+	if err := u.r.InitBindGroup(u.atlasBGP, frag.BindGroupLayoutDescriptor(atlasGroup), nil, nil); err != nil {
+		return fmt.Errorf("failed to rebuild ui atlas bind group: %w", err)
+	}
+	if oldBindGroup != nil {
+		oldBindGroup.Release()
+	}
+	u.atlasVer = version
+	return nil
+}
+
+// releaseGPU releases the bind groups, buffers and atlas texture. Caller must hold u.mu.
+func (u *uiImpl) releaseGPU() {
+	if u.quadBGP != nil {
+		u.quadBGP.Release()
+	}
+	if u.atlasBGP != nil {
+		u.atlasBGP.Release()
+	}
+	u.quadBGP = nil
+	u.atlasBGP = nil
+	u.r = nil
+}
+
+// newUIPipeline creates the pipeline drawing UI quads over the scene with alpha blending and
+// without depth testing or writing.
+//
+// Returns:
+//   - pipeline.Pipeline: the pipeline to register
+func newUIPipeline() pipeline.Pipeline {
+	return pipeline.NewPipeline(PipelineKey, pipeline.PipelineTypeRender,
+		pipeline.WithVertexShader(shader.NewShaderFromSource(PipelineKey+"_vert", shader.ShaderTypeVertex, VertexSource)),
+		pipeline.WithFragmentShader(shader.NewShaderFromSource(PipelineKey+"_frag", shader.ShaderTypeFragment, FragmentSource)),
+		pipeline.WithDepthTestEnabled(false),
+		pipeline.WithDepthWriteEnabled(false),
+		pipeline.WithBlendEnabled(true),
+		pipeline.WithCullMode(wgpu.CullModeNone),
+	)
+}
+
+// appendQuad appends one packed UIQuad.
+//
+// Parameters:
+//   - buf: the buffer to append to
+//   - q: the quad
+//
+// Returns:
+//   - []byte: the buffer with the quad appended
+func appendQuad(buf []byte, q quad) []byte {
+	for _, v := range [12]float32{
+		q.rect.X, q.rect.Y, q.rect.Width, q.rect.Height,
+		q.uv[0], q.uv[1], q.uv[2], q.uv[3],
+		q.clip.X, q.clip.Y, q.clip.X + q.clip.Width, q.clip.Y + q.clip.Height,
+	} {
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(v))
+	}
+	var mode uint32
+	if q.glyph {
+		mode = 1
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, q.color)
+	buf = binary.LittleEndian.AppendUint32(buf, mode)
+	buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(q.radius))
+	return binary.LittleEndian.AppendUint32(buf, 0)
+}
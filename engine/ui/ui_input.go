@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/window"
+)
+
+// Input events are collected as they arrive from the window and applied by the next Begin, so a
+// whole frame of widgets sees the same input.
+
+func (u *uiImpl) OnKeyDown(keyCode uint32) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.pending.keys = append(u.pending.keys, keyEvent{key: keyCode})
+}
+
+func (u *uiImpl) OnKeyRepeat(keyCode uint32) {
+	u.OnKeyDown(keyCode)
+}
+
+func (u *uiImpl) OnKeyUp(keyCode uint32) {}
+
+func (u *uiImpl) OnChar(r rune) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.pending.keys = append(u.pending.keys, keyEvent{char: r})
+}
+
+func (u *uiImpl) OnMouseButtonDown(button uint32, x, y int32) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.pending.x, u.pending.y = float32(x), float32(y)
+	if button == common.MouseButtonLeft {
+		u.pending.down = true
+		u.pending.presses++
+	}
+}
+
+func (u *uiImpl) OnMouseButtonUp(button uint32, x, y int32) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.pending.x, u.pending.y = float32(x), float32(y)
+	if button == common.MouseButtonLeft {
+		u.pending.down = false
+		u.pending.releases++
+	}
+}
+
+func (u *uiImpl) OnMouseMove(x, y int32) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.pending.x, u.pending.y = float32(x), float32(y)
+}
+
+func (u *uiImpl) OnMouseDelta(dx, dy float32) {}
+
+func (u *uiImpl) OnScroll(xDelta, yDelta float32) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.pending.scroll += yDelta
+}
+
+func (u *uiImpl) OnGamepadConnected(id int) {}
+
+func (u *uiImpl) OnGamepadDisconnected(id int) {}
+
+func (u *uiImpl) OnGamepadState(id int, state window.GamepadState) {}
@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"github.com/Carmen-Shannon/oxy-go/engine/text/font"
+)
+
+func (u *uiImpl) BeginWindow(title string, rect Rect) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !u.inFrame {
+		panic("ui: BeginWindow called outside Begin and End")
+	}
+	if len(u.stack) > 0 {
+		panic("ui: BeginWindow called inside another window")
+	}
+
+	id := hashID(0, title)
+	c, ok := u.containers[id]
+	if !ok {
+		u.zTop++
+		c = &container{id: id, window: true, rect: rect, z: u.zTop}
+		u.containers[id] = c
+	}
+	c.lastFrame = u.frame
+	c.quads = c.quads[:0]
+	u.order = append(u.order, c)
+
+	// The toggle button collapses the window, the rest of the title bar moves it and the grip in
+	// the bottom-right corner resizes it. The toggle is hit first so it wins over the title bar.
+	th := u.rowHeight()
+	toggleID, moveID, resizeID := hashID(id, "#toggle"), hashID(id, "#move"), hashID(id, "#resize")
+	toggle := Rect{X: c.rect.X, Y: c.rect.Y, Width: th, Height: th}
+	if _, _, clicked := u.hit(toggleID, toggle, id, noClip); clicked {
+		c.collapsed = !c.collapsed
+	}
+	u.hit(moveID, Rect{X: c.rect.X, Y: c.rect.Y, Width: c.rect.Width, Height: th}, id, noClip)
+	if u.active == moveID {
+		c.rect.X += u.in.dx
+		c.rect.Y = max(c.rect.Y+u.in.dy, 0)
+	}
+	grip := u.theme.WindowPadding + u.theme.CornerRadius
+	if !c.collapsed {
+		u.hit(resizeID, Rect{X: c.rect.X + c.rect.Width - grip, Y: c.rect.Y + c.rect.Height - grip, Width: grip, Height: grip}, id, noClip)
+		if u.active == resizeID {
+			c.rect.Width = max(c.rect.Width+u.in.dx, 4*th)
+			c.rect.Height = max(c.rect.Height+u.in.dy, 2*th)
+		}
+	}
+
+	titleBar := Rect{X: c.rect.X, Y: c.rect.Y, Width: c.rect.Width, Height: th}
+	titleColor := u.theme.TitleBackground
+	if c.z == u.zTop {
+		titleColor = u.theme.TitleActive
+	}
+	if c.collapsed {
+		u.fill(&c.quads, titleBar, titleColor, u.theme.CornerRadius, noClip)
+	} else {
+		u.fill(&c.quads, c.rect, u.theme.WindowBackground, u.theme.CornerRadius, noClip)
+		u.fill(&c.quads, titleBar, titleColor, u.theme.CornerRadius, noClip)
+		// Square off the bottom corners of the title bar where it meets the body.
+		u.fill(&c.quads, Rect{X: titleBar.X, Y: titleBar.Y + th/2, Width: titleBar.Width, Height: th / 2}, titleColor, 0, noClip)
+	}
+	sign := "-"
+	if c.collapsed {
+		sign = "+"
+	}
+	u.textIn(&c.quads, sign, toggle, font.AlignCenter, u.theme.Text, titleBar)
+	u.textIn(&c.quads, splitLabel(title), Rect{X: titleBar.X + th, Y: titleBar.Y, Width: titleBar.Width - th, Height: th}, font.AlignLeft, u.theme.Text, titleBar)
+	if c.collapsed {
+		return false
+	}
+
+	pad := u.theme.WindowPadding
+	body := Rect{X: c.rect.X + pad, Y: c.rect.Y + th + pad, Width: c.rect.Width - 2*pad, Height: c.rect.Height - th - 2*pad}
+	u.pushLayout(c, c, body, body)
+	return true
+}
+
+func (u *uiImpl) EndWindow() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(u.stack) == 0 || !u.stack[len(u.stack)-1].c.window {
+		panic("ui: EndWindow called without a matching BeginWindow")
+	}
+	c := u.top().c
+	u.popLayout(noClip)
+
+	grip := u.theme.WindowPadding + u.theme.CornerRadius
+	color := u.theme.Border
+	if u.active == hashID(c.id, "#resize") {
+		color = u.theme.Accent
+	}
+	u.fill(&c.quads, Rect{X: c.rect.X + c.rect.Width - grip/2 - 2, Y: c.rect.Y + c.rect.Height - grip/2 - 2, Width: grip / 2, Height: grip / 2}, color, 1, noClip)
+}
+
+func (u *uiImpl) BeginPanel(id string, height float32) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l := u.top()
+
+	pid := hashID(l.c.id, id)
+	c, ok := u.containers[pid]
+	if !ok {
+		c = &container{id: pid}
+		u.containers[pid] = c
+	}
+
+	pad := u.theme.Padding
+	if height <= 0 {
+		height = c.contentHeight + 2*pad
+	}
+	r := u.next(height)
+	root, clip := l.root, l.clip
+	u.fill(&root.quads, r, u.theme.PanelBackground, u.theme.CornerRadius, clip)
+
+	body := Rect{X: r.X + pad, Y: r.Y + pad, Width: r.Width - 2*pad, Height: r.Height - 2*pad}
+	u.pushLayout(c, root, body, body.intersect(clip))
+}
+
+func (u *uiImpl) EndPanel() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(u.stack) < 2 || u.stack[len(u.stack)-1].c.window {
+		panic("ui: EndPanel called without a matching BeginPanel")
+	}
+	u.popLayout(u.stack[len(u.stack)-2].clip)
+}
+
+func (u *uiImpl) BeginRow(widths ...float32) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l := u.top()
+	if l.row != nil {
+		panic("ui: BeginRow called inside a row")
+	}
+	if len(widths) == 0 {
+		widths = []float32{0}
+	}
+
+	spacing := u.theme.Spacing
+	var fixed float32
+	var shared int
+	for _, w := range widths {
+		if w > 0 {
+			fixed += w
+		} else {
+			shared++
+		}
+	}
+	var share float32
+	if shared > 0 {
+		share = max(l.body.Width-spacing*float32(len(widths)-1)-fixed, 0) / float32(shared)
+	}
+
+	l.row = make([]float32, len(widths))
+	for i, w := range widths {
+		if w <= 0 {
+			w = share
+		}
+		l.row[i] = w
+	}
+	l.col = 0
+	l.rowX, l.rowY, l.rowHeight = l.body.X, l.y, 0
+}
+
+func (u *uiImpl) EndRow() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l := u.top()
+	if l.row == nil {
+		panic("ui: EndRow called without a matching BeginRow")
+	}
+	l.row = nil
+}
+
+// top returns the layout of the innermost open window or panel. Caller must hold u.mu.
+//
+// Returns:
+//   - *layout: the current layout
+func (u *uiImpl) top() *layout {
+	if len(u.stack) == 0 {
+		panic("ui: widgets must be placed between BeginWindow and EndWindow")
+	}
+	return &u.stack[len(u.stack)-1]
+}
+
+// next reserves the rectangle of the next widget in the current layout: the full content width
+// outside rows, the next cell inside them. Caller must hold u.mu.
+//
+// Parameters:
+//   - height: the widget height
+//
+// Returns:
+//   - Rect: the widget rectangle
+func (u *uiImpl) next(height float32) Rect {
+	l := u.top()
+	spacing := u.theme.Spacing
+	var r Rect
+	if l.row == nil {
+		r = Rect{X: l.body.X, Y: l.y, Width: l.body.Width, Height: height}
+		l.y += height + spacing
+	} else {
+		if l.col == len(l.row) {
+			l.rowX, l.rowY = l.body.X, l.rowY+l.rowHeight+spacing
+			l.col, l.rowHeight = 0, 0
+		}
+		r = Rect{X: l.rowX, Y: l.rowY, Width: l.row[l.col], Height: height}
+		l.rowX += r.Width + spacing
+		l.col++
+		l.rowHeight = max(l.rowHeight, height)
+		l.y = l.rowY + l.rowHeight + spacing
+	}
+	l.maxY = max(l.maxY, r.Y+r.Height)
+	return r
+}
+
+// nextWidth returns the width the next widget of the current layout will have.
+// Caller must hold u.mu.
+//
+// Returns:
+//   - float32: the width in pixels
+func (u *uiImpl) nextWidth() float32 {
+	l := u.top()
+	switch {
+	case l.row == nil:
+		return l.body.Width
+	case l.col == len(l.row):
+		return l.row[0]
+	}
+	return l.row[l.col]
+}
+
+// pushLayout opens the layout of a window or panel. Content that overflowed the container in the
+// previous frame leaves room for the scrollbar and is offset by the scroll position.
+// Caller must hold u.mu.
+//
+// Parameters:
+//   - c: the window or panel
+//   - root: the window the container is in
+//   - body: the content area
+//   - clip: the visible part of the content area
+func (u *uiImpl) pushLayout(c, root *container, body, clip Rect) {
+	if c.contentHeight > body.Height {
+		body.Width -= u.theme.ScrollbarWidth + u.theme.Spacing
+	}
+	c.scroll = clamp(c.scroll, 0, max(c.contentHeight-body.Height, 0))
+	c.body = body
+
+	top := body.Y - c.scroll
+	u.stack = append(u.stack, layout{c: c, root: root, body: body, clip: clip, top: top, y: top, maxY: top})
+}
+
+// popLayout closes the current layout: it records the content height, scrolls the container with
+// the mouse wheel or its scrollbar and draws the scrollbar when the content overflows.
+// Caller must hold u.mu.
+//
+// Parameters:
+//   - parentClip: the clip rectangle the scrollbar is drawn with
+func (u *uiImpl) popLayout(parentClip Rect) {
+	l := u.top()
+	if l.row != nil {
+		panic("ui: window or panel ended with an unfinished row")
+	}
+	c, root := l.c, l.root
+	c.contentHeight = l.maxY - l.top
+	overflow := c.contentHeight - c.body.Height
+
+	// The innermost container under the cursor takes the wheel.
+	if overflow > 0 && u.in.scroll != 0 && u.hoverRoot == root.id && l.clip.contains(u.in.x, u.in.y) {
+		c.scroll -= u.in.scroll * 3 * u.rowHeight()
+		u.in.scroll = 0
+	}
+
+	if overflow > 0 {
+		track := Rect{X: c.body.X + c.body.Width + u.theme.Spacing, Y: c.body.Y, Width: u.theme.ScrollbarWidth, Height: c.body.Height}
+		thumbHeight := max(track.Height*track.Height/c.contentHeight, u.theme.ScrollbarWidth*2)
+		thumb := Rect{X: track.X, Y: track.Y + (track.Height-thumbHeight)*clamp(c.scroll/overflow, 0, 1), Width: track.Width, Height: thumbHeight}
+
+		scrollID := hashID(c.id, "#scroll")
+		u.hit(scrollID, thumb, root.id, parentClip)
+		if u.active == scrollID && track.Height > thumbHeight {
+			c.scroll += u.in.dy * overflow / (track.Height - thumbHeight)
+		}
+		c.scroll = clamp(c.scroll, 0, overflow)
+		thumb.Y = track.Y + (track.Height-thumbHeight)*c.scroll/overflow
+
+		radius := u.theme.ScrollbarWidth / 2
+		u.fill(&root.quads, track, u.theme.Widget, radius, parentClip)
+		u.fill(&root.quads, thumb, u.theme.Accent, radius, parentClip)
+	}
+	u.stack = u.stack[:len(u.stack)-1]
+}
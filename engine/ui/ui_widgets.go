@@ -0,0 +1,318 @@
+package ui
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/Carmen-Shannon/oxy-go/common"
+	"github.com/Carmen-Shannon/oxy-go/engine/text/font"
+)
+
+func (u *uiImpl) Label(s string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l := u.top()
+
+	pad := u.theme.Padding
+	lay := u.layoutText(s, u.nextWidth()-2*pad)
+	height := max(u.rowHeight(), lay.Height+2*pad)
+	r := u.next(height)
+	u.drawLayout(&l.root.quads, lay, r.X+pad, r.Y+(height-lay.Height)/2, u.theme.Text, l.clip.intersect(r))
+}
+
+func (u *uiImpl) Separator() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l := u.top()
+
+	r := u.next(u.theme.Padding)
+	u.fill(&l.root.quads, Rect{X: r.X, Y: r.Y + r.Height/2, Width: r.Width, Height: 1}, u.theme.Border, 0, l.clip)
+}
+
+func (u *uiImpl) Button(label string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l := u.top()
+
+	id := hashID(l.c.id, label)
+	r := u.next(u.rowHeight())
+	hovered, _, clicked := u.hitCell(id, r)
+	u.fill(&l.root.quads, r, u.widgetColor(id, hovered), u.theme.CornerRadius, l.clip)
+	u.textIn(&l.root.quads, splitLabel(label), r, font.AlignCenter, u.theme.Text, l.clip)
+	return clicked
+}
+
+func (u *uiImpl) Checkbox(label string, value *bool) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l := u.top()
+
+	id := hashID(l.c.id, label)
+	r := u.next(u.rowHeight())
+	hovered, _, clicked := u.hitCell(id, r)
+	if clicked {
+		*value = !*value
+	}
+
+	size := u.theme.FontSize
+	box := Rect{X: r.X, Y: r.Y + (r.Height-size)/2, Width: size, Height: size}
+	u.fill(&l.root.quads, box, u.widgetColor(id, hovered), u.theme.CornerRadius, l.clip)
+	if *value {
+		inset := size / 4
+		mark := Rect{X: box.X + inset, Y: box.Y + inset, Width: size - 2*inset, Height: size - 2*inset}
+		u.fill(&l.root.quads, mark, u.theme.Accent, u.theme.CornerRadius/2, l.clip)
+	}
+	u.textIn(&l.root.quads, splitLabel(label), Rect{X: box.X + size, Y: r.Y, Width: r.Width - size, Height: r.Height}, font.AlignLeft, u.theme.Text, l.clip)
+	return clicked
+}
+
+func (u *uiImpl) Slider(label string, value *float32, lo, hi float32) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l := u.top()
+
+	id := hashID(l.c.id, label)
+	track := u.labelled(l, label, u.next(u.rowHeight()))
+	hovered, _, _ := u.hitCell(id, track)
+
+	old := *value
+	grab := u.theme.FontSize
+	if u.active == id && hi != lo && track.Width > grab {
+		t := clamp((u.in.x-track.X-grab/2)/(track.Width-grab), 0, 1)
+		*value = lo + t*(hi-lo)
+	}
+	var t float32
+	if hi != lo {
+		t = clamp((*value-lo)/(hi-lo), 0, 1)
+	}
+
+	inset := u.theme.Padding / 2
+	u.fill(&l.root.quads, track, u.widgetColor(id, hovered), u.theme.CornerRadius, l.clip)
+	u.fill(&l.root.quads, Rect{X: track.X + t*max(track.Width-grab, 0) + inset, Y: track.Y + inset, Width: grab - 2*inset, Height: track.Height - 2*inset}, u.theme.Accent, u.theme.CornerRadius, l.clip)
+	u.textIn(&l.root.quads, fmt.Sprintf("%.2f", *value), track, font.AlignCenter, u.theme.Text, l.clip)
+	return *value != old
+}
+
+func (u *uiImpl) TextField(label string, value *string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l := u.top()
+
+	id := hashID(l.c.id, label)
+	field := u.labelled(l, label, u.next(u.rowHeight()))
+	hovered, pressed, _ := u.hitCell(id, field)
+	pad := u.theme.Padding
+	inner := Rect{X: field.X + pad, Y: field.Y, Width: field.Width - 2*pad, Height: field.Height}
+
+	switch {
+	case pressed:
+		if u.focus != id {
+			u.focus = id
+			u.textShift = 0
+		}
+		u.caret = caretAt(u.layoutText(*value, 0), *value, u.in.x-inner.X+u.textShift)
+	case u.in.pressed && !hovered && u.focus == id:
+		u.focus = 0
+	}
+
+	old := *value
+	if u.focus == id {
+		u.focusSeen = true
+		*value, u.caret = u.edit(*value, min(u.caret, len(*value)))
+	}
+	focused := u.focus == id
+
+	color := u.widgetColor(id, hovered)
+	if focused {
+		color = u.theme.WidgetActive
+	}
+	u.fill(&l.root.quads, field, color, u.theme.CornerRadius, l.clip)
+
+	// The text of the focused field is shifted left when needed to keep the caret in view.
+	lay := u.layoutText(*value, 0)
+	var shift float32
+	if focused {
+		caretX := caretOffset(lay, u.caret)
+		u.textShift = clamp(u.textShift, caretX-inner.Width, caretX)
+		u.textShift = clamp(u.textShift, 0, max(caretOffset(lay, len(*value))-inner.Width, 0))
+		shift = u.textShift
+
+		u.fill(&l.root.quads, Rect{X: inner.X + caretX - shift, Y: field.Y + pad/2, Width: 1, Height: field.Height - pad}, u.theme.Accent, 0, l.clip.intersect(field))
+	}
+	u.drawLayout(&l.root.quads, lay, inner.X-shift, field.Y+(field.Height-lay.Height)/2, u.theme.Text, l.clip.intersect(inner))
+	return *value != old
+}
+
+func (u *uiImpl) Dropdown(label string, selected *int, items []string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l := u.top()
+
+	id := hashID(l.c.id, label)
+	field := u.labelled(l, label, u.next(u.rowHeight()))
+	hovered, _, clicked := u.hitCell(id, field)
+	if clicked {
+		if u.popupID == id {
+			u.popupID = 0
+		} else {
+			u.popupID = id
+		}
+	}
+
+	changed := false
+	if u.popupID == id {
+		u.popupSeen = true
+		th := u.rowHeight()
+		popup := Rect{X: field.X, Y: field.Y + field.Height, Width: field.Width, Height: th * float32(len(items))}
+		// Open upwards when the list would run past the bottom of the viewport.
+		if u.viewHeight > 0 && popup.Y+popup.Height > u.viewHeight && field.Y-popup.Height >= 0 {
+			popup.Y = field.Y - popup.Height
+		}
+		u.popupRect = popup
+		u.fill(&u.overlay, popup, u.theme.PopupBackground, u.theme.CornerRadius, noClip)
+
+		for i, item := range items {
+			ir := Rect{X: popup.X, Y: popup.Y + th*float32(i), Width: popup.Width, Height: th}
+			itemID := hashID(id, fmt.Sprint(i))
+			itemHovered, _, itemClicked := u.hit(itemID, ir, popupRoot, noClip)
+			switch {
+			case itemHovered:
+				u.fill(&u.overlay, ir, u.theme.WidgetHovered, u.theme.CornerRadius, noClip)
+			case i == *selected:
+				u.fill(&u.overlay, ir, u.theme.WidgetActive, u.theme.CornerRadius, noClip)
+			}
+			u.textIn(&u.overlay, item, ir, font.AlignLeft, u.theme.Text, noClip)
+			if itemClicked {
+				*selected = i
+				changed = true
+				u.popupID = 0
+			}
+		}
+
+		// A press anywhere but the list or the dropdown itself closes the list.
+		if u.in.pressed && u.hoverRoot != popupRoot && !hovered {
+			u.popupID = 0
+		}
+	}
+
+	u.fill(&l.root.quads, field, u.widgetColor(id, hovered), u.theme.CornerRadius, l.clip)
+	arrow := Rect{X: field.X + field.Width - u.rowHeight(), Y: field.Y, Width: u.rowHeight(), Height: field.Height}
+	if *selected >= 0 && *selected < len(items) {
+		u.textIn(&l.root.quads, items[*selected], Rect{X: field.X, Y: field.Y, Width: field.Width - arrow.Width, Height: field.Height}, font.AlignLeft, u.theme.Text, l.clip)
+	}
+	u.textIn(&l.root.quads, "v", arrow, font.AlignCenter, u.theme.Text, l.clip)
+	return changed
+}
+
+// labelled draws the label of a slider, text field or dropdown in the left part of its row and
+// returns the rest of the row for the control. Labels without shown text take no space.
+// Caller must hold u.mu.
+//
+// Parameters:
+//   - l: the current layout
+//   - label: the widget label
+//   - r: the widget row
+//
+// Returns:
+//   - Rect: the rectangle of the control
+func (u *uiImpl) labelled(l *layout, label string, r Rect) Rect {
+	shown := splitLabel(label)
+	if shown == "" {
+		return r
+	}
+	width := r.Width * u.theme.LabelWidth
+	u.textIn(&l.root.quads, shown, Rect{X: r.X, Y: r.Y, Width: width, Height: r.Height}, font.AlignLeft, u.theme.Text, l.clip)
+	return Rect{X: r.X + width, Y: r.Y, Width: r.Width - width, Height: r.Height}
+}
+
+// edit applies the frame's typed characters and editing keys to the focused text field and
+// consumes them. Caller must hold u.mu.
+//
+// Parameters:
+//   - s: the field text
+//   - caret: the byte offset of the caret
+//
+// Returns:
+//   - string: the edited text
+//   - int: the new caret offset
+func (u *uiImpl) edit(s string, caret int) (string, int) {
+	for _, ev := range u.in.keys {
+		if ev.char != 0 {
+			if ev.char < 0x20 || ev.char == 0x7f {
+				continue
+			}
+			s = s[:caret] + string(ev.char) + s[caret:]
+			caret += utf8.RuneLen(ev.char)
+			continue
+		}
+		switch ev.key {
+		case common.KeyBackspace:
+			if caret > 0 {
+				_, n := utf8.DecodeLastRuneInString(s[:caret])
+				s = s[:caret-n] + s[caret:]
+				caret -= n
+			}
+		case common.KeyDelete:
+			if caret < len(s) {
+				_, n := utf8.DecodeRuneInString(s[caret:])
+				s = s[:caret] + s[caret+n:]
+			}
+		case common.KeyLeft:
+			if caret > 0 {
+				_, n := utf8.DecodeLastRuneInString(s[:caret])
+				caret -= n
+			}
+		case common.KeyRight:
+			if caret < len(s) {
+				_, n := utf8.DecodeRuneInString(s[caret:])
+				caret += n
+			}
+		case common.KeyHome:
+			caret = 0
+		case common.KeyEnd:
+			caret = len(s)
+		case common.KeyEnter:
+			u.focus = 0
+		}
+	}
+	u.in.keys = u.in.keys[:0]
+	return s, caret
+}
+
+// caretAt returns the caret offset nearest to a horizontal position in a single line of text.
+//
+// Parameters:
+//   - lay: the laid out text
+//   - s: the text
+//   - x: the position in pixels from the left of the text
+//
+// Returns:
+//   - int: the byte offset of the caret
+func caretAt(lay font.TextLayout, s string, x float32) int {
+	for _, g := range lay.Glyphs {
+		if x < g.X+g.Advance/2 {
+			return g.Index
+		}
+	}
+	return len(s)
+}
+
+// caretOffset returns the horizontal position of a caret in a single line of text.
+//
+// Parameters:
+//   - lay: the laid out text
+//   - caret: the byte offset of the caret
+//
+// Returns:
+//   - float32: the position in pixels from the left of the text
+func caretOffset(lay font.TextLayout, caret int) float32 {
+	for _, g := range lay.Glyphs {
+		if g.Index >= caret {
+			return g.X
+		}
+	}
+	if n := len(lay.Glyphs); n > 0 {
+		return lay.Glyphs[n-1].X + lay.Glyphs[n-1].Advance
+	}
+	return 0
+}
@@ -2,11 +2,148 @@ package window
 
 import (
 	"fmt"
+	"math"
 	"runtime"
+	"slices"
+	"sync"
+	"time"
 
+	"github.com/Carmen-Shannon/oxy-go/common"
 	"github.com/cogentcore/webgpu/wgpu"
 )
 
+// InputListener receives raw input events from a Window. Unlike the single-slot
+// Set*Callback handlers, any number of listeners can be registered, allowing systems
+// such as the input package to observe events alongside user callbacks.
+// Listener methods are invoked on the window's message loop thread.
+type InputListener interface {
+	// OnKeyDown is called for key press events. Repeat events while a key is held are sent to
+	// listeners implementing KeyRepeatListener.
+	//
+	// Parameters:
+	//   - keyCode: the virtual key code (see common key codes)
+	OnKeyDown(keyCode uint32)
+
+	// OnKeyUp is called for key release events.
+	//
+	// Parameters:
+	//   - keyCode: the virtual key code (see common key codes)
+	OnKeyUp(keyCode uint32)
+
+	// OnMouseButtonDown is called when any mouse button is pressed.
+	//
+	// Parameters:
+	//   - button: the mouse button code (see common mouse button codes)
+	//   - x, y: cursor position in window coordinates
+	OnMouseButtonDown(button uint32, x, y int32)
+
+	// OnMouseButtonUp is called when any mouse button is released.
+	//
+	// Parameters:
+	//   - button: the mouse button code (see common mouse button codes)
+	//   - x, y: cursor position in window coordinates
+	OnMouseButtonUp(button uint32, x, y int32)
+
+	// OnMouseMove is called when the cursor moves.
+	//
+	// Parameters:
+	//   - x, y: cursor position in window coordinates
+	OnMouseMove(x, y int32)
+
+	// OnMouseDelta is called with the cursor motion since the previous move event.
+	// In CursorModeCaptured this is unbounded (raw, when supported) relative motion.
+	//
+	// Parameters:
+	//   - dx, dy: cursor motion in pixels (positive y = down)
+	OnMouseDelta(dx, dy float32)
+
+	// OnScroll is called for scroll wheel and trackpad scroll events.
+	//
+	// Parameters:
+	//   - xDelta: horizontal scroll offset (positive = right)
+	//   - yDelta: vertical scroll offset (positive = up)
+	OnScroll(xDelta, yDelta float32)
+
+	// OnGamepadConnected is called when a gamepad with a standard mapping is connected.
+	// Listeners added while gamepads are already connected receive one call per gamepad.
+	//
+	// Parameters:
+	//   - id: the gamepad slot (0-15)
+	OnGamepadConnected(id int)
+
+	// OnGamepadDisconnected is called when a gamepad is disconnected.
+	//
+	// Parameters:
+	//   - id: the gamepad slot (0-15)
+	OnGamepadDisconnected(id int)
+
+	// OnGamepadState is called when a connected gamepad's polled state changes.
+	//
+	// Parameters:
+	//   - id: the gamepad slot (0-15)
+	//   - state: the new gamepad state
+	OnGamepadState(id int, state GamepadState)
+}
+
+// CharListener is an optional extension of InputListener for listeners that also receive
+// text input. Listeners added with AddInputListener that implement it are sent each typed
+// character after keyboard layout, modifiers and dead keys are applied.
+type CharListener interface {
+	// OnChar is called for each character typed.
+	//
+	// Parameters:
+	//   - r: the Unicode character
+	OnChar(r rune)
+}
+
+// KeyRepeatListener is an optional extension of InputListener for listeners that also receive
+// key repeat events. Listeners added with AddInputListener that implement it are sent a repeat
+// at the platform's key repeat rate while a key is held after its press.
+type KeyRepeatListener interface {
+	// OnKeyRepeat is called for each repeat of a held key.
+	//
+	// Parameters:
+	//   - keyCode: the virtual key code (see common key codes)
+	OnKeyRepeat(keyCode uint32)
+}
+
+// GamepadButtonCount is the number of buttons in the standard gamepad layout.
+const GamepadButtonCount = 15
+
+// GamepadAxisCount is the number of axes in the standard gamepad layout.
+const GamepadAxisCount = 6
+
+// GamepadState is a snapshot of a gamepad in the standard layout. Indices are the
+// common.GamepadButton* and common.GamepadAxis* constants. Sticks range from -1 to 1
+// (positive y = down) and triggers from 0 to 1. No dead zone is applied.
+type GamepadState struct {
+	Buttons [GamepadButtonCount]bool
+	Axes    [GamepadAxisCount]float32
+}
+
+// CursorMode controls cursor visibility and how cursor motion is reported.
+type CursorMode int
+
+const (
+	// CursorModeNormal shows the cursor and reports absolute positions. Default.
+	CursorModeNormal CursorMode = iota
+
+	// CursorModeHidden hides the cursor while it is over the window but does not restrict it.
+	CursorModeHidden
+
+	// CursorModeCaptured hides and locks the cursor to the window and reports unbounded
+	// relative motion, using raw (unaccelerated) mouse motion where the platform supports it.
+	// Intended for FPS-style camera controls.
+	CursorModeCaptured
+)
+
+// defaultDoubleClickInterval is the maximum time between two presses of the same button
+// for the second press to be reported as a double-click.
+const defaultDoubleClickInterval = 500 * time.Millisecond
+
+// doubleClickDistance is the maximum cursor travel, in pixels, between the two presses of a double-click.
+const doubleClickDistance = 4
+
 // Window provides platform windowing and input event handling.
 // Wraps platform-specific window implementations with a common interface.
 type Window interface {
@@ -28,7 +165,7 @@ type Window interface {
 	//   - callback: function receiving scroll delta (positive = up/zoom in, negative = down/zoom out)
 	SetScrollCallback(callback func(delta float32))
 
-	// SetKeyDownCallback sets the callback for key press events.
+	// SetKeyDownCallback sets the callback for key press and repeat events.
 	//
 	// Parameters:
 	//   - callback: function receiving the virtual key code
@@ -40,6 +177,13 @@ type Window interface {
 	//   - callback: function receiving the virtual key code
 	SetKeyUpCallback(callback func(keyCode uint32))
 
+	// SetCharCallback sets the callback for text input. Unlike key events, it receives the
+	// typed characters after keyboard layout, modifiers and dead keys are applied.
+	//
+	// Parameters:
+	//   - callback: function receiving the typed character
+	SetCharCallback(callback func(r rune))
+
 	// SetMiddleMouseDownCallback sets the callback for middle mouse button press.
 	//
 	// Parameters:
@@ -58,6 +202,133 @@ type Window interface {
 	//   - callback: function receiving mouse x, y position
 	SetMouseMoveCallback(callback func(x, y int32))
 
+	// SetMouseButtonDownCallback sets the callback for presses of any mouse button.
+	// The middle-button callbacks still fire for the middle button.
+	//
+	// Parameters:
+	//   - callback: function receiving the button code (see common mouse button codes),
+	//     cursor x, y position, and held modifier bits (see common modifier bits)
+	SetMouseButtonDownCallback(callback func(button uint32, x, y int32, mods uint32))
+
+	// SetMouseButtonUpCallback sets the callback for releases of any mouse button.
+	//
+	// Parameters:
+	//   - callback: function receiving the button code, cursor x, y position, and held modifier bits
+	SetMouseButtonUpCallback(callback func(button uint32, x, y int32, mods uint32))
+
+	// SetDoubleClickCallback sets the callback for double-clicks. Fired on the second press
+	// of the same button within the double-click interval, after the button-down callback.
+	//
+	// Parameters:
+	//   - callback: function receiving the button code, cursor x, y position, and held modifier bits
+	SetDoubleClickCallback(callback func(button uint32, x, y int32, mods uint32))
+
+	// SetDoubleClickInterval sets the maximum time between presses of a double-click.
+	// Values <= 0 restore the default (500ms).
+	//
+	// Parameters:
+	//   - interval: the double-click interval
+	SetDoubleClickInterval(interval time.Duration)
+
+	// SetScroll2DCallback sets the callback for scroll events on both axes, including
+	// horizontal scroll from tilt wheels and trackpads.
+	//
+	// Parameters:
+	//   - callback: function receiving horizontal (positive = right) and vertical (positive = up) scroll offsets
+	SetScroll2DCallback(callback func(xDelta, yDelta float32))
+
+	// SetMouseDeltaCallback sets the callback for relative cursor motion. In CursorModeCaptured
+	// this is the only meaningful motion signal, since the cursor position is virtual.
+	//
+	// Parameters:
+	//   - callback: function receiving the cursor motion since the previous move event
+	SetMouseDeltaCallback(callback func(dx, dy float32))
+
+	// SetCursorEnterCallback sets the callback for the cursor entering or leaving the window.
+	//
+	// Parameters:
+	//   - callback: function receiving true when the cursor enters and false when it leaves
+	SetCursorEnterCallback(callback func(entered bool))
+
+	// SetCursorMode changes cursor visibility and motion reporting. Safe to call from any
+	// goroutine; the change is applied on the message loop thread before the next event poll.
+	//
+	// Parameters:
+	//   - mode: the CursorMode to apply
+	SetCursorMode(mode CursorMode)
+
+	// CursorMode returns the most recently requested cursor mode.
+	//
+	// Returns:
+	//   - CursorMode: the cursor mode
+	CursorMode() CursorMode
+
+	// CursorPosition returns the last reported cursor position in window coordinates.
+	//
+	// Returns:
+	//   - float32: cursor x
+	//   - float32: cursor y
+	CursorPosition() (float32, float32)
+
+	// CursorInside returns whether the cursor is currently over the window's client area.
+	//
+	// Returns:
+	//   - bool: true if the cursor is inside the window
+	CursorInside() bool
+
+	// SetGamepadConnectCallback sets the callback for gamepads being connected.
+	// Only joysticks with a standard gamepad mapping are reported.
+	//
+	// Parameters:
+	//   - callback: function receiving the gamepad slot and its mapping name
+	SetGamepadConnectCallback(callback func(id int, name string))
+
+	// SetGamepadDisconnectCallback sets the callback for gamepads being disconnected.
+	//
+	// Parameters:
+	//   - callback: function receiving the gamepad slot
+	SetGamepadDisconnectCallback(callback func(id int))
+
+	// Gamepads returns the slots of all connected gamepads in ascending order.
+	//
+	// Returns:
+	//   - []int: connected gamepad slots
+	Gamepads() []int
+
+	// GamepadName returns the mapping name of a connected gamepad.
+	//
+	// Parameters:
+	//   - id: the gamepad slot
+	//
+	// Returns:
+	//   - string: the gamepad name, or "" if not connected
+	GamepadName(id int) string
+
+	// GamepadState returns the most recently polled state of a gamepad. Gamepads are polled
+	// on the message loop thread every iteration; the engine samples this state once per tick.
+	//
+	// Parameters:
+	//   - id: the gamepad slot
+	//
+	// Returns:
+	//   - GamepadState: the gamepad state
+	//   - bool: false if the gamepad is not connected
+	GamepadState(id int) (GamepadState, bool)
+
+	// AddInputListener registers an additional receiver of raw input events.
+	// Listeners are invoked after the Set*Callback handlers for the same event.
+	// Adding the same listener twice has no effect.
+	//
+	// Parameters:
+	//   - listener: the InputListener to register
+	AddInputListener(listener InputListener)
+
+	// RemoveInputListener unregisters a previously added listener.
+	//
+	// Parameters:
+	//   - listener: the InputListener to remove
+	RemoveInputListener(listener InputListener)
+
 	// SurfaceDescriptor returns a wgpu.SurfaceDescriptor suitable for creating a WebGPU surface.
 	// The descriptor is platform-appropriate (Windows HWND, X11 Xlib, Wayland, macOS Metal, etc.)
 	// and is created by the wgpuglfw bridge from the underlying GLFW window.
@@ -132,12 +403,15 @@ type engineWindow struct {
 	// Positive delta = scroll up (zoom in), negative = scroll down (zoom out).
 	onScroll func(delta float32)
 
-	// onKeyDown is called when a key is pressed.
+	// onKeyDown is called when a key is pressed and when it repeats.
 	onKeyDown func(keyCode uint32)
 
 	// onKeyUp is called when a key is released.
 	onKeyUp func(keyCode uint32)
 
+	// onChar is called for each typed character.
+	onChar func(r rune)
+
 	// onMiddleMouseDown is called when the middle mouse button is pressed.
 	onMiddleMouseDown func(x, y int32)
 
@@ -146,6 +420,62 @@ type engineWindow struct {
 
 	// onMouseMove is called when the mouse moves within the window.
 	onMouseMove func(x, y int32)
+
+	// onMouseButtonDown is called when any mouse button is pressed.
+	onMouseButtonDown func(button uint32, x, y int32, mods uint32)
+
+	// onMouseButtonUp is called when any mouse button is released.
+	onMouseButtonUp func(button uint32, x, y int32, mods uint32)
+
+	// onDoubleClick is called on the second press of a double-click.
+	onDoubleClick func(button uint32, x, y int32, mods uint32)
+
+	// onScroll2D is called for scroll events with both horizontal and vertical offsets.
+	onScroll2D func(xDelta, yDelta float32)
+
+	// onMouseDelta is called with the relative cursor motion of each move event.
+	onMouseDelta func(dx, dy float32)
+
+	// onCursorEnter is called when the cursor enters or leaves the window.
+	onCursorEnter func(entered bool)
+
+	// cursorX, cursorY are the last reported cursor position. hasCursor is false until the
+	// first position after creation, a cursor mode change, or re-entry, so no spurious delta is reported.
+	cursorX      float64
+	cursorY      float64
+	hasCursor    bool
+	cursorInside bool
+
+	// cursorMode is the requested cursor mode; cursorModeDirty marks it for application
+	// on the message loop thread.
+	cursorMode      CursorMode
+	cursorModeDirty bool
+	cursorMu        sync.Mutex
+
+	// Double-click tracking for the most recent press.
+	doubleClickInterval time.Duration
+	lastClickButton     uint32
+	lastClickTime       time.Time
+	lastClickX          float64
+	lastClickY          float64
+
+	// onGamepadConnect is called when a gamepad is connected.
+	onGamepadConnect func(id int, name string)
+
+	// onGamepadDisconnect is called when a gamepad is disconnected.
+	onGamepadDisconnect func(id int)
+
+	// gamepads holds the last polled state of each connected gamepad, keyed by slot.
+	gamepads     map[int]GamepadState
+	gamepadNames map[int]string
+	gamepadsMu   sync.RWMutex
+
+	// gamepadMappings is an SDL_GameControllerDB mapping string applied on creation.
+	gamepadMappings string
+
+	// listeners receive every raw input event in addition to the callbacks above.
+	listeners   []InputListener
+	listenersMu sync.RWMutex
 }
 
 var _ Window = &engineWindow{}
@@ -167,6 +497,10 @@ func NewWindow(options ...WindowBuilderOption) Window {
 		minHeight: 200,
 		width:     1280,
 		height:    720,
+
+		doubleClickInterval: defaultDoubleClickInterval,
+		gamepads:            make(map[int]GamepadState),
+		gamepadNames:        make(map[int]string),
 	}
 	for _, opt := range options {
 		opt(w)
@@ -197,6 +531,10 @@ func (w *engineWindow) SetKeyUpCallback(callback func(keyCode uint32)) {
 	w.onKeyUp = callback
 }
 
+func (w *engineWindow) SetCharCallback(callback func(r rune)) {
+	w.onChar = callback
+}
+
 func (w *engineWindow) SetMiddleMouseDownCallback(callback func(x, y int32)) {
 	w.onMiddleMouseDown = callback
 }
@@ -209,6 +547,353 @@ func (w *engineWindow) SetMouseMoveCallback(callback func(x, y int32)) {
 	w.onMouseMove = callback
 }
 
+func (w *engineWindow) SetMouseButtonDownCallback(callback func(button uint32, x, y int32, mods uint32)) {
+	w.onMouseButtonDown = callback
+}
+
+func (w *engineWindow) SetMouseButtonUpCallback(callback func(button uint32, x, y int32, mods uint32)) {
+	w.onMouseButtonUp = callback
+}
+
+func (w *engineWindow) SetDoubleClickCallback(callback func(button uint32, x, y int32, mods uint32)) {
+	w.onDoubleClick = callback
+}
+
+func (w *engineWindow) SetDoubleClickInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultDoubleClickInterval
+	}
+	w.doubleClickInterval = interval
+}
+
+func (w *engineWindow) SetScroll2DCallback(callback func(xDelta, yDelta float32)) {
+	w.onScroll2D = callback
+}
+
+func (w *engineWindow) SetMouseDeltaCallback(callback func(dx, dy float32)) {
+	w.onMouseDelta = callback
+}
+
+func (w *engineWindow) SetCursorEnterCallback(callback func(entered bool)) {
+	w.onCursorEnter = callback
+}
+
+func (w *engineWindow) SetCursorMode(mode CursorMode) {
+	w.cursorMu.Lock()
+	defer w.cursorMu.Unlock()
+	if mode == w.cursorMode {
+		return
+	}
+	w.cursorMode = mode
+	w.cursorModeDirty = true
+}
+
+func (w *engineWindow) CursorMode() CursorMode {
+	w.cursorMu.Lock()
+	defer w.cursorMu.Unlock()
+	return w.cursorMode
+}
+
+func (w *engineWindow) CursorPosition() (float32, float32) {
+	return float32(w.cursorX), float32(w.cursorY)
+}
+
+func (w *engineWindow) CursorInside() bool {
+	return w.cursorInside
+}
+
+func (w *engineWindow) SetGamepadConnectCallback(callback func(id int, name string)) {
+	w.onGamepadConnect = callback
+}
+
+func (w *engineWindow) SetGamepadDisconnectCallback(callback func(id int)) {
+	w.onGamepadDisconnect = callback
+}
+
+func (w *engineWindow) Gamepads() []int {
+	w.gamepadsMu.RLock()
+	defer w.gamepadsMu.RUnlock()
+	ids := make([]int, 0, len(w.gamepads))
+	for id := range w.gamepads {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (w *engineWindow) GamepadName(id int) string {
+	w.gamepadsMu.RLock()
+	defer w.gamepadsMu.RUnlock()
+	return w.gamepadNames[id]
+}
+
+func (w *engineWindow) GamepadState(id int) (GamepadState, bool) {
+	w.gamepadsMu.RLock()
+	defer w.gamepadsMu.RUnlock()
+	state, ok := w.gamepads[id]
+	return state, ok
+}
+
+func (w *engineWindow) AddInputListener(listener InputListener) {
+	w.listenersMu.Lock()
+	if slices.Contains(w.listeners, listener) {
+		w.listenersMu.Unlock()
+		return
+	}
+	w.listeners = append(w.listeners, listener)
+	w.listenersMu.Unlock()
+
+	// Replay already connected gamepads so late listeners see the same devices as early ones.
+	for _, id := range w.Gamepads() {
+		state, ok := w.GamepadState(id)
+		if !ok {
+			continue
+		}
+		listener.OnGamepadConnected(id)
+		listener.OnGamepadState(id, state)
+	}
+}
+
+func (w *engineWindow) RemoveInputListener(listener InputListener) {
+	w.listenersMu.Lock()
+	defer w.listenersMu.Unlock()
+	if i := slices.Index(w.listeners, listener); i >= 0 {
+		w.listeners = slices.Delete(w.listeners, i, i+1)
+	}
+}
+
+// notifyListeners invokes fn for each registered InputListener. The listener slice is
+// copied under the read lock so listeners may add or remove listeners from within fn.
+//
+// Parameters:
+//   - fn: the function to call for each listener
+func (w *engineWindow) notifyListeners(fn func(l InputListener)) {
+	w.listenersMu.RLock()
+	if len(w.listeners) == 0 {
+		w.listenersMu.RUnlock()
+		return
+	}
+	snapshot := slices.Clone(w.listeners)
+	w.listenersMu.RUnlock()
+
+	for _, l := range snapshot {
+		fn(l)
+	}
+}
+
+// dispatchMouseButton delivers a mouse button event to the general button callbacks, the
+// middle-button callbacks, the double-click callback, and all input listeners, in that order.
+//
+// Parameters:
+//   - button: the mouse button code
+//   - pressed: true for a press, false for a release
+//   - x, y: cursor position in window coordinates
+//   - mods: held modifier bits
+func (w *engineWindow) dispatchMouseButton(button uint32, pressed bool, x, y float64, mods uint32) {
+	ix, iy := int32(x), int32(y)
+
+	if !pressed {
+		if w.onMouseButtonUp != nil {
+			w.onMouseButtonUp(button, ix, iy, mods)
+		}
+		if button == common.MouseButtonMiddle && w.onMiddleMouseUp != nil {
+			w.onMiddleMouseUp(ix, iy)
+		}
+		w.notifyListeners(func(l InputListener) { l.OnMouseButtonUp(button, ix, iy) })
+		return
+	}
+
+	if w.onMouseButtonDown != nil {
+		w.onMouseButtonDown(button, ix, iy, mods)
+	}
+	if button == common.MouseButtonMiddle && w.onMiddleMouseDown != nil {
+		w.onMiddleMouseDown(ix, iy)
+	}
+
+	now := time.Now()
+	double := !w.lastClickTime.IsZero() &&
+		button == w.lastClickButton &&
+		now.Sub(w.lastClickTime) <= w.doubleClickInterval &&
+		math.Abs(x-w.lastClickX) <= doubleClickDistance &&
+		math.Abs(y-w.lastClickY) <= doubleClickDistance
+	if double {
+		// Reset so a third press starts a new double-click instead of completing another.
+		w.lastClickTime = time.Time{}
+		if w.onDoubleClick != nil {
+			w.onDoubleClick(button, ix, iy, mods)
+		}
+	} else {
+		w.lastClickButton = button
+		w.lastClickTime = now
+		w.lastClickX, w.lastClickY = x, y
+	}
+
+	w.notifyListeners(func(l InputListener) { l.OnMouseButtonDown(button, ix, iy) })
+}
+
+// dispatchScroll delivers a scroll event to the vertical and two-axis scroll callbacks and all input listeners.
+//
+// Parameters:
+//   - xoff: horizontal scroll offset (positive = right)
+//   - yoff: vertical scroll offset (positive = up)
+func (w *engineWindow) dispatchScroll(xoff, yoff float32) {
+	if w.onScroll != nil && yoff != 0 {
+		w.onScroll(yoff)
+	}
+	if w.onScroll2D != nil {
+		w.onScroll2D(xoff, yoff)
+	}
+	w.notifyListeners(func(l InputListener) { l.OnScroll(xoff, yoff) })
+}
+
+// dispatchChar delivers a typed character to the char callback and to the input listeners
+// that implement CharListener.
+//
+// Parameters:
+//   - r: the typed character
+func (w *engineWindow) dispatchChar(r rune) {
+	if w.onChar != nil {
+		w.onChar(r)
+	}
+	w.notifyListeners(func(l InputListener) {
+		if cl, ok := l.(CharListener); ok {
+			cl.OnChar(r)
+		}
+	})
+}
+
+// dispatchKeyDown delivers a key press or repeat to the key down callback and to the input
+// listeners. Presses are sent to OnKeyDown and repeats to the listeners that implement
+// KeyRepeatListener.
+//
+// Parameters:
+//   - keyCode: the virtual key code
+//   - repeat: whether the event repeats a held key
+func (w *engineWindow) dispatchKeyDown(keyCode uint32, repeat bool) {
+	if w.onKeyDown != nil {
+		w.onKeyDown(keyCode)
+	}
+	w.notifyListeners(func(l InputListener) {
+		if !repeat {
+			l.OnKeyDown(keyCode)
+		} else if rl, ok := l.(KeyRepeatListener); ok {
+			rl.OnKeyRepeat(keyCode)
+		}
+	})
+}
+
+// dispatchCursorPos records a new cursor position and delivers the absolute move and the
+// relative delta since the previous position.
+//
+// Parameters:
+//   - x, y: cursor position in window coordinates (virtual and unbounded in CursorModeCaptured)
+func (w *engineWindow) dispatchCursorPos(x, y float64) {
+	dx, dy := float32(x-w.cursorX), float32(y-w.cursorY)
+	hadCursor := w.hasCursor
+	w.cursorX, w.cursorY = x, y
+	w.hasCursor = true
+
+	if w.onMouseMove != nil {
+		w.onMouseMove(int32(x), int32(y))
+	}
+	w.notifyListeners(func(l InputListener) { l.OnMouseMove(int32(x), int32(y)) })
+
+	if !hadCursor || (dx == 0 && dy == 0) {
+		return
+	}
+	if w.onMouseDelta != nil {
+		w.onMouseDelta(dx, dy)
+	}
+	w.notifyListeners(func(l InputListener) { l.OnMouseDelta(dx, dy) })
+}
+
+// dispatchCursorEnter records whether the cursor is over the window and notifies the enter callback.
+// The delta baseline is reset on entry so re-entering at a different edge does not produce a jump.
+//
+// Parameters:
+//   - entered: true when the cursor enters the window, false when it leaves
+func (w *engineWindow) dispatchCursorEnter(entered bool) {
+	w.cursorInside = entered
+	if entered {
+		w.hasCursor = false
+	}
+	if w.onCursorEnter != nil {
+		w.onCursorEnter(entered)
+	}
+}
+
+// dispatchGamepadConnected records a newly connected gamepad and notifies the connect callback and listeners.
+//
+// Parameters:
+//   - id: the gamepad slot
+//   - name: the gamepad mapping name
+//   - state: the gamepad's initial state
+func (w *engineWindow) dispatchGamepadConnected(id int, name string, state GamepadState) {
+	w.gamepadsMu.Lock()
+	w.gamepads[id] = state
+	w.gamepadNames[id] = name
+	w.gamepadsMu.Unlock()
+
+	if w.onGamepadConnect != nil {
+		w.onGamepadConnect(id, name)
+	}
+	w.notifyListeners(func(l InputListener) {
+		l.OnGamepadConnected(id)
+		l.OnGamepadState(id, state)
+	})
+}
+
+// dispatchGamepadDisconnected forgets a gamepad and notifies the disconnect callback and listeners.
+//
+// Parameters:
+//   - id: the gamepad slot
+func (w *engineWindow) dispatchGamepadDisconnected(id int) {
+	w.gamepadsMu.Lock()
+	_, ok := w.gamepads[id]
+	delete(w.gamepads, id)
+	delete(w.gamepadNames, id)
+	w.gamepadsMu.Unlock()
+	if !ok {
+		return
+	}
+
+	if w.onGamepadDisconnect != nil {
+		w.onGamepadDisconnect(id)
+	}
+	w.notifyListeners(func(l InputListener) { l.OnGamepadDisconnected(id) })
+}
+
+// dispatchGamepadState stores a freshly polled gamepad state and notifies listeners if it changed.
+//
+// Parameters:
+//   - id: the gamepad slot
+//   - state: the polled state
+func (w *engineWindow) dispatchGamepadState(id int, state GamepadState) {
+	w.gamepadsMu.Lock()
+	prev, ok := w.gamepads[id]
+	if !ok || prev == state {
+		w.gamepadsMu.Unlock()
+		return
+	}
+	w.gamepads[id] = state
+	w.gamepadsMu.Unlock()
+
+	w.notifyListeners(func(l InputListener) { l.OnGamepadState(id, state) })
+}
+
+// takeCursorMode returns the requested cursor mode if it has changed since the last call.
+//
+// Returns:
+//   - CursorMode: the requested mode
+//   - bool: true if the mode must be applied
+func (w *engineWindow) takeCursorMode() (CursorMode, bool) {
+	w.cursorMu.Lock()
+	defer w.cursorMu.Unlock()
+	dirty := w.cursorModeDirty
+	w.cursorModeDirty = false
+	return w.cursorMode, dirty
+}
+
 func (w *engineWindow) SurfaceDescriptor() *wgpu.SurfaceDescriptor {
 	return platformGetSurfaceDescriptor(w)
 }
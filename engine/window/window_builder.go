@@ -1,5 +1,7 @@
 package window
 
+import "time"
+
 // WindowBuilderOption is a functional option for configuring an engineWindow.
 // Use the With* functions to create options.
 type WindowBuilderOption func(w *engineWindow)
@@ -94,3 +96,44 @@ func WithHeight(height int) WindowBuilderOption {
 		w.height = height
 	}
 }
+
+// WithDoubleClickInterval sets the maximum time between two presses of a double-click.
+// Values <= 0 will be treated as the default (500ms).
+//
+// Parameters:
+//   - interval: the double-click interval
+//
+// Returns:
+//   - WindowBuilderOption: option function to apply
+func WithDoubleClickInterval(interval time.Duration) WindowBuilderOption {
+	return func(w *engineWindow) {
+		w.SetDoubleClickInterval(interval)
+	}
+}
+
+// WithCursorMode sets the initial cursor mode, applied on the first message loop iteration.
+//
+// Parameters:
+//   - mode: the CursorMode to start in (default CursorModeNormal)
+//
+// Returns:
+//   - WindowBuilderOption: option function to apply
+func WithCursorMode(mode CursorMode) WindowBuilderOption {
+	return func(w *engineWindow) {
+		w.SetCursorMode(mode)
+	}
+}
+
+// WithGamepadMappings supplies additional SDL_GameControllerDB mappings applied when the window
+// is created, for controllers that GLFW's built-in database does not recognize.
+//
+// Parameters:
+//   - mappings: one or more newline-separated SDL_GameControllerDB mapping lines
+//
+// Returns:
+//   - WindowBuilderOption: option function to apply
+func WithGamepadMappings(mappings string) WindowBuilderOption {
+	return func(w *engineWindow) {
+		w.gamepadMappings = mappings
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"runtime"
 
+	"github.com/Carmen-Shannon/oxy-go/common"
 	"github.com/cogentcore/webgpu/wgpu"
 	"github.com/cogentcore/webgpu/wgpuglfw"
 	"github.com/go-gl/glfw/v3.3/glfw"
@@ -54,47 +55,72 @@ func newPlatformWindow(w *engineWindow) error {
 		}
 		switch action {
 		case glfw.Press, glfw.Repeat:
-			if w.onKeyDown != nil {
-				w.onKeyDown(uint32(key))
-			}
+			w.dispatchKeyDown(uint32(key), action == glfw.Repeat)
 		case glfw.Release:
 			if w.onKeyUp != nil {
 				w.onKeyUp(uint32(key))
 			}
+			w.notifyListeners(func(l InputListener) { l.OnKeyUp(uint32(key)) })
 		}
 	})
 
+	// Reference: https://pkg.go.dev/github.com/go-gl/glfw/v3.3/glfw#Window.SetCharCallback
+	win.SetCharCallback(func(_ *glfw.Window, char rune) {
+		w.dispatchChar(char)
+	})
+
 	// Reference: https://pkg.go.dev/github.com/go-gl/glfw/v3.3/glfw#Window.SetScrollCallback
 	win.SetScrollCallback(func(_ *glfw.Window, xoff, yoff float64) {
-		if w.onScroll != nil {
-			w.onScroll(float32(yoff))
-		}
+		w.dispatchScroll(float32(xoff), float32(yoff))
 	})
 
+	// GLFW modifier bits match the common Mod* constants, so they are forwarded unchanged.
 	// Reference: https://pkg.go.dev/github.com/go-gl/glfw/v3.3/glfw#Window.SetMouseButtonCallback
 	win.SetMouseButtonCallback(func(_ *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
-		if button == glfw.MouseButtonMiddle {
-			xpos, ypos := win.GetCursorPos()
-			switch action {
-			case glfw.Press:
-				if w.onMiddleMouseDown != nil {
-					w.onMiddleMouseDown(int32(xpos), int32(ypos))
-				}
-			case glfw.Release:
-				if w.onMiddleMouseUp != nil {
-					w.onMiddleMouseUp(int32(xpos), int32(ypos))
-				}
-			}
+		if action != glfw.Press && action != glfw.Release {
+			return
 		}
+		xpos, ypos := win.GetCursorPos()
+		w.dispatchMouseButton(uint32(button), action == glfw.Press, xpos, ypos, uint32(mods))
 	})
 
 	// Reference: https://pkg.go.dev/github.com/go-gl/glfw/v3.3/glfw#Window.SetCursorPosCallback
 	win.SetCursorPosCallback(func(_ *glfw.Window, xpos, ypos float64) {
-		if w.onMouseMove != nil {
-			w.onMouseMove(int32(xpos), int32(ypos))
+		w.dispatchCursorPos(xpos, ypos)
+	})
+
+	// Reference: https://pkg.go.dev/github.com/go-gl/glfw/v3.3/glfw#Window.SetCursorEnterCallback
+	win.SetCursorEnterCallback(func(_ *glfw.Window, entered bool) {
+		w.dispatchCursorEnter(entered)
+	})
+
+	if w.gamepadMappings != "" && !glfw.UpdateGamepadMappings(w.gamepadMappings) {
+		win.Destroy()
+		glfw.Terminate()
+		return fmt.Errorf("failed to apply gamepad mappings")
+	}
+
+	// Joystick events are global in GLFW and delivered during PollEvents on the main thread.
+	// Only joysticks with a standard gamepad mapping are surfaced.
+	// Reference: https://www.glfw.org/docs/latest/input_guide.html#joystick_event
+	glfw.SetJoystickCallback(func(joy glfw.Joystick, event glfw.PeripheralEvent) {
+		switch event {
+		case glfw.Connected:
+			if joy.IsGamepad() {
+				w.dispatchGamepadConnected(int(joy), joy.GetGamepadName(), readGamepadState(joy))
+			}
+		case glfw.Disconnected:
+			w.dispatchGamepadDisconnected(int(joy))
 		}
 	})
 
+	// Pick up gamepads that were connected before the window was created.
+	for joy := glfw.Joystick1; joy <= glfw.JoystickLast; joy++ {
+		if joy.Present() && joy.IsGamepad() {
+			w.dispatchGamepadConnected(int(joy), joy.GetGamepadName(), readGamepadState(joy))
+		}
+	}
+
 	// Use framebuffer size callback for pixel-accurate resize events.
 	// On high-DPI displays (e.g., macOS Retina), framebuffer size differs from window size.
 	// The renderer requires pixel dimensions for correct surface configuration.
@@ -163,11 +189,94 @@ func platformCloseWindow(w *engineWindow) error {
 	return nil
 }
 
+// platformApplyCursorMode applies a pending cursor mode change. GLFW input modes may only be
+// changed from the main thread, so SetCursorMode defers the change to the message loop.
+// Raw mouse motion is enabled in captured mode where the platform supports it.
+//
+// Reference: https://www.glfw.org/docs/latest/input_guide.html#cursor_mode
+// Reference: https://www.glfw.org/docs/latest/input_guide.html#raw_mouse_motion
+//
+// Parameters:
+//   - w: the engineWindow whose cursor mode to apply
+func platformApplyCursorMode(w *engineWindow) {
+	if w.internalWindow == nil {
+		return
+	}
+	mode, dirty := w.takeCursorMode()
+	if !dirty {
+		return
+	}
+	gw := w.internalWindow.(*glfwWindow)
+
+	switch mode {
+	case CursorModeHidden:
+		gw.window.SetInputMode(glfw.CursorMode, glfw.CursorHidden)
+	case CursorModeCaptured:
+		gw.window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+	default:
+		gw.window.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+	}
+
+	if glfw.RawMouseMotionSupported() {
+		raw := glfw.False
+		if mode == CursorModeCaptured {
+			raw = glfw.True
+		}
+		gw.window.SetInputMode(glfw.RawMouseMotion, raw)
+	}
+
+	// Switching modes moves the (virtual) cursor; start a new delta baseline.
+	w.hasCursor = false
+}
+
 // platformProcessMessages polls GLFW for pending events without blocking.
 // This is the GLFW equivalent of the Win32 PeekMessage loop.
 //
 // Reference: https://pkg.go.dev/github.com/go-gl/glfw/v3.3/glfw#PollEvents
 func platformProcessMessages(w *engineWindow) bool {
+	platformApplyCursorMode(w)
 	glfw.PollEvents()
+	platformPollGamepads(w)
 	return platformIsRunningCheck(w)
 }
+
+// platformPollGamepads reads the state of every connected gamepad. GLFW has no gamepad
+// state events, so state is polled once per message loop iteration and listeners are only
+// notified of changes.
+//
+// Reference: https://www.glfw.org/docs/latest/input_guide.html#gamepad
+//
+// Parameters:
+//   - w: the engineWindow whose gamepads to poll
+func platformPollGamepads(w *engineWindow) {
+	for _, id := range w.Gamepads() {
+		joy := glfw.Joystick(id)
+		if !joy.IsGamepad() {
+			continue
+		}
+		w.dispatchGamepadState(id, readGamepadState(joy))
+	}
+}
+
+// readGamepadState converts GLFW's gamepad state to a GamepadState. GLFW reports triggers
+// from -1 (released) to 1; they are remapped to 0..1 so a released trigger reads as zero.
+//
+// Parameters:
+//   - joy: the joystick to read
+//
+// Returns:
+//   - GamepadState: the converted state, or the zero state if the joystick has no gamepad mapping
+func readGamepadState(joy glfw.Joystick) GamepadState {
+	var state GamepadState
+	gs := joy.GetGamepadState()
+	if gs == nil {
+		return state
+	}
+	for i := range state.Buttons {
+		state.Buttons[i] = gs.Buttons[i] == glfw.Press
+	}
+	copy(state.Axes[:], gs.Axes[:])
+	state.Axes[common.GamepadAxisLeftTrigger] = (state.Axes[common.GamepadAxisLeftTrigger] + 1) / 2
+	state.Axes[common.GamepadAxisRightTrigger] = (state.Axes[common.GamepadAxisRightTrigger] + 1) / 2
+	return state
+}
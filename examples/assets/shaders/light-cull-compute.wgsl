@@ -1,7 +1,9 @@
 // Forward+ light culling compute shader
 //
 // Divides the screen into a grid of 16×16 pixel tiles and assigns lights to
-// each tile using workgroup-cooperative culling. Each workgroup handles one tile
+// each tile using workgroup-cooperative culling. Tiles are mapped into NDC
+// through the scene's viewport, so a scene drawn into a sub-rectangle of the
+// screen culls against its own camera; tiles outside the viewport get no lights. Each workgroup handles one tile
 // with 256 threads (16×16). Threads cooperatively test lights against the tile's
 // view-space frustum planes, accumulating visible light indices into shared
 // memory. The final per-tile light list and count are written to global storage
@@ -30,11 +32,11 @@ const NUM_THREADS: u32 = 256u; // TILE_SIZE * TILE_SIZE
 //     inner_cone:     f32,
 //     outer_cone:     f32,
 //     casts_shadows:  u32,
-//     _pad:           u32,
+//     shadow_index:   i32,
 // };
 
 // ── Per-frame uniforms ─────────────────────────────────────────────
-// Must match Go's light.GPULightCullUniforms (160 bytes).
+// Must match Go's light.GPULightCullUniforms (176 bytes).
 //@oxy:include light_cull_uniforms
 // struct LightCullUniforms {
 //     inv_proj:       mat4x4<f32>,
@@ -47,6 +49,7 @@ const NUM_THREADS: u32 = 256u; // TILE_SIZE * TILE_SIZE
 //     near:           f32,
 //     far:            f32,
 //     _pad:           u32,
+//     viewport:       vec4<f32>,
 // };
 
 // Light type constants matching the fragment shader.
@@ -81,8 +84,7 @@ fn unproject_ndc(ndc_xy: vec2<f32>) -> vec3<f32> {
 // Planes pass through the origin (camera position in view space) with
 // inward-pointing normals.
 fn build_tile_frustum(tile_x: u32, tile_y: u32) -> TileFrustum {
-    let sw = f32(cull_uniforms.screen_width);
-    let sh = f32(cull_uniforms.screen_height);
+    let vp = cull_uniforms.viewport;
 
     // Tile corners in screen pixels.
     let min_px = f32(tile_x * TILE_SIZE);
@@ -90,11 +92,11 @@ fn build_tile_frustum(tile_x: u32, tile_y: u32) -> TileFrustum {
     let min_py = f32(tile_y * TILE_SIZE);
     let max_py = f32(min(tile_y * TILE_SIZE + TILE_SIZE, cull_uniforms.screen_height));
 
-    // Convert to NDC [-1, 1]. Screen Y is top-down, NDC Y is bottom-up.
-    let min_x_ndc = min_px / sw * 2.0 - 1.0;
-    let max_x_ndc = max_px / sw * 2.0 - 1.0;
-    let min_y_ndc = 1.0 - max_py / sh * 2.0; // bottom of tile in NDC
-    let max_y_ndc = 1.0 - min_py / sh * 2.0; // top of tile in NDC
+    // Convert to NDC [-1, 1] relative to the viewport. Screen Y is top-down, NDC Y is bottom-up.
+    let min_x_ndc = (min_px - vp.x) / vp.z * 2.0 - 1.0;
+    let max_x_ndc = (max_px - vp.x) / vp.z * 2.0 - 1.0;
+    let min_y_ndc = 1.0 - (max_py - vp.y) / vp.w * 2.0; // bottom of tile in NDC
+    let max_y_ndc = 1.0 - (min_py - vp.y) / vp.w * 2.0; // top of tile in NDC
 
     // Unproject tile corners to view space at the near plane.
     let tl = unproject_ndc(vec2<f32>(min_x_ndc, max_y_ndc));
@@ -147,6 +149,18 @@ fn main(
     let tile_y = workgroup_id.y;
     let tile_idx = tile_y * cull_uniforms.tile_count_x + tile_x;
 
+    // Tiles outside the viewport are never shaded by this scene.
+    let vp = cull_uniforms.viewport;
+    let tile_min = vec2<f32>(f32(tile_x * TILE_SIZE), f32(tile_y * TILE_SIZE));
+    let tile_max = tile_min + vec2<f32>(f32(TILE_SIZE));
+    if tile_max.x <= vp.x || tile_min.x >= vp.x + vp.z ||
+       tile_max.y <= vp.y || tile_min.y >= vp.y + vp.w {
+        if local_idx == 0u {
+            tile_counts[tile_idx] = 0u;
+        }
+        return;
+    }
+
     // Reset shared counter.
     if local_idx == 0u {
         atomicStore(&shared_count, 0u);
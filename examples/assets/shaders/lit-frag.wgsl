@@ -7,13 +7,22 @@
 // position from the camera uniform for specular highlights. Constructs a TBN
 // matrix from interpolated world-space tangent and normal vectors to transform
 // normal map samples from tangent space to world space. Directional lights that
-// cast shadows are attenuated by a 3×3 PCF shadow map lookup.
+// cast shadows are attenuated by a 3×3 PCF lookup into cascaded shadow maps;
+// point and spot lights that were granted tiles sample the shared shadow atlas.
+// The MaterialParams uniform supplies the base color factor and alpha mode:
+// masked materials discard fragments below the alpha cutoff.
+//
+// Entry points:
+//   fs_main — forward output, used for opaque, masked and sorted blended draws
+//   fs_oit  — weighted blended order-independent transparency output
+//             (accumulation + revealage targets)
 //
 // Bind group layout:
 //   @group(0) camera     — CameraUniform (view_proj + camera_position)
-//   @group(2) material   — diffuse texture + sampler, normal map, metallic-roughness map
+//   @group(2) material   — diffuse texture + sampler, normal map, metallic-roughness map, MaterialParams uniform
 //   @group(3) lights     — LightHeader + Light array (storage buffer)
-//   @group(4) shadow     — shadow depth texture, comparison sampler, ShadowData uniform
+//   @group(4) shadow     — shadow depth texture array (one layer per cascade), comparison sampler, ShadowData uniform,
+//                          point/spot shadow atlas, LocalShadow array (storage buffer)
 //   @group(5) tiles      — TileUniforms + per-tile light counts + per-tile light indices
 
 // ── Fragment input (from vertex shader) ────────────────────────────
@@ -31,7 +40,9 @@ struct FragmentInput {
 //@oxy:include light
 //@oxy:include light_header
 //@oxy:include shadow_data
+//@oxy:include local_shadow
 //@oxy:include tile_uniforms
+//@oxy:include material_params
 
 // ── Bind groups ────────────────────────────────────────────────────
 //@oxy:group 0 0 storage_uniform camera camera
@@ -47,14 +58,17 @@ struct FragmentInput {
 @group(2) @binding(4) var metallic_roughness_texture: texture_2d<f32>;
 //@oxy:provider 2 5 material metallic_roughness_sampler
 @group(2) @binding(5) var metallic_roughness_sampler: sampler;
+//@oxy:group 2 6 storage_uniform material material_params
 
 //@oxy:group 3 0 storage_uniform light_header light_header
 //@oxy:group 3 1 storage_read lights array<light>
 
 //@oxy:provider 4 0 shadow
-@group(4) @binding(0) var shadow_texture: texture_depth_2d;
+@group(4) @binding(0) var shadow_texture: texture_depth_2d_array;
 @group(4) @binding(1) var shadow_sampler: sampler_comparison;
 //@oxy:group 4 2 storage_uniform shadow_data shadow_data
+@group(4) @binding(3) var shadow_atlas: texture_depth_2d;
+//@oxy:group 4 4 storage_read local_shadows array<local_shadow>
 
 //@oxy:group 5 0 storage_uniform tile_uniforms tile_uniforms
 //@oxy:provider 5 1 tiles
@@ -66,6 +80,9 @@ const LIGHT_TYPE_DIRECTIONAL: u32 = 0u;
 const LIGHT_TYPE_POINT:       u32 = 1u;
 const LIGHT_TYPE_SPOT:        u32 = 2u;
 
+const ALPHA_MODE_MASK:  u32 = 1u; // matches material.AlphaModeMask
+const ALPHA_MODE_BLEND: u32 = 2u; // matches material.AlphaModeBlend
+
 const SPECULAR_STRENGTH: f32 = 0.5;  // base specular contribution scale (dielectric)
 
 // ── Attenuation ────────────────────────────────────────────────────
@@ -89,26 +106,27 @@ fn spot_falloff(cos_angle: f32, inner_cone: f32, outer_cone: f32) -> f32 {
 }
 
 // ── Shadow sampling ────────────────────────────────────────────────
-// 3×3 PCF (Percentage-Closer Filtering) shadow map lookup with normal-
-// offset bias. The world position is shifted along the surface normal
-// before projecting into light clip space. The offset is largest when
-// the surface is nearly parallel to the light direction (grazing angles),
+// 3×3 PCF (Percentage-Closer Filtering) lookup into one shadow cascade with
+// normal-offset bias. The world position is shifted along the surface normal
+// before projecting into the cascade's light clip space. The offset is largest
+// when the surface is nearly parallel to the light direction (grazing angles),
 // which is exactly where concave-geometry self-shadowing artifacts are
 // worst. A small constant depth bias is applied on top for residual acne.
-fn sample_shadow(world_pos: vec3<f32>, normal: vec3<f32>, light_dir: vec3<f32>) -> f32 {
+fn sample_shadow_cascade(cascade: u32, world_pos: vec3<f32>, normal: vec3<f32>, light_dir: vec3<f32>) -> f32 {
     // Offset the world position along the surface normal to reduce shadow acne
-    // on surfaces nearly parallel to the light direction.
+    // on surfaces nearly parallel to the light direction. Each cascade covers a
+    // different area, so its texels (and the offset needed) differ in size.
     let n_dot_l = dot(normal, -light_dir);
-    let offset_scale = shadow_data.normal_bias * (1.0 - n_dot_l);
+    let offset_scale = shadow_data.cascade_normal_bias[cascade] * (1.0 - n_dot_l);
     let offset_pos = world_pos + normal * offset_scale;
 
-    let clip = shadow_data.light_vp * vec4<f32>(offset_pos, 1.0);
+    let clip = shadow_data.cascade_vp[cascade] * vec4<f32>(offset_pos, 1.0);
     let ndc = clip.xyz / clip.w;
 
     let shadow_uv = vec2<f32>(ndc.x * 0.5 + 0.5, -ndc.y * 0.5 + 0.5);
     let depth = ndc.z;
 
-    // Fragments outside the shadow map receive no shadow (fully lit).
+    // Fragments outside the cascade receive no shadow (fully lit).
     if shadow_uv.x < 0.0 || shadow_uv.x > 1.0 ||
        shadow_uv.y < 0.0 || shadow_uv.y > 1.0 ||
        depth < 0.0 || depth > 1.0 {
@@ -121,10 +139,11 @@ fn sample_shadow(world_pos: vec3<f32>, normal: vec3<f32>, light_dir: vec3<f32>)
     for (var y = -1; y <= 1; y++) {
         for (var x = -1; x <= 1; x++) {
             let offset = vec2<f32>(f32(x), f32(y)) * shadow_data.texel_size;
-            total += textureSampleCompare(
+            total += textureSampleCompareLevel(
                 shadow_texture,
                 shadow_sampler,
                 shadow_uv + offset,
+                i32(cascade),
                 depth - bias,
             );
         }
@@ -132,6 +151,103 @@ fn sample_shadow(world_pos: vec3<f32>, normal: vec3<f32>, light_dir: vec3<f32>)
     return total / 9.0;
 }
 
+// Cascaded shadow lookup. The cascade is chosen by the fragment's view depth
+// along the camera forward axis. Within blend_width of a cascade's far split
+// the result cross-fades into the next cascade to hide the resolution seam.
+// Fragments beyond the last split receive no shadow (fully lit).
+fn sample_shadow(world_pos: vec3<f32>, normal: vec3<f32>, light_dir: vec3<f32>) -> f32 {
+    let view_depth = dot(world_pos - camera.camera_position, shadow_data.camera_forward);
+    let count = shadow_data.cascade_count;
+
+    var cascade = 0u;
+    loop {
+        if cascade >= count || view_depth <= shadow_data.cascade_splits[cascade] {
+            break;
+        }
+        cascade++;
+    }
+    if cascade >= count {
+        return 1.0;
+    }
+
+    let shadow = sample_shadow_cascade(cascade, world_pos, normal, light_dir);
+
+    // Distance into the blend band at the far end of this cascade, 0 → 1.
+    var split_near = 0.0;
+    if cascade > 0u {
+        split_near = shadow_data.cascade_splits[cascade - 1u];
+    }
+    let split_far = shadow_data.cascade_splits[cascade];
+    let band = (split_far - split_near) * shadow_data.blend_width;
+    if band <= 0.0 || view_depth < split_far - band {
+        return shadow;
+    }
+    let t = (view_depth - (split_far - band)) / band;
+    if cascade + 1u >= count {
+        // Fade the last cascade out towards the shadow distance.
+        return mix(shadow, 1.0, t);
+    }
+    return mix(shadow, sample_shadow_cascade(cascade + 1u, world_pos, normal, light_dir), t);
+}
+
+// Point/spot light shadow lookup into the shadow atlas. A spot light owns one
+// perspective tile; a point light owns six cube-face tiles ordered +X, -X, +Y,
+// -Y, +Z, -Z, chosen by the major axis of the light-to-fragment vector.
+// Perspective texels grow with distance from the light, so the normal offset
+// does too. PCF taps are clamped to the tile so neighbouring tiles never bleed in.
+fn sample_local_shadow(light: Light, world_pos: vec3<f32>, normal: vec3<f32>) -> f32 {
+    let to_frag = world_pos - light.position;
+    var index = u32(light.shadow_index);
+    if light.light_type == LIGHT_TYPE_POINT {
+        let a = abs(to_frag);
+        if a.x >= a.y && a.x >= a.z {
+            index += select(1u, 0u, to_frag.x > 0.0);
+        } else if a.y >= a.z {
+            index += select(3u, 2u, to_frag.y > 0.0);
+        } else {
+            index += select(5u, 4u, to_frag.z > 0.0);
+        }
+    }
+    let shadow = local_shadows[index];
+
+    let n_dot_l = dot(normal, normalize(-to_frag));
+    let offset_scale = shadow.normal_bias * length(to_frag) * (1.0 - n_dot_l);
+    let clip = shadow.view_proj * vec4<f32>(world_pos + normal * offset_scale, 1.0);
+    if clip.w <= 0.0 {
+        return 1.0;
+    }
+    let ndc = clip.xyz / clip.w;
+
+    let tile_uv = vec2<f32>(ndc.x * 0.5 + 0.5, -ndc.y * 0.5 + 0.5);
+    let depth = ndc.z;
+
+    // Fragments outside the light's projection receive no shadow (fully lit).
+    if tile_uv.x < 0.0 || tile_uv.x > 1.0 ||
+       tile_uv.y < 0.0 || tile_uv.y > 1.0 ||
+       depth < 0.0 || depth > 1.0 {
+        return 1.0;
+    }
+
+    let shadow_uv = shadow.atlas_rect.xy + tile_uv * shadow.atlas_rect.zw;
+    let uv_min = shadow.atlas_rect.xy + vec2<f32>(0.5 * shadow.texel_size);
+    let uv_max = shadow.atlas_rect.xy + shadow.atlas_rect.zw - vec2<f32>(0.5 * shadow.texel_size);
+
+    // 3×3 PCF (percentage-closer filtering) for soft shadow edges.
+    var total = 0.0;
+    for (var y = -1; y <= 1; y++) {
+        for (var x = -1; x <= 1; x++) {
+            let offset = vec2<f32>(f32(x), f32(y)) * shadow.texel_size;
+            total += textureSampleCompareLevel(
+                shadow_atlas,
+                shadow_sampler,
+                clamp(shadow_uv + offset, uv_min, uv_max),
+                depth - shadow.bias,
+            );
+        }
+    }
+    return total / 9.0;
+}
+
 // ── Per-light contribution ─────────────────────────────────────────
 // Computes diffuse + specular for a single light using Blinn-Phong.
 // Roughness modulates the specular exponent: shininess = mix(4, 128, (1-roughness)^2).
@@ -190,19 +306,26 @@ fn evaluate_light(
     return (diffuse + specular) * atten;
 }
 
-// ── Entry point ────────────────────────────────────────────────────
-@fragment
-fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+// ── Surface shading ────────────────────────────────────────────────
+// Shared by both entry points. Returns the lit color and the surface alpha.
+fn shade(in: FragmentInput) -> vec4<f32> {
     // Sample diffuse texture
     let tex_color = textureSample(diffuse_texture, diffuse_sampler, in.uv);
 
-    // Discard fully transparent fragments
-    if tex_color.a < 0.01 {
+    // Surface alpha: texture × vertex color × material base color
+    var alpha = tex_color.a * in.color.a * material.base_color.a;
+
+    // Discard fully transparent fragments, and masked fragments below the cutoff
+    if alpha < 0.01 || (material.alpha_mode == ALPHA_MODE_MASK && alpha < material.alpha_cutoff) {
         discard;
     }
+    // Opaque and masked surfaces always write full coverage
+    if material.alpha_mode != ALPHA_MODE_BLEND {
+        alpha = 1.0;
+    }
 
-    // Surface albedo: texture × vertex color
-    let albedo = tex_color.rgb * in.color.rgb;
+    // Surface albedo: texture × vertex color × material base color
+    let albedo = tex_color.rgb * in.color.rgb * material.base_color.rgb;
 
     // Sample normal map and transform from tangent space to world space via TBN matrix.
     // The tangent and bitangent are derived from the vertex shader's world_tangent output,
@@ -250,7 +373,8 @@ fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
 
         var contribution = evaluate_light(light, in.world_position, normal, view_dir, roughness, metallic);
 
-        // Apply shadow map attenuation for shadow-casting directional lights.
+        // Apply shadow map attenuation for shadow-casting directional lights, and the
+        // shadow atlas for point and spot lights that were granted tiles this frame.
         // Skip shadow sampling when the surface barely faces the light (N·L < threshold).
         // At grazing angles the diffuse contribution is negligible and the shadow map
         // projection can produce false silhouettes from geometry on the other side.
@@ -259,11 +383,40 @@ fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
             if face_dot > 0.1 {
                 contribution *= sample_shadow(in.world_position, normal, light.direction);
             }
+        } else if light.shadow_index >= 0 {
+            contribution *= sample_local_shadow(light, in.world_position, normal);
         }
 
         total_light += contribution;
     }
 
     let final_color = albedo * total_light;
-    return vec4<f32>(final_color, tex_color.a * in.color.a);
+    return vec4<f32>(final_color, alpha);
+}
+
+// ── Entry points ───────────────────────────────────────────────────
+@fragment
+fn fs_main(in: FragmentInput) -> @location(0) vec4<f32> {
+    return shade(in);
+}
+
+// Weighted blended OIT output (McGuire & Bavoil 2013). The accumulation target
+// sums premultiplied color and alpha scaled by a depth weight; the revealage
+// target multiplies (1 - alpha) through the blend state.
+struct OITOutput {
+    @location(0) accum:  vec4<f32>,
+    @location(1) reveal: f32,
+};
+
+@fragment
+fn fs_oit(in: FragmentInput) -> OITOutput {
+    let color = shade(in);
+    let a = color.a;
+    let z = in.position.z;
+    let weight = clamp(pow(min(1.0, a * 10.0) + 0.01, 3.0) * 1e8 * pow(1.0 - z * 0.9, 3.0), 1e-2, 3e3);
+
+    var out: OITOutput;
+    out.accum = vec4<f32>(color.rgb * a, a) * weight;
+    out.reveal = a;
+    return out;
 }
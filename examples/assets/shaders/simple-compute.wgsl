@@ -34,7 +34,7 @@
 //     instance_count: u32,
 //     delta_time: f32,
 //     bounding_radius: f32,
-//     _padding: f32,
+//     cull_enabled: u32,
 //     planes: array<FrustumPlane, 6>,
 // }
 
@@ -124,6 +124,13 @@ fn main(@builtin(global_invocation_id) global_id: vec3<u32>) {
     // Write back updated rotation
     instance_data[idx].rot = anim.rot;
 
+    // Culling disabled — keep instance order stable so the CPU can
+    // address individual instances (sorted transparency)
+    if (globals.cull_enabled == 0u) {
+        build_transform(anim.pos, anim.rot, anim.scale, idx * 16u);
+        return;
+    }
+
     // Frustum cull — only visible instances are compacted into the output
     if (is_visible(anim.pos, globals.bounding_radius)) {
         let out_slot = atomicAdd(&indirect_args.instance_count, 1u);
@@ -47,7 +47,7 @@ const MAX_BONES: u32 = 64u;
 //     bounding_radius: f32,
 //     channel_data_offset: u32,
 //     keyframe_data_offset: u32,
-//     _pad1: u32,
+//     cull_enabled: u32,
 //     _pad2: u32,
 //     _pad3: u32,
 //     planes: array<FrustumPlane, 6>,
@@ -456,13 +456,18 @@ fn main(@builtin(global_invocation_id) global_id: vec3<u32>) {
     let model_matrix = model_data[instance_idx].model;
     let world_pos = model_matrix[3].xyz;
 
-    // Frustum culling test using the instance's world position
-    if (!is_visible(world_pos, globals.bounding_radius)) {
-        return;
-    }
+    // With culling disabled every instance keeps its own slot so the CPU
+    // can address individual instances (sorted transparency)
+    var out_slot = instance_idx;
+    if globals.cull_enabled != 0u {
+        // Frustum culling test using the instance's world position
+        if (!is_visible(world_pos, globals.bounding_radius)) {
+            return;
+        }
 
-    // Visible — atomically claim an output slot
-    let out_slot = atomicAdd(&indirect_args.instance_count, 1u);
+        // Visible — atomically claim an output slot
+        out_slot = atomicAdd(&indirect_args.instance_count, 1u);
+    }
 
     // Per-instance output stride in floats: (1 model matrix + MAX_BONES bone matrices) × 16 floats
     let stride = (1u + MAX_BONES) * 16u;
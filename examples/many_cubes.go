@@ -59,12 +59,15 @@ func main() {
 	// ── Renderer (uncapped FPS) ─────────────────────────────────────
 	// Toggle WithForceSoftwareRenderer(true) to benchmark CPU-only rendering
 	// via a software Vulkan ICD (requires SwiftShader or lavapipe installed).
-	r := renderer.NewRenderer(
+	r, err := renderer.NewRenderer(
 		renderer.BackendTypeWGPU,
 		eng.Window(),
 		renderer.WithPresentMode(renderer.PresentModeUncapped),
 		// renderer.WithForceSoftwareRenderer(true),
 	)
+	if err != nil {
+		log.Fatalf("Failed to create renderer: %v", err)
+	}
 
 	// ── Camera ──────────────────────────────────────────────────────
 	cam := camera.NewCamera(
@@ -123,7 +126,7 @@ func main() {
 	log.Printf("[Bench] Starting with %d cubes", currentCount)
 
 	// ── Per-frame render callback for FPS sampling ──────────────────
-	eng.SetRenderCallback(func(_ float32) {
+	eng.SetRenderCallback(func(_, _ float32) {
 		frameCount++
 
 		if stopped {
@@ -59,11 +59,14 @@ func main() {
 	)
 
 	// ── Renderer (uncapped FPS) ─────────────────────────────────────
-	r := renderer.NewRenderer(
+	r, err := renderer.NewRenderer(
 		renderer.BackendTypeWGPU,
 		eng.Window(),
 		renderer.WithPresentMode(renderer.PresentModeUncapped),
 	)
+	if err != nil {
+		log.Fatalf("Failed to create renderer: %v", err)
+	}
 
 	// ── Camera ──────────────────────────────────────────────────────
 	cam := camera.NewCamera(
@@ -112,7 +115,7 @@ func main() {
 	// ── Scene ───────────────────────────────────────────────────────
 	sc := scene.NewScene("Many Cubes Lit Bench", cam, r, litVert,
 		scene.WithActive(true),
-		scene.WithShadowHalfExtent(500),
+		scene.WithShadowDistance(1500),
 		scene.WithShadowNearFar(0.1, 5000),
 		scene.WithShadowBias(0.001),
 	)
@@ -188,7 +191,6 @@ func main() {
 			model.WithMeshProvider(bgp.NewBindGroupProvider("ground_mesh")),
 			model.WithRenderMaterials(material.NewMaterial(
 				material.WithName("ground_material"),
-				material.WithBaseColor([4]float32{0.35, 0.35, 0.35, 1.0}),
 				material.WithPipelineKey("ground_plane"),
 			)),
 		)),
@@ -233,7 +235,7 @@ func main() {
 	log.Printf("[Bench] Starting with %d lit cubes", currentCount)
 
 	// ── Per-frame render callback for FPS sampling ──────────────────
-	eng.SetRenderCallback(func(_ float32) {
+	eng.SetRenderCallback(func(_, _ float32) {
 		frameCount++
 
 		if stopped {
@@ -59,12 +59,16 @@ func main() {
 		)),
 	)
 
-	// ── Renderer (uncapped FPS) ─────────────────────────────────────
-	r := renderer.NewRenderer(
+	// ── Renderer (uncapped FPS, GPU phase timings in the profiler output) ──
+	r, err := renderer.NewRenderer(
 		renderer.BackendTypeWGPU,
 		eng.Window(),
 		renderer.WithPresentMode(renderer.PresentModeUncapped),
+		renderer.WithGPUTimestamps(true),
 	)
+	if err != nil {
+		log.Fatalf("Failed to create renderer: %v", err)
+	}
 
 	// ── Camera ──────────────────────────────────────────────────────
 	cam := camera.NewCamera(
@@ -107,7 +111,7 @@ func main() {
 	sc := scene.NewScene("Many Foxes Lit Bench", cam, r, litVert,
 		scene.WithActive(true),
 		scene.WithShadowMapResolution(4096),
-		scene.WithShadowHalfExtent(4500),
+		scene.WithShadowDistance(9000),
 		scene.WithShadowNearFar(0.1, 15000),
 		scene.WithShadowBias(0.001),
 		scene.WithShadowNormalBiasScale(1.0),
@@ -189,7 +193,6 @@ func main() {
 			model.WithMeshProvider(bgp.NewBindGroupProvider("ground_mesh")),
 			model.WithRenderMaterials(material.NewMaterial(
 				material.WithName("ground_material"),
-				material.WithBaseColor([4]float32{0.35, 0.35, 0.35, 1.0}),
 				material.WithPipelineKey("ground_plane"),
 			)),
 		)),
@@ -218,7 +221,6 @@ func main() {
 			model.WithMeshProvider(bgp.NewBindGroupProvider("sun_sphere_mesh")),
 			model.WithRenderMaterials(material.NewMaterial(
 				material.WithName("sun_sphere_material"),
-				material.WithBaseColor([4]float32{1.0, 0.6, 0.1, 1.0}),
 				material.WithPipelineKey("sun_indicator"),
 			)),
 		)),
@@ -283,7 +285,7 @@ func main() {
 		currentCount, animCount, len(foxModel.Skeleton().Bones))
 
 	// ── Per-frame render callback for FPS sampling ──────────────────
-	eng.SetRenderCallback(func(_ float32) {
+	eng.SetRenderCallback(func(_, _ float32) {
 		frameCount++
 
 		if stopped {
@@ -463,8 +465,8 @@ func setupLitFoxBenchInput(eng engine.Engine, cam camera.Camera, sun light.Light
 		dirZ := float32(-math.Sin(*sunAngle))
 		sun.SetDirection(0, dirY, dirZ)
 
-		// Update sun indicator sphere position to show the shadow-map eye.
-		// Eye = center - lightDir * far * 0.5 (matches ComputeDirectionalLightVP).
+		// Update sun indicator sphere position to sit back along the light direction.
+		// Position = center - lightDir * far * 0.5, where a cascade centred on the target places its eye.
 		{
 			dir := sun.Direction()
 			const shadowFarHalf = 7500.0 // far=15000 * 0.5
@@ -35,11 +35,14 @@ func main() {
 	)
 
 	// ── Renderer ────────────────────────────────────────────────────────
-	r := renderer.NewRenderer(
+	r, err := renderer.NewRenderer(
 		renderer.BackendTypeWGPU,
 		eng.Window(),
 		renderer.WithPresentMode(renderer.PresentModeUncapped),
 	)
+	if err != nil {
+		log.Fatalf("Failed to create renderer: %v", err)
+	}
 
 	// ── Camera ──────────────────────────────────────────────────────────
 	cam := camera.NewCamera(
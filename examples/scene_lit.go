@@ -17,11 +17,9 @@ import (
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/bind_group_provider"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/material"
-	"github.com/Carmen-Shannon/oxy-go/engine/renderer/pipeline"
 	"github.com/Carmen-Shannon/oxy-go/engine/renderer/shader"
 	"github.com/Carmen-Shannon/oxy-go/engine/scene"
 	"github.com/Carmen-Shannon/oxy-go/engine/window"
-	"github.com/cogentcore/webgpu/wgpu"
 )
 
 func main() {
@@ -37,11 +35,14 @@ func main() {
 	)
 
 	// ── Renderer ────────────────────────────────────────────────────────
-	r := renderer.NewRenderer(
+	r, err := renderer.NewRenderer(
 		renderer.BackendTypeWGPU,
 		eng.Window(),
 		renderer.WithPresentMode(renderer.PresentModeUncapped),
 	)
+	if err != nil {
+		log.Fatalf("Failed to create renderer: %v", err)
+	}
 
 	// ── Camera ──────────────────────────────────────────────────────────
 	cam := camera.NewCamera(
@@ -76,7 +77,7 @@ func main() {
 	// ── Scene ───────────────────────────────────────────────────────────
 	sc := scene.NewScene("Lit Scene", cam, r, litVert,
 		scene.WithActive(true),
-		scene.WithShadowHalfExtent(120),
+		scene.WithShadowDistance(250),
 		scene.WithShadowNearFar(0.1, 400),
 		scene.WithShadowBias(0.001),
 	)
@@ -155,7 +156,8 @@ func main() {
 	// ── Transparent Quad ──────────────────────────────────────────────────
 	// A horizontal canopy above the fox that casts a shadow from the sun.
 	// Positioned so the shadow falls directly onto the fox. Initially fully
-	// opaque (alpha=1.0). Press V to cycle transparency.
+	// opaque (alpha=1.0). Press V to cycle transparency. The material is blended,
+	// so the scene draws it after the opaque geometry in its transparent pass.
 	quadAlpha := float32(1.0)
 	quadVerts, quadIdx := buildLitQuad(quadAlpha)
 	quadObj := game_object.NewGameObject(
@@ -170,7 +172,7 @@ func main() {
 			)),
 			model.WithRenderMaterials(material.NewMaterial(
 				material.WithName("quad_material"),
-				material.WithBaseColor([4]float32{0.3, 0.5, 0.9, quadAlpha}),
+				material.WithAlphaMode(material.AlphaModeBlend),
 				material.WithPipelineKey("transparent_quad"),
 			)),
 		)),
@@ -189,21 +191,7 @@ func main() {
 		}
 	}
 
-	_ = sc.Add(quadObj, staticCompute, staticLitVert, litFrag,
-		pipeline.WithBlendEnabled(true),
-		pipeline.WithBlendState(&wgpu.BlendState{
-			Color: wgpu.BlendComponent{
-				Operation: wgpu.BlendOperationAdd,
-				SrcFactor: wgpu.BlendFactorSrcAlpha,
-				DstFactor: wgpu.BlendFactorOneMinusSrcAlpha,
-			},
-			Alpha: wgpu.BlendComponent{
-				Operation: wgpu.BlendOperationAdd,
-				SrcFactor: wgpu.BlendFactorOne,
-				DstFactor: wgpu.BlendFactorZero,
-			},
-		}),
-	)
+	_ = sc.Add(quadObj, staticCompute, staticLitVert, litFrag)
 
 	// ── Sun Indicator ───────────────────────────────────────────────────
 	// A small orange sphere that shows the shadow-map eye position for the
@@ -221,7 +209,6 @@ func main() {
 			)),
 			model.WithRenderMaterials(material.NewMaterial(
 				material.WithName("sun_sphere_material"),
-				material.WithBaseColor([4]float32{1.0, 0.6, 0.1, 1.0}),
 				material.WithPipelineKey("sun_indicator"),
 			)),
 		)),
@@ -403,8 +390,8 @@ func setupLitInput(
 			sun.SetDirection(0, dirY, dirZ)
 		}
 
-		// Update sun indicator sphere position to show the shadow-map eye.
-		// Eye = center - lightDir * far * 0.5 (matches ComputeDirectionalLightVP).
+		// Update sun indicator sphere position to sit back along the light direction.
+		// Position = center - lightDir * far * 0.5, where a cascade centred on the target places its eye.
 		{
 			dir := sun.Direction()
 			const shadowFarHalf = 200.0 // far=400 * 0.5
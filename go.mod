@@ -6,4 +6,5 @@ require (
 	github.com/Carmen-Shannon/automation v1.1.1
 	github.com/cogentcore/webgpu v0.23.0
 	github.com/go-gl/glfw/v3.3/glfw v0.0.0-20250301202403-da16c1255728
+	github.com/klauspost/compress v1.18.0
 )